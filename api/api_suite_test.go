@@ -14,6 +14,8 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api"
+	"github.com/concourse/atc/api/apitokenserver/apitokenserverfakes"
+	"github.com/concourse/atc/api/auditserver/auditserverfakes"
 	"github.com/concourse/atc/api/buildserver"
 	"github.com/concourse/atc/api/buildserver/buildserverfakes"
 	"github.com/concourse/atc/api/containerserver/containerserverfakes"
@@ -53,10 +55,14 @@ var (
 	pipelineDBFactory             *dbfakes.FakePipelineDBFactory
 	pipelinesDB                   *dbfakes.FakePipelinesDB
 	teamDB                        *teamserverfakes.FakeTeamDB
+	apiTokenDB                    *apitokenserverfakes.FakeAPITokenDB
+	auditDB                       *auditserverfakes.FakeAuditDB
 	fakeSchedulerFactory          *jobserverfakes.FakeSchedulerFactory
 	fakeScannerFactory            *resourceserverfakes.FakeScannerFactory
 	configValidationErrorMessages []string
 	configValidationWarnings      []config.Warning
+	configValidationPipelines     config.PipelineConfigs
+	configValidationConfig        atc.Config
 	peerAddr                      string
 	drain                         chan struct{}
 	cliDownloadsDir               string
@@ -102,14 +108,19 @@ var _ = BeforeEach(func() {
 	pipeDB = new(pipesfakes.FakePipeDB)
 	pipelinesDB = new(dbfakes.FakePipelinesDB)
 	teamDB = new(teamserverfakes.FakeTeamDB)
+	apiTokenDB = new(apitokenserverfakes.FakeAPITokenDB)
+	auditDB = new(auditserverfakes.FakeAuditDB)
 
 	authValidator = new(authfakes.FakeValidator)
 	userContextReader = new(authfakes.FakeUserContextReader)
+	userContextReader.GetRoleReturns(atc.RoleOwner, true)
 	fakeTokenGenerator = new(authfakes.FakeTokenGenerator)
 	providerFactory = new(authfakes.FakeProviderFactory)
 
 	configValidationErrorMessages = []string{}
 	configValidationWarnings = []config.Warning{}
+	configValidationPipelines = nil
+	configValidationConfig = atc.Config{}
 	peerAddr = "127.0.0.1:1234"
 	drain = make(chan struct{})
 
@@ -153,8 +164,12 @@ var _ = BeforeEach(func() {
 		pipeDB,
 		pipelinesDB,
 		teamDB,
+		apiTokenDB,
+		auditDB,
 
-		func(atc.Config) ([]config.Warning, []string) {
+		func(validatedConfig atc.Config, pipelines config.PipelineConfigs) ([]config.Warning, []string) {
+			configValidationConfig = validatedConfig
+			configValidationPipelines = pipelines
 			return configValidationWarnings, configValidationErrorMessages
 		},
 		peerAddr,
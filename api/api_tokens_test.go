@@ -0,0 +1,377 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("API Tokens API", func() {
+	Describe("GET /api/v1/teams/:team_name/tokens", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/team-venture/tokens")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized for the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("team-venture", 2, false, true)
+			})
+
+			Context("when the team exists", func() {
+				BeforeEach(func() {
+					apiTokenDB.GetTeamByNameReturns(db.SavedTeam{ID: 2}, true, nil)
+					apiTokenDB.GetAPITokensReturns([]db.SavedAPIToken{
+						{
+							ID: 1,
+							APIToken: db.APIToken{
+								TeamID: 2,
+								Name:   "ci-bot",
+								Role:   atc.RoleMember,
+							},
+							TeamName:   "team-venture",
+							CreatedAt:  time.Unix(100, 0),
+							LastUsedAt: time.Unix(200, 0),
+						},
+						{
+							ID: 2,
+							APIToken: db.APIToken{
+								TeamID:    2,
+								Name:      "deploy-bot",
+								Role:      atc.RoleOwner,
+								ExpiresAt: time.Unix(300, 0),
+							},
+							TeamName:  "team-venture",
+							CreatedAt: time.Unix(150, 0),
+						},
+					}, nil)
+				})
+
+				It("returns the team's tokens without their values", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					Expect(apiTokenDB.GetAPITokensCallCount()).To(Equal(1))
+					Expect(apiTokenDB.GetAPITokensArgsForCall(0)).To(Equal("team-venture"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"name": "ci-bot",
+							"team_name": "team-venture",
+							"role": "member",
+							"created_at": 100,
+							"last_used_at": 200
+						},
+						{
+							"name": "deploy-bot",
+							"team_name": "team-venture",
+							"role": "owner",
+							"created_at": 150,
+							"expires_at": 300
+						}
+					]`))
+				})
+
+				Context("when getting the tokens fails", func() {
+					BeforeEach(func() {
+						apiTokenDB.GetAPITokensReturns(nil, errors.New("disaster"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					apiTokenDB.GetTeamByNameReturns(db.SavedTeam{}, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when authorized for another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("other-team", 3, false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when the requester is not an owner of the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("team-venture", 2, false, true)
+				userContextReader.GetRoleReturns(atc.RoleMember, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/tokens", func() {
+		var requestBody string
+		var response *http.Response
+
+		BeforeEach(func() {
+			requestBody = `{"name":"ci-bot","role":"member"}`
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Post(
+				server.URL+"/api/v1/teams/team-venture/tokens",
+				"application/json",
+				bytes.NewBufferString(requestBody),
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized for the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("team-venture", 2, false, true)
+			})
+
+			Context("when the team exists", func() {
+				BeforeEach(func() {
+					apiTokenDB.GetTeamByNameReturns(db.SavedTeam{ID: 2}, true, nil)
+					apiTokenDB.CreateAPITokenStub = func(token db.APIToken, tokenHash string) (db.SavedAPIToken, error) {
+						return db.SavedAPIToken{
+							ID:        1,
+							APIToken:  token,
+							TeamName:  "team-venture",
+							CreatedAt: time.Unix(100, 0),
+						}, nil
+					}
+				})
+
+				It("returns 201 Created", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				})
+
+				It("stores the token under the hash of its value", func() {
+					var token atc.APIToken
+					err := json.NewDecoder(response.Body).Decode(&token)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(token.Name).To(Equal("ci-bot"))
+					Expect(token.Role).To(Equal(atc.RoleMember))
+					Expect(token.Value).NotTo(BeEmpty())
+
+					Expect(apiTokenDB.CreateAPITokenCallCount()).To(Equal(1))
+					savedToken, tokenHash := apiTokenDB.CreateAPITokenArgsForCall(0)
+					Expect(savedToken).To(Equal(db.APIToken{
+						TeamID: 2,
+						Name:   "ci-bot",
+						Role:   atc.RoleMember,
+					}))
+					Expect(tokenHash).To(Equal(auth.HashAPIToken(token.Value)))
+				})
+
+				Context("when no role is given", func() {
+					BeforeEach(func() {
+						requestBody = `{"name":"ci-bot"}`
+					})
+
+					It("creates an owner token", func() {
+						savedToken, _ := apiTokenDB.CreateAPITokenArgsForCall(0)
+						Expect(savedToken.Role).To(Equal(atc.RoleOwner))
+					})
+				})
+
+				Context("when an expiry is given", func() {
+					var expiresAt time.Time
+
+					BeforeEach(func() {
+						expiresAt = time.Now().Add(time.Hour)
+						requestBody = fmt.Sprintf(`{"name":"ci-bot","expires_at":%d}`, expiresAt.Unix())
+					})
+
+					It("creates a token that expires", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+						savedToken, _ := apiTokenDB.CreateAPITokenArgsForCall(0)
+						Expect(savedToken.ExpiresAt.Unix()).To(Equal(expiresAt.Unix()))
+					})
+				})
+
+				Context("when the expiry is in the past", func() {
+					BeforeEach(func() {
+						requestBody = fmt.Sprintf(`{"name":"ci-bot","expires_at":%d}`, time.Now().Add(-time.Hour).Unix())
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(apiTokenDB.CreateAPITokenCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the name is missing", func() {
+					BeforeEach(func() {
+						requestBody = `{"role":"member"}`
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when the role is invalid", func() {
+					BeforeEach(func() {
+						requestBody = `{"name":"ci-bot","role":"henchman"}`
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when a token with the same name exists", func() {
+					BeforeEach(func() {
+						apiTokenDB.CreateAPITokenStub = nil
+						apiTokenDB.CreateAPITokenReturns(db.SavedAPIToken{}, db.ErrAPITokenExists)
+					})
+
+					It("returns 409 Conflict", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+				})
+
+				Context("when creating the token fails", func() {
+					BeforeEach(func() {
+						apiTokenDB.CreateAPITokenStub = nil
+						apiTokenDB.CreateAPITokenReturns(db.SavedAPIToken{}, errors.New("disaster"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					apiTokenDB.GetTeamByNameReturns(db.SavedTeam{}, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when authorized for another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("other-team", 3, false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(apiTokenDB.CreateAPITokenCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/tokens/:token_name", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/team-venture/tokens/ci-bot", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized for the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("team-venture", 2, false, true)
+			})
+
+			Context("when the token exists", func() {
+				BeforeEach(func() {
+					apiTokenDB.DeleteAPITokenReturns(true, nil)
+				})
+
+				It("revokes the token", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+					Expect(apiTokenDB.DeleteAPITokenCallCount()).To(Equal(1))
+					teamName, tokenName := apiTokenDB.DeleteAPITokenArgsForCall(0)
+					Expect(teamName).To(Equal("team-venture"))
+					Expect(tokenName).To(Equal("ci-bot"))
+				})
+			})
+
+			Context("when the token does not exist", func() {
+				BeforeEach(func() {
+					apiTokenDB.DeleteAPITokenReturns(false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when revoking the token fails", func() {
+				BeforeEach(func() {
+					apiTokenDB.DeleteAPITokenReturns(false, errors.New("disaster"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authorized for another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("other-team", 3, false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(apiTokenDB.DeleteAPITokenCallCount()).To(BeZero())
+			})
+		})
+	})
+})
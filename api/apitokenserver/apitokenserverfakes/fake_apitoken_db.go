@@ -0,0 +1,220 @@
+// This file was generated by counterfeiter
+package apitokenserverfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/api/apitokenserver"
+	"github.com/concourse/atc/db"
+)
+
+type FakeAPITokenDB struct {
+	GetTeamByNameStub        func(teamName string) (db.SavedTeam, bool, error)
+	getTeamByNameMutex       sync.RWMutex
+	getTeamByNameArgsForCall []struct {
+		teamName string
+	}
+	getTeamByNameReturns struct {
+		result1 db.SavedTeam
+		result2 bool
+		result3 error
+	}
+	CreateAPITokenStub        func(token db.APIToken, tokenHash string) (db.SavedAPIToken, error)
+	createAPITokenMutex       sync.RWMutex
+	createAPITokenArgsForCall []struct {
+		token     db.APIToken
+		tokenHash string
+	}
+	createAPITokenReturns struct {
+		result1 db.SavedAPIToken
+		result2 error
+	}
+	GetAPITokensStub        func(teamName string) ([]db.SavedAPIToken, error)
+	getAPITokensMutex       sync.RWMutex
+	getAPITokensArgsForCall []struct {
+		teamName string
+	}
+	getAPITokensReturns struct {
+		result1 []db.SavedAPIToken
+		result2 error
+	}
+	DeleteAPITokenStub        func(teamName string, tokenName string) (bool, error)
+	deleteAPITokenMutex       sync.RWMutex
+	deleteAPITokenArgsForCall []struct {
+		teamName  string
+		tokenName string
+	}
+	deleteAPITokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAPITokenDB) GetTeamByName(teamName string) (db.SavedTeam, bool, error) {
+	fake.getTeamByNameMutex.Lock()
+	fake.getTeamByNameArgsForCall = append(fake.getTeamByNameArgsForCall, struct {
+		teamName string
+	}{teamName})
+	fake.recordInvocation("GetTeamByName", []interface{}{teamName})
+	fake.getTeamByNameMutex.Unlock()
+	if fake.GetTeamByNameStub != nil {
+		return fake.GetTeamByNameStub(teamName)
+	} else {
+		return fake.getTeamByNameReturns.result1, fake.getTeamByNameReturns.result2, fake.getTeamByNameReturns.result3
+	}
+}
+
+func (fake *FakeAPITokenDB) GetTeamByNameCallCount() int {
+	fake.getTeamByNameMutex.RLock()
+	defer fake.getTeamByNameMutex.RUnlock()
+	return len(fake.getTeamByNameArgsForCall)
+}
+
+func (fake *FakeAPITokenDB) GetTeamByNameArgsForCall(i int) string {
+	fake.getTeamByNameMutex.RLock()
+	defer fake.getTeamByNameMutex.RUnlock()
+	return fake.getTeamByNameArgsForCall[i].teamName
+}
+
+func (fake *FakeAPITokenDB) GetTeamByNameReturns(result1 db.SavedTeam, result2 bool, result3 error) {
+	fake.GetTeamByNameStub = nil
+	fake.getTeamByNameReturns = struct {
+		result1 db.SavedTeam
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAPITokenDB) CreateAPIToken(token db.APIToken, tokenHash string) (db.SavedAPIToken, error) {
+	fake.createAPITokenMutex.Lock()
+	fake.createAPITokenArgsForCall = append(fake.createAPITokenArgsForCall, struct {
+		token     db.APIToken
+		tokenHash string
+	}{token, tokenHash})
+	fake.recordInvocation("CreateAPIToken", []interface{}{token, tokenHash})
+	fake.createAPITokenMutex.Unlock()
+	if fake.CreateAPITokenStub != nil {
+		return fake.CreateAPITokenStub(token, tokenHash)
+	} else {
+		return fake.createAPITokenReturns.result1, fake.createAPITokenReturns.result2
+	}
+}
+
+func (fake *FakeAPITokenDB) CreateAPITokenCallCount() int {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	return len(fake.createAPITokenArgsForCall)
+}
+
+func (fake *FakeAPITokenDB) CreateAPITokenArgsForCall(i int) (db.APIToken, string) {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	return fake.createAPITokenArgsForCall[i].token, fake.createAPITokenArgsForCall[i].tokenHash
+}
+
+func (fake *FakeAPITokenDB) CreateAPITokenReturns(result1 db.SavedAPIToken, result2 error) {
+	fake.CreateAPITokenStub = nil
+	fake.createAPITokenReturns = struct {
+		result1 db.SavedAPIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPITokenDB) GetAPITokens(teamName string) ([]db.SavedAPIToken, error) {
+	fake.getAPITokensMutex.Lock()
+	fake.getAPITokensArgsForCall = append(fake.getAPITokensArgsForCall, struct {
+		teamName string
+	}{teamName})
+	fake.recordInvocation("GetAPITokens", []interface{}{teamName})
+	fake.getAPITokensMutex.Unlock()
+	if fake.GetAPITokensStub != nil {
+		return fake.GetAPITokensStub(teamName)
+	} else {
+		return fake.getAPITokensReturns.result1, fake.getAPITokensReturns.result2
+	}
+}
+
+func (fake *FakeAPITokenDB) GetAPITokensCallCount() int {
+	fake.getAPITokensMutex.RLock()
+	defer fake.getAPITokensMutex.RUnlock()
+	return len(fake.getAPITokensArgsForCall)
+}
+
+func (fake *FakeAPITokenDB) GetAPITokensArgsForCall(i int) string {
+	fake.getAPITokensMutex.RLock()
+	defer fake.getAPITokensMutex.RUnlock()
+	return fake.getAPITokensArgsForCall[i].teamName
+}
+
+func (fake *FakeAPITokenDB) GetAPITokensReturns(result1 []db.SavedAPIToken, result2 error) {
+	fake.GetAPITokensStub = nil
+	fake.getAPITokensReturns = struct {
+		result1 []db.SavedAPIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPITokenDB) DeleteAPIToken(teamName string, tokenName string) (bool, error) {
+	fake.deleteAPITokenMutex.Lock()
+	fake.deleteAPITokenArgsForCall = append(fake.deleteAPITokenArgsForCall, struct {
+		teamName  string
+		tokenName string
+	}{teamName, tokenName})
+	fake.recordInvocation("DeleteAPIToken", []interface{}{teamName, tokenName})
+	fake.deleteAPITokenMutex.Unlock()
+	if fake.DeleteAPITokenStub != nil {
+		return fake.DeleteAPITokenStub(teamName, tokenName)
+	} else {
+		return fake.deleteAPITokenReturns.result1, fake.deleteAPITokenReturns.result2
+	}
+}
+
+func (fake *FakeAPITokenDB) DeleteAPITokenCallCount() int {
+	fake.deleteAPITokenMutex.RLock()
+	defer fake.deleteAPITokenMutex.RUnlock()
+	return len(fake.deleteAPITokenArgsForCall)
+}
+
+func (fake *FakeAPITokenDB) DeleteAPITokenArgsForCall(i int) (string, string) {
+	fake.deleteAPITokenMutex.RLock()
+	defer fake.deleteAPITokenMutex.RUnlock()
+	return fake.deleteAPITokenArgsForCall[i].teamName, fake.deleteAPITokenArgsForCall[i].tokenName
+}
+
+func (fake *FakeAPITokenDB) DeleteAPITokenReturns(result1 bool, result2 error) {
+	fake.DeleteAPITokenStub = nil
+	fake.deleteAPITokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPITokenDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getTeamByNameMutex.RLock()
+	defer fake.getTeamByNameMutex.RUnlock()
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	fake.getAPITokensMutex.RLock()
+	defer fake.getAPITokensMutex.RUnlock()
+	fake.deleteAPITokenMutex.RLock()
+	defer fake.deleteAPITokenMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeAPITokenDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ apitokenserver.APITokenDB = new(FakeAPITokenDB)
@@ -0,0 +1,98 @@
+package apitokenserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+)
+
+func (s *Server) CreateAPIToken(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("create-api-token")
+
+	teamName := r.FormValue(":team_name")
+
+	var request atc.APIToken
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		hLog.Error("malformed-request", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if request.Name == "" {
+		hLog.Info("missing-token-name")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	role := request.Role
+	if role == "" {
+		role = atc.RoleOwner
+	}
+
+	if !atc.IsValidRole(role) {
+		hLog.Info("invalid-role", lager.Data{"role": role})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var expiresAt time.Time
+	if request.ExpiresAt != 0 {
+		expiresAt = time.Unix(request.ExpiresAt, 0)
+
+		if !expiresAt.After(time.Now()) {
+			hLog.Info("expiry-in-the-past", lager.Data{"expires-at": request.ExpiresAt})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	team, found, err := s.db.GetTeamByName(teamName)
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	value, hash, err := auth.GenerateAPIToken()
+	if err != nil {
+		hLog.Error("failed-to-generate-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	savedToken, err := s.db.CreateAPIToken(db.APIToken{
+		TeamID:    team.ID,
+		Name:      request.Name,
+		Role:      role,
+		ExpiresAt: expiresAt,
+	}, hash)
+	if err == db.ErrAPITokenExists {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		hLog.Error("failed-to-create-api-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	token := present.APIToken(savedToken)
+	token.Value = value
+
+	w.WriteHeader(http.StatusCreated)
+
+	json.NewEncoder(w).Encode(token)
+}
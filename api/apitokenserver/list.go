@@ -0,0 +1,43 @@
+package apitokenserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+)
+
+func (s *Server) ListAPITokens(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("list-api-tokens")
+
+	teamName := r.FormValue(":team_name")
+
+	_, found, err := s.db.GetTeamByName(teamName)
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	savedTokens, err := s.db.GetAPITokens(teamName)
+	if err != nil {
+		hLog.Error("failed-to-get-api-tokens", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presentedTokens := []atc.APIToken{}
+	for _, savedToken := range savedTokens {
+		presentedTokens = append(presentedTokens, present.APIToken(savedToken))
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(presentedTokens)
+}
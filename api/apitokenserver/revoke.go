@@ -0,0 +1,24 @@
+package apitokenserver
+
+import "net/http"
+
+func (s *Server) RevokeAPIToken(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("revoke-api-token")
+
+	teamName := r.FormValue(":team_name")
+	tokenName := r.FormValue(":token_name")
+
+	found, err := s.db.DeleteAPIToken(teamName, tokenName)
+	if err != nil {
+		hLog.Error("failed-to-delete-api-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
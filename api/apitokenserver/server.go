@@ -0,0 +1,30 @@
+package apitokenserver
+
+import (
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+)
+
+type Server struct {
+	logger lager.Logger
+	db     APITokenDB
+}
+
+//go:generate counterfeiter . APITokenDB
+
+type APITokenDB interface {
+	GetTeamByName(teamName string) (db.SavedTeam, bool, error)
+	CreateAPIToken(token db.APIToken, tokenHash string) (db.SavedAPIToken, error)
+	GetAPITokens(teamName string) ([]db.SavedAPIToken, error)
+	DeleteAPIToken(teamName string, tokenName string) (bool, error)
+}
+
+func NewServer(
+	logger lager.Logger,
+	db APITokenDB,
+) *Server {
+	return &Server{
+		logger: logger,
+		db:     db,
+	}
+}
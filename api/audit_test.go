@@ -0,0 +1,200 @@
+package api_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit API", func() {
+	Describe("GET /api/v1/audit", func() {
+		var response *http.Response
+		var queryParams string
+
+		returnedEvents := []db.SavedAuditEvent{
+			{
+				ID:        2,
+				CreatedAt: time.Unix(200, 0),
+				AuditEvent: db.AuditEvent{
+					TeamName:     "some-team",
+					TeamID:       2,
+					UserName:     "github:some-user",
+					Route:        atc.PausePipeline,
+					PipelineName: "some-pipeline",
+					Status:       http.StatusOK,
+				},
+			},
+			{
+				ID:        1,
+				CreatedAt: time.Unix(100, 0),
+				AuditEvent: db.AuditEvent{
+					TeamName: "some-team",
+					UserName: "token:ci-bot",
+					Route:    atc.AbortBuild,
+				},
+			},
+		}
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/audit" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(auditDB.GetAuditEventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the requester is an admin", func() {
+				BeforeEach(func() {
+					userContextReader.GetTeamReturns(atc.DefaultTeamName, 1, true, true)
+				})
+
+				It("returns events of all teams", func() {
+					Expect(auditDB.GetAuditEventsCallCount()).To(Equal(1))
+
+					teamID, _ := auditDB.GetAuditEventsArgsForCall(0)
+					Expect(teamID).To(BeZero())
+				})
+			})
+
+			Context("when the requester belongs to a non-admin team", func() {
+				BeforeEach(func() {
+					userContextReader.GetTeamReturns("some-team", 2, false, true)
+				})
+
+				It("only returns the events of that team", func() {
+					Expect(auditDB.GetAuditEventsCallCount()).To(Equal(1))
+
+					teamID, _ := auditDB.GetAuditEventsArgsForCall(0)
+					Expect(teamID).To(Equal(2))
+				})
+
+				Context("when the requester is not an owner", func() {
+					BeforeEach(func() {
+						userContextReader.GetRoleReturns(atc.RoleMember, true)
+					})
+
+					It("returns 403 Forbidden", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+			})
+
+			Context("when no params are passed", func() {
+				It("uses the default limit", func() {
+					Expect(auditDB.GetAuditEventsCallCount()).To(Equal(1))
+
+					_, page := auditDB.GetAuditEventsArgsForCall(0)
+					Expect(page).To(Equal(db.Page{
+						Since: 0,
+						Until: 0,
+						Limit: 100,
+					}))
+				})
+			})
+
+			Context("when all the params are passed", func() {
+				BeforeEach(func() {
+					queryParams = "?since=2&until=3&limit=8"
+				})
+
+				It("passes them through", func() {
+					Expect(auditDB.GetAuditEventsCallCount()).To(Equal(1))
+
+					_, page := auditDB.GetAuditEventsArgsForCall(0)
+					Expect(page).To(Equal(db.Page{
+						Since: 2,
+						Until: 3,
+						Limit: 8,
+					}))
+				})
+			})
+
+			Context("when getting the events succeeds", func() {
+				BeforeEach(func() {
+					auditDB.GetAuditEventsReturns(returnedEvents, db.Pagination{}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the events", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 2,
+							"created_at": 200,
+							"team_name": "some-team",
+							"team_id": 2,
+							"user_name": "github:some-user",
+							"route": "PausePipeline",
+							"pipeline_name": "some-pipeline",
+							"status": 200
+						},
+						{
+							"id": 1,
+							"created_at": 100,
+							"team_name": "some-team",
+							"user_name": "token:ci-bot",
+							"route": "AbortBuild"
+						}
+					]`))
+				})
+			})
+
+			Context("when next/previous pages are available", func() {
+				BeforeEach(func() {
+					auditDB.GetAuditEventsReturns(returnedEvents, db.Pagination{
+						Previous: &db.Page{Until: 2, Limit: 2},
+						Next:     &db.Page{Since: 1, Limit: 2},
+					}, nil)
+				})
+
+				It("returns Link headers per rfc5988", func() {
+					Expect(response.Header["Link"]).To(ConsistOf([]string{
+						fmt.Sprintf(`<%s/api/v1/audit?until=2&limit=2>; rel="previous"`, externalURL),
+						fmt.Sprintf(`<%s/api/v1/audit?since=1&limit=2>; rel="next"`, externalURL),
+					}))
+				})
+			})
+
+			Context("when getting the events fails", func() {
+				BeforeEach(func() {
+					auditDB.GetAuditEventsReturns(nil, db.Pagination{}, errors.New("oh no!"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
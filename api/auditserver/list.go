@@ -0,0 +1,92 @@
+package auditserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-audit-events")
+
+	var (
+		err   error
+		until int
+		since int
+		limit int
+	)
+
+	urlUntil := r.FormValue(atc.PaginationQueryUntil)
+	until, _ = strconv.Atoi(urlUntil)
+
+	urlSince := r.FormValue(atc.PaginationQuerySince)
+	since, _ = strconv.Atoi(urlSince)
+
+	urlLimit := r.FormValue(atc.PaginationQueryLimit)
+
+	limit, _ = strconv.Atoi(urlLimit)
+	if limit == 0 {
+		limit = atc.PaginationAPIDefaultLimit
+	}
+
+	// non-admin teams may only see their own events
+	var teamID int
+	_, authTeamID, isAdmin, found := auth.GetTeam(r)
+	if found && !isAdmin {
+		teamID = authTeamID
+	}
+
+	events, pagination, err := s.db.GetAuditEvents(teamID, db.Page{Until: until, Since: since, Limit: limit})
+	if err != nil {
+		logger.Error("failed-to-get-audit-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if pagination.Next != nil {
+		s.addNextLink(w, *pagination.Next)
+	}
+
+	if pagination.Previous != nil {
+		s.addPreviousLink(w, *pagination.Previous)
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	presentedEvents := make([]atc.AuditEvent, len(events))
+	for i := 0; i < len(events); i++ {
+		presentedEvents[i] = present.AuditEvent(events[i])
+	}
+
+	json.NewEncoder(w).Encode(presentedEvents)
+}
+
+func (s *Server) addNextLink(w http.ResponseWriter, page db.Page) {
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s/api/v1/audit?%s=%d&%s=%d>; rel="%s"`,
+		s.externalURL,
+		atc.PaginationQuerySince,
+		page.Since,
+		atc.PaginationQueryLimit,
+		page.Limit,
+		atc.LinkRelNext,
+	))
+}
+
+func (s *Server) addPreviousLink(w http.ResponseWriter, page db.Page) {
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s/api/v1/audit?%s=%d&%s=%d>; rel="%s"`,
+		s.externalURL,
+		atc.PaginationQueryUntil,
+		page.Until,
+		atc.PaginationQueryLimit,
+		page.Limit,
+		atc.LinkRelPrevious,
+	))
+}
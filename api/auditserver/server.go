@@ -0,0 +1,32 @@
+package auditserver
+
+import (
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+)
+
+type Server struct {
+	logger lager.Logger
+
+	externalURL string
+
+	db AuditDB
+}
+
+//go:generate counterfeiter . AuditDB
+
+type AuditDB interface {
+	GetAuditEvents(teamID int, page db.Page) ([]db.SavedAuditEvent, db.Pagination, error)
+}
+
+func NewServer(
+	logger lager.Logger,
+	externalURL string,
+	db AuditDB,
+) *Server {
+	return &Server{
+		logger:      logger,
+		externalURL: externalURL,
+		db:          db,
+	}
+}
@@ -27,6 +27,9 @@ var _ = Describe("Auth API", func() {
 				Team: db.Team{
 					Name:  atc.DefaultTeamName,
 					Admin: true,
+					BasicAuth: db.BasicAuth{
+						BasicAuthUsername: "some-user",
+					},
 				},
 			}
 
@@ -48,6 +51,23 @@ var _ = Describe("Auth API", func() {
 				authValidator.IsAuthenticatedReturns(true)
 			})
 
+			Context("when the request's authorization is an API token", func() {
+				BeforeEach(func() {
+					request.Header.Add("Authorization", "Token some-api-token")
+				})
+
+				It("returns the API token without generating a new one", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{"type":"Token","value":"some-api-token"}`))
+
+					Expect(fakeTokenGenerator.GenerateTokenCallCount()).To(Equal(0))
+				})
+			})
+
 			for _, b := range []string{"bearer", "BEARER", "Bearer"} {
 				bearer := b
 
@@ -99,11 +119,13 @@ var _ = Describe("Auth API", func() {
 
 						Expect(body).To(MatchJSON(`{"type":"some type","value":"some value"}`))
 
-						expiration, teamName, teamID, isAdmin := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						expiration, teamName, teamID, isAdmin, role, user := fakeTokenGenerator.GenerateTokenArgsForCall(0)
 						Expect(expiration).To(BeTemporally("~", time.Now().Add(24*time.Hour), time.Minute))
 						Expect(teamName).To(Equal(savedTeam.Name))
 						Expect(teamID).To(Equal(savedTeam.ID))
 						Expect(isAdmin).To(Equal(savedTeam.Admin))
+						Expect(role).To(Equal(atc.RoleOwner))
+						Expect(user).To(Equal("basic:some-user"))
 					})
 				})
 
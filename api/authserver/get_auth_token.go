@@ -25,11 +25,12 @@ func (s *Server) GetAuthToken(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var token atc.AuthToken
-	if strings.ToLower(authSegs[0]) == strings.ToLower(auth.TokenTypeBearer) {
+	if strings.ToLower(authSegs[0]) == strings.ToLower(auth.TokenTypeBearer) ||
+		strings.ToLower(authSegs[0]) == strings.ToLower(auth.APITokenType) {
 		token.Type = authSegs[0]
 		token.Value = authSegs[1]
 	} else {
-		teamName := atc.DefaultTeamName
+		teamName := auth.GetRequestedTeamName(r)
 
 		team, found, err := s.db.GetTeamByName(teamName)
 		if err != nil {
@@ -45,7 +46,12 @@ func (s *Server) GetAuthToken(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		tokenType, tokenValue, err := s.tokenGenerator.GenerateToken(time.Now().Add(tokenDuration), team.Name, team.ID, team.Admin)
+		var user string
+		if team.BasicAuthUsername != "" {
+			user = "basic:" + team.BasicAuthUsername
+		}
+
+		tokenType, tokenValue, err := s.tokenGenerator.GenerateToken(time.Now().Add(tokenDuration), team.Name, team.ID, team.Admin, atc.RoleOwner, user)
 		if err != nil {
 			logger.Error("generate-token", err)
 			w.WriteHeader(http.StatusInternalServerError)
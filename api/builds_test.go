@@ -120,6 +120,7 @@ var _ = Describe("Builds API", func() {
 
 					It("creates a one-off build and runs it asynchronously", func() {
 						Expect(buildsDB.CreateOneOffBuildCallCount()).To(Equal(1))
+						Expect(buildsDB.CreateOneOffBuildArgsForCall(0)).To(Equal(atc.DefaultTeamName))
 
 						Expect(fakeEngine.CreateBuildCallCount()).To(Equal(1))
 						_, oneOff, builtPlan := fakeEngine.CreateBuildArgsForCall(0)
@@ -138,6 +139,19 @@ var _ = Describe("Builds API", func() {
 
 						<-resumed
 					})
+
+					Context("when authenticated as a team", func() {
+						BeforeEach(func() {
+							userContextReader.GetTeamReturns("some-team", 2, false, true)
+						})
+
+						It("creates the build for the team", func() {
+							Expect(buildsDB.CreateOneOffBuildCallCount()).To(Equal(1))
+							Expect(buildsDB.CreateOneOffBuildArgsForCall(0)).To(Equal("some-team"))
+
+							<-resumed
+						})
+					})
 				})
 
 				Context("and building fails", func() {
@@ -261,6 +275,16 @@ var _ = Describe("Builds API", func() {
 						"reap_time": 200
 					}`))
 				})
+
+				Context("when the build belongs to another team", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-team", 2, false, true)
+					})
+
+					It("returns 403 Forbidden", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
 			})
 		})
 	})
@@ -284,6 +308,20 @@ var _ = Describe("Builds API", func() {
 				Expect(response.StatusCode).To(Equal(http.StatusOK))
 			})
 
+			Context("when the build belongs to another team", func() {
+				BeforeEach(func() {
+					userContextReader.GetTeamReturns("some-team", 2, false, true)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+
+				It("does not look up the build's resources", func() {
+					Expect(buildsDB.GetBuildResourcesCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when the build inputs/ouputs are not empty", func() {
 				BeforeEach(func() {
 					buildsDB.GetBuildResourcesReturns([]db.BuildInput{
@@ -554,6 +592,35 @@ var _ = Describe("Builds API", func() {
 			})
 		})
 
+		Context("when authenticated as a team that is not an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 2, false, true)
+				queryParams = "?limit=8"
+			})
+
+			It("only lists the team's builds", func() {
+				Expect(buildsDB.GetBuildsCallCount()).To(BeZero())
+				Expect(buildsDB.GetTeamBuildsCallCount()).To(Equal(1))
+
+				teamID, page := buildsDB.GetTeamBuildsArgsForCall(0)
+				Expect(teamID).To(Equal(2))
+				Expect(page).To(Equal(db.Page{Limit: 8}))
+			})
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns(atc.DefaultTeamName, 1, true, true)
+			})
+
+			It("lists every team's builds", func() {
+				Expect(buildsDB.GetBuildsCallCount()).To(Equal(1))
+				Expect(buildsDB.GetTeamBuildsCallCount()).To(BeZero())
+			})
+		})
+
 		Context("when next/previous pages are available", func() {
 			BeforeEach(func() {
 				buildsDB.GetBuildsReturns(returnedBuilds, db.Pagination{
@@ -627,6 +694,40 @@ var _ = Describe("Builds API", func() {
 					Expect(constructedEventHandler.db).To(Equal(buildsDB))
 					Expect(constructedEventHandler.buildID).To(Equal(128))
 				})
+
+				Context("when the build belongs to another team", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-team", 2, false, true)
+					})
+
+					Context("and the build is private", func() {
+						BeforeEach(func() {
+							buildsDB.GetConfigByBuildIDReturns(atc.Config{
+								Jobs: atc.JobConfigs{
+									{Name: "some-job", Public: false},
+								},
+							}, 1, nil)
+						})
+
+						It("returns 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						})
+					})
+
+					Context("and the build is public", func() {
+						BeforeEach(func() {
+							buildsDB.GetConfigByBuildIDReturns(atc.Config{
+								Jobs: atc.JobConfigs{
+									{Name: "some-job", Public: true},
+								},
+							}, 1, nil)
+						})
+
+						It("returns 200", func() {
+							Expect(response.StatusCode).To(Equal(200))
+						})
+					})
+				})
 			})
 
 			Context("when the build can not be found", func() {
@@ -772,6 +873,25 @@ var _ = Describe("Builds API", func() {
 					}, true, nil)
 				})
 
+				Context("when the build belongs to another team", func() {
+					var fakeBuild *enginefakes.FakeBuild
+
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-team", 2, false, true)
+
+						fakeBuild = new(enginefakes.FakeBuild)
+						fakeEngine.LookupBuildReturns(fakeBuild, nil)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+
+					It("does not abort the build", func() {
+						Expect(fakeBuild.AbortCallCount()).To(BeZero())
+					})
+				})
+
 				Context("when the engine returns a build", func() {
 					var fakeBuild *enginefakes.FakeBuild
 
@@ -861,6 +981,10 @@ var _ = Describe("Builds API", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		BeforeEach(func() {
+			buildsDB.GetBuildReturns(db.Build{ID: 42}, true, nil)
+		})
+
 		Context("when the build is found", func() {
 			var buildPrep db.BuildPreparation
 
@@ -876,6 +1000,7 @@ var _ = Describe("Builds API", func() {
 					},
 					InputsSatisfied:     db.BuildPreparationStatusBlocking,
 					MissingInputReasons: db.MissingInputReasons{"some-input": "some-reason"},
+					WorkersAvailable:    db.BuildPreparationStatusUnknown,
 				}
 				buildsDB.GetBuildPreparationReturns(buildPrep, true, nil)
 			})
@@ -905,9 +1030,34 @@ var _ = Describe("Builds API", func() {
 					"inputs_satisfied": "blocking",
 					"missing_input_reasons": {
 						"some-input": "some-reason"
-					}
+					},
+					"workers_available": "unknown"
 				}`))
 			})
+
+			Context("when the build belongs to another team", func() {
+				BeforeEach(func() {
+					userContextReader.GetTeamReturns("some-team", 2, false, true)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+
+				It("does not look up the build preparation", func() {
+					Expect(buildsDB.GetBuildPreparationCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the build is not found", func() {
+			BeforeEach(func() {
+				buildsDB.GetBuildReturns(db.Build{}, false, nil)
+			})
+
+			It("returns Not Found", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
 		})
 
 		Context("when the build preparation is not found", func() {
@@ -983,6 +1133,21 @@ var _ = Describe("Builds API", func() {
 				})
 			})
 
+			Context("when the build belongs to another team", func() {
+				BeforeEach(func() {
+					userContextReader.GetTeamReturns("some-team", 2, false, true)
+					engineBuild.PublicPlanReturns(publicPlan, true, nil)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+
+				It("does not look up the build's plan", func() {
+					Expect(fakeEngine.LookupBuildCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when the build has no plan", func() {
 				BeforeEach(func() {
 					engineBuild.PublicPlanReturns(atc.PublicBuildPlan{}, false, nil)
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/concourse/atc/auth"
 	"github.com/pivotal-golang/lager"
 )
 
@@ -30,6 +31,11 @@ func (s *Server) AbortBuild(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !auth.IsAuthorizedForTeam(r, build.TeamID) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
 	engineBuild, err := s.engine.LookupBuild(aLog, build)
 	if err != nil {
 		aLog.Error("failed-to-lookup-build", err)
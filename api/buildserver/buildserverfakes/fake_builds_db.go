@@ -60,10 +60,23 @@ type FakeBuildsDB struct {
 		result2 db.Pagination
 		result3 error
 	}
-	CreateOneOffBuildStub        func() (db.Build, error)
+	GetTeamBuildsStub        func(teamID int, page db.Page) ([]db.Build, db.Pagination, error)
+	getTeamBuildsMutex       sync.RWMutex
+	getTeamBuildsArgsForCall []struct {
+		teamID int
+		page   db.Page
+	}
+	getTeamBuildsReturns struct {
+		result1 []db.Build
+		result2 db.Pagination
+		result3 error
+	}
+	CreateOneOffBuildStub        func(teamName string) (db.Build, error)
 	createOneOffBuildMutex       sync.RWMutex
-	createOneOffBuildArgsForCall []struct{}
-	createOneOffBuildReturns     struct {
+	createOneOffBuildArgsForCall []struct {
+		teamName string
+	}
+	createOneOffBuildReturns struct {
 		result1 db.Build
 		result2 error
 	}
@@ -256,13 +269,51 @@ func (fake *FakeBuildsDB) GetBuildsReturns(result1 []db.Build, result2 db.Pagina
 	}{result1, result2, result3}
 }
 
-func (fake *FakeBuildsDB) CreateOneOffBuild() (db.Build, error) {
+func (fake *FakeBuildsDB) GetTeamBuilds(teamID int, page db.Page) ([]db.Build, db.Pagination, error) {
+	fake.getTeamBuildsMutex.Lock()
+	fake.getTeamBuildsArgsForCall = append(fake.getTeamBuildsArgsForCall, struct {
+		teamID int
+		page   db.Page
+	}{teamID, page})
+	fake.recordInvocation("GetTeamBuilds", []interface{}{teamID, page})
+	fake.getTeamBuildsMutex.Unlock()
+	if fake.GetTeamBuildsStub != nil {
+		return fake.GetTeamBuildsStub(teamID, page)
+	} else {
+		return fake.getTeamBuildsReturns.result1, fake.getTeamBuildsReturns.result2, fake.getTeamBuildsReturns.result3
+	}
+}
+
+func (fake *FakeBuildsDB) GetTeamBuildsCallCount() int {
+	fake.getTeamBuildsMutex.RLock()
+	defer fake.getTeamBuildsMutex.RUnlock()
+	return len(fake.getTeamBuildsArgsForCall)
+}
+
+func (fake *FakeBuildsDB) GetTeamBuildsArgsForCall(i int) (int, db.Page) {
+	fake.getTeamBuildsMutex.RLock()
+	defer fake.getTeamBuildsMutex.RUnlock()
+	return fake.getTeamBuildsArgsForCall[i].teamID, fake.getTeamBuildsArgsForCall[i].page
+}
+
+func (fake *FakeBuildsDB) GetTeamBuildsReturns(result1 []db.Build, result2 db.Pagination, result3 error) {
+	fake.GetTeamBuildsStub = nil
+	fake.getTeamBuildsReturns = struct {
+		result1 []db.Build
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuildsDB) CreateOneOffBuild(teamName string) (db.Build, error) {
 	fake.createOneOffBuildMutex.Lock()
-	fake.createOneOffBuildArgsForCall = append(fake.createOneOffBuildArgsForCall, struct{}{})
-	fake.recordInvocation("CreateOneOffBuild", []interface{}{})
+	fake.createOneOffBuildArgsForCall = append(fake.createOneOffBuildArgsForCall, struct {
+		teamName string
+	}{teamName})
+	fake.recordInvocation("CreateOneOffBuild", []interface{}{teamName})
 	fake.createOneOffBuildMutex.Unlock()
 	if fake.CreateOneOffBuildStub != nil {
-		return fake.CreateOneOffBuildStub()
+		return fake.CreateOneOffBuildStub(teamName)
 	} else {
 		return fake.createOneOffBuildReturns.result1, fake.createOneOffBuildReturns.result2
 	}
@@ -274,6 +325,12 @@ func (fake *FakeBuildsDB) CreateOneOffBuildCallCount() int {
 	return len(fake.createOneOffBuildArgsForCall)
 }
 
+func (fake *FakeBuildsDB) CreateOneOffBuildArgsForCall(i int) string {
+	fake.createOneOffBuildMutex.RLock()
+	defer fake.createOneOffBuildMutex.RUnlock()
+	return fake.createOneOffBuildArgsForCall[i].teamName
+}
+
 func (fake *FakeBuildsDB) CreateOneOffBuildReturns(result1 db.Build, result2 error) {
 	fake.CreateOneOffBuildStub = nil
 	fake.createOneOffBuildReturns = struct {
@@ -330,6 +387,8 @@ func (fake *FakeBuildsDB) Invocations() map[string][][]interface{} {
 	defer fake.getBuildPreparationMutex.RUnlock()
 	fake.getBuildsMutex.RLock()
 	defer fake.getBuildsMutex.RUnlock()
+	fake.getTeamBuildsMutex.RLock()
+	defer fake.getTeamBuildsMutex.RUnlock()
 	fake.createOneOffBuildMutex.RLock()
 	defer fake.createOneOffBuildMutex.RUnlock()
 	fake.getConfigByBuildIDMutex.RLock()
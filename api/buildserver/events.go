@@ -28,9 +28,10 @@ func (s *Server) BuildEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !auth.IsAuthenticated(r) {
+	// other teams' builds may only be watched if the job is public
+	if !auth.IsAuthenticated(r) || !auth.IsAuthorizedForTeam(r, build.TeamID) {
 		if build.OneOff() {
-			s.rejector.Unauthorized(w, r)
+			s.rejectBuildEvents(w, r)
 			return
 		}
 
@@ -49,7 +50,7 @@ func (s *Server) BuildEvents(w http.ResponseWriter, r *http.Request) {
 		}
 
 		if !public {
-			s.rejector.Unauthorized(w, r)
+			s.rejectBuildEvents(w, r)
 			return
 		}
 	}
@@ -66,3 +67,12 @@ func (s *Server) BuildEvents(w http.ResponseWriter, r *http.Request) {
 	case <-s.drain:
 	}
 }
+
+func (s *Server) rejectBuildEvents(w http.ResponseWriter, r *http.Request) {
+	if auth.IsAuthenticated(r) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	s.rejector.Unauthorized(w, r)
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
 	"github.com/pivotal-golang/lager"
 )
 
@@ -30,6 +31,11 @@ func (s *Server) GetBuild(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !auth.IsAuthorizedForTeam(r, dbBuild.TeamID) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
 	w.WriteHeader(http.StatusOK)
 
 	build := present.Build(dbBuild)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 )
 
@@ -34,7 +35,17 @@ func (s *Server) ListBuilds(w http.ResponseWriter, r *http.Request) {
 		limit = atc.PaginationAPIDefaultLimit
 	}
 
-	builds, pagination, err := s.db.GetBuilds(db.Page{Until: until, Since: since, Limit: limit})
+	page := db.Page{Until: until, Since: since, Limit: limit}
+
+	var builds []db.Build
+	var pagination db.Pagination
+
+	_, teamID, isAdmin, found := auth.GetTeam(r)
+	if found && !isAdmin {
+		builds, pagination, err = s.db.GetTeamBuilds(teamID, page)
+	} else {
+		builds, pagination, err = s.db.GetBuilds(page)
+	}
 	if err != nil {
 		logger.Error("failed-to-get-all-builds", err)
 		w.WriteHeader(http.StatusInternalServerError)
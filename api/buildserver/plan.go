@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+
+	"github.com/concourse/atc/auth"
 )
 
 func (s *Server) GetBuildPlan(w http.ResponseWriter, r *http.Request) {
@@ -29,6 +31,11 @@ func (s *Server) GetBuildPlan(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !auth.IsAuthorizedForTeam(r, build.TeamID) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
 	engineBuild, err := s.engine.LookupBuild(hLog, build)
 	if err != nil {
 		hLog.Error("failed-to-lookup-build", err)
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
 	"github.com/pivotal-golang/lager"
 )
 
@@ -20,6 +21,23 @@ func (s *Server) GetBuildPreparation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	build, found, err := s.db.GetBuild(buildID)
+	if err != nil {
+		log.Error("cannot-find-build", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if !auth.IsAuthorizedForTeam(r, build.TeamID) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
 	prep, found, err := s.db.GetBuildPreparation(buildID)
 	if err != nil {
 		log.Error("cannot-find-build-preparation", err)
@@ -7,7 +7,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/present"
-	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/auth"
 	"github.com/pivotal-golang/lager"
 )
 
@@ -20,7 +20,7 @@ func (s *Server) BuildResources(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	inputs, outputs, found, err := s.getMeAllTheThings(buildID)
+	build, found, err := s.db.GetBuild(buildID)
 	if err != nil {
 		log.Error("cannot-find-build", err, lager.Data{"buildID": r.FormValue(":build_id")})
 		w.WriteHeader(http.StatusInternalServerError)
@@ -32,6 +32,18 @@ func (s *Server) BuildResources(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !auth.IsAuthorizedForTeam(r, build.TeamID) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	inputs, outputs, err := s.db.GetBuildResources(buildID)
+	if err != nil {
+		log.Error("cannot-find-build-resources", err, lager.Data{"buildID": r.FormValue(":build_id")})
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
 	atcInputs := make([]atc.PublicBuildInput, 0, len(inputs))
 	for _, input := range inputs {
 		atcInputs = append(atcInputs, present.PublicBuildInput(input))
@@ -50,17 +62,3 @@ func (s *Server) BuildResources(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(output)
 }
-
-func (s *Server) getMeAllTheThings(buildID int) ([]db.BuildInput, []db.BuildOutput, bool, error) {
-	_, found, err := s.db.GetBuild(buildID)
-	if err != nil {
-		return []db.BuildInput{}, []db.BuildOutput{}, false, err
-	}
-
-	if !found {
-		return []db.BuildInput{}, []db.BuildOutput{}, false, nil
-	}
-
-	inputs, outputs, err := s.db.GetBuildResources(buildID)
-	return inputs, outputs, found, err
-}
@@ -39,8 +39,9 @@ type BuildsDB interface {
 	GetBuildPreparation(buildID int) (db.BuildPreparation, bool, error)
 
 	GetBuilds(db.Page) ([]db.Build, db.Pagination, error)
+	GetTeamBuilds(teamID int, page db.Page) ([]db.Build, db.Pagination, error)
 
-	CreateOneOffBuild() (db.Build, error)
+	CreateOneOffBuild(teamName string) (db.Build, error)
 	GetConfigByBuildID(buildID int) (atc.Config, db.ConfigVersion, error)
 }
 
@@ -122,15 +122,22 @@ var _ = Describe("Config API", func() {
 
 	Describe("GET /api/v1/pipelines/:name/config", func() {
 		var (
+			query    string
 			response *http.Response
 		)
 
+		BeforeEach(func() {
+			query = ""
+		})
+
 		JustBeforeEach(func() {
 			req, err := requestGenerator.CreateRequest(atc.GetConfig, rata.Params{
 				"pipeline_name": "something-else",
 			}, nil)
 			Expect(err).NotTo(HaveOccurred())
 
+			req.URL.RawQuery = query
+
 			response, err = client.Do(req)
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -142,7 +149,7 @@ var _ = Describe("Config API", func() {
 
 			Context("when the config can be loaded", func() {
 				BeforeEach(func() {
-					configDB.GetConfigReturns(pipelineConfig, atc.RawConfig("raw-config"), 1, nil)
+					configDB.GetInstanceConfigReturns(pipelineConfig, atc.RawConfig("raw-config"), 1, nil)
 				})
 
 				It("returns 200", func() {
@@ -165,15 +172,79 @@ var _ = Describe("Config API", func() {
 				})
 
 				It("calls get config with the correct arguments", func() {
-					teamName, name := configDB.GetConfigArgsForCall(0)
+					teamName, name, instanceVars := configDB.GetInstanceConfigArgsForCall(0)
 					Expect(teamName).To(Equal(atc.DefaultTeamName))
 					Expect(name).To(Equal("something-else"))
+					Expect(instanceVars).To(BeNil())
+				})
+
+				Context("when the pipeline was saved from a template", func() {
+					BeforeEach(func() {
+						configDB.GetInstanceTemplateReturns(atc.PipelineTemplate{
+							Template: "jobs: [{name: {{job}}}]",
+							Vars:     atc.TemplateVars{"job": "some-job"},
+						}, true, nil)
+					})
+
+					It("returns the template and its vars alongside the rendered raw config", func() {
+						var actualConfigResponse atc.ConfigResponse
+						err := json.NewDecoder(response.Body).Decode(&actualConfigResponse)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(actualConfigResponse).To(Equal(atc.ConfigResponse{
+							Config:       &pipelineConfig,
+							RawConfig:    atc.RawConfig("raw-config"),
+							Template:     "jobs: [{name: {{job}}}]",
+							TemplateVars: atc.TemplateVars{"job": "some-job"},
+						}))
+					})
+
+					It("looks up the template of the correct pipeline", func() {
+						teamName, name, instanceVars := configDB.GetInstanceTemplateArgsForCall(0)
+						Expect(teamName).To(Equal(atc.DefaultTeamName))
+						Expect(name).To(Equal("something-else"))
+						Expect(instanceVars).To(BeNil())
+					})
+				})
+
+				Context("when instance vars are given", func() {
+					BeforeEach(func() {
+						query = "vars=%7B%22branch%22%3A%22master%22%7D"
+					})
+
+					It("gets the config and template of the instance", func() {
+						_, _, instanceVars := configDB.GetInstanceConfigArgsForCall(0)
+						Expect(instanceVars).To(Equal(atc.InstanceVars{"branch": "master"}))
+
+						_, _, instanceVars = configDB.GetInstanceTemplateArgsForCall(0)
+						Expect(instanceVars).To(Equal(atc.InstanceVars{"branch": "master"}))
+					})
+				})
+
+				Context("when the instance vars are malformed", func() {
+					BeforeEach(func() {
+						query = "vars=nope"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when getting the template fails", func() {
+					BeforeEach(func() {
+						configDB.GetInstanceTemplateReturns(atc.PipelineTemplate{}, false, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
 				})
 			})
 
 			Context("when getting the config fails", func() {
 				BeforeEach(func() {
-					configDB.GetConfigReturns(atc.Config{}, atc.RawConfig(""), 0, errors.New("oh no!"))
+					configDB.GetInstanceConfigReturns(atc.Config{}, atc.RawConfig(""), 0, errors.New("oh no!"))
 				})
 
 				It("returns 500", func() {
@@ -183,7 +254,7 @@ var _ = Describe("Config API", func() {
 
 			Context("when getting the config fails because it is malformed", func() {
 				BeforeEach(func() {
-					configDB.GetConfigReturns(atc.Config{}, atc.RawConfig("raw-config"), 42, atc.MalformedConfigError{errors.New("invalid character")})
+					configDB.GetInstanceConfigReturns(atc.Config{}, atc.RawConfig("raw-config"), 42, atc.MalformedConfigError{errors.New("invalid character")})
 				})
 
 				It("returns 200", func() {
@@ -218,6 +289,56 @@ var _ = Describe("Config API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:name/config", func() {
+		var (
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			configDB.GetInstanceConfigReturns(pipelineConfig, atc.RawConfig("raw-config"), 1, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/something-else/config", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as the requested team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 2, false, true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("gets the config of the requested team", func() {
+				teamName, name, _ := configDB.GetInstanceConfigArgsForCall(0)
+				Expect(teamName).To(Equal("a-team"))
+				Expect(name).To(Equal("something-else"))
+			})
+		})
+
+		Context("when authenticated as another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("another-team", 3, false, true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not get the config", func() {
+				Expect(configDB.GetInstanceConfigCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/pipelines/:name/config", func() {
 		var (
 			request  *http.Request
@@ -357,6 +478,113 @@ var _ = Describe("Config API", func() {
 							})
 						})
 
+						Describe("validating cross-pipeline passed constraints", func() {
+							It("looks up the team's other pipelines", func() {
+								otherConfig := atc.Config{
+									Jobs: atc.JobConfigs{{Name: "other-job"}},
+								}
+
+								configDB.GetConfigReturns(otherConfig, atc.RawConfig("raw"), 3, nil)
+
+								foundConfig, found, err := configValidationPipelines("other-pipeline")
+								Expect(err).NotTo(HaveOccurred())
+								Expect(found).To(BeTrue())
+								Expect(foundConfig).To(Equal(otherConfig))
+
+								teamName, pipelineName := configDB.GetConfigArgsForCall(0)
+								Expect(teamName).To(Equal(atc.DefaultTeamName))
+								Expect(pipelineName).To(Equal("other-pipeline"))
+							})
+
+							It("does not find pipelines that do not exist", func() {
+								configDB.GetConfigReturns(atc.Config{}, atc.RawConfig(""), 0, nil)
+
+								_, found, err := configValidationPipelines("bogus-pipeline")
+								Expect(err).NotTo(HaveOccurred())
+								Expect(found).To(BeFalse())
+							})
+
+							It("refuses pipelines that only exist with instance vars", func() {
+								configDB.GetConfigReturns(atc.Config{}, atc.RawConfig(""), 0, nil)
+								configDB.GetPipelineInstancesReturns([]db.SavedPipeline{
+									{Pipeline: db.Pipeline{Name: "instanced-pipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}},
+								}, nil)
+
+								_, found, err := configValidationPipelines("instanced-pipeline")
+								Expect(err).To(Equal(config.ErrInstancedPipeline))
+								Expect(found).To(BeFalse())
+
+								teamName, pipelineName := configDB.GetPipelineInstancesArgsForCall(0)
+								Expect(teamName).To(Equal(atc.DefaultTeamName))
+								Expect(pipelineName).To(Equal("instanced-pipeline"))
+							})
+
+							It("does not treat the pipeline being saved as another pipeline", func() {
+								_, found, err := configValidationPipelines("a-pipeline")
+								Expect(err).NotTo(HaveOccurred())
+								Expect(found).To(BeFalse())
+								Expect(configDB.GetConfigCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when a job has a matrix", func() {
+							BeforeEach(func() {
+								pipelineConfig.Jobs = append(pipelineConfig.Jobs, atc.JobConfig{
+									Name: "unit-((matrix.go))",
+									Matrix: atc.MatrixConfig{
+										{Name: "go", Values: []string{"1.6", "1.7"}},
+									},
+								})
+
+								payload, err := json.Marshal(pipelineConfig)
+								Expect(err).NotTo(HaveOccurred())
+
+								request.Body = gbytes.BufferWithBytes(payload)
+							})
+
+							It("validates the expanded config", func() {
+								expandedConfig, err := pipelineConfig.ExpandMatrices()
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(configValidationConfig).To(Equal(expandedConfig))
+							})
+
+							It("saves the config as it was given", func() {
+								Expect(configDB.SaveConfigCallCount()).To(Equal(1))
+
+								_, _, savedConfig, _, _ := configDB.SaveConfigArgsForCall(0)
+								Expect(savedConfig).To(Equal(pipelineConfig))
+							})
+
+							Context("when the matrix cannot be expanded", func() {
+								BeforeEach(func() {
+									pipelineConfig.Jobs[len(pipelineConfig.Jobs)-1].Name = "unit-((matrix.bogus))"
+
+									payload, err := json.Marshal(pipelineConfig)
+									Expect(err).NotTo(HaveOccurred())
+
+									request.Body = gbytes.BufferWithBytes(payload)
+								})
+
+								It("returns 400", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+								})
+
+								It("returns error JSON", func() {
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`
+									{
+										"errors": [
+											"failed to expand matrix of job 'unit-((matrix.bogus))': unknown matrix parameter 'bogus'"
+										]
+									}`))
+								})
+
+								It("does not save it", func() {
+									Expect(configDB.SaveConfigCallCount()).To(BeZero())
+								})
+							})
+						})
+
 						Context("when the config is invalid", func() {
 							BeforeEach(func() {
 								configValidationErrorMessages = []string{"totally invalid"}
@@ -735,6 +963,128 @@ jobs:
 							})
 						})
 
+						Context("when a template and vars are given", func() {
+							templateValue := "jobs:\n- name: {{job}}\n  public: {{public}}\n"
+
+							writeTemplate := func(vars string) {
+								body := &bytes.Buffer{}
+								writer := multipart.NewWriter(body)
+
+								err := writer.WriteField("template", templateValue)
+								Expect(err).NotTo(HaveOccurred())
+
+								err = writer.WriteField("vars", vars)
+								Expect(err).NotTo(HaveOccurred())
+
+								writer.Close()
+
+								request.Header.Set("Content-Type", writer.FormDataContentType())
+								request.Body = gbytes.BufferWithBytes(body.Bytes())
+							}
+
+							Context("when every variable is defined", func() {
+								BeforeEach(func() {
+									writeTemplate("job: some-job\npublic: true\n")
+								})
+
+								It("returns 200", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+								})
+
+								It("saves the rendered config along with the template", func() {
+									Expect(configDB.SaveConfigCallCount()).To(BeZero())
+									Expect(configDB.SaveTemplatedConfigCallCount()).To(Equal(1))
+
+									teamName, name, savedConfig, template, id, pipelineState := configDB.SaveTemplatedConfigArgsForCall(0)
+									Expect(teamName).To(Equal(atc.DefaultTeamName))
+									Expect(name).To(Equal("a-pipeline"))
+									Expect(savedConfig).To(Equal(atc.Config{
+										Jobs: atc.JobConfigs{
+											{Name: "some-job", Public: true},
+										},
+									}))
+									Expect(template).To(Equal(atc.PipelineTemplate{
+										Template: templateValue,
+										Vars: atc.TemplateVars{
+											"job":    "some-job",
+											"public": true,
+										},
+									}))
+									Expect(id).To(Equal(db.ConfigVersion(42)))
+									Expect(pipelineState).To(Equal(db.PipelineNoChange))
+								})
+							})
+
+							Context("when saving an instance of the pipeline", func() {
+								BeforeEach(func() {
+									request.URL.RawQuery = "vars=%7B%22public%22%3Atrue%7D"
+									writeTemplate("job: some-job\n")
+								})
+
+								It("renders the template with the instance vars too", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+									Expect(configDB.SaveTemplatedConfigCallCount()).To(BeZero())
+									Expect(configDB.SaveInstanceConfigCallCount()).To(Equal(1))
+
+									teamName, name, instanceVars, savedConfig, template, id, _ := configDB.SaveInstanceConfigArgsForCall(0)
+									Expect(teamName).To(Equal(atc.DefaultTeamName))
+									Expect(name).To(Equal("a-pipeline"))
+									Expect(instanceVars).To(Equal(atc.InstanceVars{"public": true}))
+									Expect(savedConfig).To(Equal(atc.Config{
+										Jobs: atc.JobConfigs{
+											{Name: "some-job", Public: true},
+										},
+									}))
+									Expect(template).To(Equal(&atc.PipelineTemplate{
+										Template: templateValue,
+										Vars:     atc.TemplateVars{"job": "some-job"},
+									}))
+									Expect(id).To(Equal(db.ConfigVersion(42)))
+								})
+							})
+
+							Context("when variables are missing", func() {
+								BeforeEach(func() {
+									writeTemplate("job: some-job\n")
+								})
+
+								It("returns 400", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+								})
+
+								It("returns error JSON", func() {
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+										"errors": [
+											"undefined template variables: public"
+										]
+									}`))
+								})
+
+								It("does not save anything", func() {
+									Expect(configDB.SaveTemplatedConfigCallCount()).To(BeZero())
+								})
+							})
+
+							Context("when the vars are malformed", func() {
+								BeforeEach(func() {
+									writeTemplate("- not-a-map")
+								})
+
+								It("returns 400", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+								})
+
+								It("returns error JSON", func() {
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+										"errors": [
+											"template vars could not be decoded"
+										]
+									}`))
+								})
+							})
+						})
+
 						Context("when the config is malformed", func() {
 							Context("JSON", func() {
 								BeforeEach(func() {
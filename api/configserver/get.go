@@ -6,13 +6,23 @@ import (
 	"net/http"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
 	"github.com/tedsuo/rata"
 )
 
 func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.Session("get-config")
 	pipelineName := rata.Param(r, "pipeline_name")
-	config, rawConfig, id, err := s.db.GetConfig(atc.DefaultTeamName, pipelineName)
+	teamName := auth.GetRequestedTeamName(r)
+
+	instanceVars, err := atc.ParseInstanceVars(r.URL.Query().Get(atc.InstanceVarsQueryParam))
+	if err != nil {
+		logger.Error("malformed-instance-vars", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	config, rawConfig, id, err := s.db.GetInstanceConfig(teamName, pipelineName, instanceVars)
 	if err != nil {
 		if malformedErr, ok := err.(atc.MalformedConfigError); ok {
 			getConfigResponse := atc.ConfigResponse{
@@ -37,10 +47,26 @@ func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set(atc.ConfigVersionHeader, fmt.Sprintf("%d", id))
-
-	json.NewEncoder(w).Encode(atc.ConfigResponse{
+	response := atc.ConfigResponse{
 		Config:    &config,
 		RawConfig: rawConfig,
-	})
+	}
+
+	template, found, err := s.db.GetInstanceTemplate(teamName, pipelineName, instanceVars)
+	if err != nil {
+		logger.Error("failed-to-get-template", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// pipelines saved from a template come with the template, so that they
+	// can be edited and re-rendered
+	if found {
+		response.Template = template.Template
+		response.TemplateVars = template.Vars
+	}
+
+	w.Header().Set(atc.ConfigVersionHeader, fmt.Sprintf("%d", id))
+
+	json.NewEncoder(w).Encode(response)
 }
@@ -10,8 +10,10 @@ import (
 	"mime"
 	"mime/multipart"
 	"net/http"
+	"reflect"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db"
 	"github.com/mitchellh/mapstructure"
@@ -27,6 +29,8 @@ var (
 	ErrFailedToConstructDecoder   = errors.New("decoder could not be constructed")
 	ErrCouldNotDecode             = errors.New("data could not be decoded into config structure")
 	ErrInvalidPausedValue         = errors.New("invalid paused value")
+	ErrInvalidTemplateVars        = errors.New("template vars could not be decoded")
+	ErrVarsWithoutTemplate        = errors.New("template vars given without a template")
 )
 
 type ExtraKeysError struct {
@@ -65,7 +69,14 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	config, pausedState, err := saveConfigRequestUnmarshaler(r)
+	instanceVars, err := atc.ParseInstanceVars(r.URL.Query().Get(atc.InstanceVarsQueryParam))
+	if err != nil {
+		session.Error("malformed-instance-vars", err)
+		s.handleBadRequest(w, []string{fmt.Sprintf("instance vars are malformed: %s", err)}, session)
+		return
+	}
+
+	config, template, pausedState, err := saveConfigRequestUnmarshaler(r, instanceVars)
 
 	switch err {
 	case ErrStatusUnsupportedMediaType:
@@ -90,10 +101,17 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		session.Error("invalid-paused-value", err)
 		s.handleBadRequest(w, []string{"invalid paused value"}, session)
 		return
+	case ErrInvalidTemplateVars, ErrVarsWithoutTemplate:
+		session.Error("invalid-template-vars", err)
+		s.handleBadRequest(w, []string{err.Error()}, session)
+		return
 	default:
 		if err != nil {
 			if eke, ok := err.(ExtraKeysError); ok {
 				s.handleBadRequest(w, []string{eke.Error()}, session)
+			} else if uve, ok := err.(atc.UndefinedVarsError); ok {
+				session.Error("failed-to-render-template", err)
+				s.handleBadRequest(w, []string{uve.Error()}, session)
 			} else {
 				session.Error("unexpected-error", err)
 				w.WriteHeader(http.StatusInternalServerError)
@@ -103,7 +121,17 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	warnings, errorMessages := s.validate(config)
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamName := auth.GetRequestedTeamName(r)
+
+	expandedConfig, err := config.ExpandMatrices()
+	if err != nil {
+		session.Error("failed-to-expand-matrices", err)
+		s.handleBadRequest(w, []string{err.Error()}, session)
+		return
+	}
+
+	warnings, errorMessages := s.validate(expandedConfig, s.teamPipelineConfigs(teamName, pipelineName))
 	if len(errorMessages) > 0 {
 		session.Error("ignoring-invalid-config", err)
 		s.handleBadRequest(w, errorMessages, session)
@@ -112,8 +140,15 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 
 	session.Info("saving")
 
-	pipelineName := rata.Param(r, "pipeline_name")
-	_, created, err := s.db.SaveConfig(atc.DefaultTeamName, pipelineName, config, version, pausedState)
+	var created bool
+	switch {
+	case instanceVars != nil:
+		_, created, err = s.db.SaveInstanceConfig(teamName, pipelineName, instanceVars, config, template, version, pausedState)
+	case template != nil:
+		_, created, err = s.db.SaveTemplatedConfig(teamName, pipelineName, config, *template, version, pausedState)
+	default:
+		_, created, err = s.db.SaveConfig(teamName, pipelineName, config, version, pausedState)
+	}
 	if err != nil {
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -132,6 +167,36 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	s.writeSaveConfigResponse(w, SaveConfigResponse{Warnings: warnings}, session)
 }
 
+// teamPipelineConfigs looks up the configs of the team's other pipelines, for
+// validating cross-pipeline passed constraints.
+func (s *Server) teamPipelineConfigs(teamName string, pipelineName string) config.PipelineConfigs {
+	return func(otherPipelineName string) (atc.Config, bool, error) {
+		if otherPipelineName == pipelineName {
+			return atc.Config{}, false, nil
+		}
+
+		otherConfig, _, version, err := s.db.GetConfig(teamName, otherPipelineName)
+		if err != nil {
+			return atc.Config{}, false, err
+		}
+
+		if version == 0 {
+			instances, err := s.db.GetPipelineInstances(teamName, otherPipelineName)
+			if err != nil {
+				return atc.Config{}, false, err
+			}
+
+			if len(instances) > 0 {
+				return atc.Config{}, false, config.ErrInstancedPipeline
+			}
+
+			return atc.Config{}, false, nil
+		}
+
+		return otherConfig, true, nil
+	}
+}
+
 func (s *Server) handleBadRequest(w http.ResponseWriter, errorMessages []string, session lager.Logger) {
 	w.WriteHeader(http.StatusBadRequest)
 	s.writeSaveConfigResponse(w, SaveConfigResponse{
@@ -151,19 +216,25 @@ func (s *Server) writeSaveConfigResponse(w http.ResponseWriter, saveConfigRespon
 	w.Write(responseJSON)
 }
 
-func requestToConfig(contentType string, requestBody io.ReadCloser, configStructure interface{}) (db.PipelinePausedState, error) {
+// requestToConfig decodes the config in the request body. Multipart requests
+// may instead give a template and its vars, which are rendered along with the
+// instance vars of the pipeline being saved.
+func requestToConfig(contentType string, requestBody io.ReadCloser, configStructure interface{}, instanceVars atc.InstanceVars) (db.PipelinePausedState, *atc.PipelineTemplate, error) {
 	pausedState := db.PipelineNoChange
 
+	var template *atc.PipelineTemplate
+	var templateVars atc.TemplateVars
+
 	mediaType, params, err := mime.ParseMediaType(contentType)
 	if err != nil {
-		return db.PipelineNoChange, ErrCannotParseContentType
+		return db.PipelineNoChange, nil, ErrCannotParseContentType
 	}
 
 	switch mediaType {
 	case "application/json":
 		err := json.NewDecoder(requestBody).Decode(configStructure)
 		if err != nil {
-			return db.PipelineNoChange, ErrMalformedRequestPayload
+			return db.PipelineNoChange, nil, ErrMalformedRequestPayload
 		}
 
 	case "application/x-yaml":
@@ -173,7 +244,7 @@ func requestToConfig(contentType string, requestBody io.ReadCloser, configStruct
 		}
 
 		if err != nil {
-			return db.PipelineNoChange, ErrMalformedRequestPayload
+			return db.PipelineNoChange, nil, ErrMalformedRequestPayload
 		}
 
 	case "multipart/form-data":
@@ -187,13 +258,14 @@ func requestToConfig(contentType string, requestBody io.ReadCloser, configStruct
 			}
 
 			if err != nil {
-				return db.PipelineNoChange, err
+				return db.PipelineNoChange, nil, err
 			}
 
-			if part.FormName() == "paused" {
+			switch part.FormName() {
+			case "paused":
 				pausedValue, err := ioutil.ReadAll(part)
 				if err != nil {
-					return db.PipelineNoChange, err
+					return db.PipelineNoChange, nil, err
 				}
 
 				if string(pausedValue) == "true" {
@@ -201,28 +273,103 @@ func requestToConfig(contentType string, requestBody io.ReadCloser, configStruct
 				} else if string(pausedValue) == "false" {
 					pausedState = db.PipelineUnpaused
 				} else {
-					return db.PipelineNoChange, ErrInvalidPausedValue
+					return db.PipelineNoChange, nil, ErrInvalidPausedValue
 				}
-			} else {
+
+			case "template":
+				templateValue, err := ioutil.ReadAll(part)
+				if err != nil {
+					return db.PipelineNoChange, nil, err
+				}
+
+				template = &atc.PipelineTemplate{Template: string(templateValue)}
+
+			case "vars":
+				templateVars, err = requestToTemplateVars(part)
+				if err != nil {
+					return db.PipelineNoChange, nil, err
+				}
+
+			default:
 				partContentType := part.Header.Get("Content-type")
-				_, err := requestToConfig(partContentType, part, configStructure)
+				_, _, err := requestToConfig(partContentType, part, configStructure, nil)
 				if err != nil {
-					return db.PipelineNoChange, ErrMalformedRequestPayload
+					return db.PipelineNoChange, nil, ErrMalformedRequestPayload
 				}
 			}
 		}
+
+		if template == nil && templateVars != nil {
+			return db.PipelineNoChange, nil, ErrVarsWithoutTemplate
+		}
+
+		if template != nil {
+			template.Vars = templateVars
+
+			renderVars := atc.TemplateVars{}
+			for name, value := range templateVars {
+				renderVars[name] = value
+			}
+
+			for name, value := range instanceVars {
+				renderVars[name] = value
+			}
+
+			rendered, err := atc.PipelineTemplate{
+				Template: template.Template,
+				Vars:     renderVars,
+			}.Render()
+			if err != nil {
+				return db.PipelineNoChange, nil, err
+			}
+
+			err = yaml.Unmarshal(rendered, configStructure)
+			if err != nil {
+				return db.PipelineNoChange, nil, ErrMalformedRequestPayload
+			}
+		}
 	default:
-		return db.PipelineNoChange, ErrStatusUnsupportedMediaType
+		return db.PipelineNoChange, nil, ErrStatusUnsupportedMediaType
+	}
+
+	return pausedState, template, nil
+}
+
+func requestToTemplateVars(part io.Reader) (atc.TemplateVars, error) {
+	body, err := ioutil.ReadAll(part)
+	if err != nil {
+		return nil, err
+	}
+
+	var varsStructure interface{}
+	err = yaml.Unmarshal(body, &varsStructure)
+	if err != nil {
+		return nil, ErrInvalidTemplateVars
+	}
+
+	if varsStructure == nil {
+		return atc.TemplateVars{}, nil
+	}
+
+	// yaml decodes maps with interface{} keys, which cannot be rendered as JSON
+	sanitized, err := atc.SanitizeDecodeHook(reflect.Map, reflect.Map, varsStructure)
+	if err != nil {
+		return nil, ErrInvalidTemplateVars
+	}
+
+	vars, ok := sanitized.(map[string]interface{})
+	if !ok {
+		return nil, ErrInvalidTemplateVars
 	}
 
-	return pausedState, nil
+	return atc.TemplateVars(vars), nil
 }
 
-func saveConfigRequestUnmarshaler(r *http.Request) (atc.Config, db.PipelinePausedState, error) {
+func saveConfigRequestUnmarshaler(r *http.Request, instanceVars atc.InstanceVars) (atc.Config, *atc.PipelineTemplate, db.PipelinePausedState, error) {
 	var configStructure interface{}
-	pausedState, err := requestToConfig(r.Header.Get("Content-Type"), r.Body, &configStructure)
+	pausedState, template, err := requestToConfig(r.Header.Get("Content-Type"), r.Body, &configStructure, instanceVars)
 	if err != nil {
-		return atc.Config{}, db.PipelineNoChange, err
+		return atc.Config{}, nil, db.PipelineNoChange, err
 	}
 
 	var config atc.Config
@@ -239,16 +386,16 @@ func saveConfigRequestUnmarshaler(r *http.Request) (atc.Config, db.PipelinePause
 
 	decoder, err := mapstructure.NewDecoder(msConfig)
 	if err != nil {
-		return atc.Config{}, db.PipelineNoChange, ErrFailedToConstructDecoder
+		return atc.Config{}, nil, db.PipelineNoChange, ErrFailedToConstructDecoder
 	}
 
 	if err := decoder.Decode(configStructure); err != nil {
-		return atc.Config{}, db.PipelineNoChange, ErrCouldNotDecode
+		return atc.Config{}, nil, db.PipelineNoChange, ErrCouldNotDecode
 	}
 
 	if len(md.Unused) != 0 {
-		return atc.Config{}, db.PipelineNoChange, ExtraKeysError{extraKeys: md.Unused}
+		return atc.Config{}, nil, db.PipelineNoChange, ExtraKeysError{extraKeys: md.Unused}
 	}
 
-	return config, pausedState, nil
+	return config, template, pausedState, nil
 }
@@ -14,7 +14,7 @@ type Server struct {
 	validate ConfigValidator
 }
 
-type ConfigValidator func(atc.Config) ([]config.Warning, []string)
+type ConfigValidator func(atc.Config, config.PipelineConfigs) ([]config.Warning, []string)
 
 func NewServer(
 	logger lager.Logger,
@@ -223,6 +223,25 @@ var _ = Describe("Containers API", func() {
 				})
 			})
 
+			Describe("as a team that is not an admin", func() {
+				BeforeEach(func() {
+					userContextReader.GetTeamReturns("some-team", 2, false, true)
+				})
+
+				It("only queries for the team's containers", func() {
+					_, err := client.Do(req)
+					Expect(err).NotTo(HaveOccurred())
+
+					expectedArgs := db.Container{
+						ContainerMetadata: db.ContainerMetadata{
+							TeamID: 2,
+						},
+					}
+					Expect(containerDB.FindContainersByDescriptorsCallCount()).To(Equal(1))
+					Expect(containerDB.FindContainersByDescriptorsArgsForCall(0)).To(Equal(expectedArgs))
+				})
+			})
+
 			Describe("querying with pipeline name", func() {
 				BeforeEach(func() {
 					req.URL.RawQuery = url.Values{
@@ -533,6 +552,19 @@ var _ = Describe("Containers API", func() {
 						}
 					`))
 				})
+
+				Context("when the container belongs to another team", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-team", 2, false, true)
+					})
+
+					It("returns 403 Forbidden", func() {
+						response, err := client.Do(req)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
 			})
 
 			Context("when there is an error", func() {
@@ -852,6 +884,20 @@ var _ = Describe("Containers API", func() {
 				})
 			})
 
+			Context("when the container belongs to another team", func() {
+				BeforeEach(func() {
+					expectBadHandshake = true
+
+					userContextReader.GetTeamReturns("some-team", 2, false, true)
+					containerDB.GetContainerReturns(db.SavedContainer{}, true, nil)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(fakeWorkerClient.LookupContainerCallCount()).To(Equal(0))
+				})
+			})
+
 			Context("when the container cannot be found", func() {
 				BeforeEach(func() {
 					expectBadHandshake = true
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
 	"github.com/pivotal-golang/lager"
 )
 
@@ -28,6 +29,12 @@ func (s *Server) GetContainer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !auth.IsAuthorizedForTeam(r, container.TeamID) {
+		hLog.Info("not-authorized-for-container")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
 	hLog.Debug("found-container")
 
 	presentedContainer := present.Container(container)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/cloudfoundry-incubator/garden"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
 	"github.com/gorilla/websocket"
 	"github.com/pivotal-golang/lager"
 )
@@ -23,7 +24,7 @@ func (s *Server) HijackContainer(w http.ResponseWriter, r *http.Request) {
 		"handle": handle,
 	})
 
-	_, found, err := s.db.GetContainer(handle)
+	container, found, err := s.db.GetContainer(handle)
 	if err != nil {
 		hLog.Error("failed-to-find-container", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -36,6 +37,12 @@ func (s *Server) HijackContainer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !auth.IsAuthorizedForTeam(r, container.TeamID) {
+		hLog.Info("not-authorized-for-container")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
 	hLog.Debug("found-container")
 
 	conn, err := upgrader.Upgrade(w, r, nil)
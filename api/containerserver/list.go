@@ -8,6 +8,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 	"github.com/pivotal-golang/lager"
 )
@@ -26,6 +27,12 @@ func (s *Server) ListContainers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// only admins may see other teams' containers
+	_, teamID, isAdmin, found := auth.GetTeam(r)
+	if found && !isAdmin {
+		containerDescriptor.TeamID = teamID
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 
 	hLog.Debug("listing-containers")
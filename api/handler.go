@@ -8,6 +8,8 @@ import (
 	"github.com/tedsuo/rata"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/apitokenserver"
+	"github.com/concourse/atc/api/auditserver"
 	"github.com/concourse/atc/api/authserver"
 	"github.com/concourse/atc/api/buildserver"
 	"github.com/concourse/atc/api/cliserver"
@@ -53,6 +55,8 @@ func NewHandler(
 	pipeDB pipes.PipeDB,
 	pipelinesDB db.PipelinesDB,
 	teamDB teamserver.TeamDB,
+	apiTokenDB apitokenserver.APITokenDB,
+	auditDB auditserver.AuditDB,
 
 	configValidator configserver.ConfigValidator,
 	peerURL string,
@@ -97,7 +101,7 @@ func NewHandler(
 		drain,
 	)
 
-	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL)
+	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL, workerClient)
 	resourceServer := resourceserver.NewServer(logger, scannerFactory)
 	versionServer := versionserver.NewServer(logger, externalURL)
 	pipeServer := pipes.NewServer(logger, peerURL, externalURL, pipeDB)
@@ -118,6 +122,10 @@ func NewHandler(
 
 	teamServer := teamserver.NewServer(logger, teamDB)
 
+	apiTokenServer := apitokenserver.NewServer(logger, apiTokenDB)
+
+	auditServer := auditserver.NewServer(logger, externalURL, auditDB)
+
 	infoServer := infoserver.NewServer(logger, version)
 
 	handlers := map[string]http.Handler{
@@ -144,6 +152,7 @@ func NewHandler(
 		atc.CreateJobBuild: pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
 		atc.PauseJob:       pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
 		atc.UnpauseJob:     pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
+		atc.ClearJobCaches: pipelineHandlerFactory.HandlerFor(jobServer.ClearJobCaches),
 		atc.JobBadge:       pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
 
 		atc.ListPipelines:   http.HandlerFunc(pipelineServer.ListPipelines),
@@ -152,18 +161,27 @@ func NewHandler(
 		atc.OrderPipelines:  http.HandlerFunc(pipelineServer.OrderPipelines),
 		atc.PausePipeline:   pipelineHandlerFactory.HandlerFor(pipelineServer.PausePipeline),
 		atc.UnpausePipeline: pipelineHandlerFactory.HandlerFor(pipelineServer.UnpausePipeline),
+		atc.ArchivePipeline: pipelineHandlerFactory.HandlerFor(pipelineServer.ArchivePipeline),
 		atc.GetVersionsDB:   pipelineHandlerFactory.HandlerFor(pipelineServer.GetVersionsDB),
 		atc.RenamePipeline:  pipelineHandlerFactory.HandlerFor(pipelineServer.RenamePipeline),
 
-		atc.ListResources:   pipelineHandlerFactory.HandlerFor(resourceServer.ListResources),
-		atc.GetResource:     pipelineHandlerFactory.HandlerFor(resourceServer.GetResource),
-		atc.PauseResource:   pipelineHandlerFactory.HandlerFor(resourceServer.PauseResource),
-		atc.UnpauseResource: pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResource),
-		atc.CheckResource:   pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
+		atc.ListPipelineInstances:    http.HandlerFunc(pipelineServer.ListPipelineInstances),
+		atc.PausePipelineInstances:   http.HandlerFunc(pipelineServer.PausePipelineInstances),
+		atc.UnpausePipelineInstances: http.HandlerFunc(pipelineServer.UnpausePipelineInstances),
+		atc.ArchivePipelineInstances: http.HandlerFunc(pipelineServer.ArchivePipelineInstances),
+
+		atc.ListResources:        pipelineHandlerFactory.HandlerFor(resourceServer.ListResources),
+		atc.GetResource:          pipelineHandlerFactory.HandlerFor(resourceServer.GetResource),
+		atc.PauseResource:        pipelineHandlerFactory.HandlerFor(resourceServer.PauseResource),
+		atc.UnpauseResource:      pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResource),
+		atc.UnpinResource:        pipelineHandlerFactory.HandlerFor(resourceServer.UnpinResource),
+		atc.CheckResource:        pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
+		atc.CheckResourceWebhook: pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceWebhook),
 
 		atc.ListResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions),
 		atc.EnableResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
 		atc.DisableResourceVersion:        pipelineHandlerFactory.HandlerFor(versionServer.DisableResourceVersion),
+		atc.PinResourceVersion:            pipelineHandlerFactory.HandlerFor(versionServer.PinResourceVersion),
 		atc.ListBuildsWithVersionAsInput:  pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsInput),
 		atc.ListBuildsWithVersionAsOutput: pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsOutput),
 
@@ -173,6 +191,9 @@ func NewHandler(
 
 		atc.ListWorkers:    http.HandlerFunc(workerServer.ListWorkers),
 		atc.RegisterWorker: http.HandlerFunc(workerServer.RegisterWorker),
+		atc.LandWorker:     http.HandlerFunc(workerServer.LandWorker),
+		atc.RetireWorker:   http.HandlerFunc(workerServer.RetireWorker),
+		atc.PruneWorker:    http.HandlerFunc(workerServer.PruneWorker),
 
 		atc.SetLogLevel: http.HandlerFunc(logLevelServer.SetMinLevel),
 		atc.GetLogLevel: http.HandlerFunc(logLevelServer.GetMinLevel),
@@ -186,7 +207,17 @@ func NewHandler(
 
 		atc.ListVolumes: http.HandlerFunc(volumesServer.ListVolumes),
 
-		atc.SetTeam: http.HandlerFunc(teamServer.SetTeam),
+		atc.ListTeams:  http.HandlerFunc(teamServer.ListTeams),
+		atc.GetTeam:    http.HandlerFunc(teamServer.GetTeam),
+		atc.SetTeam:    http.HandlerFunc(teamServer.SetTeam),
+		atc.DeleteTeam: http.HandlerFunc(teamServer.DeleteTeam),
+		atc.RenameTeam: http.HandlerFunc(teamServer.RenameTeam),
+
+		atc.ListAPITokens:  http.HandlerFunc(apiTokenServer.ListAPITokens),
+		atc.CreateAPIToken: http.HandlerFunc(apiTokenServer.CreateAPIToken),
+		atc.RevokeAPIToken: http.HandlerFunc(apiTokenServer.RevokeAPIToken),
+
+		atc.ListAuditEvents: http.HandlerFunc(auditServer.ListAuditEvents),
 	}
 
 	results := []http.Handler{}
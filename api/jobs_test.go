@@ -17,6 +17,8 @@ import (
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/scheduler/schedulerfakes"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
 )
 
 var _ = Describe("Jobs API", func() {
@@ -1621,4 +1623,127 @@ var _ = Describe("Jobs API", func() {
 			})
 		})
 	})
+
+	Describe("DELETE /api/v1/pipelines/:pipeline_name/jobs/:job_name/caches", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/pipelines/some-pipeline/jobs/job-name/caches", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			var fakeWorker *workerfakes.FakeWorker
+			var cacheVolume *workerfakes.FakeVolume
+
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+
+				pipelineDB.GetTaskCacheVolumesReturns([]db.SavedVolume{
+					{
+						Volume: db.Volume{
+							Handle:     "some-cache-handle",
+							WorkerName: "some-worker",
+						},
+					},
+				}, nil)
+
+				cacheVolume = new(workerfakes.FakeVolume)
+
+				fakeWorker = new(workerfakes.FakeWorker)
+				fakeWorker.LookupVolumeReturns(cacheVolume, true, nil)
+
+				fakeWorkerClient.GetWorkerReturns(fakeWorker, nil)
+			})
+
+			It("returns 204", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+			})
+
+			It("forgets the job's caches", func() {
+				Expect(pipelineDB.GetTaskCacheVolumesArgsForCall(0)).To(Equal("job-name"))
+				Expect(pipelineDB.ClearTaskCachesCallCount()).To(Equal(1))
+				Expect(pipelineDB.ClearTaskCachesArgsForCall(0)).To(Equal("job-name"))
+			})
+
+			It("releases the cache volumes on their workers", func() {
+				Expect(fakeWorkerClient.GetWorkerArgsForCall(0)).To(Equal("some-worker"))
+
+				_, handle := fakeWorker.LookupVolumeArgsForCall(0)
+				Expect(handle).To(Equal("some-cache-handle"))
+
+				Expect(cacheVolume.ReleaseCallCount()).To(Equal(1))
+				Expect(cacheVolume.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(time.Minute)))
+			})
+
+			It("retires the cache volumes so that builds stop finding them", func() {
+				Expect(cacheVolume.SetPropertyCallCount()).To(Equal(1))
+
+				key, value := cacheVolume.SetPropertyArgsForCall(0)
+				Expect(key).To(Equal("task-cache-path"))
+				Expect(value).To(BeEmpty())
+			})
+
+			Context("when retiring a cache volume fails", func() {
+				BeforeEach(func() {
+					cacheVolume.SetPropertyReturns(errors.New("nope"))
+				})
+
+				It("still forgets and releases the job's caches", func() {
+					Expect(pipelineDB.ClearTaskCachesCallCount()).To(Equal(1))
+					Expect(cacheVolume.ReleaseCallCount()).To(Equal(1))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the worker of a cache cannot be found", func() {
+				BeforeEach(func() {
+					fakeWorkerClient.GetWorkerReturns(nil, errors.New("nope"))
+				})
+
+				It("still forgets the job's caches", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					Expect(pipelineDB.ClearTaskCachesCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when getting the caches fails", func() {
+				BeforeEach(func() {
+					pipelineDB.GetTaskCacheVolumesReturns(nil, errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when clearing the caches fails", func() {
+				BeforeEach(func() {
+					pipelineDB.ClearTaskCachesReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
 })
@@ -0,0 +1,85 @@
+package jobserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker"
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/rata"
+)
+
+const clearedCacheTTL = time.Minute
+
+func (s *Server) ClearJobCaches(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("clear-job-caches")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := rata.Param(r, "job_name")
+
+		savedVolumes, err := pipelineDB.GetTaskCacheVolumes(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-task-cache-volumes", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// look the volumes up before forgetting them, as volumes are only
+		// found on workers while they're in the database
+		volumes := []worker.Volume{}
+		for _, savedVolume := range savedVolumes {
+			vLogger := logger.WithData(lager.Data{
+				"worker-name": savedVolume.WorkerName,
+				"handle":      savedVolume.Handle,
+			})
+
+			volumeWorker, err := s.workerClient.GetWorker(savedVolume.WorkerName)
+			if err != nil {
+				vLogger.Info("could-not-locate-worker", lager.Data{"error": err.Error()})
+				continue
+			}
+
+			volume, found, err := volumeWorker.LookupVolume(vLogger, savedVolume.Handle)
+			if err != nil {
+				vLogger.Error("failed-to-lookup-volume", err)
+				continue
+			}
+
+			if !found {
+				continue
+			}
+
+			volumes = append(volumes, volume)
+		}
+
+		// retire the volumes so that builds stop finding them as the cache
+		// straight away, rather than once they expire
+		retired := true
+		for _, volume := range volumes {
+			err := volume.SetProperty(worker.TaskCachePathProperty, "")
+			if err != nil {
+				logger.Error("failed-to-retire-task-cache", err, lager.Data{"handle": volume.Handle()})
+				retired = false
+			}
+		}
+
+		err = pipelineDB.ClearTaskCaches(jobName)
+
+		for _, volume := range volumes {
+			volume.Release(worker.FinalTTL(clearedCacheTTL))
+		}
+
+		if err != nil {
+			logger.Error("failed-to-clear-task-caches", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !retired {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
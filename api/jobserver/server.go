@@ -3,6 +3,7 @@ package jobserver
 import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/scheduler"
+	"github.com/concourse/atc/worker"
 	"github.com/pivotal-golang/lager"
 )
 
@@ -17,16 +18,19 @@ type Server struct {
 
 	schedulerFactory SchedulerFactory
 	externalURL      string
+	workerClient     worker.Client
 }
 
 func NewServer(
 	logger lager.Logger,
 	schedulerFactory SchedulerFactory,
 	externalURL string,
+	workerClient worker.Client,
 ) *Server {
 	return &Server{
 		logger:           logger,
 		schedulerFactory: schedulerFactory,
 		externalURL:      externalURL,
+		workerClient:     workerClient,
 	}
 }
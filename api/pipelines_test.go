@@ -2,6 +2,7 @@ package api_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"io"
 	"io/ioutil"
@@ -23,7 +24,7 @@ var _ = Describe("Pipelines API", func() {
 		var response *http.Response
 
 		BeforeEach(func() {
-			pipelinesDB.GetAllPipelinesReturns([]db.SavedPipeline{
+			pipelinesDB.GetPipelinesByTeamNameReturns([]db.SavedPipeline{
 				{
 					ID:     1,
 					Paused: false,
@@ -77,6 +78,11 @@ var _ = Describe("Pipelines API", func() {
 			Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
 		})
 
+		It("looks up the pipelines of the default team", func() {
+			Expect(pipelinesDB.GetPipelinesByTeamNameCallCount()).To(Equal(1))
+			Expect(pipelinesDB.GetPipelinesByTeamNameArgsForCall(0)).To(Equal(atc.DefaultTeamName))
+		})
+
 		It("returns all active pipelines", func() {
 			body, err := ioutil.ReadAll(response.Body)
 			Expect(err).NotTo(HaveOccurred())
@@ -107,9 +113,52 @@ var _ = Describe("Pipelines API", func() {
       }]`))
 		})
 
+		Context("when a pipeline is archived", func() {
+			BeforeEach(func() {
+				pipelinesDB.GetPipelinesByTeamNameReturns([]db.SavedPipeline{
+					{
+						ID: 1,
+						Pipeline: db.Pipeline{
+							Name: "a-pipeline",
+						},
+					},
+					{
+						ID:       2,
+						Paused:   true,
+						Archived: true,
+						Pipeline: db.Pipeline{
+							Name: "archived-pipeline",
+						},
+					},
+				}, nil)
+			})
+
+			It("hides it", func() {
+				var pipelines []atc.Pipeline
+				err := json.NewDecoder(response.Body).Decode(&pipelines)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(pipelines).To(HaveLen(1))
+				Expect(pipelines[0].Name).To(Equal("a-pipeline"))
+			})
+
+			It("includes it when archived pipelines are requested", func() {
+				archivedResponse, err := client.Get(server.URL + "/api/v1/pipelines?archived=true")
+				Expect(err).NotTo(HaveOccurred())
+
+				var pipelines []atc.Pipeline
+				err = json.NewDecoder(archivedResponse.Body).Decode(&pipelines)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(pipelines).To(HaveLen(2))
+				Expect(pipelines[1].Name).To(Equal("archived-pipeline"))
+				Expect(pipelines[1].Archived).To(BeTrue())
+			})
+		})
+
 		Context("when the call to get active pipelines fails", func() {
 			BeforeEach(func() {
-				pipelinesDB.GetAllPipelinesReturns(nil, errors.New("disaster"))
+				pipelinesDB.GetPipelinesByTeamNameReturns(nil, errors.New("disaster"))
 			})
 
 			It("returns 500 internal server error", func() {
@@ -120,9 +169,12 @@ var _ = Describe("Pipelines API", func() {
 
 	Describe("GET /api/v1/pipelines/:pipeline_name", func() {
 		var response *http.Response
+		var requestURL string
 
 		BeforeEach(func() {
-			pipelinesDB.GetPipelineByTeamNameAndNameReturns(db.SavedPipeline{
+			requestURL = server.URL + "/api/v1/pipelines/some-specific-pipeline"
+
+			pipelinesDB.GetPipelineInstanceReturns(db.SavedPipeline{
 				ID:     1,
 				Paused: false,
 				Pipeline: db.Pipeline{
@@ -130,7 +182,7 @@ var _ = Describe("Pipelines API", func() {
 				},
 			}, nil)
 
-			configDB.GetConfigReturns(atc.Config{
+			configDB.GetInstanceConfigReturns(atc.Config{
 				Groups: atc.GroupConfigs{
 					{
 						Name:      "group1",
@@ -147,7 +199,7 @@ var _ = Describe("Pipelines API", func() {
 		})
 
 		JustBeforeEach(func() {
-			req, err := http.NewRequest("GET", server.URL+"/api/v1/pipelines/some-specific-pipeline", nil)
+			req, err := http.NewRequest("GET", requestURL, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			req.Header.Set("Content-Type", "application/json")
@@ -190,7 +242,7 @@ var _ = Describe("Pipelines API", func() {
 
 		Context("when the call to get pipeline fails", func() {
 			BeforeEach(func() {
-				pipelinesDB.GetPipelineByTeamNameAndNameReturns(db.SavedPipeline{}, errors.New("disaster"))
+				pipelinesDB.GetPipelineInstanceReturns(db.SavedPipeline{}, errors.New("disaster"))
 			})
 
 			It("returns 500 error", func() {
@@ -200,7 +252,7 @@ var _ = Describe("Pipelines API", func() {
 
 		Context("when the call to get the pipeline config fails", func() {
 			BeforeEach(func() {
-				configDB.GetConfigReturns(atc.Config{}, atc.RawConfig(""), 0, errors.New("disaster"))
+				configDB.GetInstanceConfigReturns(atc.Config{}, atc.RawConfig(""), 0, errors.New("disaster"))
 			})
 
 			It("returns 500 error", func() {
@@ -209,11 +261,55 @@ var _ = Describe("Pipelines API", func() {
 		})
 
 		It("looks up the pipeline in the db via the url param", func() {
-			Expect(pipelinesDB.GetPipelineByTeamNameAndNameCallCount()).To(Equal(1))
+			Expect(pipelinesDB.GetPipelineInstanceCallCount()).To(Equal(1))
 
-			teamName, actualPipelineName := pipelinesDB.GetPipelineByTeamNameAndNameArgsForCall(0)
+			teamName, actualPipelineName, instanceVars := pipelinesDB.GetPipelineInstanceArgsForCall(0)
 			Expect(actualPipelineName).To(Equal("some-specific-pipeline"))
 			Expect(teamName).To(Equal(atc.DefaultTeamName))
+			Expect(instanceVars).To(BeNil())
+		})
+
+		Context("when instance vars are given", func() {
+			BeforeEach(func() {
+				requestURL += "?vars=%7B%22branch%22%3A%22release%2F1.0%22%7D"
+
+				pipelinesDB.GetPipelineInstanceReturns(db.SavedPipeline{
+					ID: 1,
+					Pipeline: db.Pipeline{
+						Name:         "some-specific-pipeline",
+						InstanceVars: atc.InstanceVars{"branch": "release/1.0"},
+					},
+				}, nil)
+			})
+
+			It("looks up the instance", func() {
+				Expect(pipelinesDB.GetPipelineInstanceCallCount()).To(Equal(1))
+				_, _, instanceVars := pipelinesDB.GetPipelineInstanceArgsForCall(0)
+				Expect(instanceVars).To(Equal(atc.InstanceVars{"branch": "release/1.0"}))
+
+				Expect(configDB.GetInstanceConfigCallCount()).To(Equal(1))
+				_, _, instanceVars = configDB.GetInstanceConfigArgsForCall(0)
+				Expect(instanceVars).To(Equal(atc.InstanceVars{"branch": "release/1.0"}))
+			})
+
+			It("returns the instance vars without a web URL", func() {
+				var pipeline atc.Pipeline
+				err := json.NewDecoder(response.Body).Decode(&pipeline)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(pipeline.InstanceVars).To(Equal(atc.InstanceVars{"branch": "release/1.0"}))
+				Expect(pipeline.URL).To(BeEmpty())
+			})
+		})
+
+		Context("when the instance vars are malformed", func() {
+			BeforeEach(func() {
+				requestURL += "?vars=nope"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
 		})
 	})
 
@@ -281,6 +377,117 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			pipelinesDB.GetPipelinesByTeamNameReturns([]db.SavedPipeline{
+				{
+					ID:     1,
+					TeamID: 2,
+					Pipeline: db.Pipeline{
+						Name: "a-team-pipeline",
+					},
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns 200 OK", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("looks up the pipelines of the team in the url", func() {
+			Expect(pipelinesDB.GetPipelinesByTeamNameCallCount()).To(Equal(1))
+			Expect(pipelinesDB.GetPipelinesByTeamNameArgsForCall(0)).To(Equal("a-team"))
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name", func() {
+		var response *http.Response
+		var pipelineDB *dbfakes.FakePipelineDB
+
+		BeforeEach(func() {
+			pipelineDB = new(dbfakes.FakePipelineDB)
+
+			pipelineDBFactory.BuildWithTeamNameAndNameReturns(pipelineDB, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline-name", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as the requested team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 2, false, true)
+			})
+
+			It("returns 204 No Content", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+			})
+
+			It("injects the pipelineDB of the requested team", func() {
+				Expect(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).To(Equal(1))
+				teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+				Expect(teamName).To(Equal("a-team"))
+				Expect(pipelineName).To(Equal("a-pipeline-name"))
+			})
+
+			It("deletes the named pipeline from the database", func() {
+				Expect(pipelineDB.DestroyCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when authenticated as another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("another-team", 3, false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not delete the pipeline", func() {
+				Expect(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).To(BeZero())
+				Expect(pipelineDB.DestroyCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authenticated as an admin team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns(atc.DefaultTeamName, 1, true, true)
+			})
+
+			It("returns 204 No Content", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/pipelines/:pipeline_name/pause", func() {
 		var response *http.Response
 		var pipelineDB *dbfakes.FakePipelineDB
@@ -387,6 +594,16 @@ var _ = Describe("Pipelines API", func() {
 				})
 			})
 
+			Context("when the pipeline is archived", func() {
+				BeforeEach(func() {
+					pipelineDB.UnpauseReturns(db.ErrPipelineArchived)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
 			Context("when unpausing the pipeline fails", func() {
 				BeforeEach(func() {
 					pipelineDB.UnpauseReturns(errors.New("welp"))
@@ -409,6 +626,304 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/pipelines/:pipeline_name/pause with instance vars", func() {
+		var response *http.Response
+		var pipelineDB *dbfakes.FakePipelineDB
+
+		BeforeEach(func() {
+			authValidator.IsAuthenticatedReturns(true)
+
+			pipelineDB = new(dbfakes.FakePipelineDB)
+			pipelineDBFactory.BuildWithTeamNameAndInstanceReturns(pipelineDB, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/pipelines/a-pipeline/pause?vars=%7B%22branch%22%3A%22master%22%7D", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("pauses only that instance", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			Expect(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).To(BeZero())
+			Expect(pipelineDBFactory.BuildWithTeamNameAndInstanceCallCount()).To(Equal(1))
+
+			teamName, pipelineName, instanceVars := pipelineDBFactory.BuildWithTeamNameAndInstanceArgsForCall(0)
+			Expect(teamName).To(Equal(atc.DefaultTeamName))
+			Expect(pipelineName).To(Equal("a-pipeline"))
+			Expect(instanceVars).To(Equal(atc.InstanceVars{"branch": "master"}))
+
+			Expect(pipelineDB.PauseCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("GET /api/v1/pipelines/:pipeline_name/instances", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			pipelinesDB.GetPipelineInstancesReturns([]db.SavedPipeline{
+				{
+					ID:     1,
+					Paused: true,
+					Pipeline: db.Pipeline{
+						Name:         "a-pipeline",
+						InstanceVars: atc.InstanceVars{"branch": "master"},
+					},
+				},
+				{
+					ID: 2,
+					Pipeline: db.Pipeline{
+						Name:         "a-pipeline",
+						InstanceVars: atc.InstanceVars{"branch": "release"},
+					},
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/pipelines/a-pipeline/instances")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns every instance of the pipeline", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(body).To(MatchJSON(`[
+				{
+					"name": "a-pipeline",
+					"instance_vars": {"branch": "master"},
+					"paused": true
+				},
+				{
+					"name": "a-pipeline",
+					"instance_vars": {"branch": "release"},
+					"paused": false
+				}
+			]`))
+		})
+
+		It("looks up the instances by team and name", func() {
+			teamName, pipelineName := pipelinesDB.GetPipelineInstancesArgsForCall(0)
+			Expect(teamName).To(Equal(atc.DefaultTeamName))
+			Expect(pipelineName).To(Equal("a-pipeline"))
+		})
+
+		Context("when there are no instances", func() {
+			BeforeEach(func() {
+				pipelinesDB.GetPipelineInstancesReturns([]db.SavedPipeline{}, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when getting the instances fails", func() {
+			BeforeEach(func() {
+				pipelinesDB.GetPipelineInstancesReturns(nil, errors.New("disaster"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/pipelines/:pipeline_name/instances/pause", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/pipelines/a-pipeline/instances/pause", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			It("pauses every instance of the pipeline", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				Expect(pipelinesDB.PausePipelineInstancesCallCount()).To(Equal(1))
+				teamName, pipelineName := pipelinesDB.PausePipelineInstancesArgsForCall(0)
+				Expect(teamName).To(Equal(atc.DefaultTeamName))
+				Expect(pipelineName).To(Equal("a-pipeline"))
+			})
+
+			Context("when pausing fails", func() {
+				BeforeEach(func() {
+					pipelinesDB.PausePipelineInstancesReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/pipelines/:pipeline_name/instances/unpause", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/pipelines/a-pipeline/instances/unpause", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			It("unpauses every instance of the pipeline", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				Expect(pipelinesDB.UnpausePipelineInstancesCallCount()).To(Equal(1))
+				teamName, pipelineName := pipelinesDB.UnpausePipelineInstancesArgsForCall(0)
+				Expect(teamName).To(Equal(atc.DefaultTeamName))
+				Expect(pipelineName).To(Equal("a-pipeline"))
+			})
+
+			Context("when unpausing fails", func() {
+				BeforeEach(func() {
+					pipelinesDB.UnpausePipelineInstancesReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/pipelines/:pipeline_name/archive", func() {
+		var response *http.Response
+		var pipelineDB *dbfakes.FakePipelineDB
+
+		BeforeEach(func() {
+			pipelineDB = new(dbfakes.FakePipelineDB)
+
+			pipelineDBFactory.BuildWithTeamNameAndNameReturns(pipelineDB, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/pipelines/a-pipeline/archive", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			It("injects the proper pipelineDB", func() {
+				Expect(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).To(Equal(1))
+				teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+				Expect(pipelineName).To(Equal("a-pipeline"))
+				Expect(teamName).To(Equal(atc.DefaultTeamName))
+			})
+
+			It("archives the pipeline rather than destroying it", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				Expect(pipelineDB.ArchiveCallCount()).To(Equal(1))
+				Expect(pipelineDB.DestroyCallCount()).To(BeZero())
+			})
+
+			Context("when archiving the pipeline fails", func() {
+				BeforeEach(func() {
+					pipelineDB.ArchiveReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/pipelines/:pipeline_name/instances/archive", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			authValidator.IsAuthenticatedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/pipelines/a-pipeline/instances/archive", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("archives every instance of the pipeline", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			Expect(pipelinesDB.ArchivePipelineInstancesCallCount()).To(Equal(1))
+			teamName, pipelineName := pipelinesDB.ArchivePipelineInstancesArgsForCall(0)
+			Expect(teamName).To(Equal(atc.DefaultTeamName))
+			Expect(pipelineName).To(Equal("a-pipeline"))
+		})
+
+		Context("when archiving fails", func() {
+			BeforeEach(func() {
+				pipelinesDB.ArchivePipelineInstancesReturns(errors.New("welp"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/pipelines/ordering", func() {
 		var response *http.Response
 		var body io.Reader
@@ -457,7 +972,8 @@ var _ = Describe("Pipelines API", func() {
 
 				It("orders the pipelines", func() {
 					Expect(pipelinesDB.OrderPipelinesCallCount()).To(Equal(1))
-					pipelineNames := pipelinesDB.OrderPipelinesArgsForCall(0)
+					teamName, pipelineNames := pipelinesDB.OrderPipelinesArgsForCall(0)
+					Expect(teamName).To(Equal(atc.DefaultTeamName))
 					Expect(pipelineNames).To(Equal(
 						[]string{
 							"a-pipeline",
@@ -0,0 +1,38 @@
+package pipelineserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+)
+
+func (s *Server) ArchivePipeline(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("archive-pipeline")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := pipelineDB.Archive()
+		if err != nil {
+			logger.Error("failed-to-archive-pipeline", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func (s *Server) ArchivePipelineInstances(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("archive-pipeline-instances")
+	pipelineName := r.FormValue(":pipeline_name")
+	teamName := auth.GetRequestedTeamName(r)
+
+	err := s.pipelinesDB.ArchivePipelineInstances(teamName, pipelineName)
+	if err != nil {
+		logger.Error("failed-to-archive-pipeline-instances", err, lager.Data{"pipeline": pipelineName})
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
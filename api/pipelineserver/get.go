@@ -6,19 +6,28 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
 )
 
 func (s *Server) GetPipeline(w http.ResponseWriter, r *http.Request) {
 	pipelineName := r.FormValue(":pipeline_name")
+	teamName := auth.GetRequestedTeamName(r)
 
-	pipeline, err := s.pipelinesDB.GetPipelineByTeamNameAndName(atc.DefaultTeamName, pipelineName)
+	instanceVars, err := atc.ParseInstanceVars(r.URL.Query().Get(atc.InstanceVarsQueryParam))
+	if err != nil {
+		s.logger.Error("malformed-instance-vars", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	pipeline, err := s.pipelinesDB.GetPipelineInstance(teamName, pipelineName, instanceVars)
 	if err != nil {
 		s.logger.Error("call-to-get-pipeline-failed", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	config, _, _, err := s.configDB.GetConfig(atc.DefaultTeamName, pipelineName)
+	config, _, _, err := s.configDB.GetInstanceConfig(teamName, pipelineName, instanceVars)
 	if err != nil {
 		s.logger.Error("call-to-get-pipeline-config-failed", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,74 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
+	"github.com/pivotal-golang/lager"
+)
+
+func (s *Server) ListPipelineInstances(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-pipeline-instances")
+	pipelineName := r.FormValue(":pipeline_name")
+	teamName := auth.GetRequestedTeamName(r)
+
+	pipelines, err := s.pipelinesDB.GetPipelineInstances(teamName, pipelineName)
+	if err != nil {
+		logger.Error("failed-to-get-pipeline-instances", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if len(pipelines) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	includeArchived := r.FormValue("archived") == "true"
+
+	presentedPipelines := []atc.Pipeline{}
+	for _, pipeline := range pipelines {
+		if pipeline.Archived && !includeArchived {
+			continue
+		}
+
+		presentedPipelines = append(presentedPipelines, present.Pipeline(pipeline, pipeline.Config))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	json.NewEncoder(w).Encode(presentedPipelines)
+}
+
+func (s *Server) PausePipelineInstances(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("pause-pipeline-instances")
+	pipelineName := r.FormValue(":pipeline_name")
+	teamName := auth.GetRequestedTeamName(r)
+
+	err := s.pipelinesDB.PausePipelineInstances(teamName, pipelineName)
+	if err != nil {
+		logger.Error("failed-to-pause-pipeline-instances", err, lager.Data{"pipeline": pipelineName})
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) UnpausePipelineInstances(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("unpause-pipeline-instances")
+	pipelineName := r.FormValue(":pipeline_name")
+	teamName := auth.GetRequestedTeamName(r)
+
+	err := s.pipelinesDB.UnpausePipelineInstances(teamName, pipelineName)
+	if err != nil {
+		logger.Error("failed-to-unpause-pipeline-instances", err, lager.Data{"pipeline": pipelineName})
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -6,22 +6,29 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
 )
 
 func (s *Server) ListPipelines(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.Session("list-pipelines")
+	teamName := auth.GetRequestedTeamName(r)
 
-	pipelines, err := s.pipelinesDB.GetAllPipelines()
+	pipelines, err := s.pipelinesDB.GetPipelinesByTeamName(teamName)
 	if err != nil {
-		logger.Error("failed-to-get-all-active-pipelines", err)
+		logger.Error("failed-to-get-team-pipelines", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	presentedPipelines := make([]atc.Pipeline, len(pipelines))
-	for i := 0; i < len(pipelines); i++ {
-		pipeline := pipelines[i]
-		presentedPipelines[i] = present.Pipeline(pipeline, pipeline.Config)
+	includeArchived := r.FormValue("archived") == "true"
+
+	presentedPipelines := []atc.Pipeline{}
+	for _, pipeline := range pipelines {
+		if pipeline.Archived && !includeArchived {
+			continue
+		}
+
+		presentedPipelines = append(presentedPipelines, present.Pipeline(pipeline, pipeline.Config))
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/concourse/atc/auth"
 	"github.com/pivotal-golang/lager"
 )
 
@@ -17,9 +18,12 @@ func (s *Server) OrderPipelines(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := s.pipelinesDB.OrderPipelines(pipelineNames)
+	teamName := auth.GetRequestedTeamName(r)
+
+	err := s.pipelinesDB.OrderPipelines(teamName, pipelineNames)
 	if err != nil {
 		s.logger.Error("failed-to-order-pipelines", err, lager.Data{
+			"team-name":      teamName,
 			"pipeline-names": pipelineNames,
 		})
 
@@ -10,6 +10,11 @@ func (s *Server) UnpausePipeline(pipelineDB db.PipelineDB) http.Handler {
 	logger := s.logger.Session("unpause-pipeline")
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		err := pipelineDB.Unpause()
+		if err == db.ErrPipelineArchived {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
 		if err != nil {
 			logger.Error("failed-to-unpause-pipeline", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,28 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func APIToken(savedToken db.SavedAPIToken) atc.APIToken {
+	token := atc.APIToken{
+		Name:     savedToken.Name,
+		TeamName: savedToken.TeamName,
+		Role:     savedToken.Role,
+	}
+
+	if !savedToken.CreatedAt.IsZero() {
+		token.CreatedAt = savedToken.CreatedAt.Unix()
+	}
+
+	if !savedToken.ExpiresAt.IsZero() {
+		token.ExpiresAt = savedToken.ExpiresAt.Unix()
+	}
+
+	if !savedToken.LastUsedAt.IsZero() {
+		token.LastUsedAt = savedToken.LastUsedAt.Unix()
+	}
+
+	return token
+}
@@ -0,0 +1,21 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func AuditEvent(savedEvent db.SavedAuditEvent) atc.AuditEvent {
+	return atc.AuditEvent{
+		ID:           savedEvent.ID,
+		CreatedAt:    savedEvent.CreatedAt.Unix(),
+		TeamName:     savedEvent.TeamName,
+		TeamID:       savedEvent.TeamID,
+		UserName:     savedEvent.UserName,
+		Route:        savedEvent.Route,
+		PipelineName: savedEvent.PipelineName,
+		JobName:      savedEvent.JobName,
+		ResourceName: savedEvent.ResourceName,
+		Status:       savedEvent.Status,
+	}
+}
@@ -20,5 +20,6 @@ func BuildPreparation(preparation db.BuildPreparation) atc.BuildPreparation {
 		Inputs:              inputs,
 		InputsSatisfied:     atc.BuildPreparationStatus(preparation.InputsSatisfied),
 		MissingInputReasons: atc.MissingInputReasons(preparation.MissingInputReasons),
+		WorkersAvailable:    atc.BuildPreparationStatus(preparation.WorkersAvailable),
 	}
 }
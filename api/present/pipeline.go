@@ -8,18 +8,27 @@ import (
 )
 
 func Pipeline(savedPipeline db.SavedPipeline, config atc.Config) atc.Pipeline {
-	pathForRoute, err := web.Routes.CreatePathForRoute(web.Pipeline, rata.Params{
-		"pipeline": savedPipeline.Name,
-	})
+	var pathForRoute string
 
-	if err != nil {
-		panic("failed to generate url: " + err.Error())
+	// the web UI cannot address a single instance of a pipeline, so instances
+	// have no URL rather than one showing a different pipeline
+	if len(savedPipeline.InstanceVars) == 0 {
+		var err error
+		pathForRoute, err = web.Routes.CreatePathForRoute(web.Pipeline, rata.Params{
+			"pipeline": savedPipeline.Name,
+		})
+
+		if err != nil {
+			panic("failed to generate url: " + err.Error())
+		}
 	}
 
 	return atc.Pipeline{
-		Name:   savedPipeline.Name,
-		URL:    pathForRoute,
-		Paused: savedPipeline.Paused,
-		Groups: config.Groups,
+		Name:         savedPipeline.Name,
+		InstanceVars: savedPipeline.InstanceVars,
+		URL:          pathForRoute,
+		Paused:       savedPipeline.Paused,
+		Archived:     savedPipeline.Archived,
+		Groups:       config.Groups,
 	}
 }
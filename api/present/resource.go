@@ -41,6 +41,10 @@ func Resource(resource atc.ResourceConfig, groups atc.GroupConfigs, dbResource d
 
 		Paused: dbResource.Paused,
 
+		PinnedVersionID: dbResource.PinnedVersionID,
+		PinComment:      dbResource.PinComment,
+		PinnedBy:        dbResource.PinnedBy,
+
 		FailingToCheck: dbResource.FailingToCheck(),
 		CheckError:     checkErrString,
 	}
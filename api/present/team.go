@@ -6,8 +6,55 @@ import (
 )
 
 func Team(savedTeam db.SavedTeam) atc.Team {
+	var gitHubTeams []atc.GitHubTeam
+	for _, team := range savedTeam.GitHubAuth.Teams {
+		gitHubTeams = append(gitHubTeams, atc.GitHubTeam{
+			OrganizationName: team.OrganizationName,
+			TeamName:         team.TeamName,
+		})
+	}
+
+	var gitLabAuth *atc.GitLabAuth
+	if savedTeam.GitLabAuth.ClientID != "" {
+		gitLabAuth = &atc.GitLabAuth{
+			ClientID: savedTeam.GitLabAuth.ClientID,
+			Groups:   savedTeam.GitLabAuth.Groups,
+			Projects: savedTeam.GitLabAuth.Projects,
+			Users:    savedTeam.GitLabAuth.Users,
+			URL:      savedTeam.GitLabAuth.URL,
+		}
+	}
+
+	var oidcAuth *atc.OIDCAuth
+	if savedTeam.OIDCAuth.Issuer != "" {
+		oidcAuth = &atc.OIDCAuth{
+			DisplayName: savedTeam.OIDCAuth.DisplayName,
+			Issuer:      savedTeam.OIDCAuth.Issuer,
+			ClientID:    savedTeam.OIDCAuth.ClientID,
+			Scopes:      savedTeam.OIDCAuth.Scopes,
+			GroupsClaim: savedTeam.OIDCAuth.GroupsClaim,
+			Groups:      savedTeam.OIDCAuth.Groups,
+			Users:       savedTeam.OIDCAuth.Users,
+		}
+	}
+
 	return atc.Team{
 		ID:   savedTeam.ID,
 		Name: savedTeam.Name,
+		BasicAuth: atc.BasicAuth{
+			BasicAuthUsername: savedTeam.BasicAuthUsername,
+		},
+		GitHubAuth: atc.GitHubAuth{
+			ClientID:      savedTeam.GitHubAuth.ClientID,
+			Organizations: savedTeam.GitHubAuth.Organizations,
+			Teams:         gitHubTeams,
+			Users:         savedTeam.GitHubAuth.Users,
+			AuthURL:       savedTeam.GitHubAuth.AuthURL,
+			TokenURL:      savedTeam.GitHubAuth.TokenURL,
+			APIURL:        savedTeam.GitHubAuth.APIURL,
+		},
+		GitLabAuth: gitLabAuth,
+		OIDCAuth:   oidcAuth,
+		Roles:      savedTeam.Roles,
 	}
 }
@@ -1,21 +1,43 @@
 package present
 
 import (
+	"time"
+
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 )
 
-func Worker(workerInfo db.WorkerInfo) atc.Worker {
-	return atc.Worker{
-		GardenAddr:       workerInfo.GardenAddr,
-		BaggageclaimURL:  workerInfo.BaggageclaimURL,
-		HTTPProxyURL:     workerInfo.HTTPProxyURL,
-		HTTPSProxyURL:    workerInfo.HTTPSProxyURL,
-		NoProxy:          workerInfo.NoProxy,
-		ActiveContainers: workerInfo.ActiveContainers,
-		ResourceTypes:    workerInfo.ResourceTypes,
-		Platform:         workerInfo.Platform,
-		Tags:             workerInfo.Tags,
-		Name:             workerInfo.Name,
+func Worker(savedWorker db.SavedWorker) atc.Worker {
+	worker := atc.Worker{
+		GardenAddr:       savedWorker.GardenAddr,
+		BaggageclaimURL:  savedWorker.BaggageclaimURL,
+		HTTPProxyURL:     savedWorker.HTTPProxyURL,
+		HTTPSProxyURL:    savedWorker.HTTPSProxyURL,
+		NoProxy:          savedWorker.NoProxy,
+		ActiveContainers: savedWorker.ActiveContainers,
+		MaxContainers:    savedWorker.MaxContainers,
+		MemoryCapacity:   savedWorker.MemoryCapacity,
+		CPUCapacity:      savedWorker.CPUCapacity,
+		ResourceTypes:    savedWorker.ResourceTypes,
+		Platform:         savedWorker.Platform,
+		Tags:             savedWorker.Tags,
+		Name:             savedWorker.Name,
+		State:            string(savedWorker.State),
+		Team:             savedWorker.TeamName,
+	}
+
+	health := savedWorker.Health
+	if !health.CheckedAt.IsZero() {
+		worker.Health = &atc.WorkerHealth{
+			Healthy:               health.Healthy(),
+			CheckedAt:             health.CheckedAt.Unix(),
+			GardenLatencyMS:       int64(health.GardenLatency / time.Millisecond),
+			BaggageclaimLatencyMS: int64(health.BaggageclaimLatency / time.Millisecond),
+			GardenError:           health.GardenError,
+			BaggageclaimError:     health.BaggageclaimError,
+			DiskInBytes:           health.DiskInBytes,
+		}
 	}
+
+	return worker
 }
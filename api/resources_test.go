@@ -519,6 +519,76 @@ var _ = Describe("Resources API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/pipelines/:pipeline_name/resources/:resource_name/unpin", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakePipelineDB.GetResourceReturns(db.SavedResource{
+				Resource: db.Resource{
+					Name: "resource-name",
+				},
+				PinnedVersionID: 42,
+			}, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/pipelines/a-pipeline/resources/resource-name/unpin", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			Context("when unpinning the resource succeeds", func() {
+				It("unpins the right resource", func() {
+					Expect(fakePipelineDB.UnpinResourceCallCount()).To(Equal(1))
+					Expect(fakePipelineDB.UnpinResourceArgsForCall(0)).To(Equal("resource-name"))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when resource can not be found", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetResourceReturns(db.SavedResource{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when unpinning the resource fails", func() {
+				BeforeEach(func() {
+					fakePipelineDB.UnpinResourceReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/pipelines/:pipeline_name/resources/:resource_name/check", func() {
 		var fakeScanner *radarfakes.FakeScanner
 		var checkRequestBody atc.CheckRequestBody
@@ -689,4 +759,130 @@ var _ = Describe("Resources API", func() {
 			})
 		})
 	})
+
+	Describe("POST /api/v1/pipelines/:pipeline_name/resources/:resource_name/check/webhook", func() {
+		var fakeScanner *radarfakes.FakeScanner
+		var webhookToken string
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakeScanner = new(radarfakes.FakeScanner)
+			fakeScannerFactory.NewResourceScannerReturns(fakeScanner)
+
+			webhookToken = "some-webhook-token"
+
+			fakePipelineDB.GetConfigReturns(atc.Config{
+				Resources: []atc.ResourceConfig{
+					{
+						Name:         "resource-name",
+						WebhookToken: "some-webhook-token",
+					},
+					{
+						Name: "resource-without-webhook",
+					},
+				},
+			}, 1, true, nil)
+
+			authValidator.IsAuthenticatedReturns(false)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/pipelines/a-pipeline/resources/resource-name/check/webhook?webhook_token="+webhookToken, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("injects the proper pipelineDB", func() {
+			Expect(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).To(Equal(1))
+			teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+			Expect(pipelineName).To(Equal("a-pipeline"))
+			Expect(teamName).To(Equal(atc.DefaultTeamName))
+		})
+
+		It("scans the resource without requiring authentication", func() {
+			Expect(fakeScanner.ScanCallCount()).To(Equal(1))
+			_, actualResourceName := fakeScanner.ScanArgsForCall(0)
+			Expect(actualResourceName).To(Equal("resource-name"))
+		})
+
+		It("returns 200", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		Context("when the webhook token is wrong", func() {
+			BeforeEach(func() {
+				webhookToken = "some-other-token"
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not scan", func() {
+				Expect(fakeScanner.ScanCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the resource has no webhook token configured", func() {
+			BeforeEach(func() {
+				fakePipelineDB.GetConfigReturns(atc.Config{
+					Resources: []atc.ResourceConfig{
+						{Name: "resource-name"},
+					},
+				}, 1, true, nil)
+
+				webhookToken = ""
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not scan", func() {
+				Expect(fakeScanner.ScanCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the resource is not in the config", func() {
+			BeforeEach(func() {
+				fakePipelineDB.GetConfigReturns(atc.Config{}, 1, true, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when getting the config fails", func() {
+			BeforeEach(func() {
+				fakePipelineDB.GetConfigReturns(atc.Config{}, 0, false, errors.New("disaster"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when scanning fails with ResourceNotFoundError", func() {
+			BeforeEach(func() {
+				fakeScanner.ScanReturns(db.ResourceNotFoundError{})
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when scanning fails internally", func() {
+			BeforeEach(func() {
+				fakeScanner.ScanReturns(errors.New("welp"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
 })
@@ -0,0 +1,59 @@
+package resourceserver
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) CheckResourceWebhook(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("check-resource-webhook")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+		webhookToken := r.URL.Query().Get("webhook_token")
+
+		config, _, found, err := pipelineDB.GetConfig()
+		if err != nil {
+			logger.Error("failed-to-get-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("config-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		resourceConfig, found := config.Resources.Lookup(resourceName)
+		if !found {
+			logger.Info("resource-not-in-config", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if resourceConfig.WebhookToken == "" ||
+			subtle.ConstantTimeCompare([]byte(resourceConfig.WebhookToken), []byte(webhookToken)) != 1 {
+			logger.Info("invalid-webhook-token", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		scanner := s.scannerFactory.NewResourceScanner(pipelineDB)
+
+		err = scanner.Scan(logger, resourceName)
+		switch err.(type) {
+		case db.ResourceNotFoundError:
+			w.WriteHeader(http.StatusNotFound)
+		case error:
+			logger.Error("failed-to-scan", err, lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package resourceserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) UnpinResource(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("unpin-resource")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+
+		_, found, err := pipelineDB.GetResource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("resource-not-found", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		err = pipelineDB.UnpinResource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-unpin-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,51 @@
+package versionserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) PinResourceVersion(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("pin-resource-version")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+
+		versionID, err := strconv.Atoi(rata.Param(r, "resource_version_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var request atc.PinVersionRequest
+		err = json.NewDecoder(r.Body).Decode(&request)
+		if err != nil && err != io.EOF {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		pinnedBy, _ := auth.GetUser(r)
+
+		err = pipelineDB.PinResourceVersion(resourceName, versionID, request.Comment, pinnedBy)
+		if err == db.ErrVersionNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err != nil {
+			logger.Error("failed-to-pin-resource-version", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -213,6 +213,161 @@ var _ = Describe("Auth API", func() {
 				})
 			})
 
+			Describe("GitLab authentication", func() {
+				Context("ClientSecret not filled in", func() {
+					BeforeEach(func() {
+						team = atc.Team{
+							GitLabAuth: &atc.GitLabAuth{
+								ClientID: "Brock Samson",
+								Groups:   []string{"osi"},
+							},
+						}
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when no groups, projects, or users are given", func() {
+					BeforeEach(func() {
+						team = atc.Team{
+							GitLabAuth: &atc.GitLabAuth{
+								ClientID:     "Brock Samson",
+								ClientSecret: "09262-8765-001",
+							},
+						}
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when passed projects", func() {
+					BeforeEach(func() {
+						team = atc.Team{
+							GitLabAuth: &atc.GitLabAuth{
+								ClientID:     "Brock Samson",
+								ClientSecret: "09262-8765-001",
+								Projects:     []string{"osi/sphinx"},
+								URL:          "https://gitlab.venture.com",
+							},
+						}
+					})
+
+					It("saves the GitLab auth", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+						Expect(teamDB.SaveTeamCallCount()).To(Equal(1))
+						savedTeam := teamDB.SaveTeamArgsForCall(0)
+						Expect(savedTeam.GitLabAuth).To(Equal(db.GitLabAuth{
+							ClientID:     "Brock Samson",
+							ClientSecret: "09262-8765-001",
+							Projects:     []string{"osi/sphinx"},
+							URL:          "https://gitlab.venture.com",
+						}))
+					})
+				})
+			})
+
+			Describe("OIDC authentication", func() {
+				Context("ClientSecret not filled in", func() {
+					BeforeEach(func() {
+						team = atc.Team{
+							OIDCAuth: &atc.OIDCAuth{
+								Issuer:   "https://accounts.venture.com",
+								ClientID: "Brock Samson",
+								Groups:   []string{"O.S.I."},
+							},
+						}
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when no groups or users are given", func() {
+					BeforeEach(func() {
+						team = atc.Team{
+							OIDCAuth: &atc.OIDCAuth{
+								Issuer:       "https://accounts.venture.com",
+								ClientID:     "Brock Samson",
+								ClientSecret: "09262-8765-001",
+							},
+						}
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when passed groups", func() {
+					BeforeEach(func() {
+						team = atc.Team{
+							OIDCAuth: &atc.OIDCAuth{
+								Issuer:       "https://accounts.venture.com",
+								ClientID:     "Brock Samson",
+								ClientSecret: "09262-8765-001",
+								Groups:       []string{"O.S.I."},
+							},
+						}
+					})
+
+					It("does not error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					})
+
+					It("saves the OIDC auth", func() {
+						Expect(teamDB.SaveTeamCallCount()).To(Equal(1))
+						savedTeam := teamDB.SaveTeamArgsForCall(0)
+						Expect(savedTeam.OIDCAuth).To(Equal(db.OIDCAuth{
+							Issuer:       "https://accounts.venture.com",
+							ClientID:     "Brock Samson",
+							ClientSecret: "09262-8765-001",
+							Groups:       []string{"O.S.I."},
+						}))
+					})
+				})
+			})
+
+			Describe("roles", func() {
+				Context("when a role is not recognized", func() {
+					BeforeEach(func() {
+						team = atc.Team{
+							Roles: map[string]string{"github:hank": "henchman"},
+						}
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when all roles are valid", func() {
+					BeforeEach(func() {
+						team = atc.Team{
+							Roles: map[string]string{
+								"github:hank": atc.RoleViewer,
+								"github:dean": atc.RoleMember,
+							},
+						}
+					})
+
+					It("saves the roles", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+						Expect(teamDB.SaveTeamCallCount()).To(Equal(1))
+						savedTeam := teamDB.SaveTeamArgsForCall(0)
+						Expect(savedTeam.Roles).To(Equal(map[string]string{
+							"github:hank": atc.RoleViewer,
+							"github:dean": atc.RoleMember,
+						}))
+					})
+				})
+			})
+
 			Context("when there's a problem finding teams", func() {
 				BeforeEach(func() {
 					teamDB.GetTeamByNameReturns(db.SavedTeam{}, false, errors.New("a dingo ate my baby!"))
@@ -299,6 +454,43 @@ var _ = Describe("Auth API", func() {
 							Expect(teamDB.UpdateTeamGitHubAuthCallCount()).To(Equal(1))
 						})
 					})
+
+					Context("when passed OIDC auth credentials", func() {
+						BeforeEach(func() {
+							teamDB.UpdateTeamOIDCAuthStub = func(submittedTeam db.Team) (db.SavedTeam, error) {
+								Expect(submittedTeam.Name).To(Equal(teamName))
+								Expect(submittedTeam.OIDCAuth.ClientSecret).To(Equal("Giant Boy Detective"))
+								savedTeam.Team = submittedTeam
+								return savedTeam, nil
+							}
+
+							team.OIDCAuth = &atc.OIDCAuth{
+								Issuer:       "https://accounts.venture.com",
+								ClientID:     "Dean Venture",
+								ClientSecret: "Giant Boy Detective",
+								Users:        []string{"dean@venture.com"},
+							}
+						})
+
+						It("updates the OIDC auth for that team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(teamDB.UpdateTeamOIDCAuthCallCount()).To(Equal(1))
+						})
+					})
+				})
+
+				Context("when passed roles", func() {
+					BeforeEach(func() {
+						team.Roles = map[string]string{"github:hank": atc.RoleViewer}
+					})
+
+					It("updates the roles for that team", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(teamDB.UpdateTeamRolesCallCount()).To(Equal(1))
+						Expect(teamDB.UpdateTeamRolesArgsForCall(0).Roles).To(Equal(map[string]string{
+							"github:hank": atc.RoleViewer,
+						}))
+					})
 				})
 			})
 
@@ -395,6 +587,49 @@ var _ = Describe("Auth API", func() {
 			})
 		})
 
+		Context("when the requester is an owner of the team being updated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns(teamName, 2, false, true)
+				userContextReader.GetRoleReturns(atc.RoleOwner, true)
+			})
+
+			Context("when the team exists", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamByNameReturns(savedTeam, true, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamByNameReturns(db.SavedTeam{}, false, nil)
+				})
+
+				It("returns 403 forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(teamDB.SaveTeamCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the requester is a member of the team being updated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns(teamName, 2, false, true)
+				userContextReader.GetRoleReturns(atc.RoleMember, true)
+				teamDB.GetTeamByNameReturns(savedTeam, true, nil)
+			})
+
+			It("returns 403 forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(teamDB.UpdateTeamRolesCallCount()).To(BeZero())
+			})
+		})
+
 		Context("when the requester's team cannot be determined", func() {
 			BeforeEach(func() {
 				authValidator.IsAuthenticatedReturns(true)
@@ -406,4 +641,480 @@ var _ = Describe("Auth API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			teamDB.GetTeamsReturns([]db.SavedTeam{
+				{
+					ID: 1,
+					Team: db.Team{
+						Name:  atc.DefaultTeamName,
+						Admin: true,
+					},
+				},
+				{
+					ID: 2,
+					Team: db.Team{
+						Name: "team venture",
+						BasicAuth: db.BasicAuth{
+							BasicAuthUsername: "Dean Venture",
+							BasicAuthPassword: "Giant Boy Detective",
+						},
+						GitHubAuth: db.GitHubAuth{
+							ClientID:     "Dean Venture",
+							ClientSecret: "Giant Boy Detective",
+							Users:        []string{"Dean Venture"},
+						},
+					},
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the requester is an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns(atc.DefaultTeamName, 1, true, true)
+			})
+
+			It("returns 200 OK", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns all teams without their secrets", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"id": 1,
+						"name": "main"
+					},
+					{
+						"id": 2,
+						"name": "team venture",
+						"basic_auth_username": "Dean Venture",
+						"client_id": "Dean Venture",
+						"users": ["Dean Venture"]
+					}
+				]`))
+			})
+
+			Context("when getting the teams fails", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamsReturns(nil, errors.New("disaster"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the requester belongs to a non-admin team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("team venture", 2, false, true)
+			})
+
+			It("returns only the requester's team", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"id": 2,
+						"name": "team venture",
+						"basic_auth_username": "Dean Venture",
+						"client_id": "Dean Venture",
+						"users": ["Dean Venture"]
+					}
+				]`))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/team-venture")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the requester belongs to the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("team-venture", 2, false, true)
+			})
+
+			Context("when the team exists", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamByNameReturns(db.SavedTeam{
+						ID: 2,
+						Team: db.Team{
+							Name: "team-venture",
+							BasicAuth: db.BasicAuth{
+								BasicAuthUsername: "Dean Venture",
+								BasicAuthPassword: "Giant Boy Detective",
+							},
+							OIDCAuth: db.OIDCAuth{
+								Issuer:       "https://accounts.venture.com",
+								ClientID:     "Dean Venture",
+								ClientSecret: "Giant Boy Detective",
+								Users:        []string{"dean@venture.com"},
+							},
+						},
+					}, true, nil)
+				})
+
+				It("looks up the requested team", func() {
+					Expect(teamDB.GetTeamByNameCallCount()).To(Equal(1))
+					Expect(teamDB.GetTeamByNameArgsForCall(0)).To(Equal("team-venture"))
+				})
+
+				It("returns the team without its secrets", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"id": 2,
+						"name": "team-venture",
+						"basic_auth_username": "Dean Venture",
+						"oidc_auth": {
+							"issuer": "https://accounts.venture.com",
+							"client_id": "Dean Venture",
+							"users": ["dean@venture.com"]
+						}
+					}`))
+				})
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamByNameReturns(db.SavedTeam{}, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the team fails", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamByNameReturns(db.SavedTeam{}, false, errors.New("disaster"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the requester belongs to another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("other-team", 3, false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name", func() {
+		var teamName string
+		var response *http.Response
+
+		BeforeEach(func() {
+			teamName = "team-venture"
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/"+teamName, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the requester is an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns(atc.DefaultTeamName, 1, true, true)
+			})
+
+			Context("when the team exists", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamByNameReturns(db.SavedTeam{ID: 2, Team: db.Team{Name: "team-venture"}}, true, nil)
+				})
+
+				It("deletes the team", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+					Expect(teamDB.DeleteTeamByNameCallCount()).To(Equal(1))
+					Expect(teamDB.DeleteTeamByNameArgsForCall(0)).To(Equal(teamName))
+				})
+
+				Context("when the name differs from the team's only in case", func() {
+					BeforeEach(func() {
+						teamName = "TEAM-venture"
+					})
+
+					It("deletes the team that was found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+						Expect(teamDB.DeleteTeamByNameCallCount()).To(Equal(1))
+						Expect(teamDB.DeleteTeamByNameArgsForCall(0)).To(Equal("team-venture"))
+					})
+				})
+
+				Context("when deleting the team fails", func() {
+					BeforeEach(func() {
+						teamDB.DeleteTeamByNameReturns(errors.New("disaster"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamByNameReturns(db.SavedTeam{}, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					Expect(teamDB.DeleteTeamByNameCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when deleting the default team", func() {
+				BeforeEach(func() {
+					teamName = atc.DefaultTeamName
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(teamDB.DeleteTeamByNameCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when deleting the default team with a differently-cased name", func() {
+				BeforeEach(func() {
+					teamName = "MAIN"
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(teamDB.DeleteTeamByNameCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the name resolves to the default team", func() {
+				BeforeEach(func() {
+					teamName = "m_in"
+					teamDB.GetTeamByNameReturns(db.SavedTeam{ID: 1, Team: db.Team{Name: atc.DefaultTeamName}}, true, nil)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(teamDB.DeleteTeamByNameCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when the requester belongs to a non-admin team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns(teamName, 2, false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(teamDB.DeleteTeamByNameCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the requester's team cannot be determined", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("", 0, false, false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(teamDB.DeleteTeamByNameCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(teamDB.DeleteTeamByNameCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/rename", func() {
+		var teamName string
+		var response *http.Response
+
+		BeforeEach(func() {
+			teamName = "team-venture"
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest(
+				"PUT",
+				server.URL+"/api/v1/teams/"+teamName+"/rename",
+				bytes.NewBufferString(`{"name":"venture-industries"}`),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the requester is an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns(atc.DefaultTeamName, 1, true, true)
+
+				teamDB.GetTeamByNameReturns(db.SavedTeam{}, false, nil)
+			})
+
+			Context("when the team exists", func() {
+				BeforeEach(func() {
+					teamDB.RenameTeamReturns(db.SavedTeam{
+						ID:   2,
+						Team: db.Team{Name: "venture-industries"},
+					}, true, nil)
+				})
+
+				It("renames the team", func() {
+					Expect(teamDB.RenameTeamCallCount()).To(Equal(1))
+					currentName, newName := teamDB.RenameTeamArgsForCall(0)
+					Expect(currentName).To(Equal(teamName))
+					Expect(newName).To(Equal("venture-industries"))
+				})
+
+				It("returns the renamed team", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"id": 2,
+						"name": "venture-industries"
+					}`))
+				})
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					teamDB.RenameTeamReturns(db.SavedTeam{}, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when a team with the new name already exists", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamByNameReturns(db.SavedTeam{ID: 3}, true, nil)
+				})
+
+				It("returns 409 Conflict", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					Expect(teamDB.RenameTeamCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when renaming the team fails", func() {
+				BeforeEach(func() {
+					teamDB.RenameTeamReturns(db.SavedTeam{}, false, errors.New("disaster"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when renaming the default team", func() {
+				BeforeEach(func() {
+					teamName = atc.DefaultTeamName
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(teamDB.RenameTeamCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when renaming the default team with a differently-cased name", func() {
+				BeforeEach(func() {
+					teamName = "Main"
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(teamDB.RenameTeamCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when the requester belongs to a non-admin team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns(teamName, 2, false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(teamDB.RenameTeamCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the requester's team cannot be determined", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("", 0, false, false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(teamDB.RenameTeamCallCount()).To(Equal(0))
+			})
+		})
+	})
 })
@@ -0,0 +1,62 @@
+package teamserver
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/pivotal-golang/lager"
+)
+
+func (s *Server) DeleteTeam(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+
+	hLog := s.logger.Session("delete-team", lager.Data{
+		"name": teamName,
+	})
+
+	// only admins may delete or rename teams
+	_, _, isAdmin, found := auth.GetTeam(r)
+	if !found || !isAdmin {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	if strings.EqualFold(teamName, atc.DefaultTeamName) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	savedTeam, found, err := s.db.GetTeamByName(teamName)
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// team names are looked up case-insensitively, so check the team that was
+	// actually found
+	if strings.EqualFold(savedTeam.Name, atc.DefaultTeamName) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	hLog.Info("start")
+
+	err = s.db.DeleteTeamByName(savedTeam.Name)
+	if err != nil {
+		hLog.Error("failed-to-delete-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	hLog.Info("done")
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,30 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc/api/present"
+)
+
+func (s *Server) GetTeam(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("get-team")
+
+	teamName := r.FormValue(":team_name")
+
+	savedTeam, found, err := s.db.GetTeamByName(teamName)
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(present.Team(savedTeam))
+}
@@ -0,0 +1,36 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
+)
+
+func (s *Server) ListTeams(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("list-teams")
+
+	teamName, _, isAdmin, found := auth.GetTeam(r)
+
+	savedTeams, err := s.db.GetTeams()
+	if err != nil {
+		hLog.Error("failed-to-get-teams", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presentedTeams := []atc.Team{}
+	for _, savedTeam := range savedTeams {
+		if found && !isAdmin && savedTeam.Name != teamName {
+			continue
+		}
+
+		presentedTeams = append(presentedTeams, present.Team(savedTeam))
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(presentedTeams)
+}
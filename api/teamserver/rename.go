@@ -0,0 +1,73 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
+)
+
+func (s *Server) RenameTeam(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("rename-team")
+
+	// only admins may delete or rename teams
+	_, _, isAdmin, found := auth.GetTeam(r)
+	if !found || !isAdmin {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	teamName := r.FormValue(":team_name")
+
+	// RenameTeam matches the current name exactly, but refuse any spelling of
+	// the default team
+	if strings.EqualFold(teamName, atc.DefaultTeamName) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	var value struct{ Name string }
+	err := json.NewDecoder(r.Body).Decode(&value)
+	if err != nil {
+		hLog.Error("malformed-request", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if value.Name == "" {
+		hLog.Info("missing-team-name")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	_, found, err = s.db.GetTeamByName(value.Name)
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if found {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	savedTeam, found, err := s.db.RenameTeam(teamName, value.Name)
+	if err != nil {
+		hLog.Error("failed-to-rename-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(present.Team(savedTeam))
+}
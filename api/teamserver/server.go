@@ -13,10 +13,16 @@ type Server struct {
 //go:generate counterfeiter . TeamDB
 
 type TeamDB interface {
+	GetTeams() ([]db.SavedTeam, error)
 	GetTeamByName(teamName string) (db.SavedTeam, bool, error)
 	SaveTeam(team db.Team) (db.SavedTeam, error)
 	UpdateTeamBasicAuth(team db.Team) (db.SavedTeam, error)
 	UpdateTeamGitHubAuth(team db.Team) (db.SavedTeam, error)
+	UpdateTeamGitLabAuth(team db.Team) (db.SavedTeam, error)
+	UpdateTeamOIDCAuth(team db.Team) (db.SavedTeam, error)
+	UpdateTeamRoles(team db.Team) (db.SavedTeam, error)
+	RenameTeam(currentName string, newName string) (db.SavedTeam, bool, error)
+	DeleteTeamByName(teamName string) error
 }
 
 func NewServer(
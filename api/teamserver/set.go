@@ -3,8 +3,10 @@ package teamserver
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/present"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
@@ -13,20 +15,20 @@ import (
 func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 	hLog := s.logger.Session("create-team")
 
-	_, _, isAdmin, found := auth.GetTeam(r)
+	authTeamName, _, isAdmin, found := auth.GetTeam(r)
 
 	if !found {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	if !isAdmin {
+	teamName := r.FormValue(":team_name")
+
+	if !isAdmin && authTeamName != teamName {
 		w.WriteHeader(http.StatusForbidden)
 		return
 	}
 
-	teamName := r.FormValue(":team_name")
-
 	var team db.Team
 	err := json.NewDecoder(r.Body).Decode(&team)
 	if err != nil {
@@ -60,6 +62,11 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 
 		w.WriteHeader(http.StatusOK)
 	} else {
+		if !isAdmin {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
 		savedTeam, err = s.db.SaveTeam(team)
 		if err != nil {
 			hLog.Error("failed-to-save-team", err)
@@ -80,6 +87,21 @@ func (s *Server) updateCredentials(team db.Team) error {
 	}
 
 	_, err = s.db.UpdateTeamGitHubAuth(team)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.UpdateTeamGitLabAuth(team)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.UpdateTeamOIDCAuth(team)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.UpdateTeamRoles(team)
 	return err
 }
 
@@ -102,5 +124,35 @@ func (s *Server) validate(team db.Team) error {
 		return errors.New("GitHub auth requires at least one Organization, Team, or User")
 	}
 
+	gitLabAuth := team.GitLabAuth
+	if gitLabAuth.ClientID != "" || gitLabAuth.ClientSecret != "" {
+		if gitLabAuth.ClientID == "" || gitLabAuth.ClientSecret == "" {
+			return errors.New("GitLab auth missing ClientID or ClientSecret")
+		}
+
+		if len(gitLabAuth.Groups) == 0 &&
+			len(gitLabAuth.Projects) == 0 &&
+			len(gitLabAuth.Users) == 0 {
+			return errors.New("GitLab auth requires at least one Group, Project, or User")
+		}
+	}
+
+	oidcAuth := team.OIDCAuth
+	if oidcAuth.Issuer != "" || oidcAuth.ClientID != "" || oidcAuth.ClientSecret != "" {
+		if oidcAuth.Issuer == "" || oidcAuth.ClientID == "" || oidcAuth.ClientSecret == "" {
+			return errors.New("OIDC auth missing Issuer, ClientID, or ClientSecret")
+		}
+
+		if len(oidcAuth.Groups) == 0 && len(oidcAuth.Users) == 0 {
+			return errors.New("OIDC auth requires at least one Group or User")
+		}
+	}
+
+	for user, role := range team.Roles {
+		if !atc.IsValidRole(role) {
+			return fmt.Errorf("invalid role %q for user %q", role, user)
+		}
+	}
+
 	return nil
 }
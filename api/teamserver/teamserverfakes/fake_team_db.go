@@ -9,6 +9,13 @@ import (
 )
 
 type FakeTeamDB struct {
+	GetTeamsStub        func() ([]db.SavedTeam, error)
+	getTeamsMutex       sync.RWMutex
+	getTeamsArgsForCall []struct{}
+	getTeamsReturns     struct {
+		result1 []db.SavedTeam
+		result2 error
+	}
 	GetTeamByNameStub        func(teamName string) (db.SavedTeam, bool, error)
 	getTeamByNameMutex       sync.RWMutex
 	getTeamByNameArgsForCall []struct {
@@ -46,10 +53,82 @@ type FakeTeamDB struct {
 		result1 db.SavedTeam
 		result2 error
 	}
+	UpdateTeamGitLabAuthStub        func(team db.Team) (db.SavedTeam, error)
+	updateTeamGitLabAuthMutex       sync.RWMutex
+	updateTeamGitLabAuthArgsForCall []struct {
+		team db.Team
+	}
+	updateTeamGitLabAuthReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	UpdateTeamOIDCAuthStub        func(team db.Team) (db.SavedTeam, error)
+	updateTeamOIDCAuthMutex       sync.RWMutex
+	updateTeamOIDCAuthArgsForCall []struct {
+		team db.Team
+	}
+	updateTeamOIDCAuthReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	UpdateTeamRolesStub        func(team db.Team) (db.SavedTeam, error)
+	updateTeamRolesMutex       sync.RWMutex
+	updateTeamRolesArgsForCall []struct {
+		team db.Team
+	}
+	updateTeamRolesReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	RenameTeamStub        func(currentName string, newName string) (db.SavedTeam, bool, error)
+	renameTeamMutex       sync.RWMutex
+	renameTeamArgsForCall []struct {
+		currentName string
+		newName     string
+	}
+	renameTeamReturns struct {
+		result1 db.SavedTeam
+		result2 bool
+		result3 error
+	}
+	DeleteTeamByNameStub        func(teamName string) error
+	deleteTeamByNameMutex       sync.RWMutex
+	deleteTeamByNameArgsForCall []struct {
+		teamName string
+	}
+	deleteTeamByNameReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeTeamDB) GetTeams() ([]db.SavedTeam, error) {
+	fake.getTeamsMutex.Lock()
+	fake.getTeamsArgsForCall = append(fake.getTeamsArgsForCall, struct{}{})
+	fake.recordInvocation("GetTeams", []interface{}{})
+	fake.getTeamsMutex.Unlock()
+	if fake.GetTeamsStub != nil {
+		return fake.GetTeamsStub()
+	} else {
+		return fake.getTeamsReturns.result1, fake.getTeamsReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) GetTeamsCallCount() int {
+	fake.getTeamsMutex.RLock()
+	defer fake.getTeamsMutex.RUnlock()
+	return len(fake.getTeamsArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetTeamsReturns(result1 []db.SavedTeam, result2 error) {
+	fake.GetTeamsStub = nil
+	fake.getTeamsReturns = struct {
+		result1 []db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeamDB) GetTeamByName(teamName string) (db.SavedTeam, bool, error) {
 	fake.getTeamByNameMutex.Lock()
 	fake.getTeamByNameArgsForCall = append(fake.getTeamByNameArgsForCall, struct {
@@ -187,9 +266,182 @@ func (fake *FakeTeamDB) UpdateTeamGitHubAuthReturns(result1 db.SavedTeam, result
 	}{result1, result2}
 }
 
+func (fake *FakeTeamDB) UpdateTeamGitLabAuth(team db.Team) (db.SavedTeam, error) {
+	fake.updateTeamGitLabAuthMutex.Lock()
+	fake.updateTeamGitLabAuthArgsForCall = append(fake.updateTeamGitLabAuthArgsForCall, struct {
+		team db.Team
+	}{team})
+	fake.recordInvocation("UpdateTeamGitLabAuth", []interface{}{team})
+	fake.updateTeamGitLabAuthMutex.Unlock()
+	if fake.UpdateTeamGitLabAuthStub != nil {
+		return fake.UpdateTeamGitLabAuthStub(team)
+	} else {
+		return fake.updateTeamGitLabAuthReturns.result1, fake.updateTeamGitLabAuthReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) UpdateTeamGitLabAuthCallCount() int {
+	fake.updateTeamGitLabAuthMutex.RLock()
+	defer fake.updateTeamGitLabAuthMutex.RUnlock()
+	return len(fake.updateTeamGitLabAuthArgsForCall)
+}
+
+func (fake *FakeTeamDB) UpdateTeamGitLabAuthArgsForCall(i int) db.Team {
+	fake.updateTeamGitLabAuthMutex.RLock()
+	defer fake.updateTeamGitLabAuthMutex.RUnlock()
+	return fake.updateTeamGitLabAuthArgsForCall[i].team
+}
+
+func (fake *FakeTeamDB) UpdateTeamGitLabAuthReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateTeamGitLabAuthStub = nil
+	fake.updateTeamGitLabAuthReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) UpdateTeamOIDCAuth(team db.Team) (db.SavedTeam, error) {
+	fake.updateTeamOIDCAuthMutex.Lock()
+	fake.updateTeamOIDCAuthArgsForCall = append(fake.updateTeamOIDCAuthArgsForCall, struct {
+		team db.Team
+	}{team})
+	fake.recordInvocation("UpdateTeamOIDCAuth", []interface{}{team})
+	fake.updateTeamOIDCAuthMutex.Unlock()
+	if fake.UpdateTeamOIDCAuthStub != nil {
+		return fake.UpdateTeamOIDCAuthStub(team)
+	} else {
+		return fake.updateTeamOIDCAuthReturns.result1, fake.updateTeamOIDCAuthReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) UpdateTeamOIDCAuthCallCount() int {
+	fake.updateTeamOIDCAuthMutex.RLock()
+	defer fake.updateTeamOIDCAuthMutex.RUnlock()
+	return len(fake.updateTeamOIDCAuthArgsForCall)
+}
+
+func (fake *FakeTeamDB) UpdateTeamOIDCAuthArgsForCall(i int) db.Team {
+	fake.updateTeamOIDCAuthMutex.RLock()
+	defer fake.updateTeamOIDCAuthMutex.RUnlock()
+	return fake.updateTeamOIDCAuthArgsForCall[i].team
+}
+
+func (fake *FakeTeamDB) UpdateTeamOIDCAuthReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateTeamOIDCAuthStub = nil
+	fake.updateTeamOIDCAuthReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) UpdateTeamRoles(team db.Team) (db.SavedTeam, error) {
+	fake.updateTeamRolesMutex.Lock()
+	fake.updateTeamRolesArgsForCall = append(fake.updateTeamRolesArgsForCall, struct {
+		team db.Team
+	}{team})
+	fake.recordInvocation("UpdateTeamRoles", []interface{}{team})
+	fake.updateTeamRolesMutex.Unlock()
+	if fake.UpdateTeamRolesStub != nil {
+		return fake.UpdateTeamRolesStub(team)
+	} else {
+		return fake.updateTeamRolesReturns.result1, fake.updateTeamRolesReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) UpdateTeamRolesCallCount() int {
+	fake.updateTeamRolesMutex.RLock()
+	defer fake.updateTeamRolesMutex.RUnlock()
+	return len(fake.updateTeamRolesArgsForCall)
+}
+
+func (fake *FakeTeamDB) UpdateTeamRolesArgsForCall(i int) db.Team {
+	fake.updateTeamRolesMutex.RLock()
+	defer fake.updateTeamRolesMutex.RUnlock()
+	return fake.updateTeamRolesArgsForCall[i].team
+}
+
+func (fake *FakeTeamDB) UpdateTeamRolesReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateTeamRolesStub = nil
+	fake.updateTeamRolesReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) RenameTeam(currentName string, newName string) (db.SavedTeam, bool, error) {
+	fake.renameTeamMutex.Lock()
+	fake.renameTeamArgsForCall = append(fake.renameTeamArgsForCall, struct {
+		currentName string
+		newName     string
+	}{currentName, newName})
+	fake.recordInvocation("RenameTeam", []interface{}{currentName, newName})
+	fake.renameTeamMutex.Unlock()
+	if fake.RenameTeamStub != nil {
+		return fake.RenameTeamStub(currentName, newName)
+	} else {
+		return fake.renameTeamReturns.result1, fake.renameTeamReturns.result2, fake.renameTeamReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) RenameTeamCallCount() int {
+	fake.renameTeamMutex.RLock()
+	defer fake.renameTeamMutex.RUnlock()
+	return len(fake.renameTeamArgsForCall)
+}
+
+func (fake *FakeTeamDB) RenameTeamArgsForCall(i int) (string, string) {
+	fake.renameTeamMutex.RLock()
+	defer fake.renameTeamMutex.RUnlock()
+	return fake.renameTeamArgsForCall[i].currentName, fake.renameTeamArgsForCall[i].newName
+}
+
+func (fake *FakeTeamDB) RenameTeamReturns(result1 db.SavedTeam, result2 bool, result3 error) {
+	fake.RenameTeamStub = nil
+	fake.renameTeamReturns = struct {
+		result1 db.SavedTeam
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) DeleteTeamByName(teamName string) error {
+	fake.deleteTeamByNameMutex.Lock()
+	fake.deleteTeamByNameArgsForCall = append(fake.deleteTeamByNameArgsForCall, struct {
+		teamName string
+	}{teamName})
+	fake.recordInvocation("DeleteTeamByName", []interface{}{teamName})
+	fake.deleteTeamByNameMutex.Unlock()
+	if fake.DeleteTeamByNameStub != nil {
+		return fake.DeleteTeamByNameStub(teamName)
+	} else {
+		return fake.deleteTeamByNameReturns.result1
+	}
+}
+
+func (fake *FakeTeamDB) DeleteTeamByNameCallCount() int {
+	fake.deleteTeamByNameMutex.RLock()
+	defer fake.deleteTeamByNameMutex.RUnlock()
+	return len(fake.deleteTeamByNameArgsForCall)
+}
+
+func (fake *FakeTeamDB) DeleteTeamByNameArgsForCall(i int) string {
+	fake.deleteTeamByNameMutex.RLock()
+	defer fake.deleteTeamByNameMutex.RUnlock()
+	return fake.deleteTeamByNameArgsForCall[i].teamName
+}
+
+func (fake *FakeTeamDB) DeleteTeamByNameReturns(result1 error) {
+	fake.DeleteTeamByNameStub = nil
+	fake.deleteTeamByNameReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeamDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.getTeamsMutex.RLock()
+	defer fake.getTeamsMutex.RUnlock()
 	fake.getTeamByNameMutex.RLock()
 	defer fake.getTeamByNameMutex.RUnlock()
 	fake.saveTeamMutex.RLock()
@@ -198,6 +450,16 @@ func (fake *FakeTeamDB) Invocations() map[string][][]interface{} {
 	defer fake.updateTeamBasicAuthMutex.RUnlock()
 	fake.updateTeamGitHubAuthMutex.RLock()
 	defer fake.updateTeamGitHubAuthMutex.RUnlock()
+	fake.updateTeamGitLabAuthMutex.RLock()
+	defer fake.updateTeamGitLabAuthMutex.RUnlock()
+	fake.updateTeamOIDCAuthMutex.RLock()
+	defer fake.updateTeamOIDCAuthMutex.RUnlock()
+	fake.updateTeamRolesMutex.RLock()
+	defer fake.updateTeamRolesMutex.RUnlock()
+	fake.renameTeamMutex.RLock()
+	defer fake.renameTeamMutex.RUnlock()
+	fake.deleteTeamByNameMutex.RLock()
+	defer fake.deleteTeamByNameMutex.RUnlock()
 	return fake.invocations
 }
 
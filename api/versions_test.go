@@ -1,6 +1,7 @@
 package api_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -265,6 +266,115 @@ var _ = Describe("Versions API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", func() {
+		var response *http.Response
+		var requestBody string
+
+		BeforeEach(func() {
+			requestBody = `{"comment":"reverting the bad deploy"}`
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/pipelines/a-pipeline/resources/resource-name/versions/42/pin", bytes.NewBufferString(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns(atc.DefaultTeamName, 1, true, true)
+				userContextReader.GetUserReturns("some-user", true)
+			})
+
+			It("injects the proper pipelineDB", func() {
+				Expect(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).To(Equal(1))
+				teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+				Expect(pipelineName).To(Equal("a-pipeline"))
+				Expect(teamName).To(Equal(atc.DefaultTeamName))
+			})
+
+			Context("when pinning the version succeeds", func() {
+				It("pins the version with the comment and the pinning user", func() {
+					Expect(pipelineDB.PinResourceVersionCallCount()).To(Equal(1))
+					resourceName, versionID, comment, pinnedBy := pipelineDB.PinResourceVersionArgsForCall(0)
+					Expect(resourceName).To(Equal("resource-name"))
+					Expect(versionID).To(Equal(42))
+					Expect(comment).To(Equal("reverting the bad deploy"))
+					Expect(pinnedBy).To(Equal("some-user"))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when no comment is given", func() {
+				BeforeEach(func() {
+					requestBody = ""
+				})
+
+				It("pins the version without a comment", func() {
+					Expect(pipelineDB.PinResourceVersionCallCount()).To(Equal(1))
+					_, _, comment, _ := pipelineDB.PinResourceVersionArgsForCall(0)
+					Expect(comment).To(BeEmpty())
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when the request body is malformed", func() {
+				BeforeEach(func() {
+					requestBody = "{"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not pin anything", func() {
+					Expect(pipelineDB.PinResourceVersionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the version does not belong to the resource", func() {
+				BeforeEach(func() {
+					pipelineDB.PinResourceVersionReturns(db.ErrVersionNotFound)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when pinning the version fails", func() {
+				BeforeEach(func() {
+					pipelineDB.PinResourceVersionReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/disable", func() {
 		var response *http.Response
 
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -49,6 +50,7 @@ var _ = Describe("Workers API", func() {
 								Platform: "freebsd",
 								Tags:     []string{"demon"},
 							},
+							State: db.WorkerStateRunning,
 						},
 						{
 							WorkerInfo: db.WorkerInfo{
@@ -60,6 +62,7 @@ var _ = Describe("Workers API", func() {
 								Platform: "beos",
 								Tags:     []string{"best", "os", "ever", "rip"},
 							},
+							State: db.WorkerStateStalled,
 						},
 					}, nil)
 				})
@@ -86,6 +89,7 @@ var _ = Describe("Workers API", func() {
 							},
 							Platform: "freebsd",
 							Tags:     []string{"demon"},
+							State:    "running",
 						},
 						{
 							GardenAddr:       "1.2.3.4:8888",
@@ -95,12 +99,114 @@ var _ = Describe("Workers API", func() {
 							},
 							Platform: "beos",
 							Tags:     []string{"best", "os", "ever", "rip"},
+							State:    "stalled",
 						},
 					}))
 
 				})
 			})
 
+			Context("when a worker has been health checked", func() {
+				BeforeEach(func() {
+					workerDB.WorkersReturns([]db.SavedWorker{
+						{
+							WorkerInfo: db.WorkerInfo{
+								GardenAddr: "1.2.3.4:7777",
+								Name:       "some-worker",
+							},
+							State: db.WorkerStateRunning,
+							Health: db.WorkerHealth{
+								CheckedAt:           time.Unix(1461864115, 0),
+								GardenLatency:       15 * time.Millisecond,
+								BaggageclaimLatency: 2 * time.Second,
+								BaggageclaimError:   "timed out",
+								DiskInBytes:         1073741824,
+							},
+						},
+					}, nil)
+				})
+
+				It("returns the result of the check", func() {
+					var returnedWorkers []atc.Worker
+					err := json.NewDecoder(response.Body).Decode(&returnedWorkers)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(returnedWorkers).To(HaveLen(1))
+					Expect(returnedWorkers[0].Health).To(Equal(&atc.WorkerHealth{
+						Healthy:               false,
+						CheckedAt:             1461864115,
+						GardenLatencyMS:       15,
+						BaggageclaimLatencyMS: 2000,
+						BaggageclaimError:     "timed out",
+						DiskInBytes:           1073741824,
+					}))
+				})
+			})
+
+			Context("when some workers belong to teams", func() {
+				BeforeEach(func() {
+					workerDB.WorkersReturns([]db.SavedWorker{
+						{
+							WorkerInfo: db.WorkerInfo{
+								GardenAddr: "1.2.3.4:7777",
+								Name:       "shared-worker",
+							},
+							State: db.WorkerStateRunning,
+						},
+						{
+							WorkerInfo: db.WorkerInfo{
+								GardenAddr: "1.2.3.4:8888",
+								Name:       "our-worker",
+								TeamID:     2,
+								TeamName:   "some-team",
+							},
+							State: db.WorkerStateRunning,
+						},
+						{
+							WorkerInfo: db.WorkerInfo{
+								GardenAddr: "1.2.3.4:9999",
+								Name:       "their-worker",
+								TeamID:     3,
+								TeamName:   "other-team",
+							},
+							State: db.WorkerStateRunning,
+						},
+					}, nil)
+				})
+
+				Context("when authenticated as an admin team", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns(atc.DefaultTeamName, 1, true, true)
+					})
+
+					It("returns every worker", func() {
+						var returnedWorkers []atc.Worker
+						err := json.NewDecoder(response.Body).Decode(&returnedWorkers)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(returnedWorkers).To(HaveLen(3))
+						Expect(returnedWorkers[1].Team).To(Equal("some-team"))
+						Expect(returnedWorkers[2].Team).To(Equal("other-team"))
+					})
+				})
+
+				Context("when authenticated as a non-admin team", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-team", 2, false, true)
+					})
+
+					It("returns only shared workers and the team's own workers", func() {
+						var returnedWorkers []atc.Worker
+						err := json.NewDecoder(response.Body).Decode(&returnedWorkers)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(returnedWorkers).To(HaveLen(2))
+						Expect(returnedWorkers[0].Name).To(Equal("shared-worker"))
+						Expect(returnedWorkers[1].Name).To(Equal("our-worker"))
+					})
+				})
+			})
+
 			Context("when getting the workers fails", func() {
 				BeforeEach(func() {
 					workerDB.WorkersReturns(nil, errors.New("oh no!"))
@@ -215,6 +321,128 @@ var _ = Describe("Workers API", func() {
 				})
 			})
 
+			Context("when the worker has a team", func() {
+				BeforeEach(func() {
+					worker.Team = "some-team"
+				})
+
+				Context("when the team exists", func() {
+					BeforeEach(func() {
+						workerDB.GetTeamByNameReturns(db.SavedTeam{ID: 2, Team: db.Team{Name: "some-team"}}, true, nil)
+					})
+
+					It("looks up the team by name", func() {
+						Expect(workerDB.GetTeamByNameCallCount()).To(Equal(1))
+						Expect(workerDB.GetTeamByNameArgsForCall(0)).To(Equal("some-team"))
+					})
+
+					It("saves the worker with the team's ID", func() {
+						Expect(workerDB.SaveWorkerCallCount()).To(Equal(1))
+						savedInfo, _ := workerDB.SaveWorkerArgsForCall(0)
+						Expect(savedInfo.TeamID).To(Equal(2))
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+
+				Context("when the team does not exist", func() {
+					BeforeEach(func() {
+						workerDB.GetTeamByNameReturns(db.SavedTeam{}, false, nil)
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("does not save the worker", func() {
+						Expect(workerDB.SaveWorkerCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when looking up the team fails", func() {
+					BeforeEach(func() {
+						workerDB.GetTeamByNameReturns(db.SavedTeam{}, false, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when authenticated as a different non-admin team", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("other-team", 3, false, true)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+
+					It("does not save the worker", func() {
+						Expect(workerDB.SaveWorkerCallCount()).To(BeZero())
+						Expect(workerDB.SaveTeamWorkerCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when authenticated as a team that is not an admin", func() {
+				BeforeEach(func() {
+					userContextReader.GetTeamReturns("some-team", 2, false, true)
+				})
+
+				It("saves the worker as the team's without taking over other workers", func() {
+					Expect(workerDB.SaveWorkerCallCount()).To(BeZero())
+					Expect(workerDB.SaveTeamWorkerCallCount()).To(Equal(1))
+
+					savedInfo, _ := workerDB.SaveTeamWorkerArgsForCall(0)
+					Expect(savedInfo.TeamID).To(Equal(2))
+				})
+
+				It("does not need to look up the team", func() {
+					Expect(workerDB.GetTeamByNameCallCount()).To(BeZero())
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				Context("when the worker's name or address is taken by a worker the team does not own", func() {
+					BeforeEach(func() {
+						workerDB.SaveTeamWorkerReturns(db.SavedWorker{}, db.ErrWorkerNotOwnedByTeam)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+
+				Context("when saving the worker fails", func() {
+					BeforeEach(func() {
+						workerDB.SaveTeamWorkerReturns(db.SavedWorker{}, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when authenticated as an admin", func() {
+				BeforeEach(func() {
+					userContextReader.GetTeamReturns(atc.DefaultTeamName, 1, true, true)
+				})
+
+				It("saves the worker as shared", func() {
+					Expect(workerDB.SaveTeamWorkerCallCount()).To(BeZero())
+					Expect(workerDB.SaveWorkerCallCount()).To(Equal(1))
+
+					savedInfo, _ := workerDB.SaveWorkerArgsForCall(0)
+					Expect(savedInfo.TeamID).To(BeZero())
+				})
+			})
+
 			Context("when saving the worker succeeds", func() {
 				BeforeEach(func() {
 					workerDB.SaveWorkerReturns(db.SavedWorker{}, nil)
@@ -286,4 +514,494 @@ var _ = Describe("Workers API", func() {
 			})
 		})
 	})
+
+	Describe("PUT /api/v1/workers/:worker_name/land", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/workers/some-worker/land", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+
+				workerDB.GetWorkerReturns(db.SavedWorker{
+					WorkerInfo: db.WorkerInfo{Name: "some-worker"},
+				}, true, nil)
+			})
+
+			It("lands the worker", func() {
+				Expect(workerDB.LandWorkerCallCount()).To(Equal(1))
+				Expect(workerDB.LandWorkerArgsForCall(0)).To(Equal("some-worker"))
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("looks up the worker", func() {
+				Expect(workerDB.GetWorkerArgsForCall(0)).To(Equal("some-worker"))
+			})
+
+			Context("when the worker cannot be found", func() {
+				BeforeEach(func() {
+					workerDB.GetWorkerReturns(db.SavedWorker{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+
+				It("does not land the worker", func() {
+					Expect(workerDB.LandWorkerCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when looking up the worker fails", func() {
+				BeforeEach(func() {
+					workerDB.GetWorkerReturns(db.SavedWorker{}, false, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when authenticated as a team that is not an admin", func() {
+				BeforeEach(func() {
+					userContextReader.GetTeamReturns("some-team", 2, false, true)
+				})
+
+				Context("when the worker is shared", func() {
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+
+					It("does not land the worker", func() {
+						Expect(workerDB.LandWorkerCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the worker belongs to another team", func() {
+					BeforeEach(func() {
+						workerDB.GetWorkerReturns(db.SavedWorker{
+							WorkerInfo: db.WorkerInfo{Name: "some-worker", TeamID: 3},
+						}, true, nil)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+
+					It("does not land the worker", func() {
+						Expect(workerDB.LandWorkerCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the worker belongs to the team", func() {
+					BeforeEach(func() {
+						workerDB.GetWorkerReturns(db.SavedWorker{
+							WorkerInfo: db.WorkerInfo{Name: "some-worker", TeamID: 2},
+						}, true, nil)
+					})
+
+					It("lands the worker", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(workerDB.LandWorkerCallCount()).To(Equal(1))
+					})
+
+					Context("when not an owner of the team", func() {
+						BeforeEach(func() {
+							userContextReader.GetRoleReturns(atc.RoleMember, true)
+						})
+
+						It("returns 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+							Expect(workerDB.LandWorkerCallCount()).To(BeZero())
+						})
+					})
+				})
+			})
+
+			Context("when authenticated as an admin", func() {
+				BeforeEach(func() {
+					userContextReader.GetTeamReturns(atc.DefaultTeamName, 1, true, true)
+				})
+
+				It("lands shared workers", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(workerDB.LandWorkerCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the worker is not present", func() {
+				BeforeEach(func() {
+					workerDB.LandWorkerReturns(db.ErrWorkerNotPresent)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when landing the worker fails", func() {
+				BeforeEach(func() {
+					workerDB.LandWorkerReturns(errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not land the worker", func() {
+				Expect(workerDB.LandWorkerCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/workers/:worker_name/retire", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/workers/some-worker/retire", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+
+				workerDB.GetWorkerReturns(db.SavedWorker{
+					WorkerInfo: db.WorkerInfo{Name: "some-worker"},
+				}, true, nil)
+			})
+
+			It("retires the worker", func() {
+				Expect(workerDB.RetireWorkerCallCount()).To(Equal(1))
+				Expect(workerDB.RetireWorkerArgsForCall(0)).To(Equal("some-worker"))
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("looks up the worker", func() {
+				Expect(workerDB.GetWorkerArgsForCall(0)).To(Equal("some-worker"))
+			})
+
+			Context("when the worker cannot be found", func() {
+				BeforeEach(func() {
+					workerDB.GetWorkerReturns(db.SavedWorker{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+
+				It("does not retire the worker", func() {
+					Expect(workerDB.RetireWorkerCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when looking up the worker fails", func() {
+				BeforeEach(func() {
+					workerDB.GetWorkerReturns(db.SavedWorker{}, false, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when authenticated as a team that is not an admin", func() {
+				BeforeEach(func() {
+					userContextReader.GetTeamReturns("some-team", 2, false, true)
+				})
+
+				Context("when the worker is shared", func() {
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+
+					It("does not retire the worker", func() {
+						Expect(workerDB.RetireWorkerCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the worker belongs to another team", func() {
+					BeforeEach(func() {
+						workerDB.GetWorkerReturns(db.SavedWorker{
+							WorkerInfo: db.WorkerInfo{Name: "some-worker", TeamID: 3},
+						}, true, nil)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+
+					It("does not retire the worker", func() {
+						Expect(workerDB.RetireWorkerCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the worker belongs to the team", func() {
+					BeforeEach(func() {
+						workerDB.GetWorkerReturns(db.SavedWorker{
+							WorkerInfo: db.WorkerInfo{Name: "some-worker", TeamID: 2},
+						}, true, nil)
+					})
+
+					It("retires the worker", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(workerDB.RetireWorkerCallCount()).To(Equal(1))
+					})
+
+					Context("when not an owner of the team", func() {
+						BeforeEach(func() {
+							userContextReader.GetRoleReturns(atc.RoleMember, true)
+						})
+
+						It("returns 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+							Expect(workerDB.RetireWorkerCallCount()).To(BeZero())
+						})
+					})
+				})
+			})
+
+			Context("when authenticated as an admin", func() {
+				BeforeEach(func() {
+					userContextReader.GetTeamReturns(atc.DefaultTeamName, 1, true, true)
+				})
+
+				It("retires shared workers", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(workerDB.RetireWorkerCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the worker is not present", func() {
+				BeforeEach(func() {
+					workerDB.RetireWorkerReturns(db.ErrWorkerNotPresent)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when retiring the worker fails", func() {
+				BeforeEach(func() {
+					workerDB.RetireWorkerReturns(errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not retire the worker", func() {
+				Expect(workerDB.RetireWorkerCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/workers/:worker_name/prune", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/workers/some-worker/prune", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+
+				workerDB.GetWorkerReturns(db.SavedWorker{
+					WorkerInfo: db.WorkerInfo{Name: "some-worker"},
+				}, true, nil)
+			})
+
+			It("prunes the worker", func() {
+				Expect(workerDB.PruneWorkerCallCount()).To(Equal(1))
+				Expect(workerDB.PruneWorkerArgsForCall(0)).To(Equal("some-worker"))
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("looks up the worker", func() {
+				Expect(workerDB.GetWorkerArgsForCall(0)).To(Equal("some-worker"))
+			})
+
+			Context("when the worker cannot be found", func() {
+				BeforeEach(func() {
+					workerDB.GetWorkerReturns(db.SavedWorker{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+
+				It("does not prune the worker", func() {
+					Expect(workerDB.PruneWorkerCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when looking up the worker fails", func() {
+				BeforeEach(func() {
+					workerDB.GetWorkerReturns(db.SavedWorker{}, false, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when authenticated as a team that is not an admin", func() {
+				BeforeEach(func() {
+					userContextReader.GetTeamReturns("some-team", 2, false, true)
+				})
+
+				Context("when the worker is shared", func() {
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+
+					It("does not prune the worker", func() {
+						Expect(workerDB.PruneWorkerCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the worker belongs to another team", func() {
+					BeforeEach(func() {
+						workerDB.GetWorkerReturns(db.SavedWorker{
+							WorkerInfo: db.WorkerInfo{Name: "some-worker", TeamID: 3},
+						}, true, nil)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+
+					It("does not prune the worker", func() {
+						Expect(workerDB.PruneWorkerCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the worker belongs to the team", func() {
+					BeforeEach(func() {
+						workerDB.GetWorkerReturns(db.SavedWorker{
+							WorkerInfo: db.WorkerInfo{Name: "some-worker", TeamID: 2},
+						}, true, nil)
+					})
+
+					It("prunes the worker", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(workerDB.PruneWorkerCallCount()).To(Equal(1))
+					})
+
+					Context("when not an owner of the team", func() {
+						BeforeEach(func() {
+							userContextReader.GetRoleReturns(atc.RoleMember, true)
+						})
+
+						It("returns 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+							Expect(workerDB.PruneWorkerCallCount()).To(BeZero())
+						})
+					})
+				})
+			})
+
+			Context("when authenticated as an admin", func() {
+				BeforeEach(func() {
+					userContextReader.GetTeamReturns(atc.DefaultTeamName, 1, true, true)
+				})
+
+				It("prunes shared workers", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(workerDB.PruneWorkerCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the worker is not present", func() {
+				BeforeEach(func() {
+					workerDB.PruneWorkerReturns(db.ErrWorkerNotPresent)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the worker is still running", func() {
+				BeforeEach(func() {
+					workerDB.PruneWorkerReturns(db.ErrCannotPruneRunningWorker)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when pruning the worker fails", func() {
+				BeforeEach(func() {
+					workerDB.PruneWorkerReturns(errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not prune the worker", func() {
+				Expect(workerDB.PruneWorkerCallCount()).To(BeZero())
+			})
+		})
+	})
 })
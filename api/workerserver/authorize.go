@@ -0,0 +1,40 @@
+package workerserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/pivotal-golang/lager"
+)
+
+// authorizeWorker looks up the named worker and checks that the request may
+// manage it: shared workers may only be managed by admins, and a team's
+// workers by the owners of that team. If not, it writes the response and
+// returns false.
+func (s *Server) authorizeWorker(logger lager.Logger, w http.ResponseWriter, r *http.Request, workerName string) bool {
+	savedWorker, found, err := s.db.GetWorker(workerName)
+	if err != nil {
+		logger.Error("failed-to-get-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return false
+	}
+
+	if !found {
+		logger.Debug("worker-not-present", lager.Data{"worker": workerName})
+		w.WriteHeader(http.StatusNotFound)
+		return false
+	}
+
+	_, authTeamID, isAdmin, found := auth.GetTeam(r)
+	if !found || isAdmin {
+		return true
+	}
+
+	if savedWorker.TeamID == 0 || savedWorker.TeamID != authTeamID || !auth.HasRole(r, atc.RoleOwner) {
+		w.WriteHeader(http.StatusForbidden)
+		return false
+	}
+
+	return true
+}
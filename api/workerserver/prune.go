@@ -0,0 +1,40 @@
+package workerserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) PruneWorker(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("prune-worker")
+	workerName := rata.Param(r, "worker_name")
+
+	if !s.authorizeWorker(logger, w, r, workerName) {
+		return
+	}
+
+	err := s.db.PruneWorker(workerName)
+	if err == db.ErrWorkerNotPresent {
+		logger.Debug("worker-not-present", lager.Data{"worker": workerName})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if err == db.ErrCannotPruneRunningWorker {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "%s", err)
+		return
+	}
+
+	if err != nil {
+		logger.Error("failed-to-prune-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -8,8 +8,10 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/metric"
+	"github.com/pivotal-golang/lager"
 )
 
 type IntMetric int
@@ -49,24 +51,71 @@ func (s *Server) RegisterWorker(w http.ResponseWriter, r *http.Request) {
 		registration.Name = registration.GardenAddr
 	}
 
+	// teams that are not admins may only register workers of their own, and
+	// may not take over shared workers or those of other teams
+	authTeamName, authTeamID, isAdmin, found := auth.GetTeam(r)
+	teamOnly := found && !isAdmin
+
+	var teamID int
+	if teamOnly {
+		if registration.Team != "" && registration.Team != authTeamName {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		teamID = authTeamID
+	} else if registration.Team != "" {
+		savedTeam, found, err := s.db.GetTeamByName(registration.Team)
+		if err != nil {
+			logger.Error("failed-to-get-team", err, lager.Data{"team": registration.Team})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "unknown team: %s", registration.Team)
+			return
+		}
+
+		teamID = savedTeam.ID
+	}
+
 	metric.WorkerContainers{
 		WorkerName: registration.Name,
 		Containers: registration.ActiveContainers,
 	}.Emit(s.logger)
 
-	_, err = s.db.SaveWorker(db.WorkerInfo{
+	workerInfo := db.WorkerInfo{
 		GardenAddr:       registration.GardenAddr,
 		BaggageclaimURL:  registration.BaggageclaimURL,
 		HTTPProxyURL:     registration.HTTPProxyURL,
 		HTTPSProxyURL:    registration.HTTPSProxyURL,
 		NoProxy:          registration.NoProxy,
 		ActiveContainers: registration.ActiveContainers,
+		MaxContainers:    registration.MaxContainers,
+		MemoryCapacity:   registration.MemoryCapacity,
+		CPUCapacity:      registration.CPUCapacity,
 		ResourceTypes:    registration.ResourceTypes,
 		Platform:         registration.Platform,
 		Tags:             registration.Tags,
 		Name:             registration.Name,
 		StartTime:        registration.StartTime,
-	}, ttl)
+		TeamID:           teamID,
+	}
+
+	if teamOnly {
+		_, err = s.db.SaveTeamWorker(workerInfo, ttl)
+	} else {
+		_, err = s.db.SaveWorker(workerInfo, ttl)
+	}
+
+	if err == db.ErrWorkerNotOwnedByTeam {
+		logger.Info("worker-not-owned-by-team", lager.Data{"worker": registration.Name, "team": authTeamName})
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
 	if err != nil {
 		logger.Error("failed-to-save-worker", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,33 @@
+package workerserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) RetireWorker(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("retire-worker")
+	workerName := rata.Param(r, "worker_name")
+
+	if !s.authorizeWorker(logger, w, r, workerName) {
+		return
+	}
+
+	err := s.db.RetireWorker(workerName)
+	if err == db.ErrWorkerNotPresent {
+		logger.Debug("worker-not-present", lager.Data{"worker": workerName})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		logger.Error("failed-to-retire-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
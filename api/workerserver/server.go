@@ -17,7 +17,13 @@ type Server struct {
 
 type WorkerDB interface {
 	SaveWorker(db.WorkerInfo, time.Duration) (db.SavedWorker, error)
+	SaveTeamWorker(db.WorkerInfo, time.Duration) (db.SavedWorker, error)
 	Workers() ([]db.SavedWorker, error)
+	GetWorker(workerName string) (db.SavedWorker, bool, error)
+	GetTeamByName(teamName string) (db.SavedTeam, bool, error)
+	LandWorker(string) error
+	RetireWorker(string) error
+	PruneWorker(string) error
 }
 
 func NewServer(
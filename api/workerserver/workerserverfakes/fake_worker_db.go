@@ -20,6 +20,16 @@ type FakeWorkerDB struct {
 		result1 db.SavedWorker
 		result2 error
 	}
+	SaveTeamWorkerStub        func(db.WorkerInfo, time.Duration) (db.SavedWorker, error)
+	saveTeamWorkerMutex       sync.RWMutex
+	saveTeamWorkerArgsForCall []struct {
+		arg1 db.WorkerInfo
+		arg2 time.Duration
+	}
+	saveTeamWorkerReturns struct {
+		result1 db.SavedWorker
+		result2 error
+	}
 	WorkersStub        func() ([]db.SavedWorker, error)
 	workersMutex       sync.RWMutex
 	workersArgsForCall []struct{}
@@ -27,6 +37,50 @@ type FakeWorkerDB struct {
 		result1 []db.SavedWorker
 		result2 error
 	}
+	GetWorkerStub        func(workerName string) (db.SavedWorker, bool, error)
+	getWorkerMutex       sync.RWMutex
+	getWorkerArgsForCall []struct {
+		workerName string
+	}
+	getWorkerReturns struct {
+		result1 db.SavedWorker
+		result2 bool
+		result3 error
+	}
+	GetTeamByNameStub        func(teamName string) (db.SavedTeam, bool, error)
+	getTeamByNameMutex       sync.RWMutex
+	getTeamByNameArgsForCall []struct {
+		teamName string
+	}
+	getTeamByNameReturns struct {
+		result1 db.SavedTeam
+		result2 bool
+		result3 error
+	}
+	LandWorkerStub        func(string) error
+	landWorkerMutex       sync.RWMutex
+	landWorkerArgsForCall []struct {
+		arg1 string
+	}
+	landWorkerReturns struct {
+		result1 error
+	}
+	RetireWorkerStub        func(string) error
+	retireWorkerMutex       sync.RWMutex
+	retireWorkerArgsForCall []struct {
+		arg1 string
+	}
+	retireWorkerReturns struct {
+		result1 error
+	}
+	PruneWorkerStub        func(string) error
+	pruneWorkerMutex       sync.RWMutex
+	pruneWorkerArgsForCall []struct {
+		arg1 string
+	}
+	pruneWorkerReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -66,6 +120,41 @@ func (fake *FakeWorkerDB) SaveWorkerReturns(result1 db.SavedWorker, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakeWorkerDB) SaveTeamWorker(arg1 db.WorkerInfo, arg2 time.Duration) (db.SavedWorker, error) {
+	fake.saveTeamWorkerMutex.Lock()
+	fake.saveTeamWorkerArgsForCall = append(fake.saveTeamWorkerArgsForCall, struct {
+		arg1 db.WorkerInfo
+		arg2 time.Duration
+	}{arg1, arg2})
+	fake.recordInvocation("SaveTeamWorker", []interface{}{arg1, arg2})
+	fake.saveTeamWorkerMutex.Unlock()
+	if fake.SaveTeamWorkerStub != nil {
+		return fake.SaveTeamWorkerStub(arg1, arg2)
+	} else {
+		return fake.saveTeamWorkerReturns.result1, fake.saveTeamWorkerReturns.result2
+	}
+}
+
+func (fake *FakeWorkerDB) SaveTeamWorkerCallCount() int {
+	fake.saveTeamWorkerMutex.RLock()
+	defer fake.saveTeamWorkerMutex.RUnlock()
+	return len(fake.saveTeamWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerDB) SaveTeamWorkerArgsForCall(i int) (db.WorkerInfo, time.Duration) {
+	fake.saveTeamWorkerMutex.RLock()
+	defer fake.saveTeamWorkerMutex.RUnlock()
+	return fake.saveTeamWorkerArgsForCall[i].arg1, fake.saveTeamWorkerArgsForCall[i].arg2
+}
+
+func (fake *FakeWorkerDB) SaveTeamWorkerReturns(result1 db.SavedWorker, result2 error) {
+	fake.SaveTeamWorkerStub = nil
+	fake.saveTeamWorkerReturns = struct {
+		result1 db.SavedWorker
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorkerDB) Workers() ([]db.SavedWorker, error) {
 	fake.workersMutex.Lock()
 	fake.workersArgsForCall = append(fake.workersArgsForCall, struct{}{})
@@ -92,13 +181,194 @@ func (fake *FakeWorkerDB) WorkersReturns(result1 []db.SavedWorker, result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeWorkerDB) GetWorker(workerName string) (db.SavedWorker, bool, error) {
+	fake.getWorkerMutex.Lock()
+	fake.getWorkerArgsForCall = append(fake.getWorkerArgsForCall, struct {
+		workerName string
+	}{workerName})
+	fake.recordInvocation("GetWorker", []interface{}{workerName})
+	fake.getWorkerMutex.Unlock()
+	if fake.GetWorkerStub != nil {
+		return fake.GetWorkerStub(workerName)
+	} else {
+		return fake.getWorkerReturns.result1, fake.getWorkerReturns.result2, fake.getWorkerReturns.result3
+	}
+}
+
+func (fake *FakeWorkerDB) GetWorkerCallCount() int {
+	fake.getWorkerMutex.RLock()
+	defer fake.getWorkerMutex.RUnlock()
+	return len(fake.getWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerDB) GetWorkerArgsForCall(i int) string {
+	fake.getWorkerMutex.RLock()
+	defer fake.getWorkerMutex.RUnlock()
+	return fake.getWorkerArgsForCall[i].workerName
+}
+
+func (fake *FakeWorkerDB) GetWorkerReturns(result1 db.SavedWorker, result2 bool, result3 error) {
+	fake.GetWorkerStub = nil
+	fake.getWorkerReturns = struct {
+		result1 db.SavedWorker
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerDB) GetTeamByName(teamName string) (db.SavedTeam, bool, error) {
+	fake.getTeamByNameMutex.Lock()
+	fake.getTeamByNameArgsForCall = append(fake.getTeamByNameArgsForCall, struct {
+		teamName string
+	}{teamName})
+	fake.recordInvocation("GetTeamByName", []interface{}{teamName})
+	fake.getTeamByNameMutex.Unlock()
+	if fake.GetTeamByNameStub != nil {
+		return fake.GetTeamByNameStub(teamName)
+	} else {
+		return fake.getTeamByNameReturns.result1, fake.getTeamByNameReturns.result2, fake.getTeamByNameReturns.result3
+	}
+}
+
+func (fake *FakeWorkerDB) GetTeamByNameCallCount() int {
+	fake.getTeamByNameMutex.RLock()
+	defer fake.getTeamByNameMutex.RUnlock()
+	return len(fake.getTeamByNameArgsForCall)
+}
+
+func (fake *FakeWorkerDB) GetTeamByNameArgsForCall(i int) string {
+	fake.getTeamByNameMutex.RLock()
+	defer fake.getTeamByNameMutex.RUnlock()
+	return fake.getTeamByNameArgsForCall[i].teamName
+}
+
+func (fake *FakeWorkerDB) GetTeamByNameReturns(result1 db.SavedTeam, result2 bool, result3 error) {
+	fake.GetTeamByNameStub = nil
+	fake.getTeamByNameReturns = struct {
+		result1 db.SavedTeam
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerDB) LandWorker(arg1 string) error {
+	fake.landWorkerMutex.Lock()
+	fake.landWorkerArgsForCall = append(fake.landWorkerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("LandWorker", []interface{}{arg1})
+	fake.landWorkerMutex.Unlock()
+	if fake.LandWorkerStub != nil {
+		return fake.LandWorkerStub(arg1)
+	} else {
+		return fake.landWorkerReturns.result1
+	}
+}
+
+func (fake *FakeWorkerDB) LandWorkerCallCount() int {
+	fake.landWorkerMutex.RLock()
+	defer fake.landWorkerMutex.RUnlock()
+	return len(fake.landWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerDB) LandWorkerArgsForCall(i int) string {
+	fake.landWorkerMutex.RLock()
+	defer fake.landWorkerMutex.RUnlock()
+	return fake.landWorkerArgsForCall[i].arg1
+}
+
+func (fake *FakeWorkerDB) LandWorkerReturns(result1 error) {
+	fake.LandWorkerStub = nil
+	fake.landWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerDB) RetireWorker(arg1 string) error {
+	fake.retireWorkerMutex.Lock()
+	fake.retireWorkerArgsForCall = append(fake.retireWorkerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("RetireWorker", []interface{}{arg1})
+	fake.retireWorkerMutex.Unlock()
+	if fake.RetireWorkerStub != nil {
+		return fake.RetireWorkerStub(arg1)
+	} else {
+		return fake.retireWorkerReturns.result1
+	}
+}
+
+func (fake *FakeWorkerDB) RetireWorkerCallCount() int {
+	fake.retireWorkerMutex.RLock()
+	defer fake.retireWorkerMutex.RUnlock()
+	return len(fake.retireWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerDB) RetireWorkerArgsForCall(i int) string {
+	fake.retireWorkerMutex.RLock()
+	defer fake.retireWorkerMutex.RUnlock()
+	return fake.retireWorkerArgsForCall[i].arg1
+}
+
+func (fake *FakeWorkerDB) RetireWorkerReturns(result1 error) {
+	fake.RetireWorkerStub = nil
+	fake.retireWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerDB) PruneWorker(arg1 string) error {
+	fake.pruneWorkerMutex.Lock()
+	fake.pruneWorkerArgsForCall = append(fake.pruneWorkerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("PruneWorker", []interface{}{arg1})
+	fake.pruneWorkerMutex.Unlock()
+	if fake.PruneWorkerStub != nil {
+		return fake.PruneWorkerStub(arg1)
+	} else {
+		return fake.pruneWorkerReturns.result1
+	}
+}
+
+func (fake *FakeWorkerDB) PruneWorkerCallCount() int {
+	fake.pruneWorkerMutex.RLock()
+	defer fake.pruneWorkerMutex.RUnlock()
+	return len(fake.pruneWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerDB) PruneWorkerArgsForCall(i int) string {
+	fake.pruneWorkerMutex.RLock()
+	defer fake.pruneWorkerMutex.RUnlock()
+	return fake.pruneWorkerArgsForCall[i].arg1
+}
+
+func (fake *FakeWorkerDB) PruneWorkerReturns(result1 error) {
+	fake.PruneWorkerStub = nil
+	fake.pruneWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeWorkerDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
+	fake.saveTeamWorkerMutex.RLock()
+	defer fake.saveTeamWorkerMutex.RUnlock()
 	fake.workersMutex.RLock()
 	defer fake.workersMutex.RUnlock()
+	fake.getWorkerMutex.RLock()
+	defer fake.getWorkerMutex.RUnlock()
+	fake.getTeamByNameMutex.RLock()
+	defer fake.getTeamByNameMutex.RUnlock()
+	fake.landWorkerMutex.RLock()
+	defer fake.landWorkerMutex.RUnlock()
+	fake.retireWorkerMutex.RLock()
+	defer fake.retireWorkerMutex.RUnlock()
+	fake.pruneWorkerMutex.RLock()
+	defer fake.pruneWorkerMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -0,0 +1,16 @@
+package atc
+
+// APIToken is a long-lived, revocable credential for automation. The token
+// is sent as "Authorization: Token <value>".
+type APIToken struct {
+	Name     string `json:"name"`
+	TeamName string `json:"team_name,omitempty"`
+	Role     string `json:"role,omitempty"`
+
+	CreatedAt  int64 `json:"created_at,omitempty"`
+	ExpiresAt  int64 `json:"expires_at,omitempty"`
+	LastUsedAt int64 `json:"last_used_at,omitempty"`
+
+	// Value is only returned when the token is created.
+	Value string `json:"value,omitempty"`
+}
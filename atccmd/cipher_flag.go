@@ -0,0 +1,56 @@
+package atccmd
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/concourse/atc/db/encryption"
+)
+
+// CipherFlag is an AES-256 key given as 64 hex characters or as standard
+// base64, e.g. the output of `openssl rand -hex 32` or `openssl rand -base64 32`.
+type CipherFlag struct {
+	key *encryption.Key
+}
+
+func (f *CipherFlag) UnmarshalFlag(value string) error {
+	raw, err := decodeKey(value)
+	if err != nil {
+		return err
+	}
+
+	if len(raw) != encryption.KeySize {
+		return fmt.Errorf("encryption key must decode to %d bytes, got %d", encryption.KeySize, len(raw))
+	}
+
+	key, err := encryption.NewAESGCMKey(raw)
+	if err != nil {
+		return err
+	}
+
+	f.key = key
+
+	return nil
+}
+
+func (f CipherFlag) Key() *encryption.Key {
+	return f.key
+}
+
+func decodeKey(value string) ([]byte, error) {
+	if len(value) == hex.EncodedLen(encryption.KeySize) {
+		raw, err := hex.DecodeString(value)
+		if err == nil {
+			return raw, nil
+		}
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.New("encryption key must be hex or base64 encoded")
+	}
+
+	return raw, nil
+}
@@ -0,0 +1,66 @@
+package atccmd_test
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	"github.com/concourse/atc/atccmd"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CipherFlag", func() {
+	var rawKey []byte
+
+	BeforeEach(func() {
+		rawKey = []byte(strings.Repeat("k", 32))
+	})
+
+	It("accepts a hex encoded key", func() {
+		flag := atccmd.CipherFlag{}
+
+		err := flag.UnmarshalFlag(hex.EncodeToString(rawKey))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(flag.Key()).ToNot(BeNil())
+	})
+
+	It("accepts a base64 encoded key", func() {
+		flag := atccmd.CipherFlag{}
+
+		err := flag.UnmarshalFlag(base64.StdEncoding.EncodeToString(rawKey))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(flag.Key()).ToNot(BeNil())
+	})
+
+	It("decrypts what a key decoded from another encoding encrypted", func() {
+		hexFlag := atccmd.CipherFlag{}
+		err := hexFlag.UnmarshalFlag(hex.EncodeToString(rawKey))
+		Expect(err).ToNot(HaveOccurred())
+
+		base64Flag := atccmd.CipherFlag{}
+		err = base64Flag.UnmarshalFlag(base64.StdEncoding.EncodeToString(rawKey))
+		Expect(err).ToNot(HaveOccurred())
+
+		encrypted, nonce, err := hexFlag.Key().Encrypt([]byte("secret"))
+		Expect(err).ToNot(HaveOccurred())
+
+		decrypted, err := base64Flag.Key().Decrypt(encrypted, nonce)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(decrypted).To(Equal([]byte("secret")))
+	})
+
+	It("rejects a key that decodes to the wrong length", func() {
+		flag := atccmd.CipherFlag{}
+
+		err := flag.UnmarshalFlag(base64.StdEncoding.EncodeToString([]byte("too-short")))
+		Expect(err).To(MatchError("encryption key must decode to 32 bytes, got 9"))
+	})
+
+	It("rejects a key that is not encoded", func() {
+		flag := atccmd.CipherFlag{}
+
+		err := flag.UnmarshalFlag("not a key!")
+		Expect(err).To(MatchError("encryption key must be hex or base64 encoded"))
+	})
+})
@@ -23,6 +23,7 @@ import (
 	"github.com/concourse/atc/builds"
 	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/containerkeepaliver"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/migrations"
 	"github.com/concourse/atc/engine"
@@ -39,6 +40,7 @@ import (
 	"github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/image"
 	"github.com/concourse/atc/worker/transport"
+	"github.com/concourse/atc/workerhealth"
 	"github.com/concourse/atc/wrappa"
 	"github.com/dgrijalva/jwt-go"
 	"github.com/gorilla/context"
@@ -68,6 +70,9 @@ type ATCCommand struct {
 
 	PostgresDataSource string `long:"postgres-data-source" default:"postgres://127.0.0.1:5432/atc?sslmode=disable" description:"PostgreSQL connection string."`
 
+	EncryptionKey    CipherFlag `long:"encryption-key"     description:"A 32-byte key, hex or base64 encoded, used to encrypt pipeline configs, team auth settings and build metadata at rest."`
+	OldEncryptionKey CipherFlag `long:"old-encryption-key" description:"The previous encryption key. Data encrypted with it is re-encrypted with --encryption-key, or decrypted if none is given."`
+
 	DebugBindIP   IPFlag `long:"debug-bind-ip"   default:"127.0.0.1" description:"IP address on which to listen for the pprof debugger endpoints."`
 	DebugBindPort uint16 `long:"debug-bind-port" default:"8079"      description:"Port on which to listen for the pprof debugger endpoints."`
 
@@ -78,9 +83,16 @@ type ATCCommand struct {
 	ResourceCheckingInterval     time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
 	OldResourceGracePeriod       time.Duration `long:"old-resource-grace-period" default:"5m" description:"How long to cache the result of a get step after a newer version of the resource is found."`
 	ResourceCacheCleanupInterval time.Duration `long:"resource-cache-cleanup-interval" default:"30s" description:"Interval on which to cleanup old caches of resources."`
+	TaskCacheTTL                 time.Duration `long:"task-cache-ttl"                  default:"24h" description:"How long to keep a task's cache on a worker after the last build that used it."`
+
+	WorkerHealthCheckInterval time.Duration `long:"worker-health-check-interval" default:"30s" description:"Interval on which to probe the Garden and Baggageclaim servers of each worker."`
+	WorkerHealthCheckTimeout  time.Duration `long:"worker-health-check-timeout"  default:"5s"  description:"How long to wait for a worker to respond to a health check before considering it unhealthy."`
+	WorkerHealthCheckFailures int           `long:"worker-health-check-failures" default:"3"   description:"Number of health checks in a row a worker must fail before no new containers are placed on it."`
 
 	CLIArtifactsDir DirFlag `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
+	ContainerPlacementStrategy string `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"fewest-active-containers" choice:"random" description:"Method by which a worker is selected during container placement."`
+
 	Developer struct {
 		DevelopmentMode bool `short:"d" long:"development-mode"  description:"Lax security rules to make local development easier."`
 		Noop            bool `short:"n" long:"noop"              description:"Don't actually do any automatic scheduling or checking."`
@@ -108,6 +120,36 @@ type ATCCommand struct {
 		APIURL        string           `long:"api-url"       description:"Override default API endpoint URL for Github Enterprise"`
 	} `group:"GitHub Authentication" namespace:"github-auth"`
 
+	GitLabAuth struct {
+		ClientID     string   `long:"client-id"     description:"Application client ID for enabling GitLab OAuth."`
+		ClientSecret string   `long:"client-secret" description:"Application client secret for enabling GitLab OAuth."`
+		Groups       []string `long:"group"         description:"GitLab group whose members will have access." value-name:"GROUP"`
+		Projects     []string `long:"project"       description:"GitLab project whose members will have access." value-name:"GROUP/PROJECT"`
+		Users        []string `long:"user"          description:"GitLab user to permit access." value-name:"USERNAME"`
+		URL          string   `long:"url"           description:"Base URL of a self-hosted GitLab instance." default:"https://gitlab.com"`
+	} `group:"GitLab Authentication" namespace:"gitlab-auth"`
+
+	OIDCAuth struct {
+		DisplayName  string   `long:"display-name"  description:"Name of the OpenID Connect provider to show on the login page."`
+		Issuer       string   `long:"issuer"        description:"OpenID Connect issuer URL, used for endpoint discovery."`
+		ClientID     string   `long:"client-id"     description:"Application client ID for enabling OpenID Connect auth."`
+		ClientSecret string   `long:"client-secret" description:"Application client secret for enabling OpenID Connect auth."`
+		Scopes       []string `long:"scope"         description:"Additional scope to request. Can be specified multiple times." value-name:"SCOPE"`
+		GroupsClaim  string   `long:"groups-claim"  description:"ID token claim listing the user's groups." default:"groups"`
+		Groups       []string `long:"group"         description:"Group whose members will have access." value-name:"GROUP"`
+		Users        []string `long:"user"          description:"Email address of a user to permit access." value-name:"EMAIL"`
+	} `group:"OpenID Connect Authentication" namespace:"oidc-auth"`
+
+	Credentials struct {
+		File FileFlag `long:"file" description:"YAML file mapping TEAM/PIPELINE/VAR or TEAM/VAR to values for ((var)) placeholders."`
+	} `group:"Credential Management" namespace:"credentials"`
+
+	Vault struct {
+		URL        URLFlag `long:"url"         description:"Vault server address used to resolve ((var)) placeholders."`
+		Token      string  `long:"token"       description:"Vault token used to read credentials."`
+		PathPrefix string  `long:"path-prefix" description:"Path under which to look up credentials." default:"/concourse"`
+	} `group:"Vault Credential Management" namespace:"vault"`
+
 	Metrics struct {
 		HostName   string            `long:"metrics-host-name"   description:"Host string to attach to emitted metrics."`
 		Tags       []string          `long:"metrics-tag"         description:"Tag to attach to emitted metrics. Can be specified multiple times." value-name:"TAG"`
@@ -146,22 +188,32 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 	}
 
 	trackerFactory := resource.TrackerFactory{}
-	workerClient := cmd.constructWorkerPool(logger, sqlDB, trackerFactory)
+	workerClient, err := cmd.constructWorkerPool(logger, sqlDB, trackerFactory)
+	if err != nil {
+		return nil, err
+	}
 
 	tracker := resource.NewTracker(workerClient)
 	engine := cmd.constructEngine(sqlDB, workerClient, tracker)
 
+	credentialManager, err := cmd.constructCredentialManager()
+	if err != nil {
+		return nil, err
+	}
+
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
 		tracker,
 		cmd.ResourceCheckingInterval,
 		engine,
 		sqlDB,
+		credentialManager,
 	)
 
 	radarScannerFactory := radar.NewScannerFactory(
 		tracker,
 		cmd.ResourceCheckingInterval,
 		cmd.ExternalURL.String(),
+		credentialManager,
 	)
 
 	signingKey, err := cmd.loadOrGenerateSigningKey()
@@ -174,15 +226,20 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		return nil, err
 	}
 
-	authValidator := cmd.constructValidator(signingKey, sqlDB)
+	apiTokenValidator := auth.NewAPITokenValidator(sqlDB, clock.NewClock())
+
+	authValidator := cmd.constructValidator(signingKey, sqlDB, apiTokenValidator)
 
 	err = cmd.updateBasicAuthCredentials(sqlDB)
 	if err != nil {
 		return nil, err
 	}
 
-	jwtReader := auth.JWTReader{
-		PublicKey: &signingKey.PublicKey,
+	userContextReader := auth.UserContextReaderBasket{
+		auth.JWTReader{
+			PublicKey: &signingKey.PublicKey,
+		},
+		apiTokenValidator,
 	}
 
 	err = cmd.configureOAuthProviders(logger, sqlDB)
@@ -191,6 +248,7 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 	}
 
 	providerFactory := provider.NewOAuthFactory(
+		logger.Session("oauth-provider-factory"),
 		sqlDB,
 		cmd.oauthBaseURL(),
 		auth.OAuthRoutes,
@@ -207,7 +265,7 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		reconfigurableSink,
 		sqlDB,
 		authValidator,
-		jwtReader,
+		userContextReader,
 		providerFactory,
 		signingKey,
 		pipelineDBFactory,
@@ -234,7 +292,7 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 	webHandler, err := cmd.constructWebHandler(
 		logger,
 		authValidator,
-		jwtReader,
+		userContextReader,
 		pipelineDBFactory,
 	)
 	if err != nil {
@@ -343,6 +401,19 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 			clock.NewClock(),
 			30*time.Second,
 		)},
+
+		{"workerhealth", leaserunner.NewRunner(
+			logger.Session("worker-health-checker-runner"),
+			workerhealth.NewHealthChecker(
+				logger.Session("worker-health-checker"),
+				sqlDB,
+				workerhealth.NewProber(clock.NewClock(), cmd.WorkerHealthCheckTimeout),
+			),
+			"worker-health-checker",
+			sqlDB,
+			clock.NewClock(),
+			cmd.WorkerHealthCheckInterval,
+		)},
 	}
 
 	members = cmd.appendStaticWorker(logger, sqlDB, members)
@@ -412,7 +483,7 @@ func (cmd *ATCCommand) oauthBaseURL() string {
 }
 
 func (cmd *ATCCommand) authConfigured() bool {
-	return cmd.basicAuthConfigured() || cmd.gitHubAuthConfigured()
+	return cmd.basicAuthConfigured() || cmd.gitHubAuthConfigured() || cmd.gitLabAuthConfigured() || cmd.oidcAuthConfigured()
 }
 
 func (cmd *ATCCommand) basicAuthConfigured() bool {
@@ -425,6 +496,17 @@ func (cmd *ATCCommand) gitHubAuthConfigured() bool {
 		len(cmd.GitHubAuth.Users) > 0
 }
 
+func (cmd *ATCCommand) gitLabAuthConfigured() bool {
+	return len(cmd.GitLabAuth.Groups) > 0 ||
+		len(cmd.GitLabAuth.Projects) > 0 ||
+		len(cmd.GitLabAuth.Users) > 0
+}
+
+func (cmd *ATCCommand) oidcAuthConfigured() bool {
+	return len(cmd.OIDCAuth.Groups) > 0 ||
+		len(cmd.OIDCAuth.Users) > 0
+}
+
 func (cmd *ATCCommand) validate() error {
 	var errs *multierror.Error
 
@@ -451,6 +533,38 @@ func (cmd *ATCCommand) validate() error {
 		}
 	}
 
+	if cmd.gitLabAuthConfigured() {
+		if cmd.ExternalURL.URL() == nil {
+			errs = multierror.Append(
+				errs,
+				errors.New("must specify --external-url to use OAuth"),
+			)
+		}
+
+		if cmd.GitLabAuth.ClientID == "" || cmd.GitLabAuth.ClientSecret == "" {
+			errs = multierror.Append(
+				errs,
+				errors.New("must specify --gitlab-auth-client-id and --gitlab-auth-client-secret to use GitLab OAuth"),
+			)
+		}
+	}
+
+	if cmd.oidcAuthConfigured() {
+		if cmd.ExternalURL.URL() == nil {
+			errs = multierror.Append(
+				errs,
+				errors.New("must specify --external-url to use OAuth"),
+			)
+		}
+
+		if cmd.OIDCAuth.Issuer == "" || cmd.OIDCAuth.ClientID == "" || cmd.OIDCAuth.ClientSecret == "" {
+			errs = multierror.Append(
+				errs,
+				errors.New("must specify --oidc-auth-issuer, --oidc-auth-client-id, and --oidc-auth-client-secret to use OpenID Connect auth"),
+			)
+		}
+	}
+
 	if cmd.basicAuthConfigured() {
 		if cmd.BasicAuth.Username == "" {
 			errs = multierror.Append(
@@ -466,6 +580,20 @@ func (cmd *ATCCommand) validate() error {
 		}
 	}
 
+	if cmd.Credentials.File != "" && cmd.Vault.URL.URL() != nil {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify only one of --credentials-file and --vault-url"),
+		)
+	}
+
+	if cmd.Vault.URL.URL() != nil && cmd.Vault.Token == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --vault-token to use Vault"),
+		)
+	}
+
 	tlsFlagCount := 0
 	if cmd.TLSBindPort != 0 {
 		tlsFlagCount++
@@ -565,7 +693,13 @@ func (cmd *ATCCommand) constructDB(logger lager.Logger) (*db.SQLDB, db.PipelineD
 	driverName := "connection-counting"
 	metric.SetupConnectionCountingDriver("postgres", cmd.PostgresDataSource, driverName)
 
-	dbConn, err := migrations.LockDBAndMigrate(logger.Session("db.migrations"), driverName, cmd.PostgresDataSource)
+	dbConn, err := migrations.LockDBAndMigrate(
+		logger.Session("db.migrations"),
+		driverName,
+		cmd.PostgresDataSource,
+		cmd.EncryptionKey.Key(),
+		cmd.OldEncryptionKey.Key(),
+	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to migrate database: %s", err)
 	}
@@ -582,7 +716,12 @@ func (cmd *ATCCommand) constructDB(logger lager.Logger) (*db.SQLDB, db.PipelineD
 	return sqlDB, pipelineDBFactory, err
 }
 
-func (cmd *ATCCommand) constructWorkerPool(logger lager.Logger, sqlDB *db.SQLDB, trackerFactory resource.TrackerFactory) worker.Client {
+func (cmd *ATCCommand) constructWorkerPool(logger lager.Logger, sqlDB *db.SQLDB, trackerFactory resource.TrackerFactory) (worker.Client, error) {
+	strategy, err := worker.NewContainerPlacementStrategy(cmd.ContainerPlacementStrategy)
+	if err != nil {
+		return nil, err
+	}
+
 	return worker.NewPool(
 		worker.NewDBWorkerProvider(
 			logger,
@@ -592,8 +731,11 @@ func (cmd *ATCCommand) constructWorkerPool(logger lager.Logger, sqlDB *db.SQLDB,
 				Timeout: 5 * time.Minute,
 			},
 			image.NewFetcher(trackerFactory),
+			cmd.WorkerHealthCheckFailures,
 		),
-	)
+		strategy,
+		clock.NewClock(),
+	), nil
 }
 
 func (cmd *ATCCommand) loadOrGenerateSigningKey() (*rsa.PrivateKey, error) {
@@ -658,10 +800,48 @@ func (cmd *ATCCommand) configureOAuthProviders(logger lager.Logger, sqlDB db.DB)
 		return err
 	}
 
+	if cmd.gitLabAuthConfigured() {
+		team.GitLabAuth = db.GitLabAuth{
+			ClientID:     cmd.GitLabAuth.ClientID,
+			ClientSecret: cmd.GitLabAuth.ClientSecret,
+			Groups:       cmd.GitLabAuth.Groups,
+			Projects:     cmd.GitLabAuth.Projects,
+			Users:        cmd.GitLabAuth.Users,
+			URL:          cmd.GitLabAuth.URL,
+		}
+	} else {
+		team.GitLabAuth = db.GitLabAuth{}
+	}
+
+	_, err = sqlDB.UpdateTeamGitLabAuth(team)
+	if err != nil {
+		return err
+	}
+
+	if cmd.oidcAuthConfigured() {
+		team.OIDCAuth = db.OIDCAuth{
+			DisplayName:  cmd.OIDCAuth.DisplayName,
+			Issuer:       cmd.OIDCAuth.Issuer,
+			ClientID:     cmd.OIDCAuth.ClientID,
+			ClientSecret: cmd.OIDCAuth.ClientSecret,
+			Scopes:       cmd.OIDCAuth.Scopes,
+			GroupsClaim:  cmd.OIDCAuth.GroupsClaim,
+			Groups:       cmd.OIDCAuth.Groups,
+			Users:        cmd.OIDCAuth.Users,
+		}
+	} else {
+		team.OIDCAuth = db.OIDCAuth{}
+	}
+
+	_, err = sqlDB.UpdateTeamOIDCAuth(team)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
-func (cmd *ATCCommand) constructValidator(signingKey *rsa.PrivateKey, sqlDB db.DB) auth.Validator {
+func (cmd *ATCCommand) constructValidator(signingKey *rsa.PrivateKey, sqlDB db.DB, apiTokenValidator auth.Validator) auth.Validator {
 	if !cmd.authConfigured() {
 		return auth.NoopValidator{}
 	}
@@ -677,9 +857,13 @@ func (cmd *ATCCommand) constructValidator(signingKey *rsa.PrivateKey, sqlDB db.D
 				DB: sqlDB,
 			},
 			jwtValidator,
+			apiTokenValidator,
 		}
 	} else {
-		validator = jwtValidator
+		validator = auth.ValidatorBasket{
+			jwtValidator,
+			apiTokenValidator,
+		}
 	}
 
 	return validator
@@ -703,6 +887,22 @@ func (cmd *ATCCommand) updateBasicAuthCredentials(sqlDB db.DB) error {
 	return err
 }
 
+func (cmd *ATCCommand) constructCredentialManager() (creds.CredentialManager, error) {
+	if cmd.Credentials.File != "" {
+		return creds.NewFileManager(string(cmd.Credentials.File))
+	}
+
+	if cmd.Vault.URL.URL() != nil {
+		return creds.VaultManager{
+			URL:        cmd.Vault.URL.String(),
+			Token:      cmd.Vault.Token,
+			PathPrefix: cmd.Vault.PathPrefix,
+		}, nil
+	}
+
+	return nil, nil
+}
+
 func (cmd *ATCCommand) constructEngine(
 	sqlDB *db.SQLDB,
 	workerClient worker.Client,
@@ -711,6 +911,7 @@ func (cmd *ATCCommand) constructEngine(
 	gardenFactory := exec.NewGardenFactory(
 		workerClient,
 		tracker,
+		cmd.TaskCacheTTL,
 	)
 
 	execV2Engine := engine.NewExecEngine(
@@ -768,6 +969,8 @@ func (cmd *ATCCommand) constructAPIHandler(
 ) (http.Handler, http.Handler, error) {
 	apiWrapper := wrappa.MultiWrappa{
 		wrappa.NewAPIAuthWrappa(cmd.PubliclyViewable, authValidator, userContextReader),
+		// wraps the auth handlers, so that rejected requests are audited too
+		wrappa.NewAPIAuditWrappa(logger, sqlDB),
 		wrappa.NewAPIMetricsWrappa(logger),
 		wrappa.NewConcourseVersionWrappa(Version),
 	}
@@ -797,6 +1000,8 @@ func (cmd *ATCCommand) constructAPIHandler(
 		sqlDB, // pipes.PipeDB
 		sqlDB, // db.PipelinesDB
 		sqlDB, // teamserver.TeamDB
+		sqlDB, // apitokenserver.APITokenDB
+		sqlDB, // auditserver.AuditDB
 
 		config.ValidateConfig,
 		cmd.PeerURL.String(),
@@ -0,0 +1,16 @@
+package atc
+
+type AuditEvent struct {
+	ID        int    `json:"id"`
+	CreatedAt int64  `json:"created_at"`
+	TeamName  string `json:"team_name"`
+	TeamID    int    `json:"team_id,omitempty"`
+	UserName  string `json:"user_name,omitempty"`
+	Route     string `json:"route"`
+
+	PipelineName string `json:"pipeline_name,omitempty"`
+	JobName      string `json:"job_name,omitempty"`
+	ResourceName string `json:"resource_name,omitempty"`
+
+	Status int `json:"status,omitempty"`
+}
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// APITokenType is the Authorization header scheme for long-lived API tokens,
+// e.g. "Authorization: Token <value>".
+const APITokenType = "Token"
+
+const apiTokenLength = 32
+
+// GenerateAPIToken returns a new random token value along with the hash under
+// which it is stored. Only the hash is persisted; the value is handed to the
+// user once.
+func GenerateAPIToken() (string, string, error) {
+	randomBytes := make([]byte, apiTokenLength)
+
+	_, err := rand.Read(randomBytes)
+	if err != nil {
+		return "", "", err
+	}
+
+	value := hex.EncodeToString(randomBytes)
+
+	return value, HashAPIToken(value), nil
+}
+
+func HashAPIToken(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func extractAPIToken(authorizationHeader string) (string, bool) {
+	prefix := strings.ToUpper(APITokenType) + " "
+	if !strings.HasPrefix(strings.ToUpper(authorizationHeader), prefix) {
+		return "", false
+	}
+
+	value := strings.TrimSpace(authorizationHeader[len(prefix):])
+	if value == "" {
+		return "", false
+	}
+
+	return value, true
+}
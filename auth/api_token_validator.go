@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pivotal-golang/clock"
+
+	"github.com/concourse/atc/db"
+)
+
+const APITokenUserPrefix = "token:"
+
+// APITokenCacheTTL is how long a validated token is trusted before it is
+// looked up again, which bounds how long a revoked token keeps working.
+const APITokenCacheTTL = 10 * time.Second
+
+// APITokenLastUsedInterval is how often a token's last_used_at is written
+// back while it is being used.
+const APITokenLastUsedInterval = time.Minute
+
+//go:generate counterfeiter . APITokenDB
+
+type APITokenDB interface {
+	FindAPITokenByHash(tokenHash string) (db.SavedAPIToken, bool, error)
+	UpdateAPITokenLastUsed(tokenID int) error
+}
+
+// APITokenValidator authenticates requests carrying a long-lived API token
+// and reads the team and role the token was issued for.
+type APITokenValidator struct {
+	db    APITokenDB
+	clock clock.Clock
+
+	tokensL sync.Mutex
+	tokens  map[string]cachedAPIToken
+}
+
+type cachedAPIToken struct {
+	token       db.SavedAPIToken
+	validatedAt time.Time
+	usedAt      time.Time
+}
+
+func NewAPITokenValidator(db APITokenDB, clock clock.Clock) *APITokenValidator {
+	return &APITokenValidator{
+		db:    db,
+		clock: clock,
+
+		tokens: map[string]cachedAPIToken{},
+	}
+}
+
+func (validator *APITokenValidator) IsAuthenticated(r *http.Request) bool {
+	token, found := validator.findToken(r)
+	if !found {
+		return false
+	}
+
+	if validator.shouldRecordUse(token.hash) {
+		// failing to record usage should not lock out the token
+		_ = validator.db.UpdateAPITokenLastUsed(token.ID)
+	}
+
+	return true
+}
+
+func (validator *APITokenValidator) GetTeam(r *http.Request) (string, int, bool, bool) {
+	token, found := validator.findToken(r)
+	if !found {
+		return "", 0, false, false
+	}
+
+	return token.TeamName, token.TeamID, token.TeamAdmin, true
+}
+
+func (validator *APITokenValidator) GetRole(r *http.Request) (string, bool) {
+	token, found := validator.findToken(r)
+	if !found {
+		return "", false
+	}
+
+	return token.Role, true
+}
+
+// GetUser identifies requests by the name of the token, e.g. "token:ci-bot".
+func (validator *APITokenValidator) GetUser(r *http.Request) (string, bool) {
+	token, found := validator.findToken(r)
+	if !found {
+		return "", false
+	}
+
+	return APITokenUserPrefix + token.Name, true
+}
+
+type presentedAPIToken struct {
+	db.SavedAPIToken
+
+	hash string
+}
+
+func (validator *APITokenValidator) findToken(r *http.Request) (presentedAPIToken, bool) {
+	value, found := extractAPIToken(r.Header.Get("Authorization"))
+	if !found {
+		return presentedAPIToken{}, false
+	}
+
+	hash := HashAPIToken(value)
+	now := validator.clock.Now()
+
+	validator.tokensL.Lock()
+	cached, found := validator.tokens[hash]
+	validator.tokensL.Unlock()
+
+	if found && now.Sub(cached.validatedAt) < APITokenCacheTTL {
+		if !cached.token.ExpiresAt.IsZero() && !now.Before(cached.token.ExpiresAt) {
+			validator.forget(hash)
+			return presentedAPIToken{}, false
+		}
+
+		return presentedAPIToken{SavedAPIToken: cached.token, hash: hash}, true
+	}
+
+	token, found, err := validator.db.FindAPITokenByHash(hash)
+	if err != nil {
+		return presentedAPIToken{}, false
+	}
+
+	if !found {
+		validator.forget(hash)
+		return presentedAPIToken{}, false
+	}
+
+	validator.tokensL.Lock()
+	validator.tokens[hash] = cachedAPIToken{
+		token:       token,
+		validatedAt: now,
+		usedAt:      cached.usedAt,
+	}
+	validator.tokensL.Unlock()
+
+	return presentedAPIToken{SavedAPIToken: token, hash: hash}, true
+}
+
+// shouldRecordUse reports whether enough time has passed since the token's
+// use was last written back, and if so marks it as recorded now.
+func (validator *APITokenValidator) shouldRecordUse(hash string) bool {
+	now := validator.clock.Now()
+
+	validator.tokensL.Lock()
+	defer validator.tokensL.Unlock()
+
+	cached, found := validator.tokens[hash]
+	if !found {
+		return true
+	}
+
+	if !cached.usedAt.IsZero() && now.Sub(cached.usedAt) < APITokenLastUsedInterval {
+		return false
+	}
+
+	cached.usedAt = now
+	validator.tokens[hash] = cached
+
+	return true
+}
+
+func (validator *APITokenValidator) forget(hash string) {
+	validator.tokensL.Lock()
+	delete(validator.tokens, hash)
+	validator.tokensL.Unlock()
+}
@@ -0,0 +1,197 @@
+package auth_test
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/clock/fakeclock"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+	"github.com/concourse/atc/db"
+)
+
+var _ = Describe("APITokenValidator", func() {
+	var (
+		fakeAPITokenDB *authfakes.FakeAPITokenDB
+		fakeClock      *fakeclock.FakeClock
+		validator      *auth.APITokenValidator
+
+		request *http.Request
+	)
+
+	BeforeEach(func() {
+		fakeAPITokenDB = new(authfakes.FakeAPITokenDB)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+		validator = auth.NewAPITokenValidator(fakeAPITokenDB, fakeClock)
+
+		var err error
+		request, err = http.NewRequest("GET", "http://example.com", nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when the request carries an API token", func() {
+		BeforeEach(func() {
+			request.Header.Set("Authorization", "Token some-token")
+		})
+
+		Context("when the token is known", func() {
+			BeforeEach(func() {
+				fakeAPITokenDB.FindAPITokenByHashReturns(db.SavedAPIToken{
+					ID: 7,
+					APIToken: db.APIToken{
+						TeamID: 42,
+						Name:   "ci-bot",
+						Role:   atc.RoleMember,
+					},
+					TeamName:  "some-team",
+					TeamAdmin: false,
+				}, true, nil)
+			})
+
+			It("authenticates the request", func() {
+				Expect(validator.IsAuthenticated(request)).To(BeTrue())
+			})
+
+			It("looks up the token by its hash", func() {
+				validator.IsAuthenticated(request)
+
+				Expect(fakeAPITokenDB.FindAPITokenByHashCallCount()).To(Equal(1))
+				Expect(fakeAPITokenDB.FindAPITokenByHashArgsForCall(0)).To(Equal(auth.HashAPIToken("some-token")))
+			})
+
+			It("records that the token was used", func() {
+				validator.IsAuthenticated(request)
+
+				Expect(fakeAPITokenDB.UpdateAPITokenLastUsedCallCount()).To(Equal(1))
+				Expect(fakeAPITokenDB.UpdateAPITokenLastUsedArgsForCall(0)).To(Equal(7))
+			})
+
+			It("returns the team the token was issued for", func() {
+				teamName, teamID, isAdmin, found := validator.GetTeam(request)
+				Expect(found).To(BeTrue())
+				Expect(teamName).To(Equal("some-team"))
+				Expect(teamID).To(Equal(42))
+				Expect(isAdmin).To(BeFalse())
+			})
+
+			It("identifies the user by the token's name", func() {
+				user, found := validator.GetUser(request)
+				Expect(found).To(BeTrue())
+				Expect(user).To(Equal("token:ci-bot"))
+			})
+
+			It("returns the role of the token", func() {
+				role, found := validator.GetRole(request)
+				Expect(found).To(BeTrue())
+				Expect(role).To(Equal(atc.RoleMember))
+			})
+
+			It("reuses the validated token for subsequent lookups", func() {
+				validator.IsAuthenticated(request)
+				validator.GetTeam(request)
+				validator.GetRole(request)
+				validator.GetUser(request)
+
+				Expect(fakeAPITokenDB.FindAPITokenByHashCallCount()).To(Equal(1))
+			})
+
+			It("looks up the token again once the cache TTL has elapsed", func() {
+				validator.IsAuthenticated(request)
+
+				fakeClock.Increment(auth.APITokenCacheTTL)
+
+				validator.IsAuthenticated(request)
+				Expect(fakeAPITokenDB.FindAPITokenByHashCallCount()).To(Equal(2))
+			})
+
+			It("records use at most once per interval", func() {
+				validator.IsAuthenticated(request)
+				validator.IsAuthenticated(request)
+				Expect(fakeAPITokenDB.UpdateAPITokenLastUsedCallCount()).To(Equal(1))
+
+				fakeClock.Increment(auth.APITokenLastUsedInterval)
+
+				validator.IsAuthenticated(request)
+				Expect(fakeAPITokenDB.UpdateAPITokenLastUsedCallCount()).To(Equal(2))
+			})
+
+			Context("when the token is revoked after being validated", func() {
+				BeforeEach(func() {
+					Expect(validator.IsAuthenticated(request)).To(BeTrue())
+
+					fakeAPITokenDB.FindAPITokenByHashReturns(db.SavedAPIToken{}, false, nil)
+				})
+
+				It("stops authenticating once the cache TTL has elapsed", func() {
+					fakeClock.Increment(auth.APITokenCacheTTL)
+
+					Expect(validator.IsAuthenticated(request)).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when the token expires while cached", func() {
+			BeforeEach(func() {
+				fakeAPITokenDB.FindAPITokenByHashReturns(db.SavedAPIToken{
+					ID: 7,
+					APIToken: db.APIToken{
+						TeamID:    42,
+						Name:      "ci-bot",
+						Role:      atc.RoleMember,
+						ExpiresAt: time.Unix(123, 456).Add(time.Second),
+					},
+				}, true, nil)
+
+				Expect(validator.IsAuthenticated(request)).To(BeTrue())
+			})
+
+			It("stops authenticating at the expiry time", func() {
+				fakeClock.Increment(time.Second)
+
+				Expect(validator.IsAuthenticated(request)).To(BeFalse())
+			})
+		})
+
+		Context("when the token is unknown or expired", func() {
+			BeforeEach(func() {
+				fakeAPITokenDB.FindAPITokenByHashReturns(db.SavedAPIToken{}, false, nil)
+			})
+
+			It("does not authenticate the request", func() {
+				Expect(validator.IsAuthenticated(request)).To(BeFalse())
+				Expect(fakeAPITokenDB.UpdateAPITokenLastUsedCallCount()).To(BeZero())
+			})
+
+			It("does not find a team", func() {
+				_, _, _, found := validator.GetTeam(request)
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when looking up the token fails", func() {
+			BeforeEach(func() {
+				fakeAPITokenDB.FindAPITokenByHashReturns(db.SavedAPIToken{}, false, errors.New("nope"))
+			})
+
+			It("does not authenticate the request", func() {
+				Expect(validator.IsAuthenticated(request)).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when the request carries other credentials", func() {
+		BeforeEach(func() {
+			request.Header.Set("Authorization", "Bearer some-jwt")
+		})
+
+		It("does not authenticate the request", func() {
+			Expect(validator.IsAuthenticated(request)).To(BeFalse())
+			Expect(fakeAPITokenDB.FindAPITokenByHashCallCount()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,124 @@
+// This file was generated by counterfeiter
+package authfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+type FakeAPITokenDB struct {
+	FindAPITokenByHashStub        func(tokenHash string) (db.SavedAPIToken, bool, error)
+	findAPITokenByHashMutex       sync.RWMutex
+	findAPITokenByHashArgsForCall []struct {
+		tokenHash string
+	}
+	findAPITokenByHashReturns struct {
+		result1 db.SavedAPIToken
+		result2 bool
+		result3 error
+	}
+	UpdateAPITokenLastUsedStub        func(tokenID int) error
+	updateAPITokenLastUsedMutex       sync.RWMutex
+	updateAPITokenLastUsedArgsForCall []struct {
+		tokenID int
+	}
+	updateAPITokenLastUsedReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAPITokenDB) FindAPITokenByHash(tokenHash string) (db.SavedAPIToken, bool, error) {
+	fake.findAPITokenByHashMutex.Lock()
+	fake.findAPITokenByHashArgsForCall = append(fake.findAPITokenByHashArgsForCall, struct {
+		tokenHash string
+	}{tokenHash})
+	fake.recordInvocation("FindAPITokenByHash", []interface{}{tokenHash})
+	fake.findAPITokenByHashMutex.Unlock()
+	if fake.FindAPITokenByHashStub != nil {
+		return fake.FindAPITokenByHashStub(tokenHash)
+	} else {
+		return fake.findAPITokenByHashReturns.result1, fake.findAPITokenByHashReturns.result2, fake.findAPITokenByHashReturns.result3
+	}
+}
+
+func (fake *FakeAPITokenDB) FindAPITokenByHashCallCount() int {
+	fake.findAPITokenByHashMutex.RLock()
+	defer fake.findAPITokenByHashMutex.RUnlock()
+	return len(fake.findAPITokenByHashArgsForCall)
+}
+
+func (fake *FakeAPITokenDB) FindAPITokenByHashArgsForCall(i int) string {
+	fake.findAPITokenByHashMutex.RLock()
+	defer fake.findAPITokenByHashMutex.RUnlock()
+	return fake.findAPITokenByHashArgsForCall[i].tokenHash
+}
+
+func (fake *FakeAPITokenDB) FindAPITokenByHashReturns(result1 db.SavedAPIToken, result2 bool, result3 error) {
+	fake.FindAPITokenByHashStub = nil
+	fake.findAPITokenByHashReturns = struct {
+		result1 db.SavedAPIToken
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAPITokenDB) UpdateAPITokenLastUsed(tokenID int) error {
+	fake.updateAPITokenLastUsedMutex.Lock()
+	fake.updateAPITokenLastUsedArgsForCall = append(fake.updateAPITokenLastUsedArgsForCall, struct {
+		tokenID int
+	}{tokenID})
+	fake.recordInvocation("UpdateAPITokenLastUsed", []interface{}{tokenID})
+	fake.updateAPITokenLastUsedMutex.Unlock()
+	if fake.UpdateAPITokenLastUsedStub != nil {
+		return fake.UpdateAPITokenLastUsedStub(tokenID)
+	} else {
+		return fake.updateAPITokenLastUsedReturns.result1
+	}
+}
+
+func (fake *FakeAPITokenDB) UpdateAPITokenLastUsedCallCount() int {
+	fake.updateAPITokenLastUsedMutex.RLock()
+	defer fake.updateAPITokenLastUsedMutex.RUnlock()
+	return len(fake.updateAPITokenLastUsedArgsForCall)
+}
+
+func (fake *FakeAPITokenDB) UpdateAPITokenLastUsedArgsForCall(i int) int {
+	fake.updateAPITokenLastUsedMutex.RLock()
+	defer fake.updateAPITokenLastUsedMutex.RUnlock()
+	return fake.updateAPITokenLastUsedArgsForCall[i].tokenID
+}
+
+func (fake *FakeAPITokenDB) UpdateAPITokenLastUsedReturns(result1 error) {
+	fake.UpdateAPITokenLastUsedStub = nil
+	fake.updateAPITokenLastUsedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPITokenDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.findAPITokenByHashMutex.RLock()
+	defer fake.findAPITokenByHashMutex.RUnlock()
+	fake.updateAPITokenLastUsedMutex.RLock()
+	defer fake.updateAPITokenLastUsedMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeAPITokenDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ auth.APITokenDB = new(FakeAPITokenDB)
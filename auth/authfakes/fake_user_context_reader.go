@@ -20,6 +20,24 @@ type FakeUserContextReader struct {
 		result3 bool
 		result4 bool
 	}
+	GetRoleStub        func(r *http.Request) (string, bool)
+	getRoleMutex       sync.RWMutex
+	getRoleArgsForCall []struct {
+		r *http.Request
+	}
+	getRoleReturns struct {
+		result1 string
+		result2 bool
+	}
+	GetUserStub        func(r *http.Request) (string, bool)
+	getUserMutex       sync.RWMutex
+	getUserArgsForCall []struct {
+		r *http.Request
+	}
+	getUserReturns struct {
+		result1 string
+		result2 bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -60,11 +78,83 @@ func (fake *FakeUserContextReader) GetTeamReturns(result1 string, result2 int, r
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeUserContextReader) GetRole(r *http.Request) (string, bool) {
+	fake.getRoleMutex.Lock()
+	fake.getRoleArgsForCall = append(fake.getRoleArgsForCall, struct {
+		r *http.Request
+	}{r})
+	fake.recordInvocation("GetRole", []interface{}{r})
+	fake.getRoleMutex.Unlock()
+	if fake.GetRoleStub != nil {
+		return fake.GetRoleStub(r)
+	} else {
+		return fake.getRoleReturns.result1, fake.getRoleReturns.result2
+	}
+}
+
+func (fake *FakeUserContextReader) GetRoleCallCount() int {
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
+	return len(fake.getRoleArgsForCall)
+}
+
+func (fake *FakeUserContextReader) GetRoleArgsForCall(i int) *http.Request {
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
+	return fake.getRoleArgsForCall[i].r
+}
+
+func (fake *FakeUserContextReader) GetRoleReturns(result1 string, result2 bool) {
+	fake.GetRoleStub = nil
+	fake.getRoleReturns = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeUserContextReader) GetUser(r *http.Request) (string, bool) {
+	fake.getUserMutex.Lock()
+	fake.getUserArgsForCall = append(fake.getUserArgsForCall, struct {
+		r *http.Request
+	}{r})
+	fake.recordInvocation("GetUser", []interface{}{r})
+	fake.getUserMutex.Unlock()
+	if fake.GetUserStub != nil {
+		return fake.GetUserStub(r)
+	} else {
+		return fake.getUserReturns.result1, fake.getUserReturns.result2
+	}
+}
+
+func (fake *FakeUserContextReader) GetUserCallCount() int {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	return len(fake.getUserArgsForCall)
+}
+
+func (fake *FakeUserContextReader) GetUserArgsForCall(i int) *http.Request {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	return fake.getUserArgsForCall[i].r
+}
+
+func (fake *FakeUserContextReader) GetUserReturns(result1 string, result2 bool) {
+	fake.GetUserStub = nil
+	fake.getUserReturns = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *FakeUserContextReader) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getTeamMutex.RLock()
 	defer fake.getTeamMutex.RUnlock()
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -3,8 +3,6 @@ package auth
 import (
 	"net/http"
 
-	"github.com/concourse/atc"
-
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -22,7 +20,7 @@ func (validator BasicAuthValidator) IsAuthenticated(r *http.Request) bool {
 		return false
 	}
 
-	team, found, err := validator.DB.GetTeamByName(atc.DefaultTeamName)
+	team, found, err := validator.DB.GetTeamByName(GetRequestedTeamName(r))
 	if err != nil || !found {
 		return false
 	}
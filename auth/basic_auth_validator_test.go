@@ -72,6 +72,26 @@ var _ = Describe("BasicAuthValidator", func() {
 				Expect(isAuthenticated).To(BeTrue())
 			})
 
+			It("validates against the default team", func() {
+				Expect(fakeAuthDB.GetTeamByNameCallCount()).To(Equal(1))
+				Expect(fakeAuthDB.GetTeamByNameArgsForCall(0)).To(Equal(atc.DefaultTeamName))
+			})
+
+			Context("when the request is for a specific team", func() {
+				BeforeEach(func() {
+					var err error
+					request, err = http.NewRequest("GET", "http://example.com?:team_name=some-team", nil)
+					Expect(err).ToNot(HaveOccurred())
+
+					request.Header.Set("Authorization", "Basic "+b64(username+":"+password))
+				})
+
+				It("validates against the requested team", func() {
+					Expect(fakeAuthDB.GetTeamByNameCallCount()).To(Equal(1))
+					Expect(fakeAuthDB.GetTeamByNameArgsForCall(0)).To(Equal("some-team"))
+				})
+			})
+
 			Context("with different casing", func() {
 				BeforeEach(func() {
 					request.Header.Set("Authorization", "bAsIc "+b64(username+":"+password))
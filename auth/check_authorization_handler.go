@@ -0,0 +1,35 @@
+package auth
+
+import "net/http"
+
+type checkAuthorizationHandler struct {
+	handler  http.Handler
+	rejector Rejector
+	role     string
+}
+
+func CheckAuthorizationHandler(
+	handler http.Handler,
+	rejector Rejector,
+	role string,
+) http.Handler {
+	return checkAuthorizationHandler{
+		handler:  handler,
+		rejector: rejector,
+		role:     role,
+	}
+}
+
+func (h checkAuthorizationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !IsAuthenticated(r) {
+		h.rejector.Unauthorized(w, r)
+		return
+	}
+
+	if !IsAuthorized(r) || !HasRole(r, h.role) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	h.handler.ServeHTTP(w, r)
+}
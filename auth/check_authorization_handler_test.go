@@ -0,0 +1,162 @@
+package auth_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckAuthorizationHandler", func() {
+	var (
+		fakeValidator         *authfakes.FakeValidator
+		fakeUserContextReader *authfakes.FakeUserContextReader
+		fakeRejector          *authfakes.FakeRejector
+
+		server *httptest.Server
+		client *http.Client
+	)
+
+	simpleHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buffer := bytes.NewBufferString("simple ")
+
+		io.Copy(w, buffer)
+		io.Copy(w, r.Body)
+	})
+
+	BeforeEach(func() {
+		fakeValidator = new(authfakes.FakeValidator)
+		fakeUserContextReader = new(authfakes.FakeUserContextReader)
+		fakeRejector = new(authfakes.FakeRejector)
+
+		fakeRejector.UnauthorizedStub = func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "nope", http.StatusUnauthorized)
+		}
+
+		server = httptest.NewServer(auth.WrapHandler(
+			auth.CheckAuthorizationHandler(
+				simpleHandler,
+				fakeRejector,
+				atc.RoleMember,
+			),
+			fakeValidator,
+			fakeUserContextReader,
+		))
+
+		client = &http.Client{
+			Transport: &http.Transport{},
+		}
+	})
+
+	Context("when a request is made for a team", func() {
+		var request *http.Request
+		var response *http.Response
+
+		BeforeEach(func() {
+			var err error
+
+			request, err = http.NewRequest("GET", server.URL+"?:team_name=some-team", bytes.NewBufferString("hello"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the validator returns true", func() {
+			BeforeEach(func() {
+				fakeValidator.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the user is on the requested team", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetTeamReturns("some-team", 42, false, true)
+					fakeUserContextReader.GetRoleReturns(atc.RoleMember, true)
+				})
+
+				It("proxies to the handler", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					responseBody, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(responseBody)).To(Equal("simple hello"))
+				})
+
+				Context("when the user's role is insufficient", func() {
+					BeforeEach(func() {
+						fakeUserContextReader.GetRoleReturns(atc.RoleViewer, true)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+
+				Context("when the token does not carry a role", func() {
+					BeforeEach(func() {
+						fakeUserContextReader.GetRoleReturns("", false)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+			})
+
+			Context("when the user is on another team", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetTeamReturns("other-team", 43, false, true)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+
+				Context("when the user is an admin", func() {
+					BeforeEach(func() {
+						fakeUserContextReader.GetTeamReturns("other-team", 43, true, true)
+						fakeUserContextReader.GetRoleReturns(atc.RoleOwner, true)
+					})
+
+					It("proxies to the handler", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+			})
+
+			Context("when the credentials do not carry a team", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetTeamReturns("", 0, false, false)
+				})
+
+				It("proxies to the handler", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+		})
+
+		Context("when the validator returns false", func() {
+			BeforeEach(func() {
+				fakeValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("rejects the request", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				responseBody, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(responseBody)).To(Equal("nope\n"))
+			})
+		})
+	})
+})
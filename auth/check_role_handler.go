@@ -0,0 +1,35 @@
+package auth
+
+import "net/http"
+
+type checkRoleHandler struct {
+	handler  http.Handler
+	rejector Rejector
+	role     string
+}
+
+func CheckRoleHandler(
+	handler http.Handler,
+	rejector Rejector,
+	role string,
+) http.Handler {
+	return checkRoleHandler{
+		handler:  handler,
+		rejector: rejector,
+		role:     role,
+	}
+}
+
+func (h checkRoleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !IsAuthenticated(r) {
+		h.rejector.Unauthorized(w, r)
+		return
+	}
+
+	if !HasRole(r, h.role) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	h.handler.ServeHTTP(w, r)
+}
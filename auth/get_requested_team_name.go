@@ -0,0 +1,18 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+)
+
+// GetRequestedTeamName returns the team named by a team-scoped route, falling
+// back to the default team for the routes that predate teams.
+func GetRequestedTeamName(r *http.Request) string {
+	teamName := r.FormValue(":team_name")
+	if teamName == "" {
+		return atc.DefaultTeamName
+	}
+
+	return teamName
+}
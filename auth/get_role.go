@@ -0,0 +1,16 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gorilla/context"
+)
+
+func GetRole(r *http.Request) (string, bool) {
+	role, present := context.GetOk(r, roleKey)
+	if !present {
+		return "", false
+	}
+
+	return role.(string), true
+}
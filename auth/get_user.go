@@ -0,0 +1,16 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gorilla/context"
+)
+
+func GetUser(r *http.Request) (string, bool) {
+	user, present := context.GetOk(r, userKey)
+	if !present {
+		return "", false
+	}
+
+	return user.(string), true
+}
@@ -1,7 +1,10 @@
 package github
 
 import (
+	"net/http"
+
 	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/github"
 )
@@ -30,6 +33,7 @@ func NewProvider(
 	}
 
 	return Provider{
+		client: client,
 		Verifier: NewVerifierBasket(
 			NewTeamVerifier(dbTeamsToGitHubTeams(gitHubAuth.Teams), client),
 			NewOrganizationVerifier(gitHubAuth.Organizations, client),
@@ -53,6 +57,8 @@ type Provider struct {
 	// Client(context.Context, *oauth2.Token) *http.Client
 
 	Verifier
+
+	client Client
 }
 
 func dbTeamsToGitHubTeams(dbteams []db.GitHubTeam) []Team {
@@ -69,3 +75,13 @@ func dbTeamsToGitHubTeams(dbteams []db.GitHubTeam) []Team {
 func (Provider) DisplayName() string {
 	return "GitHub"
 }
+
+func (provider Provider) CurrentUser(logger lager.Logger, httpClient *http.Client) (string, error) {
+	user, err := provider.client.CurrentUser(httpClient)
+	if err != nil {
+		logger.Error("failed-to-get-current-user", err)
+		return "", err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,156 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const DefaultURL = "https://gitlab.com"
+
+//go:generate counterfeiter . Client
+
+type Client interface {
+	CurrentUser(*http.Client) (string, error)
+	Groups(*http.Client) ([]string, error)
+	Projects(*http.Client) ([]string, error)
+}
+
+type client struct {
+	baseURL string
+}
+
+func NewClient(baseURL string) Client {
+	if baseURL == "" {
+		baseURL = DefaultURL
+	}
+
+	return &client{baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+type user struct {
+	Username string `json:"username"`
+}
+
+type group struct {
+	FullPath string `json:"full_path"`
+}
+
+type project struct {
+	PathWithNamespace string `json:"path_with_namespace"`
+}
+
+func (c *client) CurrentUser(httpClient *http.Client) (string, error) {
+	var currentUser user
+	err := c.get(httpClient, "/api/v4/user", nil, &currentUser)
+	if err != nil {
+		return "", err
+	}
+
+	return currentUser.Username, nil
+}
+
+func (c *client) Groups(httpClient *http.Client) ([]string, error) {
+	groups := []string{}
+
+	err := c.paginate(httpClient, "/api/v4/groups", url.Values{"min_access_level": {"10"}}, func(page *json.Decoder) error {
+		var pageGroups []group
+		err := page.Decode(&pageGroups)
+		if err != nil {
+			return err
+		}
+
+		for _, group := range pageGroups {
+			groups = append(groups, group.FullPath)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (c *client) Projects(httpClient *http.Client) ([]string, error) {
+	projects := []string{}
+
+	err := c.paginate(httpClient, "/api/v4/projects", url.Values{"membership": {"true"}, "simple": {"true"}}, func(page *json.Decoder) error {
+		var pageProjects []project
+		err := page.Decode(&pageProjects)
+		if err != nil {
+			return err
+		}
+
+		for _, project := range pageProjects {
+			projects = append(projects, project.PathWithNamespace)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+func (c *client) paginate(httpClient *http.Client, path string, query url.Values, decode func(*json.Decoder) error) error {
+	nextPage := "1"
+
+	for nextPage != "" {
+		pageQuery := url.Values{}
+		for k, v := range query {
+			pageQuery[k] = v
+		}
+		pageQuery.Set("page", nextPage)
+
+		response, err := c.request(httpClient, path, pageQuery)
+		if err != nil {
+			return err
+		}
+
+		err = decode(json.NewDecoder(response.Body))
+		response.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		nextPage = response.Header.Get("X-Next-Page")
+	}
+
+	return nil
+}
+
+func (c *client) get(httpClient *http.Client, path string, query url.Values, dest interface{}) error {
+	response, err := c.request(httpClient, path, query)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	return json.NewDecoder(response.Body).Decode(dest)
+}
+
+func (c *client) request(httpClient *http.Client, path string, query url.Values) (*http.Response, error) {
+	requestURL := c.baseURL + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	response, err := httpClient.Get(requestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, fmt.Errorf("unexpected response from %s: %s", path, response.Status)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,172 @@
+package gitlab_test
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/atc/auth/gitlab"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		gitlabServer *ghttp.Server
+
+		client gitlab.Client
+
+		httpClient *http.Client
+	)
+
+	BeforeEach(func() {
+		gitlabServer = ghttp.NewServer()
+
+		client = gitlab.NewClient(gitlabServer.URL())
+
+		httpClient = &http.Client{}
+	})
+
+	AfterEach(func() {
+		gitlabServer.Close()
+	})
+
+	Describe("CurrentUser", func() {
+		Context("when getting the current user succeeds", func() {
+			BeforeEach(func() {
+				gitlabServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v4/user"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]string{
+							"username": "some-user",
+						}),
+					),
+				)
+			})
+
+			It("returns the user's username", func() {
+				user, err := client.CurrentUser(httpClient)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(user).To(Equal("some-user"))
+			})
+		})
+
+		Context("when getting the current user fails", func() {
+			BeforeEach(func() {
+				gitlabServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v4/user"),
+						ghttp.RespondWith(http.StatusUnauthorized, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, err := client.CurrentUser(httpClient)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Groups", func() {
+		Context("when listing groups succeeds", func() {
+			BeforeEach(func() {
+				gitlabServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v4/groups", "min_access_level=10&page=1"),
+						ghttp.RespondWithJSONEncoded(
+							http.StatusOK,
+							[]map[string]string{
+								{"full_path": "group-1"},
+								{"full_path": "group-1/subgroup"},
+							},
+							http.Header{"X-Next-Page": []string{"2"}},
+						),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v4/groups", "min_access_level=10&page=2"),
+						ghttp.RespondWithJSONEncoded(
+							http.StatusOK,
+							[]map[string]string{
+								{"full_path": "group-2"},
+							},
+							http.Header{"X-Next-Page": []string{""}},
+						),
+					),
+				)
+			})
+
+			It("returns the full paths of all groups", func() {
+				groups, err := client.Groups(httpClient)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(groups).To(Equal([]string{"group-1", "group-1/subgroup", "group-2"}))
+			})
+		})
+
+		Context("when listing groups fails", func() {
+			BeforeEach(func() {
+				gitlabServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v4/groups"),
+						ghttp.RespondWith(http.StatusUnauthorized, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, err := client.Groups(httpClient)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Projects", func() {
+		Context("when listing projects succeeds", func() {
+			BeforeEach(func() {
+				gitlabServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v4/projects", "membership=true&page=1&simple=true"),
+						ghttp.RespondWithJSONEncoded(
+							http.StatusOK,
+							[]map[string]string{
+								{"path_with_namespace": "group-1/project-1"},
+							},
+							http.Header{"X-Next-Page": []string{"2"}},
+						),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v4/projects", "membership=true&page=2&simple=true"),
+						ghttp.RespondWithJSONEncoded(
+							http.StatusOK,
+							[]map[string]string{
+								{"path_with_namespace": "group-2/project-2"},
+							},
+						),
+					),
+				)
+			})
+
+			It("returns the full paths of all projects", func() {
+				projects, err := client.Projects(httpClient)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(projects).To(Equal([]string{"group-1/project-1", "group-2/project-2"}))
+			})
+		})
+
+		Context("when listing projects fails", func() {
+			BeforeEach(func() {
+				gitlabServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v4/projects"),
+						ghttp.RespondWith(http.StatusUnauthorized, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, err := client.Projects(httpClient)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -0,0 +1,45 @@
+package gitlab
+
+import (
+	"net/http"
+
+	"github.com/pivotal-golang/lager"
+)
+
+type ProjectVerifier struct {
+	projects     []string
+	gitLabClient Client
+}
+
+func NewProjectVerifier(
+	projects []string,
+	gitLabClient Client,
+) ProjectVerifier {
+	return ProjectVerifier{
+		projects:     projects,
+		gitLabClient: gitLabClient,
+	}
+}
+
+func (verifier ProjectVerifier) Verify(logger lager.Logger, httpClient *http.Client) (bool, error) {
+	projects, err := verifier.gitLabClient.Projects(httpClient)
+	if err != nil {
+		logger.Error("failed-to-get-projects", err)
+		return false, err
+	}
+
+	for _, name := range projects {
+		for _, authorizedProject := range verifier.projects {
+			if name == authorizedProject {
+				return true, nil
+			}
+		}
+	}
+
+	logger.Info("not-in-projects", lager.Data{
+		"have": projects,
+		"want": verifier.projects,
+	})
+
+	return false, nil
+}
@@ -0,0 +1,88 @@
+package gitlab_test
+
+import (
+	"errors"
+	"net/http"
+
+	. "github.com/concourse/atc/auth/gitlab"
+	"github.com/concourse/atc/auth/gitlab/gitlabfakes"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProjectVerifier", func() {
+	var (
+		projects   []string
+		fakeClient *gitlabfakes.FakeClient
+
+		verifier Verifier
+	)
+
+	BeforeEach(func() {
+		projects = []string{"some-project", "another-project"}
+		fakeClient = new(gitlabfakes.FakeClient)
+
+		verifier = NewProjectVerifier(projects, fakeClient)
+	})
+
+	Describe("Verify", func() {
+		var (
+			httpClient *http.Client
+
+			verified  bool
+			verifyErr error
+		)
+
+		BeforeEach(func() {
+			httpClient = &http.Client{}
+		})
+
+		JustBeforeEach(func() {
+			verified, verifyErr = verifier.Verify(lagertest.NewTestLogger("test"), httpClient)
+		})
+
+		Context("when the client yields projects", func() {
+			Context("including one of the desired projects", func() {
+				BeforeEach(func() {
+					fakeClient.ProjectsReturns([]string{projects[0], "bogus-project"}, nil)
+				})
+
+				It("succeeds", func() {
+					Expect(verifyErr).ToNot(HaveOccurred())
+				})
+
+				It("returns true", func() {
+					Expect(verified).To(BeTrue())
+				})
+			})
+
+			Context("not including the desired projects", func() {
+				BeforeEach(func() {
+					fakeClient.ProjectsReturns([]string{"bogus-project"}, nil)
+				})
+
+				It("succeeds", func() {
+					Expect(verifyErr).ToNot(HaveOccurred())
+				})
+
+				It("returns false", func() {
+					Expect(verified).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when the client fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeClient.ProjectsReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(verifyErr).To(Equal(disaster))
+			})
+		})
+	})
+})
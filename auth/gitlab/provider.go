@@ -0,0 +1,72 @@
+package gitlab
+
+import (
+	"net/http"
+
+	"strings"
+
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+	"golang.org/x/oauth2"
+)
+
+const ProviderName = "gitlab"
+
+var Scopes = []string{"read_api"}
+
+func NewProvider(
+	gitLabAuth db.GitLabAuth,
+	redirectURL string,
+) Provider {
+	baseURL := strings.TrimSuffix(gitLabAuth.URL, "/")
+	if baseURL == "" {
+		baseURL = DefaultURL
+	}
+
+	client := NewClient(baseURL)
+
+	return Provider{
+		client: client,
+		Verifier: NewVerifierBasket(
+			NewGroupVerifier(gitLabAuth.Groups, client),
+			NewProjectVerifier(gitLabAuth.Projects, client),
+			NewUserVerifier(gitLabAuth.Users, client),
+		),
+		Config: &oauth2.Config{
+			ClientID:     gitLabAuth.ClientID,
+			ClientSecret: gitLabAuth.ClientSecret,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  baseURL + "/oauth/authorize",
+				TokenURL: baseURL + "/oauth/token",
+			},
+			Scopes:      Scopes,
+			RedirectURL: redirectURL,
+		},
+	}
+}
+
+type Provider struct {
+	*oauth2.Config
+	// oauth2.Config implements the required Provider methods:
+	// AuthCodeURL(string, ...oauth2.AuthCodeOption) string
+	// Exchange(context.Context, string) (*oauth2.Token, error)
+	// Client(context.Context, *oauth2.Token) *http.Client
+
+	Verifier
+
+	client Client
+}
+
+func (Provider) DisplayName() string {
+	return "GitLab"
+}
+
+func (provider Provider) CurrentUser(logger lager.Logger, httpClient *http.Client) (string, error) {
+	user, err := provider.client.CurrentUser(httpClient)
+	if err != nil {
+		logger.Error("failed-to-get-current-user", err)
+		return "", err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,43 @@
+package gitlab
+
+import (
+	"net/http"
+
+	"github.com/pivotal-golang/lager"
+)
+
+type UserVerifier struct {
+	users        []string
+	gitLabClient Client
+}
+
+func NewUserVerifier(
+	users []string,
+	gitLabClient Client,
+) Verifier {
+	return UserVerifier{
+		users:        users,
+		gitLabClient: gitLabClient,
+	}
+}
+
+func (verifier UserVerifier) Verify(logger lager.Logger, httpClient *http.Client) (bool, error) {
+	currentUser, err := verifier.gitLabClient.CurrentUser(httpClient)
+	if err != nil {
+		logger.Error("failed-to-get-current-user", err)
+		return false, err
+	}
+
+	for _, user := range verifier.users {
+		if user == currentUser {
+			return true, nil
+		}
+	}
+
+	logger.Info("not-validated-user", lager.Data{
+		"have": currentUser,
+		"want": verifier.users,
+	})
+
+	return false, nil
+}
@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+)
+
+// HasRole reports whether the authenticated user's role within their team
+// allows actions requiring the given role. Credentials that do not carry a
+// team (e.g. basic auth) are the team's own credentials, so they are treated
+// as owners.
+func HasRole(r *http.Request, role string) bool {
+	_, _, _, found := GetTeam(r)
+	if !found {
+		return true
+	}
+
+	userRole, found := GetRole(r)
+	if !found {
+		return false
+	}
+
+	return atc.RoleAllows(userRole, role)
+}
@@ -0,0 +1,27 @@
+package auth
+
+import "net/http"
+
+// IsAuthorized reports whether the authenticated user may act on the team
+// named by the request. Admins may act on any team. Credentials that do not
+// carry a team (e.g. basic auth) have already been validated against the
+// requested team, so they are authorized as well.
+func IsAuthorized(r *http.Request) bool {
+	teamName, _, isAdmin, found := GetTeam(r)
+	if !found {
+		return true
+	}
+
+	return isAdmin || teamName == GetRequestedTeamName(r)
+}
+
+// IsAuthorizedForTeam reports whether the authenticated user may act on
+// something, such as a build or container, that belongs to the given team.
+func IsAuthorizedForTeam(r *http.Request, teamID int) bool {
+	_, authTeamID, isAdmin, found := GetTeam(r)
+	if !found {
+		return true
+	}
+
+	return isAdmin || authTeamID == teamID
+}
@@ -3,6 +3,8 @@ package auth
 import (
 	"crypto/rsa"
 	"net/http"
+
+	"github.com/concourse/atc"
 )
 
 type JWTReader struct {
@@ -29,3 +31,37 @@ func (jr JWTReader) GetTeam(r *http.Request) (string, int, bool, bool) {
 
 	return teamName, teamID, isAdmin, true
 }
+
+func (jr JWTReader) GetRole(r *http.Request) (string, bool) {
+	token, err := getJWT(r, jr.PublicKey)
+	if err != nil {
+		return "", false
+	}
+
+	roleInterface, roleOK := token.Claims[roleClaimKey]
+	if !roleOK {
+		// tokens issued before roles existed granted full access to the team
+		return atc.RoleOwner, true
+	}
+
+	role, roleOK := roleInterface.(string)
+	if !roleOK {
+		return "", false
+	}
+
+	return role, true
+}
+
+func (jr JWTReader) GetUser(r *http.Request) (string, bool) {
+	token, err := getJWT(r, jr.PublicKey)
+	if err != nil {
+		return "", false
+	}
+
+	user, userOK := token.Claims[userClaimKey].(string)
+	if !userOK || user == "" {
+		return "", false
+	}
+
+	return user, true
+}
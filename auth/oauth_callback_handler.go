@@ -138,9 +138,28 @@ func (handler *OAuthCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	user, err := provider.CurrentUser(hLog.Session("current-user"), httpClient)
+	if err != nil {
+		hLog.Error("failed-to-get-current-user", err)
+		http.Error(w, "failed to get current user", http.StatusInternalServerError)
+		return
+	}
+
+	// teams without any roles configured keep the old behaviour of granting
+	// everyone full access; once roles are configured, unlisted users may
+	// only view
+	role, found := team.Roles[providerName+":"+user]
+	if !found {
+		if len(team.Roles) == 0 {
+			role = atc.RoleOwner
+		} else {
+			role = atc.RoleViewer
+		}
+	}
+
 	exp := time.Now().Add(CookieAge)
 
-	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(exp, team.Name, team.ID, team.Admin)
+	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(exp, team.Name, team.ID, team.Admin, role, providerName+":"+user)
 	if err != nil {
 		hLog.Error("failed-to-sign-token", err)
 		http.Error(w, "failed to sign token", http.StatusInternalServerError)
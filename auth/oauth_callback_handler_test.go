@@ -225,6 +225,66 @@ var _ = Describe("OAuthCallbackHandler", func() {
 								Expect(token.Claims["teamID"]).To(BeNumerically("==", team.ID))
 								Expect(token.Valid).To(BeTrue())
 							})
+
+							It("grants the owner role when the team has no roles configured", func() {
+								token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+								Expect(err).ToNot(HaveOccurred())
+
+								Expect(token.Claims["role"]).To(Equal(atc.RoleOwner))
+							})
+
+							Context("when the user has a role in the team", func() {
+								BeforeEach(func() {
+									fakeProviderB.CurrentUserReturns("some-user", nil)
+
+									team.Roles = map[string]string{"b:some-user": atc.RoleMember}
+									fakeAuthDB.GetTeamByNameReturns(team, true, nil)
+								})
+
+								It("carries the role in the token", func() {
+									token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+									Expect(err).ToNot(HaveOccurred())
+
+									Expect(token.Claims["role"]).To(Equal(atc.RoleMember))
+								})
+
+								It("identifies the user in the token", func() {
+									token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+									Expect(err).ToNot(HaveOccurred())
+
+									Expect(token.Claims["user"]).To(Equal("b:some-user"))
+								})
+							})
+
+							Context("when the team has roles but none for the user", func() {
+								BeforeEach(func() {
+									fakeProviderB.CurrentUserReturns("some-user", nil)
+
+									team.Roles = map[string]string{"b:some-other-user": atc.RoleOwner}
+									fakeAuthDB.GetTeamByNameReturns(team, true, nil)
+								})
+
+								It("grants only the viewer role", func() {
+									token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+									Expect(err).ToNot(HaveOccurred())
+
+									Expect(token.Claims["role"]).To(Equal(atc.RoleViewer))
+								})
+							})
+						})
+
+						Context("when the current user cannot be determined", func() {
+							BeforeEach(func() {
+								fakeProviderB.CurrentUserReturns("", errors.New("nope"))
+							})
+
+							It("returns Internal Server Error", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+
+							It("does not set a cookie", func() {
+								Expect(response.Cookies()).To(BeEmpty())
+							})
 						})
 
 						It("does not redirect", func() {
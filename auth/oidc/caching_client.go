@@ -0,0 +1,65 @@
+package oidc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pivotal-golang/clock"
+)
+
+// DiscoveryTTL is how long an issuer's discovery document is reused before
+// it is fetched again.
+const DiscoveryTTL = time.Hour
+
+type cachingClient struct {
+	Client
+
+	clock clock.Clock
+	ttl   time.Duration
+
+	configurationsL sync.Mutex
+	configurations  map[string]cachedConfiguration
+}
+
+type cachedConfiguration struct {
+	configuration Configuration
+	fetchedAt     time.Time
+}
+
+// NewCachingClient returns a Client that reuses each issuer's discovery
+// document for the given TTL rather than fetching it for every request.
+// Failed discoveries are not cached, so they are retried on the next call.
+func NewCachingClient(client Client, clock clock.Clock, ttl time.Duration) Client {
+	return &cachingClient{
+		Client: client,
+
+		clock: clock,
+		ttl:   ttl,
+
+		configurations: map[string]cachedConfiguration{},
+	}
+}
+
+func (c *cachingClient) Discover(issuer string) (Configuration, error) {
+	c.configurationsL.Lock()
+	cached, found := c.configurations[issuer]
+	c.configurationsL.Unlock()
+
+	if found && c.clock.Since(cached.fetchedAt) < c.ttl {
+		return cached.configuration, nil
+	}
+
+	configuration, err := c.Client.Discover(issuer)
+	if err != nil {
+		return Configuration{}, err
+	}
+
+	c.configurationsL.Lock()
+	c.configurations[issuer] = cachedConfiguration{
+		configuration: configuration,
+		fetchedAt:     c.clock.Now(),
+	}
+	c.configurationsL.Unlock()
+
+	return configuration, nil
+}
@@ -0,0 +1,109 @@
+package oidc_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/concourse/atc/auth/oidc"
+	"github.com/concourse/atc/auth/oidc/oidcfakes"
+	"github.com/pivotal-golang/clock/fakeclock"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CachingClient", func() {
+	var (
+		fakeClient *oidcfakes.FakeClient
+		fakeClock  *fakeclock.FakeClock
+
+		client Client
+
+		configuration Configuration
+	)
+
+	BeforeEach(func() {
+		fakeClient = new(oidcfakes.FakeClient)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+		configuration = Configuration{
+			Issuer:                "https://issuer.example.com",
+			AuthorizationEndpoint: "https://issuer.example.com/authorize",
+			TokenEndpoint:         "https://issuer.example.com/token",
+			JWKSURI:               "https://issuer.example.com/keys",
+		}
+
+		fakeClient.DiscoverReturns(configuration, nil)
+
+		client = NewCachingClient(fakeClient, fakeClock, time.Hour)
+	})
+
+	Describe("Discover", func() {
+		It("returns the discovered configuration", func() {
+			discovered, err := client.Discover("https://issuer.example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(discovered).To(Equal(configuration))
+
+			Expect(fakeClient.DiscoverCallCount()).To(Equal(1))
+			Expect(fakeClient.DiscoverArgsForCall(0)).To(Equal("https://issuer.example.com"))
+		})
+
+		It("reuses the configuration until the TTL elapses", func() {
+			_, err := client.Discover("https://issuer.example.com")
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeClock.Increment(59 * time.Minute)
+
+			discovered, err := client.Discover("https://issuer.example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(discovered).To(Equal(configuration))
+			Expect(fakeClient.DiscoverCallCount()).To(Equal(1))
+
+			fakeClock.Increment(time.Minute)
+
+			_, err = client.Discover("https://issuer.example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.DiscoverCallCount()).To(Equal(2))
+		})
+
+		It("caches each issuer separately", func() {
+			_, err := client.Discover("https://issuer.example.com")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.Discover("https://other-issuer.example.com")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.DiscoverCallCount()).To(Equal(2))
+		})
+
+		Context("when discovery fails", func() {
+			disaster := errors.New("disaster")
+
+			BeforeEach(func() {
+				fakeClient.DiscoverReturns(Configuration{}, disaster)
+			})
+
+			It("returns the error and tries again next time", func() {
+				_, err := client.Discover("https://issuer.example.com")
+				Expect(err).To(Equal(disaster))
+
+				_, err = client.Discover("https://issuer.example.com")
+				Expect(err).To(Equal(disaster))
+
+				Expect(fakeClient.DiscoverCallCount()).To(Equal(2))
+			})
+		})
+	})
+
+	Describe("Keys", func() {
+		It("is not cached", func() {
+			_, err := client.Keys("https://issuer.example.com/keys")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.Keys("https://issuer.example.com/keys")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.KeysCallCount()).To(Equal(2))
+		})
+	})
+})
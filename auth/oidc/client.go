@@ -0,0 +1,125 @@
+package oidc
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+)
+
+//go:generate counterfeiter . Client
+
+type Client interface {
+	Discover(issuer string) (Configuration, error)
+	Keys(jwksURI string) (map[string]*rsa.PublicKey, error)
+}
+
+// Configuration is the subset of an OpenID Provider's discovery document
+// that is needed to perform the authorization code flow.
+type Configuration struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type client struct {
+	httpClient *http.Client
+}
+
+func NewClient() Client {
+	return &client{httpClient: http.DefaultClient}
+}
+
+func (c *client) Discover(issuer string) (Configuration, error) {
+	discoveryURL := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+
+	var configuration Configuration
+	err := c.get(discoveryURL, &configuration)
+	if err != nil {
+		return Configuration{}, err
+	}
+
+	if configuration.Issuer != issuer {
+		return Configuration{}, fmt.Errorf("issuer mismatch: configured %q, discovered %q", issuer, configuration.Issuer)
+	}
+
+	if configuration.AuthorizationEndpoint == "" ||
+		configuration.TokenEndpoint == "" ||
+		configuration.JWKSURI == "" {
+		return Configuration{}, fmt.Errorf("incomplete discovery document for %q", issuer)
+	}
+
+	return configuration, nil
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+}
+
+func (c *client) Keys(jwksURI string) (map[string]*rsa.PublicKey, error) {
+	var keySet jsonWebKeySet
+	err := c.get(jwksURI, &keySet)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, key := range keySet.Keys {
+		if key.KeyType != "RSA" || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+
+		publicKey, err := key.rsaPublicKey()
+		if err != nil {
+			return nil, err
+		}
+
+		keys[key.KeyID] = publicKey
+	}
+
+	return keys, nil
+}
+
+func (key jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(key.N, "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus for key %q: %s", key.KeyID, err)
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(key.E, "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent for key %q: %s", key.KeyID, err)
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+func (c *client) get(url string, dest interface{}) error {
+	response, err := c.httpClient.Get(url)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response from %s: %s", url, response.Status)
+	}
+
+	return json.NewDecoder(response.Body).Decode(dest)
+}
@@ -0,0 +1,140 @@
+package oidc_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/atc/auth/oidc"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		issuerServer *ghttp.Server
+
+		client oidc.Client
+	)
+
+	BeforeEach(func() {
+		issuerServer = ghttp.NewServer()
+
+		client = oidc.NewClient()
+	})
+
+	AfterEach(func() {
+		issuerServer.Close()
+	})
+
+	Describe("Discover", func() {
+		var discovered oidc.Configuration
+
+		Context("when the discovery document is valid", func() {
+			BeforeEach(func() {
+				discovered = oidc.Configuration{
+					Issuer:                issuerServer.URL(),
+					AuthorizationEndpoint: issuerServer.URL() + "/authorize",
+					TokenEndpoint:         issuerServer.URL() + "/token",
+					JWKSURI:               issuerServer.URL() + "/keys",
+				}
+
+				issuerServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/.well-known/openid-configuration"),
+						ghttp.RespondWithJSONEncodedPtr(http.StatusOK, &discovered),
+					),
+				)
+			})
+
+			It("returns the configuration", func() {
+				configuration, err := client.Discover(issuerServer.URL())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(configuration).To(Equal(discovered))
+			})
+
+			Context("when the discovered issuer does not match", func() {
+				BeforeEach(func() {
+					discovered.Issuer = "https://evil.example.com"
+				})
+
+				It("returns an error", func() {
+					_, err := client.Discover(issuerServer.URL())
+					Expect(err).To(HaveOccurred())
+				})
+			})
+
+			Context("when the discovery document is missing endpoints", func() {
+				BeforeEach(func() {
+					discovered.JWKSURI = ""
+				})
+
+				It("returns an error", func() {
+					_, err := client.Discover(issuerServer.URL())
+					Expect(err).To(HaveOccurred())
+				})
+			})
+		})
+
+		Context("when the discovery document cannot be fetched", func() {
+			BeforeEach(func() {
+				issuerServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/.well-known/openid-configuration"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, err := client.Discover(issuerServer.URL())
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Keys", func() {
+		var key *rsa.PrivateKey
+
+		BeforeEach(func() {
+			var err error
+			key, err = rsa.GenerateKey(rand.Reader, 1024)
+			Expect(err).NotTo(HaveOccurred())
+
+			issuerServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/keys"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+						"keys": []map[string]string{
+							{
+								"kty": "RSA",
+								"kid": "some-key",
+								"use": "sig",
+								"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+								"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+							},
+							{
+								"kty": "RSA",
+								"kid": "some-encryption-key",
+								"use": "enc",
+								"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+								"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+							},
+						},
+					}),
+				),
+			)
+		})
+
+		It("returns the RSA signing keys by key ID", func() {
+			keys, err := client.Keys(issuerServer.URL() + "/keys")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(HaveLen(1))
+			Expect(keys["some-key"]).To(Equal(&key.PublicKey))
+		})
+	})
+})
@@ -0,0 +1,124 @@
+// This file was generated by counterfeiter
+package oidcfakes
+
+import (
+	"crypto/rsa"
+	"sync"
+
+	"github.com/concourse/atc/auth/oidc"
+)
+
+type FakeClient struct {
+	DiscoverStub        func(issuer string) (oidc.Configuration, error)
+	discoverMutex       sync.RWMutex
+	discoverArgsForCall []struct {
+		issuer string
+	}
+	discoverReturns struct {
+		result1 oidc.Configuration
+		result2 error
+	}
+	KeysStub        func(jwksURI string) (map[string]*rsa.PublicKey, error)
+	keysMutex       sync.RWMutex
+	keysArgsForCall []struct {
+		jwksURI string
+	}
+	keysReturns struct {
+		result1 map[string]*rsa.PublicKey
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeClient) Discover(issuer string) (oidc.Configuration, error) {
+	fake.discoverMutex.Lock()
+	fake.discoverArgsForCall = append(fake.discoverArgsForCall, struct {
+		issuer string
+	}{issuer})
+	fake.recordInvocation("Discover", []interface{}{issuer})
+	fake.discoverMutex.Unlock()
+	if fake.DiscoverStub != nil {
+		return fake.DiscoverStub(issuer)
+	} else {
+		return fake.discoverReturns.result1, fake.discoverReturns.result2
+	}
+}
+
+func (fake *FakeClient) DiscoverCallCount() int {
+	fake.discoverMutex.RLock()
+	defer fake.discoverMutex.RUnlock()
+	return len(fake.discoverArgsForCall)
+}
+
+func (fake *FakeClient) DiscoverArgsForCall(i int) string {
+	fake.discoverMutex.RLock()
+	defer fake.discoverMutex.RUnlock()
+	return fake.discoverArgsForCall[i].issuer
+}
+
+func (fake *FakeClient) DiscoverReturns(result1 oidc.Configuration, result2 error) {
+	fake.DiscoverStub = nil
+	fake.discoverReturns = struct {
+		result1 oidc.Configuration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) Keys(jwksURI string) (map[string]*rsa.PublicKey, error) {
+	fake.keysMutex.Lock()
+	fake.keysArgsForCall = append(fake.keysArgsForCall, struct {
+		jwksURI string
+	}{jwksURI})
+	fake.recordInvocation("Keys", []interface{}{jwksURI})
+	fake.keysMutex.Unlock()
+	if fake.KeysStub != nil {
+		return fake.KeysStub(jwksURI)
+	} else {
+		return fake.keysReturns.result1, fake.keysReturns.result2
+	}
+}
+
+func (fake *FakeClient) KeysCallCount() int {
+	fake.keysMutex.RLock()
+	defer fake.keysMutex.RUnlock()
+	return len(fake.keysArgsForCall)
+}
+
+func (fake *FakeClient) KeysArgsForCall(i int) string {
+	fake.keysMutex.RLock()
+	defer fake.keysMutex.RUnlock()
+	return fake.keysArgsForCall[i].jwksURI
+}
+
+func (fake *FakeClient) KeysReturns(result1 map[string]*rsa.PublicKey, result2 error) {
+	fake.KeysStub = nil
+	fake.keysReturns = struct {
+		result1 map[string]*rsa.PublicKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.discoverMutex.RLock()
+	defer fake.discoverMutex.RUnlock()
+	fake.keysMutex.RLock()
+	defer fake.keysMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ oidc.Client = new(FakeClient)
@@ -0,0 +1,74 @@
+package oidc
+
+import (
+	"github.com/concourse/atc/db"
+	"golang.org/x/oauth2"
+)
+
+const ProviderName = "oidc"
+
+const DefaultDisplayName = "OpenID Connect"
+
+var DefaultScopes = []string{"openid", "profile", "email"}
+
+func NewProvider(
+	oidcAuth db.OIDCAuth,
+	redirectURL string,
+	client Client,
+) (Provider, error) {
+	configuration, err := client.Discover(oidcAuth.Issuer)
+	if err != nil {
+		return Provider{}, err
+	}
+
+	displayName := oidcAuth.DisplayName
+	if displayName == "" {
+		displayName = DefaultDisplayName
+	}
+
+	scopes := DefaultScopes
+	if len(oidcAuth.Scopes) > 0 {
+		scopes = append([]string{"openid"}, oidcAuth.Scopes...)
+	}
+
+	return Provider{
+		displayName: displayName,
+		IDTokenVerifier: NewIDTokenVerifier(
+			configuration.Issuer,
+			oidcAuth.ClientID,
+			configuration.JWKSURI,
+			oidcAuth.GroupsClaim,
+			oidcAuth.Groups,
+			oidcAuth.Users,
+			client,
+		),
+		Config: &oauth2.Config{
+			ClientID:     oidcAuth.ClientID,
+			ClientSecret: oidcAuth.ClientSecret,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  configuration.AuthorizationEndpoint,
+				TokenURL: configuration.TokenEndpoint,
+			},
+			Scopes:      scopes,
+			RedirectURL: redirectURL,
+		},
+	}, nil
+}
+
+type Provider struct {
+	*oauth2.Config
+	// oauth2.Config implements the required Provider methods:
+	// AuthCodeURL(string, ...oauth2.AuthCodeOption) string
+	// Exchange(context.Context, string) (*oauth2.Token, error)
+	// Client(context.Context, *oauth2.Token) *http.Client
+
+	// IDTokenVerifier implements Verify and CurrentUser from the ID token
+	// returned alongside the access token.
+	IDTokenVerifier
+
+	displayName string
+}
+
+func (provider Provider) DisplayName() string {
+	return provider.displayName
+}
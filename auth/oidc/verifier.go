@@ -0,0 +1,212 @@
+package oidc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/pivotal-golang/lager"
+	"golang.org/x/oauth2"
+)
+
+const DefaultGroupsClaim = "groups"
+
+const emailClaim = "email"
+
+var ErrNoIDToken = errors.New("token response did not include an id_token")
+var ErrInvalidIDToken = errors.New("id_token is invalid")
+var ErrNoEmailClaim = errors.New("id_token does not include an email claim")
+
+type Verifier interface {
+	Verify(lager.Logger, *http.Client) (bool, error)
+}
+
+type IDTokenVerifier struct {
+	issuer   string
+	clientID string
+	jwksURI  string
+
+	groupsClaim string
+	groups      []string
+	users       []string
+
+	client Client
+}
+
+func NewIDTokenVerifier(
+	issuer string,
+	clientID string,
+	jwksURI string,
+	groupsClaim string,
+	groups []string,
+	users []string,
+	client Client,
+) IDTokenVerifier {
+	if groupsClaim == "" {
+		groupsClaim = DefaultGroupsClaim
+	}
+
+	return IDTokenVerifier{
+		issuer:      issuer,
+		clientID:    clientID,
+		jwksURI:     jwksURI,
+		groupsClaim: groupsClaim,
+		groups:      groups,
+		users:       users,
+		client:      client,
+	}
+}
+
+func (verifier IDTokenVerifier) Verify(logger lager.Logger, httpClient *http.Client) (bool, error) {
+	claims, valid, err := verifier.validClaims(logger, httpClient)
+	if err != nil || !valid {
+		return false, err
+	}
+
+	if email, ok := claims[emailClaim].(string); ok && contains(verifier.users, email) {
+		if verified, ok := claims["email_verified"].(bool); !ok || verified {
+			return true, nil
+		}
+	}
+
+	for _, group := range stringsClaim(claims[verifier.groupsClaim]) {
+		if contains(verifier.groups, group) {
+			return true, nil
+		}
+	}
+
+	logger.Info("not-in-groups-or-users", lager.Data{
+		"have-user":   claims[emailClaim],
+		"have-groups": claims[verifier.groupsClaim],
+		"want-users":  verifier.users,
+		"want-groups": verifier.groups,
+	})
+
+	return false, nil
+}
+
+func (verifier IDTokenVerifier) CurrentUser(logger lager.Logger, httpClient *http.Client) (string, error) {
+	claims, valid, err := verifier.validClaims(logger, httpClient)
+	if err != nil {
+		return "", err
+	}
+
+	if !valid {
+		return "", ErrInvalidIDToken
+	}
+
+	email, ok := claims[emailClaim].(string)
+	if !ok {
+		return "", ErrNoEmailClaim
+	}
+
+	return email, nil
+}
+
+// validClaims returns the claims of the ID token if it is signed by the
+// issuer and intended for this client.
+func (verifier IDTokenVerifier) validClaims(logger lager.Logger, httpClient *http.Client) (map[string]interface{}, bool, error) {
+	rawIDToken, err := idTokenFromClient(httpClient)
+	if err != nil {
+		logger.Error("failed-to-get-id-token", err)
+		return nil, false, err
+	}
+
+	keys, err := verifier.client.Keys(verifier.jwksURI)
+	if err != nil {
+		logger.Error("failed-to-get-signing-keys", err)
+		return nil, false, err
+	}
+
+	idToken, err := jwt.Parse(rawIDToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		keyID, _ := token.Header["kid"].(string)
+		if key, found := keys[keyID]; found {
+			return key, nil
+		}
+
+		if keyID == "" && len(keys) == 1 {
+			for _, key := range keys {
+				return key, nil
+			}
+		}
+
+		return nil, fmt.Errorf("unknown signing key: %s", keyID)
+	})
+	if err != nil {
+		logger.Info("invalid-id-token", lager.Data{
+			"error": err.Error(),
+		})
+		return nil, false, nil
+	}
+
+	if issuer, _ := idToken.Claims["iss"].(string); issuer != verifier.issuer {
+		logger.Info("issuer-mismatch", lager.Data{
+			"have": issuer,
+			"want": verifier.issuer,
+		})
+		return nil, false, nil
+	}
+
+	if !contains(stringsClaim(idToken.Claims["aud"]), verifier.clientID) {
+		logger.Info("audience-mismatch", lager.Data{
+			"have": idToken.Claims["aud"],
+			"want": verifier.clientID,
+		})
+		return nil, false, nil
+	}
+
+	return idToken.Claims, true, nil
+}
+
+func idTokenFromClient(httpClient *http.Client) (string, error) {
+	transport, ok := httpClient.Transport.(*oauth2.Transport)
+	if !ok {
+		return "", errors.New("http client is not configured with an oauth2 transport")
+	}
+
+	token, err := transport.Source.Token()
+	if err != nil {
+		return "", err
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return "", ErrNoIDToken
+	}
+
+	return rawIDToken, nil
+}
+
+// stringsClaim normalizes a claim that may be either a single string or an
+// array of strings, as permitted for e.g. "aud".
+func stringsClaim(claim interface{}) []string {
+	switch value := claim.(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		values := []string{}
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+
+	return false
+}
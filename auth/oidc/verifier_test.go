@@ -0,0 +1,239 @@
+package oidc_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/lager/lagertest"
+	"golang.org/x/oauth2"
+
+	"github.com/concourse/atc/auth/oidc"
+	"github.com/concourse/atc/auth/oidc/oidcfakes"
+)
+
+var _ = Describe("IDTokenVerifier", func() {
+	var (
+		fakeClient *oidcfakes.FakeClient
+		signingKey *rsa.PrivateKey
+
+		groups []string
+		users  []string
+
+		claims     map[string]interface{}
+		httpClient *http.Client
+
+		verifier oidc.IDTokenVerifier
+
+		verified  bool
+		verifyErr error
+	)
+
+	BeforeEach(func() {
+		var err error
+		signingKey, err = rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeClient = new(oidcfakes.FakeClient)
+		fakeClient.KeysReturns(map[string]*rsa.PublicKey{
+			"some-key": &signingKey.PublicKey,
+		}, nil)
+
+		groups = []string{"some-group"}
+		users = []string{"some-user@example.com"}
+
+		claims = map[string]interface{}{
+			"iss":    "https://issuer.example.com",
+			"aud":    "some-client-id",
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"email":  "some-other-user@example.com",
+			"groups": []string{"some-other-group"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		idToken := jwt.New(jwt.SigningMethodRS256)
+		idToken.Header["kid"] = "some-key"
+		idToken.Claims = claims
+
+		signedIDToken, err := idToken.SignedString(signingKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		token := (&oauth2.Token{AccessToken: "some-access-token"}).WithExtra(map[string]interface{}{
+			"id_token": signedIDToken,
+		})
+
+		if httpClient == nil {
+			httpClient = (&oauth2.Config{}).Client(oauth2.NoContext, token)
+		}
+
+		verifier = oidc.NewIDTokenVerifier(
+			"https://issuer.example.com",
+			"some-client-id",
+			"https://issuer.example.com/keys",
+			"",
+			groups,
+			users,
+			fakeClient,
+		)
+
+		verified, verifyErr = verifier.Verify(lagertest.NewTestLogger("test"), httpClient)
+	})
+
+	AfterEach(func() {
+		httpClient = nil
+	})
+
+	It("fetches the signing keys from the JWKS URI", func() {
+		Expect(fakeClient.KeysCallCount()).To(Equal(1))
+		Expect(fakeClient.KeysArgsForCall(0)).To(Equal("https://issuer.example.com/keys"))
+	})
+
+	Context("when the user is in one of the groups", func() {
+		BeforeEach(func() {
+			claims["groups"] = []string{"some-other-group", "some-group"}
+		})
+
+		It("returns true", func() {
+			Expect(verifyErr).NotTo(HaveOccurred())
+			Expect(verified).To(BeTrue())
+		})
+
+		Context("when the token has expired", func() {
+			BeforeEach(func() {
+				claims["exp"] = time.Now().Add(-time.Hour).Unix()
+			})
+
+			It("returns false", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(verified).To(BeFalse())
+			})
+		})
+
+		Context("when the token was issued by another issuer", func() {
+			BeforeEach(func() {
+				claims["iss"] = "https://evil.example.com"
+			})
+
+			It("returns false", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(verified).To(BeFalse())
+			})
+		})
+
+		Context("when the token was issued for another client", func() {
+			BeforeEach(func() {
+				claims["aud"] = []string{"some-other-client-id"}
+			})
+
+			It("returns false", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(verified).To(BeFalse())
+			})
+		})
+
+		Context("when the token is signed by an unknown key", func() {
+			BeforeEach(func() {
+				otherKey, err := rsa.GenerateKey(rand.Reader, 1024)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeClient.KeysReturns(map[string]*rsa.PublicKey{
+					"some-key": &otherKey.PublicKey,
+				}, nil)
+			})
+
+			It("returns false", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(verified).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when the user is one of the users", func() {
+		BeforeEach(func() {
+			claims["email"] = "some-user@example.com"
+		})
+
+		It("returns true", func() {
+			Expect(verifyErr).NotTo(HaveOccurred())
+			Expect(verified).To(BeTrue())
+		})
+
+		Context("when the email has not been verified", func() {
+			BeforeEach(func() {
+				claims["email_verified"] = false
+			})
+
+			It("returns false", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(verified).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when the user is neither in the groups nor the users", func() {
+		It("returns false", func() {
+			Expect(verifyErr).NotTo(HaveOccurred())
+			Expect(verified).To(BeFalse())
+		})
+	})
+
+	Context("when fetching the signing keys fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeClient.KeysReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(verifyErr).To(Equal(disaster))
+			Expect(verified).To(BeFalse())
+		})
+	})
+
+	Context("when the http client does not carry an oauth2 token", func() {
+		BeforeEach(func() {
+			httpClient = &http.Client{}
+		})
+
+		It("returns an error", func() {
+			Expect(verifyErr).To(HaveOccurred())
+			Expect(verified).To(BeFalse())
+		})
+	})
+
+	Describe("CurrentUser", func() {
+		It("returns the email claim of the ID token", func() {
+			user, err := verifier.CurrentUser(lagertest.NewTestLogger("test"), httpClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(user).To(Equal("some-other-user@example.com"))
+		})
+
+		Context("when the token was issued by another issuer", func() {
+			BeforeEach(func() {
+				claims["iss"] = "https://evil.example.com"
+			})
+
+			It("returns an error", func() {
+				_, err := verifier.CurrentUser(lagertest.NewTestLogger("test"), httpClient)
+				Expect(err).To(Equal(oidc.ErrInvalidIDToken))
+			})
+		})
+
+		Context("when the token has no email claim", func() {
+			BeforeEach(func() {
+				delete(claims, "email")
+			})
+
+			It("returns an error", func() {
+				_, err := verifier.CurrentUser(lagertest.NewTestLogger("test"), httpClient)
+				Expect(err).To(Equal(oidc.ErrNoEmailClaim))
+			})
+		})
+	})
+})
@@ -5,7 +5,11 @@ import (
 
 	"github.com/cloudfoundry/gunk/urljoiner"
 	"github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/auth/gitlab"
+	"github.com/concourse/atc/auth/oidc"
 	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
 	"github.com/tedsuo/rata"
 )
 
@@ -16,18 +20,22 @@ type FactoryDB interface {
 }
 
 type OAuthFactory struct {
+	logger         lager.Logger
 	db             FactoryDB
 	atcExternalURL string
 	routes         rata.Routes
 	callback       string
+	oidcClient     oidc.Client
 }
 
-func NewOAuthFactory(db FactoryDB, atcExternalURL string, routes rata.Routes, callback string) OAuthFactory {
+func NewOAuthFactory(logger lager.Logger, db FactoryDB, atcExternalURL string, routes rata.Routes, callback string) OAuthFactory {
 	return OAuthFactory{
+		logger:         logger,
 		db:             db,
 		atcExternalURL: atcExternalURL,
 		routes:         routes,
 		callback:       callback,
+		oidcClient:     oidc.NewCachingClient(oidc.NewClient(), clock.NewClock(), oidc.DiscoveryTTL),
 	}
 }
 
@@ -57,5 +65,41 @@ func (of OAuthFactory) GetProviders(teamName string) (Providers, error) {
 		providers[github.ProviderName] = gitHubAuthProvider
 	}
 
-	return providers, err
+	if len(team.GitLabAuth.Groups) > 0 ||
+		len(team.GitLabAuth.Projects) > 0 ||
+		len(team.GitLabAuth.Users) > 0 {
+
+		redirectURL, err := of.routes.CreatePathForRoute(of.callback, rata.Params{
+			"provider": gitlab.ProviderName,
+		})
+		if err != nil {
+			return Providers{}, err
+		}
+		gitLabAuthProvider := gitlab.NewProvider(team.GitLabAuth, urljoiner.Join(of.atcExternalURL, redirectURL))
+
+		providers[gitlab.ProviderName] = gitLabAuthProvider
+	}
+
+	if team.OIDCAuth.Issuer != "" &&
+		(len(team.OIDCAuth.Groups) > 0 || len(team.OIDCAuth.Users) > 0) {
+
+		redirectURL, err := of.routes.CreatePathForRoute(of.callback, rata.Params{
+			"provider": oidc.ProviderName,
+		})
+		if err != nil {
+			return Providers{}, err
+		}
+		// an unreachable issuer should not prevent logging in any other way
+		oidcAuthProvider, err := oidc.NewProvider(team.OIDCAuth, urljoiner.Join(of.atcExternalURL, redirectURL), of.oidcClient)
+		if err != nil {
+			of.logger.Error("failed-to-discover-oidc-provider", err, lager.Data{
+				"team":   teamName,
+				"issuer": team.OIDCAuth.Issuer,
+			})
+		} else {
+			providers[oidc.ProviderName] = oidcAuthProvider
+		}
+	}
+
+	return providers, nil
 }
@@ -1,12 +1,19 @@
 package provider_test
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/auth/gitlab"
+	"github.com/concourse/atc/auth/oidc"
 	. "github.com/concourse/atc/auth/provider"
 	"github.com/concourse/atc/auth/provider/providerfakes"
 	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager/lagertest"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -19,6 +26,7 @@ var _ = Describe("OAuthFactory", func() {
 	BeforeEach(func() {
 		fakeFactoryDB = new(providerfakes.FakeFactoryDB)
 		oauthFactory = NewOAuthFactory(
+			lagertest.NewTestLogger("test"),
 			fakeFactoryDB,
 			"http://foo.bar",
 			auth.OAuthRoutes,
@@ -69,6 +77,129 @@ var _ = Describe("OAuthFactory", func() {
 			})
 		})
 
+		Describe("GitLab Provider", func() {
+			Context("when the provider is setup", func() {
+				BeforeEach(func() {
+					savedTeam := db.SavedTeam{
+						Team: db.Team{
+							Name: atc.DefaultTeamName,
+							GitLabAuth: db.GitLabAuth{
+								ClientID:     "user1",
+								ClientSecret: "password1",
+								Groups:       []string{"some-group"},
+								URL:          "https://gitlab.example.com",
+							},
+						},
+					}
+					fakeFactoryDB.GetTeamByNameReturns(savedTeam, true, nil)
+				})
+
+				It("returns back GitLab's auth provider", func() {
+					providers, err := oauthFactory.GetProviders(atc.DefaultTeamName)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(providers).To(HaveLen(1))
+
+					provider := providers[gitlab.ProviderName]
+					Expect(provider).NotTo(BeNil())
+					Expect(provider.AuthCodeURL("some-state")).To(HavePrefix("https://gitlab.example.com/oauth/authorize?"))
+				})
+			})
+		})
+
+		Describe("OIDC Provider", func() {
+			var issuer *httptest.Server
+			var discoveryStatus int
+			var discoveryRequests int
+
+			BeforeEach(func() {
+				discoveryStatus = http.StatusOK
+				discoveryRequests = 0
+
+				issuer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					Expect(r.URL.Path).To(Equal("/.well-known/openid-configuration"))
+
+					discoveryRequests++
+
+					w.WriteHeader(discoveryStatus)
+					json.NewEncoder(w).Encode(oidc.Configuration{
+						Issuer:                issuer.URL,
+						AuthorizationEndpoint: issuer.URL + "/authorize",
+						TokenEndpoint:         issuer.URL + "/token",
+						JWKSURI:               issuer.URL + "/keys",
+					})
+				}))
+
+				savedTeam := db.SavedTeam{
+					Team: db.Team{
+						Name: atc.DefaultTeamName,
+						OIDCAuth: db.OIDCAuth{
+							Issuer:       issuer.URL,
+							ClientID:     "some-client-id",
+							ClientSecret: "some-client-secret",
+							Groups:       []string{"some-group"},
+						},
+					},
+				}
+				fakeFactoryDB.GetTeamByNameReturns(savedTeam, true, nil)
+			})
+
+			AfterEach(func() {
+				issuer.Close()
+			})
+
+			It("returns back the OIDC auth provider using the discovered endpoints", func() {
+				providers, err := oauthFactory.GetProviders(atc.DefaultTeamName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(providers).To(HaveLen(1))
+
+				provider := providers[oidc.ProviderName]
+				Expect(provider).NotTo(BeNil())
+				Expect(provider.DisplayName()).To(Equal(oidc.DefaultDisplayName))
+				Expect(provider.AuthCodeURL("some-state")).To(HavePrefix(issuer.URL + "/authorize?"))
+			})
+
+			It("only discovers the endpoints once", func() {
+				_, err := oauthFactory.GetProviders(atc.DefaultTeamName)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = oauthFactory.GetProviders(atc.DefaultTeamName)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(discoveryRequests).To(Equal(1))
+			})
+
+			Context("when discovery fails", func() {
+				BeforeEach(func() {
+					discoveryStatus = http.StatusInternalServerError
+
+					savedTeam := db.SavedTeam{
+						Team: db.Team{
+							Name: atc.DefaultTeamName,
+							GitHubAuth: db.GitHubAuth{
+								ClientID:     "user1",
+								ClientSecret: "password1",
+								Users:        []string{"thecandyman"},
+							},
+							OIDCAuth: db.OIDCAuth{
+								Issuer:       issuer.URL,
+								ClientID:     "some-client-id",
+								ClientSecret: "some-client-secret",
+								Groups:       []string{"some-group"},
+							},
+						},
+					}
+					fakeFactoryDB.GetTeamByNameReturns(savedTeam, true, nil)
+				})
+
+				It("skips the OIDC provider but returns the others", func() {
+					providers, err := oauthFactory.GetProviders(atc.DefaultTeamName)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(providers).To(HaveLen(1))
+					Expect(providers[github.ProviderName]).NotTo(BeNil())
+				})
+			})
+		})
+
 		Context("when team does not exist", func() {
 			BeforeEach(func() {
 				fakeFactoryDB.GetTeamByNameReturns(db.SavedTeam{}, false, nil)
@@ -15,6 +15,7 @@ type Providers map[string]Provider
 
 type Provider interface {
 	DisplayName() string
+	CurrentUser(lager.Logger, *http.Client) (string, error)
 
 	OAuthClient
 	Verifier
@@ -18,6 +18,16 @@ type FakeProvider struct {
 	displayNameReturns     struct {
 		result1 string
 	}
+	CurrentUserStub        func(lager.Logger, *http.Client) (string, error)
+	currentUserMutex       sync.RWMutex
+	currentUserArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 *http.Client
+	}
+	currentUserReturns struct {
+		result1 string
+		result2 error
+	}
 	AuthCodeURLStub        func(string, ...oauth2.AuthCodeOption) string
 	authCodeURLMutex       sync.RWMutex
 	authCodeURLArgsForCall []struct {
@@ -85,6 +95,41 @@ func (fake *FakeProvider) DisplayNameReturns(result1 string) {
 	}{result1}
 }
 
+func (fake *FakeProvider) CurrentUser(arg1 lager.Logger, arg2 *http.Client) (string, error) {
+	fake.currentUserMutex.Lock()
+	fake.currentUserArgsForCall = append(fake.currentUserArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 *http.Client
+	}{arg1, arg2})
+	fake.recordInvocation("CurrentUser", []interface{}{arg1, arg2})
+	fake.currentUserMutex.Unlock()
+	if fake.CurrentUserStub != nil {
+		return fake.CurrentUserStub(arg1, arg2)
+	} else {
+		return fake.currentUserReturns.result1, fake.currentUserReturns.result2
+	}
+}
+
+func (fake *FakeProvider) CurrentUserCallCount() int {
+	fake.currentUserMutex.RLock()
+	defer fake.currentUserMutex.RUnlock()
+	return len(fake.currentUserArgsForCall)
+}
+
+func (fake *FakeProvider) CurrentUserArgsForCall(i int) (lager.Logger, *http.Client) {
+	fake.currentUserMutex.RLock()
+	defer fake.currentUserMutex.RUnlock()
+	return fake.currentUserArgsForCall[i].arg1, fake.currentUserArgsForCall[i].arg2
+}
+
+func (fake *FakeProvider) CurrentUserReturns(result1 string, result2 error) {
+	fake.CurrentUserStub = nil
+	fake.currentUserReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeProvider) AuthCodeURL(arg1 string, arg2 ...oauth2.AuthCodeOption) string {
 	fake.authCodeURLMutex.Lock()
 	fake.authCodeURLArgsForCall = append(fake.authCodeURLArgsForCall, struct {
@@ -228,6 +273,8 @@ func (fake *FakeProvider) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.displayNameMutex.RLock()
 	defer fake.displayNameMutex.RUnlock()
+	fake.currentUserMutex.RLock()
+	defer fake.currentUserMutex.RUnlock()
 	fake.authCodeURLMutex.RLock()
 	defer fake.authCodeURLMutex.RUnlock()
 	fake.exchangeMutex.RLock()
@@ -17,9 +17,11 @@ const expClaimKey = "exp"
 const teamNameClaimKey = "teamName"
 const teamIDClaimKey = "teamID"
 const isAdminClaimKey = "isAdmin"
+const roleClaimKey = "role"
+const userClaimKey = "user"
 
 type TokenGenerator interface {
-	GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool) (TokenType, TokenValue, error)
+	GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool, role string, user string) (TokenType, TokenValue, error)
 }
 
 type tokenGenerator struct {
@@ -32,12 +34,14 @@ func NewTokenGenerator(privateKey *rsa.PrivateKey) TokenGenerator {
 	}
 }
 
-func (generator *tokenGenerator) GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool) (TokenType, TokenValue, error) {
+func (generator *tokenGenerator) GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool, role string, user string) (TokenType, TokenValue, error) {
 	jwtToken := jwt.New(SigningMethod)
 	jwtToken.Claims["exp"] = expiration.Unix()
 	jwtToken.Claims["teamName"] = teamName
 	jwtToken.Claims["teamID"] = teamID
 	jwtToken.Claims["isAdmin"] = isAdmin
+	jwtToken.Claims["role"] = role
+	jwtToken.Claims["user"] = user
 
 	signed, err := jwtToken.SignedString(generator.privateKey)
 	if err != nil {
@@ -6,4 +6,6 @@ import "net/http"
 
 type UserContextReader interface {
 	GetTeam(r *http.Request) (string, int, bool, bool)
+	GetRole(r *http.Request) (string, bool)
+	GetUser(r *http.Request) (string, bool)
 }
@@ -0,0 +1,38 @@
+package auth
+
+import "net/http"
+
+type UserContextReaderBasket []UserContextReader
+
+func (rb UserContextReaderBasket) GetTeam(r *http.Request) (string, int, bool, bool) {
+	for _, reader := range rb {
+		teamName, teamID, isAdmin, found := reader.GetTeam(r)
+		if found {
+			return teamName, teamID, isAdmin, true
+		}
+	}
+
+	return "", 0, false, false
+}
+
+func (rb UserContextReaderBasket) GetRole(r *http.Request) (string, bool) {
+	for _, reader := range rb {
+		role, found := reader.GetRole(r)
+		if found {
+			return role, true
+		}
+	}
+
+	return "", false
+}
+
+func (rb UserContextReaderBasket) GetUser(r *http.Request) (string, bool) {
+	for _, reader := range rb {
+		user, found := reader.GetUser(r)
+		if found {
+			return user, true
+		}
+	}
+
+	return "", false
+}
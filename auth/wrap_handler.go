@@ -10,6 +10,8 @@ var authenticated = "authenticated"
 var teamNameKey = "teamName"
 var teamIDKey = "teamID"
 var isAdminKey = "isAdmin"
+var roleKey = "role"
+var userKey = "user"
 
 func WrapHandler(
 	handler http.Handler,
@@ -36,6 +38,16 @@ func (h authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		context.Set(r, teamNameKey, teamName)
 		context.Set(r, teamIDKey, teamID)
 		context.Set(r, isAdminKey, isAdmin)
+
+		role, found := h.userContextReader.GetRole(r)
+		if found {
+			context.Set(r, roleKey, role)
+		}
+
+		user, found := h.userContextReader.GetUser(r)
+		if found {
+			context.Set(r, userKey, user)
+		}
 	}
 	h.handler.ServeHTTP(w, r)
 }
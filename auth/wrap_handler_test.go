@@ -25,6 +25,8 @@ var _ = Describe("WrapHandler", func() {
 		teamIDChan    <-chan int
 		isAdminChan   <-chan bool
 		foundChan     <-chan bool
+		roleChan      <-chan string
+		userChan      <-chan string
 	)
 
 	BeforeEach(func() {
@@ -36,11 +38,15 @@ var _ = Describe("WrapHandler", func() {
 		ti := make(chan int, 1)
 		ia := make(chan bool, 1)
 		f := make(chan bool, 1)
+		rl := make(chan string, 1)
+		u := make(chan string, 1)
 		authenticated = a
 		teamNameChan = tn
 		teamIDChan = ti
 		isAdminChan = ia
 		foundChan = f
+		roleChan = rl
+		userChan = u
 		simpleHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			a <- auth.IsAuthenticated(r)
 			teamName, teamID, isAdmin, found := auth.GetTeam(r)
@@ -48,6 +54,10 @@ var _ = Describe("WrapHandler", func() {
 			tn <- teamName
 			ti <- teamID
 			ia <- isAdmin
+			role, _ := auth.GetRole(r)
+			rl <- role
+			user, _ := auth.GetUser(r)
+			u <- user
 		})
 
 		server = httptest.NewServer(auth.WrapHandler(
@@ -102,6 +112,8 @@ var _ = Describe("WrapHandler", func() {
 		Context("when the userContextReader finds team information", func() {
 			BeforeEach(func() {
 				fakeUserContextReader.GetTeamReturns("some-team", 9, true, true)
+				fakeUserContextReader.GetRoleReturns("member", true)
+				fakeUserContextReader.GetUserReturns("github:some-user", true)
 			})
 
 			It("passes the team information along in the request object", func() {
@@ -109,6 +121,8 @@ var _ = Describe("WrapHandler", func() {
 				Expect(<-teamNameChan).To(Equal("some-team"))
 				Expect(<-teamIDChan).To(Equal(9))
 				Expect(<-isAdminChan).To(BeTrue())
+				Expect(<-roleChan).To(Equal("member"))
+				Expect(<-userChan).To(Equal("github:some-user"))
 			})
 		})
 
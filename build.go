@@ -59,4 +59,5 @@ type BuildPreparation struct {
 	Inputs              map[string]BuildPreparationStatus `json:"inputs"`
 	InputsSatisfied     BuildPreparationStatus            `json:"inputs_satisfied"`
 	MissingInputReasons MissingInputReasons               `json:"missing_input_reasons"`
+	WorkersAvailable    BuildPreparationStatus            `json:"workers_available"`
 }
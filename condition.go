@@ -0,0 +1,25 @@
+package atc
+
+// A ConditionConfig determines whether a conditional step runs. Every
+// criterion that is specified must match for the step to run.
+type ConditionConfig struct {
+	// the name of the job that the build belongs to
+	Job string `yaml:"job,omitempty" json:"job,omitempty" mapstructure:"job"`
+
+	// a field of the version fetched by an earlier get step
+	Version *VersionCondition `yaml:"version,omitempty" json:"version,omitempty" mapstructure:"version"`
+
+	// a file in an artifact, e.g. flags/deploy, whose contents must be 'true'
+	File string `yaml:"file,omitempty" json:"file,omitempty" mapstructure:"file"`
+}
+
+type VersionCondition struct {
+	// name of the get step, e.g. bosh-stemcell
+	Get string `yaml:"get" json:"get" mapstructure:"get"`
+
+	// field of the version to compare, e.g. branch
+	Key string `yaml:"key" json:"key" mapstructure:"key"`
+
+	// value the field must have, e.g. master
+	Value string `yaml:"value" json:"value" mapstructure:"value"`
+}
@@ -14,9 +14,11 @@ const DefaultTeamName = "main"
 type Tags []string
 
 type ConfigResponse struct {
-	Config    *Config   `json:"config"`
-	Errors    []string  `json:"errors"`
-	RawConfig RawConfig `json:"raw_config"`
+	Config       *Config      `json:"config"`
+	Errors       []string     `json:"errors"`
+	RawConfig    RawConfig    `json:"raw_config"`
+	Template     string       `json:"template,omitempty"`
+	TemplateVars TemplateVars `json:"template_vars,omitempty"`
 }
 
 type Config struct {
@@ -56,6 +58,8 @@ type ResourceConfig struct {
 	Type       string `yaml:"type" json:"type" mapstructure:"type"`
 	Source     Source `yaml:"source" json:"source" mapstructure:"source"`
 	CheckEvery string `yaml:"check_every,omitempty" json:"check_every" mapstructure:"check_every"`
+
+	WebhookToken string `yaml:"webhook_token,omitempty" json:"webhook_token,omitempty" mapstructure:"webhook_token"`
 }
 
 type ResourceType struct {
@@ -98,6 +102,8 @@ type JobConfig struct {
 	BuildLogsToRetain    int      `yaml:"build_logs_to_retain,omitempty" json:"build_logs_to_retain,omitempty" mapstructure:"build_logs_to_retain"`
 
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
+
+	Matrix MatrixConfig `yaml:"matrix,omitempty" json:"matrix,omitempty" mapstructure:"matrix"`
 }
 
 func (config JobConfig) MaxInFlight() int {
@@ -226,11 +232,23 @@ func (c *VersionConfig) MarshalJSON() ([]byte, error) {
 	return json.Marshal("")
 }
 
+type InParallelConfig struct {
+	// the steps to run in parallel
+	Steps PlanSequence `yaml:"steps,omitempty" json:"steps,omitempty" mapstructure:"steps"`
+
+	// maximum number of steps to run at once; unlimited if zero
+	Limit int `yaml:"limit,omitempty" json:"limit,omitempty" mapstructure:"limit"`
+
+	// interrupt the remaining steps as soon as one of them fails
+	FailFast bool `yaml:"fail_fast,omitempty" json:"fail_fast,omitempty" mapstructure:"fail_fast"`
+}
+
 // A PlanConfig is a flattened set of configuration corresponding to
 // a particular Plan, where Source and Version are populated lazily.
 type PlanConfig struct {
 	// makes the Plan conditional
 	// conditions on which to perform a nested sequence
+	Condition *ConditionConfig `yaml:"if,omitempty" json:"if,omitempty" mapstructure:"if"`
 
 	// compose a nested sequence of plans
 	// name of the nested 'do'
@@ -242,6 +260,9 @@ type PlanConfig struct {
 	// corresponds to an Aggregate plan, keyed by the name of each sub-plan
 	Aggregate *PlanSequence `yaml:"aggregate,omitempty" json:"aggregate,omitempty" mapstructure:"aggregate"`
 
+	// corresponds to an InParallel plan, running a bounded number of sub-plans at once
+	InParallel *InParallelConfig `yaml:"in_parallel,omitempty" json:"in_parallel,omitempty" mapstructure:"in_parallel"`
+
 	// corresponds to Get and Put resource plans, respectively
 	// name of 'input', e.g. bosh-stemcell
 	Get string `yaml:"get,omitempty" json:"get,omitempty" mapstructure:"get"`
@@ -59,6 +59,12 @@ func collectInputs(plan atc.PlanConfig) []JobInput {
 		}
 	}
 
+	if plan.InParallel != nil {
+		for _, p := range plan.InParallel.Steps {
+			inputs = append(inputs, collectInputs(p)...)
+		}
+	}
+
 	if plan.Get != "" {
 		get := plan.Get
 
@@ -106,6 +112,12 @@ func collectOutputs(plan atc.PlanConfig) []JobOutput {
 		}
 	}
 
+	if plan.InParallel != nil {
+		for _, p := range plan.InParallel.Steps {
+			outputs = append(outputs, collectOutputs(p)...)
+		}
+	}
+
 	if plan.Aggregate != nil {
 		var outputs []JobOutput
 
@@ -273,6 +273,39 @@ var _ = Describe("Job config", func() {
 				})
 			})
 
+			Context("when an in_parallel plan is the first step", func() {
+				BeforeEach(func() {
+					jobConfig.Plan = atc.PlanSequence{
+						{
+							InParallel: &atc.InParallelConfig{
+								Steps: atc.PlanSequence{
+									{Get: "a"},
+									{Put: "y"},
+									{Get: "b", Resource: "some-resource", Passed: []string{"x"}},
+								},
+								Limit: 1,
+							},
+						},
+					}
+				})
+
+				It("returns an input config for all get plans", func() {
+					Expect(inputs).To(Equal([]config.JobInput{
+						{
+							Name:     "a",
+							Resource: "a",
+							Trigger:  false,
+						},
+						{
+							Name:     "b",
+							Resource: "some-resource",
+							Passed:   []string{"x"},
+							Trigger:  false,
+						},
+					}))
+				})
+			})
+
 			Context("when there are not gets in the plan", func() {
 				BeforeEach(func() {
 					jobConfig.Plan = atc.PlanSequence{
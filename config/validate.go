@@ -33,7 +33,18 @@ func newDeprecationWarning(message string) Warning {
 	}
 }
 
-func ValidateConfig(c atc.Config) ([]Warning, []string) {
+// PipelineConfigs looks up the config of another pipeline in the team that a
+// config is being saved to. It is used to validate cross-pipeline passed
+// constraints, e.g. 'other-pipeline/some-job'.
+//
+// A passed constraint has no way to name one instance of a pipeline, so
+// pipelines with instance vars cannot be referenced; lookups for them return
+// ErrInstancedPipeline.
+type PipelineConfigs func(pipelineName string) (atc.Config, bool, error)
+
+var ErrInstancedPipeline = errors.New("pipelines with instance vars cannot be referenced by passed constraints")
+
+func ValidateConfig(c atc.Config, pipelines PipelineConfigs) ([]Warning, []string) {
 	warnings := []Warning{}
 	errorMessages := []string{}
 
@@ -52,7 +63,7 @@ func ValidateConfig(c atc.Config) ([]Warning, []string) {
 		errorMessages = append(errorMessages, formatErr("resource types", resourceTypesErr))
 	}
 
-	jobWarnings, jobsErr := validateJobs(c)
+	jobWarnings, jobsErr := validateJobs(c, pipelines)
 	if jobsErr != nil {
 		errorMessages = append(errorMessages, formatErr("jobs", jobsErr))
 	}
@@ -153,7 +164,7 @@ func validateResourceTypes(c atc.Config) error {
 	return compositeErr(errorMessages)
 }
 
-func validateJobs(c atc.Config) ([]Warning, error) {
+func validateJobs(c atc.Config, pipelines PipelineConfigs) ([]Warning, error) {
 	errorMessages := []string{}
 	warnings := []Warning{}
 
@@ -187,9 +198,11 @@ func validateJobs(c atc.Config) ([]Warning, error) {
 			)
 		}
 
-		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", atc.PlanConfig{Do: &job.Plan})
+		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", atc.PlanConfig{Do: &job.Plan}, pipelines)
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
+
+		errorMessages = append(errorMessages, validateConditionGets(identifier, job)...)
 	}
 
 	return warnings, compositeErr(errorMessages)
@@ -209,6 +222,12 @@ func doesAnyStepMatch(planSequence atc.PlanSequence, predicate func(step atc.Pla
 			}
 		}
 
+		if planStep.InParallel != nil {
+			if doesAnyStepMatch(planStep.InParallel.Steps, predicate) {
+				return true
+			}
+		}
+
 		if predicate(planStep) {
 			return true
 		}
@@ -217,6 +236,70 @@ func doesAnyStepMatch(planSequence atc.PlanSequence, predicate func(step atc.Pla
 	return false
 }
 
+// validateConditionGets ensures that the conditions in a job's plan only refer
+// to the versions of get steps in the same job.
+func validateConditionGets(identifier string, job atc.JobConfig) []string {
+	gets := map[string]bool{}
+	for _, input := range JobInputs(job) {
+		gets[input.Name] = true
+	}
+
+	errorMessages := []string{}
+
+	for _, condition := range collectConditions(atc.PlanConfig{Do: &job.Plan}) {
+		if condition.Version == nil || condition.Version.Get == "" {
+			continue
+		}
+
+		if !gets[condition.Version.Get] {
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf(
+					"%s has a condition referring to an unknown get step ('%s')",
+					identifier,
+					condition.Version.Get,
+				),
+			)
+		}
+	}
+
+	return errorMessages
+}
+
+func collectConditions(plan atc.PlanConfig) []atc.ConditionConfig {
+	var conditions []atc.ConditionConfig
+
+	if plan.Condition != nil {
+		conditions = append(conditions, *plan.Condition)
+	}
+
+	for _, hook := range []*atc.PlanConfig{plan.Success, plan.Failure, plan.Ensure, plan.Try} {
+		if hook != nil {
+			conditions = append(conditions, collectConditions(*hook)...)
+		}
+	}
+
+	if plan.Do != nil {
+		for _, p := range *plan.Do {
+			conditions = append(conditions, collectConditions(p)...)
+		}
+	}
+
+	if plan.Aggregate != nil {
+		for _, p := range *plan.Aggregate {
+			conditions = append(conditions, collectConditions(p)...)
+		}
+	}
+
+	if plan.InParallel != nil {
+		for _, p := range plan.InParallel.Steps {
+			conditions = append(conditions, collectConditions(p)...)
+		}
+	}
+
+	return conditions
+}
+
 type foundTypes struct {
 	identifier string
 	found      map[string]bool
@@ -246,7 +329,7 @@ func (ft foundTypes) IsValid() (bool, string) {
 	return true, ""
 }
 
-func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warning, []string) {
+func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig, pipelines PipelineConfigs) ([]Warning, []string) {
 	foundTypes := foundTypes{
 		identifier: identifier,
 		found:      make(map[string]bool),
@@ -276,6 +359,10 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		foundTypes.Find("try")
 	}
 
+	if plan.InParallel != nil {
+		foundTypes.Find("in_parallel")
+	}
+
 	if valid, message := foundTypes.IsValid(); !valid {
 		return []Warning{}, []string{message}
 	}
@@ -287,7 +374,7 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 	case plan.Do != nil:
 		for i, plan := range *plan.Do {
 			subIdentifier := fmt.Sprintf("%s[%d]", identifier, i)
-			planWarnings, planErrMessages := validatePlan(c, subIdentifier, plan)
+			planWarnings, planErrMessages := validatePlan(c, subIdentifier, plan, pipelines)
 			warnings = append(warnings, planWarnings...)
 			errorMessages = append(errorMessages, planErrMessages...)
 		}
@@ -295,7 +382,22 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 	case plan.Aggregate != nil:
 		for i, plan := range *plan.Aggregate {
 			subIdentifier := fmt.Sprintf("%s.aggregate[%d]", identifier, i)
-			planWarnings, planErrMessages := validatePlan(c, subIdentifier, plan)
+			planWarnings, planErrMessages := validatePlan(c, subIdentifier, plan, pipelines)
+			warnings = append(warnings, planWarnings...)
+			errorMessages = append(errorMessages, planErrMessages...)
+		}
+
+	case plan.InParallel != nil:
+		if plan.InParallel.Limit < 0 {
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf("%s.in_parallel.limit has an invalid value (%d)", identifier, plan.InParallel.Limit),
+			)
+		}
+
+		for i, plan := range plan.InParallel.Steps {
+			subIdentifier := fmt.Sprintf("%s.in_parallel[%d]", identifier, i)
+			planWarnings, planErrMessages := validatePlan(c, subIdentifier, plan, pipelines)
 			warnings = append(warnings, planWarnings...)
 			errorMessages = append(errorMessages, planErrMessages...)
 		}
@@ -334,8 +436,13 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		}
 
 		for _, job := range plan.Passed {
-			jobConfig, found := c.Jobs.Lookup(job)
-			if !found {
+			jobConfig, found, lookupErrMessage := lookupPassedJob(c, job, pipelines)
+			if lookupErrMessage != "" {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf("%s.passed %s", identifier, lookupErrMessage),
+				)
+			} else if !found {
 				errorMessages = append(
 					errorMessages,
 					fmt.Sprintf(
@@ -433,28 +540,28 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 
 	case plan.Try != nil:
 		subIdentifier := fmt.Sprintf("%s.try", identifier)
-		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Try)
+		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Try, pipelines)
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
 	}
 
 	if plan.Ensure != nil {
 		subIdentifier := fmt.Sprintf("%s.ensure", identifier)
-		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Ensure)
+		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Ensure, pipelines)
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
 	}
 
 	if plan.Success != nil {
 		subIdentifier := fmt.Sprintf("%s.success", identifier)
-		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Success)
+		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Success, pipelines)
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
 	}
 
 	if plan.Failure != nil {
 		subIdentifier := fmt.Sprintf("%s.failure", identifier)
-		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Failure)
+		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Failure, pipelines)
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
 	}
@@ -472,9 +579,104 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid number of attempts (%d)", plan.Attempts))
 	}
 
+	if plan.Condition != nil {
+		errorMessages = append(errorMessages, validateCondition(identifier+".if", *plan.Condition)...)
+	}
+
 	return warnings, errorMessages
 }
 
+// lookupPassedJob finds the config of a job referenced by a passed
+// constraint, which is either the name of a job in the same pipeline or
+// 'pipeline-name/job-name' for a job in another pipeline of the same team.
+func lookupPassedJob(c atc.Config, job string, pipelines PipelineConfigs) (atc.JobConfig, bool, string) {
+	jobConfig, found := c.Jobs.Lookup(job)
+	if found {
+		return jobConfig, true, ""
+	}
+
+	segs := strings.SplitN(job, "/", 2)
+	if len(segs) == 1 {
+		return atc.JobConfig{}, false, ""
+	}
+
+	pipelineName, jobName := segs[0], segs[1]
+	if pipelineName == "" || jobName == "" {
+		return atc.JobConfig{}, false, fmt.Sprintf("references a malformed job ('%s')", job)
+	}
+
+	if pipelines == nil {
+		return atc.JobConfig{}, false, fmt.Sprintf("references an unknown pipeline ('%s')", pipelineName)
+	}
+
+	pipelineConfig, found, err := pipelines(pipelineName)
+	if err == ErrInstancedPipeline {
+		return atc.JobConfig{}, false, fmt.Sprintf("references a pipeline with instance vars ('%s'), which passed constraints cannot reference", pipelineName)
+	}
+
+	if err != nil {
+		return atc.JobConfig{}, false, fmt.Sprintf("references a pipeline that could not be looked up ('%s'): %s", pipelineName, err)
+	}
+
+	if !found {
+		return atc.JobConfig{}, false, fmt.Sprintf("references an unknown pipeline ('%s')", pipelineName)
+	}
+
+	jobConfig, found = pipelineConfig.Jobs.Lookup(jobName)
+	return jobConfig, found, ""
+}
+
+func validateCondition(identifier string, condition atc.ConditionConfig) []string {
+	errorMessages := []string{}
+
+	if condition.Job == "" && condition.Version == nil && condition.File == "" {
+		errorMessages = append(errorMessages, identifier+" has no criteria specified")
+	}
+
+	if condition.Version != nil {
+		missingFields := []string{}
+
+		if condition.Version.Get == "" {
+			missingFields = append(missingFields, "get")
+		}
+
+		if condition.Version.Key == "" {
+			missingFields = append(missingFields, "key")
+		}
+
+		if condition.Version.Value == "" {
+			missingFields = append(missingFields, "value")
+		}
+
+		if len(missingFields) > 0 {
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf(
+					"%s.version is missing fields (%s)",
+					identifier,
+					strings.Join(missingFields, ", "),
+				),
+			)
+		}
+	}
+
+	if condition.File != "" {
+		segs := strings.SplitN(condition.File, "/", 2)
+		if len(segs) != 2 || segs[0] == "" || segs[1] == "" {
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf(
+					"%s.file must be of the form 'artifact-name/path' ('%s')",
+					identifier,
+					condition.File,
+				),
+			)
+		}
+	}
+
+	return errorMessages
+}
+
 func validateInapplicableFields(inapplicableFields []string, plan atc.PlanConfig, identifier string) []string {
 	errorMessages := []string{}
 	foundInapplicableFields := []string{}
@@ -1,6 +1,8 @@
 package config_test
 
 import (
+	"errors"
+
 	"github.com/concourse/atc"
 	. "github.com/concourse/atc/config"
 
@@ -10,13 +12,16 @@ import (
 
 var _ = Describe("ValidateConfig", func() {
 	var (
-		config atc.Config
+		config    atc.Config
+		pipelines PipelineConfigs
 
 		errorMessages  []string
 		configWarnings []Warning
 	)
 
 	BeforeEach(func() {
+		pipelines = nil
+
 		config = atc.Config{
 			Groups: atc.GroupConfigs{
 				{
@@ -80,7 +85,7 @@ var _ = Describe("ValidateConfig", func() {
 	})
 
 	JustBeforeEach(func() {
-		configWarnings, errorMessages = ValidateConfig(config)
+		configWarnings, errorMessages = ValidateConfig(config, pipelines)
 	})
 
 	Context("when the config is valid", func() {
@@ -761,6 +766,150 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when an in_parallel plan has a negative limit", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						InParallel: &atc.InParallelConfig{
+							Steps: atc.PlanSequence{
+								{Put: "some-resource"},
+							},
+							Limit: -1,
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].in_parallel.limit has an invalid value (-1)"))
+				})
+			})
+
+			Context("when an in_parallel plan has an invalid step", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						InParallel: &atc.InParallelConfig{
+							Steps: atc.PlanSequence{
+								{Put: "some-resource"},
+								{Put: "custom-name", Resource: "some-missing-resource"},
+							},
+							Limit: 1,
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].in_parallel[1].put.custom-name refers to a resource that does not exist ('some-missing-resource')"))
+				})
+			})
+
+			Context("when a plan has a condition with no criteria", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Put:       "some-resource",
+						Condition: &atc.ConditionConfig{},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.if has no criteria specified"))
+				})
+			})
+
+			Context("when a plan has a version condition with missing fields", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get: "some-resource",
+					}, atc.PlanConfig{
+						Put: "some-resource",
+						Condition: &atc.ConditionConfig{
+							Version: &atc.VersionCondition{Get: "some-resource"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[1].put.some-resource.if.version is missing fields (key, value)"))
+				})
+			})
+
+			Context("when a plan has a version condition referring to an unknown get step", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Try: &atc.PlanConfig{
+							Put: "some-resource",
+							Condition: &atc.ConditionConfig{
+								Version: &atc.VersionCondition{
+									Get:   "bogus-get",
+									Key:   "branch",
+									Value: "master",
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has a condition referring to an unknown get step ('bogus-get')"))
+				})
+			})
+
+			Context("when a plan has a file condition that does not name an artifact", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Put: "some-resource",
+						Condition: &atc.ConditionConfig{
+							File: "flag",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.if.file must be of the form 'artifact-name/path' ('flag')"))
+				})
+			})
+
+			Context("when a plan has a valid condition", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get: "some-resource",
+					}, atc.PlanConfig{
+						Put: "some-resource",
+						Condition: &atc.ConditionConfig{
+							Job: "some-other-job",
+							Version: &atc.VersionCondition{
+								Get:   "some-resource",
+								Key:   "branch",
+								Value: "master",
+							},
+							File: "some-resource/flag",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(BeEmpty())
+				})
+			})
+
 			Context("when a put plan has a custom name but refers to a resource that does not exist", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -859,6 +1008,153 @@ var _ = Describe("ValidateConfig", func() {
 					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a job ('some-empty-job') which doesn't interact with the resource ('some-resource')"))
 				})
 			})
+
+			Context("when a job's input's passed constraints reference a job in another pipeline", func() {
+				var lookedUp []string
+
+				BeforeEach(func() {
+					lookedUp = []string{}
+
+					pipelines = func(pipelineName string) (atc.Config, bool, error) {
+						lookedUp = append(lookedUp, pipelineName)
+
+						switch pipelineName {
+						case "other-pipeline":
+							return atc.Config{
+								Jobs: atc.JobConfigs{
+									{
+										Name: "other-job",
+										Plan: atc.PlanSequence{
+											{Put: "some-resource"},
+										},
+									},
+									{
+										Name: "other-empty-job",
+									},
+								},
+							}, true, nil
+						case "broken-pipeline":
+							return atc.Config{}, false, errors.New("disaster")
+						case "instanced-pipeline":
+							return atc.Config{}, false, ErrInstancedPipeline
+						default:
+							return atc.Config{}, false, nil
+						}
+					}
+				})
+
+				Context("when the job exists and has the resource as an output", func() {
+					BeforeEach(func() {
+						job.Plan = append(job.Plan, atc.PlanConfig{
+							Get:    "some-resource",
+							Passed: []string{"other-pipeline/other-job"},
+						})
+
+						config.Jobs = append(config.Jobs, job)
+					})
+
+					It("does not return an error", func() {
+						Expect(errorMessages).To(HaveLen(0))
+						Expect(lookedUp).To(Equal([]string{"other-pipeline"}))
+					})
+				})
+
+				Context("when the job does not interact with the resource", func() {
+					BeforeEach(func() {
+						job.Plan = append(job.Plan, atc.PlanConfig{
+							Get:    "some-resource",
+							Passed: []string{"other-pipeline/other-empty-job"},
+						})
+
+						config.Jobs = append(config.Jobs, job)
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a job ('other-pipeline/other-empty-job') which doesn't interact with the resource ('some-resource')"))
+					})
+				})
+
+				Context("when the job does not exist", func() {
+					BeforeEach(func() {
+						job.Plan = append(job.Plan, atc.PlanConfig{
+							Get:    "some-resource",
+							Passed: []string{"other-pipeline/bogus-job"},
+						})
+
+						config.Jobs = append(config.Jobs, job)
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references an unknown job ('other-pipeline/bogus-job')"))
+					})
+				})
+
+				Context("when the pipeline does not exist", func() {
+					BeforeEach(func() {
+						job.Plan = append(job.Plan, atc.PlanConfig{
+							Get:    "some-resource",
+							Passed: []string{"bogus-pipeline/other-job"},
+						})
+
+						config.Jobs = append(config.Jobs, job)
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references an unknown pipeline ('bogus-pipeline')"))
+					})
+				})
+
+				Context("when looking up the pipeline fails", func() {
+					BeforeEach(func() {
+						job.Plan = append(job.Plan, atc.PlanConfig{
+							Get:    "some-resource",
+							Passed: []string{"broken-pipeline/other-job"},
+						})
+
+						config.Jobs = append(config.Jobs, job)
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a pipeline that could not be looked up ('broken-pipeline'): disaster"))
+					})
+				})
+
+				Context("when the pipeline has instance vars", func() {
+					BeforeEach(func() {
+						job.Plan = append(job.Plan, atc.PlanConfig{
+							Get:    "some-resource",
+							Passed: []string{"instanced-pipeline/other-job"},
+						})
+
+						config.Jobs = append(config.Jobs, job)
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a pipeline with instance vars ('instanced-pipeline'), which passed constraints cannot reference"))
+					})
+				})
+
+				Context("when the reference is malformed", func() {
+					BeforeEach(func() {
+						job.Plan = append(job.Plan, atc.PlanConfig{
+							Get:    "some-resource",
+							Passed: []string{"/other-job"},
+						})
+
+						config.Jobs = append(config.Jobs, job)
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a malformed job ('/other-job')"))
+					})
+				})
+			})
 		})
 
 		Context("when two jobs have the same name", func() {
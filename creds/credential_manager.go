@@ -0,0 +1,9 @@
+package creds
+
+//go:generate counterfeiter . CredentialManager
+
+// CredentialManager looks up the value of a ((var)) placeholder. Lookups are
+// scoped to a pipeline first and fall back to the team.
+type CredentialManager interface {
+	Get(teamName string, pipelineName string, varName string) (interface{}, bool, error)
+}
@@ -0,0 +1,82 @@
+package creds_test
+
+import (
+	"io/ioutil"
+	"os"
+
+	. "github.com/concourse/atc/creds"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FileManager", func() {
+	var (
+		credentialsFile *os.File
+		manager         *FileManager
+	)
+
+	BeforeEach(func() {
+		var err error
+		credentialsFile, err = ioutil.TempFile("", "credentials")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = credentialsFile.WriteString(`
+main/some-pipeline/password: pipeline-password
+main/password: team-password
+main/keys:
+  public: pub
+  private: priv
+other-team/secret: other-secret
+`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(credentialsFile.Close()).To(Succeed())
+
+		manager, err = NewFileManager(credentialsFile.Name())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.Remove(credentialsFile.Name())).To(Succeed())
+	})
+
+	It("prefers the pipeline's value", func() {
+		value, found, err := manager.Get("main", "some-pipeline", "password")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("pipeline-password"))
+	})
+
+	It("falls back to the team's value", func() {
+		value, found, err := manager.Get("main", "other-pipeline", "password")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("team-password"))
+	})
+
+	It("returns maps that can be encoded as JSON", func() {
+		value, found, err := manager.Get("main", "some-pipeline", "keys")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal(map[string]interface{}{"public": "pub", "private": "priv"}))
+	})
+
+	It("does not find another team's values", func() {
+		_, found, err := manager.Get("main", "some-pipeline", "secret")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+
+		_, found, err = manager.Get("main", "..", "other-team/secret")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+
+		_, found, err = manager.Get("main", "some-pipeline", "../../other-team/secret")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("errors when the file does not exist", func() {
+		_, err := NewFileManager("/does/not/exist")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,55 @@
+package creds
+
+import "sort"
+
+// ResolvedValues collects the strings that placeholders resolved to, so that
+// they can be redacted from build output.
+type ResolvedValues struct {
+	values map[string]struct{}
+}
+
+func (resolved *ResolvedValues) add(value interface{}) {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return
+		}
+
+		if resolved.values == nil {
+			resolved.values = map[string]struct{}{}
+		}
+
+		resolved.values[v] = struct{}{}
+
+	case map[string]interface{}:
+		for _, sub := range v {
+			resolved.add(sub)
+		}
+
+	case map[interface{}]interface{}:
+		for _, sub := range v {
+			resolved.add(sub)
+		}
+
+	case []interface{}:
+		for _, sub := range v {
+			resolved.add(sub)
+		}
+	}
+}
+
+// Strings returns the collected values in a stable order.
+func (resolved *ResolvedValues) Strings() []string {
+	if len(resolved.values) == 0 {
+		return nil
+	}
+
+	strings := make([]string, 0, len(resolved.values))
+	for value := range resolved.values {
+		strings = append(strings, value)
+	}
+
+	sort.Strings(strings)
+
+	return strings
+}
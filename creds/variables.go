@@ -0,0 +1,238 @@
+package creds
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/concourse/atc"
+)
+
+var placeholderRegexp = regexp.MustCompile(`\(\(([-\w\p{L}./]+)\)\)`)
+
+type UndefinedVariableError struct {
+	Name string
+}
+
+func (err UndefinedVariableError) Error() string {
+	return fmt.Sprintf("undefined variable: %s", err.Name)
+}
+
+// Variables resolves ((var)) placeholders for a single pipeline. A nil
+// Manager leaves placeholders untouched. If Resolved is set, every value a
+// placeholder resolves to is recorded in it.
+type Variables struct {
+	Manager      CredentialManager
+	TeamName     string
+	PipelineName string
+
+	Resolved *ResolvedValues
+}
+
+func (variables Variables) EvaluateSource(source atc.Source) (atc.Source, error) {
+	if source == nil {
+		return nil, nil
+	}
+
+	evaluated, err := variables.evaluateMap(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return atc.Source(evaluated), nil
+}
+
+func (variables Variables) EvaluateParams(params atc.Params) (atc.Params, error) {
+	if params == nil {
+		return nil, nil
+	}
+
+	evaluated, err := variables.evaluateMap(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return atc.Params(evaluated), nil
+}
+
+// EvaluateResourceTypes resolves placeholders in the source of each resource
+// type. The given resource types are not modified.
+func (variables Variables) EvaluateResourceTypes(resourceTypes atc.ResourceTypes) (atc.ResourceTypes, error) {
+	if resourceTypes == nil {
+		return nil, nil
+	}
+
+	evaluated := make(atc.ResourceTypes, len(resourceTypes))
+
+	for i, resourceType := range resourceTypes {
+		source, err := variables.EvaluateSource(resourceType.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceType.Source = source
+		evaluated[i] = resourceType
+	}
+
+	return evaluated, nil
+}
+
+// EvaluateTaskConfig resolves placeholders in the task's params and image
+// resource source. The given config is not modified.
+func (variables Variables) EvaluateTaskConfig(config *atc.TaskConfig) (*atc.TaskConfig, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	evaluated := *config
+
+	if config.Params != nil {
+		evaluated.Params = map[string]string{}
+
+		for name, value := range config.Params {
+			interpolated, err := variables.interpolate(value)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluated.Params[name] = interpolated
+		}
+	}
+
+	if config.ImageResource != nil {
+		source, err := variables.EvaluateSource(config.ImageResource.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		evaluated.ImageResource = &atc.ImageResource{
+			Type:   config.ImageResource.Type,
+			Source: source,
+		}
+	}
+
+	return &evaluated, nil
+}
+
+func (variables Variables) evaluateMap(root map[string]interface{}) (map[string]interface{}, error) {
+	evaluated := make(map[string]interface{}, len(root))
+
+	for key, val := range root {
+		sub, err := variables.evaluate(val)
+		if err != nil {
+			return nil, err
+		}
+
+		evaluated[key] = sub
+	}
+
+	return evaluated, nil
+}
+
+func (variables Variables) evaluate(root interface{}) (interface{}, error) {
+	switch rootVal := root.(type) {
+	case map[string]interface{}:
+		return variables.evaluateMap(rootVal)
+
+	case map[interface{}]interface{}:
+		evaluated := make(map[interface{}]interface{}, len(rootVal))
+
+		for key, val := range rootVal {
+			sub, err := variables.evaluate(val)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluated[key] = sub
+		}
+
+		return evaluated, nil
+
+	case []interface{}:
+		evaluated := make([]interface{}, len(rootVal))
+
+		for i, val := range rootVal {
+			sub, err := variables.evaluate(val)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluated[i] = sub
+		}
+
+		return evaluated, nil
+
+	case string:
+		if variables.Manager == nil {
+			return rootVal, nil
+		}
+
+		// a value that is only a placeholder takes on the variable's type, so
+		// that e.g. a whole map can be provided as a credential
+		match := placeholderRegexp.FindStringSubmatch(rootVal)
+		if match != nil && match[0] == rootVal {
+			return variables.lookup(match[1])
+		}
+
+		return variables.interpolate(rootVal)
+
+	default:
+		return rootVal, nil
+	}
+}
+
+func (variables Variables) interpolate(str string) (string, error) {
+	if variables.Manager == nil {
+		return str, nil
+	}
+
+	var lookupErr error
+
+	interpolated := placeholderRegexp.ReplaceAllStringFunc(str, func(placeholder string) string {
+		if lookupErr != nil {
+			return placeholder
+		}
+
+		name := placeholderRegexp.FindStringSubmatch(placeholder)[1]
+
+		value, err := variables.lookup(name)
+		if err != nil {
+			lookupErr = err
+			return placeholder
+		}
+
+		if strValue, ok := value.(string); ok {
+			return strValue
+		}
+
+		payload, err := json.Marshal(value)
+		if err != nil {
+			lookupErr = err
+			return placeholder
+		}
+
+		return string(payload)
+	})
+	if lookupErr != nil {
+		return "", lookupErr
+	}
+
+	return interpolated, nil
+}
+
+func (variables Variables) lookup(name string) (interface{}, error) {
+	value, found, err := variables.Manager.Get(variables.TeamName, variables.PipelineName, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, UndefinedVariableError{Name: name}
+	}
+
+	if variables.Resolved != nil {
+		variables.Resolved.add(value)
+	}
+
+	return value, nil
+}
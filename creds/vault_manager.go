@@ -0,0 +1,88 @@
+package creds
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// VaultManager reads credentials from a Vault-compatible HTTP API, e.g. a
+// generic secret backend mounted at PathPrefix. A secret stored with a single
+// "value" key resolves to that value, otherwise to all of its data.
+type VaultManager struct {
+	URL        string
+	Token      string
+	PathPrefix string
+
+	Client *http.Client
+}
+
+func (manager VaultManager) Get(teamName string, pipelineName string, varName string) (interface{}, bool, error) {
+	for _, secretPath := range lookupPaths(teamName, pipelineName, varName) {
+		value, found, err := manager.read(secretPath)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if found {
+			return value, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+type vaultSecret struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+func (manager VaultManager) read(secretPath string) (interface{}, bool, error) {
+	secretURL, err := url.Parse(strings.TrimRight(manager.URL, "/"))
+	if err != nil {
+		return nil, false, err
+	}
+
+	secretURL.Path = path.Join("/", secretURL.Path, "v1", manager.PathPrefix, secretPath)
+
+	req, err := http.NewRequest("GET", secretURL.String(), nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	req.Header.Set("X-Vault-Token", manager.Token)
+
+	client := manager.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("unexpected response from vault: %s", resp.Status)
+	}
+
+	var secret vaultSecret
+	err = json.NewDecoder(resp.Body).Decode(&secret)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if value, found := secret.Data["value"]; found && len(secret.Data) == 1 {
+		return value, true, nil
+	}
+
+	return secret.Data, true, nil
+}
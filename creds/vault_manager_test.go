@@ -0,0 +1,108 @@
+package creds_test
+
+import (
+	"net/http"
+
+	. "github.com/concourse/atc/creds"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("VaultManager", func() {
+	var (
+		vaultServer *ghttp.Server
+		manager     VaultManager
+	)
+
+	BeforeEach(func() {
+		vaultServer = ghttp.NewServer()
+
+		manager = VaultManager{
+			URL:        vaultServer.URL(),
+			Token:      "some-vault-token",
+			PathPrefix: "/concourse",
+		}
+	})
+
+	AfterEach(func() {
+		vaultServer.Close()
+	})
+
+	Context("when the pipeline has the secret", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/concourse/main/some-pipeline/password"),
+					ghttp.VerifyHeaderKV("X-Vault-Token", "some-vault-token"),
+					ghttp.RespondWith(http.StatusOK, `{"data":{"value":"hunter2"}}`),
+				),
+			)
+		})
+
+		It("returns its value", func() {
+			value, found, err := manager.Get("main", "some-pipeline", "password")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("hunter2"))
+		})
+	})
+
+	Context("when only the team has the secret", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/concourse/main/some-pipeline/keys"),
+					ghttp.RespondWith(http.StatusNotFound, `{"errors":[]}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/concourse/main/keys"),
+					ghttp.RespondWith(http.StatusOK, `{"data":{"public":"pub","private":"priv"}}`),
+				),
+			)
+		})
+
+		It("returns all of the secret's data", func() {
+			value, found, err := manager.Get("main", "some-pipeline", "keys")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal(map[string]interface{}{"public": "pub", "private": "priv"}))
+		})
+	})
+
+	Context("when the secret does not exist", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusNotFound, `{"errors":[]}`),
+				ghttp.RespondWith(http.StatusNotFound, `{"errors":[]}`),
+			)
+		})
+
+		It("is not found", func() {
+			_, found, err := manager.Get("main", "some-pipeline", "missing")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when vault errors", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusForbidden, `{"errors":["permission denied"]}`),
+			)
+		})
+
+		It("returns an error", func() {
+			_, _, err := manager.Get("main", "some-pipeline", "password")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	It("does not query vault for names outside the team", func() {
+		_, found, err := manager.Get("main", "some-pipeline", "../../other-team/secret")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+		Expect(vaultServer.ReceivedRequests()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,24 @@
+package db
+
+import "time"
+
+type APIToken struct {
+	TeamID int
+	Name   string
+	Role   string
+
+	// ExpiresAt is zero for tokens that never expire.
+	ExpiresAt time.Time
+}
+
+type SavedAPIToken struct {
+	ID int
+
+	APIToken
+
+	TeamName  string
+	TeamAdmin bool
+
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
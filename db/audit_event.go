@@ -0,0 +1,28 @@
+package db
+
+import "time"
+
+type AuditEvent struct {
+	TeamName string
+	UserName string
+	Route    string
+
+	// TeamID keeps the event linked to its team if the team is renamed. If it
+	// is zero when saving, the team is looked up by TeamName.
+	TeamID int
+
+	PipelineName string
+	JobName      string
+	ResourceName string
+
+	// Status is the HTTP status the request was answered with. It is zero
+	// for events recorded before statuses were.
+	Status int
+}
+
+type SavedAuditEvent struct {
+	ID        int
+	CreatedAt time.Time
+
+	AuditEvent
+}
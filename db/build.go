@@ -29,6 +29,10 @@ type Build struct {
 	PipelineName string
 	PipelineID   int
 
+	// TeamID is the team the build belongs to; for job builds this is the
+	// pipeline's team.
+	TeamID int
+
 	Engine         string
 	EngineMetadata string
 
@@ -63,6 +67,11 @@ type SavedResource struct {
 	CheckError   error
 	Paused       bool
 	PipelineName string
+
+	PinnedVersionID int
+	PinComment      string
+	PinnedBy        string
+
 	Resource
 }
 
@@ -82,6 +91,10 @@ func (r SavedResource) FailingToCheck() bool {
 	return r.CheckError != nil
 }
 
+func (r SavedResource) Pinned() bool {
+	return r.PinnedVersionID != 0
+}
+
 type VersionedResource struct {
 	Resource   string
 	Type       string
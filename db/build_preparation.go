@@ -25,6 +25,7 @@ type BuildPreparation struct {
 	Inputs              map[string]BuildPreparationStatus
 	InputsSatisfied     BuildPreparationStatus
 	MissingInputReasons MissingInputReasons
+	WorkersAvailable    BuildPreparationStatus
 }
 
 func NewBuildPreparation(buildID int) BuildPreparation {
@@ -36,12 +37,13 @@ func NewBuildPreparation(buildID int) BuildPreparation {
 		Inputs:              map[string]BuildPreparationStatus{},
 		InputsSatisfied:     BuildPreparationStatusUnknown,
 		MissingInputReasons: MissingInputReasons{},
+		WorkersAvailable:    BuildPreparationStatusUnknown,
 	}
 }
 
 type buildPreparationHelper struct{}
 
-const BuildPreparationColumns string = "build_id, paused_pipeline, paused_job, max_running_builds, inputs, inputs_satisfied, missing_input_reasons"
+const BuildPreparationColumns string = "build_id, paused_pipeline, paused_job, max_running_builds, inputs, inputs_satisfied, missing_input_reasons, workers_available"
 
 func (b buildPreparationHelper) CreateBuildPreparation(tx Tx, buildID int) error {
 	_, err := tx.Exec(`
@@ -64,7 +66,7 @@ func (b buildPreparationHelper) UpdateBuildPreparation(tx Tx, buildPrep BuildPre
 
 	_, err = tx.Exec(`
 	UPDATE build_preparation
-	SET paused_pipeline = $2, paused_job = $3, max_running_builds = $4, inputs = $5, inputs_satisfied = $6, missing_input_reasons = $7
+	SET paused_pipeline = $2, paused_job = $3, max_running_builds = $4, inputs = $5, inputs_satisfied = $6, missing_input_reasons = $7, workers_available = $8
 	WHERE build_id = $1
 	`,
 		buildPrep.BuildID,
@@ -74,6 +76,7 @@ func (b buildPreparationHelper) UpdateBuildPreparation(tx Tx, buildPrep BuildPre
 		string(inputsJSON),
 		string(buildPrep.InputsSatisfied),
 		string(missingInputReasonsJSON),
+		string(buildPrep.WorkersAvailable),
 	)
 	return err
 }
@@ -109,10 +112,10 @@ func (b buildPreparationHelper) constructBuildPreparations(rows *sql.Rows) ([]Bu
 	buildPreps := []BuildPreparation{}
 	for rows.Next() {
 		var buildID int
-		var pausedPipeline, pausedJob, maxRunningBuilds, inputsSatisfied string
+		var pausedPipeline, pausedJob, maxRunningBuilds, inputsSatisfied, workersAvailable string
 		var inputsBlob, missingInputReasonsBlob []byte
 
-		err := rows.Scan(&buildID, &pausedPipeline, &pausedJob, &maxRunningBuilds, &inputsBlob, &inputsSatisfied, &missingInputReasonsBlob)
+		err := rows.Scan(&buildID, &pausedPipeline, &pausedJob, &maxRunningBuilds, &inputsBlob, &inputsSatisfied, &missingInputReasonsBlob, &workersAvailable)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				return []BuildPreparation{}, nil
@@ -140,6 +143,7 @@ func (b buildPreparationHelper) constructBuildPreparations(rows *sql.Rows) ([]Bu
 			Inputs:              inputs,
 			InputsSatisfied:     BuildPreparationStatus(inputsSatisfied),
 			MissingInputReasons: missingInputReasons,
+			WorkersAvailable:    BuildPreparationStatus(workersAvailable),
 		})
 	}
 
@@ -50,6 +50,15 @@ type ContainerMetadata struct {
 	EnvironmentVariables []string
 	Attempts             []int
 	User                 string
+
+	// TeamID restricts the container to the team's own workers and shared
+	// workers. It is zero for containers that may only use shared workers.
+	TeamID int
+
+	// MemoryLimit and CPULimit are the limits the container was created
+	// with, or zero if it is unlimited.
+	MemoryLimit uint64
+	CPULimit    uint64
 }
 
 type Container struct {
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/encryption"
 	"github.com/lib/pq"
 	"github.com/pivotal-golang/lager"
 )
@@ -25,6 +26,8 @@ type Conn interface {
 	QueryRow(query string, args ...interface{}) *sql.Row
 	SetMaxIdleConns(n int)
 	SetMaxOpenConns(n int)
+
+	EncryptionStrategy() encryption.Strategy
 }
 
 //go:generate counterfeiter . Tx
@@ -40,21 +43,37 @@ type Tx interface {
 }
 
 func Wrap(sqlDB *sql.DB) Conn {
-	return &wrappedDB{DB: sqlDB}
+	return WrapWithEncryption(sqlDB, encryption.NewNoEncryption())
 }
 
 func WrapWithError(sqlDB *sql.DB, err error) (Conn, error) {
-	return &wrappedDB{DB: sqlDB}, err
+	return Wrap(sqlDB), err
+}
+
+// WrapWithEncryption returns a Conn whose sensitive columns (pipeline
+// configs, team auth settings and build engine metadata) are encrypted with
+// the given strategy.
+func WrapWithEncryption(sqlDB *sql.DB, strategy encryption.Strategy) Conn {
+	return &wrappedDB{
+		DB:       sqlDB,
+		strategy: strategy,
+	}
 }
 
 type wrappedDB struct {
 	*sql.DB
+
+	strategy encryption.Strategy
 }
 
 func (wrapped *wrappedDB) Begin() (Tx, error) {
 	return wrapped.DB.Begin()
 }
 
+func (wrapped *wrappedDB) EncryptionStrategy() encryption.Strategy {
+	return wrapped.strategy
+}
+
 func swallowUniqueViolation(err error) error {
 	if err != nil {
 		if pgErr, ok := err.(*pq.Error); ok {
@@ -77,16 +96,31 @@ func HashResourceConfig(checkType string, source atc.Source) string {
 type DB interface {
 	SaveTeam(team Team) (SavedTeam, error)
 	GetTeamByName(teamName string) (SavedTeam, bool, error)
+	GetTeams() ([]SavedTeam, error)
+	RenameTeam(currentName string, newName string) (SavedTeam, bool, error)
 	UpdateTeamBasicAuth(team Team) (SavedTeam, error)
 	UpdateTeamGitHubAuth(team Team) (SavedTeam, error)
+	UpdateTeamGitLabAuth(team Team) (SavedTeam, error)
+	UpdateTeamOIDCAuth(team Team) (SavedTeam, error)
+	UpdateTeamRoles(team Team) (SavedTeam, error)
 	CreateDefaultTeamIfNotExists() error
 	DeleteTeamByName(teamName string) error
 
+	CreateAPIToken(token APIToken, tokenHash string) (SavedAPIToken, error)
+	GetAPITokens(teamName string) ([]SavedAPIToken, error)
+	DeleteAPIToken(teamName string, tokenName string) (bool, error)
+	FindAPITokenByHash(tokenHash string) (SavedAPIToken, bool, error)
+	UpdateAPITokenLastUsed(tokenID int) error
+
+	SaveAuditEvent(event AuditEvent) error
+	GetAuditEvents(teamID int, page Page) ([]SavedAuditEvent, Pagination, error)
+
 	GetBuild(buildID int) (Build, bool, error)
 
 	GetBuildVersionedResources(buildID int) (SavedVersionedResources, error)
 	GetBuildResources(buildID int) ([]BuildInput, []BuildOutput, error)
 	GetBuilds(Page) ([]Build, Pagination, error)
+	GetTeamBuilds(teamID int, page Page) ([]Build, Pagination, error)
 	GetAllStartedBuilds() ([]Build, error)
 
 	FindJobIDForBuild(buildID int) (int, bool, error)
@@ -94,9 +128,10 @@ type DB interface {
 	CreatePipe(pipeGUID string, url string) error
 	GetPipe(pipeGUID string) (Pipe, error)
 
-	CreateOneOffBuild() (Build, error)
+	CreateOneOffBuild(teamName string) (Build, error)
 	GetBuildPreparation(buildID int) (BuildPreparation, bool, error)
 	UpdateBuildPreparation(buildPreparation BuildPreparation) error
+	UpdateBuildPreparationWorkersAvailable(buildID int, status BuildPreparationStatus) error
 	ResetBuildPreparationsWithPipelinePaused(pipelineID int) error
 
 	LeaseBuildTracking(logger lager.Logger, buildID int, interval time.Duration) (Lease, bool, error)
@@ -119,9 +154,14 @@ type DB interface {
 	AbortBuild(buildID int) error
 	AbortNotifier(buildID int) (Notifier, error)
 
-	Workers() ([]SavedWorker, error) // auto-expires workers based on ttl
+	Workers() ([]SavedWorker, error) // stalls or removes workers based on ttl
 	GetWorker(workerName string) (SavedWorker, bool, error)
 	SaveWorker(WorkerInfo, time.Duration) (SavedWorker, error)
+	SaveTeamWorker(WorkerInfo, time.Duration) (SavedWorker, error)
+	LandWorker(workerName string) error
+	RetireWorker(workerName string) error
+	PruneWorker(workerName string) error
+	SaveWorkerHealth(workerName string, health WorkerHealth) error
 
 	FindContainersByDescriptors(Container) ([]SavedContainer, error)
 	GetContainer(string) (SavedContainer, bool, error)
@@ -162,10 +202,17 @@ type Notifier interface {
 
 type PipelinesDB interface {
 	GetAllPipelines() ([]SavedPipeline, error)
+	GetPipelinesByTeamName(teamName string) ([]SavedPipeline, error)
 	GetPipelineByID(pipelineID int) (SavedPipeline, error)
 	GetPipelineByTeamNameAndName(teamName string, pipelineName string) (SavedPipeline, error)
 
-	OrderPipelines([]string) error
+	GetPipelineInstance(teamName string, pipelineName string, instanceVars atc.InstanceVars) (SavedPipeline, error)
+	GetPipelineInstances(teamName string, pipelineName string) ([]SavedPipeline, error)
+	PausePipelineInstances(teamName string, pipelineName string) error
+	UnpausePipelineInstances(teamName string, pipelineName string) error
+	ArchivePipelineInstances(teamName string, pipelineName string) error
+
+	OrderPipelines(teamName string, pipelineNames []string) error
 }
 
 //go:generate counterfeiter . ConfigDB
@@ -173,9 +220,16 @@ type PipelinesDB interface {
 type ConfigDB interface {
 	GetConfig(teamName, pipelineName string) (atc.Config, atc.RawConfig, ConfigVersion, error)
 	SaveConfig(string, string, atc.Config, ConfigVersion, PipelinePausedState) (SavedPipeline, bool, error)
+	SaveTemplatedConfig(string, string, atc.Config, atc.PipelineTemplate, ConfigVersion, PipelinePausedState) (SavedPipeline, bool, error)
+	GetTemplate(teamName, pipelineName string) (atc.PipelineTemplate, bool, error)
+
+	GetPipelineInstances(teamName string, pipelineName string) ([]SavedPipeline, error)
+	GetInstanceConfig(teamName, pipelineName string, instanceVars atc.InstanceVars) (atc.Config, atc.RawConfig, ConfigVersion, error)
+	SaveInstanceConfig(string, string, atc.InstanceVars, atc.Config, *atc.PipelineTemplate, ConfigVersion, PipelinePausedState) (SavedPipeline, bool, error)
+	GetInstanceTemplate(teamName, pipelineName string, instanceVars atc.InstanceVars) (atc.PipelineTemplate, bool, error)
 }
 
-//ConfigVersion is a sequence identifier used for compare-and-swap
+// ConfigVersion is a sequence identifier used for compare-and-swap
 type ConfigVersion int
 
 var ErrConfigComparisonFailed = errors.New("comparison with existing config failed during save")
@@ -222,9 +276,49 @@ type JobHistory struct {
 type SavedWorker struct {
 	WorkerInfo
 
+	State     WorkerState
 	ExpiresIn time.Duration
+	Health    WorkerHealth
+
+	// Containers, MemoryCommitted, and CPUCommitted are tallied from the
+	// containers recorded for the worker, rather than reported by it.
+	Containers      int
+	MemoryCommitted uint64
+	CPUCommitted    uint64
 }
 
+// WorkerHealth is the result of probing a worker's Garden and Baggageclaim
+// servers. A zero CheckedAt means the worker has not been probed yet.
+type WorkerHealth struct {
+	CheckedAt time.Time
+
+	GardenLatency       time.Duration
+	BaggageclaimLatency time.Duration
+
+	GardenError       string
+	BaggageclaimError string
+
+	DiskInBytes uint64
+
+	// ConsecutiveFailures counts the unhealthy probes since the worker was
+	// last found healthy. It is maintained by SaveWorkerHealth.
+	ConsecutiveFailures int
+}
+
+func (health WorkerHealth) Healthy() bool {
+	return health.GardenError == "" && health.BaggageclaimError == ""
+}
+
+type WorkerState string
+
+const (
+	WorkerStateRunning  WorkerState = "running"
+	WorkerStateStalled  WorkerState = "stalled"
+	WorkerStateLanding  WorkerState = "landing"
+	WorkerStateLanded   WorkerState = "landed"
+	WorkerStateRetiring WorkerState = "retiring"
+)
+
 type WorkerInfo struct {
 	GardenAddr      string
 	BaggageclaimURL string
@@ -233,9 +327,17 @@ type WorkerInfo struct {
 	NoProxy         string
 
 	ActiveContainers int
+	MaxContainers    int
+	MemoryCapacity   uint64
+	CPUCapacity      uint64
 	ResourceTypes    []atc.WorkerResourceType
 	Platform         string
 	Tags             []string
 	Name             string
 	StartTime        int64
+
+	// TeamID is zero for workers shared by every team. TeamName is filled in
+	// when the worker is read back.
+	TeamID   int
+	TeamName string
 }
@@ -0,0 +1,163 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+var _ = Describe("SQL DB API Tokens", func() {
+	var dbConn db.Conn
+	var listener *pq.Listener
+
+	var database *db.SQLDB
+	var team db.SavedTeam
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		database = db.NewSQL(dbConn, bus)
+
+		var err error
+		team, err = database.SaveTeam(db.Team{Name: "avengers"})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("CreateAPIToken", func() {
+		It("saves the token along with its team", func() {
+			savedToken, err := database.CreateAPIToken(db.APIToken{
+				TeamID: team.ID,
+				Name:   "jarvis",
+				Role:   atc.RoleMember,
+			}, "some-hash")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(savedToken.Name).To(Equal("jarvis"))
+			Expect(savedToken.Role).To(Equal(atc.RoleMember))
+			Expect(savedToken.TeamID).To(Equal(team.ID))
+			Expect(savedToken.TeamName).To(Equal("avengers"))
+			Expect(savedToken.CreatedAt).To(BeTemporally("~", time.Now(), time.Minute))
+			Expect(savedToken.ExpiresAt).To(BeZero())
+			Expect(savedToken.LastUsedAt).To(BeZero())
+		})
+
+		It("does not allow two tokens with the same name in a team", func() {
+			_, err := database.CreateAPIToken(db.APIToken{TeamID: team.ID, Name: "jarvis", Role: atc.RoleOwner}, "some-hash")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = database.CreateAPIToken(db.APIToken{TeamID: team.ID, Name: "jarvis", Role: atc.RoleOwner}, "some-other-hash")
+			Expect(err).To(Equal(db.ErrAPITokenExists))
+		})
+	})
+
+	Describe("GetAPITokens", func() {
+		It("returns the team's tokens", func() {
+			otherTeam, err := database.SaveTeam(db.Team{Name: "defenders"})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = database.CreateAPIToken(db.APIToken{TeamID: team.ID, Name: "jarvis", Role: atc.RoleOwner}, "hash-1")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = database.CreateAPIToken(db.APIToken{TeamID: otherTeam.ID, Name: "karen", Role: atc.RoleOwner}, "hash-2")
+			Expect(err).NotTo(HaveOccurred())
+
+			tokens, err := database.GetAPITokens("avengers")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(HaveLen(1))
+			Expect(tokens[0].Name).To(Equal("jarvis"))
+		})
+	})
+
+	Describe("FindAPITokenByHash", func() {
+		It("finds unexpired tokens", func() {
+			_, err := database.CreateAPIToken(db.APIToken{
+				TeamID:    team.ID,
+				Name:      "jarvis",
+				Role:      atc.RoleViewer,
+				ExpiresAt: time.Now().Add(time.Hour),
+			}, "some-hash")
+			Expect(err).NotTo(HaveOccurred())
+
+			token, found, err := database.FindAPITokenByHash("some-hash")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(token.Name).To(Equal("jarvis"))
+			Expect(token.Role).To(Equal(atc.RoleViewer))
+		})
+
+		It("does not find expired tokens", func() {
+			_, err := database.CreateAPIToken(db.APIToken{
+				TeamID:    team.ID,
+				Name:      "jarvis",
+				Role:      atc.RoleOwner,
+				ExpiresAt: time.Now().Add(-time.Hour),
+			}, "some-hash")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, found, err := database.FindAPITokenByHash("some-hash")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not find unknown tokens", func() {
+			_, found, err := database.FindAPITokenByHash("bogus")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("UpdateAPITokenLastUsed", func() {
+		It("records when the token was last used", func() {
+			savedToken, err := database.CreateAPIToken(db.APIToken{TeamID: team.ID, Name: "jarvis", Role: atc.RoleOwner}, "some-hash")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = database.UpdateAPITokenLastUsed(savedToken.ID)
+			Expect(err).NotTo(HaveOccurred())
+
+			token, found, err := database.FindAPITokenByHash("some-hash")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(token.LastUsedAt).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+	})
+
+	Describe("DeleteAPIToken", func() {
+		It("revokes the token", func() {
+			_, err := database.CreateAPIToken(db.APIToken{TeamID: team.ID, Name: "jarvis", Role: atc.RoleOwner}, "some-hash")
+			Expect(err).NotTo(HaveOccurred())
+
+			deleted, err := database.DeleteAPIToken("avengers", "jarvis")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			_, found, err := database.FindAPITokenByHash("some-hash")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns false when the token does not exist", func() {
+			deleted, err := database.DeleteAPIToken("avengers", "jarvis")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,146 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/db"
+)
+
+var _ = Describe("SQL DB Audit Events", func() {
+	var dbConn db.Conn
+	var listener *pq.Listener
+
+	var database *db.SQLDB
+
+	var avengers, defenders db.SavedTeam
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		database = db.NewSQL(dbConn, bus)
+
+		var err error
+		avengers, err = database.SaveTeam(db.Team{Name: "avengers"})
+		Expect(err).NotTo(HaveOccurred())
+
+		defenders, err = database.SaveTeam(db.Team{Name: "defenders"})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("SaveAuditEvent", func() {
+		It("saves the event with a timestamp", func() {
+			err := database.SaveAuditEvent(db.AuditEvent{
+				TeamName:     "avengers",
+				UserName:     "github:thor",
+				Route:        "PauseJob",
+				PipelineName: "some-pipeline",
+				JobName:      "some-job",
+				Status:       204,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			events, _, err := database.GetAuditEvents(0, db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+
+			Expect(events[0].AuditEvent).To(Equal(db.AuditEvent{
+				TeamName:     "avengers",
+				TeamID:       avengers.ID,
+				UserName:     "github:thor",
+				Route:        "PauseJob",
+				PipelineName: "some-pipeline",
+				JobName:      "some-job",
+				Status:       204,
+			}))
+			Expect(events[0].CreatedAt).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+
+		It("keeps the event linked to its team when the team is renamed", func() {
+			err := database.SaveAuditEvent(db.AuditEvent{
+				TeamName: "avengers",
+				TeamID:   avengers.ID,
+				UserName: "github:thor",
+				Route:    "PauseJob",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, found, err := database.RenameTeam("avengers", "new-avengers")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			events, _, err := database.GetAuditEvents(avengers.ID, db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].TeamName).To(Equal("avengers"))
+		})
+	})
+
+	Describe("GetAuditEvents", func() {
+		BeforeEach(func() {
+			for _, teamName := range []string{"avengers", "defenders", "avengers", "avengers"} {
+				err := database.SaveAuditEvent(db.AuditEvent{
+					TeamName: teamName,
+					UserName: "basic:admin",
+					Route:    "SaveConfig",
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("returns the events of every team, newest first", func() {
+			events, pagination, err := database.GetAuditEvents(0, db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+
+			ids := []int{}
+			for _, event := range events {
+				ids = append(ids, event.ID)
+			}
+			Expect(ids).To(Equal([]int{4, 3, 2, 1}))
+
+			Expect(pagination.Previous).To(BeNil())
+			Expect(pagination.Next).To(BeNil())
+		})
+
+		It("filters by team", func() {
+			events, _, err := database.GetAuditEvents(defenders.ID, db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].ID).To(Equal(2))
+		})
+
+		It("paginates within the team", func() {
+			events, pagination, err := database.GetAuditEvents(avengers.ID, db.Page{Limit: 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].ID).To(Equal(4))
+			Expect(events[1].ID).To(Equal(3))
+			Expect(pagination.Previous).To(BeNil())
+			Expect(pagination.Next).To(Equal(&db.Page{Since: 3, Limit: 2}))
+
+			events, pagination, err = database.GetAuditEvents(avengers.ID, *pagination.Next)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].ID).To(Equal(1))
+			Expect(pagination.Previous).To(Equal(&db.Page{Until: 1, Limit: 2}))
+			Expect(pagination.Next).To(BeNil())
+		})
+	})
+})
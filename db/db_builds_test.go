@@ -231,7 +231,7 @@ var _ = Describe("Keeping track of builds", func() {
 		)
 
 		BeforeEach(func() {
-			oneOff, err = database.CreateOneOffBuild()
+			oneOff, err = database.CreateOneOffBuild(atc.DefaultTeamName)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -258,7 +258,7 @@ var _ = Describe("Keeping track of builds", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(jobBuild.Name).To(Equal("1"))
 
-			nextOneOff, err := database.CreateOneOffBuild()
+			nextOneOff, err := database.CreateOneOffBuild(atc.DefaultTeamName)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(nextOneOff.ID).NotTo(BeZero())
 			Expect(nextOneOff.ID).NotTo(Equal(oneOff.ID))
@@ -271,6 +271,21 @@ var _ = Describe("Keeping track of builds", func() {
 			Expect(allBuilds).To(Equal([]db.Build{nextOneOff, jobBuild, oneOff}))
 		})
 
+		It("records the team that each build belongs to", func() {
+			teamOneOff, err := database.CreateOneOffBuild(team.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(teamOneOff.TeamID).To(Equal(team.ID))
+
+			jobBuild, err := pipelineDB.CreateJobBuild("some-other-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jobBuild.TeamID).To(Equal(team.ID))
+
+			mainTeam, found, err := database.GetTeamByName(atc.DefaultTeamName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(oneOff.TeamID).To(Equal(mainTeam.ID))
+		})
+
 		It("also creates buildpreparation", func() {
 			buildPrep, found, err := database.GetBuildPreparation(oneOff.ID)
 			Expect(err).NotTo(HaveOccurred())
@@ -286,7 +301,7 @@ var _ = Describe("Keeping track of builds", func() {
 			err    error
 		)
 		BeforeEach(func() {
-			oneOff, err = database.CreateOneOffBuild()
+			oneOff, err = database.CreateOneOffBuild(atc.DefaultTeamName)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -309,6 +324,19 @@ var _ = Describe("Keeping track of builds", func() {
 
 			Expect(newBuildPrep).To(Equal(buildPrep))
 		})
+
+		It("can update whether workers are available for a build", func() {
+			err := database.UpdateBuildPreparationWorkersAvailable(oneOff.ID, db.BuildPreparationStatusBlocking)
+			Expect(err).NotTo(HaveOccurred())
+
+			buildPrep, found, err := database.GetBuildPreparation(oneOff.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			expectedBuildPrep := db.NewBuildPreparation(oneOff.ID)
+			expectedBuildPrep.WorkersAvailable = db.BuildPreparationStatusBlocking
+			Expect(buildPrep).To(Equal(expectedBuildPrep))
+		})
 	})
 
 	Describe("ResetBuildPreparationWithPipelinePaused", func() {
@@ -395,13 +423,13 @@ var _ = Describe("Keeping track of builds", func() {
 		BeforeEach(func() {
 			var err error
 
-			build1, err = database.CreateOneOffBuild()
+			build1, err = database.CreateOneOffBuild(atc.DefaultTeamName)
 			Expect(err).NotTo(HaveOccurred())
 
 			build2, err = pipelineDB.CreateJobBuild("some-job")
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err = database.CreateOneOffBuild()
+			_, err = database.CreateOneOffBuild(atc.DefaultTeamName)
 			Expect(err).NotTo(HaveOccurred())
 
 			started, err := database.StartBuild(build1.ID, build1.PipelineID, "some-engine", "so-meta")
@@ -448,7 +476,7 @@ var _ = Describe("Keeping track of builds", func() {
 			BeforeEach(func() {
 				for i := 0; i < 3; i++ {
 					var err error
-					allBuilds[i], err = database.CreateOneOffBuild()
+					allBuilds[i], err = database.CreateOneOffBuild(atc.DefaultTeamName)
 					Expect(err).NotTo(HaveOccurred())
 				}
 
@@ -499,6 +527,22 @@ var _ = Describe("Keeping track of builds", func() {
 				Expect(pagination.Previous).To(Equal(&db.Page{Until: allBuilds[2].ID, Limit: 2}))
 				Expect(pagination.Next).To(Equal(&db.Page{Since: allBuilds[1].ID, Limit: 2}))
 			})
+
+			It("can limit the builds to those of a team", func() {
+				builds, pagination, err := database.GetTeamBuilds(team.ID, db.Page{Limit: 1})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(builds).To(Equal([]db.Build{allBuilds[4]}))
+				Expect(pagination.Previous).To(BeNil())
+				Expect(pagination.Next).To(Equal(&db.Page{Since: allBuilds[4].ID, Limit: 1}))
+
+				builds, pagination, err = database.GetTeamBuilds(team.ID, *pagination.Next)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(builds).To(Equal([]db.Build{allBuilds[3]}))
+				Expect(pagination.Previous).To(Equal(&db.Page{Until: allBuilds[3].ID, Limit: 1}))
+				Expect(pagination.Next).To(BeNil())
+			})
 		})
 	})
 })
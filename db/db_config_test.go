@@ -168,6 +168,16 @@ var _ = Describe("Keeping track of pipeline configs", func() {
 			Expect(pipeline.TeamID).To(Equal(team.ID))
 		})
 
+		It("returns the team name", func() {
+			savedPipeline, _, err := database.SaveConfig(team.Name, pipelineName, config, 0, db.PipelineNoChange)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(savedPipeline.TeamName).To(Equal(team.Name))
+
+			pipeline, err := database.GetPipelineByTeamNameAndName(team.Name, pipelineName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipeline.TeamName).To(Equal(team.Name))
+		})
+
 		It("can be saved as paused", func() {
 			_, _, err := database.SaveConfig(team.Name, pipelineName, config, 0, db.PipelinePaused)
 			Expect(err).NotTo(HaveOccurred())
@@ -232,6 +242,232 @@ var _ = Describe("Keeping track of pipeline configs", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		Context("when a job has a matrix", func() {
+			BeforeEach(func() {
+				config.Jobs = append(config.Jobs, atc.JobConfig{
+					Name: "unit-((matrix.go))",
+					Matrix: atc.MatrixConfig{
+						{Name: "go", Values: []string{"1.6", "1.7"}},
+					},
+				})
+			})
+
+			It("creates the generated jobs in the database", func() {
+				_, _, err := database.SaveConfig(team.Name, pipelineName, config, 0, db.PipelineNoChange)
+				Expect(err).NotTo(HaveOccurred())
+
+				pipelineDB, err := pipelineDBFactory.BuildWithTeamNameAndName(team.Name, pipelineName)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pipelineDB.GetJob("unit-1.6")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pipelineDB.GetJob("unit-1.7")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the expanded config and the raw config separately", func() {
+				_, _, err := database.SaveConfig(team.Name, pipelineName, config, 0, db.PipelineNoChange)
+				Expect(err).NotTo(HaveOccurred())
+
+				expandedConfig, rawConfig, _, err := database.GetConfig(team.Name, pipelineName)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(expandedConfig.Jobs).To(HaveLen(len(config.Jobs) + 1))
+				Expect(expandedConfig.Jobs[len(config.Jobs)-1].Name).To(Equal("unit-1.6"))
+				Expect(expandedConfig.Jobs[len(config.Jobs)].Name).To(Equal("unit-1.7"))
+
+				var savedConfig atc.Config
+				err = json.Unmarshal([]byte(rawConfig), &savedConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedConfig).To(Equal(config))
+			})
+		})
+
+		Context("when the config is saved from a template", func() {
+			var template atc.PipelineTemplate
+
+			BeforeEach(func() {
+				template = atc.PipelineTemplate{
+					Template: "jobs: [{name: {{job}}}]",
+					Vars:     atc.TemplateVars{"job": "some-job"},
+				}
+			})
+
+			It("stores the template with the pipeline", func() {
+				_, _, err := database.SaveTemplatedConfig(team.Name, pipelineName, config, template, 0, db.PipelineNoChange)
+				Expect(err).NotTo(HaveOccurred())
+
+				savedTemplate, found, err := database.GetTemplate(team.Name, pipelineName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(savedTemplate).To(Equal(template))
+
+				savedConfig, _, _, err := database.GetConfig(team.Name, pipelineName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedConfig).To(Equal(config))
+			})
+
+			It("clears the template when a plain config is saved over it", func() {
+				_, _, err := database.SaveTemplatedConfig(team.Name, pipelineName, config, template, 0, db.PipelineNoChange)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, version, err := database.GetConfig(team.Name, pipelineName)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, err = database.SaveConfig(team.Name, pipelineName, config, version, db.PipelineNoChange)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, found, err := database.GetTemplate(team.Name, pipelineName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when saving instances of a pipeline", func() {
+			masterVars := atc.InstanceVars{"branch": "master"}
+			releaseVars := atc.InstanceVars{"branch": "release"}
+
+			BeforeEach(func() {
+				_, created, err := database.SaveConfig(team.Name, pipelineName, config, 0, db.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeTrue())
+
+				_, created, err = database.SaveInstanceConfig(team.Name, pipelineName, masterVars, config, nil, 0, db.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeTrue())
+
+				_, created, err = database.SaveInstanceConfig(team.Name, pipelineName, releaseVars, otherConfig, nil, 0, db.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeTrue())
+			})
+
+			It("identifies each instance by its vars", func() {
+				savedConfig, _, _, err := database.GetInstanceConfig(team.Name, pipelineName, releaseVars)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedConfig).To(Equal(otherConfig))
+
+				instance, err := database.GetPipelineInstance(team.Name, pipelineName, masterVars)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(instance.InstanceVars).To(Equal(masterVars))
+
+				pipeline, err := database.GetPipelineByTeamNameAndName(team.Name, pipelineName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pipeline.InstanceVars).To(BeNil())
+				Expect(pipeline.ID).NotTo(Equal(instance.ID))
+			})
+
+			It("updates only the given instance", func() {
+				_, _, version, err := database.GetInstanceConfig(team.Name, pipelineName, masterVars)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, created, err := database.SaveInstanceConfig(team.Name, pipelineName, masterVars, otherConfig, nil, version, db.PipelineNoChange)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeFalse())
+
+				savedConfig, _, _, err := database.GetConfig(team.Name, pipelineName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedConfig).To(Equal(config))
+			})
+
+			It("lists all instances of the pipeline together", func() {
+				instances, err := database.GetPipelineInstances(team.Name, pipelineName)
+				Expect(err).NotTo(HaveOccurred())
+
+				instanceVars := []atc.InstanceVars{}
+				for _, instance := range instances {
+					instanceVars = append(instanceVars, instance.InstanceVars)
+				}
+
+				Expect(instanceVars).To(ConsistOf(masterVars, releaseVars, atc.InstanceVars(nil)))
+
+				pipelines, err := database.GetPipelinesByTeamName(team.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pipelines).To(HaveLen(3))
+				for _, pipeline := range pipelines {
+					Expect(pipeline.Name).To(Equal(pipelineName))
+				}
+			})
+
+			It("pauses and unpauses all instances at once", func() {
+				err := database.PausePipelineInstances(team.Name, pipelineName)
+				Expect(err).NotTo(HaveOccurred())
+
+				instances, err := database.GetPipelineInstances(team.Name, pipelineName)
+				Expect(err).NotTo(HaveOccurred())
+				for _, instance := range instances {
+					Expect(instance.Paused).To(BeTrue())
+				}
+
+				err = database.UnpausePipelineInstances(team.Name, pipelineName)
+				Expect(err).NotTo(HaveOccurred())
+
+				instances, err = database.GetPipelineInstances(team.Name, pipelineName)
+				Expect(err).NotTo(HaveOccurred())
+				for _, instance := range instances {
+					Expect(instance.Paused).To(BeFalse())
+				}
+			})
+		})
+
+		Context("when archiving a pipeline", func() {
+			var pipelineDB db.PipelineDB
+
+			BeforeEach(func() {
+				_, _, err := database.SaveConfig(team.Name, pipelineName, config, 0, db.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+
+				pipelineDB, err = pipelineDBFactory.BuildWithTeamNameAndName(team.Name, pipelineName)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = pipelineDB.Archive()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("pauses the pipeline and keeps it around", func() {
+				pipeline, err := database.GetPipelineByTeamNameAndName(team.Name, pipelineName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pipeline.Archived).To(BeTrue())
+				Expect(pipeline.Paused).To(BeTrue())
+
+				savedConfig, _, _, err := database.GetConfig(team.Name, pipelineName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedConfig).To(Equal(config))
+			})
+
+			It("cannot be unpaused", func() {
+				err := pipelineDB.Unpause()
+				Expect(err).To(Equal(db.ErrPipelineArchived))
+
+				err = database.UnpausePipelineInstances(team.Name, pipelineName)
+				Expect(err).NotTo(HaveOccurred())
+
+				pipeline, err := database.GetPipelineByTeamNameAndName(team.Name, pipelineName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pipeline.Paused).To(BeTrue())
+			})
+
+			It("is not the default pipeline", func() {
+				_, found, err := pipelineDBFactory.BuildDefault()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("is unarchived by saving its config again", func() {
+				_, _, version, err := database.GetConfig(team.Name, pipelineName)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, created, err := database.SaveConfig(team.Name, pipelineName, otherConfig, version, db.PipelineNoChange)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeFalse())
+
+				pipeline, err := database.GetPipelineByTeamNameAndName(team.Name, pipelineName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pipeline.Archived).To(BeFalse())
+				Expect(pipeline.Paused).To(BeTrue())
+			})
+		})
+
 		It("creates all of the serial groups from the jobs in the database", func() {
 			_, _, err := database.SaveConfig(team.Name, pipelineName, config, 0, db.PipelineNoChange)
 			Expect(err).NotTo(HaveOccurred())
@@ -404,7 +640,7 @@ var _ = Describe("Keeping track of pipeline configs", func() {
 		_, _, err = database.SaveConfig(team.Name, "pipeline-5", config, 0, db.PipelineUnpaused)
 		Expect(err).NotTo(HaveOccurred())
 
-		err = database.OrderPipelines([]string{
+		err = database.OrderPipelines(team.Name, []string{
 			"pipeline-4",
 			"pipeline-3",
 			"pipeline-5",
@@ -422,8 +658,9 @@ var _ = Describe("Keeping track of pipeline configs", func() {
 
 		Expect(pipelines).To(Equal([]db.SavedPipeline{
 			{
-				ID:     5,
-				TeamID: team.ID,
+				ID:       5,
+				TeamID:   team.ID,
+				TeamName: team.Name,
 				Pipeline: db.Pipeline{
 					Name:    "pipeline-4",
 					Config:  config,
@@ -431,8 +668,9 @@ var _ = Describe("Keeping track of pipeline configs", func() {
 				},
 			},
 			{
-				ID:     4,
-				TeamID: team.ID,
+				ID:       4,
+				TeamID:   team.ID,
+				TeamName: team.Name,
 				Pipeline: db.Pipeline{
 					Name:    "pipeline-3",
 					Config:  config,
@@ -440,8 +678,9 @@ var _ = Describe("Keeping track of pipeline configs", func() {
 				},
 			},
 			{
-				ID:     6,
-				TeamID: team.ID,
+				ID:       6,
+				TeamID:   team.ID,
+				TeamName: team.Name,
 				Pipeline: db.Pipeline{
 					Name:    "pipeline-5",
 					Config:  config,
@@ -449,8 +688,9 @@ var _ = Describe("Keeping track of pipeline configs", func() {
 				},
 			},
 			{
-				ID:     2,
-				TeamID: team.ID,
+				ID:       2,
+				TeamID:   team.ID,
+				TeamName: team.Name,
 				Pipeline: db.Pipeline{
 					Name:    "pipeline-1",
 					Config:  config,
@@ -458,8 +698,9 @@ var _ = Describe("Keeping track of pipeline configs", func() {
 				},
 			},
 			{
-				ID:     3,
-				TeamID: team.ID,
+				ID:       3,
+				TeamID:   team.ID,
+				TeamName: team.Name,
 				Pipeline: db.Pipeline{
 					Name:    "pipeline-2",
 					Config:  config,
@@ -468,8 +709,9 @@ var _ = Describe("Keeping track of pipeline configs", func() {
 			},
 
 			{
-				ID:     1,
-				TeamID: team.ID,
+				ID:       1,
+				TeamID:   team.ID,
+				TeamName: team.Name,
 				Pipeline: db.Pipeline{
 					Name:    "some-pipeline",
 					Version: db.ConfigVersion(1),
@@ -477,8 +719,9 @@ var _ = Describe("Keeping track of pipeline configs", func() {
 			},
 
 			{
-				ID:     7,
-				TeamID: team.ID,
+				ID:       7,
+				TeamID:   team.ID,
+				TeamName: team.Name,
 				Pipeline: db.Pipeline{
 					Name:    "pipeline-6",
 					Config:  config,
@@ -501,7 +744,7 @@ var _ = Describe("Keeping track of pipeline configs", func() {
 		_, _, err = database.SaveConfig(team.Name, otherPipelineName, otherConfig, 0, db.PipelineUnpaused)
 		Expect(err).NotTo(HaveOccurred())
 
-		err = database.OrderPipelines([]string{
+		err = database.OrderPipelines(team.Name, []string{
 			"some-pipeline",
 			pipelineName,
 			otherPipelineName,
@@ -513,16 +756,18 @@ var _ = Describe("Keeping track of pipeline configs", func() {
 
 		Expect(pipelines).To(Equal([]db.SavedPipeline{
 			{
-				ID:     1,
-				TeamID: team.ID,
+				ID:       1,
+				TeamID:   team.ID,
+				TeamName: team.Name,
 				Pipeline: db.Pipeline{
 					Name:    "some-pipeline",
 					Version: db.ConfigVersion(1),
 				},
 			},
 			{
-				ID:     2,
-				TeamID: team.ID,
+				ID:       2,
+				TeamID:   team.ID,
+				TeamName: team.Name,
 				Pipeline: db.Pipeline{
 					Name:    pipelineName,
 					Config:  config,
@@ -530,8 +775,9 @@ var _ = Describe("Keeping track of pipeline configs", func() {
 				},
 			},
 			{
-				ID:     3,
-				TeamID: team.ID,
+				ID:       3,
+				TeamID:   team.ID,
+				TeamName: team.Name,
 				Pipeline: db.Pipeline{
 					Name:    otherPipelineName,
 					Config:  otherConfig,
@@ -730,5 +976,32 @@ var _ = Describe("Keeping track of pipeline configs", func() {
 			_, _, err = database.SaveConfig(team.Name, "steve", otherConfig, otherTeamPipelineVersion, db.PipelinePaused)
 			Expect(err).To(HaveOccurred())
 		})
+
+		It("only lists and orders the pipelines of the given team", func() {
+			_, _, err := database.SaveConfig(team.Name, "pipeline-1", config, 0, db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = database.SaveConfig(team.Name, "pipeline-2", config, 0, db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = database.SaveConfig(otherTeam.Name, "pipeline-1", otherConfig, 0, db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = database.OrderPipelines(otherTeam.Name, []string{"pipeline-2", "pipeline-1"})
+			Expect(err).NotTo(HaveOccurred())
+
+			teamPipelines, err := database.GetPipelinesByTeamName(team.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(teamPipelines).To(HaveLen(2))
+			Expect(teamPipelines[0].Name).To(Equal("pipeline-1"))
+			Expect(teamPipelines[0].TeamID).To(Equal(team.ID))
+			Expect(teamPipelines[1].Name).To(Equal("pipeline-2"))
+
+			otherTeamPipelines, err := database.GetPipelinesByTeamName(otherTeam.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(otherTeamPipelines).To(HaveLen(1))
+			Expect(otherTeamPipelines[0].Name).To(Equal("pipeline-1"))
+			Expect(otherTeamPipelines[0].TeamID).To(Equal(otherTeam.ID))
+		})
 	})
 })
@@ -110,7 +110,7 @@ var _ = Describe("Keeping track of containers", func() {
 	}
 
 	getOneOffBuildID := func() int {
-		savedBuild, err := database.CreateOneOffBuild()
+		savedBuild, err := database.CreateOneOffBuild(atc.DefaultTeamName)
 		Expect(err).NotTo(HaveOccurred())
 		return savedBuild.ID
 	}
@@ -122,7 +122,7 @@ var _ = Describe("Keeping track of containers", func() {
 		savedBuild1, err := pipelineDB.CreateJobBuild("some-other-job")
 		Expect(err).NotTo(HaveOccurred())
 
-		savedBuild2, err := database.CreateOneOffBuild()
+		savedBuild2, err := database.CreateOneOffBuild(atc.DefaultTeamName)
 		Expect(err).NotTo(HaveOccurred())
 
 		savedBuild3, err := pipelineDB.CreateJobBuild("some-random-job")
@@ -320,6 +320,7 @@ var _ = Describe("Keeping track of containers", func() {
 				EnvironmentVariables: []string{"VAR1=val1", "VAR2=val2"},
 				User:                 "test-user",
 				Attempts:             []int{1, 2, 4},
+				TeamID:               savedPipeline.TeamID,
 			},
 		}
 
@@ -396,6 +397,7 @@ var _ = Describe("Keeping track of containers", func() {
 		Expect(actualContainer.EnvironmentVariables).To(Equal(containerToCreate.EnvironmentVariables))
 		Expect(actualContainer.User).To(Equal(containerToCreate.User))
 		Expect(actualContainer.Attempts).To(Equal(containerToCreate.Attempts))
+		Expect(actualContainer.TeamID).To(Equal(savedPipeline.TeamID))
 
 		Expect(actualContainer.ResourceID).To(Equal(0))
 		Expect(actualContainer.ResourceName).To(Equal(""))
@@ -540,7 +542,7 @@ var _ = Describe("Keeping track of containers", func() {
 	})
 
 	It("differentiates between a single step's containers with different stages", func() {
-		someBuild, err := database.CreateOneOffBuild()
+		someBuild, err := database.CreateOneOffBuild(atc.DefaultTeamName)
 		Expect(err).ToNot(HaveOccurred())
 
 		checkStageAContainerID := db.ContainerIdentifier{
@@ -1124,6 +1126,41 @@ var _ = Describe("Keeping track of containers", func() {
 			}
 		}),
 
+		Entry("returns containers where the team matches", func() findContainersByDescriptorsExample {
+			return findContainersByDescriptorsExample{
+				containersToCreate: []db.Container{
+					{
+						ContainerIdentifier: db.ContainerIdentifier{
+							Stage:   db.ContainerStageRun,
+							PlanID:  "plan-id",
+							BuildID: 1234,
+						},
+						ContainerMetadata: db.ContainerMetadata{
+							Handle:     "a",
+							Type:       db.ContainerTypeTask,
+							WorkerName: "some-worker",
+							PipelineID: savedPipeline.ID,
+							TeamID:     savedPipeline.TeamID,
+						},
+					},
+					{
+						ContainerIdentifier: db.ContainerIdentifier{
+							Stage:   db.ContainerStageRun,
+							PlanID:  "plan-id",
+							BuildID: 1234,
+						},
+						ContainerMetadata: db.ContainerMetadata{
+							Handle:     "b",
+							Type:       db.ContainerTypeTask,
+							WorkerName: "some-worker",
+						},
+					},
+				},
+				descriptorsToFilterFor: db.Container{ContainerMetadata: db.ContainerMetadata{TeamID: savedPipeline.TeamID}},
+				expectedHandles:        []string{"a"},
+			}
+		}),
+
 		Entry("returns containers where the check type matches", func() findContainersByDescriptorsExample {
 			return findContainersByDescriptorsExample{
 				containersToCreate: []db.Container{
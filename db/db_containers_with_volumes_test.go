@@ -45,7 +45,7 @@ var _ = Describe("Keeping track of containers", func() {
 	Describe("CreateContainer", func() {
 		Context("when creating a container with volumes", func() {
 			It("sets ContainerTTL on each volume", func() {
-				someBuild, err := database.CreateOneOffBuild()
+				someBuild, err := database.CreateOneOffBuild(atc.DefaultTeamName)
 				Expect(err).ToNot(HaveOccurred())
 
 				volume1 := db.Volume{
@@ -150,7 +150,7 @@ var _ = Describe("Keeping track of containers", func() {
 	Describe("GetContainer", func() {
 		Context("when a container has expired", func() {
 			It("deletes the container and sets its volumes' container_id to null", func() {
-				someBuild, err := database.CreateOneOffBuild()
+				someBuild, err := database.CreateOneOffBuild(atc.DefaultTeamName)
 				Expect(err).ToNot(HaveOccurred())
 
 				container := db.Container{
@@ -0,0 +1,192 @@
+package db_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/encryption"
+)
+
+var _ = Describe("SQL DB Encryption", func() {
+	var dbConn db.Conn
+	var listener *pq.Listener
+
+	var database *db.SQLDB
+	var pipelineDBFactory db.PipelineDBFactory
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		key, err := encryption.NewAESGCMKey([]byte(strings.Repeat("k", encryption.KeySize)))
+		Expect(err).NotTo(HaveOccurred())
+
+		dbConn = db.WrapWithEncryption(postgresRunner.Open(), key)
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		database = db.NewSQL(dbConn, bus)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, database)
+
+		err = database.CreateDefaultTeamIfNotExists()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("pipeline configs", func() {
+		config := atc.Config{
+			Resources: atc.ResourceConfigs{
+				{
+					Name:   "some-resource",
+					Type:   "git",
+					Source: atc.Source{"private_key": "super-secret-key"},
+				},
+			},
+			Jobs: atc.JobConfigs{
+				{Name: "some-job"},
+			},
+		}
+
+		BeforeEach(func() {
+			_, _, err := database.SaveConfig(atc.DefaultTeamName, "some-pipeline", config, 0, db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("are encrypted at rest", func() {
+			var rawConfig string
+			var nonce *string
+			err := dbConn.QueryRow(`SELECT config, nonce FROM pipelines WHERE name = 'some-pipeline'`).Scan(&rawConfig, &nonce)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(rawConfig).NotTo(ContainSubstring("super-secret-key"))
+			Expect(nonce).NotTo(BeNil())
+		})
+
+		It("are decrypted when read", func() {
+			savedConfig, _, _, err := database.GetConfig(atc.DefaultTeamName, "some-pipeline")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(savedConfig).To(Equal(config))
+
+			pipelineDB, err := pipelineDBFactory.BuildWithTeamNameAndName(atc.DefaultTeamName, "some-pipeline")
+			Expect(err).NotTo(HaveOccurred())
+
+			pipelineConfig, _, found, err := pipelineDB.GetConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(pipelineConfig).To(Equal(config))
+		})
+	})
+
+	Describe("pipeline templates", func() {
+		template := atc.PipelineTemplate{
+			Template: "resources: [{name: some-resource, type: git, source: {private_key: {{key}}}}]",
+			Vars:     atc.TemplateVars{"key": "super-secret-key"},
+		}
+
+		BeforeEach(func() {
+			_, _, err := database.SaveTemplatedConfig(atc.DefaultTeamName, "some-pipeline", atc.Config{}, template, 0, db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("are encrypted at rest", func() {
+			var rawTemplate string
+			var nonce *string
+			err := dbConn.QueryRow(`SELECT template, template_nonce FROM pipelines WHERE name = 'some-pipeline'`).Scan(&rawTemplate, &nonce)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(rawTemplate).NotTo(ContainSubstring("super-secret-key"))
+			Expect(nonce).NotTo(BeNil())
+		})
+
+		It("are decrypted when read", func() {
+			savedTemplate, found, err := database.GetTemplate(atc.DefaultTeamName, "some-pipeline")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(savedTemplate).To(Equal(template))
+		})
+	})
+
+	Describe("team auth", func() {
+		BeforeEach(func() {
+			_, err := database.SaveTeam(db.Team{
+				Name: "avengers",
+				GitHubAuth: db.GitHubAuth{
+					ClientID:      "some-client-id",
+					ClientSecret:  "super-secret-client-secret",
+					Organizations: []string{"some-org"},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("is encrypted at rest", func() {
+			var rawGitHubAuth string
+			var nonce *string
+			err := dbConn.QueryRow(`SELECT github_auth, github_auth_nonce FROM teams WHERE name = 'avengers'`).Scan(&rawGitHubAuth, &nonce)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(rawGitHubAuth).NotTo(ContainSubstring("super-secret-client-secret"))
+			Expect(nonce).NotTo(BeNil())
+		})
+
+		It("is decrypted when read", func() {
+			team, found, err := database.GetTeamByName("avengers")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(team.GitHubAuth.ClientSecret).To(Equal("super-secret-client-secret"))
+		})
+	})
+
+	Describe("build engine metadata", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = database.CreateOneOffBuild(atc.DefaultTeamName)
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err := database.StartBuild(build.ID, 0, "some-engine", `{"source":"super-secret-source"}`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+		})
+
+		It("is encrypted at rest", func() {
+			var rawMetadata string
+			var nonce *string
+			err := dbConn.QueryRow(`SELECT engine_metadata, nonce FROM builds WHERE id = $1`, build.ID).Scan(&rawMetadata, &nonce)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(rawMetadata).NotTo(ContainSubstring("super-secret-source"))
+			Expect(nonce).NotTo(BeNil())
+		})
+
+		It("is decrypted when read", func() {
+			foundBuild, found, err := database.GetBuild(build.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(foundBuild.EngineMetadata).To(Equal(`{"source":"super-secret-source"}`))
+
+			err = database.SaveBuildEngineMetadata(build.ID, `{"source":"other-secret-source"}`)
+			Expect(err).NotTo(HaveOccurred())
+
+			foundBuild, found, err = database.GetBuild(build.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(foundBuild.EngineMetadata).To(Equal(`{"source":"other-secret-source"}`))
+		})
+	})
+})
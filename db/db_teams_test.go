@@ -19,7 +19,8 @@ var _ = Describe("SQL DB Teams", func() {
 	var dbConn db.Conn
 	var listener *pq.Listener
 
-	var database db.DB
+	var database *db.SQLDB
+	var pipelineDBFactory db.PipelineDBFactory
 
 	BeforeEach(func() {
 		postgresRunner.Truncate()
@@ -31,6 +32,7 @@ var _ = Describe("SQL DB Teams", func() {
 		bus := db.NewNotificationsBus(listener, dbConn)
 
 		database = db.NewSQL(dbConn, bus)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, database)
 
 		database.DeleteTeamByName(atc.DefaultTeamName)
 	})
@@ -120,6 +122,16 @@ var _ = Describe("SQL DB Teams", func() {
 				Expect(found).To(BeTrue())
 				Expect(actualTeam.Name).To(Equal("team-name"))
 			})
+
+			It("does not treat the name as a pattern", func() {
+				_, found, err := database.GetTeamByName("team_name")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				_, found, err = database.GetTeamByName("%")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
 		})
 	})
 
@@ -233,6 +245,159 @@ var _ = Describe("SQL DB Teams", func() {
 		})
 	})
 
+	Describe("UpdateTeamGitLabAuth", func() {
+		var gitLabAuthTeam db.Team
+		var expectedGitLabAuth db.GitLabAuth
+
+		BeforeEach(func() {
+			expectedGitLabAuth = db.GitLabAuth{
+				ClientID:     "fake id",
+				ClientSecret: "some secret",
+				Groups:       []string{"group1"},
+				Projects:     []string{"group1/project1"},
+				Users:        []string{"user1"},
+				URL:          "https://gitlab.example.com",
+			}
+
+			gitLabAuthTeam = db.Team{
+				Name:       "avengers",
+				GitLabAuth: expectedGitLabAuth,
+			}
+		})
+
+		Context("when the team exists", func() {
+			BeforeEach(func() {
+				_, err := database.SaveTeam(db.Team{Name: "avengers"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("saves gitlab auth team info to the existing team", func() {
+				savedTeam, err := database.UpdateTeamGitLabAuth(gitLabAuthTeam)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.GitLabAuth).To(Equal(expectedGitLabAuth))
+
+				team, found, err := database.GetTeamByName("avengers")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(team.GitLabAuth).To(Equal(expectedGitLabAuth))
+			})
+
+			It("nulls gitlab auth when has a blank clientSecret", func() {
+				gitLabAuthTeam.GitLabAuth.ClientSecret = ""
+				savedTeam, err := database.UpdateTeamGitLabAuth(gitLabAuthTeam)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.GitLabAuth).To(Equal(db.GitLabAuth{}))
+			})
+		})
+	})
+
+	Describe("UpdateTeamOIDCAuth", func() {
+		var oidcAuthTeam db.Team
+		var expectedOIDCAuth db.OIDCAuth
+
+		BeforeEach(func() {
+			expectedOIDCAuth = db.OIDCAuth{
+				DisplayName:  "Shield SSO",
+				Issuer:       "https://sso.example.com",
+				ClientID:     "fake id",
+				ClientSecret: "some secret",
+				Scopes:       []string{"groups"},
+				GroupsClaim:  "roles",
+				Groups:       []string{"group1", "group2"},
+				Users:        []string{"user1@example.com"},
+			}
+
+			oidcAuthTeam = db.Team{
+				Name:     "avengers",
+				OIDCAuth: expectedOIDCAuth,
+			}
+		})
+
+		Context("when the team exists", func() {
+			BeforeEach(func() {
+				_, err := database.SaveTeam(db.Team{
+					Name: "avengers",
+					GitHubAuth: db.GitHubAuth{
+						ClientID:     "github id",
+						ClientSecret: "github secret",
+						Users:        []string{"user1"},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("saves oidc auth team info without over writing the github auth", func() {
+				savedTeam, err := database.UpdateTeamOIDCAuth(oidcAuthTeam)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(savedTeam.OIDCAuth).To(Equal(expectedOIDCAuth))
+				Expect(savedTeam.GitHubAuth.ClientID).To(Equal("github id"))
+
+				team, found, err := database.GetTeamByName("avengers")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(team.OIDCAuth).To(Equal(expectedOIDCAuth))
+			})
+
+			It("nulls oidc auth when has a blank issuer", func() {
+				oidcAuthTeam.OIDCAuth.Issuer = ""
+				savedTeam, err := database.UpdateTeamOIDCAuth(oidcAuthTeam)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(savedTeam.OIDCAuth).To(Equal(db.OIDCAuth{}))
+			})
+
+			It("nulls oidc auth when has a blank clientSecret", func() {
+				oidcAuthTeam.OIDCAuth.ClientSecret = ""
+				savedTeam, err := database.UpdateTeamOIDCAuth(oidcAuthTeam)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(savedTeam.OIDCAuth).To(Equal(db.OIDCAuth{}))
+			})
+		})
+	})
+
+	Describe("UpdateTeamRoles", func() {
+		BeforeEach(func() {
+			_, err := database.SaveTeam(db.Team{
+				Name: "avengers",
+				Roles: map[string]string{
+					"github:thor": "viewer",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("replaces the roles of the team", func() {
+			savedTeam, err := database.UpdateTeamRoles(db.Team{
+				Name: "avengers",
+				Roles: map[string]string{
+					"github:hulk": "member",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(savedTeam.Roles).To(Equal(map[string]string{"github:hulk": "member"}))
+
+			team, found, err := database.GetTeamByName("avengers")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(team.Roles).To(Equal(map[string]string{"github:hulk": "member"}))
+		})
+
+		It("stores usernames containing quotes verbatim", func() {
+			roles := map[string]string{
+				"github:o'brien": "owner",
+			}
+
+			savedTeam, err := database.UpdateTeamRoles(db.Team{
+				Name:  "avengers",
+				Roles: roles,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(savedTeam.Roles).To(Equal(roles))
+		})
+	})
+
 	Describe("UpdateTeamBasicAuth", func() {
 		var basicAuthTeam, gitHubAuthTeam db.Team
 		BeforeEach(func() {
@@ -401,6 +566,86 @@ var _ = Describe("SQL DB Teams", func() {
 		})
 	})
 
+	Describe("GetTeams", func() {
+		It("returns no teams when there are none", func() {
+			teams, err := database.GetTeams()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(teams).To(BeEmpty())
+		})
+
+		Context("when there are teams", func() {
+			BeforeEach(func() {
+				_, err := database.SaveTeam(db.Team{Name: "some-team"})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = database.SaveTeam(db.Team{
+					Name: "some-other-team",
+					BasicAuth: db.BasicAuth{
+						BasicAuthUsername: "some-username",
+						BasicAuthPassword: "some-password",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns all of the teams in the order they were created", func() {
+				teams, err := database.GetTeams()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(teams).To(HaveLen(2))
+
+				Expect(teams[0].Name).To(Equal("some-team"))
+				Expect(teams[1].Name).To(Equal("some-other-team"))
+				Expect(teams[1].BasicAuthUsername).To(Equal("some-username"))
+			})
+		})
+	})
+
+	Describe("RenameTeam", func() {
+		It("returns false with no error when the team does not exist", func() {
+			_, found, err := database.RenameTeam("bogus-team", "new-team-name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when the team exists", func() {
+			var savedTeam db.SavedTeam
+
+			BeforeEach(func() {
+				var err error
+				savedTeam, err = database.SaveTeam(db.Team{Name: "team-name"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not rename a team whose name only matches case-insensitively", func() {
+				_, found, err := database.RenameTeam("TEAM-name", "new-team-name")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("does not treat the name as a pattern", func() {
+				_, found, err := database.RenameTeam("team%", "new-team-name")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("renames the team", func() {
+				renamedTeam, found, err := database.RenameTeam("team-name", "new-team-name")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(renamedTeam.ID).To(Equal(savedTeam.ID))
+				Expect(renamedTeam.Name).To(Equal("new-team-name"))
+
+				_, found, err = database.GetTeamByName("team-name")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				_, found, err = database.GetTeamByName("new-team-name")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+	})
+
 	Describe("DeleteTeamByName", func() {
 		Context("when the team exists", func() {
 			BeforeEach(func() {
@@ -421,7 +666,7 @@ var _ = Describe("SQL DB Teams", func() {
 				Expect(count.Int64).To(Equal(int64(0)))
 			})
 
-			It("deletes the team when the name matches case-insensitively", func() {
+			It("does not delete the team when the name only matches case-insensitively", func() {
 				err := database.DeleteTeamByName("TEAM-name")
 				Expect(err).NotTo(HaveOccurred())
 
@@ -429,7 +674,105 @@ var _ = Describe("SQL DB Teams", func() {
 				dbConn.QueryRow(`select count(1) from teams where name = 'team-name'`).Scan(&count)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(count.Valid).To(BeTrue())
-				Expect(count.Int64).To(Equal(int64(0)))
+				Expect(count.Int64).To(Equal(int64(1)))
+			})
+
+			It("does not treat the name as a pattern", func() {
+				err := database.DeleteTeamByName("%")
+				Expect(err).NotTo(HaveOccurred())
+
+				var count sql.NullInt64
+				dbConn.QueryRow(`select count(1) from teams where name = 'team-name'`).Scan(&count)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count.Valid).To(BeTrue())
+				Expect(count.Int64).To(Equal(int64(1)))
+			})
+
+			Context("when the team has pipelines", func() {
+				var otherTeamPipeline db.SavedPipeline
+
+				BeforeEach(func() {
+					config := atc.Config{
+						Jobs: atc.JobConfigs{
+							{Name: "some-job"},
+						},
+					}
+
+					pipeline, _, err := database.SaveConfig("team-name", "some-pipeline", config, 0, db.PipelineUnpaused)
+					Expect(err).NotTo(HaveOccurred())
+
+					pipelineDB := pipelineDBFactory.Build(pipeline)
+					_, err = pipelineDB.CreateJobBuild("some-job")
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = database.SaveTeam(db.Team{Name: "other-team-name"})
+					Expect(err).NotTo(HaveOccurred())
+
+					otherTeamPipeline, _, err = database.SaveConfig("other-team-name", "some-pipeline", config, 0, db.PipelineUnpaused)
+					Expect(err).NotTo(HaveOccurred())
+
+					otherPipelineDB := pipelineDBFactory.Build(otherTeamPipeline)
+					_, err = otherPipelineDB.CreateJobBuild("some-job")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("deletes the team's one-off builds and their containers", func() {
+					oneOff, err := database.CreateOneOffBuild("team-name")
+					Expect(err).NotTo(HaveOccurred())
+
+					otherOneOff, err := database.CreateOneOffBuild("other-team-name")
+					Expect(err).NotTo(HaveOccurred())
+
+					for _, build := range []db.Build{oneOff, otherOneOff} {
+						_, err = database.CreateContainer(db.Container{
+							ContainerIdentifier: db.ContainerIdentifier{
+								BuildID: build.ID,
+								PlanID:  "some-plan",
+								Stage:   db.ContainerStageRun,
+							},
+							ContainerMetadata: db.ContainerMetadata{
+								Handle:     fmt.Sprintf("some-handle-%d", build.ID),
+								WorkerName: "some-worker",
+								Type:       db.ContainerTypeTask,
+							},
+						}, 5*time.Minute, 0, []string{})
+						Expect(err).NotTo(HaveOccurred())
+					}
+
+					err = database.DeleteTeamByName("team-name")
+					Expect(err).NotTo(HaveOccurred())
+
+					_, found, err := database.GetBuild(oneOff.ID)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+
+					_, found, err = database.GetBuild(otherOneOff.ID)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					_, found, err = database.GetContainer(fmt.Sprintf("some-handle-%d", oneOff.ID))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+
+					_, found, err = database.GetContainer(fmt.Sprintf("some-handle-%d", otherOneOff.ID))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+				})
+
+				It("deletes the team's pipelines and their builds", func() {
+					err := database.DeleteTeamByName("team-name")
+					Expect(err).NotTo(HaveOccurred())
+
+					pipelines, err := database.GetAllPipelines()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(pipelines).To(HaveLen(1))
+					Expect(pipelines[0].ID).To(Equal(otherTeamPipeline.ID))
+
+					var count sql.NullInt64
+					err = dbConn.QueryRow(`select count(1) from builds`).Scan(&count)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(count.Int64).To(Equal(int64(1)))
+				})
 			})
 		})
 	})
@@ -55,7 +55,7 @@ var _ = Describe("SQL DB", func() {
 	})
 
 	It("saves and propagates events correctly", func() {
-		build, err := database.CreateOneOffBuild()
+		build, err := database.CreateOneOffBuild(atc.DefaultTeamName)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(build.Name).To(Equal("1"))
 
@@ -133,7 +133,7 @@ var _ = Describe("SQL DB", func() {
 	})
 
 	It("saves and emits status events", func() {
-		build, err := database.CreateOneOffBuild()
+		build, err := database.CreateOneOffBuild(atc.DefaultTeamName)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(build.Name).To(Equal("1"))
 
@@ -177,7 +177,7 @@ var _ = Describe("SQL DB", func() {
 
 	Describe("DeleteBuildEventsByBuildIDs", func() {
 		It("deletes all build logs corresponding to the given build ids", func() {
-			build1, err := database.CreateOneOffBuild()
+			build1, err := database.CreateOneOffBuild(atc.DefaultTeamName)
 			Expect(err).NotTo(HaveOccurred())
 
 			err = database.SaveBuildEvent(build1.ID, 0, event.Log{
@@ -185,7 +185,7 @@ var _ = Describe("SQL DB", func() {
 			})
 			Expect(err).NotTo(HaveOccurred())
 
-			build2, err := database.CreateOneOffBuild()
+			build2, err := database.CreateOneOffBuild(atc.DefaultTeamName)
 			Expect(err).NotTo(HaveOccurred())
 
 			err = database.SaveBuildEvent(build2.ID, 0, event.Log{
@@ -193,7 +193,7 @@ var _ = Describe("SQL DB", func() {
 			})
 			Expect(err).NotTo(HaveOccurred())
 
-			build3, err := database.CreateOneOffBuild()
+			build3, err := database.CreateOneOffBuild(atc.DefaultTeamName)
 			Expect(err).NotTo(HaveOccurred())
 
 			err = database.FinishBuild(build3.ID, 0, db.StatusSucceeded)
@@ -205,7 +205,7 @@ var _ = Describe("SQL DB", func() {
 			err = database.FinishBuild(build2.ID, 0, db.StatusSucceeded)
 			Expect(err).NotTo(HaveOccurred())
 
-			build4, err := database.CreateOneOffBuild()
+			build4, err := database.CreateOneOffBuild(atc.DefaultTeamName)
 			Expect(err).NotTo(HaveOccurred())
 
 			By("doing nothing if the list is empty")
@@ -470,6 +470,57 @@ var _ = Describe("Keeping track of volumes", func() {
 			})
 		})
 
+		Describe("task cache volumes", func() {
+			var cacheIdentifier db.VolumeIdentifier
+
+			BeforeEach(func() {
+				cacheIdentifier = db.VolumeIdentifier{
+					TaskCache: &db.TaskCacheIdentifier{
+						PipelineID: pipelineDB.GetPipelineID(),
+						JobName:    "some-job",
+						StepName:   "some-step",
+						Path:       "some/cache",
+					},
+				}
+
+				err := database.InsertVolume(db.Volume{
+					WorkerName: insertedWorker.Name,
+					TTL:        0,
+					Handle:     "my-cache-handle",
+					Identifier: cacheIdentifier,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("can be retrieved", func() {
+				savedCacheVolumes, err := database.GetVolumesByIdentifier(cacheIdentifier)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedCacheVolumes).To(HaveLen(1))
+				Expect(savedCacheVolumes[0].Handle).To(Equal("my-cache-handle"))
+				Expect(savedCacheVolumes[0].Volume.Identifier).To(Equal(cacheIdentifier))
+			})
+
+			It("can be retrieved by job", func() {
+				savedCacheVolumes, err := pipelineDB.GetTaskCacheVolumes("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedCacheVolumes).To(HaveLen(1))
+				Expect(savedCacheVolumes[0].Handle).To(Equal("my-cache-handle"))
+
+				savedCacheVolumes, err = pipelineDB.GetTaskCacheVolumes("some-other-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedCacheVolumes).To(BeEmpty())
+			})
+
+			It("can be cleared by job", func() {
+				err := pipelineDB.ClearTaskCaches("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				savedCacheVolumes, err := database.GetVolumesByIdentifier(cacheIdentifier)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedCacheVolumes).To(BeEmpty())
+			})
+		})
+
 		Describe("import volumes", func() {
 			var importVolume db.Volume
 			var importIdentifier db.VolumeIdentifier
@@ -537,9 +588,9 @@ var _ = Describe("Keeping track of volumes", func() {
 
 	Describe("GetVolumesForOneOffBuildImageResources", func() {
 		It("returns all volumes containing image resource versions which were used in one-off builds", func() {
-			oneOffBuildA, err := database.CreateOneOffBuild()
+			oneOffBuildA, err := database.CreateOneOffBuild(atc.DefaultTeamName)
 			Expect(err).NotTo(HaveOccurred())
-			oneOffBuildB, err := database.CreateOneOffBuild()
+			oneOffBuildB, err := database.CreateOneOffBuild(atc.DefaultTeamName)
 			Expect(err).NotTo(HaveOccurred())
 			jobBuild, err := pipelineDB.CreateJobBuild("some-job")
 			Expect(err).NotTo(HaveOccurred())
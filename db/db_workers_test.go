@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/lib/pq"
@@ -48,6 +49,9 @@ var _ = Describe("Keeping track of workers", func() {
 			HTTPSProxyURL:    "https://example.com",
 			NoProxy:          "example.com,127.0.0.1,localhost",
 			ActiveContainers: 42,
+			MaxContainers:    100,
+			MemoryCapacity:   8589934592,
+			CPUCapacity:      1024,
 			ResourceTypes: []atc.WorkerResourceType{
 				{Type: "some-resource-a", Image: "some-image-a"},
 			},
@@ -69,6 +73,7 @@ var _ = Describe("Keeping track of workers", func() {
 		}
 		expectedSavedWorkerA := db.SavedWorker{
 			WorkerInfo: infoA,
+			State:      db.WorkerStateRunning,
 			ExpiresIn:  0,
 		}
 
@@ -103,7 +108,7 @@ var _ = Describe("Keeping track of workers", func() {
 
 		Expect(database.Workers()).To(ConsistOf(expectedSavedWorkerA))
 
-		By("expiring TTLs")
+		By("stalling workers whose TTLs expire")
 		ttl := 1 * time.Second
 
 		_, err = database.SaveWorker(infoB, ttl)
@@ -113,28 +118,48 @@ var _ = Describe("Keeping track of workers", func() {
 			return getWorkerInfos(database.Workers())
 		}
 
-		Consistently(workerInfos, ttl/2).Should(ConsistOf(infoA, infoB))
-		Eventually(workerInfos, 2*ttl).Should(ConsistOf(infoA))
+		workerStates := func() map[string]db.WorkerState {
+			return getWorkerStates(database.Workers())
+		}
+
+		Consistently(workerStates, ttl/2).Should(Equal(map[string]db.WorkerState{
+			infoA.Name: db.WorkerStateRunning,
+			infoB.Name: db.WorkerStateRunning,
+		}))
+		Eventually(workerStates, 2*ttl).Should(Equal(map[string]db.WorkerState{
+			infoA.Name: db.WorkerStateRunning,
+			infoB.Name: db.WorkerStateStalled,
+		}))
+		Expect(workerInfos()).To(ConsistOf(infoA, infoB))
+
+		By("running stalled workers again when they heartbeat")
+		_, err = database.SaveWorker(infoB, 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(workerStates()).To(Equal(map[string]db.WorkerState{
+			infoA.Name: db.WorkerStateRunning,
+			infoB.Name: db.WorkerStateRunning,
+		}))
 
 		By("overwriting TTLs")
 		_, err = database.SaveWorker(infoA, ttl)
 		Expect(err).NotTo(HaveOccurred())
 
-		Consistently(workerInfos, ttl/2).Should(ConsistOf(infoA))
-		Eventually(workerInfos, 2*ttl).Should(BeEmpty())
+		Consistently(workerStates, ttl/2).Should(HaveKeyWithValue(infoA.Name, db.WorkerStateRunning))
+		Eventually(workerStates, 2*ttl).Should(HaveKeyWithValue(infoA.Name, db.WorkerStateStalled))
 
 		By("updating attributes by name with ttls")
 		ttl = 1 * time.Hour
 		_, err = database.SaveWorker(infoA, ttl)
 		Expect(err).NotTo(HaveOccurred())
-		Expect(getWorkerInfos(database.Workers())).To(ConsistOf(infoA))
+		Expect(workerInfos()).To(ConsistOf(infoA, infoB))
 
 		infoA.GardenAddr = "1.2.3.4:1234"
 
 		_, err = database.SaveWorker(infoA, ttl)
 		Expect(err).NotTo(HaveOccurred())
 
-		Expect(getWorkerInfos(database.Workers())).To(ConsistOf(infoA))
+		Expect(workerInfos()).To(ConsistOf(infoA, infoB))
 	})
 
 	It("it can keep track of a worker", func() {
@@ -210,13 +235,458 @@ var _ = Describe("Keeping track of workers", func() {
 		savedWorkerA, err := database.SaveWorker(infoA, ttl)
 		Expect(err).NotTo(HaveOccurred())
 
+		workerState := func() db.WorkerState {
+			savedWorker, _, _ := database.GetWorker(savedWorkerA.Name)
+			return savedWorker.State
+		}
+
+		Consistently(workerState, ttl/2).Should(Equal(db.WorkerStateRunning))
+		Eventually(workerState, 2*ttl).Should(Equal(db.WorkerStateStalled))
+	})
+
+	Describe("worker states", func() {
+		var (
+			info db.WorkerInfo
+			ttl  time.Duration
+		)
+
+		BeforeEach(func() {
+			info = db.WorkerInfo{
+				Name:             "some-worker",
+				GardenAddr:       "1.2.3.4:7777",
+				ActiveContainers: 2,
+			}
+
+			ttl = time.Hour
+		})
+
+		JustBeforeEach(func() {
+			_, err := database.SaveWorker(info, ttl)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		workerState := func() db.WorkerState {
+			savedWorker, found, err := database.GetWorker(info.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			return savedWorker.State
+		}
+
 		workerFound := func() bool {
-			_, found, _ = database.GetWorker(savedWorkerA.Name)
+			_, found, err := database.GetWorker(info.Name)
+			Expect(err).NotTo(HaveOccurred())
 			return found
 		}
 
-		Consistently(workerFound, ttl/2).Should(BeTrue())
-		Eventually(workerFound, 2*ttl).Should(BeFalse())
+		expireWorker := func() {
+			_, err := dbConn.Exec(`UPDATE workers SET expires = NOW() - '1 second'::INTERVAL WHERE name = $1`, info.Name)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		It("starts out running", func() {
+			Expect(workerState()).To(Equal(db.WorkerStateRunning))
+		})
+
+		Describe("landing", func() {
+			JustBeforeEach(func() {
+				err := database.LandWorker(info.Name)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("marks the worker as landing", func() {
+				Expect(workerState()).To(Equal(db.WorkerStateLanding))
+			})
+
+			It("keeps it landing while it heartbeats", func() {
+				_, err := database.SaveWorker(info, ttl)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(workerState()).To(Equal(db.WorkerStateLanding))
+			})
+
+			It("marks it as landed once it stops heartbeating", func() {
+				expireWorker()
+				Expect(workerState()).To(Equal(db.WorkerStateLanded))
+			})
+
+			It("runs it again when it heartbeats after landing", func() {
+				expireWorker()
+				Expect(workerState()).To(Equal(db.WorkerStateLanded))
+
+				_, err := database.SaveWorker(info, ttl)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(workerState()).To(Equal(db.WorkerStateRunning))
+			})
+
+			It("is a no-op to land it again", func() {
+				err := database.LandWorker(info.Name)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(workerState()).To(Equal(db.WorkerStateLanding))
+			})
+		})
+
+		Describe("retiring", func() {
+			JustBeforeEach(func() {
+				err := database.RetireWorker(info.Name)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("marks the worker as retiring", func() {
+				Expect(workerState()).To(Equal(db.WorkerStateRetiring))
+			})
+
+			It("keeps it retiring while it heartbeats", func() {
+				_, err := database.SaveWorker(info, ttl)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(workerState()).To(Equal(db.WorkerStateRetiring))
+			})
+
+			It("removes it once it stops heartbeating", func() {
+				expireWorker()
+				Expect(workerFound()).To(BeFalse())
+			})
+		})
+
+		Describe("stalling", func() {
+			JustBeforeEach(func() {
+				expireWorker()
+				Expect(workerState()).To(Equal(db.WorkerStateStalled))
+			})
+
+			It("removes the worker when it is retired", func() {
+				err := database.RetireWorker(info.Name)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(workerFound()).To(BeFalse())
+			})
+
+			It("does not land the worker", func() {
+				err := database.LandWorker(info.Name)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(workerState()).To(Equal(db.WorkerStateStalled))
+			})
+		})
+
+		Describe("pruning", func() {
+			Context("when the worker is stalled", func() {
+				JustBeforeEach(func() {
+					version := "some-version"
+					err := database.InsertVolume(db.Volume{
+						WorkerName: info.Name,
+						TTL:        time.Hour,
+						Handle:     "some-volume",
+						Identifier: db.VolumeIdentifier{
+							Import: &db.ImportIdentifier{
+								WorkerName: info.Name,
+								Path:       "/some/path",
+								Version:    &version,
+							},
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					expireWorker()
+					Expect(workerState()).To(Equal(db.WorkerStateStalled))
+				})
+
+				It("keeps its volumes until it is pruned", func() {
+					volumes, err := database.GetVolumes()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(volumes).To(HaveLen(1))
+
+					err = database.PruneWorker(info.Name)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(workerFound()).To(BeFalse())
+
+					volumes, err = database.GetVolumes()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(volumes).To(BeEmpty())
+				})
+			})
+
+			Context("when the worker is running", func() {
+				It("returns ErrCannotPruneRunningWorker", func() {
+					err := database.PruneWorker(info.Name)
+					Expect(err).To(Equal(db.ErrCannotPruneRunningWorker))
+
+					Expect(workerFound()).To(BeTrue())
+				})
+			})
+
+			Context("when the worker is landing", func() {
+				JustBeforeEach(func() {
+					err := database.LandWorker(info.Name)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns ErrCannotPruneRunningWorker", func() {
+					err := database.PruneWorker(info.Name)
+					Expect(err).To(Equal(db.ErrCannotPruneRunningWorker))
+				})
+			})
+		})
+
+		Context("when the worker does not exist", func() {
+			It("returns ErrWorkerNotPresent", func() {
+				Expect(database.LandWorker("bogus-worker")).To(Equal(db.ErrWorkerNotPresent))
+				Expect(database.RetireWorker("bogus-worker")).To(Equal(db.ErrWorkerNotPresent))
+				Expect(database.PruneWorker("bogus-worker")).To(Equal(db.ErrWorkerNotPresent))
+			})
+		})
+	})
+
+	Describe("container tallies", func() {
+		BeforeEach(func() {
+			_, err := database.SaveWorker(db.WorkerInfo{
+				Name:             "some-worker",
+				GardenAddr:       "1.2.3.4:7777",
+				ActiveContainers: 1,
+			}, time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			build, err := database.CreateOneOffBuild(atc.DefaultTeamName)
+			Expect(err).NotTo(HaveOccurred())
+
+			for i, limits := range []struct{ memory, cpu uint64 }{{1024, 512}, {2048, 0}, {4096, 256}} {
+				_, err = database.CreateContainer(db.Container{
+					ContainerIdentifier: db.ContainerIdentifier{
+						BuildID: build.ID,
+						PlanID:  atc.PlanID(fmt.Sprintf("some-plan-%d", i)),
+						Stage:   db.ContainerStageRun,
+					},
+					ContainerMetadata: db.ContainerMetadata{
+						Handle:      fmt.Sprintf("some-handle-%d", i),
+						WorkerName:  "some-worker",
+						Type:        db.ContainerTypeTask,
+						MemoryLimit: limits.memory,
+						CPULimit:    limits.cpu,
+					},
+				}, 5*time.Minute, 0, []string{})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			_, err = dbConn.Exec(`UPDATE containers SET expires_at = NOW() - '1 second'::INTERVAL WHERE handle = 'some-handle-2'`)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("counts the worker's live containers and the resources committed to them", func() {
+			savedWorker, found, err := database.GetWorker("some-worker")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(savedWorker.ActiveContainers).To(Equal(1))
+			Expect(savedWorker.Containers).To(Equal(2))
+			Expect(savedWorker.MemoryCommitted).To(Equal(uint64(3072)))
+			Expect(savedWorker.CPUCommitted).To(Equal(uint64(512)))
+		})
+	})
+
+	Describe("worker health", func() {
+		BeforeEach(func() {
+			_, err := database.SaveWorker(db.WorkerInfo{
+				Name:       "some-worker",
+				GardenAddr: "1.2.3.4:7777",
+			}, 0)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("starts out unchecked and healthy", func() {
+			savedWorker, found, err := database.GetWorker("some-worker")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(savedWorker.Health.CheckedAt.IsZero()).To(BeTrue())
+			Expect(savedWorker.Health.Healthy()).To(BeTrue())
+		})
+
+		It("records the result of probing the worker", func() {
+			checkedAt := time.Unix(1461864115, 0)
+
+			err := database.SaveWorkerHealth("some-worker", db.WorkerHealth{
+				CheckedAt:           checkedAt,
+				GardenLatency:       15 * time.Millisecond,
+				BaggageclaimLatency: 20 * time.Millisecond,
+				BaggageclaimError:   "connection refused",
+				DiskInBytes:         1073741824,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			savedWorker, found, err := database.GetWorker("some-worker")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(savedWorker.Health.CheckedAt.Unix()).To(Equal(checkedAt.Unix()))
+			Expect(savedWorker.Health.GardenLatency).To(Equal(15 * time.Millisecond))
+			Expect(savedWorker.Health.BaggageclaimLatency).To(Equal(20 * time.Millisecond))
+			Expect(savedWorker.Health.GardenError).To(BeEmpty())
+			Expect(savedWorker.Health.BaggageclaimError).To(Equal("connection refused"))
+			Expect(savedWorker.Health.DiskInBytes).To(Equal(uint64(1073741824)))
+			Expect(savedWorker.Health.Healthy()).To(BeFalse())
+		})
+
+		It("counts the probes that have failed in a row", func() {
+			failedHealth := db.WorkerHealth{GardenError: "connection refused"}
+
+			for i := 0; i < 2; i++ {
+				err := database.SaveWorkerHealth("some-worker", failedHealth)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			savedWorker, found, err := database.GetWorker("some-worker")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(savedWorker.Health.ConsecutiveFailures).To(Equal(2))
+
+			err = database.SaveWorkerHealth("some-worker", db.WorkerHealth{})
+			Expect(err).NotTo(HaveOccurred())
+
+			savedWorker, found, err = database.GetWorker("some-worker")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(savedWorker.Health.ConsecutiveFailures).To(BeZero())
+		})
+
+		Context("when the worker does not exist", func() {
+			It("returns ErrWorkerNotPresent", func() {
+				err := database.SaveWorkerHealth("bogus-worker", db.WorkerHealth{})
+				Expect(err).To(Equal(db.ErrWorkerNotPresent))
+			})
+		})
+	})
+
+	Describe("team workers", func() {
+		var team db.SavedTeam
+
+		BeforeEach(func() {
+			var err error
+			team, err = database.SaveTeam(db.Team{Name: "some-team"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("saves the worker's team", func() {
+			savedWorker, err := database.SaveWorker(db.WorkerInfo{
+				Name:       "team-worker",
+				GardenAddr: "1.2.3.4:7777",
+				TeamID:     team.ID,
+			}, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(savedWorker.TeamID).To(Equal(team.ID))
+			Expect(savedWorker.TeamName).To(Equal("some-team"))
+
+			foundWorker, found, err := database.GetWorker("team-worker")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(foundWorker.TeamID).To(Equal(team.ID))
+			Expect(foundWorker.TeamName).To(Equal("some-team"))
+		})
+
+		It("shares workers without a team", func() {
+			savedWorker, err := database.SaveWorker(db.WorkerInfo{
+				Name:       "shared-worker",
+				GardenAddr: "1.2.3.4:7777",
+			}, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(savedWorker.TeamID).To(BeZero())
+			Expect(savedWorker.TeamName).To(BeEmpty())
+		})
+
+		It("removes the team's workers when the team is deleted", func() {
+			_, err := database.SaveWorker(db.WorkerInfo{
+				Name:       "team-worker",
+				GardenAddr: "1.2.3.4:7777",
+				TeamID:     team.ID,
+			}, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = database.DeleteTeamByName("some-team")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(database.Workers()).To(BeEmpty())
+		})
+
+		Describe("saving a worker registered by its team", func() {
+			It("saves new workers", func() {
+				savedWorker, err := database.SaveTeamWorker(db.WorkerInfo{
+					Name:       "team-worker",
+					GardenAddr: "1.2.3.4:7777",
+					TeamID:     team.ID,
+				}, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedWorker.TeamID).To(Equal(team.ID))
+			})
+
+			It("updates workers that already belong to the team", func() {
+				_, err := database.SaveWorker(db.WorkerInfo{
+					Name:       "team-worker",
+					GardenAddr: "1.2.3.4:7777",
+					TeamID:     team.ID,
+				}, 0)
+				Expect(err).NotTo(HaveOccurred())
+
+				savedWorker, err := database.SaveTeamWorker(db.WorkerInfo{
+					Name:       "team-worker",
+					GardenAddr: "5.6.7.8:7777",
+					TeamID:     team.ID,
+				}, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedWorker.GardenAddr).To(Equal("5.6.7.8:7777"))
+			})
+
+			It("does not take over shared workers by name or address", func() {
+				_, err := database.SaveWorker(db.WorkerInfo{
+					Name:       "shared-worker",
+					GardenAddr: "1.2.3.4:7777",
+				}, 0)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = database.SaveTeamWorker(db.WorkerInfo{
+					Name:       "shared-worker",
+					GardenAddr: "5.6.7.8:7777",
+					TeamID:     team.ID,
+				}, 0)
+				Expect(err).To(Equal(db.ErrWorkerNotOwnedByTeam))
+
+				_, err = database.SaveTeamWorker(db.WorkerInfo{
+					Name:       "team-worker",
+					GardenAddr: "1.2.3.4:7777",
+					TeamID:     team.ID,
+				}, 0)
+				Expect(err).To(Equal(db.ErrWorkerNotOwnedByTeam))
+
+				sharedWorker, found, err := database.GetWorker("shared-worker")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(sharedWorker.TeamID).To(BeZero())
+				Expect(sharedWorker.GardenAddr).To(Equal("1.2.3.4:7777"))
+			})
+
+			It("does not take over other teams' workers", func() {
+				otherTeam, err := database.SaveTeam(db.Team{Name: "other-team"})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = database.SaveWorker(db.WorkerInfo{
+					Name:       "other-team-worker",
+					GardenAddr: "1.2.3.4:7777",
+					TeamID:     otherTeam.ID,
+				}, 0)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = database.SaveTeamWorker(db.WorkerInfo{
+					Name:       "other-team-worker",
+					GardenAddr: "1.2.3.4:7777",
+					TeamID:     team.ID,
+				}, 0)
+				Expect(err).To(Equal(db.ErrWorkerNotOwnedByTeam))
+
+				otherTeamWorker, found, err := database.GetWorker("other-team-worker")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(otherTeamWorker.TeamID).To(Equal(otherTeam.ID))
+			})
+		})
 	})
 
 	Describe("FindWorkerCheckResourceTypeVersion", func() {
@@ -311,6 +781,15 @@ var _ = Describe("Keeping track of workers", func() {
 	})
 })
 
+func getWorkerStates(savedWorkers []db.SavedWorker, err error) map[string]db.WorkerState {
+	Expect(err).NotTo(HaveOccurred())
+	workerStates := map[string]db.WorkerState{}
+	for _, savedWorker := range savedWorkers {
+		workerStates[savedWorker.Name] = savedWorker.State
+	}
+	return workerStates
+}
+
 func getWorkerInfos(savedWorkers []db.SavedWorker, err error) []db.WorkerInfo {
 	Expect(err).NotTo(HaveOccurred())
 	var workerInfos []db.WorkerInfo
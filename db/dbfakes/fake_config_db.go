@@ -9,7 +9,7 @@ import (
 )
 
 type FakeConfigDB struct {
-	GetConfigStub        func(teamName, pipelineName string) (atc.Config, atc.RawConfig, db.ConfigVersion, error)
+	GetConfigStub        func(teamName string, pipelineName string) (atc.Config, atc.RawConfig, db.ConfigVersion, error)
 	getConfigMutex       sync.RWMutex
 	getConfigArgsForCall []struct {
 		teamName     string
@@ -35,6 +35,83 @@ type FakeConfigDB struct {
 		result2 bool
 		result3 error
 	}
+	SaveTemplatedConfigStub        func(string, string, atc.Config, atc.PipelineTemplate, db.ConfigVersion, db.PipelinePausedState) (db.SavedPipeline, bool, error)
+	saveTemplatedConfigMutex       sync.RWMutex
+	saveTemplatedConfigArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 atc.Config
+		arg4 atc.PipelineTemplate
+		arg5 db.ConfigVersion
+		arg6 db.PipelinePausedState
+	}
+	saveTemplatedConfigReturns struct {
+		result1 db.SavedPipeline
+		result2 bool
+		result3 error
+	}
+	GetTemplateStub        func(teamName string, pipelineName string) (atc.PipelineTemplate, bool, error)
+	getTemplateMutex       sync.RWMutex
+	getTemplateArgsForCall []struct {
+		teamName     string
+		pipelineName string
+	}
+	getTemplateReturns struct {
+		result1 atc.PipelineTemplate
+		result2 bool
+		result3 error
+	}
+	GetPipelineInstancesStub        func(teamName string, pipelineName string) ([]db.SavedPipeline, error)
+	getPipelineInstancesMutex       sync.RWMutex
+	getPipelineInstancesArgsForCall []struct {
+		teamName     string
+		pipelineName string
+	}
+	getPipelineInstancesReturns struct {
+		result1 []db.SavedPipeline
+		result2 error
+	}
+	GetInstanceConfigStub        func(teamName string, pipelineName string, instanceVars atc.InstanceVars) (atc.Config, atc.RawConfig, db.ConfigVersion, error)
+	getInstanceConfigMutex       sync.RWMutex
+	getInstanceConfigArgsForCall []struct {
+		teamName     string
+		pipelineName string
+		instanceVars atc.InstanceVars
+	}
+	getInstanceConfigReturns struct {
+		result1 atc.Config
+		result2 atc.RawConfig
+		result3 db.ConfigVersion
+		result4 error
+	}
+	SaveInstanceConfigStub        func(string, string, atc.InstanceVars, atc.Config, *atc.PipelineTemplate, db.ConfigVersion, db.PipelinePausedState) (db.SavedPipeline, bool, error)
+	saveInstanceConfigMutex       sync.RWMutex
+	saveInstanceConfigArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 atc.InstanceVars
+		arg4 atc.Config
+		arg5 *atc.PipelineTemplate
+		arg6 db.ConfigVersion
+		arg7 db.PipelinePausedState
+	}
+	saveInstanceConfigReturns struct {
+		result1 db.SavedPipeline
+		result2 bool
+		result3 error
+	}
+	GetInstanceTemplateStub        func(teamName string, pipelineName string, instanceVars atc.InstanceVars) (atc.PipelineTemplate, bool, error)
+	getInstanceTemplateMutex       sync.RWMutex
+	getInstanceTemplateArgsForCall []struct {
+		teamName     string
+		pipelineName string
+		instanceVars atc.InstanceVars
+	}
+	getInstanceTemplateReturns struct {
+		result1 atc.PipelineTemplate
+		result2 bool
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -115,6 +192,233 @@ func (fake *FakeConfigDB) SaveConfigReturns(result1 db.SavedPipeline, result2 bo
 	}{result1, result2, result3}
 }
 
+func (fake *FakeConfigDB) SaveTemplatedConfig(arg1 string, arg2 string, arg3 atc.Config, arg4 atc.PipelineTemplate, arg5 db.ConfigVersion, arg6 db.PipelinePausedState) (db.SavedPipeline, bool, error) {
+	fake.saveTemplatedConfigMutex.Lock()
+	fake.saveTemplatedConfigArgsForCall = append(fake.saveTemplatedConfigArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 atc.Config
+		arg4 atc.PipelineTemplate
+		arg5 db.ConfigVersion
+		arg6 db.PipelinePausedState
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("SaveTemplatedConfig", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.saveTemplatedConfigMutex.Unlock()
+	if fake.SaveTemplatedConfigStub != nil {
+		return fake.SaveTemplatedConfigStub(arg1, arg2, arg3, arg4, arg5, arg6)
+	} else {
+		return fake.saveTemplatedConfigReturns.result1, fake.saveTemplatedConfigReturns.result2, fake.saveTemplatedConfigReturns.result3
+	}
+}
+
+func (fake *FakeConfigDB) SaveTemplatedConfigCallCount() int {
+	fake.saveTemplatedConfigMutex.RLock()
+	defer fake.saveTemplatedConfigMutex.RUnlock()
+	return len(fake.saveTemplatedConfigArgsForCall)
+}
+
+func (fake *FakeConfigDB) SaveTemplatedConfigArgsForCall(i int) (string, string, atc.Config, atc.PipelineTemplate, db.ConfigVersion, db.PipelinePausedState) {
+	fake.saveTemplatedConfigMutex.RLock()
+	defer fake.saveTemplatedConfigMutex.RUnlock()
+	return fake.saveTemplatedConfigArgsForCall[i].arg1, fake.saveTemplatedConfigArgsForCall[i].arg2, fake.saveTemplatedConfigArgsForCall[i].arg3, fake.saveTemplatedConfigArgsForCall[i].arg4, fake.saveTemplatedConfigArgsForCall[i].arg5, fake.saveTemplatedConfigArgsForCall[i].arg6
+}
+
+func (fake *FakeConfigDB) SaveTemplatedConfigReturns(result1 db.SavedPipeline, result2 bool, result3 error) {
+	fake.SaveTemplatedConfigStub = nil
+	fake.saveTemplatedConfigReturns = struct {
+		result1 db.SavedPipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeConfigDB) GetTemplate(teamName string, pipelineName string) (atc.PipelineTemplate, bool, error) {
+	fake.getTemplateMutex.Lock()
+	fake.getTemplateArgsForCall = append(fake.getTemplateArgsForCall, struct {
+		teamName     string
+		pipelineName string
+	}{teamName, pipelineName})
+	fake.recordInvocation("GetTemplate", []interface{}{teamName, pipelineName})
+	fake.getTemplateMutex.Unlock()
+	if fake.GetTemplateStub != nil {
+		return fake.GetTemplateStub(teamName, pipelineName)
+	} else {
+		return fake.getTemplateReturns.result1, fake.getTemplateReturns.result2, fake.getTemplateReturns.result3
+	}
+}
+
+func (fake *FakeConfigDB) GetTemplateCallCount() int {
+	fake.getTemplateMutex.RLock()
+	defer fake.getTemplateMutex.RUnlock()
+	return len(fake.getTemplateArgsForCall)
+}
+
+func (fake *FakeConfigDB) GetTemplateArgsForCall(i int) (string, string) {
+	fake.getTemplateMutex.RLock()
+	defer fake.getTemplateMutex.RUnlock()
+	return fake.getTemplateArgsForCall[i].teamName, fake.getTemplateArgsForCall[i].pipelineName
+}
+
+func (fake *FakeConfigDB) GetTemplateReturns(result1 atc.PipelineTemplate, result2 bool, result3 error) {
+	fake.GetTemplateStub = nil
+	fake.getTemplateReturns = struct {
+		result1 atc.PipelineTemplate
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeConfigDB) GetPipelineInstances(teamName string, pipelineName string) ([]db.SavedPipeline, error) {
+	fake.getPipelineInstancesMutex.Lock()
+	fake.getPipelineInstancesArgsForCall = append(fake.getPipelineInstancesArgsForCall, struct {
+		teamName     string
+		pipelineName string
+	}{teamName, pipelineName})
+	fake.recordInvocation("GetPipelineInstances", []interface{}{teamName, pipelineName})
+	fake.getPipelineInstancesMutex.Unlock()
+	if fake.GetPipelineInstancesStub != nil {
+		return fake.GetPipelineInstancesStub(teamName, pipelineName)
+	} else {
+		return fake.getPipelineInstancesReturns.result1, fake.getPipelineInstancesReturns.result2
+	}
+}
+
+func (fake *FakeConfigDB) GetPipelineInstancesCallCount() int {
+	fake.getPipelineInstancesMutex.RLock()
+	defer fake.getPipelineInstancesMutex.RUnlock()
+	return len(fake.getPipelineInstancesArgsForCall)
+}
+
+func (fake *FakeConfigDB) GetPipelineInstancesArgsForCall(i int) (string, string) {
+	fake.getPipelineInstancesMutex.RLock()
+	defer fake.getPipelineInstancesMutex.RUnlock()
+	return fake.getPipelineInstancesArgsForCall[i].teamName, fake.getPipelineInstancesArgsForCall[i].pipelineName
+}
+
+func (fake *FakeConfigDB) GetPipelineInstancesReturns(result1 []db.SavedPipeline, result2 error) {
+	fake.GetPipelineInstancesStub = nil
+	fake.getPipelineInstancesReturns = struct {
+		result1 []db.SavedPipeline
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeConfigDB) GetInstanceConfig(teamName string, pipelineName string, instanceVars atc.InstanceVars) (atc.Config, atc.RawConfig, db.ConfigVersion, error) {
+	fake.getInstanceConfigMutex.Lock()
+	fake.getInstanceConfigArgsForCall = append(fake.getInstanceConfigArgsForCall, struct {
+		teamName     string
+		pipelineName string
+		instanceVars atc.InstanceVars
+	}{teamName, pipelineName, instanceVars})
+	fake.recordInvocation("GetInstanceConfig", []interface{}{teamName, pipelineName, instanceVars})
+	fake.getInstanceConfigMutex.Unlock()
+	if fake.GetInstanceConfigStub != nil {
+		return fake.GetInstanceConfigStub(teamName, pipelineName, instanceVars)
+	} else {
+		return fake.getInstanceConfigReturns.result1, fake.getInstanceConfigReturns.result2, fake.getInstanceConfigReturns.result3, fake.getInstanceConfigReturns.result4
+	}
+}
+
+func (fake *FakeConfigDB) GetInstanceConfigCallCount() int {
+	fake.getInstanceConfigMutex.RLock()
+	defer fake.getInstanceConfigMutex.RUnlock()
+	return len(fake.getInstanceConfigArgsForCall)
+}
+
+func (fake *FakeConfigDB) GetInstanceConfigArgsForCall(i int) (string, string, atc.InstanceVars) {
+	fake.getInstanceConfigMutex.RLock()
+	defer fake.getInstanceConfigMutex.RUnlock()
+	return fake.getInstanceConfigArgsForCall[i].teamName, fake.getInstanceConfigArgsForCall[i].pipelineName, fake.getInstanceConfigArgsForCall[i].instanceVars
+}
+
+func (fake *FakeConfigDB) GetInstanceConfigReturns(result1 atc.Config, result2 atc.RawConfig, result3 db.ConfigVersion, result4 error) {
+	fake.GetInstanceConfigStub = nil
+	fake.getInstanceConfigReturns = struct {
+		result1 atc.Config
+		result2 atc.RawConfig
+		result3 db.ConfigVersion
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeConfigDB) SaveInstanceConfig(arg1 string, arg2 string, arg3 atc.InstanceVars, arg4 atc.Config, arg5 *atc.PipelineTemplate, arg6 db.ConfigVersion, arg7 db.PipelinePausedState) (db.SavedPipeline, bool, error) {
+	fake.saveInstanceConfigMutex.Lock()
+	fake.saveInstanceConfigArgsForCall = append(fake.saveInstanceConfigArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 atc.InstanceVars
+		arg4 atc.Config
+		arg5 *atc.PipelineTemplate
+		arg6 db.ConfigVersion
+		arg7 db.PipelinePausedState
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	fake.recordInvocation("SaveInstanceConfig", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	fake.saveInstanceConfigMutex.Unlock()
+	if fake.SaveInstanceConfigStub != nil {
+		return fake.SaveInstanceConfigStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	} else {
+		return fake.saveInstanceConfigReturns.result1, fake.saveInstanceConfigReturns.result2, fake.saveInstanceConfigReturns.result3
+	}
+}
+
+func (fake *FakeConfigDB) SaveInstanceConfigCallCount() int {
+	fake.saveInstanceConfigMutex.RLock()
+	defer fake.saveInstanceConfigMutex.RUnlock()
+	return len(fake.saveInstanceConfigArgsForCall)
+}
+
+func (fake *FakeConfigDB) SaveInstanceConfigArgsForCall(i int) (string, string, atc.InstanceVars, atc.Config, *atc.PipelineTemplate, db.ConfigVersion, db.PipelinePausedState) {
+	fake.saveInstanceConfigMutex.RLock()
+	defer fake.saveInstanceConfigMutex.RUnlock()
+	return fake.saveInstanceConfigArgsForCall[i].arg1, fake.saveInstanceConfigArgsForCall[i].arg2, fake.saveInstanceConfigArgsForCall[i].arg3, fake.saveInstanceConfigArgsForCall[i].arg4, fake.saveInstanceConfigArgsForCall[i].arg5, fake.saveInstanceConfigArgsForCall[i].arg6, fake.saveInstanceConfigArgsForCall[i].arg7
+}
+
+func (fake *FakeConfigDB) SaveInstanceConfigReturns(result1 db.SavedPipeline, result2 bool, result3 error) {
+	fake.SaveInstanceConfigStub = nil
+	fake.saveInstanceConfigReturns = struct {
+		result1 db.SavedPipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeConfigDB) GetInstanceTemplate(teamName string, pipelineName string, instanceVars atc.InstanceVars) (atc.PipelineTemplate, bool, error) {
+	fake.getInstanceTemplateMutex.Lock()
+	fake.getInstanceTemplateArgsForCall = append(fake.getInstanceTemplateArgsForCall, struct {
+		teamName     string
+		pipelineName string
+		instanceVars atc.InstanceVars
+	}{teamName, pipelineName, instanceVars})
+	fake.recordInvocation("GetInstanceTemplate", []interface{}{teamName, pipelineName, instanceVars})
+	fake.getInstanceTemplateMutex.Unlock()
+	if fake.GetInstanceTemplateStub != nil {
+		return fake.GetInstanceTemplateStub(teamName, pipelineName, instanceVars)
+	} else {
+		return fake.getInstanceTemplateReturns.result1, fake.getInstanceTemplateReturns.result2, fake.getInstanceTemplateReturns.result3
+	}
+}
+
+func (fake *FakeConfigDB) GetInstanceTemplateCallCount() int {
+	fake.getInstanceTemplateMutex.RLock()
+	defer fake.getInstanceTemplateMutex.RUnlock()
+	return len(fake.getInstanceTemplateArgsForCall)
+}
+
+func (fake *FakeConfigDB) GetInstanceTemplateArgsForCall(i int) (string, string, atc.InstanceVars) {
+	fake.getInstanceTemplateMutex.RLock()
+	defer fake.getInstanceTemplateMutex.RUnlock()
+	return fake.getInstanceTemplateArgsForCall[i].teamName, fake.getInstanceTemplateArgsForCall[i].pipelineName, fake.getInstanceTemplateArgsForCall[i].instanceVars
+}
+
+func (fake *FakeConfigDB) GetInstanceTemplateReturns(result1 atc.PipelineTemplate, result2 bool, result3 error) {
+	fake.GetInstanceTemplateStub = nil
+	fake.getInstanceTemplateReturns = struct {
+		result1 atc.PipelineTemplate
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeConfigDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -122,6 +426,18 @@ func (fake *FakeConfigDB) Invocations() map[string][][]interface{} {
 	defer fake.getConfigMutex.RUnlock()
 	fake.saveConfigMutex.RLock()
 	defer fake.saveConfigMutex.RUnlock()
+	fake.saveTemplatedConfigMutex.RLock()
+	defer fake.saveTemplatedConfigMutex.RUnlock()
+	fake.getTemplateMutex.RLock()
+	defer fake.getTemplateMutex.RUnlock()
+	fake.getPipelineInstancesMutex.RLock()
+	defer fake.getPipelineInstancesMutex.RUnlock()
+	fake.getInstanceConfigMutex.RLock()
+	defer fake.getInstanceConfigMutex.RUnlock()
+	fake.saveInstanceConfigMutex.RLock()
+	defer fake.saveInstanceConfigMutex.RUnlock()
+	fake.getInstanceTemplateMutex.RLock()
+	defer fake.getInstanceTemplateMutex.RUnlock()
 	return fake.invocations
 }
 
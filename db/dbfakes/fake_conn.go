@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/encryption"
 )
 
 type FakeConn struct {
@@ -83,6 +84,12 @@ type FakeConn struct {
 	setMaxOpenConnsArgsForCall []struct {
 		n int
 	}
+	EncryptionStrategyStub        func() encryption.Strategy
+	encryptionStrategyMutex       sync.RWMutex
+	encryptionStrategyArgsForCall []struct{}
+	encryptionStrategyReturns     struct {
+		result1 encryption.Strategy
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -374,6 +381,31 @@ func (fake *FakeConn) SetMaxOpenConnsArgsForCall(i int) int {
 	return fake.setMaxOpenConnsArgsForCall[i].n
 }
 
+func (fake *FakeConn) EncryptionStrategy() encryption.Strategy {
+	fake.encryptionStrategyMutex.Lock()
+	fake.encryptionStrategyArgsForCall = append(fake.encryptionStrategyArgsForCall, struct{}{})
+	fake.recordInvocation("EncryptionStrategy", []interface{}{})
+	fake.encryptionStrategyMutex.Unlock()
+	if fake.EncryptionStrategyStub != nil {
+		return fake.EncryptionStrategyStub()
+	} else {
+		return fake.encryptionStrategyReturns.result1
+	}
+}
+
+func (fake *FakeConn) EncryptionStrategyCallCount() int {
+	fake.encryptionStrategyMutex.RLock()
+	defer fake.encryptionStrategyMutex.RUnlock()
+	return len(fake.encryptionStrategyArgsForCall)
+}
+
+func (fake *FakeConn) EncryptionStrategyReturns(result1 encryption.Strategy) {
+	fake.EncryptionStrategyStub = nil
+	fake.encryptionStrategyReturns = struct {
+		result1 encryption.Strategy
+	}{result1}
+}
+
 func (fake *FakeConn) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -397,6 +429,8 @@ func (fake *FakeConn) Invocations() map[string][][]interface{} {
 	defer fake.setMaxIdleConnsMutex.RUnlock()
 	fake.setMaxOpenConnsMutex.RLock()
 	defer fake.setMaxOpenConnsMutex.RUnlock()
+	fake.encryptionStrategyMutex.RLock()
+	defer fake.encryptionStrategyMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -0,0 +1,3405 @@
+// This file was generated by counterfeiter
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+)
+
+type FakeDB struct {
+	SaveTeamStub        func(team db.Team) (db.SavedTeam, error)
+	saveTeamMutex       sync.RWMutex
+	saveTeamArgsForCall []struct {
+		team db.Team
+	}
+	saveTeamReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	GetTeamByNameStub        func(teamName string) (db.SavedTeam, bool, error)
+	getTeamByNameMutex       sync.RWMutex
+	getTeamByNameArgsForCall []struct {
+		teamName string
+	}
+	getTeamByNameReturns struct {
+		result1 db.SavedTeam
+		result2 bool
+		result3 error
+	}
+	GetTeamsStub        func() ([]db.SavedTeam, error)
+	getTeamsMutex       sync.RWMutex
+	getTeamsArgsForCall []struct{}
+	getTeamsReturns     struct {
+		result1 []db.SavedTeam
+		result2 error
+	}
+	RenameTeamStub        func(currentName string, newName string) (db.SavedTeam, bool, error)
+	renameTeamMutex       sync.RWMutex
+	renameTeamArgsForCall []struct {
+		currentName string
+		newName     string
+	}
+	renameTeamReturns struct {
+		result1 db.SavedTeam
+		result2 bool
+		result3 error
+	}
+	UpdateTeamBasicAuthStub        func(team db.Team) (db.SavedTeam, error)
+	updateTeamBasicAuthMutex       sync.RWMutex
+	updateTeamBasicAuthArgsForCall []struct {
+		team db.Team
+	}
+	updateTeamBasicAuthReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	UpdateTeamGitHubAuthStub        func(team db.Team) (db.SavedTeam, error)
+	updateTeamGitHubAuthMutex       sync.RWMutex
+	updateTeamGitHubAuthArgsForCall []struct {
+		team db.Team
+	}
+	updateTeamGitHubAuthReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	UpdateTeamGitLabAuthStub        func(team db.Team) (db.SavedTeam, error)
+	updateTeamGitLabAuthMutex       sync.RWMutex
+	updateTeamGitLabAuthArgsForCall []struct {
+		team db.Team
+	}
+	updateTeamGitLabAuthReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	UpdateTeamOIDCAuthStub        func(team db.Team) (db.SavedTeam, error)
+	updateTeamOIDCAuthMutex       sync.RWMutex
+	updateTeamOIDCAuthArgsForCall []struct {
+		team db.Team
+	}
+	updateTeamOIDCAuthReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	UpdateTeamRolesStub        func(team db.Team) (db.SavedTeam, error)
+	updateTeamRolesMutex       sync.RWMutex
+	updateTeamRolesArgsForCall []struct {
+		team db.Team
+	}
+	updateTeamRolesReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	CreateDefaultTeamIfNotExistsStub        func() error
+	createDefaultTeamIfNotExistsMutex       sync.RWMutex
+	createDefaultTeamIfNotExistsArgsForCall []struct{}
+	createDefaultTeamIfNotExistsReturns     struct {
+		result1 error
+	}
+	DeleteTeamByNameStub        func(teamName string) error
+	deleteTeamByNameMutex       sync.RWMutex
+	deleteTeamByNameArgsForCall []struct {
+		teamName string
+	}
+	deleteTeamByNameReturns struct {
+		result1 error
+	}
+	CreateAPITokenStub        func(token db.APIToken, tokenHash string) (db.SavedAPIToken, error)
+	createAPITokenMutex       sync.RWMutex
+	createAPITokenArgsForCall []struct {
+		token     db.APIToken
+		tokenHash string
+	}
+	createAPITokenReturns struct {
+		result1 db.SavedAPIToken
+		result2 error
+	}
+	GetAPITokensStub        func(teamName string) ([]db.SavedAPIToken, error)
+	getAPITokensMutex       sync.RWMutex
+	getAPITokensArgsForCall []struct {
+		teamName string
+	}
+	getAPITokensReturns struct {
+		result1 []db.SavedAPIToken
+		result2 error
+	}
+	DeleteAPITokenStub        func(teamName string, tokenName string) (bool, error)
+	deleteAPITokenMutex       sync.RWMutex
+	deleteAPITokenArgsForCall []struct {
+		teamName  string
+		tokenName string
+	}
+	deleteAPITokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	FindAPITokenByHashStub        func(tokenHash string) (db.SavedAPIToken, bool, error)
+	findAPITokenByHashMutex       sync.RWMutex
+	findAPITokenByHashArgsForCall []struct {
+		tokenHash string
+	}
+	findAPITokenByHashReturns struct {
+		result1 db.SavedAPIToken
+		result2 bool
+		result3 error
+	}
+	UpdateAPITokenLastUsedStub        func(tokenID int) error
+	updateAPITokenLastUsedMutex       sync.RWMutex
+	updateAPITokenLastUsedArgsForCall []struct {
+		tokenID int
+	}
+	updateAPITokenLastUsedReturns struct {
+		result1 error
+	}
+	SaveAuditEventStub        func(event db.AuditEvent) error
+	saveAuditEventMutex       sync.RWMutex
+	saveAuditEventArgsForCall []struct {
+		event db.AuditEvent
+	}
+	saveAuditEventReturns struct {
+		result1 error
+	}
+	GetAuditEventsStub        func(teamID int, page db.Page) ([]db.SavedAuditEvent, db.Pagination, error)
+	getAuditEventsMutex       sync.RWMutex
+	getAuditEventsArgsForCall []struct {
+		teamID int
+		page   db.Page
+	}
+	getAuditEventsReturns struct {
+		result1 []db.SavedAuditEvent
+		result2 db.Pagination
+		result3 error
+	}
+	GetBuildStub        func(buildID int) (db.Build, bool, error)
+	getBuildMutex       sync.RWMutex
+	getBuildArgsForCall []struct {
+		buildID int
+	}
+	getBuildReturns struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	GetBuildVersionedResourcesStub        func(buildID int) (db.SavedVersionedResources, error)
+	getBuildVersionedResourcesMutex       sync.RWMutex
+	getBuildVersionedResourcesArgsForCall []struct {
+		buildID int
+	}
+	getBuildVersionedResourcesReturns struct {
+		result1 db.SavedVersionedResources
+		result2 error
+	}
+	GetBuildResourcesStub        func(buildID int) ([]db.BuildInput, []db.BuildOutput, error)
+	getBuildResourcesMutex       sync.RWMutex
+	getBuildResourcesArgsForCall []struct {
+		buildID int
+	}
+	getBuildResourcesReturns struct {
+		result1 []db.BuildInput
+		result2 []db.BuildOutput
+		result3 error
+	}
+	GetBuildsStub        func(db.Page) ([]db.Build, db.Pagination, error)
+	getBuildsMutex       sync.RWMutex
+	getBuildsArgsForCall []struct {
+		arg1 db.Page
+	}
+	getBuildsReturns struct {
+		result1 []db.Build
+		result2 db.Pagination
+		result3 error
+	}
+	GetTeamBuildsStub        func(teamID int, page db.Page) ([]db.Build, db.Pagination, error)
+	getTeamBuildsMutex       sync.RWMutex
+	getTeamBuildsArgsForCall []struct {
+		teamID int
+		page   db.Page
+	}
+	getTeamBuildsReturns struct {
+		result1 []db.Build
+		result2 db.Pagination
+		result3 error
+	}
+	GetAllStartedBuildsStub        func() ([]db.Build, error)
+	getAllStartedBuildsMutex       sync.RWMutex
+	getAllStartedBuildsArgsForCall []struct{}
+	getAllStartedBuildsReturns     struct {
+		result1 []db.Build
+		result2 error
+	}
+	FindJobIDForBuildStub        func(buildID int) (int, bool, error)
+	findJobIDForBuildMutex       sync.RWMutex
+	findJobIDForBuildArgsForCall []struct {
+		buildID int
+	}
+	findJobIDForBuildReturns struct {
+		result1 int
+		result2 bool
+		result3 error
+	}
+	CreatePipeStub        func(pipeGUID string, url string) error
+	createPipeMutex       sync.RWMutex
+	createPipeArgsForCall []struct {
+		pipeGUID string
+		url      string
+	}
+	createPipeReturns struct {
+		result1 error
+	}
+	GetPipeStub        func(pipeGUID string) (db.Pipe, error)
+	getPipeMutex       sync.RWMutex
+	getPipeArgsForCall []struct {
+		pipeGUID string
+	}
+	getPipeReturns struct {
+		result1 db.Pipe
+		result2 error
+	}
+	CreateOneOffBuildStub        func(teamName string) (db.Build, error)
+	createOneOffBuildMutex       sync.RWMutex
+	createOneOffBuildArgsForCall []struct {
+		teamName string
+	}
+	createOneOffBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	GetBuildPreparationStub        func(buildID int) (db.BuildPreparation, bool, error)
+	getBuildPreparationMutex       sync.RWMutex
+	getBuildPreparationArgsForCall []struct {
+		buildID int
+	}
+	getBuildPreparationReturns struct {
+		result1 db.BuildPreparation
+		result2 bool
+		result3 error
+	}
+	UpdateBuildPreparationStub        func(buildPreparation db.BuildPreparation) error
+	updateBuildPreparationMutex       sync.RWMutex
+	updateBuildPreparationArgsForCall []struct {
+		buildPreparation db.BuildPreparation
+	}
+	updateBuildPreparationReturns struct {
+		result1 error
+	}
+	UpdateBuildPreparationWorkersAvailableStub        func(buildID int, status db.BuildPreparationStatus) error
+	updateBuildPreparationWorkersAvailableMutex       sync.RWMutex
+	updateBuildPreparationWorkersAvailableArgsForCall []struct {
+		buildID int
+		status  db.BuildPreparationStatus
+	}
+	updateBuildPreparationWorkersAvailableReturns struct {
+		result1 error
+	}
+	ResetBuildPreparationsWithPipelinePausedStub        func(pipelineID int) error
+	resetBuildPreparationsWithPipelinePausedMutex       sync.RWMutex
+	resetBuildPreparationsWithPipelinePausedArgsForCall []struct {
+		pipelineID int
+	}
+	resetBuildPreparationsWithPipelinePausedReturns struct {
+		result1 error
+	}
+	LeaseBuildTrackingStub        func(logger lager.Logger, buildID int, interval time.Duration) (db.Lease, bool, error)
+	leaseBuildTrackingMutex       sync.RWMutex
+	leaseBuildTrackingArgsForCall []struct {
+		logger   lager.Logger
+		buildID  int
+		interval time.Duration
+	}
+	leaseBuildTrackingReturns struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}
+	LeaseBuildSchedulingStub        func(logger lager.Logger, buildID int, interval time.Duration) (db.Lease, bool, error)
+	leaseBuildSchedulingMutex       sync.RWMutex
+	leaseBuildSchedulingArgsForCall []struct {
+		logger   lager.Logger
+		buildID  int
+		interval time.Duration
+	}
+	leaseBuildSchedulingReturns struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}
+	GetLeaseStub        func(logger lager.Logger, taskName string, interval time.Duration) (db.Lease, bool, error)
+	getLeaseMutex       sync.RWMutex
+	getLeaseArgsForCall []struct {
+		logger   lager.Logger
+		taskName string
+		interval time.Duration
+	}
+	getLeaseReturns struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}
+	StartBuildStub        func(buildID int, pipelineID int, engineName string, engineMetadata string) (bool, error)
+	startBuildMutex       sync.RWMutex
+	startBuildArgsForCall []struct {
+		buildID        int
+		pipelineID     int
+		engineName     string
+		engineMetadata string
+	}
+	startBuildReturns struct {
+		result1 bool
+		result2 error
+	}
+	FinishBuildStub        func(buildID int, pipelineID int, status db.Status) error
+	finishBuildMutex       sync.RWMutex
+	finishBuildArgsForCall []struct {
+		buildID    int
+		pipelineID int
+		status     db.Status
+	}
+	finishBuildReturns struct {
+		result1 error
+	}
+	ErrorBuildStub        func(buildID int, pipelineID int, cause error) error
+	errorBuildMutex       sync.RWMutex
+	errorBuildArgsForCall []struct {
+		buildID    int
+		pipelineID int
+		cause      error
+	}
+	errorBuildReturns struct {
+		result1 error
+	}
+	SaveBuildInputStub        func(buildID int, input db.BuildInput) (db.SavedVersionedResource, error)
+	saveBuildInputMutex       sync.RWMutex
+	saveBuildInputArgsForCall []struct {
+		buildID int
+		input   db.BuildInput
+	}
+	saveBuildInputReturns struct {
+		result1 db.SavedVersionedResource
+		result2 error
+	}
+	SaveBuildOutputStub        func(buildID int, vr db.VersionedResource, explicit bool) (db.SavedVersionedResource, error)
+	saveBuildOutputMutex       sync.RWMutex
+	saveBuildOutputArgsForCall []struct {
+		buildID  int
+		vr       db.VersionedResource
+		explicit bool
+	}
+	saveBuildOutputReturns struct {
+		result1 db.SavedVersionedResource
+		result2 error
+	}
+	GetBuildEventsStub        func(buildID int, from uint) (db.EventSource, error)
+	getBuildEventsMutex       sync.RWMutex
+	getBuildEventsArgsForCall []struct {
+		buildID int
+		from    uint
+	}
+	getBuildEventsReturns struct {
+		result1 db.EventSource
+		result2 error
+	}
+	SaveBuildEventStub        func(buildID int, pipelineID int, event atc.Event) error
+	saveBuildEventMutex       sync.RWMutex
+	saveBuildEventArgsForCall []struct {
+		buildID    int
+		pipelineID int
+		event      atc.Event
+	}
+	saveBuildEventReturns struct {
+		result1 error
+	}
+	DeleteBuildEventsByBuildIDsStub        func(buildIDs []int) error
+	deleteBuildEventsByBuildIDsMutex       sync.RWMutex
+	deleteBuildEventsByBuildIDsArgsForCall []struct {
+		buildIDs []int
+	}
+	deleteBuildEventsByBuildIDsReturns struct {
+		result1 error
+	}
+	SaveBuildEngineMetadataStub        func(buildID int, engineMetadata string) error
+	saveBuildEngineMetadataMutex       sync.RWMutex
+	saveBuildEngineMetadataArgsForCall []struct {
+		buildID        int
+		engineMetadata string
+	}
+	saveBuildEngineMetadataReturns struct {
+		result1 error
+	}
+	AbortBuildStub        func(buildID int) error
+	abortBuildMutex       sync.RWMutex
+	abortBuildArgsForCall []struct {
+		buildID int
+	}
+	abortBuildReturns struct {
+		result1 error
+	}
+	AbortNotifierStub        func(buildID int) (db.Notifier, error)
+	abortNotifierMutex       sync.RWMutex
+	abortNotifierArgsForCall []struct {
+		buildID int
+	}
+	abortNotifierReturns struct {
+		result1 db.Notifier
+		result2 error
+	}
+	WorkersStub        func() ([]db.SavedWorker, error)
+	workersMutex       sync.RWMutex
+	workersArgsForCall []struct{}
+	workersReturns     struct {
+		result1 []db.SavedWorker
+		result2 error
+	}
+	GetWorkerStub        func(workerName string) (db.SavedWorker, bool, error)
+	getWorkerMutex       sync.RWMutex
+	getWorkerArgsForCall []struct {
+		workerName string
+	}
+	getWorkerReturns struct {
+		result1 db.SavedWorker
+		result2 bool
+		result3 error
+	}
+	SaveWorkerStub        func(db.WorkerInfo, time.Duration) (db.SavedWorker, error)
+	saveWorkerMutex       sync.RWMutex
+	saveWorkerArgsForCall []struct {
+		arg1 db.WorkerInfo
+		arg2 time.Duration
+	}
+	saveWorkerReturns struct {
+		result1 db.SavedWorker
+		result2 error
+	}
+	SaveTeamWorkerStub        func(db.WorkerInfo, time.Duration) (db.SavedWorker, error)
+	saveTeamWorkerMutex       sync.RWMutex
+	saveTeamWorkerArgsForCall []struct {
+		arg1 db.WorkerInfo
+		arg2 time.Duration
+	}
+	saveTeamWorkerReturns struct {
+		result1 db.SavedWorker
+		result2 error
+	}
+	LandWorkerStub        func(workerName string) error
+	landWorkerMutex       sync.RWMutex
+	landWorkerArgsForCall []struct {
+		workerName string
+	}
+	landWorkerReturns struct {
+		result1 error
+	}
+	RetireWorkerStub        func(workerName string) error
+	retireWorkerMutex       sync.RWMutex
+	retireWorkerArgsForCall []struct {
+		workerName string
+	}
+	retireWorkerReturns struct {
+		result1 error
+	}
+	PruneWorkerStub        func(workerName string) error
+	pruneWorkerMutex       sync.RWMutex
+	pruneWorkerArgsForCall []struct {
+		workerName string
+	}
+	pruneWorkerReturns struct {
+		result1 error
+	}
+	SaveWorkerHealthStub        func(workerName string, health db.WorkerHealth) error
+	saveWorkerHealthMutex       sync.RWMutex
+	saveWorkerHealthArgsForCall []struct {
+		workerName string
+		health     db.WorkerHealth
+	}
+	saveWorkerHealthReturns struct {
+		result1 error
+	}
+	FindContainersByDescriptorsStub        func(db.Container) ([]db.SavedContainer, error)
+	findContainersByDescriptorsMutex       sync.RWMutex
+	findContainersByDescriptorsArgsForCall []struct {
+		arg1 db.Container
+	}
+	findContainersByDescriptorsReturns struct {
+		result1 []db.SavedContainer
+		result2 error
+	}
+	GetContainerStub        func(string) (db.SavedContainer, bool, error)
+	getContainerMutex       sync.RWMutex
+	getContainerArgsForCall []struct {
+		arg1 string
+	}
+	getContainerReturns struct {
+		result1 db.SavedContainer
+		result2 bool
+		result3 error
+	}
+	CreateContainerStub        func(container db.Container, ttl time.Duration, maxLifetime time.Duration, volumeHandles []string) (db.SavedContainer, error)
+	createContainerMutex       sync.RWMutex
+	createContainerArgsForCall []struct {
+		container     db.Container
+		ttl           time.Duration
+		maxLifetime   time.Duration
+		volumeHandles []string
+	}
+	createContainerReturns struct {
+		result1 db.SavedContainer
+		result2 error
+	}
+	FindContainerByIdentifierStub        func(db.ContainerIdentifier) (db.SavedContainer, bool, error)
+	findContainerByIdentifierMutex       sync.RWMutex
+	findContainerByIdentifierArgsForCall []struct {
+		arg1 db.ContainerIdentifier
+	}
+	findContainerByIdentifierReturns struct {
+		result1 db.SavedContainer
+		result2 bool
+		result3 error
+	}
+	FindLatestSuccessfulBuildsPerJobStub        func() (map[int]int, error)
+	findLatestSuccessfulBuildsPerJobMutex       sync.RWMutex
+	findLatestSuccessfulBuildsPerJobArgsForCall []struct{}
+	findLatestSuccessfulBuildsPerJobReturns     struct {
+		result1 map[int]int
+		result2 error
+	}
+	FindJobContainersFromUnsuccessfulBuildsStub        func() ([]db.SavedContainer, error)
+	findJobContainersFromUnsuccessfulBuildsMutex       sync.RWMutex
+	findJobContainersFromUnsuccessfulBuildsArgsForCall []struct{}
+	findJobContainersFromUnsuccessfulBuildsReturns     struct {
+		result1 []db.SavedContainer
+		result2 error
+	}
+	UpdateExpiresAtOnContainerStub        func(handle string, ttl time.Duration) error
+	updateExpiresAtOnContainerMutex       sync.RWMutex
+	updateExpiresAtOnContainerArgsForCall []struct {
+		handle string
+		ttl    time.Duration
+	}
+	updateExpiresAtOnContainerReturns struct {
+		result1 error
+	}
+	ReapContainerStub        func(handle string) error
+	reapContainerMutex       sync.RWMutex
+	reapContainerArgsForCall []struct {
+		handle string
+	}
+	reapContainerReturns struct {
+		result1 error
+	}
+	DeleteContainerStub        func(string) error
+	deleteContainerMutex       sync.RWMutex
+	deleteContainerArgsForCall []struct {
+		arg1 string
+	}
+	deleteContainerReturns struct {
+		result1 error
+	}
+	GetConfigByBuildIDStub        func(buildID int) (atc.Config, db.ConfigVersion, error)
+	getConfigByBuildIDMutex       sync.RWMutex
+	getConfigByBuildIDArgsForCall []struct {
+		buildID int
+	}
+	getConfigByBuildIDReturns struct {
+		result1 atc.Config
+		result2 db.ConfigVersion
+		result3 error
+	}
+	InsertVolumeStub        func(data db.Volume) error
+	insertVolumeMutex       sync.RWMutex
+	insertVolumeArgsForCall []struct {
+		data db.Volume
+	}
+	insertVolumeReturns struct {
+		result1 error
+	}
+	GetVolumesStub        func() ([]db.SavedVolume, error)
+	getVolumesMutex       sync.RWMutex
+	getVolumesArgsForCall []struct{}
+	getVolumesReturns     struct {
+		result1 []db.SavedVolume
+		result2 error
+	}
+	GetVolumesByIdentifierStub        func(db.VolumeIdentifier) ([]db.SavedVolume, error)
+	getVolumesByIdentifierMutex       sync.RWMutex
+	getVolumesByIdentifierArgsForCall []struct {
+		arg1 db.VolumeIdentifier
+	}
+	getVolumesByIdentifierReturns struct {
+		result1 []db.SavedVolume
+		result2 error
+	}
+	ReapVolumeStub        func(string) error
+	reapVolumeMutex       sync.RWMutex
+	reapVolumeArgsForCall []struct {
+		arg1 string
+	}
+	reapVolumeReturns struct {
+		result1 error
+	}
+	SetVolumeTTLStub        func(string, time.Duration) error
+	setVolumeTTLMutex       sync.RWMutex
+	setVolumeTTLArgsForCall []struct {
+		arg1 string
+		arg2 time.Duration
+	}
+	setVolumeTTLReturns struct {
+		result1 error
+	}
+	GetVolumeTTLStub        func(volumeHandle string) (time.Duration, bool, error)
+	getVolumeTTLMutex       sync.RWMutex
+	getVolumeTTLArgsForCall []struct {
+		volumeHandle string
+	}
+	getVolumeTTLReturns struct {
+		result1 time.Duration
+		result2 bool
+		result3 error
+	}
+	SetVolumeSizeInBytesStub        func(string, int64) error
+	setVolumeSizeInBytesMutex       sync.RWMutex
+	setVolumeSizeInBytesArgsForCall []struct {
+		arg1 string
+		arg2 int64
+	}
+	setVolumeSizeInBytesReturns struct {
+		result1 error
+	}
+	GetVolumesForOneOffBuildImageResourcesStub        func() ([]db.SavedVolume, error)
+	getVolumesForOneOffBuildImageResourcesMutex       sync.RWMutex
+	getVolumesForOneOffBuildImageResourcesArgsForCall []struct{}
+	getVolumesForOneOffBuildImageResourcesReturns     struct {
+		result1 []db.SavedVolume
+		result2 error
+	}
+	FindWorkerCheckResourceTypeVersionStub        func(workerName string, checkType string) (string, bool, error)
+	findWorkerCheckResourceTypeVersionMutex       sync.RWMutex
+	findWorkerCheckResourceTypeVersionArgsForCall []struct {
+		workerName string
+		checkType  string
+	}
+	findWorkerCheckResourceTypeVersionReturns struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
+	SaveImageResourceVersionStub        func(buildID int, planID atc.PlanID, identifier db.ResourceCacheIdentifier) error
+	saveImageResourceVersionMutex       sync.RWMutex
+	saveImageResourceVersionArgsForCall []struct {
+		buildID    int
+		planID     atc.PlanID
+		identifier db.ResourceCacheIdentifier
+	}
+	saveImageResourceVersionReturns struct {
+		result1 error
+	}
+	GetImageResourceCacheIdentifiersByBuildIDStub        func(buildID int) ([]db.ResourceCacheIdentifier, error)
+	getImageResourceCacheIdentifiersByBuildIDMutex       sync.RWMutex
+	getImageResourceCacheIdentifiersByBuildIDArgsForCall []struct {
+		buildID int
+	}
+	getImageResourceCacheIdentifiersByBuildIDReturns struct {
+		result1 []db.ResourceCacheIdentifier
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDB) SaveTeam(team db.Team) (db.SavedTeam, error) {
+	fake.saveTeamMutex.Lock()
+	fake.saveTeamArgsForCall = append(fake.saveTeamArgsForCall, struct {
+		team db.Team
+	}{team})
+	fake.recordInvocation("SaveTeam", []interface{}{team})
+	fake.saveTeamMutex.Unlock()
+	if fake.SaveTeamStub != nil {
+		return fake.SaveTeamStub(team)
+	} else {
+		return fake.saveTeamReturns.result1, fake.saveTeamReturns.result2
+	}
+}
+
+func (fake *FakeDB) SaveTeamCallCount() int {
+	fake.saveTeamMutex.RLock()
+	defer fake.saveTeamMutex.RUnlock()
+	return len(fake.saveTeamArgsForCall)
+}
+
+func (fake *FakeDB) SaveTeamArgsForCall(i int) db.Team {
+	fake.saveTeamMutex.RLock()
+	defer fake.saveTeamMutex.RUnlock()
+	return fake.saveTeamArgsForCall[i].team
+}
+
+func (fake *FakeDB) SaveTeamReturns(result1 db.SavedTeam, result2 error) {
+	fake.SaveTeamStub = nil
+	fake.saveTeamReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetTeamByName(teamName string) (db.SavedTeam, bool, error) {
+	fake.getTeamByNameMutex.Lock()
+	fake.getTeamByNameArgsForCall = append(fake.getTeamByNameArgsForCall, struct {
+		teamName string
+	}{teamName})
+	fake.recordInvocation("GetTeamByName", []interface{}{teamName})
+	fake.getTeamByNameMutex.Unlock()
+	if fake.GetTeamByNameStub != nil {
+		return fake.GetTeamByNameStub(teamName)
+	} else {
+		return fake.getTeamByNameReturns.result1, fake.getTeamByNameReturns.result2, fake.getTeamByNameReturns.result3
+	}
+}
+
+func (fake *FakeDB) GetTeamByNameCallCount() int {
+	fake.getTeamByNameMutex.RLock()
+	defer fake.getTeamByNameMutex.RUnlock()
+	return len(fake.getTeamByNameArgsForCall)
+}
+
+func (fake *FakeDB) GetTeamByNameArgsForCall(i int) string {
+	fake.getTeamByNameMutex.RLock()
+	defer fake.getTeamByNameMutex.RUnlock()
+	return fake.getTeamByNameArgsForCall[i].teamName
+}
+
+func (fake *FakeDB) GetTeamByNameReturns(result1 db.SavedTeam, result2 bool, result3 error) {
+	fake.GetTeamByNameStub = nil
+	fake.getTeamByNameReturns = struct {
+		result1 db.SavedTeam
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) GetTeams() ([]db.SavedTeam, error) {
+	fake.getTeamsMutex.Lock()
+	fake.getTeamsArgsForCall = append(fake.getTeamsArgsForCall, struct{}{})
+	fake.recordInvocation("GetTeams", []interface{}{})
+	fake.getTeamsMutex.Unlock()
+	if fake.GetTeamsStub != nil {
+		return fake.GetTeamsStub()
+	} else {
+		return fake.getTeamsReturns.result1, fake.getTeamsReturns.result2
+	}
+}
+
+func (fake *FakeDB) GetTeamsCallCount() int {
+	fake.getTeamsMutex.RLock()
+	defer fake.getTeamsMutex.RUnlock()
+	return len(fake.getTeamsArgsForCall)
+}
+
+func (fake *FakeDB) GetTeamsReturns(result1 []db.SavedTeam, result2 error) {
+	fake.GetTeamsStub = nil
+	fake.getTeamsReturns = struct {
+		result1 []db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) RenameTeam(currentName string, newName string) (db.SavedTeam, bool, error) {
+	fake.renameTeamMutex.Lock()
+	fake.renameTeamArgsForCall = append(fake.renameTeamArgsForCall, struct {
+		currentName string
+		newName     string
+	}{currentName, newName})
+	fake.recordInvocation("RenameTeam", []interface{}{currentName, newName})
+	fake.renameTeamMutex.Unlock()
+	if fake.RenameTeamStub != nil {
+		return fake.RenameTeamStub(currentName, newName)
+	} else {
+		return fake.renameTeamReturns.result1, fake.renameTeamReturns.result2, fake.renameTeamReturns.result3
+	}
+}
+
+func (fake *FakeDB) RenameTeamCallCount() int {
+	fake.renameTeamMutex.RLock()
+	defer fake.renameTeamMutex.RUnlock()
+	return len(fake.renameTeamArgsForCall)
+}
+
+func (fake *FakeDB) RenameTeamArgsForCall(i int) (string, string) {
+	fake.renameTeamMutex.RLock()
+	defer fake.renameTeamMutex.RUnlock()
+	return fake.renameTeamArgsForCall[i].currentName, fake.renameTeamArgsForCall[i].newName
+}
+
+func (fake *FakeDB) RenameTeamReturns(result1 db.SavedTeam, result2 bool, result3 error) {
+	fake.RenameTeamStub = nil
+	fake.renameTeamReturns = struct {
+		result1 db.SavedTeam
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) UpdateTeamBasicAuth(team db.Team) (db.SavedTeam, error) {
+	fake.updateTeamBasicAuthMutex.Lock()
+	fake.updateTeamBasicAuthArgsForCall = append(fake.updateTeamBasicAuthArgsForCall, struct {
+		team db.Team
+	}{team})
+	fake.recordInvocation("UpdateTeamBasicAuth", []interface{}{team})
+	fake.updateTeamBasicAuthMutex.Unlock()
+	if fake.UpdateTeamBasicAuthStub != nil {
+		return fake.UpdateTeamBasicAuthStub(team)
+	} else {
+		return fake.updateTeamBasicAuthReturns.result1, fake.updateTeamBasicAuthReturns.result2
+	}
+}
+
+func (fake *FakeDB) UpdateTeamBasicAuthCallCount() int {
+	fake.updateTeamBasicAuthMutex.RLock()
+	defer fake.updateTeamBasicAuthMutex.RUnlock()
+	return len(fake.updateTeamBasicAuthArgsForCall)
+}
+
+func (fake *FakeDB) UpdateTeamBasicAuthArgsForCall(i int) db.Team {
+	fake.updateTeamBasicAuthMutex.RLock()
+	defer fake.updateTeamBasicAuthMutex.RUnlock()
+	return fake.updateTeamBasicAuthArgsForCall[i].team
+}
+
+func (fake *FakeDB) UpdateTeamBasicAuthReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateTeamBasicAuthStub = nil
+	fake.updateTeamBasicAuthReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) UpdateTeamGitHubAuth(team db.Team) (db.SavedTeam, error) {
+	fake.updateTeamGitHubAuthMutex.Lock()
+	fake.updateTeamGitHubAuthArgsForCall = append(fake.updateTeamGitHubAuthArgsForCall, struct {
+		team db.Team
+	}{team})
+	fake.recordInvocation("UpdateTeamGitHubAuth", []interface{}{team})
+	fake.updateTeamGitHubAuthMutex.Unlock()
+	if fake.UpdateTeamGitHubAuthStub != nil {
+		return fake.UpdateTeamGitHubAuthStub(team)
+	} else {
+		return fake.updateTeamGitHubAuthReturns.result1, fake.updateTeamGitHubAuthReturns.result2
+	}
+}
+
+func (fake *FakeDB) UpdateTeamGitHubAuthCallCount() int {
+	fake.updateTeamGitHubAuthMutex.RLock()
+	defer fake.updateTeamGitHubAuthMutex.RUnlock()
+	return len(fake.updateTeamGitHubAuthArgsForCall)
+}
+
+func (fake *FakeDB) UpdateTeamGitHubAuthArgsForCall(i int) db.Team {
+	fake.updateTeamGitHubAuthMutex.RLock()
+	defer fake.updateTeamGitHubAuthMutex.RUnlock()
+	return fake.updateTeamGitHubAuthArgsForCall[i].team
+}
+
+func (fake *FakeDB) UpdateTeamGitHubAuthReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateTeamGitHubAuthStub = nil
+	fake.updateTeamGitHubAuthReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) UpdateTeamGitLabAuth(team db.Team) (db.SavedTeam, error) {
+	fake.updateTeamGitLabAuthMutex.Lock()
+	fake.updateTeamGitLabAuthArgsForCall = append(fake.updateTeamGitLabAuthArgsForCall, struct {
+		team db.Team
+	}{team})
+	fake.recordInvocation("UpdateTeamGitLabAuth", []interface{}{team})
+	fake.updateTeamGitLabAuthMutex.Unlock()
+	if fake.UpdateTeamGitLabAuthStub != nil {
+		return fake.UpdateTeamGitLabAuthStub(team)
+	} else {
+		return fake.updateTeamGitLabAuthReturns.result1, fake.updateTeamGitLabAuthReturns.result2
+	}
+}
+
+func (fake *FakeDB) UpdateTeamGitLabAuthCallCount() int {
+	fake.updateTeamGitLabAuthMutex.RLock()
+	defer fake.updateTeamGitLabAuthMutex.RUnlock()
+	return len(fake.updateTeamGitLabAuthArgsForCall)
+}
+
+func (fake *FakeDB) UpdateTeamGitLabAuthArgsForCall(i int) db.Team {
+	fake.updateTeamGitLabAuthMutex.RLock()
+	defer fake.updateTeamGitLabAuthMutex.RUnlock()
+	return fake.updateTeamGitLabAuthArgsForCall[i].team
+}
+
+func (fake *FakeDB) UpdateTeamGitLabAuthReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateTeamGitLabAuthStub = nil
+	fake.updateTeamGitLabAuthReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) UpdateTeamOIDCAuth(team db.Team) (db.SavedTeam, error) {
+	fake.updateTeamOIDCAuthMutex.Lock()
+	fake.updateTeamOIDCAuthArgsForCall = append(fake.updateTeamOIDCAuthArgsForCall, struct {
+		team db.Team
+	}{team})
+	fake.recordInvocation("UpdateTeamOIDCAuth", []interface{}{team})
+	fake.updateTeamOIDCAuthMutex.Unlock()
+	if fake.UpdateTeamOIDCAuthStub != nil {
+		return fake.UpdateTeamOIDCAuthStub(team)
+	} else {
+		return fake.updateTeamOIDCAuthReturns.result1, fake.updateTeamOIDCAuthReturns.result2
+	}
+}
+
+func (fake *FakeDB) UpdateTeamOIDCAuthCallCount() int {
+	fake.updateTeamOIDCAuthMutex.RLock()
+	defer fake.updateTeamOIDCAuthMutex.RUnlock()
+	return len(fake.updateTeamOIDCAuthArgsForCall)
+}
+
+func (fake *FakeDB) UpdateTeamOIDCAuthArgsForCall(i int) db.Team {
+	fake.updateTeamOIDCAuthMutex.RLock()
+	defer fake.updateTeamOIDCAuthMutex.RUnlock()
+	return fake.updateTeamOIDCAuthArgsForCall[i].team
+}
+
+func (fake *FakeDB) UpdateTeamOIDCAuthReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateTeamOIDCAuthStub = nil
+	fake.updateTeamOIDCAuthReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) UpdateTeamRoles(team db.Team) (db.SavedTeam, error) {
+	fake.updateTeamRolesMutex.Lock()
+	fake.updateTeamRolesArgsForCall = append(fake.updateTeamRolesArgsForCall, struct {
+		team db.Team
+	}{team})
+	fake.recordInvocation("UpdateTeamRoles", []interface{}{team})
+	fake.updateTeamRolesMutex.Unlock()
+	if fake.UpdateTeamRolesStub != nil {
+		return fake.UpdateTeamRolesStub(team)
+	} else {
+		return fake.updateTeamRolesReturns.result1, fake.updateTeamRolesReturns.result2
+	}
+}
+
+func (fake *FakeDB) UpdateTeamRolesCallCount() int {
+	fake.updateTeamRolesMutex.RLock()
+	defer fake.updateTeamRolesMutex.RUnlock()
+	return len(fake.updateTeamRolesArgsForCall)
+}
+
+func (fake *FakeDB) UpdateTeamRolesArgsForCall(i int) db.Team {
+	fake.updateTeamRolesMutex.RLock()
+	defer fake.updateTeamRolesMutex.RUnlock()
+	return fake.updateTeamRolesArgsForCall[i].team
+}
+
+func (fake *FakeDB) UpdateTeamRolesReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateTeamRolesStub = nil
+	fake.updateTeamRolesReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) CreateDefaultTeamIfNotExists() error {
+	fake.createDefaultTeamIfNotExistsMutex.Lock()
+	fake.createDefaultTeamIfNotExistsArgsForCall = append(fake.createDefaultTeamIfNotExistsArgsForCall, struct{}{})
+	fake.recordInvocation("CreateDefaultTeamIfNotExists", []interface{}{})
+	fake.createDefaultTeamIfNotExistsMutex.Unlock()
+	if fake.CreateDefaultTeamIfNotExistsStub != nil {
+		return fake.CreateDefaultTeamIfNotExistsStub()
+	} else {
+		return fake.createDefaultTeamIfNotExistsReturns.result1
+	}
+}
+
+func (fake *FakeDB) CreateDefaultTeamIfNotExistsCallCount() int {
+	fake.createDefaultTeamIfNotExistsMutex.RLock()
+	defer fake.createDefaultTeamIfNotExistsMutex.RUnlock()
+	return len(fake.createDefaultTeamIfNotExistsArgsForCall)
+}
+
+func (fake *FakeDB) CreateDefaultTeamIfNotExistsReturns(result1 error) {
+	fake.CreateDefaultTeamIfNotExistsStub = nil
+	fake.createDefaultTeamIfNotExistsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) DeleteTeamByName(teamName string) error {
+	fake.deleteTeamByNameMutex.Lock()
+	fake.deleteTeamByNameArgsForCall = append(fake.deleteTeamByNameArgsForCall, struct {
+		teamName string
+	}{teamName})
+	fake.recordInvocation("DeleteTeamByName", []interface{}{teamName})
+	fake.deleteTeamByNameMutex.Unlock()
+	if fake.DeleteTeamByNameStub != nil {
+		return fake.DeleteTeamByNameStub(teamName)
+	} else {
+		return fake.deleteTeamByNameReturns.result1
+	}
+}
+
+func (fake *FakeDB) DeleteTeamByNameCallCount() int {
+	fake.deleteTeamByNameMutex.RLock()
+	defer fake.deleteTeamByNameMutex.RUnlock()
+	return len(fake.deleteTeamByNameArgsForCall)
+}
+
+func (fake *FakeDB) DeleteTeamByNameArgsForCall(i int) string {
+	fake.deleteTeamByNameMutex.RLock()
+	defer fake.deleteTeamByNameMutex.RUnlock()
+	return fake.deleteTeamByNameArgsForCall[i].teamName
+}
+
+func (fake *FakeDB) DeleteTeamByNameReturns(result1 error) {
+	fake.DeleteTeamByNameStub = nil
+	fake.deleteTeamByNameReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) CreateAPIToken(token db.APIToken, tokenHash string) (db.SavedAPIToken, error) {
+	fake.createAPITokenMutex.Lock()
+	fake.createAPITokenArgsForCall = append(fake.createAPITokenArgsForCall, struct {
+		token     db.APIToken
+		tokenHash string
+	}{token, tokenHash})
+	fake.recordInvocation("CreateAPIToken", []interface{}{token, tokenHash})
+	fake.createAPITokenMutex.Unlock()
+	if fake.CreateAPITokenStub != nil {
+		return fake.CreateAPITokenStub(token, tokenHash)
+	} else {
+		return fake.createAPITokenReturns.result1, fake.createAPITokenReturns.result2
+	}
+}
+
+func (fake *FakeDB) CreateAPITokenCallCount() int {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	return len(fake.createAPITokenArgsForCall)
+}
+
+func (fake *FakeDB) CreateAPITokenArgsForCall(i int) (db.APIToken, string) {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	return fake.createAPITokenArgsForCall[i].token, fake.createAPITokenArgsForCall[i].tokenHash
+}
+
+func (fake *FakeDB) CreateAPITokenReturns(result1 db.SavedAPIToken, result2 error) {
+	fake.CreateAPITokenStub = nil
+	fake.createAPITokenReturns = struct {
+		result1 db.SavedAPIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetAPITokens(teamName string) ([]db.SavedAPIToken, error) {
+	fake.getAPITokensMutex.Lock()
+	fake.getAPITokensArgsForCall = append(fake.getAPITokensArgsForCall, struct {
+		teamName string
+	}{teamName})
+	fake.recordInvocation("GetAPITokens", []interface{}{teamName})
+	fake.getAPITokensMutex.Unlock()
+	if fake.GetAPITokensStub != nil {
+		return fake.GetAPITokensStub(teamName)
+	} else {
+		return fake.getAPITokensReturns.result1, fake.getAPITokensReturns.result2
+	}
+}
+
+func (fake *FakeDB) GetAPITokensCallCount() int {
+	fake.getAPITokensMutex.RLock()
+	defer fake.getAPITokensMutex.RUnlock()
+	return len(fake.getAPITokensArgsForCall)
+}
+
+func (fake *FakeDB) GetAPITokensArgsForCall(i int) string {
+	fake.getAPITokensMutex.RLock()
+	defer fake.getAPITokensMutex.RUnlock()
+	return fake.getAPITokensArgsForCall[i].teamName
+}
+
+func (fake *FakeDB) GetAPITokensReturns(result1 []db.SavedAPIToken, result2 error) {
+	fake.GetAPITokensStub = nil
+	fake.getAPITokensReturns = struct {
+		result1 []db.SavedAPIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) DeleteAPIToken(teamName string, tokenName string) (bool, error) {
+	fake.deleteAPITokenMutex.Lock()
+	fake.deleteAPITokenArgsForCall = append(fake.deleteAPITokenArgsForCall, struct {
+		teamName  string
+		tokenName string
+	}{teamName, tokenName})
+	fake.recordInvocation("DeleteAPIToken", []interface{}{teamName, tokenName})
+	fake.deleteAPITokenMutex.Unlock()
+	if fake.DeleteAPITokenStub != nil {
+		return fake.DeleteAPITokenStub(teamName, tokenName)
+	} else {
+		return fake.deleteAPITokenReturns.result1, fake.deleteAPITokenReturns.result2
+	}
+}
+
+func (fake *FakeDB) DeleteAPITokenCallCount() int {
+	fake.deleteAPITokenMutex.RLock()
+	defer fake.deleteAPITokenMutex.RUnlock()
+	return len(fake.deleteAPITokenArgsForCall)
+}
+
+func (fake *FakeDB) DeleteAPITokenArgsForCall(i int) (string, string) {
+	fake.deleteAPITokenMutex.RLock()
+	defer fake.deleteAPITokenMutex.RUnlock()
+	return fake.deleteAPITokenArgsForCall[i].teamName, fake.deleteAPITokenArgsForCall[i].tokenName
+}
+
+func (fake *FakeDB) DeleteAPITokenReturns(result1 bool, result2 error) {
+	fake.DeleteAPITokenStub = nil
+	fake.deleteAPITokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) FindAPITokenByHash(tokenHash string) (db.SavedAPIToken, bool, error) {
+	fake.findAPITokenByHashMutex.Lock()
+	fake.findAPITokenByHashArgsForCall = append(fake.findAPITokenByHashArgsForCall, struct {
+		tokenHash string
+	}{tokenHash})
+	fake.recordInvocation("FindAPITokenByHash", []interface{}{tokenHash})
+	fake.findAPITokenByHashMutex.Unlock()
+	if fake.FindAPITokenByHashStub != nil {
+		return fake.FindAPITokenByHashStub(tokenHash)
+	} else {
+		return fake.findAPITokenByHashReturns.result1, fake.findAPITokenByHashReturns.result2, fake.findAPITokenByHashReturns.result3
+	}
+}
+
+func (fake *FakeDB) FindAPITokenByHashCallCount() int {
+	fake.findAPITokenByHashMutex.RLock()
+	defer fake.findAPITokenByHashMutex.RUnlock()
+	return len(fake.findAPITokenByHashArgsForCall)
+}
+
+func (fake *FakeDB) FindAPITokenByHashArgsForCall(i int) string {
+	fake.findAPITokenByHashMutex.RLock()
+	defer fake.findAPITokenByHashMutex.RUnlock()
+	return fake.findAPITokenByHashArgsForCall[i].tokenHash
+}
+
+func (fake *FakeDB) FindAPITokenByHashReturns(result1 db.SavedAPIToken, result2 bool, result3 error) {
+	fake.FindAPITokenByHashStub = nil
+	fake.findAPITokenByHashReturns = struct {
+		result1 db.SavedAPIToken
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) UpdateAPITokenLastUsed(tokenID int) error {
+	fake.updateAPITokenLastUsedMutex.Lock()
+	fake.updateAPITokenLastUsedArgsForCall = append(fake.updateAPITokenLastUsedArgsForCall, struct {
+		tokenID int
+	}{tokenID})
+	fake.recordInvocation("UpdateAPITokenLastUsed", []interface{}{tokenID})
+	fake.updateAPITokenLastUsedMutex.Unlock()
+	if fake.UpdateAPITokenLastUsedStub != nil {
+		return fake.UpdateAPITokenLastUsedStub(tokenID)
+	} else {
+		return fake.updateAPITokenLastUsedReturns.result1
+	}
+}
+
+func (fake *FakeDB) UpdateAPITokenLastUsedCallCount() int {
+	fake.updateAPITokenLastUsedMutex.RLock()
+	defer fake.updateAPITokenLastUsedMutex.RUnlock()
+	return len(fake.updateAPITokenLastUsedArgsForCall)
+}
+
+func (fake *FakeDB) UpdateAPITokenLastUsedArgsForCall(i int) int {
+	fake.updateAPITokenLastUsedMutex.RLock()
+	defer fake.updateAPITokenLastUsedMutex.RUnlock()
+	return fake.updateAPITokenLastUsedArgsForCall[i].tokenID
+}
+
+func (fake *FakeDB) UpdateAPITokenLastUsedReturns(result1 error) {
+	fake.UpdateAPITokenLastUsedStub = nil
+	fake.updateAPITokenLastUsedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SaveAuditEvent(event db.AuditEvent) error {
+	fake.saveAuditEventMutex.Lock()
+	fake.saveAuditEventArgsForCall = append(fake.saveAuditEventArgsForCall, struct {
+		event db.AuditEvent
+	}{event})
+	fake.recordInvocation("SaveAuditEvent", []interface{}{event})
+	fake.saveAuditEventMutex.Unlock()
+	if fake.SaveAuditEventStub != nil {
+		return fake.SaveAuditEventStub(event)
+	} else {
+		return fake.saveAuditEventReturns.result1
+	}
+}
+
+func (fake *FakeDB) SaveAuditEventCallCount() int {
+	fake.saveAuditEventMutex.RLock()
+	defer fake.saveAuditEventMutex.RUnlock()
+	return len(fake.saveAuditEventArgsForCall)
+}
+
+func (fake *FakeDB) SaveAuditEventArgsForCall(i int) db.AuditEvent {
+	fake.saveAuditEventMutex.RLock()
+	defer fake.saveAuditEventMutex.RUnlock()
+	return fake.saveAuditEventArgsForCall[i].event
+}
+
+func (fake *FakeDB) SaveAuditEventReturns(result1 error) {
+	fake.SaveAuditEventStub = nil
+	fake.saveAuditEventReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) GetAuditEvents(teamID int, page db.Page) ([]db.SavedAuditEvent, db.Pagination, error) {
+	fake.getAuditEventsMutex.Lock()
+	fake.getAuditEventsArgsForCall = append(fake.getAuditEventsArgsForCall, struct {
+		teamID int
+		page   db.Page
+	}{teamID, page})
+	fake.recordInvocation("GetAuditEvents", []interface{}{teamID, page})
+	fake.getAuditEventsMutex.Unlock()
+	if fake.GetAuditEventsStub != nil {
+		return fake.GetAuditEventsStub(teamID, page)
+	} else {
+		return fake.getAuditEventsReturns.result1, fake.getAuditEventsReturns.result2, fake.getAuditEventsReturns.result3
+	}
+}
+
+func (fake *FakeDB) GetAuditEventsCallCount() int {
+	fake.getAuditEventsMutex.RLock()
+	defer fake.getAuditEventsMutex.RUnlock()
+	return len(fake.getAuditEventsArgsForCall)
+}
+
+func (fake *FakeDB) GetAuditEventsArgsForCall(i int) (int, db.Page) {
+	fake.getAuditEventsMutex.RLock()
+	defer fake.getAuditEventsMutex.RUnlock()
+	return fake.getAuditEventsArgsForCall[i].teamID, fake.getAuditEventsArgsForCall[i].page
+}
+
+func (fake *FakeDB) GetAuditEventsReturns(result1 []db.SavedAuditEvent, result2 db.Pagination, result3 error) {
+	fake.GetAuditEventsStub = nil
+	fake.getAuditEventsReturns = struct {
+		result1 []db.SavedAuditEvent
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) GetBuild(buildID int) (db.Build, bool, error) {
+	fake.getBuildMutex.Lock()
+	fake.getBuildArgsForCall = append(fake.getBuildArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.recordInvocation("GetBuild", []interface{}{buildID})
+	fake.getBuildMutex.Unlock()
+	if fake.GetBuildStub != nil {
+		return fake.GetBuildStub(buildID)
+	} else {
+		return fake.getBuildReturns.result1, fake.getBuildReturns.result2, fake.getBuildReturns.result3
+	}
+}
+
+func (fake *FakeDB) GetBuildCallCount() int {
+	fake.getBuildMutex.RLock()
+	defer fake.getBuildMutex.RUnlock()
+	return len(fake.getBuildArgsForCall)
+}
+
+func (fake *FakeDB) GetBuildArgsForCall(i int) int {
+	fake.getBuildMutex.RLock()
+	defer fake.getBuildMutex.RUnlock()
+	return fake.getBuildArgsForCall[i].buildID
+}
+
+func (fake *FakeDB) GetBuildReturns(result1 db.Build, result2 bool, result3 error) {
+	fake.GetBuildStub = nil
+	fake.getBuildReturns = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) GetBuildVersionedResources(buildID int) (db.SavedVersionedResources, error) {
+	fake.getBuildVersionedResourcesMutex.Lock()
+	fake.getBuildVersionedResourcesArgsForCall = append(fake.getBuildVersionedResourcesArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.recordInvocation("GetBuildVersionedResources", []interface{}{buildID})
+	fake.getBuildVersionedResourcesMutex.Unlock()
+	if fake.GetBuildVersionedResourcesStub != nil {
+		return fake.GetBuildVersionedResourcesStub(buildID)
+	} else {
+		return fake.getBuildVersionedResourcesReturns.result1, fake.getBuildVersionedResourcesReturns.result2
+	}
+}
+
+func (fake *FakeDB) GetBuildVersionedResourcesCallCount() int {
+	fake.getBuildVersionedResourcesMutex.RLock()
+	defer fake.getBuildVersionedResourcesMutex.RUnlock()
+	return len(fake.getBuildVersionedResourcesArgsForCall)
+}
+
+func (fake *FakeDB) GetBuildVersionedResourcesArgsForCall(i int) int {
+	fake.getBuildVersionedResourcesMutex.RLock()
+	defer fake.getBuildVersionedResourcesMutex.RUnlock()
+	return fake.getBuildVersionedResourcesArgsForCall[i].buildID
+}
+
+func (fake *FakeDB) GetBuildVersionedResourcesReturns(result1 db.SavedVersionedResources, result2 error) {
+	fake.GetBuildVersionedResourcesStub = nil
+	fake.getBuildVersionedResourcesReturns = struct {
+		result1 db.SavedVersionedResources
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetBuildResources(buildID int) ([]db.BuildInput, []db.BuildOutput, error) {
+	fake.getBuildResourcesMutex.Lock()
+	fake.getBuildResourcesArgsForCall = append(fake.getBuildResourcesArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.recordInvocation("GetBuildResources", []interface{}{buildID})
+	fake.getBuildResourcesMutex.Unlock()
+	if fake.GetBuildResourcesStub != nil {
+		return fake.GetBuildResourcesStub(buildID)
+	} else {
+		return fake.getBuildResourcesReturns.result1, fake.getBuildResourcesReturns.result2, fake.getBuildResourcesReturns.result3
+	}
+}
+
+func (fake *FakeDB) GetBuildResourcesCallCount() int {
+	fake.getBuildResourcesMutex.RLock()
+	defer fake.getBuildResourcesMutex.RUnlock()
+	return len(fake.getBuildResourcesArgsForCall)
+}
+
+func (fake *FakeDB) GetBuildResourcesArgsForCall(i int) int {
+	fake.getBuildResourcesMutex.RLock()
+	defer fake.getBuildResourcesMutex.RUnlock()
+	return fake.getBuildResourcesArgsForCall[i].buildID
+}
+
+func (fake *FakeDB) GetBuildResourcesReturns(result1 []db.BuildInput, result2 []db.BuildOutput, result3 error) {
+	fake.GetBuildResourcesStub = nil
+	fake.getBuildResourcesReturns = struct {
+		result1 []db.BuildInput
+		result2 []db.BuildOutput
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) GetBuilds(arg1 db.Page) ([]db.Build, db.Pagination, error) {
+	fake.getBuildsMutex.Lock()
+	fake.getBuildsArgsForCall = append(fake.getBuildsArgsForCall, struct {
+		arg1 db.Page
+	}{arg1})
+	fake.recordInvocation("GetBuilds", []interface{}{arg1})
+	fake.getBuildsMutex.Unlock()
+	if fake.GetBuildsStub != nil {
+		return fake.GetBuildsStub(arg1)
+	} else {
+		return fake.getBuildsReturns.result1, fake.getBuildsReturns.result2, fake.getBuildsReturns.result3
+	}
+}
+
+func (fake *FakeDB) GetBuildsCallCount() int {
+	fake.getBuildsMutex.RLock()
+	defer fake.getBuildsMutex.RUnlock()
+	return len(fake.getBuildsArgsForCall)
+}
+
+func (fake *FakeDB) GetBuildsArgsForCall(i int) db.Page {
+	fake.getBuildsMutex.RLock()
+	defer fake.getBuildsMutex.RUnlock()
+	return fake.getBuildsArgsForCall[i].arg1
+}
+
+func (fake *FakeDB) GetBuildsReturns(result1 []db.Build, result2 db.Pagination, result3 error) {
+	fake.GetBuildsStub = nil
+	fake.getBuildsReturns = struct {
+		result1 []db.Build
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) GetTeamBuilds(teamID int, page db.Page) ([]db.Build, db.Pagination, error) {
+	fake.getTeamBuildsMutex.Lock()
+	fake.getTeamBuildsArgsForCall = append(fake.getTeamBuildsArgsForCall, struct {
+		teamID int
+		page   db.Page
+	}{teamID, page})
+	fake.recordInvocation("GetTeamBuilds", []interface{}{teamID, page})
+	fake.getTeamBuildsMutex.Unlock()
+	if fake.GetTeamBuildsStub != nil {
+		return fake.GetTeamBuildsStub(teamID, page)
+	} else {
+		return fake.getTeamBuildsReturns.result1, fake.getTeamBuildsReturns.result2, fake.getTeamBuildsReturns.result3
+	}
+}
+
+func (fake *FakeDB) GetTeamBuildsCallCount() int {
+	fake.getTeamBuildsMutex.RLock()
+	defer fake.getTeamBuildsMutex.RUnlock()
+	return len(fake.getTeamBuildsArgsForCall)
+}
+
+func (fake *FakeDB) GetTeamBuildsArgsForCall(i int) (int, db.Page) {
+	fake.getTeamBuildsMutex.RLock()
+	defer fake.getTeamBuildsMutex.RUnlock()
+	return fake.getTeamBuildsArgsForCall[i].teamID, fake.getTeamBuildsArgsForCall[i].page
+}
+
+func (fake *FakeDB) GetTeamBuildsReturns(result1 []db.Build, result2 db.Pagination, result3 error) {
+	fake.GetTeamBuildsStub = nil
+	fake.getTeamBuildsReturns = struct {
+		result1 []db.Build
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) GetAllStartedBuilds() ([]db.Build, error) {
+	fake.getAllStartedBuildsMutex.Lock()
+	fake.getAllStartedBuildsArgsForCall = append(fake.getAllStartedBuildsArgsForCall, struct{}{})
+	fake.recordInvocation("GetAllStartedBuilds", []interface{}{})
+	fake.getAllStartedBuildsMutex.Unlock()
+	if fake.GetAllStartedBuildsStub != nil {
+		return fake.GetAllStartedBuildsStub()
+	} else {
+		return fake.getAllStartedBuildsReturns.result1, fake.getAllStartedBuildsReturns.result2
+	}
+}
+
+func (fake *FakeDB) GetAllStartedBuildsCallCount() int {
+	fake.getAllStartedBuildsMutex.RLock()
+	defer fake.getAllStartedBuildsMutex.RUnlock()
+	return len(fake.getAllStartedBuildsArgsForCall)
+}
+
+func (fake *FakeDB) GetAllStartedBuildsReturns(result1 []db.Build, result2 error) {
+	fake.GetAllStartedBuildsStub = nil
+	fake.getAllStartedBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) FindJobIDForBuild(buildID int) (int, bool, error) {
+	fake.findJobIDForBuildMutex.Lock()
+	fake.findJobIDForBuildArgsForCall = append(fake.findJobIDForBuildArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.recordInvocation("FindJobIDForBuild", []interface{}{buildID})
+	fake.findJobIDForBuildMutex.Unlock()
+	if fake.FindJobIDForBuildStub != nil {
+		return fake.FindJobIDForBuildStub(buildID)
+	} else {
+		return fake.findJobIDForBuildReturns.result1, fake.findJobIDForBuildReturns.result2, fake.findJobIDForBuildReturns.result3
+	}
+}
+
+func (fake *FakeDB) FindJobIDForBuildCallCount() int {
+	fake.findJobIDForBuildMutex.RLock()
+	defer fake.findJobIDForBuildMutex.RUnlock()
+	return len(fake.findJobIDForBuildArgsForCall)
+}
+
+func (fake *FakeDB) FindJobIDForBuildArgsForCall(i int) int {
+	fake.findJobIDForBuildMutex.RLock()
+	defer fake.findJobIDForBuildMutex.RUnlock()
+	return fake.findJobIDForBuildArgsForCall[i].buildID
+}
+
+func (fake *FakeDB) FindJobIDForBuildReturns(result1 int, result2 bool, result3 error) {
+	fake.FindJobIDForBuildStub = nil
+	fake.findJobIDForBuildReturns = struct {
+		result1 int
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) CreatePipe(pipeGUID string, url string) error {
+	fake.createPipeMutex.Lock()
+	fake.createPipeArgsForCall = append(fake.createPipeArgsForCall, struct {
+		pipeGUID string
+		url      string
+	}{pipeGUID, url})
+	fake.recordInvocation("CreatePipe", []interface{}{pipeGUID, url})
+	fake.createPipeMutex.Unlock()
+	if fake.CreatePipeStub != nil {
+		return fake.CreatePipeStub(pipeGUID, url)
+	} else {
+		return fake.createPipeReturns.result1
+	}
+}
+
+func (fake *FakeDB) CreatePipeCallCount() int {
+	fake.createPipeMutex.RLock()
+	defer fake.createPipeMutex.RUnlock()
+	return len(fake.createPipeArgsForCall)
+}
+
+func (fake *FakeDB) CreatePipeArgsForCall(i int) (string, string) {
+	fake.createPipeMutex.RLock()
+	defer fake.createPipeMutex.RUnlock()
+	return fake.createPipeArgsForCall[i].pipeGUID, fake.createPipeArgsForCall[i].url
+}
+
+func (fake *FakeDB) CreatePipeReturns(result1 error) {
+	fake.CreatePipeStub = nil
+	fake.createPipeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) GetPipe(pipeGUID string) (db.Pipe, error) {
+	fake.getPipeMutex.Lock()
+	fake.getPipeArgsForCall = append(fake.getPipeArgsForCall, struct {
+		pipeGUID string
+	}{pipeGUID})
+	fake.recordInvocation("GetPipe", []interface{}{pipeGUID})
+	fake.getPipeMutex.Unlock()
+	if fake.GetPipeStub != nil {
+		return fake.GetPipeStub(pipeGUID)
+	} else {
+		return fake.getPipeReturns.result1, fake.getPipeReturns.result2
+	}
+}
+
+func (fake *FakeDB) GetPipeCallCount() int {
+	fake.getPipeMutex.RLock()
+	defer fake.getPipeMutex.RUnlock()
+	return len(fake.getPipeArgsForCall)
+}
+
+func (fake *FakeDB) GetPipeArgsForCall(i int) string {
+	fake.getPipeMutex.RLock()
+	defer fake.getPipeMutex.RUnlock()
+	return fake.getPipeArgsForCall[i].pipeGUID
+}
+
+func (fake *FakeDB) GetPipeReturns(result1 db.Pipe, result2 error) {
+	fake.GetPipeStub = nil
+	fake.getPipeReturns = struct {
+		result1 db.Pipe
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) CreateOneOffBuild(teamName string) (db.Build, error) {
+	fake.createOneOffBuildMutex.Lock()
+	fake.createOneOffBuildArgsForCall = append(fake.createOneOffBuildArgsForCall, struct {
+		teamName string
+	}{teamName})
+	fake.recordInvocation("CreateOneOffBuild", []interface{}{teamName})
+	fake.createOneOffBuildMutex.Unlock()
+	if fake.CreateOneOffBuildStub != nil {
+		return fake.CreateOneOffBuildStub(teamName)
+	} else {
+		return fake.createOneOffBuildReturns.result1, fake.createOneOffBuildReturns.result2
+	}
+}
+
+func (fake *FakeDB) CreateOneOffBuildCallCount() int {
+	fake.createOneOffBuildMutex.RLock()
+	defer fake.createOneOffBuildMutex.RUnlock()
+	return len(fake.createOneOffBuildArgsForCall)
+}
+
+func (fake *FakeDB) CreateOneOffBuildArgsForCall(i int) string {
+	fake.createOneOffBuildMutex.RLock()
+	defer fake.createOneOffBuildMutex.RUnlock()
+	return fake.createOneOffBuildArgsForCall[i].teamName
+}
+
+func (fake *FakeDB) CreateOneOffBuildReturns(result1 db.Build, result2 error) {
+	fake.CreateOneOffBuildStub = nil
+	fake.createOneOffBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetBuildPreparation(buildID int) (db.BuildPreparation, bool, error) {
+	fake.getBuildPreparationMutex.Lock()
+	fake.getBuildPreparationArgsForCall = append(fake.getBuildPreparationArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.recordInvocation("GetBuildPreparation", []interface{}{buildID})
+	fake.getBuildPreparationMutex.Unlock()
+	if fake.GetBuildPreparationStub != nil {
+		return fake.GetBuildPreparationStub(buildID)
+	} else {
+		return fake.getBuildPreparationReturns.result1, fake.getBuildPreparationReturns.result2, fake.getBuildPreparationReturns.result3
+	}
+}
+
+func (fake *FakeDB) GetBuildPreparationCallCount() int {
+	fake.getBuildPreparationMutex.RLock()
+	defer fake.getBuildPreparationMutex.RUnlock()
+	return len(fake.getBuildPreparationArgsForCall)
+}
+
+func (fake *FakeDB) GetBuildPreparationArgsForCall(i int) int {
+	fake.getBuildPreparationMutex.RLock()
+	defer fake.getBuildPreparationMutex.RUnlock()
+	return fake.getBuildPreparationArgsForCall[i].buildID
+}
+
+func (fake *FakeDB) GetBuildPreparationReturns(result1 db.BuildPreparation, result2 bool, result3 error) {
+	fake.GetBuildPreparationStub = nil
+	fake.getBuildPreparationReturns = struct {
+		result1 db.BuildPreparation
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) UpdateBuildPreparation(buildPreparation db.BuildPreparation) error {
+	fake.updateBuildPreparationMutex.Lock()
+	fake.updateBuildPreparationArgsForCall = append(fake.updateBuildPreparationArgsForCall, struct {
+		buildPreparation db.BuildPreparation
+	}{buildPreparation})
+	fake.recordInvocation("UpdateBuildPreparation", []interface{}{buildPreparation})
+	fake.updateBuildPreparationMutex.Unlock()
+	if fake.UpdateBuildPreparationStub != nil {
+		return fake.UpdateBuildPreparationStub(buildPreparation)
+	} else {
+		return fake.updateBuildPreparationReturns.result1
+	}
+}
+
+func (fake *FakeDB) UpdateBuildPreparationCallCount() int {
+	fake.updateBuildPreparationMutex.RLock()
+	defer fake.updateBuildPreparationMutex.RUnlock()
+	return len(fake.updateBuildPreparationArgsForCall)
+}
+
+func (fake *FakeDB) UpdateBuildPreparationArgsForCall(i int) db.BuildPreparation {
+	fake.updateBuildPreparationMutex.RLock()
+	defer fake.updateBuildPreparationMutex.RUnlock()
+	return fake.updateBuildPreparationArgsForCall[i].buildPreparation
+}
+
+func (fake *FakeDB) UpdateBuildPreparationReturns(result1 error) {
+	fake.UpdateBuildPreparationStub = nil
+	fake.updateBuildPreparationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) UpdateBuildPreparationWorkersAvailable(buildID int, status db.BuildPreparationStatus) error {
+	fake.updateBuildPreparationWorkersAvailableMutex.Lock()
+	fake.updateBuildPreparationWorkersAvailableArgsForCall = append(fake.updateBuildPreparationWorkersAvailableArgsForCall, struct {
+		buildID int
+		status  db.BuildPreparationStatus
+	}{buildID, status})
+	fake.recordInvocation("UpdateBuildPreparationWorkersAvailable", []interface{}{buildID, status})
+	fake.updateBuildPreparationWorkersAvailableMutex.Unlock()
+	if fake.UpdateBuildPreparationWorkersAvailableStub != nil {
+		return fake.UpdateBuildPreparationWorkersAvailableStub(buildID, status)
+	} else {
+		return fake.updateBuildPreparationWorkersAvailableReturns.result1
+	}
+}
+
+func (fake *FakeDB) UpdateBuildPreparationWorkersAvailableCallCount() int {
+	fake.updateBuildPreparationWorkersAvailableMutex.RLock()
+	defer fake.updateBuildPreparationWorkersAvailableMutex.RUnlock()
+	return len(fake.updateBuildPreparationWorkersAvailableArgsForCall)
+}
+
+func (fake *FakeDB) UpdateBuildPreparationWorkersAvailableArgsForCall(i int) (int, db.BuildPreparationStatus) {
+	fake.updateBuildPreparationWorkersAvailableMutex.RLock()
+	defer fake.updateBuildPreparationWorkersAvailableMutex.RUnlock()
+	return fake.updateBuildPreparationWorkersAvailableArgsForCall[i].buildID, fake.updateBuildPreparationWorkersAvailableArgsForCall[i].status
+}
+
+func (fake *FakeDB) UpdateBuildPreparationWorkersAvailableReturns(result1 error) {
+	fake.UpdateBuildPreparationWorkersAvailableStub = nil
+	fake.updateBuildPreparationWorkersAvailableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) ResetBuildPreparationsWithPipelinePaused(pipelineID int) error {
+	fake.resetBuildPreparationsWithPipelinePausedMutex.Lock()
+	fake.resetBuildPreparationsWithPipelinePausedArgsForCall = append(fake.resetBuildPreparationsWithPipelinePausedArgsForCall, struct {
+		pipelineID int
+	}{pipelineID})
+	fake.recordInvocation("ResetBuildPreparationsWithPipelinePaused", []interface{}{pipelineID})
+	fake.resetBuildPreparationsWithPipelinePausedMutex.Unlock()
+	if fake.ResetBuildPreparationsWithPipelinePausedStub != nil {
+		return fake.ResetBuildPreparationsWithPipelinePausedStub(pipelineID)
+	} else {
+		return fake.resetBuildPreparationsWithPipelinePausedReturns.result1
+	}
+}
+
+func (fake *FakeDB) ResetBuildPreparationsWithPipelinePausedCallCount() int {
+	fake.resetBuildPreparationsWithPipelinePausedMutex.RLock()
+	defer fake.resetBuildPreparationsWithPipelinePausedMutex.RUnlock()
+	return len(fake.resetBuildPreparationsWithPipelinePausedArgsForCall)
+}
+
+func (fake *FakeDB) ResetBuildPreparationsWithPipelinePausedArgsForCall(i int) int {
+	fake.resetBuildPreparationsWithPipelinePausedMutex.RLock()
+	defer fake.resetBuildPreparationsWithPipelinePausedMutex.RUnlock()
+	return fake.resetBuildPreparationsWithPipelinePausedArgsForCall[i].pipelineID
+}
+
+func (fake *FakeDB) ResetBuildPreparationsWithPipelinePausedReturns(result1 error) {
+	fake.ResetBuildPreparationsWithPipelinePausedStub = nil
+	fake.resetBuildPreparationsWithPipelinePausedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) LeaseBuildTracking(logger lager.Logger, buildID int, interval time.Duration) (db.Lease, bool, error) {
+	fake.leaseBuildTrackingMutex.Lock()
+	fake.leaseBuildTrackingArgsForCall = append(fake.leaseBuildTrackingArgsForCall, struct {
+		logger   lager.Logger
+		buildID  int
+		interval time.Duration
+	}{logger, buildID, interval})
+	fake.recordInvocation("LeaseBuildTracking", []interface{}{logger, buildID, interval})
+	fake.leaseBuildTrackingMutex.Unlock()
+	if fake.LeaseBuildTrackingStub != nil {
+		return fake.LeaseBuildTrackingStub(logger, buildID, interval)
+	} else {
+		return fake.leaseBuildTrackingReturns.result1, fake.leaseBuildTrackingReturns.result2, fake.leaseBuildTrackingReturns.result3
+	}
+}
+
+func (fake *FakeDB) LeaseBuildTrackingCallCount() int {
+	fake.leaseBuildTrackingMutex.RLock()
+	defer fake.leaseBuildTrackingMutex.RUnlock()
+	return len(fake.leaseBuildTrackingArgsForCall)
+}
+
+func (fake *FakeDB) LeaseBuildTrackingArgsForCall(i int) (lager.Logger, int, time.Duration) {
+	fake.leaseBuildTrackingMutex.RLock()
+	defer fake.leaseBuildTrackingMutex.RUnlock()
+	return fake.leaseBuildTrackingArgsForCall[i].logger, fake.leaseBuildTrackingArgsForCall[i].buildID, fake.leaseBuildTrackingArgsForCall[i].interval
+}
+
+func (fake *FakeDB) LeaseBuildTrackingReturns(result1 db.Lease, result2 bool, result3 error) {
+	fake.LeaseBuildTrackingStub = nil
+	fake.leaseBuildTrackingReturns = struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) LeaseBuildScheduling(logger lager.Logger, buildID int, interval time.Duration) (db.Lease, bool, error) {
+	fake.leaseBuildSchedulingMutex.Lock()
+	fake.leaseBuildSchedulingArgsForCall = append(fake.leaseBuildSchedulingArgsForCall, struct {
+		logger   lager.Logger
+		buildID  int
+		interval time.Duration
+	}{logger, buildID, interval})
+	fake.recordInvocation("LeaseBuildScheduling", []interface{}{logger, buildID, interval})
+	fake.leaseBuildSchedulingMutex.Unlock()
+	if fake.LeaseBuildSchedulingStub != nil {
+		return fake.LeaseBuildSchedulingStub(logger, buildID, interval)
+	} else {
+		return fake.leaseBuildSchedulingReturns.result1, fake.leaseBuildSchedulingReturns.result2, fake.leaseBuildSchedulingReturns.result3
+	}
+}
+
+func (fake *FakeDB) LeaseBuildSchedulingCallCount() int {
+	fake.leaseBuildSchedulingMutex.RLock()
+	defer fake.leaseBuildSchedulingMutex.RUnlock()
+	return len(fake.leaseBuildSchedulingArgsForCall)
+}
+
+func (fake *FakeDB) LeaseBuildSchedulingArgsForCall(i int) (lager.Logger, int, time.Duration) {
+	fake.leaseBuildSchedulingMutex.RLock()
+	defer fake.leaseBuildSchedulingMutex.RUnlock()
+	return fake.leaseBuildSchedulingArgsForCall[i].logger, fake.leaseBuildSchedulingArgsForCall[i].buildID, fake.leaseBuildSchedulingArgsForCall[i].interval
+}
+
+func (fake *FakeDB) LeaseBuildSchedulingReturns(result1 db.Lease, result2 bool, result3 error) {
+	fake.LeaseBuildSchedulingStub = nil
+	fake.leaseBuildSchedulingReturns = struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) GetLease(logger lager.Logger, taskName string, interval time.Duration) (db.Lease, bool, error) {
+	fake.getLeaseMutex.Lock()
+	fake.getLeaseArgsForCall = append(fake.getLeaseArgsForCall, struct {
+		logger   lager.Logger
+		taskName string
+		interval time.Duration
+	}{logger, taskName, interval})
+	fake.recordInvocation("GetLease", []interface{}{logger, taskName, interval})
+	fake.getLeaseMutex.Unlock()
+	if fake.GetLeaseStub != nil {
+		return fake.GetLeaseStub(logger, taskName, interval)
+	} else {
+		return fake.getLeaseReturns.result1, fake.getLeaseReturns.result2, fake.getLeaseReturns.result3
+	}
+}
+
+func (fake *FakeDB) GetLeaseCallCount() int {
+	fake.getLeaseMutex.RLock()
+	defer fake.getLeaseMutex.RUnlock()
+	return len(fake.getLeaseArgsForCall)
+}
+
+func (fake *FakeDB) GetLeaseArgsForCall(i int) (lager.Logger, string, time.Duration) {
+	fake.getLeaseMutex.RLock()
+	defer fake.getLeaseMutex.RUnlock()
+	return fake.getLeaseArgsForCall[i].logger, fake.getLeaseArgsForCall[i].taskName, fake.getLeaseArgsForCall[i].interval
+}
+
+func (fake *FakeDB) GetLeaseReturns(result1 db.Lease, result2 bool, result3 error) {
+	fake.GetLeaseStub = nil
+	fake.getLeaseReturns = struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) StartBuild(buildID int, pipelineID int, engineName string, engineMetadata string) (bool, error) {
+	fake.startBuildMutex.Lock()
+	fake.startBuildArgsForCall = append(fake.startBuildArgsForCall, struct {
+		buildID        int
+		pipelineID     int
+		engineName     string
+		engineMetadata string
+	}{buildID, pipelineID, engineName, engineMetadata})
+	fake.recordInvocation("StartBuild", []interface{}{buildID, pipelineID, engineName, engineMetadata})
+	fake.startBuildMutex.Unlock()
+	if fake.StartBuildStub != nil {
+		return fake.StartBuildStub(buildID, pipelineID, engineName, engineMetadata)
+	} else {
+		return fake.startBuildReturns.result1, fake.startBuildReturns.result2
+	}
+}
+
+func (fake *FakeDB) StartBuildCallCount() int {
+	fake.startBuildMutex.RLock()
+	defer fake.startBuildMutex.RUnlock()
+	return len(fake.startBuildArgsForCall)
+}
+
+func (fake *FakeDB) StartBuildArgsForCall(i int) (int, int, string, string) {
+	fake.startBuildMutex.RLock()
+	defer fake.startBuildMutex.RUnlock()
+	return fake.startBuildArgsForCall[i].buildID, fake.startBuildArgsForCall[i].pipelineID, fake.startBuildArgsForCall[i].engineName, fake.startBuildArgsForCall[i].engineMetadata
+}
+
+func (fake *FakeDB) StartBuildReturns(result1 bool, result2 error) {
+	fake.StartBuildStub = nil
+	fake.startBuildReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) FinishBuild(buildID int, pipelineID int, status db.Status) error {
+	fake.finishBuildMutex.Lock()
+	fake.finishBuildArgsForCall = append(fake.finishBuildArgsForCall, struct {
+		buildID    int
+		pipelineID int
+		status     db.Status
+	}{buildID, pipelineID, status})
+	fake.recordInvocation("FinishBuild", []interface{}{buildID, pipelineID, status})
+	fake.finishBuildMutex.Unlock()
+	if fake.FinishBuildStub != nil {
+		return fake.FinishBuildStub(buildID, pipelineID, status)
+	} else {
+		return fake.finishBuildReturns.result1
+	}
+}
+
+func (fake *FakeDB) FinishBuildCallCount() int {
+	fake.finishBuildMutex.RLock()
+	defer fake.finishBuildMutex.RUnlock()
+	return len(fake.finishBuildArgsForCall)
+}
+
+func (fake *FakeDB) FinishBuildArgsForCall(i int) (int, int, db.Status) {
+	fake.finishBuildMutex.RLock()
+	defer fake.finishBuildMutex.RUnlock()
+	return fake.finishBuildArgsForCall[i].buildID, fake.finishBuildArgsForCall[i].pipelineID, fake.finishBuildArgsForCall[i].status
+}
+
+func (fake *FakeDB) FinishBuildReturns(result1 error) {
+	fake.FinishBuildStub = nil
+	fake.finishBuildReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) ErrorBuild(buildID int, pipelineID int, cause error) error {
+	fake.errorBuildMutex.Lock()
+	fake.errorBuildArgsForCall = append(fake.errorBuildArgsForCall, struct {
+		buildID    int
+		pipelineID int
+		cause      error
+	}{buildID, pipelineID, cause})
+	fake.recordInvocation("ErrorBuild", []interface{}{buildID, pipelineID, cause})
+	fake.errorBuildMutex.Unlock()
+	if fake.ErrorBuildStub != nil {
+		return fake.ErrorBuildStub(buildID, pipelineID, cause)
+	} else {
+		return fake.errorBuildReturns.result1
+	}
+}
+
+func (fake *FakeDB) ErrorBuildCallCount() int {
+	fake.errorBuildMutex.RLock()
+	defer fake.errorBuildMutex.RUnlock()
+	return len(fake.errorBuildArgsForCall)
+}
+
+func (fake *FakeDB) ErrorBuildArgsForCall(i int) (int, int, error) {
+	fake.errorBuildMutex.RLock()
+	defer fake.errorBuildMutex.RUnlock()
+	return fake.errorBuildArgsForCall[i].buildID, fake.errorBuildArgsForCall[i].pipelineID, fake.errorBuildArgsForCall[i].cause
+}
+
+func (fake *FakeDB) ErrorBuildReturns(result1 error) {
+	fake.ErrorBuildStub = nil
+	fake.errorBuildReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SaveBuildInput(buildID int, input db.BuildInput) (db.SavedVersionedResource, error) {
+	fake.saveBuildInputMutex.Lock()
+	fake.saveBuildInputArgsForCall = append(fake.saveBuildInputArgsForCall, struct {
+		buildID int
+		input   db.BuildInput
+	}{buildID, input})
+	fake.recordInvocation("SaveBuildInput", []interface{}{buildID, input})
+	fake.saveBuildInputMutex.Unlock()
+	if fake.SaveBuildInputStub != nil {
+		return fake.SaveBuildInputStub(buildID, input)
+	} else {
+		return fake.saveBuildInputReturns.result1, fake.saveBuildInputReturns.result2
+	}
+}
+
+func (fake *FakeDB) SaveBuildInputCallCount() int {
+	fake.saveBuildInputMutex.RLock()
+	defer fake.saveBuildInputMutex.RUnlock()
+	return len(fake.saveBuildInputArgsForCall)
+}
+
+func (fake *FakeDB) SaveBuildInputArgsForCall(i int) (int, db.BuildInput) {
+	fake.saveBuildInputMutex.RLock()
+	defer fake.saveBuildInputMutex.RUnlock()
+	return fake.saveBuildInputArgsForCall[i].buildID, fake.saveBuildInputArgsForCall[i].input
+}
+
+func (fake *FakeDB) SaveBuildInputReturns(result1 db.SavedVersionedResource, result2 error) {
+	fake.SaveBuildInputStub = nil
+	fake.saveBuildInputReturns = struct {
+		result1 db.SavedVersionedResource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) SaveBuildOutput(buildID int, vr db.VersionedResource, explicit bool) (db.SavedVersionedResource, error) {
+	fake.saveBuildOutputMutex.Lock()
+	fake.saveBuildOutputArgsForCall = append(fake.saveBuildOutputArgsForCall, struct {
+		buildID  int
+		vr       db.VersionedResource
+		explicit bool
+	}{buildID, vr, explicit})
+	fake.recordInvocation("SaveBuildOutput", []interface{}{buildID, vr, explicit})
+	fake.saveBuildOutputMutex.Unlock()
+	if fake.SaveBuildOutputStub != nil {
+		return fake.SaveBuildOutputStub(buildID, vr, explicit)
+	} else {
+		return fake.saveBuildOutputReturns.result1, fake.saveBuildOutputReturns.result2
+	}
+}
+
+func (fake *FakeDB) SaveBuildOutputCallCount() int {
+	fake.saveBuildOutputMutex.RLock()
+	defer fake.saveBuildOutputMutex.RUnlock()
+	return len(fake.saveBuildOutputArgsForCall)
+}
+
+func (fake *FakeDB) SaveBuildOutputArgsForCall(i int) (int, db.VersionedResource, bool) {
+	fake.saveBuildOutputMutex.RLock()
+	defer fake.saveBuildOutputMutex.RUnlock()
+	return fake.saveBuildOutputArgsForCall[i].buildID, fake.saveBuildOutputArgsForCall[i].vr, fake.saveBuildOutputArgsForCall[i].explicit
+}
+
+func (fake *FakeDB) SaveBuildOutputReturns(result1 db.SavedVersionedResource, result2 error) {
+	fake.SaveBuildOutputStub = nil
+	fake.saveBuildOutputReturns = struct {
+		result1 db.SavedVersionedResource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetBuildEvents(buildID int, from uint) (db.EventSource, error) {
+	fake.getBuildEventsMutex.Lock()
+	fake.getBuildEventsArgsForCall = append(fake.getBuildEventsArgsForCall, struct {
+		buildID int
+		from    uint
+	}{buildID, from})
+	fake.recordInvocation("GetBuildEvents", []interface{}{buildID, from})
+	fake.getBuildEventsMutex.Unlock()
+	if fake.GetBuildEventsStub != nil {
+		return fake.GetBuildEventsStub(buildID, from)
+	} else {
+		return fake.getBuildEventsReturns.result1, fake.getBuildEventsReturns.result2
+	}
+}
+
+func (fake *FakeDB) GetBuildEventsCallCount() int {
+	fake.getBuildEventsMutex.RLock()
+	defer fake.getBuildEventsMutex.RUnlock()
+	return len(fake.getBuildEventsArgsForCall)
+}
+
+func (fake *FakeDB) GetBuildEventsArgsForCall(i int) (int, uint) {
+	fake.getBuildEventsMutex.RLock()
+	defer fake.getBuildEventsMutex.RUnlock()
+	return fake.getBuildEventsArgsForCall[i].buildID, fake.getBuildEventsArgsForCall[i].from
+}
+
+func (fake *FakeDB) GetBuildEventsReturns(result1 db.EventSource, result2 error) {
+	fake.GetBuildEventsStub = nil
+	fake.getBuildEventsReturns = struct {
+		result1 db.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) SaveBuildEvent(buildID int, pipelineID int, event atc.Event) error {
+	fake.saveBuildEventMutex.Lock()
+	fake.saveBuildEventArgsForCall = append(fake.saveBuildEventArgsForCall, struct {
+		buildID    int
+		pipelineID int
+		event      atc.Event
+	}{buildID, pipelineID, event})
+	fake.recordInvocation("SaveBuildEvent", []interface{}{buildID, pipelineID, event})
+	fake.saveBuildEventMutex.Unlock()
+	if fake.SaveBuildEventStub != nil {
+		return fake.SaveBuildEventStub(buildID, pipelineID, event)
+	} else {
+		return fake.saveBuildEventReturns.result1
+	}
+}
+
+func (fake *FakeDB) SaveBuildEventCallCount() int {
+	fake.saveBuildEventMutex.RLock()
+	defer fake.saveBuildEventMutex.RUnlock()
+	return len(fake.saveBuildEventArgsForCall)
+}
+
+func (fake *FakeDB) SaveBuildEventArgsForCall(i int) (int, int, atc.Event) {
+	fake.saveBuildEventMutex.RLock()
+	defer fake.saveBuildEventMutex.RUnlock()
+	return fake.saveBuildEventArgsForCall[i].buildID, fake.saveBuildEventArgsForCall[i].pipelineID, fake.saveBuildEventArgsForCall[i].event
+}
+
+func (fake *FakeDB) SaveBuildEventReturns(result1 error) {
+	fake.SaveBuildEventStub = nil
+	fake.saveBuildEventReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
+	var buildIDsCopy []int
+	if buildIDs != nil {
+		buildIDsCopy = make([]int, len(buildIDs))
+		copy(buildIDsCopy, buildIDs)
+	}
+	fake.deleteBuildEventsByBuildIDsMutex.Lock()
+	fake.deleteBuildEventsByBuildIDsArgsForCall = append(fake.deleteBuildEventsByBuildIDsArgsForCall, struct {
+		buildIDs []int
+	}{buildIDsCopy})
+	fake.recordInvocation("DeleteBuildEventsByBuildIDs", []interface{}{buildIDsCopy})
+	fake.deleteBuildEventsByBuildIDsMutex.Unlock()
+	if fake.DeleteBuildEventsByBuildIDsStub != nil {
+		return fake.DeleteBuildEventsByBuildIDsStub(buildIDs)
+	} else {
+		return fake.deleteBuildEventsByBuildIDsReturns.result1
+	}
+}
+
+func (fake *FakeDB) DeleteBuildEventsByBuildIDsCallCount() int {
+	fake.deleteBuildEventsByBuildIDsMutex.RLock()
+	defer fake.deleteBuildEventsByBuildIDsMutex.RUnlock()
+	return len(fake.deleteBuildEventsByBuildIDsArgsForCall)
+}
+
+func (fake *FakeDB) DeleteBuildEventsByBuildIDsArgsForCall(i int) []int {
+	fake.deleteBuildEventsByBuildIDsMutex.RLock()
+	defer fake.deleteBuildEventsByBuildIDsMutex.RUnlock()
+	return fake.deleteBuildEventsByBuildIDsArgsForCall[i].buildIDs
+}
+
+func (fake *FakeDB) DeleteBuildEventsByBuildIDsReturns(result1 error) {
+	fake.DeleteBuildEventsByBuildIDsStub = nil
+	fake.deleteBuildEventsByBuildIDsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SaveBuildEngineMetadata(buildID int, engineMetadata string) error {
+	fake.saveBuildEngineMetadataMutex.Lock()
+	fake.saveBuildEngineMetadataArgsForCall = append(fake.saveBuildEngineMetadataArgsForCall, struct {
+		buildID        int
+		engineMetadata string
+	}{buildID, engineMetadata})
+	fake.recordInvocation("SaveBuildEngineMetadata", []interface{}{buildID, engineMetadata})
+	fake.saveBuildEngineMetadataMutex.Unlock()
+	if fake.SaveBuildEngineMetadataStub != nil {
+		return fake.SaveBuildEngineMetadataStub(buildID, engineMetadata)
+	} else {
+		return fake.saveBuildEngineMetadataReturns.result1
+	}
+}
+
+func (fake *FakeDB) SaveBuildEngineMetadataCallCount() int {
+	fake.saveBuildEngineMetadataMutex.RLock()
+	defer fake.saveBuildEngineMetadataMutex.RUnlock()
+	return len(fake.saveBuildEngineMetadataArgsForCall)
+}
+
+func (fake *FakeDB) SaveBuildEngineMetadataArgsForCall(i int) (int, string) {
+	fake.saveBuildEngineMetadataMutex.RLock()
+	defer fake.saveBuildEngineMetadataMutex.RUnlock()
+	return fake.saveBuildEngineMetadataArgsForCall[i].buildID, fake.saveBuildEngineMetadataArgsForCall[i].engineMetadata
+}
+
+func (fake *FakeDB) SaveBuildEngineMetadataReturns(result1 error) {
+	fake.SaveBuildEngineMetadataStub = nil
+	fake.saveBuildEngineMetadataReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) AbortBuild(buildID int) error {
+	fake.abortBuildMutex.Lock()
+	fake.abortBuildArgsForCall = append(fake.abortBuildArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.recordInvocation("AbortBuild", []interface{}{buildID})
+	fake.abortBuildMutex.Unlock()
+	if fake.AbortBuildStub != nil {
+		return fake.AbortBuildStub(buildID)
+	} else {
+		return fake.abortBuildReturns.result1
+	}
+}
+
+func (fake *FakeDB) AbortBuildCallCount() int {
+	fake.abortBuildMutex.RLock()
+	defer fake.abortBuildMutex.RUnlock()
+	return len(fake.abortBuildArgsForCall)
+}
+
+func (fake *FakeDB) AbortBuildArgsForCall(i int) int {
+	fake.abortBuildMutex.RLock()
+	defer fake.abortBuildMutex.RUnlock()
+	return fake.abortBuildArgsForCall[i].buildID
+}
+
+func (fake *FakeDB) AbortBuildReturns(result1 error) {
+	fake.AbortBuildStub = nil
+	fake.abortBuildReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) AbortNotifier(buildID int) (db.Notifier, error) {
+	fake.abortNotifierMutex.Lock()
+	fake.abortNotifierArgsForCall = append(fake.abortNotifierArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.recordInvocation("AbortNotifier", []interface{}{buildID})
+	fake.abortNotifierMutex.Unlock()
+	if fake.AbortNotifierStub != nil {
+		return fake.AbortNotifierStub(buildID)
+	} else {
+		return fake.abortNotifierReturns.result1, fake.abortNotifierReturns.result2
+	}
+}
+
+func (fake *FakeDB) AbortNotifierCallCount() int {
+	fake.abortNotifierMutex.RLock()
+	defer fake.abortNotifierMutex.RUnlock()
+	return len(fake.abortNotifierArgsForCall)
+}
+
+func (fake *FakeDB) AbortNotifierArgsForCall(i int) int {
+	fake.abortNotifierMutex.RLock()
+	defer fake.abortNotifierMutex.RUnlock()
+	return fake.abortNotifierArgsForCall[i].buildID
+}
+
+func (fake *FakeDB) AbortNotifierReturns(result1 db.Notifier, result2 error) {
+	fake.AbortNotifierStub = nil
+	fake.abortNotifierReturns = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) Workers() ([]db.SavedWorker, error) {
+	fake.workersMutex.Lock()
+	fake.workersArgsForCall = append(fake.workersArgsForCall, struct{}{})
+	fake.recordInvocation("Workers", []interface{}{})
+	fake.workersMutex.Unlock()
+	if fake.WorkersStub != nil {
+		return fake.WorkersStub()
+	} else {
+		return fake.workersReturns.result1, fake.workersReturns.result2
+	}
+}
+
+func (fake *FakeDB) WorkersCallCount() int {
+	fake.workersMutex.RLock()
+	defer fake.workersMutex.RUnlock()
+	return len(fake.workersArgsForCall)
+}
+
+func (fake *FakeDB) WorkersReturns(result1 []db.SavedWorker, result2 error) {
+	fake.WorkersStub = nil
+	fake.workersReturns = struct {
+		result1 []db.SavedWorker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetWorker(workerName string) (db.SavedWorker, bool, error) {
+	fake.getWorkerMutex.Lock()
+	fake.getWorkerArgsForCall = append(fake.getWorkerArgsForCall, struct {
+		workerName string
+	}{workerName})
+	fake.recordInvocation("GetWorker", []interface{}{workerName})
+	fake.getWorkerMutex.Unlock()
+	if fake.GetWorkerStub != nil {
+		return fake.GetWorkerStub(workerName)
+	} else {
+		return fake.getWorkerReturns.result1, fake.getWorkerReturns.result2, fake.getWorkerReturns.result3
+	}
+}
+
+func (fake *FakeDB) GetWorkerCallCount() int {
+	fake.getWorkerMutex.RLock()
+	defer fake.getWorkerMutex.RUnlock()
+	return len(fake.getWorkerArgsForCall)
+}
+
+func (fake *FakeDB) GetWorkerArgsForCall(i int) string {
+	fake.getWorkerMutex.RLock()
+	defer fake.getWorkerMutex.RUnlock()
+	return fake.getWorkerArgsForCall[i].workerName
+}
+
+func (fake *FakeDB) GetWorkerReturns(result1 db.SavedWorker, result2 bool, result3 error) {
+	fake.GetWorkerStub = nil
+	fake.getWorkerReturns = struct {
+		result1 db.SavedWorker
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) SaveWorker(arg1 db.WorkerInfo, arg2 time.Duration) (db.SavedWorker, error) {
+	fake.saveWorkerMutex.Lock()
+	fake.saveWorkerArgsForCall = append(fake.saveWorkerArgsForCall, struct {
+		arg1 db.WorkerInfo
+		arg2 time.Duration
+	}{arg1, arg2})
+	fake.recordInvocation("SaveWorker", []interface{}{arg1, arg2})
+	fake.saveWorkerMutex.Unlock()
+	if fake.SaveWorkerStub != nil {
+		return fake.SaveWorkerStub(arg1, arg2)
+	} else {
+		return fake.saveWorkerReturns.result1, fake.saveWorkerReturns.result2
+	}
+}
+
+func (fake *FakeDB) SaveWorkerCallCount() int {
+	fake.saveWorkerMutex.RLock()
+	defer fake.saveWorkerMutex.RUnlock()
+	return len(fake.saveWorkerArgsForCall)
+}
+
+func (fake *FakeDB) SaveWorkerArgsForCall(i int) (db.WorkerInfo, time.Duration) {
+	fake.saveWorkerMutex.RLock()
+	defer fake.saveWorkerMutex.RUnlock()
+	return fake.saveWorkerArgsForCall[i].arg1, fake.saveWorkerArgsForCall[i].arg2
+}
+
+func (fake *FakeDB) SaveWorkerReturns(result1 db.SavedWorker, result2 error) {
+	fake.SaveWorkerStub = nil
+	fake.saveWorkerReturns = struct {
+		result1 db.SavedWorker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) SaveTeamWorker(arg1 db.WorkerInfo, arg2 time.Duration) (db.SavedWorker, error) {
+	fake.saveTeamWorkerMutex.Lock()
+	fake.saveTeamWorkerArgsForCall = append(fake.saveTeamWorkerArgsForCall, struct {
+		arg1 db.WorkerInfo
+		arg2 time.Duration
+	}{arg1, arg2})
+	fake.recordInvocation("SaveTeamWorker", []interface{}{arg1, arg2})
+	fake.saveTeamWorkerMutex.Unlock()
+	if fake.SaveTeamWorkerStub != nil {
+		return fake.SaveTeamWorkerStub(arg1, arg2)
+	} else {
+		return fake.saveTeamWorkerReturns.result1, fake.saveTeamWorkerReturns.result2
+	}
+}
+
+func (fake *FakeDB) SaveTeamWorkerCallCount() int {
+	fake.saveTeamWorkerMutex.RLock()
+	defer fake.saveTeamWorkerMutex.RUnlock()
+	return len(fake.saveTeamWorkerArgsForCall)
+}
+
+func (fake *FakeDB) SaveTeamWorkerArgsForCall(i int) (db.WorkerInfo, time.Duration) {
+	fake.saveTeamWorkerMutex.RLock()
+	defer fake.saveTeamWorkerMutex.RUnlock()
+	return fake.saveTeamWorkerArgsForCall[i].arg1, fake.saveTeamWorkerArgsForCall[i].arg2
+}
+
+func (fake *FakeDB) SaveTeamWorkerReturns(result1 db.SavedWorker, result2 error) {
+	fake.SaveTeamWorkerStub = nil
+	fake.saveTeamWorkerReturns = struct {
+		result1 db.SavedWorker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) LandWorker(workerName string) error {
+	fake.landWorkerMutex.Lock()
+	fake.landWorkerArgsForCall = append(fake.landWorkerArgsForCall, struct {
+		workerName string
+	}{workerName})
+	fake.recordInvocation("LandWorker", []interface{}{workerName})
+	fake.landWorkerMutex.Unlock()
+	if fake.LandWorkerStub != nil {
+		return fake.LandWorkerStub(workerName)
+	} else {
+		return fake.landWorkerReturns.result1
+	}
+}
+
+func (fake *FakeDB) LandWorkerCallCount() int {
+	fake.landWorkerMutex.RLock()
+	defer fake.landWorkerMutex.RUnlock()
+	return len(fake.landWorkerArgsForCall)
+}
+
+func (fake *FakeDB) LandWorkerArgsForCall(i int) string {
+	fake.landWorkerMutex.RLock()
+	defer fake.landWorkerMutex.RUnlock()
+	return fake.landWorkerArgsForCall[i].workerName
+}
+
+func (fake *FakeDB) LandWorkerReturns(result1 error) {
+	fake.LandWorkerStub = nil
+	fake.landWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) RetireWorker(workerName string) error {
+	fake.retireWorkerMutex.Lock()
+	fake.retireWorkerArgsForCall = append(fake.retireWorkerArgsForCall, struct {
+		workerName string
+	}{workerName})
+	fake.recordInvocation("RetireWorker", []interface{}{workerName})
+	fake.retireWorkerMutex.Unlock()
+	if fake.RetireWorkerStub != nil {
+		return fake.RetireWorkerStub(workerName)
+	} else {
+		return fake.retireWorkerReturns.result1
+	}
+}
+
+func (fake *FakeDB) RetireWorkerCallCount() int {
+	fake.retireWorkerMutex.RLock()
+	defer fake.retireWorkerMutex.RUnlock()
+	return len(fake.retireWorkerArgsForCall)
+}
+
+func (fake *FakeDB) RetireWorkerArgsForCall(i int) string {
+	fake.retireWorkerMutex.RLock()
+	defer fake.retireWorkerMutex.RUnlock()
+	return fake.retireWorkerArgsForCall[i].workerName
+}
+
+func (fake *FakeDB) RetireWorkerReturns(result1 error) {
+	fake.RetireWorkerStub = nil
+	fake.retireWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) PruneWorker(workerName string) error {
+	fake.pruneWorkerMutex.Lock()
+	fake.pruneWorkerArgsForCall = append(fake.pruneWorkerArgsForCall, struct {
+		workerName string
+	}{workerName})
+	fake.recordInvocation("PruneWorker", []interface{}{workerName})
+	fake.pruneWorkerMutex.Unlock()
+	if fake.PruneWorkerStub != nil {
+		return fake.PruneWorkerStub(workerName)
+	} else {
+		return fake.pruneWorkerReturns.result1
+	}
+}
+
+func (fake *FakeDB) PruneWorkerCallCount() int {
+	fake.pruneWorkerMutex.RLock()
+	defer fake.pruneWorkerMutex.RUnlock()
+	return len(fake.pruneWorkerArgsForCall)
+}
+
+func (fake *FakeDB) PruneWorkerArgsForCall(i int) string {
+	fake.pruneWorkerMutex.RLock()
+	defer fake.pruneWorkerMutex.RUnlock()
+	return fake.pruneWorkerArgsForCall[i].workerName
+}
+
+func (fake *FakeDB) PruneWorkerReturns(result1 error) {
+	fake.PruneWorkerStub = nil
+	fake.pruneWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SaveWorkerHealth(workerName string, health db.WorkerHealth) error {
+	fake.saveWorkerHealthMutex.Lock()
+	fake.saveWorkerHealthArgsForCall = append(fake.saveWorkerHealthArgsForCall, struct {
+		workerName string
+		health     db.WorkerHealth
+	}{workerName, health})
+	fake.recordInvocation("SaveWorkerHealth", []interface{}{workerName, health})
+	fake.saveWorkerHealthMutex.Unlock()
+	if fake.SaveWorkerHealthStub != nil {
+		return fake.SaveWorkerHealthStub(workerName, health)
+	} else {
+		return fake.saveWorkerHealthReturns.result1
+	}
+}
+
+func (fake *FakeDB) SaveWorkerHealthCallCount() int {
+	fake.saveWorkerHealthMutex.RLock()
+	defer fake.saveWorkerHealthMutex.RUnlock()
+	return len(fake.saveWorkerHealthArgsForCall)
+}
+
+func (fake *FakeDB) SaveWorkerHealthArgsForCall(i int) (string, db.WorkerHealth) {
+	fake.saveWorkerHealthMutex.RLock()
+	defer fake.saveWorkerHealthMutex.RUnlock()
+	return fake.saveWorkerHealthArgsForCall[i].workerName, fake.saveWorkerHealthArgsForCall[i].health
+}
+
+func (fake *FakeDB) SaveWorkerHealthReturns(result1 error) {
+	fake.SaveWorkerHealthStub = nil
+	fake.saveWorkerHealthReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) FindContainersByDescriptors(arg1 db.Container) ([]db.SavedContainer, error) {
+	fake.findContainersByDescriptorsMutex.Lock()
+	fake.findContainersByDescriptorsArgsForCall = append(fake.findContainersByDescriptorsArgsForCall, struct {
+		arg1 db.Container
+	}{arg1})
+	fake.recordInvocation("FindContainersByDescriptors", []interface{}{arg1})
+	fake.findContainersByDescriptorsMutex.Unlock()
+	if fake.FindContainersByDescriptorsStub != nil {
+		return fake.FindContainersByDescriptorsStub(arg1)
+	} else {
+		return fake.findContainersByDescriptorsReturns.result1, fake.findContainersByDescriptorsReturns.result2
+	}
+}
+
+func (fake *FakeDB) FindContainersByDescriptorsCallCount() int {
+	fake.findContainersByDescriptorsMutex.RLock()
+	defer fake.findContainersByDescriptorsMutex.RUnlock()
+	return len(fake.findContainersByDescriptorsArgsForCall)
+}
+
+func (fake *FakeDB) FindContainersByDescriptorsArgsForCall(i int) db.Container {
+	fake.findContainersByDescriptorsMutex.RLock()
+	defer fake.findContainersByDescriptorsMutex.RUnlock()
+	return fake.findContainersByDescriptorsArgsForCall[i].arg1
+}
+
+func (fake *FakeDB) FindContainersByDescriptorsReturns(result1 []db.SavedContainer, result2 error) {
+	fake.FindContainersByDescriptorsStub = nil
+	fake.findContainersByDescriptorsReturns = struct {
+		result1 []db.SavedContainer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetContainer(arg1 string) (db.SavedContainer, bool, error) {
+	fake.getContainerMutex.Lock()
+	fake.getContainerArgsForCall = append(fake.getContainerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetContainer", []interface{}{arg1})
+	fake.getContainerMutex.Unlock()
+	if fake.GetContainerStub != nil {
+		return fake.GetContainerStub(arg1)
+	} else {
+		return fake.getContainerReturns.result1, fake.getContainerReturns.result2, fake.getContainerReturns.result3
+	}
+}
+
+func (fake *FakeDB) GetContainerCallCount() int {
+	fake.getContainerMutex.RLock()
+	defer fake.getContainerMutex.RUnlock()
+	return len(fake.getContainerArgsForCall)
+}
+
+func (fake *FakeDB) GetContainerArgsForCall(i int) string {
+	fake.getContainerMutex.RLock()
+	defer fake.getContainerMutex.RUnlock()
+	return fake.getContainerArgsForCall[i].arg1
+}
+
+func (fake *FakeDB) GetContainerReturns(result1 db.SavedContainer, result2 bool, result3 error) {
+	fake.GetContainerStub = nil
+	fake.getContainerReturns = struct {
+		result1 db.SavedContainer
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) CreateContainer(container db.Container, ttl time.Duration, maxLifetime time.Duration, volumeHandles []string) (db.SavedContainer, error) {
+	var volumeHandlesCopy []string
+	if volumeHandles != nil {
+		volumeHandlesCopy = make([]string, len(volumeHandles))
+		copy(volumeHandlesCopy, volumeHandles)
+	}
+	fake.createContainerMutex.Lock()
+	fake.createContainerArgsForCall = append(fake.createContainerArgsForCall, struct {
+		container     db.Container
+		ttl           time.Duration
+		maxLifetime   time.Duration
+		volumeHandles []string
+	}{container, ttl, maxLifetime, volumeHandlesCopy})
+	fake.recordInvocation("CreateContainer", []interface{}{container, ttl, maxLifetime, volumeHandlesCopy})
+	fake.createContainerMutex.Unlock()
+	if fake.CreateContainerStub != nil {
+		return fake.CreateContainerStub(container, ttl, maxLifetime, volumeHandles)
+	} else {
+		return fake.createContainerReturns.result1, fake.createContainerReturns.result2
+	}
+}
+
+func (fake *FakeDB) CreateContainerCallCount() int {
+	fake.createContainerMutex.RLock()
+	defer fake.createContainerMutex.RUnlock()
+	return len(fake.createContainerArgsForCall)
+}
+
+func (fake *FakeDB) CreateContainerArgsForCall(i int) (db.Container, time.Duration, time.Duration, []string) {
+	fake.createContainerMutex.RLock()
+	defer fake.createContainerMutex.RUnlock()
+	return fake.createContainerArgsForCall[i].container, fake.createContainerArgsForCall[i].ttl, fake.createContainerArgsForCall[i].maxLifetime, fake.createContainerArgsForCall[i].volumeHandles
+}
+
+func (fake *FakeDB) CreateContainerReturns(result1 db.SavedContainer, result2 error) {
+	fake.CreateContainerStub = nil
+	fake.createContainerReturns = struct {
+		result1 db.SavedContainer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) FindContainerByIdentifier(arg1 db.ContainerIdentifier) (db.SavedContainer, bool, error) {
+	fake.findContainerByIdentifierMutex.Lock()
+	fake.findContainerByIdentifierArgsForCall = append(fake.findContainerByIdentifierArgsForCall, struct {
+		arg1 db.ContainerIdentifier
+	}{arg1})
+	fake.recordInvocation("FindContainerByIdentifier", []interface{}{arg1})
+	fake.findContainerByIdentifierMutex.Unlock()
+	if fake.FindContainerByIdentifierStub != nil {
+		return fake.FindContainerByIdentifierStub(arg1)
+	} else {
+		return fake.findContainerByIdentifierReturns.result1, fake.findContainerByIdentifierReturns.result2, fake.findContainerByIdentifierReturns.result3
+	}
+}
+
+func (fake *FakeDB) FindContainerByIdentifierCallCount() int {
+	fake.findContainerByIdentifierMutex.RLock()
+	defer fake.findContainerByIdentifierMutex.RUnlock()
+	return len(fake.findContainerByIdentifierArgsForCall)
+}
+
+func (fake *FakeDB) FindContainerByIdentifierArgsForCall(i int) db.ContainerIdentifier {
+	fake.findContainerByIdentifierMutex.RLock()
+	defer fake.findContainerByIdentifierMutex.RUnlock()
+	return fake.findContainerByIdentifierArgsForCall[i].arg1
+}
+
+func (fake *FakeDB) FindContainerByIdentifierReturns(result1 db.SavedContainer, result2 bool, result3 error) {
+	fake.FindContainerByIdentifierStub = nil
+	fake.findContainerByIdentifierReturns = struct {
+		result1 db.SavedContainer
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) FindLatestSuccessfulBuildsPerJob() (map[int]int, error) {
+	fake.findLatestSuccessfulBuildsPerJobMutex.Lock()
+	fake.findLatestSuccessfulBuildsPerJobArgsForCall = append(fake.findLatestSuccessfulBuildsPerJobArgsForCall, struct{}{})
+	fake.recordInvocation("FindLatestSuccessfulBuildsPerJob", []interface{}{})
+	fake.findLatestSuccessfulBuildsPerJobMutex.Unlock()
+	if fake.FindLatestSuccessfulBuildsPerJobStub != nil {
+		return fake.FindLatestSuccessfulBuildsPerJobStub()
+	} else {
+		return fake.findLatestSuccessfulBuildsPerJobReturns.result1, fake.findLatestSuccessfulBuildsPerJobReturns.result2
+	}
+}
+
+func (fake *FakeDB) FindLatestSuccessfulBuildsPerJobCallCount() int {
+	fake.findLatestSuccessfulBuildsPerJobMutex.RLock()
+	defer fake.findLatestSuccessfulBuildsPerJobMutex.RUnlock()
+	return len(fake.findLatestSuccessfulBuildsPerJobArgsForCall)
+}
+
+func (fake *FakeDB) FindLatestSuccessfulBuildsPerJobReturns(result1 map[int]int, result2 error) {
+	fake.FindLatestSuccessfulBuildsPerJobStub = nil
+	fake.findLatestSuccessfulBuildsPerJobReturns = struct {
+		result1 map[int]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) FindJobContainersFromUnsuccessfulBuilds() ([]db.SavedContainer, error) {
+	fake.findJobContainersFromUnsuccessfulBuildsMutex.Lock()
+	fake.findJobContainersFromUnsuccessfulBuildsArgsForCall = append(fake.findJobContainersFromUnsuccessfulBuildsArgsForCall, struct{}{})
+	fake.recordInvocation("FindJobContainersFromUnsuccessfulBuilds", []interface{}{})
+	fake.findJobContainersFromUnsuccessfulBuildsMutex.Unlock()
+	if fake.FindJobContainersFromUnsuccessfulBuildsStub != nil {
+		return fake.FindJobContainersFromUnsuccessfulBuildsStub()
+	} else {
+		return fake.findJobContainersFromUnsuccessfulBuildsReturns.result1, fake.findJobContainersFromUnsuccessfulBuildsReturns.result2
+	}
+}
+
+func (fake *FakeDB) FindJobContainersFromUnsuccessfulBuildsCallCount() int {
+	fake.findJobContainersFromUnsuccessfulBuildsMutex.RLock()
+	defer fake.findJobContainersFromUnsuccessfulBuildsMutex.RUnlock()
+	return len(fake.findJobContainersFromUnsuccessfulBuildsArgsForCall)
+}
+
+func (fake *FakeDB) FindJobContainersFromUnsuccessfulBuildsReturns(result1 []db.SavedContainer, result2 error) {
+	fake.FindJobContainersFromUnsuccessfulBuildsStub = nil
+	fake.findJobContainersFromUnsuccessfulBuildsReturns = struct {
+		result1 []db.SavedContainer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) UpdateExpiresAtOnContainer(handle string, ttl time.Duration) error {
+	fake.updateExpiresAtOnContainerMutex.Lock()
+	fake.updateExpiresAtOnContainerArgsForCall = append(fake.updateExpiresAtOnContainerArgsForCall, struct {
+		handle string
+		ttl    time.Duration
+	}{handle, ttl})
+	fake.recordInvocation("UpdateExpiresAtOnContainer", []interface{}{handle, ttl})
+	fake.updateExpiresAtOnContainerMutex.Unlock()
+	if fake.UpdateExpiresAtOnContainerStub != nil {
+		return fake.UpdateExpiresAtOnContainerStub(handle, ttl)
+	} else {
+		return fake.updateExpiresAtOnContainerReturns.result1
+	}
+}
+
+func (fake *FakeDB) UpdateExpiresAtOnContainerCallCount() int {
+	fake.updateExpiresAtOnContainerMutex.RLock()
+	defer fake.updateExpiresAtOnContainerMutex.RUnlock()
+	return len(fake.updateExpiresAtOnContainerArgsForCall)
+}
+
+func (fake *FakeDB) UpdateExpiresAtOnContainerArgsForCall(i int) (string, time.Duration) {
+	fake.updateExpiresAtOnContainerMutex.RLock()
+	defer fake.updateExpiresAtOnContainerMutex.RUnlock()
+	return fake.updateExpiresAtOnContainerArgsForCall[i].handle, fake.updateExpiresAtOnContainerArgsForCall[i].ttl
+}
+
+func (fake *FakeDB) UpdateExpiresAtOnContainerReturns(result1 error) {
+	fake.UpdateExpiresAtOnContainerStub = nil
+	fake.updateExpiresAtOnContainerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) ReapContainer(handle string) error {
+	fake.reapContainerMutex.Lock()
+	fake.reapContainerArgsForCall = append(fake.reapContainerArgsForCall, struct {
+		handle string
+	}{handle})
+	fake.recordInvocation("ReapContainer", []interface{}{handle})
+	fake.reapContainerMutex.Unlock()
+	if fake.ReapContainerStub != nil {
+		return fake.ReapContainerStub(handle)
+	} else {
+		return fake.reapContainerReturns.result1
+	}
+}
+
+func (fake *FakeDB) ReapContainerCallCount() int {
+	fake.reapContainerMutex.RLock()
+	defer fake.reapContainerMutex.RUnlock()
+	return len(fake.reapContainerArgsForCall)
+}
+
+func (fake *FakeDB) ReapContainerArgsForCall(i int) string {
+	fake.reapContainerMutex.RLock()
+	defer fake.reapContainerMutex.RUnlock()
+	return fake.reapContainerArgsForCall[i].handle
+}
+
+func (fake *FakeDB) ReapContainerReturns(result1 error) {
+	fake.ReapContainerStub = nil
+	fake.reapContainerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) DeleteContainer(arg1 string) error {
+	fake.deleteContainerMutex.Lock()
+	fake.deleteContainerArgsForCall = append(fake.deleteContainerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("DeleteContainer", []interface{}{arg1})
+	fake.deleteContainerMutex.Unlock()
+	if fake.DeleteContainerStub != nil {
+		return fake.DeleteContainerStub(arg1)
+	} else {
+		return fake.deleteContainerReturns.result1
+	}
+}
+
+func (fake *FakeDB) DeleteContainerCallCount() int {
+	fake.deleteContainerMutex.RLock()
+	defer fake.deleteContainerMutex.RUnlock()
+	return len(fake.deleteContainerArgsForCall)
+}
+
+func (fake *FakeDB) DeleteContainerArgsForCall(i int) string {
+	fake.deleteContainerMutex.RLock()
+	defer fake.deleteContainerMutex.RUnlock()
+	return fake.deleteContainerArgsForCall[i].arg1
+}
+
+func (fake *FakeDB) DeleteContainerReturns(result1 error) {
+	fake.DeleteContainerStub = nil
+	fake.deleteContainerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) GetConfigByBuildID(buildID int) (atc.Config, db.ConfigVersion, error) {
+	fake.getConfigByBuildIDMutex.Lock()
+	fake.getConfigByBuildIDArgsForCall = append(fake.getConfigByBuildIDArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.recordInvocation("GetConfigByBuildID", []interface{}{buildID})
+	fake.getConfigByBuildIDMutex.Unlock()
+	if fake.GetConfigByBuildIDStub != nil {
+		return fake.GetConfigByBuildIDStub(buildID)
+	} else {
+		return fake.getConfigByBuildIDReturns.result1, fake.getConfigByBuildIDReturns.result2, fake.getConfigByBuildIDReturns.result3
+	}
+}
+
+func (fake *FakeDB) GetConfigByBuildIDCallCount() int {
+	fake.getConfigByBuildIDMutex.RLock()
+	defer fake.getConfigByBuildIDMutex.RUnlock()
+	return len(fake.getConfigByBuildIDArgsForCall)
+}
+
+func (fake *FakeDB) GetConfigByBuildIDArgsForCall(i int) int {
+	fake.getConfigByBuildIDMutex.RLock()
+	defer fake.getConfigByBuildIDMutex.RUnlock()
+	return fake.getConfigByBuildIDArgsForCall[i].buildID
+}
+
+func (fake *FakeDB) GetConfigByBuildIDReturns(result1 atc.Config, result2 db.ConfigVersion, result3 error) {
+	fake.GetConfigByBuildIDStub = nil
+	fake.getConfigByBuildIDReturns = struct {
+		result1 atc.Config
+		result2 db.ConfigVersion
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) InsertVolume(data db.Volume) error {
+	fake.insertVolumeMutex.Lock()
+	fake.insertVolumeArgsForCall = append(fake.insertVolumeArgsForCall, struct {
+		data db.Volume
+	}{data})
+	fake.recordInvocation("InsertVolume", []interface{}{data})
+	fake.insertVolumeMutex.Unlock()
+	if fake.InsertVolumeStub != nil {
+		return fake.InsertVolumeStub(data)
+	} else {
+		return fake.insertVolumeReturns.result1
+	}
+}
+
+func (fake *FakeDB) InsertVolumeCallCount() int {
+	fake.insertVolumeMutex.RLock()
+	defer fake.insertVolumeMutex.RUnlock()
+	return len(fake.insertVolumeArgsForCall)
+}
+
+func (fake *FakeDB) InsertVolumeArgsForCall(i int) db.Volume {
+	fake.insertVolumeMutex.RLock()
+	defer fake.insertVolumeMutex.RUnlock()
+	return fake.insertVolumeArgsForCall[i].data
+}
+
+func (fake *FakeDB) InsertVolumeReturns(result1 error) {
+	fake.InsertVolumeStub = nil
+	fake.insertVolumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) GetVolumes() ([]db.SavedVolume, error) {
+	fake.getVolumesMutex.Lock()
+	fake.getVolumesArgsForCall = append(fake.getVolumesArgsForCall, struct{}{})
+	fake.recordInvocation("GetVolumes", []interface{}{})
+	fake.getVolumesMutex.Unlock()
+	if fake.GetVolumesStub != nil {
+		return fake.GetVolumesStub()
+	} else {
+		return fake.getVolumesReturns.result1, fake.getVolumesReturns.result2
+	}
+}
+
+func (fake *FakeDB) GetVolumesCallCount() int {
+	fake.getVolumesMutex.RLock()
+	defer fake.getVolumesMutex.RUnlock()
+	return len(fake.getVolumesArgsForCall)
+}
+
+func (fake *FakeDB) GetVolumesReturns(result1 []db.SavedVolume, result2 error) {
+	fake.GetVolumesStub = nil
+	fake.getVolumesReturns = struct {
+		result1 []db.SavedVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetVolumesByIdentifier(arg1 db.VolumeIdentifier) ([]db.SavedVolume, error) {
+	fake.getVolumesByIdentifierMutex.Lock()
+	fake.getVolumesByIdentifierArgsForCall = append(fake.getVolumesByIdentifierArgsForCall, struct {
+		arg1 db.VolumeIdentifier
+	}{arg1})
+	fake.recordInvocation("GetVolumesByIdentifier", []interface{}{arg1})
+	fake.getVolumesByIdentifierMutex.Unlock()
+	if fake.GetVolumesByIdentifierStub != nil {
+		return fake.GetVolumesByIdentifierStub(arg1)
+	} else {
+		return fake.getVolumesByIdentifierReturns.result1, fake.getVolumesByIdentifierReturns.result2
+	}
+}
+
+func (fake *FakeDB) GetVolumesByIdentifierCallCount() int {
+	fake.getVolumesByIdentifierMutex.RLock()
+	defer fake.getVolumesByIdentifierMutex.RUnlock()
+	return len(fake.getVolumesByIdentifierArgsForCall)
+}
+
+func (fake *FakeDB) GetVolumesByIdentifierArgsForCall(i int) db.VolumeIdentifier {
+	fake.getVolumesByIdentifierMutex.RLock()
+	defer fake.getVolumesByIdentifierMutex.RUnlock()
+	return fake.getVolumesByIdentifierArgsForCall[i].arg1
+}
+
+func (fake *FakeDB) GetVolumesByIdentifierReturns(result1 []db.SavedVolume, result2 error) {
+	fake.GetVolumesByIdentifierStub = nil
+	fake.getVolumesByIdentifierReturns = struct {
+		result1 []db.SavedVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) ReapVolume(arg1 string) error {
+	fake.reapVolumeMutex.Lock()
+	fake.reapVolumeArgsForCall = append(fake.reapVolumeArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ReapVolume", []interface{}{arg1})
+	fake.reapVolumeMutex.Unlock()
+	if fake.ReapVolumeStub != nil {
+		return fake.ReapVolumeStub(arg1)
+	} else {
+		return fake.reapVolumeReturns.result1
+	}
+}
+
+func (fake *FakeDB) ReapVolumeCallCount() int {
+	fake.reapVolumeMutex.RLock()
+	defer fake.reapVolumeMutex.RUnlock()
+	return len(fake.reapVolumeArgsForCall)
+}
+
+func (fake *FakeDB) ReapVolumeArgsForCall(i int) string {
+	fake.reapVolumeMutex.RLock()
+	defer fake.reapVolumeMutex.RUnlock()
+	return fake.reapVolumeArgsForCall[i].arg1
+}
+
+func (fake *FakeDB) ReapVolumeReturns(result1 error) {
+	fake.ReapVolumeStub = nil
+	fake.reapVolumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetVolumeTTL(arg1 string, arg2 time.Duration) error {
+	fake.setVolumeTTLMutex.Lock()
+	fake.setVolumeTTLArgsForCall = append(fake.setVolumeTTLArgsForCall, struct {
+		arg1 string
+		arg2 time.Duration
+	}{arg1, arg2})
+	fake.recordInvocation("SetVolumeTTL", []interface{}{arg1, arg2})
+	fake.setVolumeTTLMutex.Unlock()
+	if fake.SetVolumeTTLStub != nil {
+		return fake.SetVolumeTTLStub(arg1, arg2)
+	} else {
+		return fake.setVolumeTTLReturns.result1
+	}
+}
+
+func (fake *FakeDB) SetVolumeTTLCallCount() int {
+	fake.setVolumeTTLMutex.RLock()
+	defer fake.setVolumeTTLMutex.RUnlock()
+	return len(fake.setVolumeTTLArgsForCall)
+}
+
+func (fake *FakeDB) SetVolumeTTLArgsForCall(i int) (string, time.Duration) {
+	fake.setVolumeTTLMutex.RLock()
+	defer fake.setVolumeTTLMutex.RUnlock()
+	return fake.setVolumeTTLArgsForCall[i].arg1, fake.setVolumeTTLArgsForCall[i].arg2
+}
+
+func (fake *FakeDB) SetVolumeTTLReturns(result1 error) {
+	fake.SetVolumeTTLStub = nil
+	fake.setVolumeTTLReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) GetVolumeTTL(volumeHandle string) (time.Duration, bool, error) {
+	fake.getVolumeTTLMutex.Lock()
+	fake.getVolumeTTLArgsForCall = append(fake.getVolumeTTLArgsForCall, struct {
+		volumeHandle string
+	}{volumeHandle})
+	fake.recordInvocation("GetVolumeTTL", []interface{}{volumeHandle})
+	fake.getVolumeTTLMutex.Unlock()
+	if fake.GetVolumeTTLStub != nil {
+		return fake.GetVolumeTTLStub(volumeHandle)
+	} else {
+		return fake.getVolumeTTLReturns.result1, fake.getVolumeTTLReturns.result2, fake.getVolumeTTLReturns.result3
+	}
+}
+
+func (fake *FakeDB) GetVolumeTTLCallCount() int {
+	fake.getVolumeTTLMutex.RLock()
+	defer fake.getVolumeTTLMutex.RUnlock()
+	return len(fake.getVolumeTTLArgsForCall)
+}
+
+func (fake *FakeDB) GetVolumeTTLArgsForCall(i int) string {
+	fake.getVolumeTTLMutex.RLock()
+	defer fake.getVolumeTTLMutex.RUnlock()
+	return fake.getVolumeTTLArgsForCall[i].volumeHandle
+}
+
+func (fake *FakeDB) GetVolumeTTLReturns(result1 time.Duration, result2 bool, result3 error) {
+	fake.GetVolumeTTLStub = nil
+	fake.getVolumeTTLReturns = struct {
+		result1 time.Duration
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) SetVolumeSizeInBytes(arg1 string, arg2 int64) error {
+	fake.setVolumeSizeInBytesMutex.Lock()
+	fake.setVolumeSizeInBytesArgsForCall = append(fake.setVolumeSizeInBytesArgsForCall, struct {
+		arg1 string
+		arg2 int64
+	}{arg1, arg2})
+	fake.recordInvocation("SetVolumeSizeInBytes", []interface{}{arg1, arg2})
+	fake.setVolumeSizeInBytesMutex.Unlock()
+	if fake.SetVolumeSizeInBytesStub != nil {
+		return fake.SetVolumeSizeInBytesStub(arg1, arg2)
+	} else {
+		return fake.setVolumeSizeInBytesReturns.result1
+	}
+}
+
+func (fake *FakeDB) SetVolumeSizeInBytesCallCount() int {
+	fake.setVolumeSizeInBytesMutex.RLock()
+	defer fake.setVolumeSizeInBytesMutex.RUnlock()
+	return len(fake.setVolumeSizeInBytesArgsForCall)
+}
+
+func (fake *FakeDB) SetVolumeSizeInBytesArgsForCall(i int) (string, int64) {
+	fake.setVolumeSizeInBytesMutex.RLock()
+	defer fake.setVolumeSizeInBytesMutex.RUnlock()
+	return fake.setVolumeSizeInBytesArgsForCall[i].arg1, fake.setVolumeSizeInBytesArgsForCall[i].arg2
+}
+
+func (fake *FakeDB) SetVolumeSizeInBytesReturns(result1 error) {
+	fake.SetVolumeSizeInBytesStub = nil
+	fake.setVolumeSizeInBytesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) GetVolumesForOneOffBuildImageResources() ([]db.SavedVolume, error) {
+	fake.getVolumesForOneOffBuildImageResourcesMutex.Lock()
+	fake.getVolumesForOneOffBuildImageResourcesArgsForCall = append(fake.getVolumesForOneOffBuildImageResourcesArgsForCall, struct{}{})
+	fake.recordInvocation("GetVolumesForOneOffBuildImageResources", []interface{}{})
+	fake.getVolumesForOneOffBuildImageResourcesMutex.Unlock()
+	if fake.GetVolumesForOneOffBuildImageResourcesStub != nil {
+		return fake.GetVolumesForOneOffBuildImageResourcesStub()
+	} else {
+		return fake.getVolumesForOneOffBuildImageResourcesReturns.result1, fake.getVolumesForOneOffBuildImageResourcesReturns.result2
+	}
+}
+
+func (fake *FakeDB) GetVolumesForOneOffBuildImageResourcesCallCount() int {
+	fake.getVolumesForOneOffBuildImageResourcesMutex.RLock()
+	defer fake.getVolumesForOneOffBuildImageResourcesMutex.RUnlock()
+	return len(fake.getVolumesForOneOffBuildImageResourcesArgsForCall)
+}
+
+func (fake *FakeDB) GetVolumesForOneOffBuildImageResourcesReturns(result1 []db.SavedVolume, result2 error) {
+	fake.GetVolumesForOneOffBuildImageResourcesStub = nil
+	fake.getVolumesForOneOffBuildImageResourcesReturns = struct {
+		result1 []db.SavedVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) FindWorkerCheckResourceTypeVersion(workerName string, checkType string) (string, bool, error) {
+	fake.findWorkerCheckResourceTypeVersionMutex.Lock()
+	fake.findWorkerCheckResourceTypeVersionArgsForCall = append(fake.findWorkerCheckResourceTypeVersionArgsForCall, struct {
+		workerName string
+		checkType  string
+	}{workerName, checkType})
+	fake.recordInvocation("FindWorkerCheckResourceTypeVersion", []interface{}{workerName, checkType})
+	fake.findWorkerCheckResourceTypeVersionMutex.Unlock()
+	if fake.FindWorkerCheckResourceTypeVersionStub != nil {
+		return fake.FindWorkerCheckResourceTypeVersionStub(workerName, checkType)
+	} else {
+		return fake.findWorkerCheckResourceTypeVersionReturns.result1, fake.findWorkerCheckResourceTypeVersionReturns.result2, fake.findWorkerCheckResourceTypeVersionReturns.result3
+	}
+}
+
+func (fake *FakeDB) FindWorkerCheckResourceTypeVersionCallCount() int {
+	fake.findWorkerCheckResourceTypeVersionMutex.RLock()
+	defer fake.findWorkerCheckResourceTypeVersionMutex.RUnlock()
+	return len(fake.findWorkerCheckResourceTypeVersionArgsForCall)
+}
+
+func (fake *FakeDB) FindWorkerCheckResourceTypeVersionArgsForCall(i int) (string, string) {
+	fake.findWorkerCheckResourceTypeVersionMutex.RLock()
+	defer fake.findWorkerCheckResourceTypeVersionMutex.RUnlock()
+	return fake.findWorkerCheckResourceTypeVersionArgsForCall[i].workerName, fake.findWorkerCheckResourceTypeVersionArgsForCall[i].checkType
+}
+
+func (fake *FakeDB) FindWorkerCheckResourceTypeVersionReturns(result1 string, result2 bool, result3 error) {
+	fake.FindWorkerCheckResourceTypeVersionStub = nil
+	fake.findWorkerCheckResourceTypeVersionReturns = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) SaveImageResourceVersion(buildID int, planID atc.PlanID, identifier db.ResourceCacheIdentifier) error {
+	fake.saveImageResourceVersionMutex.Lock()
+	fake.saveImageResourceVersionArgsForCall = append(fake.saveImageResourceVersionArgsForCall, struct {
+		buildID    int
+		planID     atc.PlanID
+		identifier db.ResourceCacheIdentifier
+	}{buildID, planID, identifier})
+	fake.recordInvocation("SaveImageResourceVersion", []interface{}{buildID, planID, identifier})
+	fake.saveImageResourceVersionMutex.Unlock()
+	if fake.SaveImageResourceVersionStub != nil {
+		return fake.SaveImageResourceVersionStub(buildID, planID, identifier)
+	} else {
+		return fake.saveImageResourceVersionReturns.result1
+	}
+}
+
+func (fake *FakeDB) SaveImageResourceVersionCallCount() int {
+	fake.saveImageResourceVersionMutex.RLock()
+	defer fake.saveImageResourceVersionMutex.RUnlock()
+	return len(fake.saveImageResourceVersionArgsForCall)
+}
+
+func (fake *FakeDB) SaveImageResourceVersionArgsForCall(i int) (int, atc.PlanID, db.ResourceCacheIdentifier) {
+	fake.saveImageResourceVersionMutex.RLock()
+	defer fake.saveImageResourceVersionMutex.RUnlock()
+	return fake.saveImageResourceVersionArgsForCall[i].buildID, fake.saveImageResourceVersionArgsForCall[i].planID, fake.saveImageResourceVersionArgsForCall[i].identifier
+}
+
+func (fake *FakeDB) SaveImageResourceVersionReturns(result1 error) {
+	fake.SaveImageResourceVersionStub = nil
+	fake.saveImageResourceVersionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) GetImageResourceCacheIdentifiersByBuildID(buildID int) ([]db.ResourceCacheIdentifier, error) {
+	fake.getImageResourceCacheIdentifiersByBuildIDMutex.Lock()
+	fake.getImageResourceCacheIdentifiersByBuildIDArgsForCall = append(fake.getImageResourceCacheIdentifiersByBuildIDArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.recordInvocation("GetImageResourceCacheIdentifiersByBuildID", []interface{}{buildID})
+	fake.getImageResourceCacheIdentifiersByBuildIDMutex.Unlock()
+	if fake.GetImageResourceCacheIdentifiersByBuildIDStub != nil {
+		return fake.GetImageResourceCacheIdentifiersByBuildIDStub(buildID)
+	} else {
+		return fake.getImageResourceCacheIdentifiersByBuildIDReturns.result1, fake.getImageResourceCacheIdentifiersByBuildIDReturns.result2
+	}
+}
+
+func (fake *FakeDB) GetImageResourceCacheIdentifiersByBuildIDCallCount() int {
+	fake.getImageResourceCacheIdentifiersByBuildIDMutex.RLock()
+	defer fake.getImageResourceCacheIdentifiersByBuildIDMutex.RUnlock()
+	return len(fake.getImageResourceCacheIdentifiersByBuildIDArgsForCall)
+}
+
+func (fake *FakeDB) GetImageResourceCacheIdentifiersByBuildIDArgsForCall(i int) int {
+	fake.getImageResourceCacheIdentifiersByBuildIDMutex.RLock()
+	defer fake.getImageResourceCacheIdentifiersByBuildIDMutex.RUnlock()
+	return fake.getImageResourceCacheIdentifiersByBuildIDArgsForCall[i].buildID
+}
+
+func (fake *FakeDB) GetImageResourceCacheIdentifiersByBuildIDReturns(result1 []db.ResourceCacheIdentifier, result2 error) {
+	fake.GetImageResourceCacheIdentifiersByBuildIDStub = nil
+	fake.getImageResourceCacheIdentifiersByBuildIDReturns = struct {
+		result1 []db.ResourceCacheIdentifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.saveTeamMutex.RLock()
+	defer fake.saveTeamMutex.RUnlock()
+	fake.getTeamByNameMutex.RLock()
+	defer fake.getTeamByNameMutex.RUnlock()
+	fake.getTeamsMutex.RLock()
+	defer fake.getTeamsMutex.RUnlock()
+	fake.renameTeamMutex.RLock()
+	defer fake.renameTeamMutex.RUnlock()
+	fake.updateTeamBasicAuthMutex.RLock()
+	defer fake.updateTeamBasicAuthMutex.RUnlock()
+	fake.updateTeamGitHubAuthMutex.RLock()
+	defer fake.updateTeamGitHubAuthMutex.RUnlock()
+	fake.updateTeamGitLabAuthMutex.RLock()
+	defer fake.updateTeamGitLabAuthMutex.RUnlock()
+	fake.updateTeamOIDCAuthMutex.RLock()
+	defer fake.updateTeamOIDCAuthMutex.RUnlock()
+	fake.updateTeamRolesMutex.RLock()
+	defer fake.updateTeamRolesMutex.RUnlock()
+	fake.createDefaultTeamIfNotExistsMutex.RLock()
+	defer fake.createDefaultTeamIfNotExistsMutex.RUnlock()
+	fake.deleteTeamByNameMutex.RLock()
+	defer fake.deleteTeamByNameMutex.RUnlock()
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	fake.getAPITokensMutex.RLock()
+	defer fake.getAPITokensMutex.RUnlock()
+	fake.deleteAPITokenMutex.RLock()
+	defer fake.deleteAPITokenMutex.RUnlock()
+	fake.findAPITokenByHashMutex.RLock()
+	defer fake.findAPITokenByHashMutex.RUnlock()
+	fake.updateAPITokenLastUsedMutex.RLock()
+	defer fake.updateAPITokenLastUsedMutex.RUnlock()
+	fake.saveAuditEventMutex.RLock()
+	defer fake.saveAuditEventMutex.RUnlock()
+	fake.getAuditEventsMutex.RLock()
+	defer fake.getAuditEventsMutex.RUnlock()
+	fake.getBuildMutex.RLock()
+	defer fake.getBuildMutex.RUnlock()
+	fake.getBuildVersionedResourcesMutex.RLock()
+	defer fake.getBuildVersionedResourcesMutex.RUnlock()
+	fake.getBuildResourcesMutex.RLock()
+	defer fake.getBuildResourcesMutex.RUnlock()
+	fake.getBuildsMutex.RLock()
+	defer fake.getBuildsMutex.RUnlock()
+	fake.getTeamBuildsMutex.RLock()
+	defer fake.getTeamBuildsMutex.RUnlock()
+	fake.getAllStartedBuildsMutex.RLock()
+	defer fake.getAllStartedBuildsMutex.RUnlock()
+	fake.findJobIDForBuildMutex.RLock()
+	defer fake.findJobIDForBuildMutex.RUnlock()
+	fake.createPipeMutex.RLock()
+	defer fake.createPipeMutex.RUnlock()
+	fake.getPipeMutex.RLock()
+	defer fake.getPipeMutex.RUnlock()
+	fake.createOneOffBuildMutex.RLock()
+	defer fake.createOneOffBuildMutex.RUnlock()
+	fake.getBuildPreparationMutex.RLock()
+	defer fake.getBuildPreparationMutex.RUnlock()
+	fake.updateBuildPreparationMutex.RLock()
+	defer fake.updateBuildPreparationMutex.RUnlock()
+	fake.updateBuildPreparationWorkersAvailableMutex.RLock()
+	defer fake.updateBuildPreparationWorkersAvailableMutex.RUnlock()
+	fake.resetBuildPreparationsWithPipelinePausedMutex.RLock()
+	defer fake.resetBuildPreparationsWithPipelinePausedMutex.RUnlock()
+	fake.leaseBuildTrackingMutex.RLock()
+	defer fake.leaseBuildTrackingMutex.RUnlock()
+	fake.leaseBuildSchedulingMutex.RLock()
+	defer fake.leaseBuildSchedulingMutex.RUnlock()
+	fake.getLeaseMutex.RLock()
+	defer fake.getLeaseMutex.RUnlock()
+	fake.startBuildMutex.RLock()
+	defer fake.startBuildMutex.RUnlock()
+	fake.finishBuildMutex.RLock()
+	defer fake.finishBuildMutex.RUnlock()
+	fake.errorBuildMutex.RLock()
+	defer fake.errorBuildMutex.RUnlock()
+	fake.saveBuildInputMutex.RLock()
+	defer fake.saveBuildInputMutex.RUnlock()
+	fake.saveBuildOutputMutex.RLock()
+	defer fake.saveBuildOutputMutex.RUnlock()
+	fake.getBuildEventsMutex.RLock()
+	defer fake.getBuildEventsMutex.RUnlock()
+	fake.saveBuildEventMutex.RLock()
+	defer fake.saveBuildEventMutex.RUnlock()
+	fake.deleteBuildEventsByBuildIDsMutex.RLock()
+	defer fake.deleteBuildEventsByBuildIDsMutex.RUnlock()
+	fake.saveBuildEngineMetadataMutex.RLock()
+	defer fake.saveBuildEngineMetadataMutex.RUnlock()
+	fake.abortBuildMutex.RLock()
+	defer fake.abortBuildMutex.RUnlock()
+	fake.abortNotifierMutex.RLock()
+	defer fake.abortNotifierMutex.RUnlock()
+	fake.workersMutex.RLock()
+	defer fake.workersMutex.RUnlock()
+	fake.getWorkerMutex.RLock()
+	defer fake.getWorkerMutex.RUnlock()
+	fake.saveWorkerMutex.RLock()
+	defer fake.saveWorkerMutex.RUnlock()
+	fake.saveTeamWorkerMutex.RLock()
+	defer fake.saveTeamWorkerMutex.RUnlock()
+	fake.landWorkerMutex.RLock()
+	defer fake.landWorkerMutex.RUnlock()
+	fake.retireWorkerMutex.RLock()
+	defer fake.retireWorkerMutex.RUnlock()
+	fake.pruneWorkerMutex.RLock()
+	defer fake.pruneWorkerMutex.RUnlock()
+	fake.saveWorkerHealthMutex.RLock()
+	defer fake.saveWorkerHealthMutex.RUnlock()
+	fake.findContainersByDescriptorsMutex.RLock()
+	defer fake.findContainersByDescriptorsMutex.RUnlock()
+	fake.getContainerMutex.RLock()
+	defer fake.getContainerMutex.RUnlock()
+	fake.createContainerMutex.RLock()
+	defer fake.createContainerMutex.RUnlock()
+	fake.findContainerByIdentifierMutex.RLock()
+	defer fake.findContainerByIdentifierMutex.RUnlock()
+	fake.findLatestSuccessfulBuildsPerJobMutex.RLock()
+	defer fake.findLatestSuccessfulBuildsPerJobMutex.RUnlock()
+	fake.findJobContainersFromUnsuccessfulBuildsMutex.RLock()
+	defer fake.findJobContainersFromUnsuccessfulBuildsMutex.RUnlock()
+	fake.updateExpiresAtOnContainerMutex.RLock()
+	defer fake.updateExpiresAtOnContainerMutex.RUnlock()
+	fake.reapContainerMutex.RLock()
+	defer fake.reapContainerMutex.RUnlock()
+	fake.deleteContainerMutex.RLock()
+	defer fake.deleteContainerMutex.RUnlock()
+	fake.getConfigByBuildIDMutex.RLock()
+	defer fake.getConfigByBuildIDMutex.RUnlock()
+	fake.insertVolumeMutex.RLock()
+	defer fake.insertVolumeMutex.RUnlock()
+	fake.getVolumesMutex.RLock()
+	defer fake.getVolumesMutex.RUnlock()
+	fake.getVolumesByIdentifierMutex.RLock()
+	defer fake.getVolumesByIdentifierMutex.RUnlock()
+	fake.reapVolumeMutex.RLock()
+	defer fake.reapVolumeMutex.RUnlock()
+	fake.setVolumeTTLMutex.RLock()
+	defer fake.setVolumeTTLMutex.RUnlock()
+	fake.getVolumeTTLMutex.RLock()
+	defer fake.getVolumeTTLMutex.RUnlock()
+	fake.setVolumeSizeInBytesMutex.RLock()
+	defer fake.setVolumeSizeInBytesMutex.RUnlock()
+	fake.getVolumesForOneOffBuildImageResourcesMutex.RLock()
+	defer fake.getVolumesForOneOffBuildImageResourcesMutex.RUnlock()
+	fake.findWorkerCheckResourceTypeVersionMutex.RLock()
+	defer fake.findWorkerCheckResourceTypeVersionMutex.RUnlock()
+	fake.saveImageResourceVersionMutex.RLock()
+	defer fake.saveImageResourceVersionMutex.RUnlock()
+	fake.getImageResourceCacheIdentifiersByBuildIDMutex.RLock()
+	defer fake.getImageResourceCacheIdentifiersByBuildIDMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.DB = new(FakeDB)
@@ -25,6 +25,18 @@ type FakePipelineDB struct {
 	getPipelineIDReturns     struct {
 		result1 int
 	}
+	GetTeamNameStub        func() string
+	getTeamNameMutex       sync.RWMutex
+	getTeamNameArgsForCall []struct{}
+	getTeamNameReturns     struct {
+		result1 string
+	}
+	GetTeamIDStub        func() int
+	getTeamIDMutex       sync.RWMutex
+	getTeamIDArgsForCall []struct{}
+	getTeamIDReturns     struct {
+		result1 int
+	}
 	ScopedNameStub        func(string) string
 	scopedNameMutex       sync.RWMutex
 	scopedNameArgsForCall []struct {
@@ -60,6 +72,12 @@ type FakePipelineDB struct {
 	updateNameReturns struct {
 		result1 error
 	}
+	ArchiveStub        func() error
+	archiveMutex       sync.RWMutex
+	archiveArgsForCall []struct{}
+	archiveReturns     struct {
+		result1 error
+	}
 	DestroyStub        func() error
 	destroyMutex       sync.RWMutex
 	destroyArgsForCall []struct{}
@@ -143,6 +161,25 @@ type FakePipelineDB struct {
 	unpauseResourceReturns struct {
 		result1 error
 	}
+	PinResourceVersionStub        func(resourceName string, versionedResourceID int, comment string, pinnedBy string) error
+	pinResourceVersionMutex       sync.RWMutex
+	pinResourceVersionArgsForCall []struct {
+		resourceName        string
+		versionedResourceID int
+		comment             string
+		pinnedBy            string
+	}
+	pinResourceVersionReturns struct {
+		result1 error
+	}
+	UnpinResourceStub        func(resourceName string) error
+	unpinResourceMutex       sync.RWMutex
+	unpinResourceArgsForCall []struct {
+		resourceName string
+	}
+	unpinResourceReturns struct {
+		result1 error
+	}
 	SaveResourceVersionsStub        func(atc.ResourceConfig, []atc.Version) error
 	saveResourceVersionsMutex       sync.RWMutex
 	saveResourceVersionsArgsForCall []struct {
@@ -257,6 +294,23 @@ type FakePipelineDB struct {
 	unpauseJobReturns struct {
 		result1 error
 	}
+	GetTaskCacheVolumesStub        func(job string) ([]db.SavedVolume, error)
+	getTaskCacheVolumesMutex       sync.RWMutex
+	getTaskCacheVolumesArgsForCall []struct {
+		job string
+	}
+	getTaskCacheVolumesReturns struct {
+		result1 []db.SavedVolume
+		result2 error
+	}
+	ClearTaskCachesStub        func(job string) error
+	clearTaskCachesMutex       sync.RWMutex
+	clearTaskCachesArgsForCall []struct {
+		job string
+	}
+	clearTaskCachesReturns struct {
+		result1 error
+	}
 	UpdateFirstLoggedBuildIDStub        func(job string, newFirstLoggedBuildID int) error
 	updateFirstLoggedBuildIDMutex       sync.RWMutex
 	updateFirstLoggedBuildIDArgsForCall []struct {
@@ -535,6 +589,56 @@ func (fake *FakePipelineDB) GetPipelineIDReturns(result1 int) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) GetTeamName() string {
+	fake.getTeamNameMutex.Lock()
+	fake.getTeamNameArgsForCall = append(fake.getTeamNameArgsForCall, struct{}{})
+	fake.recordInvocation("GetTeamName", []interface{}{})
+	fake.getTeamNameMutex.Unlock()
+	if fake.GetTeamNameStub != nil {
+		return fake.GetTeamNameStub()
+	} else {
+		return fake.getTeamNameReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) GetTeamNameCallCount() int {
+	fake.getTeamNameMutex.RLock()
+	defer fake.getTeamNameMutex.RUnlock()
+	return len(fake.getTeamNameArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetTeamNameReturns(result1 string) {
+	fake.GetTeamNameStub = nil
+	fake.getTeamNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakePipelineDB) GetTeamID() int {
+	fake.getTeamIDMutex.Lock()
+	fake.getTeamIDArgsForCall = append(fake.getTeamIDArgsForCall, struct{}{})
+	fake.recordInvocation("GetTeamID", []interface{}{})
+	fake.getTeamIDMutex.Unlock()
+	if fake.GetTeamIDStub != nil {
+		return fake.GetTeamIDStub()
+	} else {
+		return fake.getTeamIDReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) GetTeamIDCallCount() int {
+	fake.getTeamIDMutex.RLock()
+	defer fake.getTeamIDMutex.RUnlock()
+	return len(fake.getTeamIDArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetTeamIDReturns(result1 int) {
+	fake.GetTeamIDStub = nil
+	fake.getTeamIDReturns = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakePipelineDB) ScopedName(arg1 string) string {
 	fake.scopedNameMutex.Lock()
 	fake.scopedNameArgsForCall = append(fake.scopedNameArgsForCall, struct {
@@ -677,6 +781,31 @@ func (fake *FakePipelineDB) UpdateNameReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) Archive() error {
+	fake.archiveMutex.Lock()
+	fake.archiveArgsForCall = append(fake.archiveArgsForCall, struct{}{})
+	fake.recordInvocation("Archive", []interface{}{})
+	fake.archiveMutex.Unlock()
+	if fake.ArchiveStub != nil {
+		return fake.ArchiveStub()
+	} else {
+		return fake.archiveReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) ArchiveCallCount() int {
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	return len(fake.archiveArgsForCall)
+}
+
+func (fake *FakePipelineDB) ArchiveReturns(result1 error) {
+	fake.ArchiveStub = nil
+	fake.archiveReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipelineDB) Destroy() error {
 	fake.destroyMutex.Lock()
 	fake.destroyArgsForCall = append(fake.destroyArgsForCall, struct{}{})
@@ -967,6 +1096,75 @@ func (fake *FakePipelineDB) UnpauseResourceReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) PinResourceVersion(resourceName string, versionedResourceID int, comment string, pinnedBy string) error {
+	fake.pinResourceVersionMutex.Lock()
+	fake.pinResourceVersionArgsForCall = append(fake.pinResourceVersionArgsForCall, struct {
+		resourceName        string
+		versionedResourceID int
+		comment             string
+		pinnedBy            string
+	}{resourceName, versionedResourceID, comment, pinnedBy})
+	fake.recordInvocation("PinResourceVersion", []interface{}{resourceName, versionedResourceID, comment, pinnedBy})
+	fake.pinResourceVersionMutex.Unlock()
+	if fake.PinResourceVersionStub != nil {
+		return fake.PinResourceVersionStub(resourceName, versionedResourceID, comment, pinnedBy)
+	} else {
+		return fake.pinResourceVersionReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) PinResourceVersionCallCount() int {
+	fake.pinResourceVersionMutex.RLock()
+	defer fake.pinResourceVersionMutex.RUnlock()
+	return len(fake.pinResourceVersionArgsForCall)
+}
+
+func (fake *FakePipelineDB) PinResourceVersionArgsForCall(i int) (string, int, string, string) {
+	fake.pinResourceVersionMutex.RLock()
+	defer fake.pinResourceVersionMutex.RUnlock()
+	return fake.pinResourceVersionArgsForCall[i].resourceName, fake.pinResourceVersionArgsForCall[i].versionedResourceID, fake.pinResourceVersionArgsForCall[i].comment, fake.pinResourceVersionArgsForCall[i].pinnedBy
+}
+
+func (fake *FakePipelineDB) PinResourceVersionReturns(result1 error) {
+	fake.PinResourceVersionStub = nil
+	fake.pinResourceVersionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) UnpinResource(resourceName string) error {
+	fake.unpinResourceMutex.Lock()
+	fake.unpinResourceArgsForCall = append(fake.unpinResourceArgsForCall, struct {
+		resourceName string
+	}{resourceName})
+	fake.recordInvocation("UnpinResource", []interface{}{resourceName})
+	fake.unpinResourceMutex.Unlock()
+	if fake.UnpinResourceStub != nil {
+		return fake.UnpinResourceStub(resourceName)
+	} else {
+		return fake.unpinResourceReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) UnpinResourceCallCount() int {
+	fake.unpinResourceMutex.RLock()
+	defer fake.unpinResourceMutex.RUnlock()
+	return len(fake.unpinResourceArgsForCall)
+}
+
+func (fake *FakePipelineDB) UnpinResourceArgsForCall(i int) string {
+	fake.unpinResourceMutex.RLock()
+	defer fake.unpinResourceMutex.RUnlock()
+	return fake.unpinResourceArgsForCall[i].resourceName
+}
+
+func (fake *FakePipelineDB) UnpinResourceReturns(result1 error) {
+	fake.UnpinResourceStub = nil
+	fake.unpinResourceReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipelineDB) SaveResourceVersions(arg1 atc.ResourceConfig, arg2 []atc.Version) error {
 	var arg2Copy []atc.Version
 	if arg2 != nil {
@@ -1386,6 +1584,73 @@ func (fake *FakePipelineDB) UnpauseJobReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) GetTaskCacheVolumes(job string) ([]db.SavedVolume, error) {
+	fake.getTaskCacheVolumesMutex.Lock()
+	fake.getTaskCacheVolumesArgsForCall = append(fake.getTaskCacheVolumesArgsForCall, struct {
+		job string
+	}{job})
+	fake.recordInvocation("GetTaskCacheVolumes", []interface{}{job})
+	fake.getTaskCacheVolumesMutex.Unlock()
+	if fake.GetTaskCacheVolumesStub != nil {
+		return fake.GetTaskCacheVolumesStub(job)
+	} else {
+		return fake.getTaskCacheVolumesReturns.result1, fake.getTaskCacheVolumesReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetTaskCacheVolumesCallCount() int {
+	fake.getTaskCacheVolumesMutex.RLock()
+	defer fake.getTaskCacheVolumesMutex.RUnlock()
+	return len(fake.getTaskCacheVolumesArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetTaskCacheVolumesArgsForCall(i int) string {
+	fake.getTaskCacheVolumesMutex.RLock()
+	defer fake.getTaskCacheVolumesMutex.RUnlock()
+	return fake.getTaskCacheVolumesArgsForCall[i].job
+}
+
+func (fake *FakePipelineDB) GetTaskCacheVolumesReturns(result1 []db.SavedVolume, result2 error) {
+	fake.GetTaskCacheVolumesStub = nil
+	fake.getTaskCacheVolumesReturns = struct {
+		result1 []db.SavedVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) ClearTaskCaches(job string) error {
+	fake.clearTaskCachesMutex.Lock()
+	fake.clearTaskCachesArgsForCall = append(fake.clearTaskCachesArgsForCall, struct {
+		job string
+	}{job})
+	fake.recordInvocation("ClearTaskCaches", []interface{}{job})
+	fake.clearTaskCachesMutex.Unlock()
+	if fake.ClearTaskCachesStub != nil {
+		return fake.ClearTaskCachesStub(job)
+	} else {
+		return fake.clearTaskCachesReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) ClearTaskCachesCallCount() int {
+	fake.clearTaskCachesMutex.RLock()
+	defer fake.clearTaskCachesMutex.RUnlock()
+	return len(fake.clearTaskCachesArgsForCall)
+}
+
+func (fake *FakePipelineDB) ClearTaskCachesArgsForCall(i int) string {
+	fake.clearTaskCachesMutex.RLock()
+	defer fake.clearTaskCachesMutex.RUnlock()
+	return fake.clearTaskCachesArgsForCall[i].job
+}
+
+func (fake *FakePipelineDB) ClearTaskCachesReturns(result1 error) {
+	fake.ClearTaskCachesStub = nil
+	fake.clearTaskCachesReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipelineDB) UpdateFirstLoggedBuildID(job string, newFirstLoggedBuildID int) error {
 	fake.updateFirstLoggedBuildIDMutex.Lock()
 	fake.updateFirstLoggedBuildIDArgsForCall = append(fake.updateFirstLoggedBuildIDArgsForCall, struct {
@@ -2205,6 +2470,10 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.getPipelineNameMutex.RUnlock()
 	fake.getPipelineIDMutex.RLock()
 	defer fake.getPipelineIDMutex.RUnlock()
+	fake.getTeamNameMutex.RLock()
+	defer fake.getTeamNameMutex.RUnlock()
+	fake.getTeamIDMutex.RLock()
+	defer fake.getTeamIDMutex.RUnlock()
 	fake.scopedNameMutex.RLock()
 	defer fake.scopedNameMutex.RUnlock()
 	fake.pauseMutex.RLock()
@@ -2215,6 +2484,8 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.isPausedMutex.RUnlock()
 	fake.updateNameMutex.RLock()
 	defer fake.updateNameMutex.RUnlock()
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
 	fake.destroyMutex.RLock()
 	defer fake.destroyMutex.RUnlock()
 	fake.getConfigMutex.RLock()
@@ -2233,6 +2504,10 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.pauseResourceMutex.RUnlock()
 	fake.unpauseResourceMutex.RLock()
 	defer fake.unpauseResourceMutex.RUnlock()
+	fake.pinResourceVersionMutex.RLock()
+	defer fake.pinResourceVersionMutex.RUnlock()
+	fake.unpinResourceMutex.RLock()
+	defer fake.unpinResourceMutex.RUnlock()
 	fake.saveResourceVersionsMutex.RLock()
 	defer fake.saveResourceVersionsMutex.RUnlock()
 	fake.saveResourceTypeVersionMutex.RLock()
@@ -2257,6 +2532,10 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.pauseJobMutex.RUnlock()
 	fake.unpauseJobMutex.RLock()
 	defer fake.unpauseJobMutex.RUnlock()
+	fake.getTaskCacheVolumesMutex.RLock()
+	defer fake.getTaskCacheVolumesMutex.RUnlock()
+	fake.clearTaskCachesMutex.RLock()
+	defer fake.clearTaskCachesMutex.RUnlock()
 	fake.updateFirstLoggedBuildIDMutex.RLock()
 	defer fake.updateFirstLoggedBuildIDMutex.RUnlock()
 	fake.getJobFinishedAndNextBuildMutex.RLock()
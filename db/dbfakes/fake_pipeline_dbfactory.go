@@ -4,6 +4,7 @@ package dbfakes
 import (
 	"sync"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 )
 
@@ -25,7 +26,7 @@ type FakePipelineDBFactory struct {
 		result1 db.PipelineDB
 		result2 error
 	}
-	BuildWithTeamNameAndNameStub        func(teamName, pipelineName string) (db.PipelineDB, error)
+	BuildWithTeamNameAndNameStub        func(teamName string, pipelineName string) (db.PipelineDB, error)
 	buildWithTeamNameAndNameMutex       sync.RWMutex
 	buildWithTeamNameAndNameArgsForCall []struct {
 		teamName     string
@@ -35,6 +36,17 @@ type FakePipelineDBFactory struct {
 		result1 db.PipelineDB
 		result2 error
 	}
+	BuildWithTeamNameAndInstanceStub        func(teamName string, pipelineName string, instanceVars atc.InstanceVars) (db.PipelineDB, error)
+	buildWithTeamNameAndInstanceMutex       sync.RWMutex
+	buildWithTeamNameAndInstanceArgsForCall []struct {
+		teamName     string
+		pipelineName string
+		instanceVars atc.InstanceVars
+	}
+	buildWithTeamNameAndInstanceReturns struct {
+		result1 db.PipelineDB
+		result2 error
+	}
 	BuildDefaultStub        func() (db.PipelineDB, bool, error)
 	buildDefaultMutex       sync.RWMutex
 	buildDefaultArgsForCall []struct{}
@@ -149,6 +161,42 @@ func (fake *FakePipelineDBFactory) BuildWithTeamNameAndNameReturns(result1 db.Pi
 	}{result1, result2}
 }
 
+func (fake *FakePipelineDBFactory) BuildWithTeamNameAndInstance(teamName string, pipelineName string, instanceVars atc.InstanceVars) (db.PipelineDB, error) {
+	fake.buildWithTeamNameAndInstanceMutex.Lock()
+	fake.buildWithTeamNameAndInstanceArgsForCall = append(fake.buildWithTeamNameAndInstanceArgsForCall, struct {
+		teamName     string
+		pipelineName string
+		instanceVars atc.InstanceVars
+	}{teamName, pipelineName, instanceVars})
+	fake.recordInvocation("BuildWithTeamNameAndInstance", []interface{}{teamName, pipelineName, instanceVars})
+	fake.buildWithTeamNameAndInstanceMutex.Unlock()
+	if fake.BuildWithTeamNameAndInstanceStub != nil {
+		return fake.BuildWithTeamNameAndInstanceStub(teamName, pipelineName, instanceVars)
+	} else {
+		return fake.buildWithTeamNameAndInstanceReturns.result1, fake.buildWithTeamNameAndInstanceReturns.result2
+	}
+}
+
+func (fake *FakePipelineDBFactory) BuildWithTeamNameAndInstanceCallCount() int {
+	fake.buildWithTeamNameAndInstanceMutex.RLock()
+	defer fake.buildWithTeamNameAndInstanceMutex.RUnlock()
+	return len(fake.buildWithTeamNameAndInstanceArgsForCall)
+}
+
+func (fake *FakePipelineDBFactory) BuildWithTeamNameAndInstanceArgsForCall(i int) (string, string, atc.InstanceVars) {
+	fake.buildWithTeamNameAndInstanceMutex.RLock()
+	defer fake.buildWithTeamNameAndInstanceMutex.RUnlock()
+	return fake.buildWithTeamNameAndInstanceArgsForCall[i].teamName, fake.buildWithTeamNameAndInstanceArgsForCall[i].pipelineName, fake.buildWithTeamNameAndInstanceArgsForCall[i].instanceVars
+}
+
+func (fake *FakePipelineDBFactory) BuildWithTeamNameAndInstanceReturns(result1 db.PipelineDB, result2 error) {
+	fake.BuildWithTeamNameAndInstanceStub = nil
+	fake.buildWithTeamNameAndInstanceReturns = struct {
+		result1 db.PipelineDB
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDBFactory) BuildDefault() (db.PipelineDB, bool, error) {
 	fake.buildDefaultMutex.Lock()
 	fake.buildDefaultArgsForCall = append(fake.buildDefaultArgsForCall, struct{}{})
@@ -185,6 +233,8 @@ func (fake *FakePipelineDBFactory) Invocations() map[string][][]interface{} {
 	defer fake.buildWithIDMutex.RUnlock()
 	fake.buildWithTeamNameAndNameMutex.RLock()
 	defer fake.buildWithTeamNameAndNameMutex.RUnlock()
+	fake.buildWithTeamNameAndInstanceMutex.RLock()
+	defer fake.buildWithTeamNameAndInstanceMutex.RUnlock()
 	fake.buildDefaultMutex.RLock()
 	defer fake.buildDefaultMutex.RUnlock()
 	return fake.invocations
@@ -4,6 +4,7 @@ package dbfakes
 import (
 	"sync"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 )
 
@@ -15,6 +16,15 @@ type FakePipelinesDB struct {
 		result1 []db.SavedPipeline
 		result2 error
 	}
+	GetPipelinesByTeamNameStub        func(teamName string) ([]db.SavedPipeline, error)
+	getPipelinesByTeamNameMutex       sync.RWMutex
+	getPipelinesByTeamNameArgsForCall []struct {
+		teamName string
+	}
+	getPipelinesByTeamNameReturns struct {
+		result1 []db.SavedPipeline
+		result2 error
+	}
 	GetPipelineByIDStub        func(pipelineID int) (db.SavedPipeline, error)
 	getPipelineByIDMutex       sync.RWMutex
 	getPipelineByIDArgsForCall []struct {
@@ -34,10 +44,59 @@ type FakePipelinesDB struct {
 		result1 db.SavedPipeline
 		result2 error
 	}
-	OrderPipelinesStub        func([]string) error
+	GetPipelineInstanceStub        func(teamName string, pipelineName string, instanceVars atc.InstanceVars) (db.SavedPipeline, error)
+	getPipelineInstanceMutex       sync.RWMutex
+	getPipelineInstanceArgsForCall []struct {
+		teamName     string
+		pipelineName string
+		instanceVars atc.InstanceVars
+	}
+	getPipelineInstanceReturns struct {
+		result1 db.SavedPipeline
+		result2 error
+	}
+	GetPipelineInstancesStub        func(teamName string, pipelineName string) ([]db.SavedPipeline, error)
+	getPipelineInstancesMutex       sync.RWMutex
+	getPipelineInstancesArgsForCall []struct {
+		teamName     string
+		pipelineName string
+	}
+	getPipelineInstancesReturns struct {
+		result1 []db.SavedPipeline
+		result2 error
+	}
+	PausePipelineInstancesStub        func(teamName string, pipelineName string) error
+	pausePipelineInstancesMutex       sync.RWMutex
+	pausePipelineInstancesArgsForCall []struct {
+		teamName     string
+		pipelineName string
+	}
+	pausePipelineInstancesReturns struct {
+		result1 error
+	}
+	UnpausePipelineInstancesStub        func(teamName string, pipelineName string) error
+	unpausePipelineInstancesMutex       sync.RWMutex
+	unpausePipelineInstancesArgsForCall []struct {
+		teamName     string
+		pipelineName string
+	}
+	unpausePipelineInstancesReturns struct {
+		result1 error
+	}
+	ArchivePipelineInstancesStub        func(teamName string, pipelineName string) error
+	archivePipelineInstancesMutex       sync.RWMutex
+	archivePipelineInstancesArgsForCall []struct {
+		teamName     string
+		pipelineName string
+	}
+	archivePipelineInstancesReturns struct {
+		result1 error
+	}
+	OrderPipelinesStub        func(teamName string, pipelineNames []string) error
 	orderPipelinesMutex       sync.RWMutex
 	orderPipelinesArgsForCall []struct {
-		arg1 []string
+		teamName      string
+		pipelineNames []string
 	}
 	orderPipelinesReturns struct {
 		result1 error
@@ -72,6 +131,40 @@ func (fake *FakePipelinesDB) GetAllPipelinesReturns(result1 []db.SavedPipeline,
 	}{result1, result2}
 }
 
+func (fake *FakePipelinesDB) GetPipelinesByTeamName(teamName string) ([]db.SavedPipeline, error) {
+	fake.getPipelinesByTeamNameMutex.Lock()
+	fake.getPipelinesByTeamNameArgsForCall = append(fake.getPipelinesByTeamNameArgsForCall, struct {
+		teamName string
+	}{teamName})
+	fake.recordInvocation("GetPipelinesByTeamName", []interface{}{teamName})
+	fake.getPipelinesByTeamNameMutex.Unlock()
+	if fake.GetPipelinesByTeamNameStub != nil {
+		return fake.GetPipelinesByTeamNameStub(teamName)
+	} else {
+		return fake.getPipelinesByTeamNameReturns.result1, fake.getPipelinesByTeamNameReturns.result2
+	}
+}
+
+func (fake *FakePipelinesDB) GetPipelinesByTeamNameCallCount() int {
+	fake.getPipelinesByTeamNameMutex.RLock()
+	defer fake.getPipelinesByTeamNameMutex.RUnlock()
+	return len(fake.getPipelinesByTeamNameArgsForCall)
+}
+
+func (fake *FakePipelinesDB) GetPipelinesByTeamNameArgsForCall(i int) string {
+	fake.getPipelinesByTeamNameMutex.RLock()
+	defer fake.getPipelinesByTeamNameMutex.RUnlock()
+	return fake.getPipelinesByTeamNameArgsForCall[i].teamName
+}
+
+func (fake *FakePipelinesDB) GetPipelinesByTeamNameReturns(result1 []db.SavedPipeline, result2 error) {
+	fake.GetPipelinesByTeamNameStub = nil
+	fake.getPipelinesByTeamNameReturns = struct {
+		result1 []db.SavedPipeline
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelinesDB) GetPipelineByID(pipelineID int) (db.SavedPipeline, error) {
 	fake.getPipelineByIDMutex.Lock()
 	fake.getPipelineByIDArgsForCall = append(fake.getPipelineByIDArgsForCall, struct {
@@ -141,20 +234,194 @@ func (fake *FakePipelinesDB) GetPipelineByTeamNameAndNameReturns(result1 db.Save
 	}{result1, result2}
 }
 
-func (fake *FakePipelinesDB) OrderPipelines(arg1 []string) error {
-	var arg1Copy []string
-	if arg1 != nil {
-		arg1Copy = make([]string, len(arg1))
-		copy(arg1Copy, arg1)
+func (fake *FakePipelinesDB) GetPipelineInstance(teamName string, pipelineName string, instanceVars atc.InstanceVars) (db.SavedPipeline, error) {
+	fake.getPipelineInstanceMutex.Lock()
+	fake.getPipelineInstanceArgsForCall = append(fake.getPipelineInstanceArgsForCall, struct {
+		teamName     string
+		pipelineName string
+		instanceVars atc.InstanceVars
+	}{teamName, pipelineName, instanceVars})
+	fake.recordInvocation("GetPipelineInstance", []interface{}{teamName, pipelineName, instanceVars})
+	fake.getPipelineInstanceMutex.Unlock()
+	if fake.GetPipelineInstanceStub != nil {
+		return fake.GetPipelineInstanceStub(teamName, pipelineName, instanceVars)
+	} else {
+		return fake.getPipelineInstanceReturns.result1, fake.getPipelineInstanceReturns.result2
+	}
+}
+
+func (fake *FakePipelinesDB) GetPipelineInstanceCallCount() int {
+	fake.getPipelineInstanceMutex.RLock()
+	defer fake.getPipelineInstanceMutex.RUnlock()
+	return len(fake.getPipelineInstanceArgsForCall)
+}
+
+func (fake *FakePipelinesDB) GetPipelineInstanceArgsForCall(i int) (string, string, atc.InstanceVars) {
+	fake.getPipelineInstanceMutex.RLock()
+	defer fake.getPipelineInstanceMutex.RUnlock()
+	return fake.getPipelineInstanceArgsForCall[i].teamName, fake.getPipelineInstanceArgsForCall[i].pipelineName, fake.getPipelineInstanceArgsForCall[i].instanceVars
+}
+
+func (fake *FakePipelinesDB) GetPipelineInstanceReturns(result1 db.SavedPipeline, result2 error) {
+	fake.GetPipelineInstanceStub = nil
+	fake.getPipelineInstanceReturns = struct {
+		result1 db.SavedPipeline
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelinesDB) GetPipelineInstances(teamName string, pipelineName string) ([]db.SavedPipeline, error) {
+	fake.getPipelineInstancesMutex.Lock()
+	fake.getPipelineInstancesArgsForCall = append(fake.getPipelineInstancesArgsForCall, struct {
+		teamName     string
+		pipelineName string
+	}{teamName, pipelineName})
+	fake.recordInvocation("GetPipelineInstances", []interface{}{teamName, pipelineName})
+	fake.getPipelineInstancesMutex.Unlock()
+	if fake.GetPipelineInstancesStub != nil {
+		return fake.GetPipelineInstancesStub(teamName, pipelineName)
+	} else {
+		return fake.getPipelineInstancesReturns.result1, fake.getPipelineInstancesReturns.result2
+	}
+}
+
+func (fake *FakePipelinesDB) GetPipelineInstancesCallCount() int {
+	fake.getPipelineInstancesMutex.RLock()
+	defer fake.getPipelineInstancesMutex.RUnlock()
+	return len(fake.getPipelineInstancesArgsForCall)
+}
+
+func (fake *FakePipelinesDB) GetPipelineInstancesArgsForCall(i int) (string, string) {
+	fake.getPipelineInstancesMutex.RLock()
+	defer fake.getPipelineInstancesMutex.RUnlock()
+	return fake.getPipelineInstancesArgsForCall[i].teamName, fake.getPipelineInstancesArgsForCall[i].pipelineName
+}
+
+func (fake *FakePipelinesDB) GetPipelineInstancesReturns(result1 []db.SavedPipeline, result2 error) {
+	fake.GetPipelineInstancesStub = nil
+	fake.getPipelineInstancesReturns = struct {
+		result1 []db.SavedPipeline
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelinesDB) PausePipelineInstances(teamName string, pipelineName string) error {
+	fake.pausePipelineInstancesMutex.Lock()
+	fake.pausePipelineInstancesArgsForCall = append(fake.pausePipelineInstancesArgsForCall, struct {
+		teamName     string
+		pipelineName string
+	}{teamName, pipelineName})
+	fake.recordInvocation("PausePipelineInstances", []interface{}{teamName, pipelineName})
+	fake.pausePipelineInstancesMutex.Unlock()
+	if fake.PausePipelineInstancesStub != nil {
+		return fake.PausePipelineInstancesStub(teamName, pipelineName)
+	} else {
+		return fake.pausePipelineInstancesReturns.result1
+	}
+}
+
+func (fake *FakePipelinesDB) PausePipelineInstancesCallCount() int {
+	fake.pausePipelineInstancesMutex.RLock()
+	defer fake.pausePipelineInstancesMutex.RUnlock()
+	return len(fake.pausePipelineInstancesArgsForCall)
+}
+
+func (fake *FakePipelinesDB) PausePipelineInstancesArgsForCall(i int) (string, string) {
+	fake.pausePipelineInstancesMutex.RLock()
+	defer fake.pausePipelineInstancesMutex.RUnlock()
+	return fake.pausePipelineInstancesArgsForCall[i].teamName, fake.pausePipelineInstancesArgsForCall[i].pipelineName
+}
+
+func (fake *FakePipelinesDB) PausePipelineInstancesReturns(result1 error) {
+	fake.PausePipelineInstancesStub = nil
+	fake.pausePipelineInstancesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelinesDB) UnpausePipelineInstances(teamName string, pipelineName string) error {
+	fake.unpausePipelineInstancesMutex.Lock()
+	fake.unpausePipelineInstancesArgsForCall = append(fake.unpausePipelineInstancesArgsForCall, struct {
+		teamName     string
+		pipelineName string
+	}{teamName, pipelineName})
+	fake.recordInvocation("UnpausePipelineInstances", []interface{}{teamName, pipelineName})
+	fake.unpausePipelineInstancesMutex.Unlock()
+	if fake.UnpausePipelineInstancesStub != nil {
+		return fake.UnpausePipelineInstancesStub(teamName, pipelineName)
+	} else {
+		return fake.unpausePipelineInstancesReturns.result1
+	}
+}
+
+func (fake *FakePipelinesDB) UnpausePipelineInstancesCallCount() int {
+	fake.unpausePipelineInstancesMutex.RLock()
+	defer fake.unpausePipelineInstancesMutex.RUnlock()
+	return len(fake.unpausePipelineInstancesArgsForCall)
+}
+
+func (fake *FakePipelinesDB) UnpausePipelineInstancesArgsForCall(i int) (string, string) {
+	fake.unpausePipelineInstancesMutex.RLock()
+	defer fake.unpausePipelineInstancesMutex.RUnlock()
+	return fake.unpausePipelineInstancesArgsForCall[i].teamName, fake.unpausePipelineInstancesArgsForCall[i].pipelineName
+}
+
+func (fake *FakePipelinesDB) UnpausePipelineInstancesReturns(result1 error) {
+	fake.UnpausePipelineInstancesStub = nil
+	fake.unpausePipelineInstancesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelinesDB) ArchivePipelineInstances(teamName string, pipelineName string) error {
+	fake.archivePipelineInstancesMutex.Lock()
+	fake.archivePipelineInstancesArgsForCall = append(fake.archivePipelineInstancesArgsForCall, struct {
+		teamName     string
+		pipelineName string
+	}{teamName, pipelineName})
+	fake.recordInvocation("ArchivePipelineInstances", []interface{}{teamName, pipelineName})
+	fake.archivePipelineInstancesMutex.Unlock()
+	if fake.ArchivePipelineInstancesStub != nil {
+		return fake.ArchivePipelineInstancesStub(teamName, pipelineName)
+	} else {
+		return fake.archivePipelineInstancesReturns.result1
+	}
+}
+
+func (fake *FakePipelinesDB) ArchivePipelineInstancesCallCount() int {
+	fake.archivePipelineInstancesMutex.RLock()
+	defer fake.archivePipelineInstancesMutex.RUnlock()
+	return len(fake.archivePipelineInstancesArgsForCall)
+}
+
+func (fake *FakePipelinesDB) ArchivePipelineInstancesArgsForCall(i int) (string, string) {
+	fake.archivePipelineInstancesMutex.RLock()
+	defer fake.archivePipelineInstancesMutex.RUnlock()
+	return fake.archivePipelineInstancesArgsForCall[i].teamName, fake.archivePipelineInstancesArgsForCall[i].pipelineName
+}
+
+func (fake *FakePipelinesDB) ArchivePipelineInstancesReturns(result1 error) {
+	fake.ArchivePipelineInstancesStub = nil
+	fake.archivePipelineInstancesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelinesDB) OrderPipelines(teamName string, pipelineNames []string) error {
+	var pipelineNamesCopy []string
+	if pipelineNames != nil {
+		pipelineNamesCopy = make([]string, len(pipelineNames))
+		copy(pipelineNamesCopy, pipelineNames)
 	}
 	fake.orderPipelinesMutex.Lock()
 	fake.orderPipelinesArgsForCall = append(fake.orderPipelinesArgsForCall, struct {
-		arg1 []string
-	}{arg1Copy})
-	fake.recordInvocation("OrderPipelines", []interface{}{arg1Copy})
+		teamName      string
+		pipelineNames []string
+	}{teamName, pipelineNamesCopy})
+	fake.recordInvocation("OrderPipelines", []interface{}{teamName, pipelineNamesCopy})
 	fake.orderPipelinesMutex.Unlock()
 	if fake.OrderPipelinesStub != nil {
-		return fake.OrderPipelinesStub(arg1)
+		return fake.OrderPipelinesStub(teamName, pipelineNames)
 	} else {
 		return fake.orderPipelinesReturns.result1
 	}
@@ -166,10 +433,10 @@ func (fake *FakePipelinesDB) OrderPipelinesCallCount() int {
 	return len(fake.orderPipelinesArgsForCall)
 }
 
-func (fake *FakePipelinesDB) OrderPipelinesArgsForCall(i int) []string {
+func (fake *FakePipelinesDB) OrderPipelinesArgsForCall(i int) (string, []string) {
 	fake.orderPipelinesMutex.RLock()
 	defer fake.orderPipelinesMutex.RUnlock()
-	return fake.orderPipelinesArgsForCall[i].arg1
+	return fake.orderPipelinesArgsForCall[i].teamName, fake.orderPipelinesArgsForCall[i].pipelineNames
 }
 
 func (fake *FakePipelinesDB) OrderPipelinesReturns(result1 error) {
@@ -184,10 +451,22 @@ func (fake *FakePipelinesDB) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.getAllPipelinesMutex.RLock()
 	defer fake.getAllPipelinesMutex.RUnlock()
+	fake.getPipelinesByTeamNameMutex.RLock()
+	defer fake.getPipelinesByTeamNameMutex.RUnlock()
 	fake.getPipelineByIDMutex.RLock()
 	defer fake.getPipelineByIDMutex.RUnlock()
 	fake.getPipelineByTeamNameAndNameMutex.RLock()
 	defer fake.getPipelineByTeamNameAndNameMutex.RUnlock()
+	fake.getPipelineInstanceMutex.RLock()
+	defer fake.getPipelineInstanceMutex.RUnlock()
+	fake.getPipelineInstancesMutex.RLock()
+	defer fake.getPipelineInstancesMutex.RUnlock()
+	fake.pausePipelineInstancesMutex.RLock()
+	defer fake.pausePipelineInstancesMutex.RUnlock()
+	fake.unpausePipelineInstancesMutex.RLock()
+	defer fake.unpausePipelineInstancesMutex.RUnlock()
+	fake.archivePipelineInstancesMutex.RLock()
+	defer fake.archivePipelineInstancesMutex.RUnlock()
 	fake.orderPipelinesMutex.RLock()
 	defer fake.orderPipelinesMutex.RUnlock()
 	return fake.invocations
@@ -0,0 +1,25 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/concourse/atc/db/encryption"
+)
+
+func decryptColumn(strategy encryption.Strategy, text string, nonce sql.NullString) ([]byte, error) {
+	if nonce.Valid {
+		return strategy.Decrypt(text, &nonce.String)
+	}
+
+	return strategy.Decrypt(text, nil)
+}
+
+func unmarshalEncryptedColumn(strategy encryption.Strategy, text string, nonce sql.NullString, dest interface{}) error {
+	payload, err := decryptColumn(strategy, text, nonce)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(payload, dest)
+}
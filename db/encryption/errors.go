@@ -0,0 +1,6 @@
+package encryption
+
+import "errors"
+
+var ErrDataIsEncrypted = errors.New("failed to decrypt data that is encrypted")
+var ErrDataIsNotEncrypted = errors.New("failed to decrypt data that is not encrypted")
@@ -0,0 +1,74 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// KeySize is the length of an AES-256 key.
+const KeySize = 32
+
+type Key struct {
+	aesgcm cipher.AEAD
+}
+
+func NewKey(aesgcm cipher.AEAD) *Key {
+	return &Key{
+		aesgcm: aesgcm,
+	}
+}
+
+// NewAESGCMKey returns a Key using AES-256 in GCM mode with the given key.
+func NewAESGCMKey(key []byte) (*Key, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes, got %d", KeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKey(aesgcm), nil
+}
+
+func (key *Key) Encrypt(plaintext []byte) (string, *string, error) {
+	nonce := make([]byte, key.aesgcm.NonceSize())
+	_, err := io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return "", nil, err
+	}
+
+	ciphertext := key.aesgcm.Seal(nil, nonce, plaintext, nil)
+
+	encodedNonce := hex.EncodeToString(nonce)
+
+	return hex.EncodeToString(ciphertext), &encodedNonce, nil
+}
+
+func (key *Key) Decrypt(text string, encodedNonce *string) ([]byte, error) {
+	if encodedNonce == nil {
+		return nil, ErrDataIsNotEncrypted
+	}
+
+	nonce, err := hex.DecodeString(*encodedNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := hex.DecodeString(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.aesgcm.Open(nil, nonce, ciphertext, nil)
+}
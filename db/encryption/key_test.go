@@ -0,0 +1,89 @@
+package encryption_test
+
+import (
+	"strings"
+
+	. "github.com/concourse/atc/db/encryption"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Key", func() {
+	var key *Key
+
+	BeforeEach(func() {
+		var err error
+		key, err = NewAESGCMKey([]byte(strings.Repeat("a", KeySize)))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("round-trips values", func() {
+		ciphertext, nonce, err := key.Encrypt([]byte("super-secret"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nonce).NotTo(BeNil())
+		Expect(ciphertext).NotTo(ContainSubstring("super-secret"))
+
+		plaintext, err := key.Decrypt(ciphertext, nonce)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(plaintext)).To(Equal("super-secret"))
+	})
+
+	It("uses a fresh nonce every time", func() {
+		ciphertext1, nonce1, err := key.Encrypt([]byte("super-secret"))
+		Expect(err).NotTo(HaveOccurred())
+
+		ciphertext2, nonce2, err := key.Encrypt([]byte("super-secret"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(*nonce1).NotTo(Equal(*nonce2))
+		Expect(ciphertext1).NotTo(Equal(ciphertext2))
+	})
+
+	It("fails to decrypt values encrypted with another key", func() {
+		otherKey, err := NewAESGCMKey([]byte(strings.Repeat("b", KeySize)))
+		Expect(err).NotTo(HaveOccurred())
+
+		ciphertext, nonce, err := otherKey.Encrypt([]byte("super-secret"))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = key.Decrypt(ciphertext, nonce)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails to decrypt plaintext", func() {
+		_, err := key.Decrypt("super-secret", nil)
+		Expect(err).To(Equal(ErrDataIsNotEncrypted))
+	})
+
+	It("rejects keys of the wrong size", func() {
+		_, err := NewAESGCMKey([]byte("too-short"))
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("NoEncryption", func() {
+	var strategy Strategy
+
+	BeforeEach(func() {
+		strategy = NewNoEncryption()
+	})
+
+	It("stores values as plaintext", func() {
+		text, nonce, err := strategy.Encrypt([]byte("not-so-secret"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(text).To(Equal("not-so-secret"))
+		Expect(nonce).To(BeNil())
+
+		plaintext, err := strategy.Decrypt(text, nonce)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(plaintext)).To(Equal("not-so-secret"))
+	})
+
+	It("fails to decrypt encrypted values", func() {
+		nonce := "some-nonce"
+
+		_, err := strategy.Decrypt("ciphertext", &nonce)
+		Expect(err).To(Equal(ErrDataIsEncrypted))
+	})
+})
@@ -0,0 +1,21 @@
+package encryption
+
+// NoEncryption stores values in plaintext. It is used when no encryption key
+// is configured.
+type NoEncryption struct{}
+
+func NewNoEncryption() *NoEncryption {
+	return &NoEncryption{}
+}
+
+func (NoEncryption) Encrypt(plaintext []byte) (string, *string, error) {
+	return string(plaintext), nil, nil
+}
+
+func (NoEncryption) Decrypt(text string, nonce *string) ([]byte, error) {
+	if nonce != nil {
+		return nil, ErrDataIsEncrypted
+	}
+
+	return []byte(text), nil
+}
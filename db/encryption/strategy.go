@@ -0,0 +1,10 @@
+package encryption
+
+//go:generate counterfeiter . Strategy
+
+// Strategy encrypts and decrypts sensitive column values. The nonce is stored
+// alongside the value; a nil nonce means the value is stored in plaintext.
+type Strategy interface {
+	Encrypt(plaintext []byte) (string, *string, error)
+	Decrypt(text string, nonce *string) ([]byte, error)
+}
@@ -3,13 +3,21 @@ package db
 import "errors"
 
 var ErrNoVersions = errors.New("no versions found")
+var ErrVersionNotFound = errors.New("version not found for resource")
 var ErrNoBuild = errors.New("no build found")
 
 var ErrPipelineNotFound = errors.New("pipeline not found")
+var ErrPipelineArchived = errors.New("pipeline is archived")
 
 var ErrLockRowNotPresentOrAlreadyDeleted = errors.New("lock could not be acquired because it didn't exist or was already cleaned up")
 
 var ErrLockNotAvailable = errors.New("lock is currently held and cannot be immediately acquired")
 
+var ErrWorkerNotPresent = errors.New("worker not present")
+var ErrCannotPruneRunningWorker = errors.New("worker must be stalled or landed to be pruned")
+var ErrWorkerNotOwnedByTeam = errors.New("worker with the same name or address is not owned by the team")
+
 var ErrNoContainer = errors.New("no container found")
 var ErrMultipleContainersFound = errors.New("multiple containers found for given identifier")
+
+var ErrAPITokenExists = errors.New("api token with the same name already exists")
@@ -0,0 +1,12 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddOIDCAuthToTeams(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE teams
+		ADD COLUMN oidc_auth json null;
+	`)
+
+	return err
+}
@@ -0,0 +1,12 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddGitLabAuthToTeams(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE teams
+		ADD COLUMN gitlab_auth json null;
+	`)
+
+	return err
+}
@@ -0,0 +1,12 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddRolesToTeams(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE teams
+		ADD COLUMN roles json null;
+	`)
+
+	return err
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func CreateAPITokens(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE api_tokens (
+			id serial PRIMARY KEY,
+			team_id int NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+			name text NOT NULL,
+			token_hash text NOT NULL,
+			role text NOT NULL,
+			created_at timestamp with time zone NOT NULL DEFAULT now(),
+			expires_at timestamp with time zone,
+			last_used_at timestamp with time zone,
+			UNIQUE (team_id, name),
+			UNIQUE (token_hash)
+		)
+	`)
+
+	return err
+}
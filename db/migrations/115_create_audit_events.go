@@ -0,0 +1,27 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func CreateAuditEvents(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE audit_events (
+			id serial PRIMARY KEY,
+			created_at timestamp with time zone NOT NULL DEFAULT now(),
+			team_name text NOT NULL,
+			user_name text NOT NULL,
+			route text NOT NULL,
+			pipeline_name text,
+			job_name text,
+			resource_name text
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX audit_events_team_name_idx ON audit_events (team_name)
+	`)
+
+	return err
+}
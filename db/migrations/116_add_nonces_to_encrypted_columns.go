@@ -0,0 +1,34 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddNoncesToEncryptedColumns(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE pipelines
+		ADD COLUMN nonce text
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN nonce text
+	`)
+	if err != nil {
+		return err
+	}
+
+	// encrypted values are not valid JSON
+	_, err = tx.Exec(`
+		ALTER TABLE teams
+		ALTER COLUMN github_auth TYPE text,
+		ALTER COLUMN gitlab_auth TYPE text,
+		ALTER COLUMN oidc_auth TYPE text,
+		ADD COLUMN github_auth_nonce text,
+		ADD COLUMN gitlab_auth_nonce text,
+		ADD COLUMN oidc_auth_nonce text
+	`)
+
+	return err
+}
@@ -0,0 +1,13 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddTemplateToPipelines(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE pipelines
+		ADD COLUMN template text,
+		ADD COLUMN template_nonce text
+	`)
+
+	return err
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddInstanceVarsToPipelines(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE pipelines
+		ADD COLUMN instance_vars text NOT NULL DEFAULT '{}'
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE pipelines
+		DROP CONSTRAINT pipelines_name_team_id
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE pipelines
+		ADD CONSTRAINT pipelines_name_team_id_instance_vars UNIQUE (name, team_id, instance_vars)
+	`)
+
+	return err
+}
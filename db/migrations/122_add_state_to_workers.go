@@ -0,0 +1,12 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddStateToWorkers(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE workers
+		ADD COLUMN state text NOT NULL DEFAULT 'running'
+	`)
+
+	return err
+}
@@ -0,0 +1,14 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddCapacityToWorkers(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE workers
+		ADD COLUMN max_containers integer NOT NULL DEFAULT 0,
+		ADD COLUMN memory_capacity bigint NOT NULL DEFAULT 0,
+		ADD COLUMN cpu_capacity bigint NOT NULL DEFAULT 0
+	`)
+
+	return err
+}
@@ -0,0 +1,11 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddWorkersAvailableToBuildPreparation(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE build_preparation
+	ADD COLUMN workers_available text NOT NULL DEFAULT 'unknown'
+	`)
+	return err
+}
@@ -0,0 +1,19 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddTeamIDToWorkersAndContainers(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE workers
+	ADD COLUMN team_id integer REFERENCES teams (id) ON DELETE CASCADE
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+	ALTER TABLE containers
+	ADD COLUMN team_id integer REFERENCES teams (id) ON DELETE CASCADE
+	`)
+	return err
+}
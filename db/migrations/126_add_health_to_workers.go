@@ -0,0 +1,16 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddHealthToWorkers(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE workers
+	ADD COLUMN health_checked_at timestamp with time zone,
+	ADD COLUMN garden_latency bigint NOT NULL DEFAULT 0,
+	ADD COLUMN baggageclaim_latency bigint NOT NULL DEFAULT 0,
+	ADD COLUMN garden_error text,
+	ADD COLUMN baggageclaim_error text,
+	ADD COLUMN disk_in_bytes bigint NOT NULL DEFAULT 0
+	`)
+	return err
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddTeamIDToBuilds(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE builds
+	ADD COLUMN team_id integer REFERENCES teams (id) ON DELETE SET NULL
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+	UPDATE builds b
+	SET team_id = p.team_id
+	FROM jobs j, pipelines p
+	WHERE b.job_id = j.id
+	AND j.pipeline_id = p.id
+	`)
+	if err != nil {
+		return err
+	}
+
+	// one-off builds predate teams, so they belonged to the main team
+	_, err = tx.Exec(`
+	UPDATE builds
+	SET team_id = (SELECT id FROM teams WHERE name = 'main')
+	WHERE job_id IS NULL
+	`)
+	return err
+}
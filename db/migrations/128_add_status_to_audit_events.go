@@ -0,0 +1,11 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddStatusToAuditEvents(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE audit_events
+	ADD COLUMN status integer
+	`)
+	return err
+}
@@ -0,0 +1,13 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddLimitsToContainers(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE containers
+		ADD COLUMN memory_limit bigint NOT NULL DEFAULT 0,
+		ADD COLUMN cpu_limit bigint NOT NULL DEFAULT 0
+	`)
+
+	return err
+}
@@ -0,0 +1,12 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddConsecutiveHealthFailuresToWorkers(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE workers
+		ADD COLUMN consecutive_health_failures integer NOT NULL DEFAULT 0
+	`)
+
+	return err
+}
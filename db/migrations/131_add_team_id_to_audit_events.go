@@ -0,0 +1,21 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddTeamIDToAuditEvents(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE audit_events
+	ADD COLUMN team_id integer REFERENCES teams (id) ON DELETE SET NULL
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+	UPDATE audit_events a
+	SET team_id = t.id
+	FROM teams t
+	WHERE a.team_name = t.name
+	`)
+	return err
+}
@@ -0,0 +1,154 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/BurntSushi/migration"
+	"github.com/concourse/atc/db/encryption"
+)
+
+var ErrEncryptionKeyMissing = errors.New("database contains encrypted data but no encryption key was given")
+
+type encryptedColumn struct {
+	table       string
+	column      string
+	nonceColumn string
+}
+
+var encryptedColumns = []encryptedColumn{
+	{table: "pipelines", column: "config", nonceColumn: "nonce"},
+	{table: "pipelines", column: "template", nonceColumn: "template_nonce"},
+	{table: "builds", column: "engine_metadata", nonceColumn: "nonce"},
+	{table: "teams", column: "github_auth", nonceColumn: "github_auth_nonce"},
+	{table: "teams", column: "gitlab_auth", nonceColumn: "gitlab_auth_nonce"},
+	{table: "teams", column: "oidc_auth", nonceColumn: "oidc_auth_nonce"},
+}
+
+// MigrateEncryption brings the sensitive columns in line with the configured
+// keys. With only a new key, plaintext values are encrypted. With both keys,
+// values encrypted with the old key are re-encrypted with the new one. With
+// only an old key, values are decrypted back to plaintext.
+func MigrateEncryption(tx migration.LimitedTx, newKey *encryption.Key, oldKey *encryption.Key) error {
+	for _, column := range encryptedColumns {
+		err := column.migrate(tx, newKey, oldKey)
+		if err != nil {
+			return fmt.Errorf("failed to migrate %s.%s: %s", column.table, column.column, err)
+		}
+	}
+
+	return nil
+}
+
+type encryptedValue struct {
+	id    int
+	value string
+	nonce *string
+}
+
+func (column encryptedColumn) migrate(tx migration.LimitedTx, newKey *encryption.Key, oldKey *encryption.Key) error {
+	if newKey == nil && oldKey == nil {
+		var encrypted bool
+		err := tx.QueryRow(fmt.Sprintf(`
+			SELECT EXISTS (
+				SELECT 1 FROM %s WHERE %s IS NOT NULL
+			)
+		`, column.table, column.nonceColumn)).Scan(&encrypted)
+		if err != nil {
+			return err
+		}
+
+		if encrypted {
+			return ErrEncryptionKeyMissing
+		}
+
+		return nil
+	}
+
+	condition := column.column + " IS NOT NULL"
+	if oldKey == nil {
+		condition += " AND " + column.nonceColumn + " IS NULL"
+	} else if newKey == nil {
+		condition += " AND " + column.nonceColumn + " IS NOT NULL"
+	}
+
+	values, err := column.load(tx, condition)
+	if err != nil {
+		return err
+	}
+
+	for _, value := range values {
+		plaintext := []byte(value.value)
+
+		if value.nonce != nil {
+			plaintext, err = oldKey.Decrypt(value.value, value.nonce)
+			if err != nil {
+				if newKey != nil {
+					_, newErr := newKey.Decrypt(value.value, value.nonce)
+					if newErr == nil {
+						// already rotated, e.g. by a previous run with the same keys
+						continue
+					}
+				}
+
+				return err
+			}
+		}
+
+		var text string
+		var nonce *string
+		if newKey != nil {
+			text, nonce, err = newKey.Encrypt(plaintext)
+			if err != nil {
+				return err
+			}
+		} else {
+			text = string(plaintext)
+		}
+
+		_, err = tx.Exec(fmt.Sprintf(`
+			UPDATE %s
+			SET %s = $1, %s = $2
+			WHERE id = $3
+		`, column.table, column.column, column.nonceColumn), text, nonce, value.id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (column encryptedColumn) load(tx migration.LimitedTx, condition string) ([]encryptedValue, error) {
+	rows, err := tx.Query(fmt.Sprintf(`
+		SELECT id, %s, %s
+		FROM %s
+		WHERE %s
+	`, column.column, column.nonceColumn, column.table, condition))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	values := []encryptedValue{}
+
+	for rows.Next() {
+		var value encryptedValue
+		var nonce sql.NullString
+
+		err := rows.Scan(&value.id, &value.value, &nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		if nonce.Valid {
+			value.nonce = &nonce.String
+		}
+
+		values = append(values, value)
+	}
+
+	return values, rows.Err()
+}
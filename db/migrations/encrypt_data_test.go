@@ -0,0 +1,190 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/migration"
+	"github.com/concourse/atc/db/encryption"
+	. "github.com/concourse/atc/db/migrations"
+	"github.com/concourse/atc/postgresrunner"
+	_ "github.com/lib/pq"
+	"github.com/tedsuo/ifrit"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MigrateEncryption", func() {
+	var postgresRunner postgresrunner.Runner
+
+	var dbProcess ifrit.Process
+
+	var dbConn *sql.DB
+
+	var newKey *encryption.Key
+	var oldKey *encryption.Key
+
+	const plaintextConfig = `{"resources":[{"name":"some-resource","source":{"private_key":"super-secret"}}]}`
+
+	migrateEncryption := func(newKey *encryption.Key, oldKey *encryption.Key) error {
+		tx, err := dbConn.Begin()
+		Expect(err).NotTo(HaveOccurred())
+
+		defer tx.Rollback()
+
+		err = MigrateEncryption(tx, newKey, oldKey)
+		if err != nil {
+			return err
+		}
+
+		return tx.Commit()
+	}
+
+	readConfig := func() (string, *string) {
+		var config string
+		var nonce sql.NullString
+		err := dbConn.QueryRow(`SELECT config, nonce FROM pipelines WHERE name = 'some-pipeline'`).Scan(&config, &nonce)
+		Expect(err).NotTo(HaveOccurred())
+
+		if nonce.Valid {
+			return config, &nonce.String
+		}
+
+		return config, nil
+	}
+
+	BeforeEach(func() {
+		var err error
+
+		postgresRunner = postgresrunner.Runner{
+			Port: 5433 + GinkgoParallelNode(),
+		}
+
+		dbProcess = ifrit.Invoke(postgresRunner)
+
+		postgresRunner.CreateTestDB()
+
+		dbConn, err = migration.Open("postgres", postgresRunner.DataSourceName(), Migrations)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = dbConn.Exec(`INSERT INTO teams (name) VALUES ('main')`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = dbConn.Exec(`
+			INSERT INTO pipelines (name, config, version, ordering, team_id)
+			VALUES ('some-pipeline', $1, 1, 1, (SELECT id FROM teams WHERE name = 'main'))
+		`, plaintextConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		newKey, err = encryption.NewAESGCMKey([]byte(strings.Repeat("n", encryption.KeySize)))
+		Expect(err).NotTo(HaveOccurred())
+
+		oldKey, err = encryption.NewAESGCMKey([]byte(strings.Repeat("o", encryption.KeySize)))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		postgresRunner.DropTestDB()
+
+		dbProcess.Signal(os.Interrupt)
+		Eventually(dbProcess.Wait(), 10*time.Second).Should(Receive())
+	})
+
+	Context("with only a new key", func() {
+		It("encrypts plaintext values", func() {
+			Expect(migrateEncryption(newKey, nil)).To(Succeed())
+
+			config, nonce := readConfig()
+			Expect(config).NotTo(ContainSubstring("super-secret"))
+			Expect(nonce).NotTo(BeNil())
+
+			decrypted, err := newKey.Decrypt(config, nonce)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(decrypted)).To(Equal(plaintextConfig))
+		})
+
+		It("leaves values it already encrypted alone", func() {
+			Expect(migrateEncryption(newKey, nil)).To(Succeed())
+			config, nonce := readConfig()
+
+			Expect(migrateEncryption(newKey, nil)).To(Succeed())
+			sameConfig, sameNonce := readConfig()
+			Expect(sameConfig).To(Equal(config))
+			Expect(*sameNonce).To(Equal(*nonce))
+		})
+	})
+
+	Context("with a new and an old key", func() {
+		BeforeEach(func() {
+			Expect(migrateEncryption(oldKey, nil)).To(Succeed())
+		})
+
+		It("re-encrypts values with the new key", func() {
+			Expect(migrateEncryption(newKey, oldKey)).To(Succeed())
+
+			config, nonce := readConfig()
+
+			_, err := oldKey.Decrypt(config, nonce)
+			Expect(err).To(HaveOccurred())
+
+			decrypted, err := newKey.Decrypt(config, nonce)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(decrypted)).To(Equal(plaintextConfig))
+		})
+
+		It("can be run again with the same keys", func() {
+			Expect(migrateEncryption(newKey, oldKey)).To(Succeed())
+			Expect(migrateEncryption(newKey, oldKey)).To(Succeed())
+
+			config, nonce := readConfig()
+
+			decrypted, err := newKey.Decrypt(config, nonce)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(decrypted)).To(Equal(plaintextConfig))
+		})
+
+		It("fails if the old key is wrong", func() {
+			wrongKey, err := encryption.NewAESGCMKey([]byte(strings.Repeat("w", encryption.KeySize)))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(migrateEncryption(newKey, wrongKey)).NotTo(Succeed())
+		})
+	})
+
+	Context("with only an old key", func() {
+		BeforeEach(func() {
+			Expect(migrateEncryption(oldKey, nil)).To(Succeed())
+		})
+
+		It("decrypts values back to plaintext", func() {
+			Expect(migrateEncryption(nil, oldKey)).To(Succeed())
+
+			config, nonce := readConfig()
+			Expect(config).To(Equal(plaintextConfig))
+			Expect(nonce).To(BeNil())
+		})
+	})
+
+	Context("without any keys", func() {
+		It("succeeds when nothing is encrypted", func() {
+			Expect(migrateEncryption(nil, nil)).To(Succeed())
+
+			config, nonce := readConfig()
+			Expect(config).To(Equal(plaintextConfig))
+			Expect(nonce).To(BeNil())
+		})
+
+		It("fails when values are encrypted", func() {
+			Expect(migrateEncryption(newKey, nil)).To(Succeed())
+
+			err := migrateEncryption(nil, nil)
+			Expect(err).To(MatchError(ContainSubstring(ErrEncryptionKeyMissing.Error())))
+		})
+	})
+})
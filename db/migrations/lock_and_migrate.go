@@ -7,12 +7,17 @@ import (
 	"time"
 
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/encryption"
 	"github.com/pivotal-golang/lager"
 
 	"github.com/BurntSushi/migration"
 )
 
-func LockDBAndMigrate(logger lager.Logger, sqlDriver string, sqlDataSource string) (db.Conn, error) {
+// LockDBAndMigrate runs the migrations and then encrypts, rotates or decrypts
+// the sensitive columns according to the given keys, holding an advisory lock
+// so that only one ATC does so at a time. The returned Conn encrypts with the
+// new key, if given.
+func LockDBAndMigrate(logger lager.Logger, sqlDriver string, sqlDataSource string, newKey *encryption.Key, oldKey *encryption.Key) (db.Conn, error) {
 	var err error
 	var dbLockConn db.Conn
 	var dbConn db.Conn
@@ -44,23 +49,56 @@ func LockDBAndMigrate(logger lager.Logger, sqlDriver string, sqlDataSource strin
 		logger.Info("migration-lock-acquired")
 
 		migrations := Translogrifier(logger, Migrations)
-		dbConn, err = db.WrapWithError(migration.OpenWith(sqlDriver, sqlDataSource, migrations, safeGetVersion, safeSetVersion))
+		sqlDB, err := migration.OpenWith(sqlDriver, sqlDataSource, migrations, safeGetVersion, safeSetVersion)
 		if err != nil {
 			logger.Fatal("failed-to-run-migrations", err)
 		}
 
+		encryptionErr := migrateEncryption(sqlDB, newKey, oldKey)
+		if encryptionErr != nil {
+			logger.Error("failed-to-migrate-encryption", encryptionErr)
+		}
+
 		_, err = dbLockConn.Exec(`select pg_advisory_unlock($1)`, lockName)
 		if err != nil {
 			logger.Error("failed-to-release-lock", err)
 		}
 
 		dbLockConn.Close()
+
+		if encryptionErr != nil {
+			sqlDB.Close()
+			return nil, encryptionErr
+		}
+
+		if newKey != nil {
+			dbConn = db.WrapWithEncryption(sqlDB, newKey)
+		} else {
+			dbConn = db.Wrap(sqlDB)
+		}
+
 		break
 	}
 
 	return dbConn, nil
 }
 
+func migrateEncryption(sqlDB *sql.DB, newKey *encryption.Key, oldKey *encryption.Key) error {
+	tx, err := sqlDB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	err = MigrateEncryption(tx, newKey, oldKey)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func safeGetVersion(tx migration.LimitedTx) (int, error) {
 	v, err := getVersion(tx)
 	if err != nil {
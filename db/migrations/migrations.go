@@ -111,4 +111,25 @@ var Migrations = []migration.Migrator{
 	MakeContainersExpiresAtNullable,
 	AddContainerIDToVolumes,
 	AddOnDeleteSetNullToFKeyContainerId,
+	AddOIDCAuthToTeams,
+	AddGitLabAuthToTeams,
+	AddRolesToTeams,
+	CreateAPITokens,
+	CreateAuditEvents,
+	AddNoncesToEncryptedColumns,
+	AddTemplateToPipelines,
+	AddInstanceVarsToPipelines,
+	AddArchivedToPipelines,
+	AddPinnedVersionToResources,
+	AddTaskCacheToVolumes,
+	AddStateToWorkers,
+	AddCapacityToWorkers,
+	AddWorkersAvailableToBuildPreparation,
+	AddTeamIDToWorkersAndContainers,
+	AddHealthToWorkers,
+	AddTeamIDToBuilds,
+	AddStatusToAuditEvents,
+	AddLimitsToContainers,
+	AddConsecutiveHealthFailuresToWorkers,
+	AddTeamIDToAuditEvents,
 }
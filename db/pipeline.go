@@ -3,15 +3,18 @@ package db
 import "github.com/concourse/atc"
 
 type Pipeline struct {
-	Name    string
-	Config  atc.Config
-	Version ConfigVersion
+	Name         string
+	InstanceVars atc.InstanceVars
+	Config       atc.Config
+	Version      ConfigVersion
 }
 
 type SavedPipeline struct {
-	ID     int
-	Paused bool
-	TeamID int
+	ID       int
+	Paused   bool
+	Archived bool
+	TeamID   int
+	TeamName string
 
 	Pipeline
 }
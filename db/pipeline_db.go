@@ -19,6 +19,8 @@ import (
 type PipelineDB interface {
 	GetPipelineName() string
 	GetPipelineID() int
+	GetTeamName() string
+	GetTeamID() int
 	ScopedName(string) string
 
 	Pause() error
@@ -26,6 +28,7 @@ type PipelineDB interface {
 	IsPaused() (bool, error)
 	UpdateName(string) error
 
+	Archive() error
 	Destroy() error
 
 	GetConfig() (atc.Config, ConfigVersion, bool, error)
@@ -40,6 +43,9 @@ type PipelineDB interface {
 	PauseResource(resourceName string) error
 	UnpauseResource(resourceName string) error
 
+	PinResourceVersion(resourceName string, versionedResourceID int, comment string, pinnedBy string) error
+	UnpinResource(resourceName string) error
+
 	SaveResourceVersions(atc.ResourceConfig, []atc.Version) error
 	SaveResourceTypeVersion(atc.ResourceType, atc.Version) error
 	GetLatestVersionedResource(resourceName string) (SavedVersionedResource, bool, error)
@@ -53,6 +59,8 @@ type PipelineDB interface {
 	GetJob(job string) (SavedJob, error)
 	PauseJob(job string) error
 	UnpauseJob(job string) error
+	GetTaskCacheVolumes(job string) ([]SavedVolume, error)
+	ClearTaskCaches(job string) error
 	UpdateFirstLoggedBuildID(job string, newFirstLoggedBuildID int) error
 
 	GetJobFinishedAndNextBuild(job string) (*Build, *Build, error)
@@ -124,17 +132,39 @@ func (pdb *pipelineDB) GetPipelineID() int {
 	return pdb.ID
 }
 
+func (pdb *pipelineDB) GetTeamName() string {
+	return pdb.TeamName
+}
+
+func (pdb *pipelineDB) GetTeamID() int {
+	return pdb.TeamID
+}
+
 func (pdb *pipelineDB) ScopedName(name string) string {
 	return pdb.Name + ":" + name
 }
 
 func (pdb *pipelineDB) Unpause() error {
-	_, err := pdb.conn.Exec(`
+	result, err := pdb.conn.Exec(`
 		UPDATE pipelines
 		SET paused = false
 		WHERE id = $1
+		AND NOT archived
 	`, pdb.ID)
-	return err
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrPipelineArchived
+	}
+
+	return nil
 }
 
 func (pdb *pipelineDB) Pause() error {
@@ -146,6 +176,17 @@ func (pdb *pipelineDB) Pause() error {
 	return err
 }
 
+// Archive pauses the pipeline until its config is next saved, keeping its
+// builds around.
+func (pdb *pipelineDB) Archive() error {
+	_, err := pdb.conn.Exec(`
+		UPDATE pipelines
+		SET archived = true, paused = true
+		WHERE id = $1
+	`, pdb.ID)
+	return err
+}
+
 func (pdb *pipelineDB) UpdateName(newName string) error {
 	_, err := pdb.conn.Exec(`
 		UPDATE pipelines
@@ -197,14 +238,15 @@ func (pdb *pipelineDB) Destroy() error {
 }
 
 func (pdb *pipelineDB) GetConfig() (atc.Config, ConfigVersion, bool, error) {
-	var configBlob []byte
+	var encryptedConfig string
+	var nonce sql.NullString
 	var version int
 
 	err := pdb.conn.QueryRow(`
-			SELECT config, version
+			SELECT config, nonce, version
 			FROM pipelines
 			WHERE id = $1
-		`, pdb.ID).Scan(&configBlob, &version)
+		`, pdb.ID).Scan(&encryptedConfig, &nonce, &version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return atc.Config{}, 0, false, nil
@@ -213,8 +255,12 @@ func (pdb *pipelineDB) GetConfig() (atc.Config, ConfigVersion, bool, error) {
 		return atc.Config{}, 0, false, err
 	}
 
-	var config atc.Config
-	err = json.Unmarshal(configBlob, &config)
+	configBlob, err := decryptColumn(pdb.conn.EncryptionStrategy(), encryptedConfig, nonce)
+	if err != nil {
+		return atc.Config{}, 0, false, err
+	}
+
+	config, err := unmarshalConfig(configBlob)
 	if err != nil {
 		return atc.Config{}, 0, false, err
 	}
@@ -245,7 +291,7 @@ func (pdb *pipelineDB) GetResource(resourceName string) (SavedResource, bool, er
 
 func (pdb *pipelineDB) GetResources() ([]DashboardResource, atc.GroupConfigs, bool, error) {
 	rows, err := pdb.conn.Query(`
-			SELECT id, name, check_error, paused
+			SELECT id, name, check_error, paused, pinned_version_id, pin_comment, pinned_by
 			FROM resources
 			WHERE pipeline_id = $1
 		`, pdb.ID)
@@ -259,16 +305,12 @@ func (pdb *pipelineDB) GetResources() ([]DashboardResource, atc.GroupConfigs, bo
 	savedResources := map[string]SavedResource{}
 
 	for rows.Next() {
-		savedResource := SavedResource{PipelineName: pdb.Name}
-		var checkErr sql.NullString
-		err := rows.Scan(&savedResource.ID, &savedResource.Name, &checkErr, &savedResource.Paused)
+		savedResource, err := scanResource(rows)
 		if err != nil {
 			return nil, nil, false, err
 		}
 
-		if checkErr.Valid {
-			savedResource.CheckError = errors.New(checkErr.String)
-		}
+		savedResource.PipelineName = pdb.Name
 		savedResources[savedResource.Name] = savedResource
 	}
 
@@ -585,15 +627,12 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 }
 
 func (pdb *pipelineDB) getResource(tx Tx, name string) (SavedResource, bool, error) {
-	var checkErr sql.NullString
-	var resource SavedResource
-
-	err := tx.QueryRow(`
-			SELECT id, name, check_error, paused
+	resource, err := scanResource(tx.QueryRow(`
+			SELECT id, name, check_error, paused, pinned_version_id, pin_comment, pinned_by
 			FROM resources
 			WHERE name = $1
 				AND pipeline_id = $2
-		`, name, pdb.ID).Scan(&resource.ID, &resource.Name, &checkErr, &resource.Paused)
+		`, name, pdb.ID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return SavedResource{}, false, nil
@@ -604,11 +643,30 @@ func (pdb *pipelineDB) getResource(tx Tx, name string) (SavedResource, bool, err
 
 	resource.PipelineName = pdb.GetPipelineName()
 
+	return resource, true, nil
+}
+
+func scanResource(row scannable) (SavedResource, error) {
+	var resource SavedResource
+	var checkErr, pinComment, pinnedBy sql.NullString
+	var pinnedVersionID sql.NullInt64
+
+	err := row.Scan(&resource.ID, &resource.Name, &checkErr, &resource.Paused, &pinnedVersionID, &pinComment, &pinnedBy)
+	if err != nil {
+		return SavedResource{}, err
+	}
+
 	if checkErr.Valid {
 		resource.CheckError = errors.New(checkErr.String)
 	}
 
-	return resource, true, nil
+	if pinnedVersionID.Valid {
+		resource.PinnedVersionID = int(pinnedVersionID.Int64)
+		resource.PinComment = pinComment.String
+		resource.PinnedBy = pinnedBy.String
+	}
+
+	return resource, nil
 }
 
 func (pdb *pipelineDB) GetResourceType(name string) (SavedResourceType, bool, error) {
@@ -698,6 +756,55 @@ func (pdb *pipelineDB) updatePaused(resource string, pause bool) error {
 	return tx.Commit()
 }
 
+func (pdb *pipelineDB) PinResourceVersion(resourceName string, versionedResourceID int, comment string, pinnedBy string) error {
+	result, err := pdb.conn.Exec(`
+		UPDATE resources r
+		SET pinned_version_id = v.id, pin_comment = $1, pinned_by = $2
+		FROM versioned_resources v
+		WHERE v.id = $3
+			AND v.resource_id = r.id
+			AND r.name = $4
+			AND r.pipeline_id = $5
+	`, comment, pinnedBy, versionedResourceID, resourceName, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrVersionNotFound
+	}
+
+	return nil
+}
+
+func (pdb *pipelineDB) UnpinResource(resourceName string) error {
+	result, err := pdb.conn.Exec(`
+		UPDATE resources
+		SET pinned_version_id = NULL, pin_comment = NULL, pinned_by = NULL
+		WHERE name = $1
+			AND pipeline_id = $2
+	`, resourceName, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	return nil
+}
+
 func (pdb *pipelineDB) SaveResourceVersions(config atc.ResourceConfig, versions []atc.Version) error {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
@@ -1064,7 +1171,7 @@ func (pdb *pipelineDB) GetJobBuild(job string, name string) (Build, bool, error)
 		INNER JOIN pipelines p ON j.pipeline_id = p.id
 		WHERE b.job_id = $1
 		AND b.name = $2
-	`, dbJob.ID, name))
+	`, dbJob.ID, name), pdb.conn.EncryptionStrategy())
 	if err != nil {
 		return Build{}, false, err
 	}
@@ -1208,8 +1315,13 @@ func (pdb *pipelineDB) createJobBuild(jobName string, tx Tx) (Build, error) {
 	// RETURNING statement in lib/pq... sorry
 
 	build, _, err := scanBuild(tx.QueryRow(`
-		INSERT INTO builds (name, job_id, status)
-		VALUES ($1, $2, 'pending')
+		INSERT INTO builds (name, job_id, status, team_id)
+		VALUES ($1, $2, 'pending', (
+			SELECT p.team_id
+			FROM jobs j
+			INNER JOIN pipelines p ON j.pipeline_id = p.id
+			WHERE j.id = $2
+		))
 		RETURNING `+buildColumns+`,
 			(
 				SELECT j.name
@@ -1227,7 +1339,7 @@ func (pdb *pipelineDB) createJobBuild(jobName string, tx Tx) (Build, error) {
 				INNER JOIN pipelines p ON j.pipeline_id = p.id
 				WHERE j.id = job_id
 			)
-	`, name, dbJob.ID))
+	`, name, dbJob.ID), pdb.conn.EncryptionStrategy())
 	if err != nil {
 		return Build{}, err
 	}
@@ -1263,7 +1375,7 @@ func (pdb *pipelineDB) GetBuildsWithVersionAsInput(versionedResourceID int) ([]B
 
 	builds := []Build{}
 	for rows.Next() {
-		build, _, err := scanBuild(rows)
+		build, _, err := scanBuild(rows, pdb.conn.EncryptionStrategy())
 		if err != nil {
 			return nil, err
 		}
@@ -1290,7 +1402,7 @@ func (pdb *pipelineDB) GetBuildsWithVersionAsOutput(versionedResourceID int) ([]
 
 	builds := []Build{}
 	for rows.Next() {
-		build, _, err := scanBuild(rows)
+		build, _, err := scanBuild(rows, pdb.conn.EncryptionStrategy())
 		if err != nil {
 			return nil, err
 		}
@@ -1485,7 +1597,7 @@ func (pdb *pipelineDB) GetJobBuildForInputs(job string, inputs []BuildInput) (Bu
 		strings.Join(from, ", "),
 		strings.Join(conditions, "\nAND ")),
 		params...,
-	))
+	), pdb.conn.EncryptionStrategy())
 }
 
 func (pdb *pipelineDB) GetNextPendingBuild(job string) (Build, bool, error) {
@@ -1515,7 +1627,7 @@ func (pdb *pipelineDB) GetNextPendingBuild(job string) (Build, bool, error) {
 		AND b.status = 'pending'
 		ORDER BY b.id ASC
 		LIMIT 1
-	`, dbJob.ID))
+	`, dbJob.ID), pdb.conn.EncryptionStrategy())
 }
 
 func (pdb *pipelineDB) updateSerialGroupsForJob(jobName string, serialGroups []string) error {
@@ -1575,7 +1687,7 @@ func (pdb *pipelineDB) GetNextPendingBuildBySerialGroup(jobName string, serialGr
 			AND j.pipeline_id = $1
 		ORDER BY b.id ASC
 		LIMIT 1
-	`, args...))
+	`, args...), pdb.conn.EncryptionStrategy())
 }
 
 func (pdb *pipelineDB) GetRunningBuildsBySerialGroup(jobName string, serialGroups []string) ([]Build, error) {
@@ -1613,7 +1725,7 @@ func (pdb *pipelineDB) GetRunningBuildsBySerialGroup(jobName string, serialGroup
 	bs := []Build{}
 
 	for rows.Next() {
-		build, _, err := scanBuild(rows)
+		build, _, err := scanBuild(rows, pdb.conn.EncryptionStrategy())
 		if err != nil {
 			return nil, err
 		}
@@ -1631,7 +1743,7 @@ func (pdb *pipelineDB) GetBuild(buildID int) (Build, bool, error) {
 		INNER JOIN jobs j ON b.job_id = j.id
 		INNER JOIN pipelines p ON j.pipeline_id = p.id
 		WHERE b.id = $1
-	`, buildID))
+	`, buildID), pdb.conn.EncryptionStrategy())
 }
 
 func (pdb *pipelineDB) UpdateBuildPreparation(prep BuildPreparation) error {
@@ -1703,7 +1815,7 @@ func (pdb *pipelineDB) GetCurrentBuild(job string) (Build, bool, error) {
 	defer rows.Close()
 
 	if rows.Next() {
-		return scanBuild(rows)
+		return scanBuild(rows, pdb.conn.EncryptionStrategy())
 	}
 
 	pendingRows, err := pdb.conn.Query(`
@@ -1724,13 +1836,29 @@ func (pdb *pipelineDB) GetCurrentBuild(job string) (Build, bool, error) {
 	defer pendingRows.Close()
 
 	if pendingRows.Next() {
-		return scanBuild(pendingRows)
+		return scanBuild(pendingRows, pdb.conn.EncryptionStrategy())
 	}
 
 	return Build{}, false, nil
 }
 
-func (pdb *pipelineDB) getLatestModifiedTime() (time.Time, error) {
+// referencesOtherPipelines reports whether any of the pipeline's passed
+// constraints name a job in another pipeline, i.e. 'other-pipeline/job'.
+func (pdb *pipelineDB) referencesOtherPipelines() bool {
+	for _, job := range pdb.Config.Jobs {
+		for _, input := range config.JobInputs(job) {
+			for _, passed := range input.Passed {
+				if _, found := pdb.Config.Jobs.Lookup(passed); !found && strings.Contains(passed, "/") {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func (pdb *pipelineDB) getLatestModifiedTime(acrossTeam bool) (time.Time, error) {
 	var max_modified_time time.Time
 
 	err := pdb.conn.QueryRow(`
@@ -1746,7 +1874,9 @@ func (pdb *pipelineDB) getLatestModifiedTime() (time.Time, error) {
 			FROM build_outputs bo
 			LEFT OUTER JOIN versioned_resources v ON v.id = bo.versioned_resource_id
 			LEFT OUTER JOIN resources r ON r.id = v.resource_id
+			LEFT OUTER JOIN pipelines p ON p.id = r.pipeline_id
 			WHERE r.pipeline_id = $1
+			OR ($2 AND p.team_id = (SELECT team_id FROM pipelines WHERE id = $1))
 		) bo,
 		(
 			SELECT COALESCE(MAX(bi.modified_time), 'epoch') as bi_max
@@ -1761,13 +1891,17 @@ func (pdb *pipelineDB) getLatestModifiedTime() (time.Time, error) {
 			LEFT OUTER JOIN resources r ON r.id = vr.resource_id
 			WHERE r.pipeline_id = $1
 		) vr
-	`, pdb.ID).Scan(&max_modified_time)
+	`, pdb.ID, acrossTeam).Scan(&max_modified_time)
 
 	return max_modified_time, err
 }
 
 func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
-	latestModifiedTime, err := pdb.getLatestModifiedTime()
+	// the team's other pipelines only matter to pipelines whose passed
+	// constraints reference them, so the rest skip the team-wide queries
+	acrossTeam := pdb.referencesOtherPipelines()
+
+	latestModifiedTime, err := pdb.getLatestModifiedTime(acrossTeam)
 	if err != nil {
 		return nil, err
 	}
@@ -1812,6 +1946,48 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		db.BuildOutputs = append(db.BuildOutputs, output)
 	}
 
+	if acrossTeam {
+		// outputs of jobs in the team's other pipelines count as outputs of the
+		// same version of the identically named resource in this pipeline, so
+		// that they can satisfy 'other-pipeline/job' passed constraints
+		rows, err = pdb.conn.Query(`
+			SELECT lv.id, lv.check_order, lr.id, o.build_id, j.id
+			FROM build_outputs o, builds b, versioned_resources v, jobs j, resources r, pipelines p,
+				resources lr, versioned_resources lv
+			WHERE v.id = o.versioned_resource_id
+			AND b.id = o.build_id
+			AND j.id = b.job_id
+			AND r.id = v.resource_id
+			AND p.id = r.pipeline_id
+			AND lr.name = r.name
+			AND lv.resource_id = lr.id
+			AND lv.type = v.type
+			AND lv.version = v.version
+			AND v.enabled
+			AND lv.enabled
+			AND b.status = 'succeeded'
+			AND lr.pipeline_id = $1
+			AND p.id != $1
+			AND p.instance_vars = '{}'
+			AND p.team_id = (SELECT team_id FROM pipelines WHERE id = $1)
+		`, pdb.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			var output algorithm.BuildOutput
+			err := rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &output.BuildID, &output.JobID)
+			if err != nil {
+				return nil, err
+			}
+
+			output.ResourceVersion.CheckOrder = output.CheckOrder
+
+			db.BuildOutputs = append(db.BuildOutputs, output)
+		}
+	}
+
 	rows, err = pdb.conn.Query(`
     SELECT v.id, v.check_order, r.id, i.build_id, j.id
     FROM build_inputs i, builds b, versioned_resources v, jobs j, resources r
@@ -1859,6 +2035,31 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		db.ResourceVersions = append(db.ResourceVersions, output)
 	}
 
+	if acrossTeam {
+		rows, err = pdb.conn.Query(`
+			SELECT p.name || '/' || j.name, j.id
+			FROM jobs j, pipelines p
+			WHERE p.id = j.pipeline_id
+			AND p.id != $1
+			AND p.instance_vars = '{}'
+			AND p.team_id = (SELECT team_id FROM pipelines WHERE id = $1)
+		`, pdb.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			var name string
+			var id int
+			err := rows.Scan(&name, &id)
+			if err != nil {
+				return nil, err
+			}
+
+			db.JobIDs[name] = id
+		}
+	}
+
 	rows, err = pdb.conn.Query(`
     SELECT j.name, j.id
     FROM jobs j
@@ -1904,6 +2105,34 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 	return db, nil
 }
 
+func (pdb *pipelineDB) getPinnedResourceVersions() (map[string]int, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT name, pinned_version_id
+		FROM resources
+		WHERE pipeline_id = $1
+			AND pinned_version_id IS NOT NULL
+	`, pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	pinnedVersions := map[string]int{}
+	for rows.Next() {
+		var name string
+		var versionID int
+		err := rows.Scan(&name, &versionID)
+		if err != nil {
+			return nil, err
+		}
+
+		pinnedVersions[name] = versionID
+	}
+
+	return pinnedVersions, nil
+}
+
 func (pdb *pipelineDB) GetNextInputVersions(db *algorithm.VersionsDB, jobName string, inputs []config.JobInput) ([]BuildInput, bool, MissingInputReasons, error) {
 	if len(inputs) == 0 {
 		return []BuildInput{}, true, MissingInputReasons{}, nil
@@ -1912,14 +2141,21 @@ func (pdb *pipelineDB) GetNextInputVersions(db *algorithm.VersionsDB, jobName st
 	var inputConfigs algorithm.InputConfigs
 	missingInputReasons := algorithm.MissingInputReasons{}
 
+	pinnedResourceVersions, err := pdb.getPinnedResourceVersions()
+	if err != nil {
+		return []BuildInput{}, false, MissingInputReasons{}, err
+	}
+
 	for _, input := range inputs {
 		jobs := algorithm.JobSet{}
 		for _, jobName := range input.Passed {
 			jobs[db.JobIDs[jobName]] = struct{}{}
 		}
 
-		var pinnedVersionID int
-		if input.Version != nil && input.Version.Pinned != nil {
+		// a version pinned through the API takes precedence over the one
+		// pinned in the pipeline config
+		pinnedVersionID, pinned := pinnedResourceVersions[input.Resource]
+		if !pinned && input.Version != nil && input.Version.Pinned != nil {
 			versionJSON, err := json.Marshal(input.Version.Pinned)
 			if err != nil {
 				return []BuildInput{}, false, MissingInputReasons{}, err
@@ -2051,6 +2287,50 @@ func (pdb *pipelineDB) UpdateFirstLoggedBuildID(job string, newFirstLoggedBuildI
 	return tx.Commit()
 }
 
+func (pdb *pipelineDB) GetTaskCacheVolumes(job string) ([]SavedVolume, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT
+			v.worker_name,
+			v.ttl,
+			EXTRACT(epoch FROM v.expires_at - NOW()),
+			v.handle,
+			v.resource_version,
+			v.resource_hash,
+			v.id,
+			v.original_volume_handle,
+			v.output_name,
+			v.replicated_from,
+			v.path,
+			v.host_path_version,
+			v.task_cache_pipeline_id,
+			v.task_cache_job_name,
+			v.task_cache_step_name,
+			v.task_cache_path,
+			v.size_in_bytes,
+			c.ttl
+		FROM volumes v
+		LEFT JOIN containers c
+		ON v.container_id = c.id
+		WHERE v.task_cache_pipeline_id = $1
+		AND v.task_cache_job_name = $2
+		ORDER BY v.id ASC
+	`, pdb.ID, job)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanVolumes(rows)
+}
+
+func (pdb *pipelineDB) ClearTaskCaches(job string) error {
+	_, err := pdb.conn.Exec(`
+		DELETE FROM volumes
+		WHERE task_cache_pipeline_id = $1
+		AND task_cache_job_name = $2
+	`, pdb.ID, job)
+	return err
+}
+
 func (pdb *pipelineDB) updatePausedJob(job string, pause bool) error {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
@@ -2145,7 +2425,7 @@ func (pdb *pipelineDB) GetJobBuilds(jobName string, page Page) ([]Build, Paginat
 	builds := []Build{}
 
 	for rows.Next() {
-		build, _, err := scanBuild(rows)
+		build, _, err := scanBuild(rows, pdb.conn.EncryptionStrategy())
 		if err != nil {
 			return nil, Pagination{}, err
 		}
@@ -2208,7 +2488,7 @@ func (pdb *pipelineDB) GetAllJobBuilds(job string) ([]Build, error) {
 	bs := []Build{}
 
 	for rows.Next() {
-		build, _, err := scanBuild(rows)
+		build, _, err := scanBuild(rows, pdb.conn.EncryptionStrategy())
 		if err != nil {
 			return nil, err
 		}
@@ -2233,7 +2513,7 @@ func (pdb *pipelineDB) GetJobFinishedAndNextBuild(job string) (*Build, *Build, e
 			AND b.status NOT IN ('pending', 'started')
 		ORDER BY b.id DESC
 		LIMIT 1
-	`, job, pdb.ID))
+	`, job, pdb.ID), pdb.conn.EncryptionStrategy())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2252,7 +2532,7 @@ func (pdb *pipelineDB) GetJobFinishedAndNextBuild(job string) (*Build, *Build, e
 			AND status IN ('pending', 'started')
 		ORDER BY b.id ASC
 		LIMIT 1
-	`, job, pdb.ID))
+	`, job, pdb.ID), pdb.conn.EncryptionStrategy())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2377,7 +2657,7 @@ func (pdb *pipelineDB) getLastJobBuildsSatisfying(bRequirement string) (map[stri
 	for rows.Next() {
 		var build Build
 
-		build, scanned, err := scanBuild(rows)
+		build, scanned, err := scanBuild(rows, pdb.conn.EncryptionStrategy())
 		if err != nil {
 			return nil, err
 		}
@@ -1,11 +1,14 @@
 package db
 
+import "github.com/concourse/atc"
+
 //go:generate counterfeiter . PipelineDBFactory
 
 type PipelineDBFactory interface {
 	Build(pipeline SavedPipeline) PipelineDB
 	BuildWithID(pipelineID int) (PipelineDB, error)
 	BuildWithTeamNameAndName(teamName, pipelineName string) (PipelineDB, error)
+	BuildWithTeamNameAndInstance(teamName, pipelineName string, instanceVars atc.InstanceVars) (PipelineDB, error)
 	BuildDefault() (PipelineDB, bool, error)
 }
 
@@ -45,6 +48,15 @@ func (pdbf *pipelineDBFactory) BuildWithTeamNameAndName(teamName, pipelineName s
 	return pdbf.Build(savedPipeline), nil
 }
 
+func (pdbf *pipelineDBFactory) BuildWithTeamNameAndInstance(teamName, pipelineName string, instanceVars atc.InstanceVars) (PipelineDB, error) {
+	savedPipeline, err := pdbf.pipelinesDB.GetPipelineInstance(teamName, pipelineName, instanceVars)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdbf.Build(savedPipeline), nil
+}
+
 func (pdbf *pipelineDBFactory) Build(pipeline SavedPipeline) PipelineDB {
 	return &pipelineDB{
 		conn: pdbf.conn,
@@ -60,14 +72,18 @@ func (pdbf *pipelineDBFactory) BuildDefault() (PipelineDB, bool, error) {
 		return nil, false, err
 	}
 
-	if len(orderedPipelines) < 1 {
-		return nil, false, nil
-	}
+	for _, pipeline := range orderedPipelines {
+		if pipeline.Archived {
+			continue
+		}
 
-	return &pipelineDB{
-		conn: pdbf.conn,
-		bus:  pdbf.bus,
+		return &pipelineDB{
+			conn: pdbf.conn,
+			bus:  pdbf.bus,
+
+			SavedPipeline: pipeline,
+		}, true, nil
+	}
 
-		SavedPipeline: orderedPipelines[0],
-	}, true, nil
+	return nil, false, nil
 }
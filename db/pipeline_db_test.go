@@ -257,7 +257,7 @@ var _ = Describe("PipelineDB", func() {
 			build, err := fetchedPipelineDB.CreateJobBuild("some-job")
 			Expect(err).NotTo(HaveOccurred())
 
-			oneOffBuild, err := sqlDB.CreateOneOffBuild()
+			oneOffBuild, err := sqlDB.CreateOneOffBuild(atc.DefaultTeamName)
 			Expect(err).NotTo(HaveOccurred())
 
 			// populate jobs_serial_groups table
@@ -956,6 +956,78 @@ var _ = Describe("PipelineDB", func() {
 				Expect(buildInputs[0].VersionedResource.Version).To(Equal(db.Version{"version": "2"}))
 			})
 
+			Context("when a version of the resource is pinned through the API", func() {
+				var pinnedVR db.SavedVersionedResource
+
+				BeforeEach(func() {
+					resourceConfig := atc.ResourceConfig{
+						Name:   resource.Name,
+						Type:   "some-type",
+						Source: atc.Source{"some": "source"},
+					}
+
+					err := pipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					var found bool
+					pinnedVR, found, err = pipelineDB.GetLatestVersionedResource(resource.Name)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					err = pipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "2"}, {"version": "3"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					err = pipelineDB.PinResourceVersion(resource.Name, pinnedVR.ID, "some-comment", "some-user")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("uses the pinned version instead of the latest one", func() {
+					jobBuildInputs := []config.JobInput{
+						{
+							Name:     "some-input-name",
+							Resource: resource.Name,
+						},
+					}
+
+					buildInputs, found, _, err := loadAndGetNextInputVersions("some-job", jobBuildInputs)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(buildInputs[0].VersionedResource.Version).To(Equal(db.Version{"version": "1"}))
+				})
+
+				It("takes precedence over the version pinned in the config", func() {
+					jobBuildInputs := []config.JobInput{
+						{
+							Name:     "some-input-name",
+							Resource: resource.Name,
+							Version:  &atc.VersionConfig{Pinned: atc.Version{"version": "2"}},
+						},
+					}
+
+					buildInputs, found, _, err := loadAndGetNextInputVersions("some-job", jobBuildInputs)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(buildInputs[0].VersionedResource.Version).To(Equal(db.Version{"version": "1"}))
+				})
+
+				It("goes back to the latest version once unpinned", func() {
+					err := pipelineDB.UnpinResource(resource.Name)
+					Expect(err).NotTo(HaveOccurred())
+
+					jobBuildInputs := []config.JobInput{
+						{
+							Name:     "some-input-name",
+							Resource: resource.Name,
+						},
+					}
+
+					buildInputs, found, _, err := loadAndGetNextInputVersions("some-job", jobBuildInputs)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(buildInputs[0].VersionedResource.Version).To(Equal(db.Version{"version": "3"}))
+				})
+			})
+
 			It("returns not found when the pinned version cannot be found", func() {
 				jobBuildInputs := []config.JobInput{
 					{
@@ -1007,6 +1079,92 @@ var _ = Describe("PipelineDB", func() {
 					"some-input-name": "no versions available",
 				}))
 			})
+
+			Context("when the passed constraints reference a job in another pipeline of the team", func() {
+				var jobBuildInputs []config.JobInput
+
+				BeforeEach(func() {
+					crossPipelineConfig := pipelineConfig
+					crossPipelineConfig.Jobs = append(atc.JobConfigs{}, pipelineConfig.Jobs...)
+					crossPipelineConfig.Jobs = append(crossPipelineConfig.Jobs, atc.JobConfig{
+						Name: "cross-pipeline-job",
+						Plan: atc.PlanSequence{
+							{
+								Get:    resource.Name,
+								Passed: []string{"other-pipeline-name/a-job"},
+							},
+						},
+					})
+
+					_, _, err := sqlDB.SaveConfig(team.Name, "a-pipeline-name", crossPipelineConfig, savedPipeline.Version, db.PipelineNoChange)
+					Expect(err).NotTo(HaveOccurred())
+
+					crossSavedPipeline, err := sqlDB.GetPipelineByTeamNameAndName(team.Name, "a-pipeline-name")
+					Expect(err).NotTo(HaveOccurred())
+
+					pipelineDB = pipelineDBFactory.Build(crossSavedPipeline)
+
+					resourceConfig := atc.ResourceConfig{
+						Name:   resource.Name,
+						Type:   "some-type",
+						Source: atc.Source{"some": "source"},
+					}
+
+					err = pipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}, {"version": "2"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					err = otherPipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}, {"version": "2"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					jobBuildInputs = []config.JobInput{
+						{
+							Name:     "some-input-name",
+							Resource: resource.Name,
+							Passed:   []string{"other-pipeline-name/a-job"},
+						},
+					}
+				})
+
+				It("loads the IDs of the other pipeline's jobs", func() {
+					otherPipelineJob, err := otherPipelineDB.GetJob("a-job")
+					Expect(err).NotTo(HaveOccurred())
+
+					versions, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(versions.JobIDs).To(HaveKeyWithValue("other-pipeline-name/a-job", otherPipelineJob.ID))
+				})
+
+				It("returns not found until the other pipeline's job has succeeded with a version", func() {
+					_, found, reasons, err := loadAndGetNextInputVersions("some-job", jobBuildInputs)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+					Expect(reasons).To(Equal(db.MissingInputReasons{
+						"some-input-name": "no versions satisfy passed constraints",
+					}))
+				})
+
+				It("returns the local version of the resource that passed the other pipeline's job", func() {
+					otherVR, found, err := otherPipelineDB.GetLatestVersionedResource(resource.Name)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					otherPipelineBuild, err := otherPipelineDB.CreateJobBuild("a-job")
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = otherPipelineDB.SaveBuildOutput(otherPipelineBuild.ID, otherVR.VersionedResource, false)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = sqlDB.FinishBuild(otherPipelineBuild.ID, otherPipelineBuild.PipelineID, db.StatusSucceeded)
+					Expect(err).NotTo(HaveOccurred())
+
+					buildInputs, found, _, err := loadAndGetNextInputVersions("some-job", jobBuildInputs)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(buildInputs).To(HaveLen(1))
+					Expect(buildInputs[0].VersionedResource.PipelineID).To(Equal(savedPipeline.ID))
+					Expect(buildInputs[0].VersionedResource.Version).To(Equal(db.Version{"version": "2"}))
+				})
+			})
 		})
 
 		It("can load up the latest enabled versioned resource", func() {
@@ -1170,6 +1328,74 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Describe("pinning and unpinning resources", func() {
+			var savedVR db.SavedVersionedResource
+
+			BeforeEach(func() {
+				resourceConfig := atc.ResourceConfig{
+					Name:   resourceName,
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}
+
+				err := pipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = otherPipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				var found bool
+				savedVR, found, err = pipelineDB.GetLatestVersionedResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("starts out as unpinned", func() {
+				resource, _, err := pipelineDB.GetResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(resource.Pinned()).To(BeFalse())
+			})
+
+			It("can be pinned to a version with a comment and the pinning user", func() {
+				err := pipelineDB.PinResourceVersion(resourceName, savedVR.ID, "some-comment", "some-user")
+				Expect(err).NotTo(HaveOccurred())
+
+				pinnedResource, _, err := pipelineDB.GetResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pinnedResource.PinnedVersionID).To(Equal(savedVR.ID))
+				Expect(pinnedResource.PinComment).To(Equal("some-comment"))
+				Expect(pinnedResource.PinnedBy).To(Equal("some-user"))
+
+				resource, _, err := otherPipelineDB.GetResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resource.Pinned()).To(BeFalse())
+			})
+
+			It("cannot be pinned to a version of another resource", func() {
+				otherVR, found, err := otherPipelineDB.GetLatestVersionedResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = pipelineDB.PinResourceVersion(resourceName, otherVR.ID, "", "some-user")
+				Expect(err).To(Equal(db.ErrVersionNotFound))
+			})
+
+			It("can be unpinned", func() {
+				err := pipelineDB.PinResourceVersion(resourceName, savedVR.ID, "some-comment", "some-user")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = pipelineDB.UnpinResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+
+				unpinnedResource, _, err := pipelineDB.GetResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(unpinnedResource.Pinned()).To(BeFalse())
+				Expect(unpinnedResource.PinComment).To(BeEmpty())
+				Expect(unpinnedResource.PinnedBy).To(BeEmpty())
+			})
+		})
+
 		Describe("enabling and disabling versioned resources", func() {
 			It("returns an error if the resource or version is bogus", func() {
 				err := pipelineDB.EnableVersionedResource(42)
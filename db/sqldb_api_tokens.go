@@ -0,0 +1,154 @@
+package db
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+const apiTokenColumns = "t.id, t.team_id, t.name, t.role, t.expires_at, tm.name, tm.admin, t.created_at, t.last_used_at"
+
+func (db *SQLDB) CreateAPIToken(token APIToken, tokenHash string) (SavedAPIToken, error) {
+	var expiresAt pq.NullTime
+	if !token.ExpiresAt.IsZero() {
+		expiresAt = pq.NullTime{Time: token.ExpiresAt, Valid: true}
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return SavedAPIToken{}, err
+	}
+
+	defer tx.Rollback()
+
+	var id int
+	err = tx.QueryRow(`
+		INSERT INTO api_tokens (team_id, name, token_hash, role, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`, token.TeamID, token.Name, tokenHash, token.Role, expiresAt).Scan(&id)
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code.Name() == "unique_violation" {
+			return SavedAPIToken{}, ErrAPITokenExists
+		}
+
+		return SavedAPIToken{}, err
+	}
+
+	savedToken, err := scanAPIToken(tx.QueryRow(`
+		SELECT `+apiTokenColumns+`
+		FROM api_tokens t
+		JOIN teams tm ON tm.id = t.team_id
+		WHERE t.id = $1
+	`, id))
+	if err != nil {
+		return SavedAPIToken{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return SavedAPIToken{}, err
+	}
+
+	return savedToken, nil
+}
+
+func (db *SQLDB) GetAPITokens(teamName string) ([]SavedAPIToken, error) {
+	rows, err := db.conn.Query(`
+		SELECT `+apiTokenColumns+`
+		FROM api_tokens t
+		JOIN teams tm ON tm.id = t.team_id
+		WHERE tm.name = $1
+		ORDER BY t.name ASC
+	`, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	tokens := []SavedAPIToken{}
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+func (db *SQLDB) DeleteAPIToken(teamName string, tokenName string) (bool, error) {
+	result, err := db.conn.Exec(`
+		DELETE FROM api_tokens
+		WHERE name = $1
+		AND team_id = (SELECT id FROM teams WHERE name = $2)
+	`, tokenName, teamName)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// FindAPITokenByHash returns the unexpired token with the given hash.
+func (db *SQLDB) FindAPITokenByHash(tokenHash string) (SavedAPIToken, bool, error) {
+	token, err := scanAPIToken(db.conn.QueryRow(`
+		SELECT `+apiTokenColumns+`
+		FROM api_tokens t
+		JOIN teams tm ON tm.id = t.team_id
+		WHERE t.token_hash = $1
+		AND (t.expires_at IS NULL OR t.expires_at > now())
+	`, tokenHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return SavedAPIToken{}, false, nil
+		}
+
+		return SavedAPIToken{}, false, err
+	}
+
+	return token, true, nil
+}
+
+func (db *SQLDB) UpdateAPITokenLastUsed(tokenID int) error {
+	_, err := db.conn.Exec(`
+		UPDATE api_tokens
+		SET last_used_at = now()
+		WHERE id = $1
+	`, tokenID)
+
+	return err
+}
+
+func scanAPIToken(row scannable) (SavedAPIToken, error) {
+	var token SavedAPIToken
+	var expiresAt, lastUsedAt pq.NullTime
+
+	err := row.Scan(
+		&token.ID,
+		&token.TeamID,
+		&token.Name,
+		&token.Role,
+		&expiresAt,
+		&token.TeamName,
+		&token.TeamAdmin,
+		&token.CreatedAt,
+		&lastUsedAt,
+	)
+	if err != nil {
+		return SavedAPIToken{}, err
+	}
+
+	token.ExpiresAt = expiresAt.Time
+	token.LastUsedAt = lastUsedAt.Time
+
+	return token, nil
+}
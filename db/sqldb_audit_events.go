@@ -0,0 +1,145 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+const auditEventColumns = "id, created_at, team_name, team_id, user_name, route, pipeline_name, job_name, resource_name, status"
+
+func (db *SQLDB) SaveAuditEvent(event AuditEvent) error {
+	_, err := db.conn.Exec(`
+		INSERT INTO audit_events (team_name, team_id, user_name, route, pipeline_name, job_name, resource_name, status)
+		VALUES ($1, COALESCE(NULLIF($2, 0), (SELECT id FROM teams WHERE name ILIKE $8)), $3, $4, $5, $6, $7, $9)
+	`, event.TeamName, event.TeamID, event.UserName, event.Route, nullIfEmpty(event.PipelineName), nullIfEmpty(event.JobName), nullIfEmpty(event.ResourceName), likeTeamName(event.TeamName), event.Status)
+
+	return err
+}
+
+// GetAuditEvents returns a page of audit events, newest first. A zero team
+// ID returns the events of all teams.
+func (db *SQLDB) GetAuditEvents(teamID int, page Page) ([]SavedAuditEvent, Pagination, error) {
+	query := `
+		SELECT ` + auditEventColumns + `
+		FROM audit_events
+		WHERE ($1 = 0 OR team_id = $1)
+	`
+
+	var rows *sql.Rows
+	var err error
+
+	if page.Since == 0 && page.Until == 0 {
+		rows, err = db.conn.Query(fmt.Sprintf(`
+			%s
+			ORDER BY id DESC
+			LIMIT $2
+		`, query), teamID, page.Limit)
+	} else if page.Until != 0 {
+		rows, err = db.conn.Query(fmt.Sprintf(`
+			SELECT sub.*
+				FROM (
+						%s
+				AND id > $2
+				ORDER BY id ASC
+				LIMIT $3
+			) sub
+			ORDER BY sub.id DESC
+		`, query), teamID, page.Until, page.Limit)
+	} else {
+		rows, err = db.conn.Query(fmt.Sprintf(`
+			%s
+			AND id < $2
+			ORDER BY id DESC
+			LIMIT $3
+		`, query), teamID, page.Since, page.Limit)
+	}
+
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer rows.Close()
+
+	events := []SavedAuditEvent{}
+
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		events = append(events, event)
+	}
+
+	if len(events) == 0 {
+		return events, Pagination{}, nil
+	}
+
+	var minID int
+	var maxID int
+
+	err = db.conn.QueryRow(`
+		SELECT COALESCE(MAX(id), 0) as maxID,
+			COALESCE(MIN(id), 0) as minID
+		FROM audit_events
+		WHERE ($1 = 0 OR team_id = $1)
+	`, teamID).Scan(&maxID, &minID)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	first := events[0]
+	last := events[len(events)-1]
+
+	var pagination Pagination
+
+	if first.ID < maxID {
+		pagination.Previous = &Page{
+			Until: first.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	if last.ID > minID {
+		pagination.Next = &Page{
+			Since: last.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	return events, pagination, nil
+}
+
+func scanAuditEvent(row scannable) (SavedAuditEvent, error) {
+	var event SavedAuditEvent
+	var pipelineName, jobName, resourceName sql.NullString
+	var teamID, status sql.NullInt64
+
+	err := row.Scan(
+		&event.ID,
+		&event.CreatedAt,
+		&event.TeamName,
+		&teamID,
+		&event.UserName,
+		&event.Route,
+		&pipelineName,
+		&jobName,
+		&resourceName,
+		&status,
+	)
+	if err != nil {
+		return SavedAuditEvent{}, err
+	}
+
+	event.TeamID = int(teamID.Int64)
+	event.PipelineName = pipelineName.String
+	event.JobName = jobName.String
+	event.ResourceName = resourceName.String
+	event.Status = int(status.Int64)
+
+	return event, nil
+}
+
+func nullIfEmpty(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
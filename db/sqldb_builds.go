@@ -9,14 +9,26 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/encryption"
 	"github.com/concourse/atc/event"
 	"github.com/lib/pq"
 )
 
-const buildColumns = "id, name, job_id, status, scheduled, inputs_determined, engine, engine_metadata, start_time, end_time, reap_time"
-const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.status, b.scheduled, b.inputs_determined, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name"
+const buildColumns = "id, name, job_id, status, scheduled, inputs_determined, engine, engine_metadata, nonce, start_time, end_time, reap_time, team_id"
+const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.status, b.scheduled, b.inputs_determined, b.engine, b.engine_metadata, b.nonce, b.start_time, b.end_time, b.reap_time, b.team_id, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name"
 
 func (db *SQLDB) GetBuilds(page Page) ([]Build, Pagination, error) {
+	return db.getBuilds(0, page)
+}
+
+// GetTeamBuilds returns a page of the builds belonging to the given team.
+func (db *SQLDB) GetTeamBuilds(teamID int, page Page) ([]Build, Pagination, error) {
+	return db.getBuilds(teamID, page)
+}
+
+// getBuilds returns a page of builds, limited to the given team unless the
+// team ID is 0.
+func (db *SQLDB) getBuilds(teamID int, page Page) ([]Build, Pagination, error) {
 	query := `
 		SELECT ` + qualifiedBuildColumns + `
 		FROM builds b
@@ -30,27 +42,30 @@ func (db *SQLDB) GetBuilds(page Page) ([]Build, Pagination, error) {
 	if page.Since == 0 && page.Until == 0 {
 		rows, err = db.conn.Query(fmt.Sprintf(`
 			%s
+			WHERE ($2 = 0 OR b.team_id = $2)
 			ORDER BY b.id DESC
 			LIMIT $1
-		`, query), page.Limit)
+		`, query), page.Limit, teamID)
 	} else if page.Until != 0 {
 		rows, err = db.conn.Query(fmt.Sprintf(`
 			SELECT sub.*
 				FROM (
 						%s
 				WHERE b.id > $1
+				AND ($3 = 0 OR b.team_id = $3)
 				ORDER BY b.id ASC
 				LIMIT $2
 			) sub
 			ORDER BY sub.id DESC
-		`, query), page.Until, page.Limit)
+		`, query), page.Until, page.Limit, teamID)
 	} else {
 		rows, err = db.conn.Query(fmt.Sprintf(`
 			%s
 			WHERE b.id < $1
+			AND ($3 = 0 OR b.team_id = $3)
 			ORDER BY b.id DESC
 			LIMIT $2
-		`, query), page.Since, page.Limit)
+		`, query), page.Since, page.Limit, teamID)
 	}
 
 	if err != nil {
@@ -62,7 +77,7 @@ func (db *SQLDB) GetBuilds(page Page) ([]Build, Pagination, error) {
 	builds := []Build{}
 
 	for rows.Next() {
-		build, _, err := scanBuild(rows)
+		build, _, err := scanBuild(rows, db.conn.EncryptionStrategy())
 		if err != nil {
 			return nil, Pagination{}, err
 		}
@@ -81,7 +96,8 @@ func (db *SQLDB) GetBuilds(page Page) ([]Build, Pagination, error) {
 		SELECT COALESCE(MAX(b.id), 0) as maxID,
 			COALESCE(MIN(b.id), 0) as minID
 		FROM builds b
-	`).Scan(&maxID, &minID)
+		WHERE ($1 = 0 OR b.team_id = $1)
+	`, teamID).Scan(&maxID, &minID)
 	if err != nil {
 		return nil, Pagination{}, err
 	}
@@ -144,7 +160,7 @@ func (db *SQLDB) GetAllStartedBuilds() ([]Build, error) {
 	bs := []Build{}
 
 	for rows.Next() {
-		build, _, err := scanBuild(rows)
+		build, _, err := scanBuild(rows, db.conn.EncryptionStrategy())
 		if err != nil {
 			return nil, err
 		}
@@ -162,7 +178,7 @@ func (db *SQLDB) GetBuild(buildID int) (Build, bool, error) {
 		LEFT OUTER JOIN jobs j ON b.job_id = j.id
 		LEFT OUTER JOIN pipelines p ON j.pipeline_id = p.id
 		WHERE b.id = $1
-	`, buildID))
+	`, buildID), db.conn.EncryptionStrategy())
 }
 
 func (db *SQLDB) getPipelineName(buildID int) (string, error) {
@@ -355,7 +371,7 @@ func (db *SQLDB) GetBuildVersionedResources(buildID int) (SavedVersionedResource
 		WHERE b.id = $1 AND bo.explicit`)
 }
 
-func (db *SQLDB) CreateOneOffBuild() (Build, error) {
+func (db *SQLDB) CreateOneOffBuild(teamName string) (Build, error) {
 	tx, err := db.conn.Begin()
 	if err != nil {
 		return Build{}, err
@@ -364,10 +380,10 @@ func (db *SQLDB) CreateOneOffBuild() (Build, error) {
 	defer tx.Rollback()
 
 	build, _, err := scanBuild(tx.QueryRow(`
-		INSERT INTO builds (name, status)
-		VALUES (nextval('one_off_name'), 'pending')
-		RETURNING ` + buildColumns + `, null, null, null
-	`))
+		INSERT INTO builds (name, status, team_id)
+		VALUES (nextval('one_off_name'), 'pending', (SELECT id FROM teams WHERE name = $1))
+		RETURNING `+buildColumns+`, null, null, null
+	`, teamName), db.conn.EncryptionStrategy())
 	if err != nil {
 		return Build{}, err
 	}
@@ -412,6 +428,15 @@ func (db *SQLDB) UpdateBuildPreparation(buildPrep BuildPreparation) error {
 	return tx.Commit()
 }
 
+func (db *SQLDB) UpdateBuildPreparationWorkersAvailable(buildID int, status BuildPreparationStatus) error {
+	_, err := db.conn.Exec(`
+		UPDATE build_preparation
+		SET workers_available = $2
+		WHERE build_id = $1
+	`, buildID, string(status))
+	return err
+}
+
 func (db *SQLDB) ResetBuildPreparationsWithPipelinePaused(pipelineID int) error {
 	_, err := db.conn.Exec(`
 			UPDATE build_preparation
@@ -419,7 +444,8 @@ func (db *SQLDB) ResetBuildPreparationsWithPipelinePaused(pipelineID int) error
 			    paused_job='unknown',
 					max_running_builds='unknown',
 					inputs='{}',
-					inputs_satisfied='unknown'
+					inputs_satisfied='unknown',
+					workers_available='unknown'
 			FROM build_preparation bp, builds b, jobs j
 			WHERE bp.build_id = b.id AND b.job_id = j.id
 				AND j.pipeline_id = $1 AND b.status = 'pending' AND b.scheduled = false
@@ -428,6 +454,11 @@ func (db *SQLDB) ResetBuildPreparationsWithPipelinePaused(pipelineID int) error
 }
 
 func (db *SQLDB) StartBuild(buildID int, pipelineID int, engine, metadata string) (bool, error) {
+	encryptedMetadata, nonce, err := db.conn.EncryptionStrategy().Encrypt([]byte(metadata))
+	if err != nil {
+		return false, err
+	}
+
 	tx, err := db.conn.Begin()
 	if err != nil {
 		return false, err
@@ -439,11 +470,11 @@ func (db *SQLDB) StartBuild(buildID int, pipelineID int, engine, metadata string
 
 	err = tx.QueryRow(`
 		UPDATE builds
-		SET status = 'started', start_time = now(), engine = $2, engine_metadata = $3
+		SET status = 'started', start_time = now(), engine = $2, engine_metadata = $3, nonce = $4
 		WHERE id = $1
 		AND status = 'pending'
 		RETURNING start_time
-	`, buildID, engine, metadata).Scan(&startTime)
+	`, buildID, engine, encryptedMetadata, nonce).Scan(&startTime)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return false, nil
@@ -553,11 +584,16 @@ func (db *SQLDB) SaveBuildOutput(buildID int, vr VersionedResource, explicit boo
 }
 
 func (db *SQLDB) SaveBuildEngineMetadata(buildID int, engineMetadata string) error {
-	_, err := db.conn.Exec(`
+	encryptedMetadata, nonce, err := db.conn.EncryptionStrategy().Encrypt([]byte(engineMetadata))
+	if err != nil {
+		return err
+	}
+
+	_, err = db.conn.Exec(`
 		UPDATE builds
-		SET engine_metadata = $2
+		SET engine_metadata = $2, nonce = $3
 		WHERE id = $1
-	`, buildID, engineMetadata)
+	`, buildID, encryptedMetadata, nonce)
 	if err != nil {
 		return err
 	}
@@ -746,19 +782,19 @@ func (db *SQLDB) saveBuildEvent(tx Tx, buildID int, pipelineID int, event atc.Ev
 	return nil
 }
 
-func scanBuild(row scannable) (Build, bool, error) {
+func scanBuild(row scannable, strategy encryption.Strategy) (Build, bool, error) {
 	var id int
 	var name string
-	var jobID, pipelineID sql.NullInt64
+	var jobID, pipelineID, teamID sql.NullInt64
 	var status string
 	var scheduled bool
 	var inputsDetermined bool
-	var engine, engineMetadata, jobName, pipelineName sql.NullString
+	var engine, engineMetadata, nonce, jobName, pipelineName sql.NullString
 	var startTime pq.NullTime
 	var endTime pq.NullTime
 	var reapTime pq.NullTime
 
-	err := row.Scan(&id, &name, &jobID, &status, &scheduled, &inputsDetermined, &engine, &engineMetadata, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName)
+	err := row.Scan(&id, &name, &jobID, &status, &scheduled, &inputsDetermined, &engine, &engineMetadata, &nonce, &startTime, &endTime, &reapTime, &teamID, &jobName, &pipelineID, &pipelineName)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return Build{}, false, nil
@@ -767,6 +803,14 @@ func scanBuild(row scannable) (Build, bool, error) {
 		return Build{}, false, err
 	}
 
+	var metadata []byte
+	if engineMetadata.Valid {
+		metadata, err = decryptColumn(strategy, engineMetadata.String, nonce)
+		if err != nil {
+			return Build{}, false, err
+		}
+	}
+
 	build := Build{
 		ID:               id,
 		Name:             name,
@@ -775,11 +819,13 @@ func scanBuild(row scannable) (Build, bool, error) {
 		InputsDetermined: inputsDetermined,
 
 		Engine:         engine.String,
-		EngineMetadata: engineMetadata.String,
+		EngineMetadata: string(metadata),
 
 		StartTime: startTime.Time,
 		EndTime:   endTime.Time,
 		ReapTime:  reapTime.Time,
+
+		TeamID: int(teamID.Int64),
 	}
 
 	if jobID.Valid {
@@ -11,7 +11,7 @@ import (
 	"github.com/concourse/atc"
 )
 
-const containerColumns = "worker_name, resource_id, check_type, check_source, build_id, plan_id, stage, handle, b.name as build_name, r.name as resource_name, p.id as pipeline_id, p.name as pipeline_name, j.name as job_name, step_name, type, working_directory, env_variables, attempts, process_user, ttl, EXTRACT(epoch FROM expires_at - NOW()), c.id, resource_type_version"
+const containerColumns = "worker_name, resource_id, check_type, check_source, build_id, plan_id, stage, handle, b.name as build_name, r.name as resource_name, p.id as pipeline_id, p.name as pipeline_name, j.name as job_name, step_name, type, working_directory, env_variables, attempts, process_user, ttl, EXTRACT(epoch FROM expires_at - NOW()), c.id, resource_type_version, c.team_id, c.memory_limit, c.cpu_limit"
 
 const containerJoins = `
 		LEFT JOIN pipelines p
@@ -98,6 +98,11 @@ func (db *SQLDB) FindContainersByDescriptors(id Container) ([]SavedContainer, er
 		params = append(params, attemptsBlob)
 	}
 
+	if id.TeamID != 0 {
+		whereCriteria = append(whereCriteria, fmt.Sprintf("c.team_id = $%d", len(params)+1))
+		params = append(params, id.TeamID)
+	}
+
 	var rows *sql.Rows
 	selectQuery := `
 		SELECT ` + containerColumns + `
@@ -366,6 +371,12 @@ func (db *SQLDB) CreateContainer(
 		workerName = container.WorkerName
 	}
 
+	var teamID sql.NullInt64
+	if container.TeamID != 0 {
+		teamID.Int64 = int64(container.TeamID)
+		teamID.Valid = true
+	}
+
 	var attempts sql.NullString
 	if len(container.Attempts) > 0 {
 		attemptsBlob, err := json.Marshal(container.Attempts)
@@ -402,8 +413,8 @@ func (db *SQLDB) CreateContainer(
 		INSERT INTO containers (handle, resource_id, step_name, pipeline_id, build_id, type, worker_name,
 			expires_at, ttl, best_if_used_by, check_type, check_source, plan_id, working_directory,
 			env_variables, attempts, stage, image_resource_type, image_resource_source,
-			process_user, resource_type_version)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW() + $8::INTERVAL, $9,`+maxLifetimeValue+`, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+			process_user, resource_type_version, team_id, memory_limit, cpu_limit)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW() + $8::INTERVAL, $9,`+maxLifetimeValue+`, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
 		RETURNING id`,
 		container.Handle,
 		resourceID,
@@ -425,6 +436,9 @@ func (db *SQLDB) CreateContainer(
 		imageResourceSource,
 		user,
 		resourceTypeVersion,
+		teamID,
+		container.MemoryLimit,
+		container.CPULimit,
 	).Scan(&id)
 	if err != nil {
 		return SavedContainer{}, err
@@ -611,6 +625,7 @@ func scanContainer(row scannable) (SavedContainer, error) {
 		attempts            sql.NullString
 		ttlInSeconds        *float64
 		resourceTypeVersion []byte
+		teamID              sql.NullInt64
 	)
 	container := SavedContainer{}
 
@@ -638,6 +653,9 @@ func scanContainer(row scannable) (SavedContainer, error) {
 		&ttlInSeconds,
 		&container.ID,
 		&resourceTypeVersion,
+		&teamID,
+		&container.MemoryLimit,
+		&container.CPULimit,
 	)
 
 	if err != nil {
@@ -676,6 +694,10 @@ func scanContainer(row scannable) (SavedContainer, error) {
 		container.JobName = jobName.String
 	}
 
+	if teamID.Valid {
+		container.TeamID = int(teamID.Int64)
+	}
+
 	container.Type, err = ContainerTypeFromString(infoType)
 	if err != nil {
 		return SavedContainer{}, err
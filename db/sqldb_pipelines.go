@@ -6,9 +6,10 @@ import (
 	"fmt"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/encryption"
 )
 
-const pipelineColumns = "id, name, config, version, paused, team_id"
+const pipelineColumns = "id, name, instance_vars, config, nonce, version, paused, archived, team_id, (SELECT t.name FROM teams t WHERE t.id = team_id) AS team_name"
 
 func (db *SQLDB) GetPipelineByID(pipelineID int) (SavedPipeline, error) {
 	row := db.conn.QueryRow(`
@@ -17,27 +18,104 @@ func (db *SQLDB) GetPipelineByID(pipelineID int) (SavedPipeline, error) {
 		WHERE id = $1
 	`, pipelineID)
 
-	return scanPipeline(row)
+	return scanPipeline(row, db.conn.EncryptionStrategy())
 }
 
 func (db *SQLDB) GetPipelineByTeamNameAndName(teamName string, pipelineName string) (SavedPipeline, error) {
+	return db.GetPipelineInstance(teamName, pipelineName, nil)
+}
+
+func (db *SQLDB) GetPipelineInstance(teamName string, pipelineName string, instanceVars atc.InstanceVars) (SavedPipeline, error) {
+	instanceVarsPayload, err := marshalInstanceVars(instanceVars)
+	if err != nil {
+		return SavedPipeline{}, err
+	}
+
 	row := db.conn.QueryRow(`
 		SELECT `+pipelineColumns+`
 		FROM pipelines
 		WHERE name = $1
+		AND instance_vars = $2
 		AND team_id = (
-				SELECT id FROM teams WHERE name = $2
+				SELECT id FROM teams WHERE name = $3
 			)
+	`, pipelineName, instanceVarsPayload, teamName)
+
+	return scanPipeline(row, db.conn.EncryptionStrategy())
+}
+
+// GetPipelineInstances returns every pipeline with the given name, i.e. the
+// pipeline itself and all of its instances.
+func (db *SQLDB) GetPipelineInstances(teamName string, pipelineName string) ([]SavedPipeline, error) {
+	rows, err := db.conn.Query(`
+		SELECT `+pipelineColumns+`
+		FROM pipelines
+		WHERE name = $1
+		AND team_id = (
+			SELECT id FROM teams WHERE name = $2
+		)
+		ORDER BY instance_vars
 	`, pipelineName, teamName)
+	if err != nil {
+		return nil, err
+	}
 
-	return scanPipeline(row)
+	defer rows.Close()
+
+	pipelines := []SavedPipeline{}
+
+	for rows.Next() {
+		pipeline, err := scanPipeline(rows, db.conn.EncryptionStrategy())
+		if err != nil {
+			return nil, err
+		}
+
+		pipelines = append(pipelines, pipeline)
+	}
+
+	return pipelines, nil
+}
+
+func (db *SQLDB) PausePipelineInstances(teamName string, pipelineName string) error {
+	return db.updatePipelineInstancesPaused(teamName, pipelineName, true)
+}
+
+func (db *SQLDB) UnpausePipelineInstances(teamName string, pipelineName string) error {
+	return db.updatePipelineInstancesPaused(teamName, pipelineName, false)
+}
+
+// archived pipelines are always paused, and stay so until their config is
+// saved again
+func (db *SQLDB) updatePipelineInstancesPaused(teamName string, pipelineName string, pause bool) error {
+	_, err := db.conn.Exec(`
+		UPDATE pipelines
+		SET paused = $1
+		WHERE name = $2
+		AND team_id = (
+			SELECT id FROM teams WHERE name = $3
+		)
+		AND NOT archived
+	`, pause, pipelineName, teamName)
+	return err
+}
+
+func (db *SQLDB) ArchivePipelineInstances(teamName string, pipelineName string) error {
+	_, err := db.conn.Exec(`
+		UPDATE pipelines
+		SET archived = true, paused = true
+		WHERE name = $1
+		AND team_id = (
+			SELECT id FROM teams WHERE name = $2
+		)
+	`, pipelineName, teamName)
+	return err
 }
 
 func (db *SQLDB) GetAllPipelines() ([]SavedPipeline, error) {
 	rows, err := db.conn.Query(`
 		SELECT ` + pipelineColumns + `
 		FROM pipelines
-		ORDER BY ordering
+		ORDER BY ordering, name, instance_vars
 	`)
 	if err != nil {
 		return nil, err
@@ -48,7 +126,37 @@ func (db *SQLDB) GetAllPipelines() ([]SavedPipeline, error) {
 	pipelines := []SavedPipeline{}
 
 	for rows.Next() {
-		pipeline, err := scanPipeline(rows)
+		pipeline, err := scanPipeline(rows, db.conn.EncryptionStrategy())
+
+		if err != nil {
+			return nil, err
+		}
+
+		pipelines = append(pipelines, pipeline)
+	}
+
+	return pipelines, nil
+}
+
+func (db *SQLDB) GetPipelinesByTeamName(teamName string) ([]SavedPipeline, error) {
+	rows, err := db.conn.Query(`
+		SELECT `+pipelineColumns+`
+		FROM pipelines
+		WHERE team_id = (
+			SELECT id FROM teams WHERE name = $1
+		)
+		ORDER BY ordering, name, instance_vars
+	`, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	pipelines := []SavedPipeline{}
+
+	for rows.Next() {
+		pipeline, err := scanPipeline(rows, db.conn.EncryptionStrategy())
 
 		if err != nil {
 			return nil, err
@@ -60,7 +168,7 @@ func (db *SQLDB) GetAllPipelines() ([]SavedPipeline, error) {
 	return pipelines, nil
 }
 
-func (db *SQLDB) OrderPipelines(pipelineNames []string) error {
+func (db *SQLDB) OrderPipelines(teamName string, pipelineNames []string) error {
 	tx, err := db.conn.Begin()
 	if err != nil {
 		return err
@@ -68,6 +176,18 @@ func (db *SQLDB) OrderPipelines(pipelineNames []string) error {
 
 	defer tx.Rollback()
 
+	var teamID int
+
+	err = tx.QueryRow(`
+		SELECT id
+		FROM teams
+		WHERE name = $1
+	`, teamName).Scan(&teamID)
+
+	if err != nil {
+		return err
+	}
+
 	var pipelineCount int
 
 	err = tx.QueryRow(`
@@ -82,7 +202,8 @@ func (db *SQLDB) OrderPipelines(pipelineNames []string) error {
 	_, err = tx.Exec(`
 		UPDATE pipelines
 		SET ordering = $1
-	`, pipelineCount+1)
+		WHERE team_id = $2
+	`, pipelineCount+1, teamID)
 
 	if err != nil {
 		return err
@@ -93,7 +214,8 @@ func (db *SQLDB) OrderPipelines(pipelineNames []string) error {
 			UPDATE pipelines
 			SET ordering = $1
 			WHERE name = $2
-		`, i, name)
+			AND team_id = $3
+		`, i, name, teamID)
 
 		if err != nil {
 			return err
@@ -104,15 +226,16 @@ func (db *SQLDB) OrderPipelines(pipelineNames []string) error {
 }
 
 func (db *SQLDB) GetConfigByBuildID(buildID int) (atc.Config, ConfigVersion, error) {
-	var configBlob []byte
+	var encryptedConfig string
+	var nonce sql.NullString
 	var version int
 	err := db.conn.QueryRow(`
-			SELECT p.config, p.version
+			SELECT p.config, p.nonce, p.version
 			FROM builds b
 			INNER JOIN jobs j ON b.job_id = j.id
 			INNER JOIN pipelines p ON j.pipeline_id = p.id
 			WHERE b.ID = $1
-		`, buildID).Scan(&configBlob, &version)
+		`, buildID).Scan(&encryptedConfig, &nonce, &version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return atc.Config{}, 0, nil
@@ -121,8 +244,12 @@ func (db *SQLDB) GetConfigByBuildID(buildID int) (atc.Config, ConfigVersion, err
 		}
 	}
 
-	var config atc.Config
-	err = json.Unmarshal(configBlob, &config)
+	configBlob, err := decryptColumn(db.conn.EncryptionStrategy(), encryptedConfig, nonce)
+	if err != nil {
+		return atc.Config{}, 0, err
+	}
+
+	config, err := unmarshalConfig(configBlob)
 	if err != nil {
 		return atc.Config{}, 0, err
 	}
@@ -131,17 +258,27 @@ func (db *SQLDB) GetConfigByBuildID(buildID int) (atc.Config, ConfigVersion, err
 }
 
 func (db *SQLDB) GetConfig(teamName, pipelineName string) (atc.Config, atc.RawConfig, ConfigVersion, error) {
-	var configBlob []byte
+	return db.GetInstanceConfig(teamName, pipelineName, nil)
+}
+
+func (db *SQLDB) GetInstanceConfig(teamName, pipelineName string, instanceVars atc.InstanceVars) (atc.Config, atc.RawConfig, ConfigVersion, error) {
+	instanceVarsPayload, err := marshalInstanceVars(instanceVars)
+	if err != nil {
+		return atc.Config{}, atc.RawConfig(""), 0, err
+	}
+
+	var encryptedConfig string
+	var nonce sql.NullString
 	var version int
-	err := db.conn.QueryRow(`
-		SELECT config, version
+	err = db.conn.QueryRow(`
+		SELECT config, nonce, version
 		FROM pipelines
-		WHERE name = $1 AND team_id = (
+		WHERE name = $1 AND instance_vars = $2 AND team_id = (
 			SELECT id
 			FROM teams
-			WHERE name = $2
+			WHERE name = $3
 		)
-	`, pipelineName, teamName).Scan(&configBlob, &version)
+	`, pipelineName, instanceVarsPayload, teamName).Scan(&encryptedConfig, &nonce, &version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return atc.Config{}, atc.RawConfig(""), 0, nil
@@ -149,8 +286,12 @@ func (db *SQLDB) GetConfig(teamName, pipelineName string) (atc.Config, atc.RawCo
 		return atc.Config{}, atc.RawConfig(""), 0, err
 	}
 
-	var config atc.Config
-	err = json.Unmarshal(configBlob, &config)
+	configBlob, err := decryptColumn(db.conn.EncryptionStrategy(), encryptedConfig, nonce)
+	if err != nil {
+		return atc.Config{}, atc.RawConfig(""), 0, err
+	}
+
+	config, err := unmarshalConfig(configBlob)
 	if err != nil {
 		return atc.Config{}, atc.RawConfig(string(configBlob)), ConfigVersion(version), atc.MalformedConfigError{err}
 	}
@@ -184,12 +325,108 @@ func (state PipelinePausedState) Bool() *bool {
 
 func (db *SQLDB) SaveConfig(
 	teamName string, pipelineName string, config atc.Config, from ConfigVersion, pausedState PipelinePausedState,
+) (SavedPipeline, bool, error) {
+	return db.saveConfig(teamName, pipelineName, nil, config, nil, from, pausedState)
+}
+
+// SaveTemplatedConfig saves a config rendered from a template, storing the
+// template and its vars alongside it so that it can be re-rendered later.
+func (db *SQLDB) SaveTemplatedConfig(
+	teamName string, pipelineName string, config atc.Config, template atc.PipelineTemplate, from ConfigVersion, pausedState PipelinePausedState,
+) (SavedPipeline, bool, error) {
+	return db.saveConfig(teamName, pipelineName, nil, config, &template, from, pausedState)
+}
+
+// SaveInstanceConfig saves the config of one instance of a pipeline, along with
+// the template it was rendered from, if any. New instances are ordered
+// alongside the other instances of the pipeline.
+func (db *SQLDB) SaveInstanceConfig(
+	teamName string, pipelineName string, instanceVars atc.InstanceVars, config atc.Config, template *atc.PipelineTemplate, from ConfigVersion, pausedState PipelinePausedState,
+) (SavedPipeline, bool, error) {
+	return db.saveConfig(teamName, pipelineName, instanceVars, config, template, from, pausedState)
+}
+
+func (db *SQLDB) GetTemplate(teamName, pipelineName string) (atc.PipelineTemplate, bool, error) {
+	return db.GetInstanceTemplate(teamName, pipelineName, nil)
+}
+
+func (db *SQLDB) GetInstanceTemplate(teamName, pipelineName string, instanceVars atc.InstanceVars) (atc.PipelineTemplate, bool, error) {
+	instanceVarsPayload, err := marshalInstanceVars(instanceVars)
+	if err != nil {
+		return atc.PipelineTemplate{}, false, err
+	}
+
+	var encryptedTemplate sql.NullString
+	var nonce sql.NullString
+	err = db.conn.QueryRow(`
+		SELECT template, template_nonce
+		FROM pipelines
+		WHERE name = $1 AND instance_vars = $2 AND team_id = (
+			SELECT id
+			FROM teams
+			WHERE name = $3
+		)
+	`, pipelineName, instanceVarsPayload, teamName).Scan(&encryptedTemplate, &nonce)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.PipelineTemplate{}, false, nil
+		}
+		return atc.PipelineTemplate{}, false, err
+	}
+
+	if !encryptedTemplate.Valid {
+		return atc.PipelineTemplate{}, false, nil
+	}
+
+	var template atc.PipelineTemplate
+	err = unmarshalEncryptedColumn(db.conn.EncryptionStrategy(), encryptedTemplate.String, nonce, &template)
+	if err != nil {
+		return atc.PipelineTemplate{}, false, err
+	}
+
+	return template, true, nil
+}
+
+func (db *SQLDB) saveConfig(
+	teamName string, pipelineName string, instanceVars atc.InstanceVars, config atc.Config, template *atc.PipelineTemplate, from ConfigVersion, pausedState PipelinePausedState,
 ) (SavedPipeline, bool, error) {
 	payload, err := json.Marshal(config)
 	if err != nil {
 		return SavedPipeline{}, false, err
 	}
 
+	instanceVarsPayload, err := marshalInstanceVars(instanceVars)
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
+	expandedConfig, err := config.ExpandMatrices()
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
+	encryptedPayload, nonce, err := db.conn.EncryptionStrategy().Encrypt(payload)
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
+	// saving a plain config clears any template the pipeline had
+	var encryptedTemplate, templateNonce *string
+	if template != nil {
+		templatePayload, err := json.Marshal(template)
+		if err != nil {
+			return SavedPipeline{}, false, err
+		}
+
+		encrypted, nonce, err := db.conn.EncryptionStrategy().Encrypt(templatePayload)
+		if err != nil {
+			return SavedPipeline{}, false, err
+		}
+
+		encryptedTemplate = &encrypted
+		templateNonce = nonce
+	}
+
 	tx, err := db.conn.Begin()
 	if err != nil {
 		return SavedPipeline{}, false, err
@@ -205,10 +442,11 @@ func (db *SQLDB) SaveConfig(
 		SELECT COUNT(1)
 		FROM pipelines
 		WHERE name = $1
+		  AND instance_vars = $2
 		  AND team_id = (
-				SELECT id FROM teams WHERE name = $2
+				SELECT id FROM teams WHERE name = $3
 		  )
-	`, pipelineName, teamName).Scan(&existingConfig)
+	`, pipelineName, instanceVarsPayload, teamName).Scan(&existingConfig)
 	if err != nil {
 		return SavedPipeline{}, false, err
 	}
@@ -219,17 +457,28 @@ func (db *SQLDB) SaveConfig(
 		}
 
 		savedPipeline, err = scanPipeline(tx.QueryRow(`
-		INSERT INTO pipelines (name, config, version, ordering, paused, team_id)
+		INSERT INTO pipelines (name, instance_vars, config, nonce, template, template_nonce, version, ordering, paused, team_id)
 		VALUES (
 			$1,
 			$2,
-			nextval('config_version_seq'),
-			(SELECT COUNT(1) + 1 FROM pipelines),
 			$3,
-			(SELECT id FROM teams WHERE name = $4)
+			$4,
+			$5,
+			$6,
+			nextval('config_version_seq'),
+			COALESCE(
+				(
+					SELECT MIN(ordering) FROM pipelines
+					WHERE name = $1
+					AND team_id = (SELECT id FROM teams WHERE name = $8)
+				),
+				(SELECT COUNT(1) + 1 FROM pipelines)
+			),
+			$7,
+			(SELECT id FROM teams WHERE name = $8)
 		)
 		RETURNING `+pipelineColumns+`
-		`, pipelineName, payload, pausedState.Bool(), teamName))
+		`, pipelineName, instanceVarsPayload, encryptedPayload, nonce, encryptedTemplate, templateNonce, pausedState.Bool(), teamName), db.conn.EncryptionStrategy())
 		if err != nil {
 			return SavedPipeline{}, false, err
 		}
@@ -261,25 +510,27 @@ func (db *SQLDB) SaveConfig(
 		if pausedState == PipelineNoChange {
 			savedPipeline, err = scanPipeline(tx.QueryRow(`
 			UPDATE pipelines
-			SET config = $1, version = nextval('config_version_seq')
-			WHERE name = $2
-			AND version = $3
+			SET config = $1, nonce = $2, template = $3, template_nonce = $4, archived = false, version = nextval('config_version_seq')
+			WHERE name = $5
+			AND instance_vars = $6
+			AND version = $7
 			AND team_id = (
-				SELECT id FROM teams WHERE name = $4
+				SELECT id FROM teams WHERE name = $8
 			)
 			RETURNING `+pipelineColumns+`
-			`, payload, pipelineName, from, teamName))
+			`, encryptedPayload, nonce, encryptedTemplate, templateNonce, pipelineName, instanceVarsPayload, from, teamName), db.conn.EncryptionStrategy())
 		} else {
 			savedPipeline, err = scanPipeline(tx.QueryRow(`
 			UPDATE pipelines
-			SET config = $1, version = nextval('config_version_seq'), paused = $2
-			WHERE name = $3
-			AND version = $4
+			SET config = $1, nonce = $2, template = $3, template_nonce = $4, archived = false, version = nextval('config_version_seq'), paused = $5
+			WHERE name = $6
+			AND instance_vars = $7
+			AND version = $8
 			AND team_id = (
-				SELECT id FROM teams WHERE name = $5
+				SELECT id FROM teams WHERE name = $9
 			)
 			RETURNING `+pipelineColumns+`
-			`, payload, pausedState.Bool(), pipelineName, from, teamName))
+			`, encryptedPayload, nonce, encryptedTemplate, templateNonce, pausedState.Bool(), pipelineName, instanceVarsPayload, from, teamName), db.conn.EncryptionStrategy())
 		}
 
 		if err != nil && err != sql.ErrNoRows {
@@ -303,21 +554,21 @@ func (db *SQLDB) SaveConfig(
 		}
 	}
 
-	for _, resource := range config.Resources {
+	for _, resource := range expandedConfig.Resources {
 		err = db.registerResource(tx, resource.Name, savedPipeline.ID)
 		if err != nil {
 			return SavedPipeline{}, false, err
 		}
 	}
 
-	for _, resourceType := range config.ResourceTypes {
+	for _, resourceType := range expandedConfig.ResourceTypes {
 		err = db.registerResourceType(tx, resourceType, savedPipeline.ID)
 		if err != nil {
 			return SavedPipeline{}, false, err
 		}
 	}
 
-	for _, job := range config.Jobs {
+	for _, job := range expandedConfig.Jobs {
 		err = db.registerJob(tx, job.Name, savedPipeline.ID)
 		if err != nil {
 			return SavedPipeline{}, false, err
@@ -334,6 +585,34 @@ func (db *SQLDB) SaveConfig(
 	return savedPipeline, created, tx.Commit()
 }
 
+// marshalInstanceVars encodes instance vars as they are stored. Pipelines that
+// are not instances have no vars, stored as '{}'. Map keys are encoded in
+// order, so equal vars are always stored the same way.
+func marshalInstanceVars(instanceVars atc.InstanceVars) (string, error) {
+	if len(instanceVars) == 0 {
+		return "{}", nil
+	}
+
+	payload, err := json.Marshal(instanceVars)
+	if err != nil {
+		return "", err
+	}
+
+	return string(payload), nil
+}
+
+// unmarshalConfig decodes a pipeline's config as it was saved and expands any
+// job matrices in it.
+func unmarshalConfig(configBlob []byte) (atc.Config, error) {
+	var config atc.Config
+	err := json.Unmarshal(configBlob, &config)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	return config.ExpandMatrices()
+}
+
 func (db *SQLDB) registerJob(tx Tx, name string, pipelineID int) error {
 	_, err := tx.Exec(`
 		INSERT INTO jobs (name, pipeline_id)
@@ -389,33 +668,49 @@ func (db *SQLDB) registerResourceType(tx Tx, resourceType atc.ResourceType, pipe
 	return swallowUniqueViolation(err)
 }
 
-func scanPipeline(rows scannable) (SavedPipeline, error) {
+func scanPipeline(rows scannable, strategy encryption.Strategy) (SavedPipeline, error) {
 	var id int
 	var name string
-	var configBlob []byte
+	var instanceVarsPayload string
+	var encryptedConfig string
+	var nonce sql.NullString
 	var version int
 	var paused bool
+	var archived bool
 	var teamID int
+	var teamName string
 
-	err := rows.Scan(&id, &name, &configBlob, &version, &paused, &teamID)
+	err := rows.Scan(&id, &name, &instanceVarsPayload, &encryptedConfig, &nonce, &version, &paused, &archived, &teamID, &teamName)
 	if err != nil {
 		return SavedPipeline{}, err
 	}
 
-	var config atc.Config
-	err = json.Unmarshal(configBlob, &config)
+	instanceVars, err := atc.ParseInstanceVars(instanceVarsPayload)
+	if err != nil {
+		return SavedPipeline{}, err
+	}
+
+	configBlob, err := decryptColumn(strategy, encryptedConfig, nonce)
+	if err != nil {
+		return SavedPipeline{}, err
+	}
+
+	config, err := unmarshalConfig(configBlob)
 	if err != nil {
 		return SavedPipeline{}, err
 	}
 
 	return SavedPipeline{
-		ID:     id,
-		Paused: paused,
-		TeamID: teamID,
+		ID:       id,
+		Paused:   paused,
+		Archived: archived,
+		TeamID:   teamID,
+		TeamName: teamName,
 		Pipeline: Pipeline{
-			Name:    name,
-			Config:  config,
-			Version: ConfigVersion(version),
+			Name:         name,
+			InstanceVars: instanceVars,
+			Config:       config,
+			Version:      ConfigVersion(version),
 		},
 	}, nil
 }
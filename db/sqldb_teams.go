@@ -4,10 +4,12 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/encryption"
 )
 
 func (db *SQLDB) CreateDefaultTeamIfNotExists() error {
@@ -32,6 +34,8 @@ func (db *SQLDB) CreateDefaultTeamIfNotExists() error {
 	return err
 }
 
+const teamColumns = "id, name, admin, basic_auth, github_auth, github_auth_nonce, gitlab_auth, gitlab_auth_nonce, oidc_auth, oidc_auth_nonce, roles"
+
 func (db *SQLDB) SaveTeam(data Team) (SavedTeam, error) {
 	jsonEncodedBasicAuth, err := db.jsonEncodeTeamBasicAuth(data)
 	if err != nil {
@@ -41,42 +45,91 @@ func (db *SQLDB) SaveTeam(data Team) (SavedTeam, error) {
 	if err != nil {
 		return SavedTeam{}, err
 	}
+	jsonEncodedGitLabAuth, err := db.jsonEncodeTeamGitLabAuth(data)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+	jsonEncodedOIDCAuth, err := db.jsonEncodeTeamOIDCAuth(data)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+	jsonEncodedRoles, err := json.Marshal(data.Roles)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	strategy := db.conn.EncryptionStrategy()
+
+	encryptedGitHubAuth, gitHubAuthNonce, err := strategy.Encrypt([]byte(jsonEncodedGitHubAuth))
+	if err != nil {
+		return SavedTeam{}, err
+	}
+	encryptedGitLabAuth, gitLabAuthNonce, err := strategy.Encrypt([]byte(jsonEncodedGitLabAuth))
+	if err != nil {
+		return SavedTeam{}, err
+	}
+	encryptedOIDCAuth, oidcAuthNonce, err := strategy.Encrypt([]byte(jsonEncodedOIDCAuth))
+	if err != nil {
+		return SavedTeam{}, err
+	}
 
-	return db.queryTeam(fmt.Sprintf(`
+	return db.queryTeam(`
 	INSERT INTO teams (
-    name, basic_auth, github_auth
+    name, basic_auth, github_auth, github_auth_nonce, gitlab_auth, gitlab_auth_nonce, oidc_auth, oidc_auth_nonce, roles
 	) VALUES (
-		'%s', '%s', '%s'
+		$1, $2, $3, $4, $5, $6, $7, $8, $9
+	)
+	RETURNING `+teamColumns,
+		data.Name,
+		jsonEncodedBasicAuth,
+		encryptedGitHubAuth, gitHubAuthNonce,
+		encryptedGitLabAuth, gitLabAuthNonce,
+		encryptedOIDCAuth, oidcAuthNonce,
+		string(jsonEncodedRoles),
 	)
-	RETURNING id, name, admin, basic_auth, github_auth
-	`, data.Name, jsonEncodedBasicAuth, jsonEncodedGitHubAuth,
-	))
 }
 
-func (db *SQLDB) queryTeam(query string) (SavedTeam, error) {
-	var basicAuth, gitHubAuth sql.NullString
-	var savedTeam SavedTeam
-
+func (db *SQLDB) queryTeam(query string, args ...interface{}) (SavedTeam, error) {
 	tx, err := db.conn.Begin()
 	if err != nil {
 		return SavedTeam{}, err
 	}
 	defer tx.Rollback()
 
-	err = tx.QueryRow(query).Scan(
+	savedTeam, err := scanTeam(tx.QueryRow(query, args...), db.conn.EncryptionStrategy())
+	if err != nil {
+		return savedTeam, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return savedTeam, err
+	}
+
+	return savedTeam, nil
+}
+
+func scanTeam(rows scannable, strategy encryption.Strategy) (SavedTeam, error) {
+	var basicAuth, gitHubAuth, gitLabAuth, oidcAuth, roles sql.NullString
+	var gitHubAuthNonce, gitLabAuthNonce, oidcAuthNonce sql.NullString
+	var savedTeam SavedTeam
+
+	err := rows.Scan(
 		&savedTeam.ID,
 		&savedTeam.Name,
 		&savedTeam.Admin,
 		&basicAuth,
 		&gitHubAuth,
+		&gitHubAuthNonce,
+		&gitLabAuth,
+		&gitLabAuthNonce,
+		&oidcAuth,
+		&oidcAuthNonce,
+		&roles,
 	)
 	if err != nil {
 		return savedTeam, err
 	}
-	err = tx.Commit()
-	if err != nil {
-		return savedTeam, err
-	}
 
 	if basicAuth.Valid {
 		err = json.Unmarshal([]byte(basicAuth.String), &savedTeam.BasicAuth)
@@ -86,7 +139,28 @@ func (db *SQLDB) queryTeam(query string) (SavedTeam, error) {
 	}
 
 	if gitHubAuth.Valid {
-		err = json.Unmarshal([]byte(gitHubAuth.String), &savedTeam.GitHubAuth)
+		err = unmarshalEncryptedColumn(strategy, gitHubAuth.String, gitHubAuthNonce, &savedTeam.GitHubAuth)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
+	if gitLabAuth.Valid {
+		err = unmarshalEncryptedColumn(strategy, gitLabAuth.String, gitLabAuthNonce, &savedTeam.GitLabAuth)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
+	if oidcAuth.Valid {
+		err = unmarshalEncryptedColumn(strategy, oidcAuth.String, oidcAuthNonce, &savedTeam.OIDCAuth)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
+	if roles.Valid {
+		err = json.Unmarshal([]byte(roles.String), &savedTeam.Roles)
 		if err != nil {
 			return savedTeam, err
 		}
@@ -95,14 +169,38 @@ func (db *SQLDB) queryTeam(query string) (SavedTeam, error) {
 	return savedTeam, nil
 }
 
+func (db *SQLDB) GetTeams() ([]SavedTeam, error) {
+	rows, err := db.conn.Query(`
+		SELECT ` + teamColumns + `
+		FROM teams
+		ORDER BY id ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	teams := []SavedTeam{}
+
+	for rows.Next() {
+		team, err := scanTeam(rows, db.conn.EncryptionStrategy())
+		if err != nil {
+			return nil, err
+		}
+
+		teams = append(teams, team)
+	}
+
+	return teams, nil
+}
+
 func (db *SQLDB) GetTeamByName(teamName string) (SavedTeam, bool, error) {
-	query := fmt.Sprintf(`
-		SELECT id, name, admin, basic_auth, github_auth
+	savedTeam, err := db.queryTeam(`
+		SELECT `+teamColumns+`
 		FROM teams
-		WHERE name ILIKE '%s'
-	`, teamName,
-	)
-	savedTeam, err := db.queryTeam(query)
+		WHERE name ILIKE $1
+	`, likeTeamName(teamName))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return savedTeam, false, nil
@@ -129,14 +227,91 @@ func (db *SQLDB) UpdateTeamGitHubAuth(team Team) (SavedTeam, error) {
 		return SavedTeam{}, err
 	}
 
-	query := fmt.Sprintf(`
+	encryptedGitHubAuth, nonce, err := db.conn.EncryptionStrategy().Encrypt([]byte(gitHubAuth))
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	return db.queryTeam(`
+		UPDATE teams
+		SET github_auth = $1, github_auth_nonce = $2
+		WHERE name ILIKE $3
+		RETURNING `+teamColumns,
+		encryptedGitHubAuth, nonce, likeTeamName(team.Name),
+	)
+}
+
+func (db *SQLDB) jsonEncodeTeamGitLabAuth(team Team) (string, error) {
+	if team.GitLabAuth.ClientID == "" || team.GitLabAuth.ClientSecret == "" {
+		team.GitLabAuth = GitLabAuth{}
+	}
+
+	json, err := json.Marshal(team.GitLabAuth)
+	return string(json), err
+}
+
+func (db *SQLDB) UpdateTeamGitLabAuth(team Team) (SavedTeam, error) {
+	gitLabAuth, err := db.jsonEncodeTeamGitLabAuth(team)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	encryptedGitLabAuth, nonce, err := db.conn.EncryptionStrategy().Encrypt([]byte(gitLabAuth))
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	return db.queryTeam(`
+		UPDATE teams
+		SET gitlab_auth = $1, gitlab_auth_nonce = $2
+		WHERE name ILIKE $3
+		RETURNING `+teamColumns,
+		encryptedGitLabAuth, nonce, likeTeamName(team.Name),
+	)
+}
+
+func (db *SQLDB) jsonEncodeTeamOIDCAuth(team Team) (string, error) {
+	if team.OIDCAuth.Issuer == "" || team.OIDCAuth.ClientID == "" || team.OIDCAuth.ClientSecret == "" {
+		team.OIDCAuth = OIDCAuth{}
+	}
+
+	json, err := json.Marshal(team.OIDCAuth)
+	return string(json), err
+}
+
+func (db *SQLDB) UpdateTeamOIDCAuth(team Team) (SavedTeam, error) {
+	oidcAuth, err := db.jsonEncodeTeamOIDCAuth(team)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	encryptedOIDCAuth, nonce, err := db.conn.EncryptionStrategy().Encrypt([]byte(oidcAuth))
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	return db.queryTeam(`
+		UPDATE teams
+		SET oidc_auth = $1, oidc_auth_nonce = $2
+		WHERE name ILIKE $3
+		RETURNING `+teamColumns,
+		encryptedOIDCAuth, nonce, likeTeamName(team.Name),
+	)
+}
+
+func (db *SQLDB) UpdateTeamRoles(team Team) (SavedTeam, error) {
+	roles, err := json.Marshal(team.Roles)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	return db.queryTeam(`
 		UPDATE teams
-		SET github_auth = '%s'
-		WHERE name ILIKE '%s'
-		RETURNING id, name, admin, basic_auth, github_auth
-	`, gitHubAuth, team.Name,
+		SET roles = $1
+		WHERE name ILIKE $2
+		RETURNING `+teamColumns,
+		string(roles), likeTeamName(team.Name),
 	)
-	return db.queryTeam(query)
 }
 
 func (db *SQLDB) jsonEncodeTeamBasicAuth(team Team) (string, error) {
@@ -160,19 +335,152 @@ func (db *SQLDB) UpdateTeamBasicAuth(team Team) (SavedTeam, error) {
 		return SavedTeam{}, err
 	}
 
-	query := fmt.Sprintf(`
+	return db.queryTeam(`
+		UPDATE teams
+		SET basic_auth = $1
+		WHERE name ILIKE $2
+		RETURNING `+teamColumns,
+		basicAuth, likeTeamName(team.Name),
+	)
+}
+
+func (db *SQLDB) RenameTeam(currentName string, newName string) (SavedTeam, bool, error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return SavedTeam{}, false, err
+	}
+
+	defer tx.Rollback()
+
+	savedTeam, err := scanTeam(tx.QueryRow(`
 		UPDATE teams
-		SET basic_auth = '%s'
-		WHERE name ILIKE '%s'
-		RETURNING id, name, admin, basic_auth, github_auth
-	`, basicAuth, team.Name)
-	return db.queryTeam(query)
+		SET name = $2
+		WHERE name = $1
+		RETURNING `+teamColumns,
+		currentName, newName,
+	), db.conn.EncryptionStrategy())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return SavedTeam{}, false, nil
+		}
+
+		return SavedTeam{}, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return SavedTeam{}, false, err
+	}
+
+	return savedTeam, true, nil
 }
 
 func (db *SQLDB) DeleteTeamByName(teamName string) error {
-	_, err := db.conn.Exec(`
-    DELETE FROM teams
-		WHERE name ILIKE $1
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		SELECT p.id
+		FROM pipelines p, teams t
+		WHERE p.team_id = t.id
+		AND t.name = $1
 	`, teamName)
-	return err
+	if err != nil {
+		return err
+	}
+
+	var pipelineIDs []int
+	for rows.Next() {
+		var pipelineID int
+		err = rows.Scan(&pipelineID)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		pipelineIDs = append(pipelineIDs, pipelineID)
+	}
+
+	err = rows.Close()
+	if err != nil {
+		return err
+	}
+
+	for _, pipelineID := range pipelineIDs {
+		_, err = tx.Exec(`
+			DELETE FROM containers
+			WHERE pipeline_id = $1
+			OR build_id IN (
+				SELECT b.id
+				FROM builds b, jobs j
+				WHERE b.job_id = j.id
+				AND j.pipeline_id = $1
+			)
+		`, pipelineID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(fmt.Sprintf(`
+			DROP TABLE pipeline_build_events_%d
+		`, pipelineID))
+		if err != nil {
+			return err
+		}
+
+		// builds, jobs, and resources cascade from the pipeline
+		_, err = tx.Exec(`
+			DELETE FROM pipelines WHERE id = $1
+		`, pipelineID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// one-off builds belong to the team directly rather than through a
+	// pipeline; their events and preparation cascade from them
+	_, err = tx.Exec(`
+		DELETE FROM containers
+		WHERE build_id IN (
+			SELECT b.id
+			FROM builds b, teams t
+			WHERE b.team_id = t.id
+			AND t.name = $1
+		)
+	`, teamName)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM builds
+		WHERE team_id IN (
+			SELECT id FROM teams WHERE name = $1
+		)
+	`, teamName)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM teams
+		WHERE name = $1
+	`, teamName)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// likeTeamName escapes the wildcards in a team name, so that matching it with
+// ILIKE ignores only case.
+func likeTeamName(teamName string) string {
+	return likeEscaper.Replace(teamName)
 }
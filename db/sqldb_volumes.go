@@ -67,6 +67,22 @@ func (db *SQLDB) InsertVolume(data Volume) error {
 		columns = append(columns, "replicated_from")
 		params = append(params, data.Identifier.Replication.ReplicatedVolumeHandle)
 		values = append(values, fmt.Sprintf("$%d", len(params)))
+	case data.Identifier.TaskCache != nil:
+		columns = append(columns, "task_cache_pipeline_id")
+		params = append(params, data.Identifier.TaskCache.PipelineID)
+		values = append(values, fmt.Sprintf("$%d", len(params)))
+
+		columns = append(columns, "task_cache_job_name")
+		params = append(params, data.Identifier.TaskCache.JobName)
+		values = append(values, fmt.Sprintf("$%d", len(params)))
+
+		columns = append(columns, "task_cache_step_name")
+		params = append(params, data.Identifier.TaskCache.StepName)
+		values = append(values, fmt.Sprintf("$%d", len(params)))
+
+		columns = append(columns, "task_cache_path")
+		params = append(params, data.Identifier.TaskCache.Path)
+		values = append(values, fmt.Sprintf("$%d", len(params)))
 	}
 
 	_, err = tx.Exec(
@@ -120,6 +136,10 @@ func (db *SQLDB) GetVolumes() ([]SavedVolume, error) {
 			v.replicated_from,
 			v.path,
 			v.host_path_version,
+			v.task_cache_pipeline_id,
+			v.task_cache_job_name,
+			v.task_cache_step_name,
+			v.task_cache_path,
 			v.size_in_bytes,
 			c.ttl
 		FROM volumes v
@@ -168,6 +188,11 @@ func (db *SQLDB) GetVolumesByIdentifier(id VolumeIdentifier) ([]SavedVolume, err
 		}
 	case id.Replication != nil:
 		addParam("replicated_from", id.Replication.ReplicatedVolumeHandle)
+	case id.TaskCache != nil:
+		addParam("task_cache_pipeline_id", id.TaskCache.PipelineID)
+		addParam("task_cache_job_name", id.TaskCache.JobName)
+		addParam("task_cache_step_name", id.TaskCache.StepName)
+		addParam("task_cache_path", id.TaskCache.Path)
 	}
 
 	statement := `
@@ -184,6 +209,10 @@ func (db *SQLDB) GetVolumesByIdentifier(id VolumeIdentifier) ([]SavedVolume, err
 			v.replicated_from,
 			v.path,
 			v.host_path_version,
+			v.task_cache_pipeline_id,
+			v.task_cache_job_name,
+			v.task_cache_step_name,
+			v.task_cache_path,
 			v.size_in_bytes,
 			c.ttl
 		FROM volumes v
@@ -229,6 +258,10 @@ func (db *SQLDB) GetVolumesForOneOffBuildImageResources() ([]SavedVolume, error)
 			v.replicated_from,
 			v.path,
 			v.host_path_version,
+			v.task_cache_pipeline_id,
+			v.task_cache_job_name,
+			v.task_cache_step_name,
+			v.task_cache_path,
 			v.size_in_bytes,
 			c.ttl
 		FROM volumes v
@@ -319,6 +352,10 @@ func (db *SQLDB) getVolume(originalVolumeHandle string) (SavedVolume, error) {
 			v.replicated_from,
 			v.path,
 			v.host_path_version,
+			v.task_cache_pipeline_id,
+			v.task_cache_job_name,
+			v.task_cache_step_name,
+			v.task_cache_path,
 			v.size_in_bytes,
 			c.ttl
 		FROM volumes v
@@ -370,6 +407,10 @@ func scanVolumes(rows *sql.Rows) ([]SavedVolume, error) {
 			replicationName      sql.NullString
 			path                 sql.NullString
 			hostPathVersion      sql.NullString
+			taskCachePipelineID  sql.NullInt64
+			taskCacheJobName     sql.NullString
+			taskCacheStepName    sql.NullString
+			taskCachePath        sql.NullString
 		)
 
 		err := rows.Scan(
@@ -385,6 +426,10 @@ func scanVolumes(rows *sql.Rows) ([]SavedVolume, error) {
 			&replicationName,
 			&path,
 			&hostPathVersion,
+			&taskCachePipelineID,
+			&taskCacheJobName,
+			&taskCacheStepName,
+			&taskCachePath,
 			&volume.SizeInBytes,
 			&volume.ContainerTTL,
 		)
@@ -426,6 +471,13 @@ func scanVolumes(rows *sql.Rows) ([]SavedVolume, error) {
 				WorkerName: volume.WorkerName,
 				Version:    &hostPathVersion.String,
 			}
+		case taskCachePipelineID.Valid:
+			volume.Volume.Identifier.TaskCache = &TaskCacheIdentifier{
+				PipelineID: int(taskCachePipelineID.Int64),
+				JobName:    taskCacheJobName.String,
+				StepName:   taskCacheStepName.String,
+				Path:       taskCachePath.String,
+			}
 		}
 
 		volumes = append(volumes, volume)
@@ -6,17 +6,21 @@ import (
 	"errors"
 	"fmt"
 	"time"
+
+	"github.com/lib/pq"
 )
 
-var workerColumns = "EXTRACT(epoch FROM expires - NOW()), addr, baggageclaim_url, http_proxy_url, https_proxy_url, no_proxy, active_containers, resource_types, platform, tags, name, start_time"
+var workerColumns = "EXTRACT(epoch FROM expires - NOW()), addr, baggageclaim_url, http_proxy_url, https_proxy_url, no_proxy, active_containers, max_containers, memory_capacity, cpu_capacity, resource_types, platform, tags, name, start_time, state, team_id, (SELECT t.name FROM teams t WHERE t.id = workers.team_id), health_checked_at, garden_latency, baggageclaim_latency, garden_error, baggageclaim_error, disk_in_bytes, consecutive_health_failures, " + workerContainerTallies
+
+// workerContainerTallies counts the live containers recorded for each worker
+// and the resources committed to them, which unlike the worker's own report
+// include containers created since its last heartbeat.
+const workerContainerTallies = `(SELECT COUNT(*) FROM containers c WHERE c.worker_name = workers.name AND c.expires_at > NOW()),
+	(SELECT COALESCE(SUM(c.memory_limit), 0) FROM containers c WHERE c.worker_name = workers.name AND c.expires_at > NOW()),
+	(SELECT COALESCE(SUM(c.cpu_limit), 0) FROM containers c WHERE c.worker_name = workers.name AND c.expires_at > NOW())`
 
 func (db *SQLDB) Workers() ([]SavedWorker, error) {
-	// reap expired workers
-	_, err := db.conn.Exec(`
-		DELETE FROM workers
-		WHERE expires IS NOT NULL
-		AND expires < NOW()
-	`)
+	err := db.expireWorkers()
 	if err != nil {
 		return nil, err
 	}
@@ -65,12 +69,7 @@ func (db *SQLDB) FindWorkerCheckResourceTypeVersion(workerName string, checkType
 }
 
 func (db *SQLDB) GetWorker(name string) (SavedWorker, bool, error) {
-	// reap expired workers
-	_, err := db.conn.Exec(`
-		DELETE FROM workers
-		WHERE expires IS NOT NULL
-		AND expires < NOW()
-	`)
+	err := db.expireWorkers()
 	if err != nil {
 		return SavedWorker{}, false, err
 	}
@@ -92,6 +91,17 @@ func (db *SQLDB) GetWorker(name string) (SavedWorker, bool, error) {
 }
 
 func (db *SQLDB) SaveWorker(info WorkerInfo, ttl time.Duration) (SavedWorker, error) {
+	return db.saveWorker(info, ttl, false)
+}
+
+// SaveTeamWorker saves a worker registered by its team, which may only
+// replace a worker with the same name or address that already belongs to the
+// team.
+func (db *SQLDB) SaveTeamWorker(info WorkerInfo, ttl time.Duration) (SavedWorker, error) {
+	return db.saveWorker(info, ttl, true)
+}
+
+func (db *SQLDB) saveWorker(info WorkerInfo, ttl time.Duration, teamOnly bool) (SavedWorker, error) {
 	var savedWorker SavedWorker
 	resourceTypes, err := json.Marshal(info.ResourceTypes)
 	if err != nil {
@@ -103,6 +113,12 @@ func (db *SQLDB) SaveWorker(info WorkerInfo, ttl time.Duration) (SavedWorker, er
 		return SavedWorker{}, err
 	}
 
+	var teamID sql.NullInt64
+	if info.TeamID != 0 {
+		teamID.Int64 = int64(info.TeamID)
+		teamID.Valid = true
+	}
+
 	expires := "NULL"
 	if ttl != 0 {
 		expires = fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(ttl.Seconds()))
@@ -110,18 +126,38 @@ func (db *SQLDB) SaveWorker(info WorkerInfo, ttl time.Duration) (SavedWorker, er
 
 	row := db.conn.QueryRow(`
 			UPDATE workers
-			SET addr = $1, expires = `+expires+`, active_containers = $2, resource_types = $3, platform = $4, tags = $5, baggageclaim_url = $6, http_proxy_url = $7, https_proxy_url = $8, no_proxy = $9, name = $10, start_time = $11
-			WHERE name = $10 OR addr = $1
+			SET addr = $1, expires = `+expires+`, state = CASE WHEN state IN ('stalled', 'landed') THEN 'running' ELSE state END, active_containers = $2, resource_types = $3, platform = $4, tags = $5, baggageclaim_url = $6, http_proxy_url = $7, https_proxy_url = $8, no_proxy = $9, name = $10, start_time = $11, max_containers = $12, memory_capacity = $13, cpu_capacity = $14, team_id = $15
+			WHERE (name = $10 OR addr = $1)
+			AND (NOT $16 OR team_id = $15)
 			RETURNING  `+workerColumns,
-		info.GardenAddr, info.ActiveContainers, resourceTypes, info.Platform, tags, info.BaggageclaimURL, info.HTTPProxyURL, info.HTTPSProxyURL, info.NoProxy, info.Name, info.StartTime)
+		info.GardenAddr, info.ActiveContainers, resourceTypes, info.Platform, tags, info.BaggageclaimURL, info.HTTPProxyURL, info.HTTPSProxyURL, info.NoProxy, info.Name, info.StartTime, info.MaxContainers, info.MemoryCapacity, info.CPUCapacity, teamID, teamOnly)
 
 	savedWorker, err = scanWorker(row)
+	if err == sql.ErrNoRows && teamOnly {
+		// anything left with the name or address is shared or another team's
+		var conflicting int
+		err = db.conn.QueryRow(`
+			SELECT COUNT(*)
+			FROM workers
+			WHERE name = $1 OR addr = $2
+		`, info.Name, info.GardenAddr).Scan(&conflicting)
+		if err != nil {
+			return SavedWorker{}, err
+		}
+
+		if conflicting > 0 {
+			return SavedWorker{}, ErrWorkerNotOwnedByTeam
+		}
+
+		err = sql.ErrNoRows
+	}
+
 	if err == sql.ErrNoRows {
 		row = db.conn.QueryRow(`
-				INSERT INTO workers (addr, expires, active_containers, resource_types, platform, tags, baggageclaim_url, http_proxy_url, https_proxy_url, no_proxy, name, start_time)
-				VALUES ($1, `+expires+`, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+				INSERT INTO workers (addr, expires, active_containers, resource_types, platform, tags, baggageclaim_url, http_proxy_url, https_proxy_url, no_proxy, name, start_time, max_containers, memory_capacity, cpu_capacity, team_id)
+				VALUES ($1, `+expires+`, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 				RETURNING `+workerColumns,
-			info.GardenAddr, info.ActiveContainers, resourceTypes, info.Platform, tags, info.BaggageclaimURL, info.HTTPProxyURL, info.HTTPSProxyURL, info.NoProxy, info.Name, info.StartTime)
+			info.GardenAddr, info.ActiveContainers, resourceTypes, info.Platform, tags, info.BaggageclaimURL, info.HTTPProxyURL, info.HTTPSProxyURL, info.NoProxy, info.Name, info.StartTime, info.MaxContainers, info.MemoryCapacity, info.CPUCapacity, teamID)
 		savedWorker, err = scanWorker(row)
 	}
 	if err != nil {
@@ -131,6 +167,192 @@ func (db *SQLDB) SaveWorker(info WorkerInfo, ttl time.Duration) (SavedWorker, er
 	return savedWorker, nil
 }
 
+// LandWorker stops new containers from being placed on a running worker.
+// Once the worker stops heartbeating it becomes landed rather than stalled.
+func (db *SQLDB) LandWorker(name string) error {
+	result, err := db.conn.Exec(`
+		UPDATE workers
+		SET state = 'landing'
+		WHERE name = $1
+		AND state = 'running'
+	`, name)
+	if err != nil {
+		return err
+	}
+
+	return db.checkWorkerTransitioned(name, result)
+}
+
+// RetireWorker stops new containers from being placed on a worker, and
+// removes it once it stops heartbeating. Workers that are no longer
+// heartbeating have nothing left to drain, so are removed immediately.
+func (db *SQLDB) RetireWorker(name string) error {
+	result, err := db.conn.Exec(`
+		DELETE FROM workers
+		WHERE name = $1
+		AND state IN ('stalled', 'landed')
+	`, name)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 1 {
+		return nil
+	}
+
+	result, err = db.conn.Exec(`
+		UPDATE workers
+		SET state = 'retiring'
+		WHERE name = $1
+		AND state IN ('running', 'landing')
+	`, name)
+	if err != nil {
+		return err
+	}
+
+	return db.checkWorkerTransitioned(name, result)
+}
+
+// PruneWorker removes a worker that is no longer heartbeating, along with
+// the record of its containers and volumes.
+func (db *SQLDB) PruneWorker(name string) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	var state WorkerState
+	err = tx.QueryRow(`
+		SELECT state
+		FROM workers
+		WHERE name = $1
+		FOR UPDATE
+	`, name).Scan(&state)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrWorkerNotPresent
+		}
+
+		return err
+	}
+
+	if state != WorkerStateStalled && state != WorkerStateLanded {
+		return ErrCannotPruneRunningWorker
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM containers
+		WHERE worker_name = $1
+	`, name)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM volumes
+		WHERE worker_name = $1
+	`, name)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM workers
+		WHERE name = $1
+	`, name)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// expireWorkers transitions workers whose heartbeat has expired. Retiring
+// workers are removed, landing workers become landed, and all others become
+// stalled, keeping their volumes recorded until they come back or are pruned.
+func (db *SQLDB) expireWorkers() error {
+	_, err := db.conn.Exec(`
+		DELETE FROM workers
+		WHERE state = 'retiring'
+		AND expires IS NOT NULL
+		AND expires < NOW()
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.conn.Exec(`
+		UPDATE workers
+		SET state = CASE WHEN state = 'landing' THEN 'landed' ELSE 'stalled' END, expires = NULL
+		WHERE expires IS NOT NULL
+		AND expires < NOW()
+	`)
+
+	return err
+}
+
+// SaveWorkerHealth records the result of probing a worker, counting how many
+// probes in a row have failed.
+func (db *SQLDB) SaveWorkerHealth(name string, health WorkerHealth) error {
+	result, err := db.conn.Exec(`
+		UPDATE workers
+		SET health_checked_at = $2, garden_latency = $3, baggageclaim_latency = $4, garden_error = $5, baggageclaim_error = $6, disk_in_bytes = $7,
+			consecutive_health_failures = CASE WHEN $8 THEN consecutive_health_failures + 1 ELSE 0 END
+		WHERE name = $1
+	`, name, health.CheckedAt, int64(health.GardenLatency), int64(health.BaggageclaimLatency), nullIfEmpty(health.GardenError), nullIfEmpty(health.BaggageclaimError), int64(health.DiskInBytes), !health.Healthy())
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrWorkerNotPresent
+	}
+
+	return nil
+}
+
+func (db *SQLDB) checkWorkerTransitioned(name string, result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 1 {
+		return nil
+	}
+
+	var exists bool
+	err = db.conn.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM workers
+			WHERE name = $1
+		)
+	`, name).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return ErrWorkerNotPresent
+	}
+
+	// already not accepting new containers; nothing to do
+	return nil
+}
+
 func scanWorker(row scannable) (SavedWorker, error) {
 	info := SavedWorker{}
 
@@ -142,7 +364,14 @@ func scanWorker(row scannable) (SavedWorker, error) {
 	var httpsProxyURL sql.NullString
 	var noProxy sql.NullString
 
-	err := row.Scan(&ttlSeconds, &info.GardenAddr, &info.BaggageclaimURL, &httpProxyURL, &httpsProxyURL, &noProxy, &info.ActiveContainers, &resourceTypes, &info.Platform, &tags, &info.Name, &info.StartTime)
+	var teamID sql.NullInt64
+	var teamName sql.NullString
+
+	var healthCheckedAt pq.NullTime
+	var gardenError sql.NullString
+	var baggageclaimError sql.NullString
+
+	err := row.Scan(&ttlSeconds, &info.GardenAddr, &info.BaggageclaimURL, &httpProxyURL, &httpsProxyURL, &noProxy, &info.ActiveContainers, &info.MaxContainers, &info.MemoryCapacity, &info.CPUCapacity, &resourceTypes, &info.Platform, &tags, &info.Name, &info.StartTime, &info.State, &teamID, &teamName, &healthCheckedAt, &info.Health.GardenLatency, &info.Health.BaggageclaimLatency, &gardenError, &baggageclaimError, &info.Health.DiskInBytes, &info.Health.ConsecutiveFailures, &info.Containers, &info.MemoryCommitted, &info.CPUCommitted)
 	if err != nil {
 		return SavedWorker{}, err
 	}
@@ -163,6 +392,23 @@ func scanWorker(row scannable) (SavedWorker, error) {
 		info.NoProxy = noProxy.String
 	}
 
+	if teamID.Valid {
+		info.TeamID = int(teamID.Int64)
+		info.TeamName = teamName.String
+	}
+
+	if healthCheckedAt.Valid {
+		info.Health.CheckedAt = healthCheckedAt.Time
+	}
+
+	if gardenError.Valid {
+		info.Health.GardenError = gardenError.String
+	}
+
+	if baggageclaimError.Valid {
+		info.Health.BaggageclaimError = baggageclaimError.String
+	}
+
 	err = json.Unmarshal(resourceTypes, &info.ResourceTypes)
 	if err != nil {
 		return SavedWorker{}, err
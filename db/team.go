@@ -5,6 +5,11 @@ type Team struct {
 	Admin bool
 	BasicAuth
 	GitHubAuth
+
+	GitLabAuth GitLabAuth `json:"gitlab_auth"`
+	OIDCAuth   OIDCAuth   `json:"oidc_auth"`
+
+	Roles map[string]string `json:"roles"`
 }
 
 type BasicAuth struct {
@@ -28,6 +33,26 @@ type GitHubTeam struct {
 	TeamName         string `json:"team_name"`
 }
 
+type GitLabAuth struct {
+	ClientID     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret"`
+	Groups       []string `json:"groups"`
+	Projects     []string `json:"projects"`
+	Users        []string `json:"users"`
+	URL          string   `json:"url"`
+}
+
+type OIDCAuth struct {
+	DisplayName  string   `json:"display_name"`
+	Issuer       string   `json:"issuer"`
+	ClientID     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret"`
+	Scopes       []string `json:"scopes"`
+	GroupsClaim  string   `json:"groups_claim"`
+	Groups       []string `json:"groups"`
+	Users        []string `json:"users"`
+}
+
 type SavedTeam struct {
 	ID int
 	Team
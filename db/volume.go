@@ -25,6 +25,7 @@ type VolumeIdentifier struct {
 	Output        *OutputIdentifier
 	Import        *ImportIdentifier
 	Replication   *ReplicationIdentifier
+	TaskCache     *TaskCacheIdentifier
 }
 
 func (i VolumeIdentifier) Type() string {
@@ -39,6 +40,8 @@ func (i VolumeIdentifier) Type() string {
 		return "import"
 	case i.Replication != nil:
 		return "replication"
+	case i.TaskCache != nil:
+		return "task-cache"
 	default:
 		return ""
 	}
@@ -56,6 +59,8 @@ func (i VolumeIdentifier) String() string {
 		return i.Import.String()
 	case i.Replication != nil:
 		return i.Replication.String()
+	case i.TaskCache != nil:
+		return i.TaskCache.String()
 	default:
 		return ""
 	}
@@ -101,6 +106,17 @@ func (i ReplicationIdentifier) String() string {
 	return i.ReplicatedVolumeHandle
 }
 
+type TaskCacheIdentifier struct {
+	PipelineID int
+	JobName    string
+	StepName   string
+	Path       string
+}
+
+func (i TaskCacheIdentifier) String() string {
+	return fmt.Sprintf("%s/%s:%s", i.JobName, i.StepName, i.Path)
+}
+
 type ImportIdentifier struct {
 	WorkerName string
 	Path       string
@@ -0,0 +1,194 @@
+package engine
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/concourse/atc"
+)
+
+const redactedSecret = "((redacted))"
+
+// literal values shorter than this are far more likely to be things like
+// "true" or a port number than a credential, and scrubbing them would mangle
+// the output
+const minLiteralSecretLength = 4
+
+// params whose names contain any of these are taken to hold credentials
+var secretParamNameParts = []string{"password", "passphrase", "secret", "token", "key", "credential", "private"}
+
+type secretRedactor struct {
+	secrets  []string
+	replacer *strings.Replacer
+}
+
+// newSecretRedactor scrubs the credential values that were resolved into the
+// given plan when the build was created, along with credentials configured
+// literally in its sources and params, which no credential manager resolved.
+func newSecretRedactor(plan atc.Plan) *secretRedactor {
+	found := map[string]struct{}{}
+	for _, secret := range plan.Secrets {
+		found[secret] = struct{}{}
+	}
+
+	collectLiteralSecrets(plan, found)
+
+	secrets := make([]string, 0, len(found))
+	for secret := range found {
+		secrets = append(secrets, secret)
+	}
+
+	// longest first, so that a secret containing another is scrubbed whole
+	sort.Sort(byLengthDescending(secrets))
+
+	pairs := make([]string, 0, len(secrets)*2)
+	for _, secret := range secrets {
+		pairs = append(pairs, secret, redactedSecret)
+	}
+
+	return &secretRedactor{
+		secrets:  secrets,
+		replacer: strings.NewReplacer(pairs...),
+	}
+}
+
+// collectLiteralSecrets collects every string configured in the plan's
+// sources, and those configured in its params under names that look like
+// they hold credentials.
+func collectLiteralSecrets(plan atc.Plan, found map[string]struct{}) {
+	collectSources := func(types atc.ResourceTypes) {
+		for _, t := range types {
+			collectStrings(map[string]interface{}(t.Source), found)
+		}
+	}
+
+	atc.NewPlanTraversal(func(plan *atc.Plan) error {
+		switch {
+		case plan.Get != nil:
+			collectStrings(map[string]interface{}(plan.Get.Source), found)
+			collectSecretParams(map[string]interface{}(plan.Get.Params), found)
+			collectSources(plan.Get.ResourceTypes)
+		case plan.Put != nil:
+			collectStrings(map[string]interface{}(plan.Put.Source), found)
+			collectSecretParams(map[string]interface{}(plan.Put.Params), found)
+			collectSources(plan.Put.ResourceTypes)
+		case plan.DependentGet != nil:
+			collectStrings(map[string]interface{}(plan.DependentGet.Source), found)
+			collectSecretParams(map[string]interface{}(plan.DependentGet.Params), found)
+			collectSources(plan.DependentGet.ResourceTypes)
+		case plan.Task != nil:
+			collectSecretParams(map[string]interface{}(plan.Task.Params), found)
+			collectSources(plan.Task.ResourceTypes)
+
+			if plan.Task.Config != nil {
+				for name, value := range plan.Task.Config.Params {
+					if looksSecret(name) {
+						collectStrings(value, found)
+					}
+				}
+
+				if plan.Task.Config.ImageResource != nil {
+					collectStrings(map[string]interface{}(plan.Task.Config.ImageResource.Source), found)
+				}
+			}
+		}
+
+		return nil
+	}).Traverse(&plan)
+}
+
+func collectStrings(value interface{}, found map[string]struct{}) {
+	switch v := value.(type) {
+	case string:
+		if len(v) >= minLiteralSecretLength {
+			found[v] = struct{}{}
+		}
+	case map[string]interface{}:
+		for _, sub := range v {
+			collectStrings(sub, found)
+		}
+	case map[interface{}]interface{}:
+		for _, sub := range v {
+			collectStrings(sub, found)
+		}
+	case []interface{}:
+		for _, sub := range v {
+			collectStrings(sub, found)
+		}
+	}
+}
+
+func collectSecretParams(value interface{}, found map[string]struct{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, sub := range v {
+			if looksSecret(name) {
+				collectStrings(sub, found)
+			} else {
+				collectSecretParams(sub, found)
+			}
+		}
+	case map[interface{}]interface{}:
+		for name, sub := range v {
+			if name, ok := name.(string); ok && looksSecret(name) {
+				collectStrings(sub, found)
+			} else {
+				collectSecretParams(sub, found)
+			}
+		}
+	case []interface{}:
+		for _, sub := range v {
+			collectSecretParams(sub, found)
+		}
+	}
+}
+
+func looksSecret(name string) bool {
+	name = strings.ToLower(name)
+
+	for _, part := range secretParamNameParts {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (redactor *secretRedactor) redact(text string) string {
+	if len(redactor.secrets) == 0 {
+		return text
+	}
+
+	return redactor.replacer.Replace(text)
+}
+
+// partialSecretLength returns the length of the longest suffix of text that
+// could be the beginning of a secret, i.e. how much of it must be held back
+// until more output arrives.
+func (redactor *secretRedactor) partialSecretLength(text string) int {
+	longest := 0
+
+	for _, secret := range redactor.secrets {
+		for n := len(secret) - 1; n > longest; n-- {
+			if n <= len(text) && strings.HasSuffix(text, secret[:n]) {
+				longest = n
+				break
+			}
+		}
+	}
+
+	return longest
+}
+
+type byLengthDescending []string
+
+func (s byLengthDescending) Len() int      { return len(s) }
+func (s byLengthDescending) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byLengthDescending) Less(i, j int) bool {
+	if len(s[i]) != len(s[j]) {
+		return len(s[i]) > len(s[j])
+	}
+
+	return s[i] < s[j]
+}
@@ -22,6 +22,23 @@ func (build *execBuild) buildAggregateStep(logger lager.Logger, plan atc.Plan) e
 	return step
 }
 
+func (build *execBuild) buildInParallelStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("in-parallel")
+
+	step := exec.InParallel{
+		Limit:    plan.InParallel.Limit,
+		FailFast: plan.InParallel.FailFast,
+	}
+
+	for _, innerPlan := range plan.InParallel.Steps {
+		innerPlan.Attempts = plan.Attempts
+		stepFactory := build.buildStepFactory(logger, innerPlan)
+		step.Steps = append(step.Steps, stepFactory)
+	}
+
+	return step
+}
+
 func (build *execBuild) buildDoStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	logger = logger.Session("do")
 
@@ -53,6 +70,21 @@ func (build *execBuild) buildTryStep(logger lager.Logger, plan atc.Plan) exec.St
 	return exec.Try(step)
 }
 
+func (build *execBuild) buildConditionalStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("conditional")
+
+	innerPlan := plan.Conditional.Step
+	innerPlan.Attempts = plan.Attempts
+	step := build.buildStepFactory(logger, innerPlan)
+
+	return exec.Conditional(
+		plan.Conditional.Condition,
+		build.stepMetadata.JobName,
+		step,
+		build.delegate.ConditionalDelegate(logger, *plan.Conditional, event.OriginID(plan.ID)),
+	)
+}
+
 func (build *execBuild) buildOnSuccessStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	plan.OnSuccess.Step.Attempts = plan.Attempts
 	step := build.buildStepFactory(logger, plan.OnSuccess.Step)
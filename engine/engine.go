@@ -29,6 +29,8 @@ type EngineDB interface {
 
 	SaveImageResourceVersion(buildID int, planID atc.PlanID, identifier db.ResourceCacheIdentifier) error
 
+	UpdateBuildPreparationWorkersAvailable(buildID int, status db.BuildPreparationStatus) error
+
 	GetPipelineByTeamNameAndName(teamName string, pipelineName string) (db.SavedPipeline, error)
 }
 
@@ -42,6 +42,16 @@ type FakeBuildDelegate struct {
 	outputDelegateReturns struct {
 		result1 exec.PutDelegate
 	}
+	ConditionalDelegateStub        func(lager.Logger, atc.ConditionalPlan, event.OriginID) exec.ConditionalDelegate
+	conditionalDelegateMutex       sync.RWMutex
+	conditionalDelegateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.ConditionalPlan
+		arg3 event.OriginID
+	}
+	conditionalDelegateReturns struct {
+		result1 exec.ConditionalDelegate
+	}
 	FinishStub        func(lager.Logger, error, exec.Success, bool)
 	finishMutex       sync.RWMutex
 	finishArgsForCall []struct {
@@ -159,6 +169,41 @@ func (fake *FakeBuildDelegate) OutputDelegateReturns(result1 exec.PutDelegate) {
 	}{result1}
 }
 
+func (fake *FakeBuildDelegate) ConditionalDelegate(arg1 lager.Logger, arg2 atc.ConditionalPlan, arg3 event.OriginID) exec.ConditionalDelegate {
+	fake.conditionalDelegateMutex.Lock()
+	fake.conditionalDelegateArgsForCall = append(fake.conditionalDelegateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.ConditionalPlan
+		arg3 event.OriginID
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ConditionalDelegate", []interface{}{arg1, arg2, arg3})
+	fake.conditionalDelegateMutex.Unlock()
+	if fake.ConditionalDelegateStub != nil {
+		return fake.ConditionalDelegateStub(arg1, arg2, arg3)
+	} else {
+		return fake.conditionalDelegateReturns.result1
+	}
+}
+
+func (fake *FakeBuildDelegate) ConditionalDelegateCallCount() int {
+	fake.conditionalDelegateMutex.RLock()
+	defer fake.conditionalDelegateMutex.RUnlock()
+	return len(fake.conditionalDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) ConditionalDelegateArgsForCall(i int) (lager.Logger, atc.ConditionalPlan, event.OriginID) {
+	fake.conditionalDelegateMutex.RLock()
+	defer fake.conditionalDelegateMutex.RUnlock()
+	return fake.conditionalDelegateArgsForCall[i].arg1, fake.conditionalDelegateArgsForCall[i].arg2, fake.conditionalDelegateArgsForCall[i].arg3
+}
+
+func (fake *FakeBuildDelegate) ConditionalDelegateReturns(result1 exec.ConditionalDelegate) {
+	fake.ConditionalDelegateStub = nil
+	fake.conditionalDelegateReturns = struct {
+		result1 exec.ConditionalDelegate
+	}{result1}
+}
+
 func (fake *FakeBuildDelegate) Finish(arg1 lager.Logger, arg2 error, arg3 exec.Success, arg4 bool) {
 	fake.finishMutex.Lock()
 	fake.finishArgsForCall = append(fake.finishArgsForCall, struct {
@@ -195,6 +240,8 @@ func (fake *FakeBuildDelegate) Invocations() map[string][][]interface{} {
 	defer fake.executionDelegateMutex.RUnlock()
 	fake.outputDelegateMutex.RLock()
 	defer fake.outputDelegateMutex.RUnlock()
+	fake.conditionalDelegateMutex.RLock()
+	defer fake.conditionalDelegateMutex.RUnlock()
 	fake.finishMutex.RLock()
 	defer fake.finishMutex.RUnlock()
 	return fake.invocations
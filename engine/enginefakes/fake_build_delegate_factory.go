@@ -4,15 +4,17 @@ package enginefakes
 import (
 	"sync"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/engine"
 )
 
 type FakeBuildDelegateFactory struct {
-	DelegateStub        func(buildID int, pipelineID int) engine.BuildDelegate
+	DelegateStub        func(buildID int, pipelineID int, plan atc.Plan) engine.BuildDelegate
 	delegateMutex       sync.RWMutex
 	delegateArgsForCall []struct {
 		buildID    int
 		pipelineID int
+		plan       atc.Plan
 	}
 	delegateReturns struct {
 		result1 engine.BuildDelegate
@@ -21,16 +23,17 @@ type FakeBuildDelegateFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeBuildDelegateFactory) Delegate(buildID int, pipelineID int) engine.BuildDelegate {
+func (fake *FakeBuildDelegateFactory) Delegate(buildID int, pipelineID int, plan atc.Plan) engine.BuildDelegate {
 	fake.delegateMutex.Lock()
 	fake.delegateArgsForCall = append(fake.delegateArgsForCall, struct {
 		buildID    int
 		pipelineID int
-	}{buildID, pipelineID})
-	fake.recordInvocation("Delegate", []interface{}{buildID, pipelineID})
+		plan       atc.Plan
+	}{buildID, pipelineID, plan})
+	fake.recordInvocation("Delegate", []interface{}{buildID, pipelineID, plan})
 	fake.delegateMutex.Unlock()
 	if fake.DelegateStub != nil {
-		return fake.DelegateStub(buildID, pipelineID)
+		return fake.DelegateStub(buildID, pipelineID, plan)
 	} else {
 		return fake.delegateReturns.result1
 	}
@@ -42,10 +45,10 @@ func (fake *FakeBuildDelegateFactory) DelegateCallCount() int {
 	return len(fake.delegateArgsForCall)
 }
 
-func (fake *FakeBuildDelegateFactory) DelegateArgsForCall(i int) (int, int) {
+func (fake *FakeBuildDelegateFactory) DelegateArgsForCall(i int) (int, int, atc.Plan) {
 	fake.delegateMutex.RLock()
 	defer fake.delegateMutex.RUnlock()
-	return fake.delegateArgsForCall[i].buildID, fake.delegateArgsForCall[i].pipelineID
+	return fake.delegateArgsForCall[i].buildID, fake.delegateArgsForCall[i].pipelineID, fake.delegateArgsForCall[i].plan
 }
 
 func (fake *FakeBuildDelegateFactory) DelegateReturns(result1 engine.BuildDelegate) {
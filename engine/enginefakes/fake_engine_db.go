@@ -70,6 +70,15 @@ type FakeEngineDB struct {
 	saveImageResourceVersionReturns struct {
 		result1 error
 	}
+	UpdateBuildPreparationWorkersAvailableStub        func(buildID int, status db.BuildPreparationStatus) error
+	updateBuildPreparationWorkersAvailableMutex       sync.RWMutex
+	updateBuildPreparationWorkersAvailableArgsForCall []struct {
+		buildID int
+		status  db.BuildPreparationStatus
+	}
+	updateBuildPreparationWorkersAvailableReturns struct {
+		result1 error
+	}
 	GetPipelineByTeamNameAndNameStub        func(teamName string, pipelineName string) (db.SavedPipeline, error)
 	getPipelineByTeamNameAndNameMutex       sync.RWMutex
 	getPipelineByTeamNameAndNameArgsForCall []struct {
@@ -294,6 +303,40 @@ func (fake *FakeEngineDB) SaveImageResourceVersionReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeEngineDB) UpdateBuildPreparationWorkersAvailable(buildID int, status db.BuildPreparationStatus) error {
+	fake.updateBuildPreparationWorkersAvailableMutex.Lock()
+	fake.updateBuildPreparationWorkersAvailableArgsForCall = append(fake.updateBuildPreparationWorkersAvailableArgsForCall, struct {
+		buildID int
+		status  db.BuildPreparationStatus
+	}{buildID, status})
+	fake.recordInvocation("UpdateBuildPreparationWorkersAvailable", []interface{}{buildID, status})
+	fake.updateBuildPreparationWorkersAvailableMutex.Unlock()
+	if fake.UpdateBuildPreparationWorkersAvailableStub != nil {
+		return fake.UpdateBuildPreparationWorkersAvailableStub(buildID, status)
+	} else {
+		return fake.updateBuildPreparationWorkersAvailableReturns.result1
+	}
+}
+
+func (fake *FakeEngineDB) UpdateBuildPreparationWorkersAvailableCallCount() int {
+	fake.updateBuildPreparationWorkersAvailableMutex.RLock()
+	defer fake.updateBuildPreparationWorkersAvailableMutex.RUnlock()
+	return len(fake.updateBuildPreparationWorkersAvailableArgsForCall)
+}
+
+func (fake *FakeEngineDB) UpdateBuildPreparationWorkersAvailableArgsForCall(i int) (int, db.BuildPreparationStatus) {
+	fake.updateBuildPreparationWorkersAvailableMutex.RLock()
+	defer fake.updateBuildPreparationWorkersAvailableMutex.RUnlock()
+	return fake.updateBuildPreparationWorkersAvailableArgsForCall[i].buildID, fake.updateBuildPreparationWorkersAvailableArgsForCall[i].status
+}
+
+func (fake *FakeEngineDB) UpdateBuildPreparationWorkersAvailableReturns(result1 error) {
+	fake.UpdateBuildPreparationWorkersAvailableStub = nil
+	fake.updateBuildPreparationWorkersAvailableReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeEngineDB) GetPipelineByTeamNameAndName(teamName string, pipelineName string) (db.SavedPipeline, error) {
 	fake.getPipelineByTeamNameAndNameMutex.Lock()
 	fake.getPipelineByTeamNameAndNameArgsForCall = append(fake.getPipelineByTeamNameAndNameArgsForCall, struct {
@@ -344,6 +387,8 @@ func (fake *FakeEngineDB) Invocations() map[string][][]interface{} {
 	defer fake.saveBuildOutputMutex.RUnlock()
 	fake.saveImageResourceVersionMutex.RLock()
 	defer fake.saveImageResourceVersionMutex.RUnlock()
+	fake.updateBuildPreparationWorkersAvailableMutex.RLock()
+	defer fake.updateBuildPreparationWorkersAvailableMutex.RUnlock()
 	fake.getPipelineByTeamNameAndNameMutex.RLock()
 	defer fake.getPipelineByTeamNameAndNameMutex.RUnlock()
 	return fake.invocations
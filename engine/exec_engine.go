@@ -47,11 +47,12 @@ func (engine *execEngine) Name() string {
 func (engine *execEngine) CreateBuild(logger lager.Logger, model db.Build, plan atc.Plan) (Build, error) {
 	return &execBuild{
 		buildID:      model.ID,
+		teamID:       model.TeamID,
 		stepMetadata: buildMetadata(model, engine.externalURL),
 
 		db:       engine.db,
 		factory:  engine.factory,
-		delegate: engine.delegateFactory.Delegate(model.ID, model.PipelineID),
+		delegate: engine.delegateFactory.Delegate(model.ID, model.PipelineID, plan),
 		metadata: execMetadata{
 			Plan: plan,
 		},
@@ -78,11 +79,12 @@ func (engine *execEngine) LookupBuild(logger lager.Logger, model db.Build) (Buil
 
 	return &execBuild{
 		buildID:      model.ID,
+		teamID:       model.TeamID,
 		stepMetadata: buildMetadata(model, engine.externalURL),
 
 		db:       engine.db,
 		factory:  engine.factory,
-		delegate: engine.delegateFactory.Delegate(model.ID, model.PipelineID),
+		delegate: engine.delegateFactory.Delegate(model.ID, model.PipelineID, metadata.Plan),
 		metadata: metadata,
 
 		signals: make(chan os.Signal, 1),
@@ -146,6 +148,7 @@ func buildMetadata(model db.Build, externalURL string) StepMetadata {
 
 type execBuild struct {
 	buildID      int
+	teamID       int
 	stepMetadata StepMetadata
 
 	db EngineDB
@@ -223,6 +226,10 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return build.buildAggregateStep(logger, plan)
 	}
 
+	if plan.InParallel != nil {
+		return build.buildInParallelStep(logger, plan)
+	}
+
 	if plan.Do != nil {
 		return build.buildDoStep(logger, plan)
 	}
@@ -235,6 +242,10 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return build.buildTryStep(logger, plan)
 	}
 
+	if plan.Conditional != nil {
+		return build.buildConditionalStep(logger, plan)
+	}
+
 	if plan.OnSuccess != nil {
 		return build.buildOnSuccessStep(logger, plan)
 	}
@@ -291,6 +302,7 @@ func (build *execBuild) stepIdentifier(
 			StepName:   stepName,
 			Type:       stepType,
 			PipelineID: pipelineID,
+			TeamID:     build.teamID,
 			Attempts:   attempts,
 		}
 }
@@ -25,6 +25,7 @@ type BuildDelegate interface {
 	InputDelegate(lager.Logger, atc.GetPlan, event.OriginID) exec.GetDelegate
 	ExecutionDelegate(lager.Logger, atc.TaskPlan, event.OriginID) exec.TaskDelegate
 	OutputDelegate(lager.Logger, atc.PutPlan, event.OriginID) exec.PutDelegate
+	ConditionalDelegate(lager.Logger, atc.ConditionalPlan, event.OriginID) exec.ConditionalDelegate
 
 	Finish(lager.Logger, error, exec.Success, bool)
 }
@@ -32,7 +33,7 @@ type BuildDelegate interface {
 //go:generate counterfeiter . BuildDelegateFactory
 
 type BuildDelegateFactory interface {
-	Delegate(buildID int, pipelineID int) BuildDelegate
+	Delegate(buildID int, pipelineID int, plan atc.Plan) BuildDelegate
 }
 
 type buildDelegateFactory struct {
@@ -43,8 +44,8 @@ func NewBuildDelegateFactory(db EngineDB) BuildDelegateFactory {
 	return buildDelegateFactory{db}
 }
 
-func (factory buildDelegateFactory) Delegate(buildID int, pipelineID int) BuildDelegate {
-	return newBuildDelegate(factory.db, buildID, pipelineID)
+func (factory buildDelegateFactory) Delegate(buildID int, pipelineID int, plan atc.Plan) BuildDelegate {
+	return newBuildDelegate(factory.db, buildID, pipelineID, plan)
 }
 
 type delegate struct {
@@ -55,10 +56,12 @@ type delegate struct {
 
 	implicitOutputs map[string]implicitOutput
 
+	redactor *secretRedactor
+
 	lock sync.Mutex
 }
 
-func newBuildDelegate(db EngineDB, buildID int, pipelineID int) BuildDelegate {
+func newBuildDelegate(db EngineDB, buildID int, pipelineID int, plan atc.Plan) BuildDelegate {
 	return &delegate{
 		db: db,
 
@@ -66,6 +69,8 @@ func newBuildDelegate(db EngineDB, buildID int, pipelineID int) BuildDelegate {
 		pipelineID: pipelineID,
 
 		implicitOutputs: make(map[string]implicitOutput),
+
+		redactor: newSecretRedactor(plan),
 	}
 }
 
@@ -76,6 +81,9 @@ func (delegate *delegate) InputDelegate(logger lager.Logger, plan atc.GetPlan, i
 		id:       id,
 		plan:     plan,
 		delegate: delegate,
+
+		stdout: delegate.eventWriter(event.Origin{Source: event.OriginSourceStdout, ID: id}),
+		stderr: delegate.eventWriter(event.Origin{Source: event.OriginSourceStderr, ID: id}),
 	}
 }
 
@@ -86,6 +94,9 @@ func (delegate *delegate) OutputDelegate(logger lager.Logger, plan atc.PutPlan,
 		id:       id,
 		plan:     plan,
 		delegate: delegate,
+
+		stdout: delegate.eventWriter(event.Origin{Source: event.OriginSourceStdout, ID: id}),
+		stderr: delegate.eventWriter(event.Origin{Source: event.OriginSourceStderr, ID: id}),
 	}
 }
 
@@ -96,6 +107,19 @@ func (delegate *delegate) ExecutionDelegate(logger lager.Logger, plan atc.TaskPl
 		id:       id,
 		plan:     plan,
 		delegate: delegate,
+
+		stdout: delegate.eventWriter(event.Origin{Source: event.OriginSourceStdout, ID: id}),
+		stderr: delegate.eventWriter(event.Origin{Source: event.OriginSourceStderr, ID: id}),
+	}
+}
+
+func (delegate *delegate) ConditionalDelegate(logger lager.Logger, plan atc.ConditionalPlan, id event.OriginID) exec.ConditionalDelegate {
+	return &conditionalDelegate{
+		logger: logger,
+
+		id:       id,
+		plan:     plan,
+		delegate: delegate,
 	}
 }
 
@@ -186,6 +210,16 @@ func (delegate *delegate) saveFinish(logger lager.Logger, status exec.ExitStatus
 	}
 }
 
+func (delegate *delegate) saveSkip(logger lager.Logger, origin event.Origin) {
+	err := delegate.db.SaveBuildEvent(delegate.buildID, delegate.pipelineID, event.SkipStep{
+		Time:   time.Now().Unix(),
+		Origin: origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-skip-event", err)
+	}
+}
+
 func (delegate *delegate) saveStatus(logger lager.Logger, status atc.BuildStatus) {
 	err := delegate.db.FinishBuild(delegate.buildID, delegate.pipelineID, db.Status(status))
 	if err != nil {
@@ -193,6 +227,13 @@ func (delegate *delegate) saveStatus(logger lager.Logger, status atc.BuildStatus
 	}
 }
 
+func (delegate *delegate) saveWorkersAvailable(logger lager.Logger, status db.BuildPreparationStatus) {
+	err := delegate.db.UpdateBuildPreparationWorkersAvailable(delegate.buildID, status)
+	if err != nil {
+		logger.Error("failed-to-update-workers-available", err)
+	}
+}
+
 func (delegate *delegate) saveErr(logger lager.Logger, errVal error, origin event.Origin) {
 	err := delegate.db.SaveBuildEvent(delegate.buildID, delegate.pipelineID, event.Error{
 		Message: errVal.Error(),
@@ -301,12 +342,13 @@ func (delegate *delegate) saveImplicitOutput(logger lager.Logger, plan atc.GetPl
 	logger.Info("saved", lager.Data{"resource": plan.Resource})
 }
 
-func (delegate *delegate) eventWriter(origin event.Origin) io.Writer {
+func (delegate *delegate) eventWriter(origin event.Origin) *dbEventWriter {
 	return &dbEventWriter{
 		db:         delegate.db,
 		buildID:    delegate.buildID,
 		pipelineID: delegate.pipelineID,
 		origin:     origin,
+		redactor:   delegate.redactor,
 	}
 }
 
@@ -316,6 +358,9 @@ type inputDelegate struct {
 	plan     atc.GetPlan
 	id       event.OriginID
 	delegate *delegate
+
+	stdout *dbEventWriter
+	stderr *dbEventWriter
 }
 
 func (input *inputDelegate) Initializing() {
@@ -323,6 +368,8 @@ func (input *inputDelegate) Initializing() {
 }
 
 func (input *inputDelegate) Completed(status exec.ExitStatus, info *exec.VersionInfo) {
+	input.flushOutput()
+
 	input.delegate.saveInput(input.logger, status, input.plan, info, event.Origin{
 		ID: input.id,
 	})
@@ -335,6 +382,8 @@ func (input *inputDelegate) Completed(status exec.ExitStatus, info *exec.Version
 }
 
 func (input *inputDelegate) Failed(err error) {
+	input.flushOutput()
+
 	input.delegate.saveErr(input.logger, err, event.Origin{
 		ID: input.id,
 	})
@@ -346,18 +395,25 @@ func (input *inputDelegate) ImageVersionDetermined(identifier worker.VolumeIdent
 	return input.delegate.db.SaveImageResourceVersion(input.delegate.buildID, atc.PlanID(input.id), *identifier.ResourceCache)
 }
 
+func (input *inputDelegate) WaitingForWorker() {
+	input.delegate.saveWorkersAvailable(input.logger, db.BuildPreparationStatusBlocking)
+}
+
+func (input *inputDelegate) WorkerFound() {
+	input.delegate.saveWorkersAvailable(input.logger, db.BuildPreparationStatusNotBlocking)
+}
+
 func (input *inputDelegate) Stdout() io.Writer {
-	return input.delegate.eventWriter(event.Origin{
-		Source: event.OriginSourceStdout,
-		ID:     input.id,
-	})
+	return input.stdout
 }
 
 func (input *inputDelegate) Stderr() io.Writer {
-	return input.delegate.eventWriter(event.Origin{
-		Source: event.OriginSourceStderr,
-		ID:     input.id,
-	})
+	return input.stderr
+}
+
+func (input *inputDelegate) flushOutput() {
+	input.stdout.Flush()
+	input.stderr.Flush()
 }
 
 type outputDelegate struct {
@@ -368,6 +424,9 @@ type outputDelegate struct {
 
 	delegate *delegate
 	hook     string
+
+	stdout *dbEventWriter
+	stderr *dbEventWriter
 }
 
 func (output *outputDelegate) Initializing() {
@@ -375,6 +434,8 @@ func (output *outputDelegate) Initializing() {
 }
 
 func (output *outputDelegate) Completed(status exec.ExitStatus, info *exec.VersionInfo) {
+	output.flushOutput()
+
 	output.delegate.unregisterImplicitOutput(output.plan.Resource)
 	output.delegate.saveOutput(output.logger, status, output.plan, info, event.Origin{
 		ID: output.id,
@@ -384,6 +445,8 @@ func (output *outputDelegate) Completed(status exec.ExitStatus, info *exec.Versi
 }
 
 func (output *outputDelegate) Failed(err error) {
+	output.flushOutput()
+
 	output.delegate.saveErr(output.logger, err, event.Origin{
 		ID: output.id,
 	})
@@ -395,18 +458,25 @@ func (output *outputDelegate) ImageVersionDetermined(identifier worker.VolumeIde
 	return output.delegate.db.SaveImageResourceVersion(output.delegate.buildID, atc.PlanID(output.id), *identifier.ResourceCache)
 }
 
+func (output *outputDelegate) WaitingForWorker() {
+	output.delegate.saveWorkersAvailable(output.logger, db.BuildPreparationStatusBlocking)
+}
+
+func (output *outputDelegate) WorkerFound() {
+	output.delegate.saveWorkersAvailable(output.logger, db.BuildPreparationStatusNotBlocking)
+}
+
 func (output *outputDelegate) Stdout() io.Writer {
-	return output.delegate.eventWriter(event.Origin{
-		Source: event.OriginSourceStdout,
-		ID:     output.id,
-	})
+	return output.stdout
 }
 
 func (output *outputDelegate) Stderr() io.Writer {
-	return output.delegate.eventWriter(event.Origin{
-		Source: event.OriginSourceStderr,
-		ID:     output.id,
-	})
+	return output.stderr
+}
+
+func (output *outputDelegate) flushOutput() {
+	output.stdout.Flush()
+	output.stderr.Flush()
 }
 
 type executionDelegate struct {
@@ -418,6 +488,9 @@ type executionDelegate struct {
 	delegate *delegate
 
 	hook string
+
+	stdout *dbEventWriter
+	stderr *dbEventWriter
 }
 
 func (execution *executionDelegate) Initializing(config atc.TaskConfig) {
@@ -437,6 +510,8 @@ func (execution *executionDelegate) Started() {
 }
 
 func (execution *executionDelegate) Finished(status exec.ExitStatus) {
+	execution.flushOutput()
+
 	execution.delegate.saveFinish(execution.logger, status, event.Origin{
 		ID: execution.id,
 	})
@@ -445,6 +520,8 @@ func (execution *executionDelegate) Finished(status exec.ExitStatus) {
 }
 
 func (execution *executionDelegate) Failed(err error) {
+	execution.flushOutput()
+
 	execution.delegate.saveErr(execution.logger, err, event.Origin{
 		ID: execution.id,
 	})
@@ -455,20 +532,56 @@ func (execution *executionDelegate) ImageVersionDetermined(identifier worker.Vol
 	return execution.delegate.db.SaveImageResourceVersion(execution.delegate.buildID, atc.PlanID(execution.id), *identifier.ResourceCache)
 }
 
+func (execution *executionDelegate) WaitingForWorker() {
+	execution.delegate.saveWorkersAvailable(execution.logger, db.BuildPreparationStatusBlocking)
+}
+
+func (execution *executionDelegate) WorkerFound() {
+	execution.delegate.saveWorkersAvailable(execution.logger, db.BuildPreparationStatusNotBlocking)
+}
+
 func (execution *executionDelegate) Stdout() io.Writer {
-	return execution.delegate.eventWriter(event.Origin{
-		Source: event.OriginSourceStdout,
-		ID:     execution.id,
-	})
+	return execution.stdout
 }
 
 func (execution *executionDelegate) Stderr() io.Writer {
-	return execution.delegate.eventWriter(event.Origin{
-		Source: event.OriginSourceStderr,
-		ID:     execution.id,
+	return execution.stderr
+}
+
+func (execution *executionDelegate) flushOutput() {
+	execution.stdout.Flush()
+	execution.stderr.Flush()
+}
+
+type conditionalDelegate struct {
+	logger lager.Logger
+
+	plan atc.ConditionalPlan
+	id   event.OriginID
+
+	delegate *delegate
+}
+
+func (conditional *conditionalDelegate) Skipped() {
+	conditional.delegate.saveSkip(conditional.logger, event.Origin{
+		ID: conditional.id,
+	})
+
+	conditional.logger.Info("skipped")
+}
+
+func (conditional *conditionalDelegate) Failed(err error) {
+	conditional.delegate.saveErr(conditional.logger, err, event.Origin{
+		ID: conditional.id,
 	})
+
+	conditional.logger.Info("errored", lager.Data{"error": err.Error()})
 }
 
+// dbEventWriter saves output as log events, scrubbing any of the build's
+// secrets from it first. Output that may be the start of a secret (or of a
+// multi-byte rune) is held back until the rest of it is written or the writer
+// is flushed.
 type dbEventWriter struct {
 	buildID    int
 	pipelineID int
@@ -477,26 +590,54 @@ type dbEventWriter struct {
 
 	origin event.Origin
 
+	redactor *secretRedactor
+
 	dangling []byte
+
+	lock sync.Mutex
 }
 
 func (writer *dbEventWriter) Write(data []byte) (int, error) {
-	text := append(writer.dangling, data...)
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
+	text := writer.redactor.redact(string(append(writer.dangling, data...)))
+
+	complete := text[:len(text)-writer.redactor.partialSecretLength(text)]
 
-	checkEncoding, _ := utf8.DecodeLastRune(text)
+	checkEncoding, _ := utf8.DecodeLastRuneInString(complete)
 	if checkEncoding == utf8.RuneError {
-		writer.dangling = text
+		writer.dangling = []byte(text)
 		return len(data), nil
 	}
 
+	writer.dangling = []byte(text[len(complete):])
+
+	writer.save(complete)
+
+	return len(data), nil
+}
+
+// Flush saves any output still being held back.
+func (writer *dbEventWriter) Flush() {
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
+	if len(writer.dangling) == 0 {
+		return
+	}
+
+	text := writer.redactor.redact(string(writer.dangling))
 	writer.dangling = nil
 
+	writer.save(text)
+}
+
+func (writer *dbEventWriter) save(text string) {
 	writer.db.SaveBuildEvent(writer.buildID, writer.pipelineID, event.Log{
-		Payload: string(text),
+		Payload: text,
 		Origin:  writer.origin,
 	})
-
-	return len(data), nil
 }
 
 func vrFromInput(plan atc.GetPlan, fetchedInfo exec.VersionInfo) db.VersionedResource {
@@ -37,7 +37,7 @@ var _ = Describe("BuildDelegate", func() {
 		factory = NewBuildDelegateFactory(fakeDB)
 
 		buildID = 42
-		delegate = factory.Delegate(buildID, 57)
+		delegate = factory.Delegate(buildID, 57, atc.Plan{})
 
 		logger = lagertest.NewTestLogger("test")
 
@@ -423,6 +423,32 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("WaitingForWorker", func() {
+			JustBeforeEach(func() {
+				inputDelegate.WaitingForWorker()
+			})
+
+			It("marks the build as blocked on workers", func() {
+				Expect(fakeDB.UpdateBuildPreparationWorkersAvailableCallCount()).To(Equal(1))
+				actualBuildID, status := fakeDB.UpdateBuildPreparationWorkersAvailableArgsForCall(0)
+				Expect(actualBuildID).To(Equal(42))
+				Expect(status).To(Equal(db.BuildPreparationStatusBlocking))
+			})
+		})
+
+		Describe("WorkerFound", func() {
+			JustBeforeEach(func() {
+				inputDelegate.WorkerFound()
+			})
+
+			It("marks the build as no longer blocked on workers", func() {
+				Expect(fakeDB.UpdateBuildPreparationWorkersAvailableCallCount()).To(Equal(1))
+				actualBuildID, status := fakeDB.UpdateBuildPreparationWorkersAvailableArgsForCall(0)
+				Expect(actualBuildID).To(Equal(42))
+				Expect(status).To(Equal(db.BuildPreparationStatusNotBlocking))
+			})
+		})
+
 		Describe("Failed", func() {
 			JustBeforeEach(func() {
 				inputDelegate.Failed(errors.New("nope"))
@@ -770,6 +796,32 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("WaitingForWorker", func() {
+			JustBeforeEach(func() {
+				executionDelegate.WaitingForWorker()
+			})
+
+			It("marks the build as blocked on workers", func() {
+				Expect(fakeDB.UpdateBuildPreparationWorkersAvailableCallCount()).To(Equal(1))
+				actualBuildID, status := fakeDB.UpdateBuildPreparationWorkersAvailableArgsForCall(0)
+				Expect(actualBuildID).To(Equal(42))
+				Expect(status).To(Equal(db.BuildPreparationStatusBlocking))
+			})
+		})
+
+		Describe("WorkerFound", func() {
+			JustBeforeEach(func() {
+				executionDelegate.WorkerFound()
+			})
+
+			It("marks the build as no longer blocked on workers", func() {
+				Expect(fakeDB.UpdateBuildPreparationWorkersAvailableCallCount()).To(Equal(1))
+				actualBuildID, status := fakeDB.UpdateBuildPreparationWorkersAvailableArgsForCall(0)
+				Expect(actualBuildID).To(Equal(42))
+				Expect(status).To(Equal(db.BuildPreparationStatusNotBlocking))
+			})
+		})
+
 		Describe("Stdout", func() {
 			var writer io.Writer
 
@@ -1045,6 +1097,32 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("WaitingForWorker", func() {
+			JustBeforeEach(func() {
+				outputDelegate.WaitingForWorker()
+			})
+
+			It("marks the build as blocked on workers", func() {
+				Expect(fakeDB.UpdateBuildPreparationWorkersAvailableCallCount()).To(Equal(1))
+				actualBuildID, status := fakeDB.UpdateBuildPreparationWorkersAvailableArgsForCall(0)
+				Expect(actualBuildID).To(Equal(42))
+				Expect(status).To(Equal(db.BuildPreparationStatusBlocking))
+			})
+		})
+
+		Describe("WorkerFound", func() {
+			JustBeforeEach(func() {
+				outputDelegate.WorkerFound()
+			})
+
+			It("marks the build as no longer blocked on workers", func() {
+				Expect(fakeDB.UpdateBuildPreparationWorkersAvailableCallCount()).To(Equal(1))
+				actualBuildID, status := fakeDB.UpdateBuildPreparationWorkersAvailableArgsForCall(0)
+				Expect(actualBuildID).To(Equal(42))
+				Expect(status).To(Equal(db.BuildPreparationStatusNotBlocking))
+			})
+		})
+
 		Describe("Failed", func() {
 			JustBeforeEach(func() {
 				outputDelegate.Failed(errors.New("nope"))
@@ -1162,6 +1240,55 @@ var _ = Describe("BuildDelegate", func() {
 		})
 	})
 
+	Describe("ConditionalDelegate", func() {
+		var conditionalDelegate exec.ConditionalDelegate
+
+		BeforeEach(func() {
+			conditionalDelegate = delegate.ConditionalDelegate(logger, atc.ConditionalPlan{
+				Condition: atc.ConditionConfig{Job: "some-job"},
+			}, originID)
+		})
+
+		Describe("Skipped", func() {
+			JustBeforeEach(func() {
+				conditionalDelegate.Skipped()
+			})
+
+			It("saves a skip event", func() {
+				Expect(fakeDB.SaveBuildEventCallCount()).To(Equal(1))
+
+				buildID, pipelineID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
+				Expect(buildID).To(Equal(42))
+				Expect(pipelineID).To(Equal(57))
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.SkipStep{}))
+				Expect(savedEvent.(event.SkipStep).Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(savedEvent.(event.SkipStep).Origin).To(Equal(event.Origin{
+					ID: originID,
+				}))
+			})
+		})
+
+		Describe("Failed", func() {
+			JustBeforeEach(func() {
+				conditionalDelegate.Failed(errors.New("nope"))
+			})
+
+			It("saves an error event", func() {
+				Expect(fakeDB.SaveBuildEventCallCount()).To(Equal(1))
+
+				buildID, pipelineID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
+				Expect(buildID).To(Equal(42))
+				Expect(pipelineID).To(Equal(57))
+				Expect(savedEvent).To(Equal(event.Error{
+					Origin: event.Origin{
+						ID: originID,
+					},
+					Message: "nope",
+				}))
+			})
+		})
+	})
+
 	Describe("Aborted", func() {
 		var aborted bool
 
@@ -1216,4 +1343,143 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 	})
+
+	Describe("redacting secrets", func() {
+		var (
+			executionDelegate exec.TaskDelegate
+			writer            io.Writer
+		)
+
+		savedPayloads := func() []string {
+			payloads := []string{}
+			for i := 0; i < fakeDB.SaveBuildEventCallCount(); i++ {
+				_, _, savedEvent := fakeDB.SaveBuildEventArgsForCall(i)
+				if log, ok := savedEvent.(event.Log); ok {
+					payloads = append(payloads, log.Payload)
+				}
+			}
+
+			return payloads
+		}
+
+		BeforeEach(func() {
+			planFactory := atc.NewPlanFactory(123)
+
+			plan := planFactory.NewPlan(atc.DoPlan{
+				planFactory.NewPlan(atc.GetPlan{
+					Name:     "some-input",
+					Resource: "some-input-resource",
+					Source: atc.Source{
+						"private_key": "some-private-key",
+						"uri":         "https://example.com/some-repo",
+					},
+				}),
+				planFactory.NewPlan(atc.TaskPlan{
+					Name: "some-task",
+					Config: &atc.TaskConfig{
+						Params: map[string]string{"TOKEN": "some-token", "GO_VERSION": "some-version"},
+					},
+				}),
+			})
+
+			plan.Secrets = []string{"some-private-key", "some-password", "some-token"}
+
+			delegate = factory.Delegate(buildID, 57, plan)
+
+			executionDelegate = delegate.ExecutionDelegate(logger, atc.TaskPlan{}, originID)
+			writer = executionDelegate.Stdout()
+		})
+
+		It("scrubs the credentials resolved into the build's plan", func() {
+			_, err := writer.Write([]byte("key some-private-key, pass some-password, token some-token\n"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(savedPayloads()).To(Equal([]string{
+				"key ((redacted)), pass ((redacted)), token ((redacted))\n",
+			}))
+		})
+
+		It("scrubs values configured literally in sources", func() {
+			_, err := writer.Write([]byte("cloning https://example.com/some-repo\n"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(savedPayloads()).To(Equal([]string{"cloning ((redacted))\n"}))
+		})
+
+		It("does not scrub params that do not look like credentials", func() {
+			_, err := writer.Write([]byte("building with some-version\n"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(savedPayloads()).To(Equal([]string{"building with some-version\n"}))
+		})
+
+		Context("when no credential manager resolved any secrets", func() {
+			BeforeEach(func() {
+				planFactory := atc.NewPlanFactory(123)
+
+				plan := planFactory.NewPlan(atc.DoPlan{
+					planFactory.NewPlan(atc.PutPlan{
+						Name:     "some-output",
+						Resource: "some-output-resource",
+						Source: atc.Source{
+							"credentials": map[string]interface{}{
+								"password": "some-literal-password",
+							},
+						},
+						Params: atc.Params{
+							"api_token": "some-literal-token",
+							"path":      "some-path",
+						},
+					}),
+				})
+
+				delegate = factory.Delegate(buildID, 57, plan)
+
+				executionDelegate = delegate.ExecutionDelegate(logger, atc.TaskPlan{}, originID)
+				writer = executionDelegate.Stdout()
+			})
+
+			It("scrubs literal sources and secret-looking params", func() {
+				_, err := writer.Write([]byte("pass some-literal-password, token some-literal-token, path some-path\n"))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(savedPayloads()).To(Equal([]string{
+					"pass ((redacted)), token ((redacted)), path some-path\n",
+				}))
+			})
+		})
+
+		It("scrubs secrets split across writes", func() {
+			_, err := writer.Write([]byte("token some-to"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(savedPayloads()).To(Equal([]string{"token "}))
+
+			_, err = writer.Write([]byte("ken done\n"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(savedPayloads()).To(Equal([]string{"token ", "((redacted)) done\n"}))
+		})
+
+		It("saves held-back output that did not turn out to be a secret", func() {
+			_, err := writer.Write([]byte("some-to"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = writer.Write([]byte("p\n"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(savedPayloads()).To(Equal([]string{"some-top\n"}))
+		})
+
+		It("saves held-back output when the step finishes", func() {
+			_, err := writer.Write([]byte("ends with some-"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(savedPayloads()).To(Equal([]string{"ends with "}))
+
+			executionDelegate.Finished(0)
+
+			Expect(savedPayloads()).To(Equal([]string{"ends with ", "some-"}))
+		})
+	})
 })
@@ -125,6 +125,77 @@ var _ = Describe("ExecEngine", func() {
 			fakeFactory.DependentGetReturns(dependentStepFactory)
 		})
 
+		Describe("with a conditional step", func() {
+			var (
+				fakeConditionalDelegate *execfakes.FakeConditionalDelegate
+
+				conditionalPlan atc.Plan
+				condition       atc.ConditionConfig
+			)
+
+			BeforeEach(func() {
+				fakeConditionalDelegate = new(execfakes.FakeConditionalDelegate)
+				fakeDelegate.ConditionalDelegateReturns(fakeConditionalDelegate)
+
+				condition = atc.ConditionConfig{}
+			})
+
+			JustBeforeEach(func() {
+				conditionalPlan = planFactory.NewPlan(atc.ConditionalPlan{
+					Condition: condition,
+					Step: planFactory.NewPlan(atc.TaskPlan{
+						Name:       "some-task",
+						PipelineID: 57,
+						ConfigPath: "some-config-path",
+					}),
+				})
+
+				build, err := execEngine.CreateBuild(logger, buildModel, conditionalPlan)
+				Expect(err).NotTo(HaveOccurred())
+
+				build.Resume(logger)
+			})
+
+			It("constructs the conditional's delegate", func() {
+				Expect(fakeDelegate.ConditionalDelegateCallCount()).To(Equal(1))
+
+				_, plan, originID := fakeDelegate.ConditionalDelegateArgsForCall(0)
+				Expect(plan).To(Equal(*conditionalPlan.Conditional))
+				Expect(originID).To(Equal(event.OriginID(conditionalPlan.ID)))
+			})
+
+			Context("when the condition matches the build's job", func() {
+				BeforeEach(func() {
+					condition = atc.ConditionConfig{Job: "some-job"}
+				})
+
+				It("runs the nested step", func() {
+					Expect(taskStep.RunCallCount()).To(Equal(1))
+					Expect(fakeConditionalDelegate.SkippedCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the condition does not match the build's job", func() {
+				BeforeEach(func() {
+					condition = atc.ConditionConfig{Job: "some-other-job"}
+				})
+
+				It("skips the nested step", func() {
+					Expect(taskStep.RunCallCount()).To(BeZero())
+					Expect(fakeConditionalDelegate.SkippedCallCount()).To(Equal(1))
+				})
+
+				It("finishes the build successfully", func() {
+					Expect(fakeDelegate.FinishCallCount()).To(Equal(1))
+
+					_, err, succeeded, aborted := fakeDelegate.FinishArgsForCall(0)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(succeeded).To(Equal(exec.Success(true)))
+					Expect(aborted).To(BeFalse())
+				})
+			})
+		})
+
 		Describe("with a putget in an aggregate", func() {
 			var (
 				putPlan               atc.Plan
@@ -206,6 +277,41 @@ var _ = Describe("ExecEngine", func() {
 				Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 			})
 
+			It("gives the steps' containers the build's team", func() {
+				buildModel.TeamID = 3
+
+				var err error
+				build, err = execEngine.CreateBuild(logger, buildModel, outputPlan)
+				Expect(err).NotTo(HaveOccurred())
+
+				build.Resume(logger)
+				Expect(fakeFactory.PutCallCount()).To(Equal(2))
+
+				_, _, _, workerMetadata, _, _, _, _, _, _, _ := fakeFactory.PutArgsForCall(0)
+				Expect(workerMetadata.TeamID).To(Equal(3))
+			})
+
+			Context("when the build is a one-off build", func() {
+				BeforeEach(func() {
+					buildModel.JobName = ""
+					buildModel.PipelineName = ""
+					buildModel.PipelineID = 0
+					buildModel.TeamID = 3
+				})
+
+				It("still gives the steps' containers the build's team", func() {
+					var err error
+					build, err = execEngine.CreateBuild(logger, buildModel, outputPlan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+					Expect(fakeFactory.PutCallCount()).To(Equal(2))
+
+					_, _, _, workerMetadata, _, _, _, _, _, _, _ := fakeFactory.PutArgsForCall(0)
+					Expect(workerMetadata.TeamID).To(Equal(3))
+				})
+			})
+
 			Context("constructing outputs", func() {
 				It("constructs the put correctly", func() {
 					var err error
@@ -155,3 +155,11 @@ type InitializePut struct {
 
 func (InitializePut) EventType() atc.EventType  { return EventTypeInitializePut }
 func (InitializePut) Version() atc.EventVersion { return "1.0" }
+
+type SkipStep struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+}
+
+func (SkipStep) EventType() atc.EventType  { return EventTypeSkipStep }
+func (SkipStep) Version() atc.EventVersion { return "4.0" }
@@ -40,6 +40,7 @@ func init() {
 	registerEvent(FinishGet{})
 	registerEvent(InitializePut{})
 	registerEvent(FinishPut{})
+	registerEvent(SkipStep{})
 	registerEvent(Status{})
 	registerEvent(Log{})
 	registerEvent(Error{})
@@ -32,6 +32,9 @@ const (
 	// finished putting something
 	EventTypeFinishPut atc.EventType = "finish-put"
 
+	// conditional step skipped (its condition was not met)
+	EventTypeSkipStep atc.EventType = "skip-step"
+
 	// error occurred
 	EventTypeError atc.EventType = "error"
 )
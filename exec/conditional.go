@@ -0,0 +1,160 @@
+package exec
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/concourse/atc"
+)
+
+//go:generate counterfeiter . ConditionalDelegate
+
+// ConditionalDelegate is used to record events related to a ConditionalStep's
+// evaluation.
+type ConditionalDelegate interface {
+	Skipped()
+	Failed(error)
+}
+
+// ConditionalStep only runs its nested step if all of the criteria in its
+// condition are met.
+type ConditionalStep struct {
+	condition atc.ConditionConfig
+	jobName   string
+	step      StepFactory
+	delegate  ConditionalDelegate
+
+	repo    *SourceRepository
+	runStep Step
+	ran     bool
+}
+
+// Conditional constructs a ConditionalStep factory. The job name is that of
+// the build being run, which is empty for one-off builds.
+func Conditional(
+	condition atc.ConditionConfig,
+	jobName string,
+	step StepFactory,
+	delegate ConditionalDelegate,
+) ConditionalStep {
+	return ConditionalStep{
+		condition: condition,
+		jobName:   jobName,
+		step:      step,
+		delegate:  delegate,
+	}
+}
+
+// Using constructs a *ConditionalStep.
+func (cs ConditionalStep) Using(prev Step, repo *SourceRepository) Step {
+	cs.repo = repo
+	cs.runStep = cs.step.Using(prev, repo)
+	return &cs
+}
+
+// Run evaluates the condition and, if it is met, runs the nested step and
+// returns its error.
+//
+// If the condition is not met, the delegate is informed that the step was
+// skipped and nil is returned. If the condition could not be evaluated (e.g.
+// the referenced get step or file does not exist), the delegate is informed of
+// the failure and the error is returned.
+func (cs *ConditionalStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	met, err := cs.conditionMet()
+	if err != nil {
+		cs.delegate.Failed(err)
+		return err
+	}
+
+	if !met {
+		cs.delegate.Skipped()
+		close(ready)
+		return nil
+	}
+
+	cs.ran = true
+
+	return cs.runStep.Run(signals, ready)
+}
+
+// Release releases the nested step, if it ran.
+func (cs *ConditionalStep) Release() {
+	if cs.ran {
+		cs.runStep.Release()
+	}
+}
+
+// Result indicates Success as true if the nested step was skipped, and
+// otherwise delegates to the nested step.
+func (cs *ConditionalStep) Result(x interface{}) bool {
+	if cs.ran {
+		return cs.runStep.Result(x)
+	}
+
+	switch v := x.(type) {
+	case *Success:
+		*v = Success(true)
+		return true
+	default:
+		return false
+	}
+}
+
+func (cs *ConditionalStep) conditionMet() (bool, error) {
+	if cs.condition.Job != "" && cs.condition.Job != cs.jobName {
+		return false, nil
+	}
+
+	if cs.condition.Version != nil {
+		met, err := cs.versionMatches(*cs.condition.Version)
+		if err != nil || !met {
+			return false, err
+		}
+	}
+
+	if cs.condition.File != "" {
+		met, err := cs.fileIsTrue(cs.condition.File)
+		if err != nil || !met {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func (cs *ConditionalStep) versionMatches(condition atc.VersionCondition) (bool, error) {
+	source, found := cs.repo.SourceFor(SourceName(condition.Get))
+	if !found {
+		return false, fmt.Errorf("unknown get step in condition: %s", condition.Get)
+	}
+
+	step, ok := source.(Step)
+	if !ok {
+		return false, fmt.Errorf("no version available for condition: %s", condition.Get)
+	}
+
+	var info VersionInfo
+	if !step.Result(&info) {
+		return false, fmt.Errorf("no version available for condition: %s", condition.Get)
+	}
+
+	return info.Version[condition.Key] == condition.Value, nil
+}
+
+func (cs *ConditionalStep) fileIsTrue(path string) (bool, error) {
+	stream, err := cs.repo.StreamFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	defer stream.Close()
+
+	contents, err := ioutil.ReadAll(stream)
+	if err != nil {
+		return false, err
+	}
+
+	return strings.TrimSpace(string(contents)) == "true", nil
+}
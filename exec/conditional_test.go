@@ -0,0 +1,252 @@
+package exec_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/exec"
+
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type fakeGetArtifact struct {
+	*execfakes.FakeArtifactSource
+	*execfakes.FakeStep
+}
+
+var _ = Describe("Conditional Step", func() {
+	var (
+		fakeStepFactoryStep *execfakes.FakeStepFactory
+		runStep             *execfakes.FakeStep
+		fakeDelegate        *execfakes.FakeConditionalDelegate
+
+		repo *SourceRepository
+
+		condition atc.ConditionConfig
+		jobName   string
+
+		step Step
+
+		ready  chan struct{}
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeStepFactoryStep = new(execfakes.FakeStepFactory)
+		runStep = new(execfakes.FakeStep)
+		fakeStepFactoryStep.UsingReturns(runStep)
+
+		fakeDelegate = new(execfakes.FakeConditionalDelegate)
+
+		repo = NewSourceRepository()
+
+		condition = atc.ConditionConfig{}
+		jobName = "some-job"
+	})
+
+	JustBeforeEach(func() {
+		step = Conditional(condition, jobName, fakeStepFactoryStep, fakeDelegate).Using(nil, repo)
+
+		ready = make(chan struct{})
+		runErr = step.Run(nil, ready)
+	})
+
+	itRunsTheStep := func() {
+		It("runs the nested step", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(runStep.RunCallCount()).To(Equal(1))
+			Expect(fakeDelegate.SkippedCallCount()).To(BeZero())
+		})
+
+		It("delegates its result to the nested step", func() {
+			runStep.ResultStub = successResult(false)
+
+			var success Success
+			Expect(step.Result(&success)).To(BeTrue())
+			Expect(success).To(Equal(Success(false)))
+		})
+
+		It("releases the nested step", func() {
+			step.Release()
+			Expect(runStep.ReleaseCallCount()).To(Equal(1))
+		})
+	}
+
+	itSkipsTheStep := func() {
+		It("does not run the nested step", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(runStep.RunCallCount()).To(BeZero())
+		})
+
+		It("informs the delegate that it was skipped", func() {
+			Expect(fakeDelegate.SkippedCallCount()).To(Equal(1))
+			Expect(fakeDelegate.FailedCallCount()).To(BeZero())
+		})
+
+		It("indicates that it's ready", func() {
+			Expect(ready).To(BeClosed())
+		})
+
+		It("succeeds", func() {
+			var success Success
+			Expect(step.Result(&success)).To(BeTrue())
+			Expect(success).To(Equal(Success(true)))
+		})
+
+		It("does not release the nested step", func() {
+			step.Release()
+			Expect(runStep.ReleaseCallCount()).To(BeZero())
+		})
+	}
+
+	Context("with no criteria", func() {
+		itRunsTheStep()
+	})
+
+	Context("with a job criterion", func() {
+		Context("when the job name matches", func() {
+			BeforeEach(func() {
+				condition.Job = "some-job"
+			})
+
+			itRunsTheStep()
+		})
+
+		Context("when the job name does not match", func() {
+			BeforeEach(func() {
+				condition.Job = "some-other-job"
+			})
+
+			itSkipsTheStep()
+		})
+	})
+
+	Context("with a version criterion", func() {
+		var fakeGet fakeGetArtifact
+
+		BeforeEach(func() {
+			condition.Version = &atc.VersionCondition{
+				Get:   "some-input",
+				Key:   "branch",
+				Value: "master",
+			}
+
+			fakeGet = fakeGetArtifact{
+				FakeArtifactSource: new(execfakes.FakeArtifactSource),
+				FakeStep:           new(execfakes.FakeStep),
+			}
+		})
+
+		Context("when the get step is present", func() {
+			var version atc.Version
+
+			BeforeEach(func() {
+				fakeGet.FakeStep.ResultStub = func(x interface{}) bool {
+					switch v := x.(type) {
+					case *VersionInfo:
+						*v = VersionInfo{Version: version}
+						return true
+					default:
+						return false
+					}
+				}
+
+				repo.RegisterSource("some-input", fakeGet)
+			})
+
+			Context("when the version matches", func() {
+				BeforeEach(func() {
+					version = atc.Version{"branch": "master", "ref": "abc"}
+				})
+
+				itRunsTheStep()
+			})
+
+			Context("when the version does not match", func() {
+				BeforeEach(func() {
+					version = atc.Version{"branch": "develop", "ref": "abc"}
+				})
+
+				itSkipsTheStep()
+			})
+		})
+
+		Context("when the get step is not present", func() {
+			It("fails without running the nested step", func() {
+				Expect(runErr).To(HaveOccurred())
+				Expect(runStep.RunCallCount()).To(BeZero())
+
+				Expect(fakeDelegate.FailedCallCount()).To(Equal(1))
+				Expect(fakeDelegate.FailedArgsForCall(0)).To(Equal(runErr))
+			})
+		})
+	})
+
+	Context("with a file criterion", func() {
+		var fakeSource *execfakes.FakeArtifactSource
+
+		BeforeEach(func() {
+			condition.File = "some-input/flag"
+
+			fakeSource = new(execfakes.FakeArtifactSource)
+			repo.RegisterSource("some-input", fakeSource)
+		})
+
+		Context("when the file contains true", func() {
+			BeforeEach(func() {
+				fakeSource.StreamFileReturns(ioutil.NopCloser(bytes.NewBufferString("true\n")), nil)
+			})
+
+			itRunsTheStep()
+
+			It("streams the file from the artifact", func() {
+				Expect(fakeSource.StreamFileCallCount()).To(Equal(1))
+				Expect(fakeSource.StreamFileArgsForCall(0)).To(Equal("flag"))
+			})
+		})
+
+		Context("when the file contains anything else", func() {
+			BeforeEach(func() {
+				fakeSource.StreamFileReturns(ioutil.NopCloser(bytes.NewBufferString("false\n")), nil)
+			})
+
+			itSkipsTheStep()
+		})
+
+		Context("when the file cannot be streamed", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeSource.StreamFileReturns(nil, disaster)
+			})
+
+			It("fails without running the nested step", func() {
+				Expect(runErr).To(Equal(disaster))
+				Expect(runStep.RunCallCount()).To(BeZero())
+
+				Expect(fakeDelegate.FailedCallCount()).To(Equal(1))
+				Expect(fakeDelegate.FailedArgsForCall(0)).To(Equal(disaster))
+			})
+		})
+	})
+
+	Context("with several criteria", func() {
+		BeforeEach(func() {
+			condition.Job = "some-job"
+			condition.File = "some-input/flag"
+
+			fakeSource := new(execfakes.FakeArtifactSource)
+			fakeSource.StreamFileReturns(ioutil.NopCloser(bytes.NewBufferString("nope")), nil)
+			repo.RegisterSource("some-input", fakeSource)
+		})
+
+		It("requires all of them to be met", func() {
+			Expect(runStep.RunCallCount()).To(BeZero())
+			Expect(fakeDelegate.SkippedCallCount()).To(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,85 @@
+// This file was generated by counterfeiter
+package execfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/exec"
+)
+
+type FakeConditionalDelegate struct {
+	SkippedStub        func()
+	skippedMutex       sync.RWMutex
+	skippedArgsForCall []struct{}
+	FailedStub         func(error)
+	failedMutex        sync.RWMutex
+	failedArgsForCall  []struct {
+		arg1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeConditionalDelegate) Skipped() {
+	fake.skippedMutex.Lock()
+	fake.skippedArgsForCall = append(fake.skippedArgsForCall, struct{}{})
+	fake.recordInvocation("Skipped", []interface{}{})
+	fake.skippedMutex.Unlock()
+	if fake.SkippedStub != nil {
+		fake.SkippedStub()
+	}
+}
+
+func (fake *FakeConditionalDelegate) SkippedCallCount() int {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	return len(fake.skippedArgsForCall)
+}
+
+func (fake *FakeConditionalDelegate) Failed(arg1 error) {
+	fake.failedMutex.Lock()
+	fake.failedArgsForCall = append(fake.failedArgsForCall, struct {
+		arg1 error
+	}{arg1})
+	fake.recordInvocation("Failed", []interface{}{arg1})
+	fake.failedMutex.Unlock()
+	if fake.FailedStub != nil {
+		fake.FailedStub(arg1)
+	}
+}
+
+func (fake *FakeConditionalDelegate) FailedCallCount() int {
+	fake.failedMutex.RLock()
+	defer fake.failedMutex.RUnlock()
+	return len(fake.failedArgsForCall)
+}
+
+func (fake *FakeConditionalDelegate) FailedArgsForCall(i int) error {
+	fake.failedMutex.RLock()
+	defer fake.failedMutex.RUnlock()
+	return fake.failedArgsForCall[i].arg1
+}
+
+func (fake *FakeConditionalDelegate) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	fake.failedMutex.RLock()
+	defer fake.failedMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeConditionalDelegate) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.ConditionalDelegate = new(FakeConditionalDelegate)
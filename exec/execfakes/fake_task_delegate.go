@@ -37,10 +37,16 @@ type FakeTaskDelegate struct {
 	imageVersionDeterminedReturns struct {
 		result1 error
 	}
-	StdoutStub        func() io.Writer
-	stdoutMutex       sync.RWMutex
-	stdoutArgsForCall []struct{}
-	stdoutReturns     struct {
+	WaitingForWorkerStub        func()
+	waitingForWorkerMutex       sync.RWMutex
+	waitingForWorkerArgsForCall []struct{}
+	WorkerFoundStub             func()
+	workerFoundMutex            sync.RWMutex
+	workerFoundArgsForCall      []struct{}
+	StdoutStub                  func() io.Writer
+	stdoutMutex                 sync.RWMutex
+	stdoutArgsForCall           []struct{}
+	stdoutReturns               struct {
 		result1 io.Writer
 	}
 	StderrStub        func() io.Writer
@@ -174,6 +180,38 @@ func (fake *FakeTaskDelegate) ImageVersionDeterminedReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTaskDelegate) WaitingForWorker() {
+	fake.waitingForWorkerMutex.Lock()
+	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct{}{})
+	fake.recordInvocation("WaitingForWorker", []interface{}{})
+	fake.waitingForWorkerMutex.Unlock()
+	if fake.WaitingForWorkerStub != nil {
+		fake.WaitingForWorkerStub()
+	}
+}
+
+func (fake *FakeTaskDelegate) WaitingForWorkerCallCount() int {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	return len(fake.waitingForWorkerArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) WorkerFound() {
+	fake.workerFoundMutex.Lock()
+	fake.workerFoundArgsForCall = append(fake.workerFoundArgsForCall, struct{}{})
+	fake.recordInvocation("WorkerFound", []interface{}{})
+	fake.workerFoundMutex.Unlock()
+	if fake.WorkerFoundStub != nil {
+		fake.WorkerFoundStub()
+	}
+}
+
+func (fake *FakeTaskDelegate) WorkerFoundCallCount() int {
+	fake.workerFoundMutex.RLock()
+	defer fake.workerFoundMutex.RUnlock()
+	return len(fake.workerFoundArgsForCall)
+}
+
 func (fake *FakeTaskDelegate) Stdout() io.Writer {
 	fake.stdoutMutex.Lock()
 	fake.stdoutArgsForCall = append(fake.stdoutArgsForCall, struct{}{})
@@ -237,6 +275,10 @@ func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	defer fake.failedMutex.RUnlock()
 	fake.imageVersionDeterminedMutex.RLock()
 	defer fake.imageVersionDeterminedMutex.RUnlock()
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	fake.workerFoundMutex.RLock()
+	defer fake.workerFoundMutex.RUnlock()
 	fake.stdoutMutex.RLock()
 	defer fake.stdoutMutex.RUnlock()
 	fake.stderrMutex.RLock()
@@ -102,6 +102,9 @@ type TaskDelegate interface {
 
 	ImageVersionDetermined(worker.VolumeIdentifier) error
 
+	WaitingForWorker()
+	WorkerFound()
+
 	Stdout() io.Writer
 	Stderr() io.Writer
 }
@@ -116,6 +119,9 @@ type ResourceDelegate interface {
 
 	ImageVersionDetermined(worker.VolumeIdentifier) error
 
+	WaitingForWorker()
+	WorkerFound()
+
 	Stdout() io.Writer
 	Stderr() io.Writer
 }
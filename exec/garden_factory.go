@@ -18,6 +18,7 @@ type gardenFactory struct {
 	workerClient   worker.Client
 	tracker        resource.Tracker
 	trackerFactory TrackerFactory
+	taskCacheTTL   time.Duration
 }
 
 //go:generate counterfeiter . TrackerFactory
@@ -29,10 +30,12 @@ type TrackerFactory interface {
 func NewGardenFactory(
 	workerClient worker.Client,
 	tracker resource.Tracker,
+	taskCacheTTL time.Duration,
 ) Factory {
 	return &gardenFactory{
 		workerClient: workerClient,
 		tracker:      tracker,
+		taskCacheTTL: taskCacheTTL,
 	}
 }
 
@@ -184,6 +187,7 @@ func (factory *gardenFactory) Task(
 		clock,
 		containerSuccessTTL,
 		containerFailureTTL,
+		factory.taskCacheTTL,
 	)
 }
 
@@ -0,0 +1,183 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tedsuo/ifrit"
+)
+
+// InParallel constructs a Step that will run its steps in parallel, with at
+// most Limit of them running at once.
+type InParallel struct {
+	Steps    []StepFactory
+	Limit    int
+	FailFast bool
+}
+
+// Using delegates to each StepFactory and returns an *InParallelStep. As with
+// Aggregate, every step shares the same SourceRepository, so the artifacts
+// they produce are all available to subsequent steps.
+func (p InParallel) Using(prev Step, repo *SourceRepository) Step {
+	step := &InParallelStep{
+		limit:    p.Limit,
+		failFast: p.FailFast,
+	}
+
+	for _, factory := range p.Steps {
+		step.steps = append(step.steps, factory.Using(prev, repo))
+	}
+
+	return step
+}
+
+// InParallelStep is a step of steps to run in parallel, bounded by a limit.
+type InParallelStep struct {
+	steps    []Step
+	limit    int
+	failFast bool
+
+	started  int
+	canceled bool
+}
+
+type exitedStep struct {
+	index int
+	err   error
+}
+
+// Run starts as many steps as the limit permits (all of them if the limit is
+// zero), and starts another each time one exits. It indicates that it's ready
+// as soon as the first steps have been started, and propagates any signal
+// received to all running steps.
+//
+// If FailFast is set, the first step to fail or error causes the running steps
+// to be interrupted and no further steps to be started. Otherwise it waits for
+// every step to run, as Aggregate does. The errors of all steps (other than
+// those it interrupted itself) are aggregated and returned as a single error.
+func (step *InParallelStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	running := map[int]ifrit.Process{}
+	exited := make(chan exitedStep, len(step.steps))
+
+	startMore := func() {
+		for step.started < len(step.steps) && (step.limit <= 0 || len(running) < step.limit) {
+			index := step.started
+			step.started++
+
+			process := ifrit.Background(step.steps[index])
+			running[index] = process
+
+			go func() {
+				exited <- exitedStep{index: index, err: <-process.Wait()}
+			}()
+		}
+	}
+
+	startMore()
+
+	close(ready)
+
+	var errorMessages []string
+
+	for len(running) > 0 {
+		select {
+		case sig := <-signals:
+			for _, process := range running {
+				process.Signal(sig)
+			}
+
+			for len(running) > 0 {
+				delete(running, (<-exited).index)
+			}
+
+			return ErrInterrupted
+
+		case exit := <-exited:
+			delete(running, exit.index)
+
+			if exit.err != nil && !(step.canceled && exit.err == ErrInterrupted) {
+				errorMessages = append(errorMessages, exit.err.Error())
+			}
+
+			if step.canceled {
+				continue
+			}
+
+			if step.failFast && !stepSucceeded(step.steps[exit.index], exit.err) {
+				step.canceled = true
+
+				for _, process := range running {
+					process.Signal(os.Interrupt)
+				}
+
+				continue
+			}
+
+			startMore()
+		}
+	}
+
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("steps failed:\n%s", strings.Join(errorMessages, "\n"))
+	}
+
+	return nil
+}
+
+// Release iterates over the steps and Releases them individually.
+func (step *InParallelStep) Release() {
+	for _, s := range step.steps {
+		s.Release()
+	}
+}
+
+// Result indicates Success as true if every step was run and all of those
+// indicating Success indicate it as true, or if there were no steps at all. If
+// none of the steps can indicate Success, it will return false and not
+// indicate success itself.
+//
+// All other result types are ignored, and Result will return false.
+func (step *InParallelStep) Result(x interface{}) bool {
+	if success, ok := x.(*Success); ok {
+		if len(step.steps) == 0 {
+			*success = Success(true)
+			return true
+		}
+
+		succeeded := !step.canceled && step.started == len(step.steps)
+		anyIndicated := false
+		for _, s := range step.steps[:step.started] {
+			var r Success
+			if !s.Result(&r) {
+				continue
+			}
+
+			anyIndicated = true
+			succeeded = succeeded && bool(r)
+		}
+
+		if !anyIndicated {
+			return false
+		}
+
+		*success = Success(succeeded)
+
+		return true
+	}
+
+	return false
+}
+
+func stepSucceeded(step Step, err error) bool {
+	if err != nil {
+		return false
+	}
+
+	var success Success
+	if !step.Result(&success) {
+		return true
+	}
+
+	return bool(success)
+}
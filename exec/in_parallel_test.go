@@ -0,0 +1,248 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+
+	. "github.com/concourse/atc/exec"
+
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("InParallel", func() {
+	var (
+		fakeStepA *execfakes.FakeStepFactory
+		fakeStepB *execfakes.FakeStepFactory
+		fakeStepC *execfakes.FakeStepFactory
+
+		limit    int
+		failFast bool
+
+		inStep *execfakes.FakeStep
+		repo   *SourceRepository
+
+		outStepA *execfakes.FakeStep
+		outStepB *execfakes.FakeStep
+		outStepC *execfakes.FakeStep
+
+		step    Step
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		fakeStepA = new(execfakes.FakeStepFactory)
+		fakeStepB = new(execfakes.FakeStepFactory)
+		fakeStepC = new(execfakes.FakeStepFactory)
+
+		limit = 0
+		failFast = false
+
+		inStep = new(execfakes.FakeStep)
+		repo = NewSourceRepository()
+
+		outStepA = new(execfakes.FakeStep)
+		outStepA.ResultStub = successResult(true)
+		fakeStepA.UsingReturns(outStepA)
+
+		outStepB = new(execfakes.FakeStep)
+		outStepB.ResultStub = successResult(true)
+		fakeStepB.UsingReturns(outStepB)
+
+		outStepC = new(execfakes.FakeStep)
+		outStepC.ResultStub = successResult(true)
+		fakeStepC.UsingReturns(outStepC)
+	})
+
+	JustBeforeEach(func() {
+		step = InParallel{
+			Steps:    []StepFactory{fakeStepA, fakeStepB, fakeStepC},
+			Limit:    limit,
+			FailFast: failFast,
+		}.Using(inStep, repo)
+
+		process = ifrit.Invoke(step)
+	})
+
+	It("uses the input source and repository for all steps", func() {
+		for _, factory := range []*execfakes.FakeStepFactory{fakeStepA, fakeStepB, fakeStepC} {
+			Expect(factory.UsingCallCount()).To(Equal(1))
+			prev, stepRepo := factory.UsingArgsForCall(0)
+			Expect(prev).To(Equal(inStep))
+			Expect(stepRepo).To(BeIdenticalTo(repo))
+		}
+	})
+
+	It("runs every step and exits successfully", func() {
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+
+		Expect(outStepA.RunCallCount()).To(Equal(1))
+		Expect(outStepB.RunCallCount()).To(Equal(1))
+		Expect(outStepC.RunCallCount()).To(Equal(1))
+
+		var success Success
+		Expect(step.Result(&success)).To(BeTrue())
+		Expect(success).To(Equal(Success(true)))
+	})
+
+	Context("with a limit", func() {
+		var (
+			releaseA chan struct{}
+			releaseB chan struct{}
+		)
+
+		BeforeEach(func() {
+			limit = 2
+
+			releaseA = make(chan struct{})
+			releaseB = make(chan struct{})
+
+			outStepA.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+				<-releaseA
+				return nil
+			}
+
+			outStepB.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+				<-releaseB
+				return nil
+			}
+		})
+
+		AfterEach(func() {
+			close(releaseB)
+			Eventually(process.Wait()).Should(Receive())
+		})
+
+		It("only runs that many steps at once", func() {
+			Eventually(outStepA.RunCallCount).Should(Equal(1))
+			Eventually(outStepB.RunCallCount).Should(Equal(1))
+			Consistently(outStepC.RunCallCount).Should(BeZero())
+
+			close(releaseA)
+
+			Eventually(outStepC.RunCallCount).Should(Equal(1))
+		})
+	})
+
+	Context("when a step fails", func() {
+		BeforeEach(func() {
+			limit = 1
+			outStepA.ResultStub = successResult(false)
+		})
+
+		Context("without fail_fast", func() {
+			It("still runs every step", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(outStepB.RunCallCount()).To(Equal(1))
+				Expect(outStepC.RunCallCount()).To(Equal(1))
+			})
+
+			It("does not succeed", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				var success Success
+				Expect(step.Result(&success)).To(BeTrue())
+				Expect(success).To(Equal(Success(false)))
+			})
+		})
+
+		Context("with fail_fast", func() {
+			BeforeEach(func() {
+				failFast = true
+			})
+
+			It("does not start any more steps", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(outStepA.RunCallCount()).To(Equal(1))
+				Expect(outStepB.RunCallCount()).To(BeZero())
+				Expect(outStepC.RunCallCount()).To(BeZero())
+			})
+
+			It("does not succeed", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				var success Success
+				Expect(step.Result(&success)).To(BeTrue())
+				Expect(success).To(Equal(Success(false)))
+			})
+		})
+	})
+
+	Context("with fail_fast when a step errors while its siblings are running", func() {
+		disaster := errors.New("nope")
+
+		var receivedSignals chan os.Signal
+
+		BeforeEach(func() {
+			failFast = true
+
+			receivedSignals = make(chan os.Signal, 2)
+
+			outStepA.RunReturns(disaster)
+
+			interruptible := func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+				receivedSignals <- <-signals
+				return ErrInterrupted
+			}
+
+			outStepB.RunStub = interruptible
+			outStepC.RunStub = interruptible
+		})
+
+		It("interrupts the siblings", func() {
+			Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+			Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+		})
+
+		It("returns only the original error", func() {
+			var err error
+			Eventually(process.Wait()).Should(Receive(&err))
+			Expect(err).To(MatchError("steps failed:\nnope"))
+		})
+	})
+
+	Describe("signalling", func() {
+		var receivedSignals chan os.Signal
+
+		BeforeEach(func() {
+			receivedSignals = make(chan os.Signal, 3)
+
+			interruptible := func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+				receivedSignals <- <-signals
+				return ErrInterrupted
+			}
+
+			outStepA.RunStub = interruptible
+			outStepB.RunStub = interruptible
+			outStepC.RunStub = interruptible
+		})
+
+		It("propagates the signal to running steps and returns ErrInterrupted", func() {
+			process.Signal(os.Interrupt)
+
+			Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+			Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+			Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+			Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+		})
+	})
+
+	Describe("releasing", func() {
+		It("releases all steps", func() {
+			Eventually(process.Wait()).Should(Receive())
+
+			step.Release()
+			Expect(outStepA.ReleaseCallCount()).To(Equal(1))
+			Expect(outStepB.ReleaseCallCount()).To(Equal(1))
+			Expect(outStepC.ReleaseCallCount()).To(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,173 @@
+package exec
+
+import (
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker"
+	"github.com/pivotal-golang/lager"
+)
+
+// TaskCacheIdentifier identifies the volume backing one of a task's caches.
+// Caches are scoped to the pipeline, job, and step that declares them, so
+// that each build of the job picks up the contents left by the last
+// successful one on the same worker.
+//
+// Builds never write to the cache itself. Each build works in its own
+// copy-on-write copy of it, whose contents are committed to a fresh volume
+// as the new cache only if the build succeeds.
+type TaskCacheIdentifier struct {
+	PipelineID int
+	JobName    string
+	StepName   string
+	Path       string
+
+	Privileged bool
+}
+
+func (identifier TaskCacheIdentifier) FindOn(logger lager.Logger, workerClient worker.Client) (worker.Volume, bool, error) {
+	volumes, err := workerClient.ListVolumes(logger, identifier.volumeProperties())
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(volumes) == 0 {
+		return nil, false, nil
+	}
+
+	// concurrent builds may each have committed a cache; keep one and let the
+	// rest expire
+	for _, extra := range volumes[1:] {
+		err := identifier.retire(extra)
+		if err != nil {
+			logger.Error("failed-to-retire-cache", err, lager.Data{"volume-handle": extra.Handle()})
+		}
+
+		extra.Release(nil)
+	}
+
+	return volumes[0], true, nil
+}
+
+// CreateWorkingCopyOn creates the volume a build mounts for the cache: a
+// copy-on-write child of the current cache, or an empty volume if there is no
+// cache yet. The working copy is not found by FindOn until it is committed.
+func (identifier TaskCacheIdentifier) CreateWorkingCopyOn(
+	logger lager.Logger,
+	workerClient worker.Client,
+	cache worker.Volume,
+	ttl time.Duration,
+) (worker.Volume, error) {
+	var strategy worker.Strategy = identifier.strategy()
+	if cache != nil {
+		strategy = worker.TaskCacheCOWStrategy{
+			Parent:     cache,
+			PipelineID: identifier.PipelineID,
+			JobName:    identifier.JobName,
+			StepName:   identifier.StepName,
+			Path:       identifier.Path,
+		}
+	}
+
+	return workerClient.CreateVolume(
+		logger,
+		worker.VolumeSpec{
+			Strategy:   strategy,
+			Properties: identifier.workingCopyProperties(),
+			Privileged: identifier.Privileged,
+			TTL:        ttl,
+		},
+	)
+}
+
+// Commit streams the contents of a build's working copy into a fresh volume
+// and makes that the cache found by later builds, retiring the cache the
+// working copy was made from. The working copy itself is never kept, so that
+// each cache is not a copy-on-write child of the one before it.
+func (identifier TaskCacheIdentifier) Commit(
+	logger lager.Logger,
+	workerClient worker.Client,
+	contents io.Reader,
+	cache worker.Volume,
+	ttl time.Duration,
+) (worker.Volume, error) {
+	committed, err := workerClient.CreateVolume(
+		logger,
+		worker.VolumeSpec{
+			Strategy:   identifier.strategy(),
+			Properties: identifier.workingCopyProperties(),
+			Privileged: identifier.Privileged,
+			TTL:        ttl,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = identifier.commit(committed, contents, cache)
+	if err != nil {
+		// it was never found as the cache, so it can just expire
+		committed.Release(nil)
+		return nil, err
+	}
+
+	return committed, nil
+}
+
+func (identifier TaskCacheIdentifier) commit(committed worker.Volume, contents io.Reader, cache worker.Volume) error {
+	err := committed.StreamIn(".", contents)
+	if err != nil {
+		return err
+	}
+
+	if cache != nil {
+		err := identifier.retire(cache)
+		if err != nil {
+			return err
+		}
+	}
+
+	return committed.SetProperty(worker.TaskCachePathProperty, identifier.Path)
+}
+
+func (identifier TaskCacheIdentifier) VolumeIdentifier() worker.VolumeIdentifier {
+	return worker.VolumeIdentifier{
+		TaskCache: &db.TaskCacheIdentifier{
+			PipelineID: identifier.PipelineID,
+			JobName:    identifier.JobName,
+			StepName:   identifier.StepName,
+			Path:       identifier.Path,
+		},
+	}
+}
+
+func (identifier TaskCacheIdentifier) strategy() worker.TaskCacheStrategy {
+	return worker.TaskCacheStrategy{
+		PipelineID: identifier.PipelineID,
+		JobName:    identifier.JobName,
+		StepName:   identifier.StepName,
+		Path:       identifier.Path,
+	}
+}
+
+// retire stops the volume from being found as the cache; it expires once its
+// TTL runs out.
+func (identifier TaskCacheIdentifier) retire(volume worker.Volume) error {
+	return volume.SetProperty(worker.TaskCachePathProperty, "")
+}
+
+func (identifier TaskCacheIdentifier) volumeProperties() worker.VolumeProperties {
+	properties := identifier.workingCopyProperties()
+	properties[worker.TaskCachePathProperty] = identifier.Path
+	return properties
+}
+
+func (identifier TaskCacheIdentifier) workingCopyProperties() worker.VolumeProperties {
+	return worker.VolumeProperties{
+		"task-cache-pipeline-id": strconv.Itoa(identifier.PipelineID),
+		"task-cache-job":         identifier.JobName,
+		"task-cache-step":        identifier.StepName,
+	}
+}
@@ -56,14 +56,27 @@ type TaskStep struct {
 	repo              *SourceRepository
 
 	container           worker.Container
+	caches              []taskCache
 	containerSuccessTTL time.Duration
 	containerFailureTTL time.Duration
+	taskCacheTTL        time.Duration
 
 	process garden.Process
 
+	exited     bool
 	exitStatus int
 }
 
+// taskCache is a build's working copy of one of the task's caches, along with
+// the cache it was copied from, if there was one.
+type taskCache struct {
+	identifier  TaskCacheIdentifier
+	worker      worker.Worker
+	mountPath   string
+	cache       worker.Volume
+	workingCopy worker.Volume
+}
+
 func newTaskStep(
 	logger lager.Logger,
 	containerID worker.Identifier,
@@ -82,6 +95,7 @@ func newTaskStep(
 	clock clock.Clock,
 	containerSuccessTTL time.Duration,
 	containerFailureTTL time.Duration,
+	taskCacheTTL time.Duration,
 ) TaskStep {
 	return TaskStep{
 		logger:              logger,
@@ -101,6 +115,7 @@ func newTaskStep(
 		clock:               clock,
 		containerSuccessTTL: containerSuccessTTL,
 		containerFailureTTL: containerFailureTTL,
+		taskCacheTTL:        taskCacheTTL,
 	}
 }
 
@@ -172,6 +187,8 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 				return err
 			}
 
+			step.exited = true
+
 			step.registerSource(config)
 			return nil
 		}
@@ -199,19 +216,23 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		workerSpec := worker.WorkerSpec{
 			Platform: config.Platform,
 			Tags:     step.tags,
+			TeamID:   step.metadata.TeamID,
+			Limits:   config.Limits,
 		}
 
 		if config.ImageResource != nil {
 			workerSpec.ResourceType = config.ImageResource.Type
 		}
 
-		compatibleWorkers, err := step.workerPool.AllSatisfying(workerSpec, step.resourceTypes)
+		workerSpec.Inputs = step.inputSources(config.Inputs)
+
+		chosenWorker, err := step.chooseWorker(workerSpec, signals)
 		if err != nil {
 			return err
 		}
 
 		var inputsToStream []inputPair
-		step.container, inputsToStream, err = step.createContainer(compatibleWorkers, config, signals)
+		step.container, inputsToStream, err = step.createContainer(chosenWorker, config, signals)
 
 		if err != nil {
 			return err
@@ -284,6 +305,7 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		step.registerSource(config)
 
 		step.exitStatus = processStatus
+		step.exited = true
 
 		err := step.container.SetProperty(taskExitStatusPropertyName, fmt.Sprintf("%d", processStatus))
 		if err != nil {
@@ -296,8 +318,28 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 	}
 }
 
-func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config atc.TaskConfig, signals <-chan os.Signal) (worker.Container, []inputPair, error) {
-	chosenWorker, inputMounts, inputsToStream, err := step.chooseWorkerWithMostVolumes(compatibleWorkers, config.Inputs)
+// chooseWorker picks a worker satisfying the spec. If every compatible worker
+// is at capacity, it waits for one to free up, reporting this to the delegate.
+func (step *TaskStep) chooseWorker(workerSpec worker.WorkerSpec, signals <-chan os.Signal) (worker.Worker, error) {
+	var chosenWorker worker.Worker
+	err := worker.WaitForCapacity(step.logger, step.clock, signals, step.delegate, func() error {
+		var err error
+		chosenWorker, err = step.workerPool.Satisfying(workerSpec, step.resourceTypes)
+		return err
+	})
+	if err == worker.ErrWaitingInterrupted {
+		return nil, ErrInterrupted
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return chosenWorker, nil
+}
+
+func (step *TaskStep) createContainer(chosenWorker worker.Worker, config atc.TaskConfig, signals <-chan os.Signal) (worker.Container, []inputPair, error) {
+	inputMounts, inputsToStream, err := step.inputsOn(config.Inputs, chosenWorker)
 	if err != nil {
 		return nil, []inputPair{}, err
 	}
@@ -330,6 +372,11 @@ func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config
 		step.logger.Debug("created-output-volume", lager.Data{"volume-Handle": outVolume.Handle()})
 	}
 
+	cacheMounts, err := step.cacheMounts(chosenWorker, config.Caches)
+	if err != nil {
+		return nil, []inputPair{}, err
+	}
+
 	var imageSpec worker.ImageSpec
 	if step.imageArtifactName != "" {
 		source, found := step.repo.SourceFor(SourceName(step.imageArtifactName))
@@ -410,9 +457,11 @@ func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config
 	containerSpec := worker.ContainerSpec{
 		Platform:  config.Platform,
 		Tags:      step.tags,
+		TeamID:    step.metadata.TeamID,
 		Inputs:    inputMounts,
-		Outputs:   outputMounts,
+		Outputs:   append(outputMounts, cacheMounts...),
 		ImageSpec: imageSpec,
+		Limits:    config.Limits,
 	}
 
 	runContainerID := step.containerID
@@ -442,6 +491,66 @@ func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config
 	return container, inputsToStream, err
 }
 
+// cacheMounts creates a working copy of each of the task's caches on the
+// chosen worker. Caches are only kept for builds of a job; one-off builds
+// start with empty directories.
+func (step *TaskStep) cacheMounts(chosenWorker worker.Worker, caches []atc.TaskCacheConfig) ([]worker.VolumeMount, error) {
+	cacheMounts := []worker.VolumeMount{}
+
+	if step.metadata.JobName == "" {
+		return cacheMounts, nil
+	}
+
+	for _, cache := range caches {
+		identifier := TaskCacheIdentifier{
+			PipelineID: step.metadata.PipelineID,
+			JobName:    step.metadata.JobName,
+			StepName:   step.metadata.StepName,
+			Path:       cache.Path,
+			Privileged: bool(step.privileged),
+		}
+
+		cacheVolume, _, err := identifier.FindOn(step.logger, chosenWorker)
+		if err != nil {
+			return nil, err
+		}
+
+		workingCopy, err := identifier.CreateWorkingCopyOn(step.logger, chosenWorker, cacheVolume, step.taskCacheTTL)
+		if err != nil {
+			if cacheVolume != nil {
+				cacheVolume.Release(nil)
+			}
+
+			if err == worker.ErrNoVolumeManager {
+				break
+			}
+
+			return nil, err
+		}
+
+		mountPath := path.Join(step.artifactsRoot, cache.Path)
+
+		// keep heartbeating the volumes until the step is released so that
+		// they outlive the container
+		step.caches = append(step.caches, taskCache{
+			identifier:  identifier,
+			worker:      chosenWorker,
+			mountPath:   mountPath,
+			cache:       cacheVolume,
+			workingCopy: workingCopy,
+		})
+
+		cacheMounts = append(cacheMounts, worker.VolumeMount{
+			Volume:    workingCopy,
+			MountPath: mountPath,
+		})
+
+		step.logger.Debug("using-cache-volume", lager.Data{"volume-handle": workingCopy.Handle(), "path": cache.Path})
+	}
+
+	return cacheMounts, nil
+}
+
 func (step *TaskStep) registerSource(config atc.TaskConfig) {
 	volumeMounts := step.container.VolumeMounts()
 
@@ -488,15 +597,79 @@ func (step *TaskStep) Result(x interface{}) bool {
 }
 
 func (step *TaskStep) Release() {
+	// releasing the container expires its volumes, so the caches are released
+	// afterwards to keep them around for the next build
+	defer step.releaseCaches()
+
 	if step.container == nil {
 		return
 	}
 
+	step.container.Release(worker.FinalTTL(step.containerTTL()))
+}
+
+func (step *TaskStep) containerTTL() time.Duration {
 	if step.exitStatus == 0 {
-		step.container.Release(worker.FinalTTL(step.containerSuccessTTL))
-	} else {
-		step.container.Release(worker.FinalTTL(step.containerFailureTTL))
+		return step.containerSuccessTTL
 	}
+
+	return step.containerFailureTTL
+}
+
+// releaseCaches commits the contents of each working copy as the new cache
+// if the task succeeded. Working copies are discarded along with the
+// container either way. A cache that is still current is kept for another
+// taskCacheTTL from now.
+func (step *TaskStep) releaseCaches() {
+	succeeded := step.exited && step.exitStatus == 0
+
+	for _, cache := range step.caches {
+		committed := false
+		if succeeded {
+			err := step.commitCache(cache)
+			if err != nil {
+				step.logger.Error("failed-to-commit-cache", err, lager.Data{"path": cache.identifier.Path})
+			} else {
+				committed = true
+			}
+		}
+
+		cache.workingCopy.Release(worker.FinalTTL(step.containerTTL()))
+
+		if cache.cache == nil {
+			continue
+		}
+
+		if committed {
+			// builds that copied the retired cache may still be running, so
+			// it is left to expire rather than being removed
+			cache.cache.Release(nil)
+		} else {
+			cache.cache.Release(worker.FinalTTL(step.taskCacheTTL))
+		}
+	}
+}
+
+// commitCache streams what the task left in a cache's working copy out of
+// the container and into a fresh volume, which becomes the new cache.
+func (step *TaskStep) commitCache(cache taskCache) error {
+	out, err := step.container.StreamOut(garden.StreamOutSpec{
+		Path: cache.mountPath + "/",
+	})
+	if err != nil {
+		return err
+	}
+
+	defer out.Close()
+
+	committed, err := cache.identifier.Commit(step.logger, cache.worker, out, cache.cache, step.taskCacheTTL)
+	if err != nil {
+		return err
+	}
+
+	committed.Release(worker.FinalTTL(step.taskCacheTTL))
+
+	return nil
 }
 
 // StreamFile streams the given file out of the task's container.
@@ -540,33 +713,22 @@ func (step *TaskStep) VolumeOn(worker worker.Worker) (worker.Volume, bool, error
 	return nil, false, nil
 }
 
-func (step *TaskStep) chooseWorkerWithMostVolumes(compatibleWorkers []worker.Worker, inputs []atc.TaskInputConfig) (worker.Worker, []worker.VolumeMount, []inputPair, error) {
-	inputMounts := []worker.VolumeMount{}
-	inputsToStream := []inputPair{}
+func (step *TaskStep) inputSources(inputs []atc.TaskInputConfig) []worker.InputSource {
+	var sources []worker.InputSource
 
-	var chosenWorker worker.Worker
-	for _, w := range compatibleWorkers {
-		mounts, toStream, err := step.inputsOn(inputs, w)
-		if err != nil {
-			return nil, nil, nil, err
+	for _, input := range inputs {
+		inputName := input.Name
+		if sourceName, ok := step.inputMapping[inputName]; ok {
+			inputName = sourceName
 		}
 
-		if len(mounts) >= len(inputMounts) {
-			for _, mount := range inputMounts {
-				mount.Volume.Release(nil)
-			}
-
-			inputMounts = mounts
-			inputsToStream = toStream
-			chosenWorker = w
-		} else {
-			for _, mount := range mounts {
-				mount.Volume.Release(nil)
-			}
+		source, found := step.repo.SourceFor(SourceName(inputName))
+		if found {
+			sources = append(sources, source)
 		}
 	}
 
-	return chosenWorker, inputMounts, inputsToStream, nil
+	return sources
 }
 
 type inputPair struct {
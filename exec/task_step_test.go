@@ -4,7 +4,6 @@ import (
 	"archive/tar"
 	"bytes"
 	"errors"
-	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
@@ -45,13 +44,15 @@ var _ = Describe("GardenFactory", func() {
 		imageArtifactName string
 		identifier        worker.Identifier
 		workerMetadata    worker.Metadata
+		taskCacheTTL      time.Duration
 	)
 
 	BeforeEach(func() {
 		fakeWorkerClient = new(wfakes.FakeClient)
 		fakeTracker = new(rfakes.FakeTracker)
+		taskCacheTTL = 24 * time.Hour
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeTracker)
+		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, taskCacheTTL)
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -169,7 +170,7 @@ var _ = Describe("GardenFactory", func() {
 					disaster := errors.New("nope")
 
 					BeforeEach(func() {
-						fakeWorkerClient.AllSatisfyingReturns(nil, disaster)
+						fakeWorkerClient.SatisfyingReturns(nil, disaster)
 					})
 
 					It("exits with the error", func() {
@@ -177,12 +178,69 @@ var _ = Describe("GardenFactory", func() {
 					})
 				})
 
+				Context("when every compatible worker is at capacity", func() {
+					BeforeEach(func() {
+						fakeWorkerClient.SatisfyingReturns(nil, worker.ErrAllWorkersAtCapacity)
+					})
+
+					It("tells the delegate it is waiting for a worker", func() {
+						Eventually(taskDelegate.WaitingForWorkerCallCount).Should(Equal(1))
+
+						process.Signal(os.Interrupt)
+						Expect(<-process.Wait()).To(Equal(ErrInterrupted))
+					})
+
+					It("keeps checking for a worker, only telling the delegate once", func() {
+						Eventually(func() int {
+							fakeClock.Increment(worker.WorkerPollingInterval)
+							return fakeWorkerClient.SatisfyingCallCount()
+						}).Should(BeNumerically(">=", 3))
+
+						Expect(taskDelegate.WaitingForWorkerCallCount()).To(Equal(1))
+
+						process.Signal(os.Interrupt)
+						Expect(<-process.Wait()).To(Equal(ErrInterrupted))
+					})
+
+					Context("when a worker frees up", func() {
+						var fakeWorker *wfakes.FakeWorker
+
+						disaster := errors.New("nope")
+
+						BeforeEach(func() {
+							fakeWorker = new(wfakes.FakeWorker)
+							fakeWorker.CreateContainerReturns(nil, disaster)
+
+							fakeWorkerClient.SatisfyingStub = func(worker.WorkerSpec, atc.ResourceTypes) (worker.Worker, error) {
+								if fakeWorkerClient.SatisfyingCallCount() == 1 {
+									return nil, worker.ErrAllWorkersAtCapacity
+								}
+
+								return fakeWorker, nil
+							}
+						})
+
+						It("tells the delegate and uses the worker", func() {
+							Eventually(func() int {
+								fakeClock.Increment(worker.WorkerPollingInterval)
+								return fakeWorkerClient.SatisfyingCallCount()
+							}).Should(Equal(2))
+
+							Expect(<-process.Wait()).To(Equal(disaster))
+
+							Expect(taskDelegate.WaitingForWorkerCallCount()).To(Equal(1))
+							Expect(taskDelegate.WorkerFoundCallCount()).To(Equal(1))
+							Expect(fakeWorker.CreateContainerCallCount()).To(Equal(1))
+						})
+					})
+				})
+
 				Context("when a single worker can be located", func() {
 					var fakeWorker *wfakes.FakeWorker
 
 					BeforeEach(func() {
 						fakeWorker = new(wfakes.FakeWorker)
-						fakeWorkerClient.AllSatisfyingReturns([]worker.Worker{fakeWorker}, nil)
+						fakeWorkerClient.SatisfyingReturns(fakeWorker, nil)
 					})
 
 					Context("when creating the task's container works", func() {
@@ -218,8 +276,8 @@ var _ = Describe("GardenFactory", func() {
 						})
 
 						It("found the worker with the right spec", func() {
-							Expect(fakeWorkerClient.AllSatisfyingCallCount()).To(Equal(1))
-							spec, actualResourceTypes := fakeWorkerClient.AllSatisfyingArgsForCall(0)
+							Expect(fakeWorkerClient.SatisfyingCallCount()).To(Equal(1))
+							spec, actualResourceTypes := fakeWorkerClient.SatisfyingArgsForCall(0)
 							Expect(spec.Platform).To(Equal("some-platform"))
 							Expect(actualResourceTypes).To(Equal(atc.ResourceTypes{
 								{
@@ -326,6 +384,49 @@ var _ = Describe("GardenFactory", func() {
 							Expect(taskDelegate.StartedCallCount()).To(Equal(1))
 						})
 
+						Context("when the step's container belongs to a team", func() {
+							BeforeEach(func() {
+								workerMetadata.TeamID = 3
+							})
+
+							It("finds a worker the team may use", func() {
+								spec, _ := fakeWorkerClient.SatisfyingArgsForCall(0)
+								Expect(spec.TeamID).To(Equal(3))
+							})
+
+							It("creates the container for the team", func() {
+								_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+								Expect(spec.TeamID).To(Equal(3))
+							})
+						})
+
+						Context("when the config has container limits", func() {
+							var limits atc.ContainerLimits
+
+							BeforeEach(func() {
+								cpu := uint64(512)
+								memory := uint64(1024)
+
+								limits = atc.ContainerLimits{
+									CPU:    &cpu,
+									Memory: &memory,
+								}
+
+								fetchedConfig.Limits = limits
+								configSource.FetchConfigReturns(fetchedConfig, nil)
+							})
+
+							It("finds a worker with room for the limits", func() {
+								spec, _ := fakeWorkerClient.SatisfyingArgsForCall(0)
+								Expect(spec.Limits).To(Equal(limits))
+							})
+
+							It("creates the container with the limits", func() {
+								_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+								Expect(spec.Limits).To(Equal(limits))
+							})
+						})
+
 						Context("when privileged", func() {
 							BeforeEach(func() {
 								privileged = true
@@ -440,6 +541,11 @@ var _ = Describe("GardenFactory", func() {
 									Eventually(process.Wait()).Should(Receive(BeNil()))
 								})
 
+								It("places the container near the inputs' volumes", func() {
+									spec, _ := fakeWorkerClient.SatisfyingArgsForCall(0)
+									Expect(spec.Inputs).To(ConsistOf(inputSource, otherInputSource))
+								})
+
 								Context("when the inputs have volumes on the chosen worker", func() {
 									var inputVolume *wfakes.FakeVolume
 									var otherInputVolume *wfakes.FakeVolume
@@ -623,6 +729,269 @@ var _ = Describe("GardenFactory", func() {
 							})
 						})
 
+						Context("when the configuration specifies caches", func() {
+							var existingCache *wfakes.FakeVolume
+							var existingCacheCopy *wfakes.FakeVolume
+							var newCacheCopy *wfakes.FakeVolume
+							var committedCaches map[string]*wfakes.FakeVolume
+							var committing bool
+
+							BeforeEach(func() {
+								configSource.FetchConfigReturns(atc.TaskConfig{
+									Platform: "some-platform",
+									Image:    "some-image",
+									Run: atc.TaskRunConfig{
+										Path: "ls",
+									},
+									Caches: []atc.TaskCacheConfig{
+										{Path: "some-cache"},
+										{Path: "some-other-cache"},
+									},
+								}, nil)
+
+								existingCache = new(wfakes.FakeVolume)
+								existingCache.HandleReturns("existing-cache")
+
+								existingCacheCopy = new(wfakes.FakeVolume)
+								existingCacheCopy.HandleReturns("existing-cache-copy")
+
+								newCacheCopy = new(wfakes.FakeVolume)
+								newCacheCopy.HandleReturns("new-cache-copy")
+
+								committedCaches = map[string]*wfakes.FakeVolume{}
+								committing = false
+
+								fakeWorker.ListVolumesStub = func(_ lager.Logger, properties worker.VolumeProperties) ([]worker.Volume, error) {
+									if properties["task-cache-path"] == "some-cache" {
+										return []worker.Volume{existingCache}, nil
+									}
+
+									return []worker.Volume{}, nil
+								}
+
+								fakeWorker.CreateVolumeStub = func(_ lager.Logger, spec worker.VolumeSpec) (worker.Volume, error) {
+									if _, ok := spec.Strategy.(worker.TaskCacheCOWStrategy); ok {
+										return existingCacheCopy, nil
+									}
+
+									if committing {
+										committedCache := new(wfakes.FakeVolume)
+										committedCaches[spec.Strategy.(worker.TaskCacheStrategy).Path] = committedCache
+										return committedCache, nil
+									}
+
+									return newCacheCopy, nil
+								}
+
+								fakeContainer.StreamOutStub = func(spec garden.StreamOutSpec) (io.ReadCloser, error) {
+									return ioutil.NopCloser(bytes.NewBufferString("contents of " + spec.Path)), nil
+								}
+							})
+
+							It("looks up the caches for the job's step", func() {
+								Expect(fakeWorker.ListVolumesCallCount()).To(Equal(2))
+
+								_, properties := fakeWorker.ListVolumesArgsForCall(0)
+								Expect(properties).To(Equal(worker.VolumeProperties{
+									"task-cache-pipeline-id": "0",
+									"task-cache-job":         "some-job",
+									"task-cache-step":        "some-step",
+									"task-cache-path":        "some-cache",
+								}))
+							})
+
+							It("creates a copy-on-write working copy of the existing caches", func() {
+								Expect(fakeWorker.CreateVolumeCallCount()).To(Equal(2))
+
+								_, spec := fakeWorker.CreateVolumeArgsForCall(0)
+								Expect(spec.Strategy).To(Equal(worker.TaskCacheCOWStrategy{
+									Parent:   existingCache,
+									JobName:  "some-job",
+									StepName: "some-step",
+									Path:     "some-cache",
+								}))
+								Expect(spec.TTL).To(Equal(taskCacheTTL))
+							})
+
+							It("creates an empty working copy of the caches that do not exist yet", func() {
+								_, spec := fakeWorker.CreateVolumeArgsForCall(1)
+								Expect(spec.Strategy).To(Equal(worker.TaskCacheStrategy{
+									JobName:  "some-job",
+									StepName: "some-step",
+									Path:     "some-other-cache",
+								}))
+								Expect(spec.TTL).To(Equal(taskCacheTTL))
+							})
+
+							It("does not let other builds find the working copies as caches", func() {
+								_, spec := fakeWorker.CreateVolumeArgsForCall(0)
+								Expect(spec.Properties).To(Equal(worker.VolumeProperties{
+									"task-cache-pipeline-id": "0",
+									"task-cache-job":         "some-job",
+									"task-cache-step":        "some-step",
+								}))
+							})
+
+							It("mounts the working copies into the container", func() {
+								_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+								Expect(spec.Outputs).To(Equal([]worker.VolumeMount{
+									{
+										Volume:    existingCacheCopy,
+										MountPath: "/tmp/build/a1f5c0c1/some-cache",
+									},
+									{
+										Volume:    newCacheCopy,
+										MountPath: "/tmp/build/a1f5c0c1/some-other-cache",
+									},
+								}))
+							})
+
+							Context("when the task succeeds", func() {
+								BeforeEach(func() {
+									fakeProcess.WaitReturns(0, nil)
+								})
+
+								JustBeforeEach(func() {
+									Eventually(process.Wait()).Should(Receive())
+
+									Expect(existingCacheCopy.ReleaseCallCount()).To(BeZero())
+									Expect(newCacheCopy.ReleaseCallCount()).To(BeZero())
+
+									committing = true
+									step.Release()
+								})
+
+								It("copies the contents of the working copies into fresh caches", func() {
+									Expect(fakeContainer.StreamOutCallCount()).To(Equal(2))
+									Expect(fakeContainer.StreamOutArgsForCall(0)).To(Equal(garden.StreamOutSpec{Path: "/tmp/build/a1f5c0c1/some-cache/"}))
+									Expect(fakeContainer.StreamOutArgsForCall(1)).To(Equal(garden.StreamOutSpec{Path: "/tmp/build/a1f5c0c1/some-other-cache/"}))
+
+									Expect(fakeWorker.CreateVolumeCallCount()).To(Equal(4))
+
+									_, spec := fakeWorker.CreateVolumeArgsForCall(2)
+									Expect(spec.Strategy).To(Equal(worker.TaskCacheStrategy{
+										JobName:  "some-job",
+										StepName: "some-step",
+										Path:     "some-cache",
+									}))
+									Expect(spec.TTL).To(Equal(taskCacheTTL))
+
+									Expect(committedCaches).To(HaveLen(2))
+
+									for path, committedCache := range committedCaches {
+										Expect(committedCache.StreamInCallCount()).To(Equal(1))
+										dest, contents := committedCache.StreamInArgsForCall(0)
+										Expect(dest).To(Equal("."))
+										Expect(ioutil.ReadAll(contents)).To(Equal([]byte("contents of /tmp/build/a1f5c0c1/" + path + "/")))
+									}
+								})
+
+								It("commits the fresh volumes as the new caches", func() {
+									for path, committedCache := range committedCaches {
+										Expect(committedCache.SetPropertyCallCount()).To(Equal(1))
+										key, value := committedCache.SetPropertyArgsForCall(0)
+										Expect(key).To(Equal("task-cache-path"))
+										Expect(value).To(Equal(path))
+									}
+
+									Expect(existingCacheCopy.SetPropertyCallCount()).To(BeZero())
+									Expect(newCacheCopy.SetPropertyCallCount()).To(BeZero())
+								})
+
+								It("keeps the new caches for the cache TTL", func() {
+									for _, committedCache := range committedCaches {
+										Expect(committedCache.ReleaseCallCount()).To(Equal(1))
+										Expect(committedCache.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(taskCacheTTL)))
+									}
+								})
+
+								It("discards the working copies along with the container", func() {
+									Expect(existingCacheCopy.ReleaseCallCount()).To(Equal(1))
+									Expect(existingCacheCopy.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(successTTL)))
+
+									Expect(newCacheCopy.ReleaseCallCount()).To(Equal(1))
+									Expect(newCacheCopy.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(successTTL)))
+								})
+
+								Context("when streaming the contents into the fresh cache fails", func() {
+									BeforeEach(func() {
+										fakeWorker.CreateVolumeStub = func(_ lager.Logger, spec worker.VolumeSpec) (worker.Volume, error) {
+											if _, ok := spec.Strategy.(worker.TaskCacheCOWStrategy); ok {
+												return existingCacheCopy, nil
+											}
+
+											if committing {
+												committedCache := new(wfakes.FakeVolume)
+												committedCache.StreamInReturns(errors.New("nope"))
+												committedCaches[spec.Strategy.(worker.TaskCacheStrategy).Path] = committedCache
+												return committedCache, nil
+											}
+
+											return newCacheCopy, nil
+										}
+									})
+
+									It("does not commit it or retire the existing cache", func() {
+										for _, committedCache := range committedCaches {
+											Expect(committedCache.SetPropertyCallCount()).To(BeZero())
+											Expect(committedCache.ReleaseArgsForCall(0)).To(BeNil())
+										}
+
+										Expect(existingCache.SetPropertyCallCount()).To(BeZero())
+										Expect(existingCache.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(taskCacheTTL)))
+									})
+								})
+
+								It("retires the cache the working copy was made from and lets it expire", func() {
+									Expect(existingCache.SetPropertyCallCount()).To(Equal(1))
+									key, value := existingCache.SetPropertyArgsForCall(0)
+									Expect(key).To(Equal("task-cache-path"))
+									Expect(value).To(BeEmpty())
+
+									Expect(existingCache.ReleaseCallCount()).To(Equal(1))
+									Expect(existingCache.ReleaseArgsForCall(0)).To(BeNil())
+								})
+							})
+
+							Context("when the task fails", func() {
+								BeforeEach(func() {
+									fakeProcess.WaitReturns(1, nil)
+								})
+
+								JustBeforeEach(func() {
+									Eventually(process.Wait()).Should(Receive())
+
+									step.Release()
+								})
+
+								It("discards the working copies along with the container", func() {
+									Expect(existingCacheCopy.SetPropertyCallCount()).To(BeZero())
+									Expect(existingCacheCopy.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(failureTTL)))
+
+									Expect(newCacheCopy.SetPropertyCallCount()).To(BeZero())
+									Expect(newCacheCopy.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(failureTTL)))
+								})
+
+								It("keeps the existing cache for another cache TTL", func() {
+									Expect(existingCache.SetPropertyCallCount()).To(BeZero())
+
+									Expect(existingCache.ReleaseCallCount()).To(Equal(1))
+									Expect(existingCache.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(taskCacheTTL)))
+								})
+							})
+
+							Context("when the build is a one-off build", func() {
+								BeforeEach(func() {
+									workerMetadata.JobName = ""
+								})
+
+								It("does not use any caches", func() {
+									Expect(fakeWorker.ListVolumesCallCount()).To(BeZero())
+									Expect(fakeWorker.CreateVolumeCallCount()).To(BeZero())
+								})
+							})
+						})
+
 						Context("when the configuration specifies paths for outputs", func() {
 							BeforeEach(func() {
 								configSource.FetchConfigReturns(atc.TaskConfig{
@@ -1915,119 +2284,6 @@ var _ = Describe("GardenFactory", func() {
 						})
 					})
 				})
-
-				Context("when more than one worker can be located", func() {
-					var fakeWorker *wfakes.FakeWorker
-					var fakeWorker2 *wfakes.FakeWorker
-					var fakeWorker3 *wfakes.FakeWorker
-
-					BeforeEach(func() {
-						fakeWorker = new(wfakes.FakeWorker)
-						fakeWorker2 = new(wfakes.FakeWorker)
-						fakeWorker3 = new(wfakes.FakeWorker)
-
-						fakeWorkerClient.AllSatisfyingReturns([]worker.Worker{fakeWorker, fakeWorker2, fakeWorker3}, nil)
-					})
-
-					Context("when the configuration has inputs", func() {
-						var inputSource *execfakes.FakeArtifactSource
-						var otherInputSource *execfakes.FakeArtifactSource
-
-						BeforeEach(func() {
-							inputSource = new(execfakes.FakeArtifactSource)
-							otherInputSource = new(execfakes.FakeArtifactSource)
-
-							configSource.FetchConfigReturns(atc.TaskConfig{
-								Platform: "some-platform",
-								Image:    "some-image",
-								Params:   map[string]string{"SOME": "params"},
-								Run: atc.TaskRunConfig{
-									Path: "ls",
-									Args: []string{"some", "args"},
-								},
-								Inputs: []atc.TaskInputConfig{
-									{Name: "some-input"},
-									{Name: "some-other-input"},
-								},
-							}, nil)
-						})
-
-						Context("when all inputs are present in the in source repository", func() {
-							BeforeEach(func() {
-								repo.RegisterSource("some-input", inputSource)
-								repo.RegisterSource("some-other-input", otherInputSource)
-							})
-
-							Context("and some workers have more matching input volumes than others", func() {
-								var rootVolume *wfakes.FakeVolume
-								var inputVolume *wfakes.FakeVolume
-								var inputVolume2 *wfakes.FakeVolume
-								var inputVolume3 *wfakes.FakeVolume
-								var otherInputVolume *wfakes.FakeVolume
-
-								BeforeEach(func() {
-									rootVolume = new(wfakes.FakeVolume)
-									rootVolume.HandleReturns("root-volume")
-
-									inputVolume = new(wfakes.FakeVolume)
-									inputVolume.HandleReturns("input-volume")
-
-									inputVolume2 = new(wfakes.FakeVolume)
-									inputVolume2.HandleReturns("input-volume")
-
-									inputVolume3 = new(wfakes.FakeVolume)
-									inputVolume3.HandleReturns("input-volume")
-
-									otherInputVolume = new(wfakes.FakeVolume)
-									otherInputVolume.HandleReturns("other-input-volume")
-
-									fakeWorker2.CreateVolumeReturns(rootVolume, nil)
-
-									inputSource.VolumeOnStub = func(w worker.Worker) (worker.Volume, bool, error) {
-										if w == fakeWorker {
-											return inputVolume, true, nil
-										} else if w == fakeWorker2 {
-											return inputVolume2, true, nil
-										} else if w == fakeWorker3 {
-											return inputVolume3, true, nil
-										} else {
-											return nil, false, fmt.Errorf("unexpected worker: %#v\n", w)
-										}
-									}
-
-									otherInputSource.VolumeOnStub = func(w worker.Worker) (worker.Volume, bool, error) {
-										if w == fakeWorker {
-											return nil, false, nil
-										} else if w == fakeWorker2 {
-											return otherInputVolume, true, nil
-										} else if w == fakeWorker3 {
-											return nil, false, nil
-										} else {
-											return nil, false, fmt.Errorf("unexpected worker: %#v\n", w)
-										}
-									}
-
-									fakeWorker.CreateContainerReturns(nil, errors.New("fall out of method here"))
-									fakeWorker2.CreateContainerReturns(nil, errors.New("fall out of method here"))
-								})
-
-								It("picks the worker that has the most", func() {
-									Expect(fakeWorker.CreateContainerCallCount()).To(Equal(0))
-									Expect(fakeWorker2.CreateContainerCallCount()).To(Equal(1))
-									Expect(fakeWorker3.CreateContainerCallCount()).To(Equal(0))
-								})
-
-								It("releases the volumes on the unused workers", func() {
-									Expect(inputVolume.ReleaseCallCount()).To(Equal(1))
-									Expect(inputVolume3.ReleaseCallCount()).To(Equal(1))
-
-									Expect(inputVolume2.ReleaseCallCount()).To(Equal(1))
-									Expect(otherInputVolume.ReleaseCallCount()).To(Equal(1))
-								})
-							})
-						})
-					})
-				})
 			})
 
 			Context("when getting the config fails", func() {
@@ -0,0 +1,259 @@
+package atc
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// A MatrixConfig expands a job into one job for every combination of its
+// parameters' values. Each parameter's value is substituted for
+// ((matrix.name)) anywhere in the job's config, including its name.
+type MatrixConfig []MatrixParam
+
+type MatrixParam struct {
+	// the name of the parameter, e.g. go_version
+	Name string `yaml:"name" json:"name" mapstructure:"name"`
+
+	// the values to expand the job for, e.g. 1.6, 1.7
+	Values []string `yaml:"values" json:"values" mapstructure:"values"`
+}
+
+var matrixPlaceholderRegexp = regexp.MustCompile(`\(\(matrix\.([-\w]+)\)\)`)
+
+// ExpandMatrices replaces every job that has a matrix with the jobs generated
+// from it.
+//
+// Groups and passed constraints may refer to a matrix job by its unexpanded
+// name, e.g. unit-((matrix.go_version)), in which case they are replaced with
+// every job generated from it. Generated jobs may also be referred to by
+// name.
+func (config Config) ExpandMatrices() (Config, error) {
+	expandedNames := map[string][]string{}
+
+	jobs := JobConfigs{}
+
+	for _, job := range config.Jobs {
+		if len(job.Matrix) == 0 {
+			jobs = append(jobs, job)
+			continue
+		}
+
+		expanded, err := expandJob(job)
+		if err != nil {
+			return Config{}, fmt.Errorf("failed to expand matrix of job '%s': %s", job.Name, err)
+		}
+
+		for _, expandedJob := range expanded {
+			expandedNames[job.Name] = append(expandedNames[job.Name], expandedJob.Name)
+		}
+
+		jobs = append(jobs, expanded...)
+	}
+
+	if len(expandedNames) == 0 {
+		return config, nil
+	}
+
+	for i, job := range jobs {
+		jobs[i].Plan = expandPassed(job.Plan, expandedNames)
+	}
+
+	if config.Groups != nil {
+		groups := make(GroupConfigs, len(config.Groups))
+		for i, group := range config.Groups {
+			groups[i] = group
+			groups[i].Jobs = expandNames(group.Jobs, expandedNames)
+		}
+
+		config.Groups = groups
+	}
+
+	config.Jobs = jobs
+
+	return config, nil
+}
+
+func expandJob(job JobConfig) (JobConfigs, error) {
+	combinations := []map[string]string{{}}
+
+	for _, param := range job.Matrix {
+		if param.Name == "" {
+			return nil, fmt.Errorf("matrix has a parameter with no name")
+		}
+
+		if len(param.Values) == 0 {
+			return nil, fmt.Errorf("matrix parameter '%s' has no values", param.Name)
+		}
+
+		if _, found := combinations[0][param.Name]; found {
+			return nil, fmt.Errorf("matrix parameter '%s' is specified more than once", param.Name)
+		}
+
+		expanded := []map[string]string{}
+
+		for _, combination := range combinations {
+			for _, value := range param.Values {
+				next := map[string]string{}
+				for k, v := range combination {
+					next[k] = v
+				}
+
+				next[param.Name] = value
+
+				expanded = append(expanded, next)
+			}
+		}
+
+		combinations = expanded
+	}
+
+	job.Matrix = nil
+
+	payload, err := json.Marshal(job)
+	if err != nil {
+		return nil, err
+	}
+
+	var template interface{}
+	err = json.Unmarshal(payload, &template)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := JobConfigs{}
+
+	for _, combination := range combinations {
+		substituted, err := substituteMatrixParams(template, combination)
+		if err != nil {
+			return nil, err
+		}
+
+		payload, err := json.Marshal(substituted)
+		if err != nil {
+			return nil, err
+		}
+
+		var expandedJob JobConfig
+		err = json.Unmarshal(payload, &expandedJob)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, expandedJob)
+	}
+
+	return jobs, nil
+}
+
+func substituteMatrixParams(value interface{}, params map[string]string) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		var err error
+
+		substituted := matrixPlaceholderRegexp.ReplaceAllStringFunc(v, func(placeholder string) string {
+			name := matrixPlaceholderRegexp.FindStringSubmatch(placeholder)[1]
+
+			value, found := params[name]
+			if !found {
+				err = fmt.Errorf("unknown matrix parameter '%s'", name)
+			}
+
+			return value
+		})
+
+		return substituted, err
+
+	case map[string]interface{}:
+		substituted := map[string]interface{}{}
+
+		for k, sub := range v {
+			s, err := substituteMatrixParams(sub, params)
+			if err != nil {
+				return nil, err
+			}
+
+			substituted[k] = s
+		}
+
+		return substituted, nil
+
+	case []interface{}:
+		substituted := make([]interface{}, len(v))
+
+		for i, sub := range v {
+			s, err := substituteMatrixParams(sub, params)
+			if err != nil {
+				return nil, err
+			}
+
+			substituted[i] = s
+		}
+
+		return substituted, nil
+
+	default:
+		return value, nil
+	}
+}
+
+func expandPassed(plan PlanSequence, expandedNames map[string][]string) PlanSequence {
+	if plan == nil {
+		return nil
+	}
+
+	expanded := make(PlanSequence, len(plan))
+
+	for i, step := range plan {
+		expanded[i] = expandStepPassed(step, expandedNames)
+	}
+
+	return expanded
+}
+
+func expandStepPassed(step PlanConfig, expandedNames map[string][]string) PlanConfig {
+	step.Passed = expandNames(step.Passed, expandedNames)
+
+	if step.Do != nil {
+		do := expandPassed(*step.Do, expandedNames)
+		step.Do = &do
+	}
+
+	if step.Aggregate != nil {
+		aggregate := expandPassed(*step.Aggregate, expandedNames)
+		step.Aggregate = &aggregate
+	}
+
+	if step.InParallel != nil {
+		inParallel := *step.InParallel
+		inParallel.Steps = expandPassed(inParallel.Steps, expandedNames)
+		step.InParallel = &inParallel
+	}
+
+	for _, hook := range []**PlanConfig{&step.Try, &step.Success, &step.Failure, &step.Ensure} {
+		if *hook != nil {
+			expanded := expandStepPassed(**hook, expandedNames)
+			*hook = &expanded
+		}
+	}
+
+	return step
+}
+
+func expandNames(names []string, expandedNames map[string][]string) []string {
+	if names == nil {
+		return nil
+	}
+
+	expanded := []string{}
+
+	for _, name := range names {
+		if generated, found := expandedNames[name]; found {
+			expanded = append(expanded, generated...)
+		} else {
+			expanded = append(expanded, name)
+		}
+	}
+
+	return expanded
+}
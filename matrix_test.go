@@ -0,0 +1,143 @@
+package atc_test
+
+import (
+	. "github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Matrix", func() {
+	Describe("ExpandMatrices", func() {
+		var (
+			config Config
+
+			expanded  Config
+			expandErr error
+		)
+
+		BeforeEach(func() {
+			config = Config{
+				Groups: GroupConfigs{
+					{
+						Name: "some-group",
+						Jobs: []string{"unit-((matrix.go))-((matrix.os))", "ship"},
+					},
+				},
+				Jobs: JobConfigs{
+					{
+						Name: "unit-((matrix.go))-((matrix.os))",
+						Plan: PlanSequence{
+							{Get: "some-resource"},
+							{
+								Task: "unit",
+								TaskConfig: &TaskConfig{
+									Platform: "((matrix.os))",
+									Params:   map[string]string{"GO_VERSION": "((matrix.go))"},
+								},
+							},
+						},
+						Matrix: MatrixConfig{
+							{Name: "go", Values: []string{"1.6", "1.7"}},
+							{Name: "os", Values: []string{"linux", "darwin"}},
+						},
+					},
+					{
+						Name: "ship",
+						Plan: PlanSequence{
+							{
+								Get:    "some-resource",
+								Passed: []string{"unit-((matrix.go))-((matrix.os))"},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			expanded, expandErr = config.ExpandMatrices()
+		})
+
+		It("generates a job for every combination of values", func() {
+			Expect(expandErr).NotTo(HaveOccurred())
+
+			names := []string{}
+			for _, job := range expanded.Jobs {
+				names = append(names, job.Name)
+			}
+
+			Expect(names).To(Equal([]string{
+				"unit-1.6-linux",
+				"unit-1.6-darwin",
+				"unit-1.7-linux",
+				"unit-1.7-darwin",
+				"ship",
+			}))
+		})
+
+		It("substitutes the values throughout the generated jobs", func() {
+			Expect(expandErr).NotTo(HaveOccurred())
+
+			job := expanded.Jobs[1]
+			Expect(job.Matrix).To(BeEmpty())
+			Expect(job.Plan[1].TaskConfig.Platform).To(Equal("darwin"))
+			Expect(job.Plan[1].TaskConfig.Params).To(Equal(map[string]string{"GO_VERSION": "1.6"}))
+		})
+
+		It("expands references to the unexpanded name in groups and passed constraints", func() {
+			Expect(expandErr).NotTo(HaveOccurred())
+
+			generated := []string{"unit-1.6-linux", "unit-1.6-darwin", "unit-1.7-linux", "unit-1.7-darwin"}
+
+			Expect(expanded.Groups[0].Jobs).To(Equal(append(generated, "ship")))
+			Expect(expanded.Jobs[4].Plan[0].Passed).To(Equal(generated))
+		})
+
+		It("does not modify the original config", func() {
+			Expect(config.Jobs).To(HaveLen(2))
+			Expect(config.Groups[0].Jobs[0]).To(Equal("unit-((matrix.go))-((matrix.os))"))
+		})
+
+		Context("when there are no matrices", func() {
+			BeforeEach(func() {
+				config.Jobs = config.Jobs[1:]
+			})
+
+			It("returns the config as-is", func() {
+				Expect(expandErr).NotTo(HaveOccurred())
+				Expect(expanded).To(Equal(config))
+			})
+		})
+
+		Context("when a job refers to an unknown parameter", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Plan[1].TaskConfig.Platform = "((matrix.arch))"
+			})
+
+			It("returns an error", func() {
+				Expect(expandErr).To(MatchError("failed to expand matrix of job 'unit-((matrix.go))-((matrix.os))': unknown matrix parameter 'arch'"))
+			})
+		})
+
+		Context("when a parameter has no values", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Matrix[1].Values = nil
+			})
+
+			It("returns an error", func() {
+				Expect(expandErr).To(MatchError(ContainSubstring("matrix parameter 'os' has no values")))
+			})
+		})
+
+		Context("when a parameter is specified more than once", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Matrix[1].Name = "go"
+			})
+
+			It("returns an error", func() {
+				Expect(expandErr).To(MatchError(ContainSubstring("matrix parameter 'go' is specified more than once")))
+			})
+		})
+	})
+})
@@ -1,8 +1,40 @@
 package atc
 
+import "encoding/json"
+
+// InstanceVarsQueryParam is the query parameter used to address a single
+// instance of a pipeline, as JSON-encoded instance vars.
+const InstanceVarsQueryParam = "vars"
+
 type Pipeline struct {
-	Name   string       `json:"name"`
-	URL    string       `json:"url"`
-	Paused bool         `json:"paused"`
-	Groups GroupConfigs `json:"groups,omitempty"`
+	Name         string       `json:"name"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+	URL          string       `json:"url,omitempty"`
+	Paused       bool         `json:"paused"`
+	Archived     bool         `json:"archived,omitempty"`
+	Groups       GroupConfigs `json:"groups,omitempty"`
+}
+
+// InstanceVars distinguish the instances of a pipeline that share a name.
+// Together with the pipeline's name they form its identity.
+type InstanceVars map[string]interface{}
+
+// ParseInstanceVars decodes instance vars from their JSON encoding. An empty
+// string decodes to nil, i.e. a pipeline that is not an instance.
+func ParseInstanceVars(payload string) (InstanceVars, error) {
+	if payload == "" {
+		return nil, nil
+	}
+
+	var vars InstanceVars
+	err := json.Unmarshal([]byte(payload), &vars)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vars) == 0 {
+		return nil, nil
+	}
+
+	return vars, nil
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 )
 
@@ -15,7 +16,21 @@ type PipelineHandlerFactory struct {
 func (pdbh *PipelineHandlerFactory) HandlerFor(pipelineScopedHandler func(db.PipelineDB) http.Handler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		pipelineName := r.FormValue(":pipeline_name")
-		pipelineDB, err := pdbh.pipelineDBFactory.BuildWithTeamNameAndName(atc.DefaultTeamName, pipelineName)
+		teamName := auth.GetRequestedTeamName(r)
+
+		instanceVars, err := atc.ParseInstanceVars(r.URL.Query().Get(atc.InstanceVarsQueryParam))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var pipelineDB db.PipelineDB
+		if instanceVars != nil {
+			pipelineDB, err = pdbh.pipelineDBFactory.BuildWithTeamNameAndInstance(teamName, pipelineName, instanceVars)
+		} else {
+			pipelineDB, err = pdbh.pipelineDBFactory.BuildWithTeamNameAndName(teamName, pipelineName)
+		}
+
 		if err != nil {
 			if err == sql.ErrNoRows {
 				w.WriteHeader(http.StatusNotFound)
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/radar"
@@ -21,10 +22,11 @@ type RadarSchedulerFactory interface {
 }
 
 type radarSchedulerFactory struct {
-	tracker  resource.Tracker
-	interval time.Duration
-	engine   engine.Engine
-	db       db.DB
+	tracker           resource.Tracker
+	interval          time.Duration
+	engine            engine.Engine
+	db                db.DB
+	credentialManager creds.CredentialManager
 }
 
 func NewRadarSchedulerFactory(
@@ -32,17 +34,19 @@ func NewRadarSchedulerFactory(
 	interval time.Duration,
 	engine engine.Engine,
 	db db.DB,
+	credentialManager creds.CredentialManager,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
-		tracker:  tracker,
-		interval: interval,
-		engine:   engine,
-		db:       db,
+		tracker:           tracker,
+		interval:          interval,
+		engine:            engine,
+		db:                db,
+		credentialManager: credentialManager,
 	}
 }
 
 func (rsf *radarSchedulerFactory) BuildScanRunnerFactory(pipelineDB db.PipelineDB, externalURL string) radar.ScanRunnerFactory {
-	return radar.NewScanRunnerFactory(rsf.tracker, rsf.interval, pipelineDB, clock.NewClock(), externalURL)
+	return radar.NewScanRunnerFactory(rsf.tracker, rsf.interval, pipelineDB, clock.NewClock(), externalURL, rsf.credentialManager)
 }
 
 func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, externalURL string) scheduler.BuildScheduler {
@@ -52,12 +56,18 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, exter
 		rsf.interval,
 		pipelineDB,
 		externalURL,
+		rsf.credentialManager,
 	)
 	return &scheduler.Scheduler{
 		PipelineDB: pipelineDB,
 		BuildsDB:   rsf.db,
 		Factory: factory.NewBuildFactory(
 			pipelineDB.GetPipelineID(),
+			creds.Variables{
+				Manager:      rsf.credentialManager,
+				TeamName:     pipelineDB.GetTeamName(),
+				PipelineName: pipelineDB.GetPipelineName(),
+			},
 			atc.NewPlanFactory(time.Now().Unix()),
 		),
 		Engine:  rsf.engine,
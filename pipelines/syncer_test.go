@@ -117,6 +117,40 @@ var _ = Describe("Pipelines Syncer", func() {
 		})
 	})
 
+	Context("when a pipeline is archived", func() {
+		It("stops the process and does not start it again", func() {
+			Expect(fakeRunner.RunCallCount()).To(Equal(1))
+
+			syncherDB.GetAllPipelinesReturns([]db.SavedPipeline{
+				{
+					ID:       1,
+					Archived: true,
+					Pipeline: db.Pipeline{
+						Name: "pipeline",
+					},
+				},
+				{
+					ID: 2,
+					Pipeline: db.Pipeline{
+						Name: "other-pipeline",
+					},
+				},
+			}, nil)
+
+			syncer.Sync()
+
+			signals, _ := fakeRunner.RunArgsForCall(0)
+			Eventually(signals).Should(Receive(Equal(os.Interrupt)))
+
+			Expect(syncherDB.ResetBuildPreparationsWithPipelinePausedCallCount()).To(Equal(1))
+			Expect(syncherDB.ResetBuildPreparationsWithPipelinePausedArgsForCall(0)).To(Equal(1))
+
+			syncer.Sync()
+
+			Expect(fakeRunner.RunCallCount()).To(Equal(1))
+		})
+	})
+
 	Context("when a pipeline is deleted", func() {
 		It("stops the process", func() {
 			Expect(fakeRunner.RunCallCount()).To(Equal(1))
@@ -5,6 +5,7 @@ type Plan struct {
 	Attempts []int  `json:"attempts,omitempty"`
 
 	Aggregate    *AggregatePlan    `json:"aggregate,omitempty"`
+	InParallel   *InParallelPlan   `json:"in_parallel,omitempty"`
 	Do           *DoPlan           `json:"do,omitempty"`
 	Get          *GetPlan          `json:"get,omitempty"`
 	Put          *PutPlan          `json:"put,omitempty"`
@@ -16,6 +17,12 @@ type Plan struct {
 	DependentGet *DependentGetPlan `json:"dependent_get,omitempty"`
 	Timeout      *TimeoutPlan      `json:"timeout,omitempty"`
 	Retry        *RetryPlan        `json:"retry,omitempty"`
+	Conditional  *ConditionalPlan  `json:"conditional,omitempty"`
+
+	// Secrets are the credential values resolved into the plan, which are
+	// redacted from the build's output. Only the root plan carries them and
+	// they are never part of the public plan.
+	Secrets []string `json:"secrets,omitempty"`
 }
 
 type PlanID string
@@ -70,8 +77,19 @@ type TryPlan struct {
 	Step Plan `json:"step"`
 }
 
+type ConditionalPlan struct {
+	Condition ConditionConfig `json:"condition"`
+	Step      Plan            `json:"step"`
+}
+
 type AggregatePlan []Plan
 
+type InParallelPlan struct {
+	Steps    []Plan `json:"steps"`
+	Limit    int    `json:"limit,omitempty"`
+	FailFast bool   `json:"fail_fast,omitempty"`
+}
+
 type DoPlan []Plan
 
 type GetPlan struct {
@@ -244,6 +244,51 @@ var _ = Describe("Plan", func() {
 						},
 					},
 				},
+
+				atc.Plan{
+					ID: "26",
+					Conditional: &atc.ConditionalPlan{
+						Condition: atc.ConditionConfig{
+							Job: "some-job",
+							Version: &atc.VersionCondition{
+								Get:   "some-input",
+								Key:   "branch",
+								Value: "master",
+							},
+							File: "some-input/flag",
+						},
+						Step: atc.Plan{
+							ID: "27",
+							Task: &atc.TaskPlan{
+								Name:       "name",
+								ConfigPath: "some/config/path.yml",
+								Config: &atc.TaskConfig{
+									Params: map[string]string{"some": "secret"},
+								},
+							},
+						},
+					},
+				},
+
+				atc.Plan{
+					ID: "28",
+					InParallel: &atc.InParallelPlan{
+						Steps: []atc.Plan{
+							atc.Plan{
+								ID: "29",
+								Task: &atc.TaskPlan{
+									Name:       "name",
+									ConfigPath: "some/config/path.yml",
+									Config: &atc.TaskConfig{
+										Params: map[string]string{"some": "secret"},
+									},
+								},
+							},
+						},
+						Limit:    2,
+						FailFast: true,
+					},
+				},
 			},
 		}
 
@@ -417,6 +462,43 @@ var _ = Describe("Plan", func() {
           }
         }
       ]
+    },
+    {
+      "id": "26",
+      "conditional": {
+        "condition": {
+          "job": "some-job",
+          "version": {
+            "get": "some-input",
+            "key": "branch",
+            "value": "master"
+          },
+          "file": "some-input/flag"
+        },
+        "step": {
+          "id": "27",
+          "task": {
+            "name": "name",
+            "privileged": false
+          }
+        }
+      }
+    },
+    {
+      "id": "28",
+      "in_parallel": {
+        "steps": [
+          {
+            "id": "29",
+            "task": {
+              "name": "name",
+              "privileged": false
+            }
+          }
+        ],
+        "limit": 2,
+        "fail_fast": true
+      }
     }
   ]
 }
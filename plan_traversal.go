@@ -29,6 +29,14 @@ func (pt *planTraversal) Traverse(plan *Plan) error {
 			}
 		}
 
+	case plan.InParallel != nil:
+		for i := range plan.InParallel.Steps {
+			err = pt.Traverse(&plan.InParallel.Steps[i])
+			if err != nil {
+				return err
+			}
+		}
+
 	case plan.Do != nil:
 		for i := range *plan.Do {
 			err = pt.Traverse(&(*plan.Do)[i])
@@ -43,6 +51,9 @@ func (pt *planTraversal) Traverse(plan *Plan) error {
 	case plan.Try != nil:
 		return pt.Traverse(&plan.Try.Step)
 
+	case plan.Conditional != nil:
+		return pt.Traverse(&plan.Conditional.Step)
+
 	case plan.OnSuccess != nil:
 		err = pt.Traverse(&plan.OnSuccess.Step)
 		if err != nil {
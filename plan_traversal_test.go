@@ -178,13 +178,49 @@ var _ = Describe("PlanTraversal", func() {
 							},
 						},
 					},
+
+					atc.Plan{
+						ID: "26",
+						Conditional: &atc.ConditionalPlan{
+							Condition: atc.ConditionConfig{
+								Job: "some-job",
+							},
+							Step: atc.Plan{
+								ID: "27",
+								Task: &atc.TaskPlan{
+									Name: "name",
+								},
+							},
+						},
+					},
+
+					atc.Plan{
+						ID: "28",
+						InParallel: &atc.InParallelPlan{
+							Steps: []atc.Plan{
+								atc.Plan{
+									ID: "29",
+									Task: &atc.TaskPlan{
+										Name: "name",
+									},
+								},
+								atc.Plan{
+									ID: "30",
+									Task: &atc.TaskPlan{
+										Name: "name",
+									},
+								},
+							},
+							Limit: 1,
+						},
+					},
 				},
 			}
 
 			err := planTraversal.Traverse(plan)
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(allPlans).To(HaveLen(26))
+			Expect(allPlans).To(HaveLen(31))
 			Expect(allPlans[0]).To(Equal(plan))
 			Expect(allPlans[1]).To(Equal(&(*plan.Aggregate)[0]))
 			Expect(allPlans[2]).To(Equal(&(*(*plan.Aggregate)[0].Aggregate)[0]))
@@ -211,6 +247,11 @@ var _ = Describe("PlanTraversal", func() {
 			Expect(allPlans[23]).To(Equal(&(*(*plan.Aggregate)[11].Retry)[0]))
 			Expect(allPlans[24]).To(Equal(&(*(*plan.Aggregate)[11].Retry)[1]))
 			Expect(allPlans[25]).To(Equal(&(*(*plan.Aggregate)[11].Retry)[2]))
+			Expect(allPlans[26]).To(Equal(&(*plan.Aggregate)[12]))
+			Expect(allPlans[27]).To(Equal(&(*plan.Aggregate)[12].Conditional.Step))
+			Expect(allPlans[28]).To(Equal(&(*plan.Aggregate)[13]))
+			Expect(allPlans[29]).To(Equal(&(*plan.Aggregate)[13].InParallel.Steps[0]))
+			Expect(allPlans[30]).To(Equal(&(*plan.Aggregate)[13].InParallel.Steps[1]))
 		})
 		It("propagates errors from traverseFunc and stops the traversal", func() {
 			allPlans := []*atc.Plan{}
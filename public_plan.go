@@ -7,6 +7,7 @@ func (plan Plan) Public() *json.RawMessage {
 		ID PlanID `json:"id"`
 
 		Aggregate    *json.RawMessage `json:"aggregate,omitempty"`
+		InParallel   *json.RawMessage `json:"in_parallel,omitempty"`
 		Do           *json.RawMessage `json:"do,omitempty"`
 		Get          *json.RawMessage `json:"get,omitempty"`
 		Put          *json.RawMessage `json:"put,omitempty"`
@@ -18,6 +19,7 @@ func (plan Plan) Public() *json.RawMessage {
 		DependentGet *json.RawMessage `json:"dependent_get,omitempty"`
 		Timeout      *json.RawMessage `json:"timeout,omitempty"`
 		Retry        *json.RawMessage `json:"retry,omitempty"`
+		Conditional  *json.RawMessage `json:"conditional,omitempty"`
 	}
 
 	public.ID = plan.ID
@@ -26,6 +28,10 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Aggregate = plan.Aggregate.Public()
 	}
 
+	if plan.InParallel != nil {
+		public.InParallel = plan.InParallel.Public()
+	}
+
 	if plan.Do != nil {
 		public.Do = plan.Do.Public()
 	}
@@ -70,6 +76,10 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Retry = plan.Retry.Public()
 	}
 
+	if plan.Conditional != nil {
+		public.Conditional = plan.Conditional.Public()
+	}
+
 	return enc(public)
 }
 
@@ -83,6 +93,24 @@ func (plan AggregatePlan) Public() *json.RawMessage {
 	return enc(public)
 }
 
+func (plan InParallelPlan) Public() *json.RawMessage {
+	steps := make([]*json.RawMessage, len(plan.Steps))
+
+	for i := 0; i < len(plan.Steps); i++ {
+		steps[i] = plan.Steps[i].Public()
+	}
+
+	return enc(struct {
+		Steps    []*json.RawMessage `json:"steps"`
+		Limit    int                `json:"limit,omitempty"`
+		FailFast bool               `json:"fail_fast,omitempty"`
+	}{
+		Steps:    steps,
+		Limit:    plan.Limit,
+		FailFast: plan.FailFast,
+	})
+}
+
 func (plan DoPlan) Public() *json.RawMessage {
 	public := make([]*json.RawMessage, len(plan))
 
@@ -189,6 +217,16 @@ func (plan TryPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan ConditionalPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Condition ConditionConfig  `json:"condition"`
+		Step      *json.RawMessage `json:"step"`
+	}{
+		Condition: plan.Condition,
+		Step:      plan.Step.Public(),
+	})
+}
+
 func (plan RetryPlan) Public() *json.RawMessage {
 	public := make([]*json.RawMessage, len(plan))
 
@@ -13,6 +13,8 @@ import (
 type RadarDB interface {
 	GetPipelineName() string
 	GetPipelineID() int
+	GetTeamID() int
+	GetTeamName() string
 	ScopedName(string) string
 
 	IsPaused() (bool, error)
@@ -24,6 +24,18 @@ type FakeRadarDB struct {
 	getPipelineIDReturns     struct {
 		result1 int
 	}
+	GetTeamIDStub        func() int
+	getTeamIDMutex       sync.RWMutex
+	getTeamIDArgsForCall []struct{}
+	getTeamIDReturns     struct {
+		result1 int
+	}
+	GetTeamNameStub        func() string
+	getTeamNameMutex       sync.RWMutex
+	getTeamNameArgsForCall []struct{}
+	getTeamNameReturns     struct {
+		result1 string
+	}
 	ScopedNameStub        func(string) string
 	scopedNameMutex       sync.RWMutex
 	scopedNameArgsForCall []struct {
@@ -201,6 +213,56 @@ func (fake *FakeRadarDB) GetPipelineIDReturns(result1 int) {
 	}{result1}
 }
 
+func (fake *FakeRadarDB) GetTeamID() int {
+	fake.getTeamIDMutex.Lock()
+	fake.getTeamIDArgsForCall = append(fake.getTeamIDArgsForCall, struct{}{})
+	fake.recordInvocation("GetTeamID", []interface{}{})
+	fake.getTeamIDMutex.Unlock()
+	if fake.GetTeamIDStub != nil {
+		return fake.GetTeamIDStub()
+	} else {
+		return fake.getTeamIDReturns.result1
+	}
+}
+
+func (fake *FakeRadarDB) GetTeamIDCallCount() int {
+	fake.getTeamIDMutex.RLock()
+	defer fake.getTeamIDMutex.RUnlock()
+	return len(fake.getTeamIDArgsForCall)
+}
+
+func (fake *FakeRadarDB) GetTeamIDReturns(result1 int) {
+	fake.GetTeamIDStub = nil
+	fake.getTeamIDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeRadarDB) GetTeamName() string {
+	fake.getTeamNameMutex.Lock()
+	fake.getTeamNameArgsForCall = append(fake.getTeamNameArgsForCall, struct{}{})
+	fake.recordInvocation("GetTeamName", []interface{}{})
+	fake.getTeamNameMutex.Unlock()
+	if fake.GetTeamNameStub != nil {
+		return fake.GetTeamNameStub()
+	} else {
+		return fake.getTeamNameReturns.result1
+	}
+}
+
+func (fake *FakeRadarDB) GetTeamNameCallCount() int {
+	fake.getTeamNameMutex.RLock()
+	defer fake.getTeamNameMutex.RUnlock()
+	return len(fake.getTeamNameArgsForCall)
+}
+
+func (fake *FakeRadarDB) GetTeamNameReturns(result1 string) {
+	fake.GetTeamNameStub = nil
+	fake.getTeamNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeRadarDB) ScopedName(arg1 string) string {
 	fake.scopedNameMutex.Lock()
 	fake.scopedNameArgsForCall = append(fake.scopedNameArgsForCall, struct {
@@ -649,6 +711,10 @@ func (fake *FakeRadarDB) Invocations() map[string][][]interface{} {
 	defer fake.getPipelineNameMutex.RUnlock()
 	fake.getPipelineIDMutex.RLock()
 	defer fake.getPipelineIDMutex.RUnlock()
+	fake.getTeamIDMutex.RLock()
+	defer fake.getTeamIDMutex.RUnlock()
+	fake.getTeamNameMutex.RLock()
+	defer fake.getTeamNameMutex.RUnlock()
 	fake.scopedNameMutex.RLock()
 	defer fake.scopedNameMutex.RUnlock()
 	fake.isPausedMutex.RLock()
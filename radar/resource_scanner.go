@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -14,11 +15,12 @@ import (
 )
 
 type resourceScanner struct {
-	clock           clock.Clock
-	tracker         resource.Tracker
-	defaultInterval time.Duration
-	db              RadarDB
-	externalURL     string
+	clock             clock.Clock
+	tracker           resource.Tracker
+	defaultInterval   time.Duration
+	db                RadarDB
+	externalURL       string
+	credentialManager creds.CredentialManager
 }
 
 func NewResourceScanner(
@@ -27,13 +29,15 @@ func NewResourceScanner(
 	defaultInterval time.Duration,
 	db RadarDB,
 	externalURL string,
+	credentialManager creds.CredentialManager,
 ) Scanner {
 	return &resourceScanner{
-		clock:           clock,
-		tracker:         tracker,
-		defaultInterval: defaultInterval,
-		db:              db,
-		externalURL:     externalURL,
+		clock:             clock,
+		tracker:           tracker,
+		defaultInterval:   defaultInterval,
+		db:                db,
+		externalURL:       externalURL,
+		credentialManager: credentialManager,
 	}
 }
 
@@ -192,8 +196,35 @@ func (scanner *resourceScanner) scan(
 		return nil
 	}
 
+	if savedResource.Pinned() {
+		logger.Debug("resource-pinned")
+		return nil
+	}
+
 	pipelineID := scanner.db.GetPipelineID()
 
+	// the check runs with resolved credentials, but the container is
+	// identified and versions are saved by the config as written
+	variables := creds.Variables{
+		Manager:      scanner.credentialManager,
+		TeamName:     scanner.db.GetTeamName(),
+		PipelineName: scanner.db.GetPipelineName(),
+	}
+
+	source, err := variables.EvaluateSource(resourceConfig.Source)
+	if err != nil {
+		logger.Error("failed-to-evaluate-source", err)
+		scanner.setCheckError(logger, savedResource, err)
+		return err
+	}
+
+	evaluatedResourceTypes, err := variables.EvaluateResourceTypes(resourceTypes)
+	if err != nil {
+		logger.Error("failed-to-evaluate-resource-types", err)
+		scanner.setCheckError(logger, savedResource, err)
+		return err
+	}
+
 	var resourceTypeVersion atc.Version
 	_, found := resourceTypes.Lookup(resourceConfig.Type)
 	if found {
@@ -218,6 +249,7 @@ func (scanner *resourceScanner) scan(
 		Metadata: worker.Metadata{
 			Type:       db.ContainerTypeCheck,
 			PipelineID: pipelineID,
+			TeamID:     scanner.db.GetTeamID(),
 		},
 		Ephemeral: true,
 	}
@@ -232,7 +264,7 @@ func (scanner *resourceScanner) scan(
 		session,
 		resource.ResourceType(resourceConfig.Type),
 		[]string{},
-		resourceTypes,
+		evaluatedResourceTypes,
 		worker.NoopImageFetchingDelegate{},
 	)
 	if err != nil {
@@ -246,12 +278,9 @@ func (scanner *resourceScanner) scan(
 		"from": fromVersion,
 	})
 
-	newVersions, err := res.Check(resourceConfig.Source, fromVersion)
+	newVersions, err := res.Check(source, fromVersion)
 
-	setErr := scanner.db.SetResourceCheckError(savedResource, err)
-	if setErr != nil {
-		logger.Error("failed-to-set-check-error", err)
-	}
+	scanner.setCheckError(logger, savedResource, err)
 
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
@@ -283,6 +312,13 @@ func (scanner *resourceScanner) scan(
 	return nil
 }
 
+func (scanner *resourceScanner) setCheckError(logger lager.Logger, savedResource db.SavedResource, err error) {
+	setErr := scanner.db.SetResourceCheckError(savedResource, err)
+	if setErr != nil {
+		logger.Error("failed-to-set-check-error", setErr)
+	}
+}
+
 func swallowErrResourceScriptFailed(err error) error {
 	if _, ok := err.(resource.ErrResourceScriptFailed); ok {
 		return nil
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -11,10 +12,11 @@ import (
 )
 
 type resourceTypeScanner struct {
-	tracker         resource.Tracker
-	defaultInterval time.Duration
-	db              RadarDB
-	externalURL     string
+	tracker           resource.Tracker
+	defaultInterval   time.Duration
+	db                RadarDB
+	externalURL       string
+	credentialManager creds.CredentialManager
 }
 
 func NewResourceTypeScanner(
@@ -22,12 +24,14 @@ func NewResourceTypeScanner(
 	defaultInterval time.Duration,
 	db RadarDB,
 	externalURL string,
+	credentialManager creds.CredentialManager,
 ) Scanner {
 	return &resourceTypeScanner{
-		tracker:         tracker,
-		defaultInterval: defaultInterval,
-		db:              db,
-		externalURL:     externalURL,
+		tracker:           tracker,
+		defaultInterval:   defaultInterval,
+		db:                db,
+		externalURL:       externalURL,
+		credentialManager: credentialManager,
 	}
 }
 
@@ -99,6 +103,20 @@ func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, resour
 
 	pipelineID := scanner.db.GetPipelineID()
 
+	variables := creds.Variables{
+		Manager:      scanner.credentialManager,
+		TeamName:     scanner.db.GetTeamName(),
+		PipelineName: scanner.db.GetPipelineName(),
+	}
+
+	// the image is fetched and checked with resolved credentials, but the
+	// container is identified by the source as written
+	source, err := variables.EvaluateSource(resourceType.Source)
+	if err != nil {
+		logger.Error("failed-to-evaluate-source", err)
+		return err
+	}
+
 	session := resource.Session{
 		ID: worker.Identifier{
 			Stage:               db.ContainerStageCheck,
@@ -110,6 +128,7 @@ func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, resour
 		Metadata: worker.Metadata{
 			Type:                 db.ContainerTypeCheck,
 			PipelineID:           pipelineID,
+			TeamID:               scanner.db.GetTeamID(),
 			WorkingDirectory:     "",
 			EnvironmentVariables: nil,
 		},
@@ -133,7 +152,7 @@ func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, resour
 
 	logger.Debug("checking")
 
-	newVersions, err := res.Check(resourceType.Source, atc.Version(from))
+	newVersions, err := res.Check(source, atc.Version(from))
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
 			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
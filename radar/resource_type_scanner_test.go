@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	. "github.com/concourse/atc/radar"
 	"github.com/concourse/atc/radar/radarfakes"
@@ -22,9 +23,10 @@ var _ = Describe("ResourceTypeScanner", func() {
 	var (
 		epoch time.Time
 
-		fakeTracker *rfakes.FakeTracker
-		fakeRadarDB *radarfakes.FakeRadarDB
-		interval    time.Duration
+		fakeTracker           *rfakes.FakeTracker
+		fakeRadarDB           *radarfakes.FakeRadarDB
+		fakeCredentialManager *credsfakes.FakeCredentialManager
+		interval              time.Duration
 
 		scanner Scanner
 
@@ -37,14 +39,17 @@ var _ = Describe("ResourceTypeScanner", func() {
 		epoch = time.Unix(123, 456).UTC()
 		fakeTracker = new(rfakes.FakeTracker)
 		fakeRadarDB = new(radarfakes.FakeRadarDB)
+		fakeCredentialManager = new(credsfakes.FakeCredentialManager)
 		interval = 1 * time.Minute
 
 		fakeRadarDB.GetPipelineIDReturns(42)
+		fakeRadarDB.GetTeamIDReturns(7)
 		scanner = NewResourceTypeScanner(
 			fakeTracker,
 			interval,
 			fakeRadarDB,
 			"https://www.example.com",
+			fakeCredentialManager,
 		)
 
 		fakeRadarDB.ScopedNameStub = func(thing string) string {
@@ -128,6 +133,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 					Metadata: worker.Metadata{
 						Type:                 db.ContainerTypeCheck,
 						PipelineID:           42,
+						TeamID:               7,
 						WorkingDirectory:     "",
 						EnvironmentVariables: nil,
 					},
@@ -139,6 +145,43 @@ var _ = Describe("ResourceTypeScanner", func() {
 				Expect(delegate).To(Equal(worker.NoopImageFetchingDelegate{}))
 			})
 
+			Context("when the source contains credential placeholders", func() {
+				BeforeEach(func() {
+					fakeRadarDB.GetConfigReturns(atc.Config{
+						ResourceTypes: atc.ResourceTypes{
+							{
+								Name:   "some-resource-type",
+								Type:   "docker-image",
+								Source: atc.Source{"password": "((password))"},
+							},
+						},
+					}, 1, true, nil)
+
+					fakeCredentialManager.GetReturns("hunter2", true, nil)
+				})
+
+				It("checks with the resolved source", func() {
+					source, _ := fakeResource.CheckArgsForCall(0)
+					Expect(source).To(Equal(atc.Source{"password": "hunter2"}))
+				})
+
+				It("identifies the container by the source as written", func() {
+					_, _, session, _, _, _, _ := fakeTracker.InitArgsForCall(0)
+					Expect(session.ID.CheckSource).To(Equal(atc.Source{"password": "((password))"}))
+				})
+
+				Context("when the check returns versions", func() {
+					BeforeEach(func() {
+						fakeResource.CheckReturns([]atc.Version{{"version": "1"}}, nil)
+					})
+
+					It("saves the version against the resource type as written", func() {
+						resourceType, _ := fakeRadarDB.SaveResourceTypeVersionArgsForCall(0)
+						Expect(resourceType.Source).To(Equal(atc.Source{"password": "((password))"}))
+					})
+				})
+			})
+
 			It("grabs a periodic resource checking lease before checking, breaks lease after done", func() {
 				Expect(fakeRadarDB.LeaseResourceTypeCheckingCallCount()).To(Equal(1))
 
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/resource"
 	"github.com/tedsuo/ifrit"
 
@@ -45,6 +46,7 @@ func NewScanRunnerFactory(
 	db RadarDB,
 	clock clock.Clock,
 	externalURL string,
+	credentialManager creds.CredentialManager,
 ) ScanRunnerFactory {
 	resourceScanner := NewResourceScanner(
 		clock,
@@ -52,12 +54,14 @@ func NewScanRunnerFactory(
 		defaultInterval,
 		db,
 		externalURL,
+		credentialManager,
 	)
 	resourceTypeScanner := NewResourceTypeScanner(
 		tracker,
 		defaultInterval,
 		db,
 		externalURL,
+		credentialManager,
 	)
 
 	return &scanRunnerFactory{
@@ -3,6 +3,7 @@ package radar
 import (
 	"time"
 
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/resource"
 	"github.com/pivotal-golang/clock"
 )
@@ -12,23 +13,26 @@ type ScannerFactory interface {
 }
 
 type scannerFactory struct {
-	tracker         resource.Tracker
-	defaultInterval time.Duration
-	externalURL     string
+	tracker           resource.Tracker
+	defaultInterval   time.Duration
+	externalURL       string
+	credentialManager creds.CredentialManager
 }
 
 func NewScannerFactory(
 	tracker resource.Tracker,
 	defaultInterval time.Duration,
 	externalURL string,
+	credentialManager creds.CredentialManager,
 ) ScannerFactory {
 	return &scannerFactory{
-		tracker:         tracker,
-		defaultInterval: defaultInterval,
-		externalURL:     externalURL,
+		tracker:           tracker,
+		defaultInterval:   defaultInterval,
+		externalURL:       externalURL,
+		credentialManager: credentialManager,
 	}
 }
 
 func (f *scannerFactory) NewResourceScanner(db RadarDB) Scanner {
-	return NewResourceScanner(clock.NewClock(), f.tracker, f.defaultInterval, db, f.externalURL)
+	return NewResourceScanner(clock.NewClock(), f.tracker, f.defaultInterval, db, f.externalURL, f.credentialManager)
 }
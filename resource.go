@@ -8,6 +8,14 @@ type Resource struct {
 
 	Paused bool `json:"paused,omitempty"`
 
+	PinnedVersionID int    `json:"pinned_version_id,omitempty"`
+	PinComment      string `json:"pin_comment,omitempty"`
+	PinnedBy        string `json:"pinned_by,omitempty"`
+
 	FailingToCheck bool   `json:"failing_to_check,omitempty"`
 	CheckError     string `json:"check_error,omitempty"`
 }
+
+type PinVersionRequest struct {
+	Comment string `json:"comment"`
+}
@@ -98,10 +98,16 @@ func (tracker *tracker) InitWithSources(
 		},
 		Ephemeral: session.Ephemeral,
 		Tags:      tags,
+		TeamID:    session.Metadata.TeamID,
 		Env:       metadata.Env(),
 	}
 
-	compatibleWorkers, err := tracker.workerClient.AllSatisfying(resourceSpec.WorkerSpec(), resourceTypes)
+	var compatibleWorkers []worker.Worker
+	err = worker.WaitForCapacity(logger, tracker.clock, nil, imageFetchingDelegate, func() error {
+		var err error
+		compatibleWorkers, err = tracker.workerClient.AllSatisfying(resourceSpec.WorkerSpec(), resourceTypes)
+		return err
+	})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -211,6 +217,7 @@ func (tracker *tracker) Init(
 			},
 			Ephemeral: session.Ephemeral,
 			Tags:      tags,
+			TeamID:    session.Metadata.TeamID,
 			Env:       metadata.Env(),
 		},
 		resourceTypes,
@@ -268,9 +275,15 @@ func (tracker *tracker) InitWithCache(
 	resourceSpec := worker.WorkerSpec{
 		ResourceType: string(typ),
 		Tags:         tags,
+		TeamID:       session.Metadata.TeamID,
 	}
 
-	chosenWorker, err := tracker.workerClient.Satisfying(resourceSpec, resourceTypes)
+	var chosenWorker worker.Worker
+	err = worker.WaitForCapacity(logger, tracker.clock, nil, imageFetchingDelegate, func() error {
+		var err error
+		chosenWorker, err = tracker.workerClient.Satisfying(resourceSpec, resourceTypes)
+		return err
+	})
 	if err != nil {
 		logger.Info("no-workers-satisfying-spec", lager.Data{
 			"error": err.Error(),
@@ -285,6 +298,7 @@ func (tracker *tracker) InitWithCache(
 		},
 		Ephemeral: session.Ephemeral,
 		Tags:      tags,
+		TeamID:    session.Metadata.TeamID,
 		Env:       metadata.Env(),
 	}
 
@@ -33,6 +33,7 @@ var _ = Describe("Tracker", func() {
 		Metadata: worker.Metadata{
 			WorkerName:           "some-worker",
 			EnvironmentVariables: []string{"some=value"},
+			TeamID:               5,
 		},
 		Ephemeral: true,
 	}
@@ -95,6 +96,7 @@ var _ = Describe("Tracker", func() {
 
 				Expect(spec.Platform).To(BeEmpty())
 				Expect(spec.Tags).To(ConsistOf("resource", "tags"))
+				Expect(spec.TeamID).To(Equal(5))
 				Expect(spec.ImageSpec).To(Equal(worker.ImageSpec{
 					ResourceType: string(initType),
 					Privileged:   true,
@@ -235,6 +237,7 @@ var _ = Describe("Tracker", func() {
 							worker.WorkerSpec{
 								ResourceType: "type1",
 								Tags:         []string{"resource", "tags"},
+								TeamID:       5,
 							},
 						))
 						Expect(actualCustomTypes).To(Equal(customTypes))
@@ -254,6 +257,7 @@ var _ = Describe("Tracker", func() {
 
 						Expect(spec.Platform).To(BeEmpty())
 						Expect(spec.Tags).To(ConsistOf("resource", "tags"))
+						Expect(spec.TeamID).To(Equal(5))
 						Expect(spec.ImageSpec).To(Equal(worker.ImageSpec{
 							ResourceType: string(initType),
 							Privileged:   true,
@@ -365,6 +369,7 @@ var _ = Describe("Tracker", func() {
 								worker.WorkerSpec{
 									ResourceType: "type1",
 									Tags:         []string{"resource", "tags"},
+									TeamID:       5,
 								},
 							))
 							Expect(actualCustomTypes).To(Equal(customTypes))
@@ -384,6 +389,7 @@ var _ = Describe("Tracker", func() {
 
 							Expect(spec.Platform).To(BeEmpty())
 							Expect(spec.Tags).To(ConsistOf("resource", "tags"))
+							Expect(spec.TeamID).To(Equal(5))
 							Expect(spec.ImageSpec).To(Equal(worker.ImageSpec{
 								ResourceType: string(initType),
 								Privileged:   true,
@@ -482,6 +488,7 @@ var _ = Describe("Tracker", func() {
 
 							Expect(spec.Platform).To(BeEmpty())
 							Expect(spec.Tags).To(ConsistOf("resource", "tags"))
+							Expect(spec.TeamID).To(Equal(5))
 							Expect(spec.ImageSpec).To(Equal(worker.ImageSpec{
 								ResourceType: string(initType),
 								Privileged:   true,
@@ -782,6 +789,7 @@ var _ = Describe("Tracker", func() {
 							worker.WorkerSpec{
 								ResourceType: "type1",
 								Tags:         []string{"resource", "tags"},
+								TeamID:       5,
 							},
 						))
 						Expect(actualCustomTypes).To(Equal(customTypes))
@@ -810,6 +818,7 @@ var _ = Describe("Tracker", func() {
 
 						Expect(spec.Platform).To(BeEmpty())
 						Expect(spec.Tags).To(ConsistOf("resource", "tags"))
+						Expect(spec.TeamID).To(Equal(5))
 						Expect(spec.ImageSpec).To(Equal(worker.ImageSpec{
 							ResourceType: string(initType),
 							Privileged:   true,
@@ -871,6 +880,7 @@ var _ = Describe("Tracker", func() {
 
 						Expect(spec.Platform).To(BeEmpty())
 						Expect(spec.Tags).To(ConsistOf("resource", "tags"))
+						Expect(spec.TeamID).To(Equal(5))
 						Expect(spec.ImageSpec).To(Equal(worker.ImageSpec{
 							ResourceType: string(initType),
 							Privileged:   true,
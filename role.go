@@ -0,0 +1,26 @@
+package atc
+
+// Roles grant a user abilities within their team. Each role includes the
+// abilities of the roles ranked below it.
+const (
+	RoleOwner  = "owner"
+	RoleMember = "member"
+	RoleViewer = "viewer"
+)
+
+var roleRanks = map[string]int{
+	RoleViewer: 1,
+	RoleMember: 2,
+	RoleOwner:  3,
+}
+
+func IsValidRole(role string) bool {
+	_, found := roleRanks[role]
+	return found
+}
+
+// RoleAllows reports whether a user with the given role may perform an action
+// requiring the required role.
+func RoleAllows(role string, required string) bool {
+	return IsValidRole(role) && roleRanks[role] >= roleRanks[required]
+}
@@ -23,18 +23,22 @@ const (
 	GetJobBuild    = "GetJobBuild"
 	PauseJob       = "PauseJob"
 	UnpauseJob     = "UnpauseJob"
+	ClearJobCaches = "ClearJobCaches"
 	GetVersionsDB  = "GetVersionsDB"
 	JobBadge       = "JobBadge"
 
-	ListResources   = "ListResources"
-	GetResource     = "GetResource"
-	PauseResource   = "PauseResource"
-	UnpauseResource = "UnpauseResource"
-	CheckResource   = "CheckResource"
+	ListResources        = "ListResources"
+	GetResource          = "GetResource"
+	PauseResource        = "PauseResource"
+	UnpauseResource      = "UnpauseResource"
+	UnpinResource        = "UnpinResource"
+	CheckResource        = "CheckResource"
+	CheckResourceWebhook = "CheckResourceWebhook"
 
 	ListResourceVersions          = "ListResourceVersions"
 	EnableResourceVersion         = "EnableResourceVersion"
 	DisableResourceVersion        = "DisableResourceVersion"
+	PinResourceVersion            = "PinResourceVersion"
 	ListBuildsWithVersionAsInput  = "ListBuildsWithVersionAsInput"
 	ListBuildsWithVersionAsOutput = "ListBuildsWithVersionAsOutput"
 
@@ -46,12 +50,22 @@ const (
 	UnpausePipeline = "UnpausePipeline"
 	RenamePipeline  = "RenamePipeline"
 
+	ArchivePipeline = "ArchivePipeline"
+
+	ListPipelineInstances    = "ListPipelineInstances"
+	PausePipelineInstances   = "PausePipelineInstances"
+	UnpausePipelineInstances = "UnpausePipelineInstances"
+	ArchivePipelineInstances = "ArchivePipelineInstances"
+
 	CreatePipe = "CreatePipe"
 	WritePipe  = "WritePipe"
 	ReadPipe   = "ReadPipe"
 
 	RegisterWorker = "RegisterWorker"
 	ListWorkers    = "ListWorkers"
+	LandWorker     = "LandWorker"
+	RetireWorker   = "RetireWorker"
+	PruneWorker    = "PruneWorker"
 
 	SetLogLevel = "SetLogLevel"
 	GetLogLevel = "GetLogLevel"
@@ -68,7 +82,17 @@ const (
 	ListAuthMethods = "ListAuthMethods"
 	GetAuthToken    = "GetAuthToken"
 
-	SetTeam = "SetTeam"
+	ListTeams  = "ListTeams"
+	GetTeam    = "GetTeam"
+	SetTeam    = "SetTeam"
+	DeleteTeam = "DeleteTeam"
+	RenameTeam = "RenameTeam"
+
+	ListAPITokens  = "ListAPITokens"
+	CreateAPIToken = "CreateAPIToken"
+	RevokeAPIToken = "RevokeAPIToken"
+
+	ListAuditEvents = "ListAuditEvents"
 )
 
 var Routes = rata.Routes([]rata.Route{
@@ -92,6 +116,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
+	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/caches", Method: "DELETE", Name: ClearJobCaches},
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: JobBadge},
 
 	{Path: "/api/v1/pipelines", Method: "GET", Name: ListPipelines},
@@ -100,6 +125,11 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/pipelines/ordering", Method: "PUT", Name: OrderPipelines},
 	{Path: "/api/v1/pipelines/:pipeline_name/pause", Method: "PUT", Name: PausePipeline},
 	{Path: "/api/v1/pipelines/:pipeline_name/unpause", Method: "PUT", Name: UnpausePipeline},
+	{Path: "/api/v1/pipelines/:pipeline_name/archive", Method: "PUT", Name: ArchivePipeline},
+	{Path: "/api/v1/pipelines/:pipeline_name/instances", Method: "GET", Name: ListPipelineInstances},
+	{Path: "/api/v1/pipelines/:pipeline_name/instances/pause", Method: "PUT", Name: PausePipelineInstances},
+	{Path: "/api/v1/pipelines/:pipeline_name/instances/unpause", Method: "PUT", Name: UnpausePipelineInstances},
+	{Path: "/api/v1/pipelines/:pipeline_name/instances/archive", Method: "PUT", Name: ArchivePipelineInstances},
 	{Path: "/api/v1/pipelines/:pipeline_name/versions-db", Method: "GET", Name: GetVersionsDB},
 	{Path: "/api/v1/pipelines/:pipeline_name/rename", Method: "PUT", Name: RenamePipeline},
 
@@ -107,11 +137,14 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name", Method: "GET", Name: GetResource},
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/pause", Method: "PUT", Name: PauseResource},
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/unpause", Method: "PUT", Name: UnpauseResource},
+	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/unpin", Method: "PUT", Name: UnpinResource},
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
+	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebhook},
 
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "GET", Name: ListResourceVersions},
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/disable", Method: "PUT", Name: DisableResourceVersion},
+	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", Method: "PUT", Name: PinResourceVersion},
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/input_to", Method: "GET", Name: ListBuildsWithVersionAsInput},
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/output_of", Method: "GET", Name: ListBuildsWithVersionAsOutput},
 
@@ -121,6 +154,9 @@ var Routes = rata.Routes([]rata.Route{
 
 	{Path: "/api/v1/workers", Method: "GET", Name: ListWorkers},
 	{Path: "/api/v1/workers", Method: "POST", Name: RegisterWorker},
+	{Path: "/api/v1/workers/:worker_name/land", Method: "PUT", Name: LandWorker},
+	{Path: "/api/v1/workers/:worker_name/retire", Method: "PUT", Name: RetireWorker},
+	{Path: "/api/v1/workers/:worker_name/prune", Method: "PUT", Name: PruneWorker},
 
 	{Path: "/api/v1/log-level", Method: "GET", Name: GetLogLevel},
 	{Path: "/api/v1/log-level", Method: "PUT", Name: SetLogLevel},
@@ -137,5 +173,60 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/auth/methods", Method: "GET", Name: ListAuthMethods},
 	{Path: "/api/v1/auth/token", Method: "GET", Name: GetAuthToken},
 
+	{Path: "/api/v1/teams", Method: "GET", Name: ListTeams},
+	{Path: "/api/v1/teams/:team_name", Method: "GET", Name: GetTeam},
 	{Path: "/api/v1/teams/:team_name", Method: "PUT", Name: SetTeam},
+	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DeleteTeam},
+	{Path: "/api/v1/teams/:team_name/rename", Method: "PUT", Name: RenameTeam},
+
+	{Path: "/api/v1/teams/:team_name/tokens", Method: "GET", Name: ListAPITokens},
+	{Path: "/api/v1/teams/:team_name/tokens", Method: "POST", Name: CreateAPIToken},
+	{Path: "/api/v1/teams/:team_name/tokens/:token_name", Method: "DELETE", Name: RevokeAPIToken},
+
+	{Path: "/api/v1/audit", Method: "GET", Name: ListAuditEvents},
+
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PUT", Name: SaveConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "GET", Name: GetConfig},
+
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name", Method: "GET", Name: GetJob},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "GET", Name: ListJobBuilds},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "POST", Name: CreateJobBuild},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", Method: "GET", Name: ListJobInputs},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/caches", Method: "DELETE", Name: ClearJobCaches},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: JobBadge},
+
+	{Path: "/api/v1/teams/:team_name/pipelines", Method: "GET", Name: ListPipelines},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name", Method: "GET", Name: GetPipeline},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name", Method: "DELETE", Name: DeletePipeline},
+	{Path: "/api/v1/teams/:team_name/pipelines/ordering", Method: "PUT", Name: OrderPipelines},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/pause", Method: "PUT", Name: PausePipeline},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/unpause", Method: "PUT", Name: UnpausePipeline},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/archive", Method: "PUT", Name: ArchivePipeline},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/instances", Method: "GET", Name: ListPipelineInstances},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/instances/pause", Method: "PUT", Name: PausePipelineInstances},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/instances/unpause", Method: "PUT", Name: UnpausePipelineInstances},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/instances/archive", Method: "PUT", Name: ArchivePipelineInstances},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/versions-db", Method: "GET", Name: GetVersionsDB},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/rename", Method: "PUT", Name: RenamePipeline},
+
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources", Method: "GET", Name: ListResources},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name", Method: "GET", Name: GetResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pause", Method: "PUT", Name: PauseResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpause", Method: "PUT", Name: UnpauseResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", Method: "PUT", Name: UnpinResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebhook},
+
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "GET", Name: ListResourceVersions},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/disable", Method: "PUT", Name: DisableResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", Method: "PUT", Name: PinResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/input_to", Method: "GET", Name: ListBuildsWithVersionAsInput},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/output_of", Method: "GET", Name: ListBuildsWithVersionAsOutput},
+
+	{Path: "/api/v1/teams/:team_name/auth/token", Method: "GET", Name: GetAuthToken},
 })
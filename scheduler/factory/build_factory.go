@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 )
 
@@ -19,12 +20,17 @@ type BuildFactory interface {
 
 type buildFactory struct {
 	PipelineID  int
+	variables   creds.Variables
 	planFactory atc.PlanFactory
 }
 
-func NewBuildFactory(pipelineID int, planFactory atc.PlanFactory) BuildFactory {
+// NewBuildFactory returns a BuildFactory that resolves ((var)) placeholders
+// in sources and params with the given variables. The resolved values only
+// end up in the build's plan; the pipeline's config is left untouched.
+func NewBuildFactory(pipelineID int, variables creds.Variables, planFactory atc.PlanFactory) BuildFactory {
 	return &buildFactory{
 		PipelineID:  pipelineID,
+		variables:   variables,
 		planFactory: planFactory,
 	}
 }
@@ -35,6 +41,34 @@ func (factory *buildFactory) Create(
 	resourceTypes atc.ResourceTypes,
 	inputs []db.BuildInput,
 ) (atc.Plan, error) {
+	// record what this build's placeholders resolve to, so that the engine
+	// knows what to redact from its output
+	resolved := &creds.ResolvedValues{}
+
+	buildFactory := *factory
+	buildFactory.variables.Resolved = resolved
+
+	plan, err := buildFactory.create(job, resources, resourceTypes, inputs)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	plan.Secrets = resolved.Strings()
+
+	return plan, nil
+}
+
+func (factory *buildFactory) create(
+	job atc.JobConfig,
+	resources atc.ResourceConfigs,
+	resourceTypes atc.ResourceTypes,
+	inputs []db.BuildInput,
+) (atc.Plan, error) {
+	resourceTypes, err := factory.variables.EvaluateResourceTypes(resourceTypes)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
 	planSequence := job.Plan
 
 	if len(planSequence) == 1 {
@@ -126,6 +160,13 @@ func (factory *buildFactory) constructPlanFromConfig(
 		return atc.Plan{}, err
 	}
 
+	if planConfig.Condition != nil {
+		constructionParams.plan = factory.planFactory.NewPlan(atc.ConditionalPlan{
+			Condition: *planConfig.Condition,
+			Step:      constructionParams.plan,
+		})
+	}
+
 	return constructionParams.plan, nil
 }
 
@@ -158,9 +199,19 @@ func (factory *buildFactory) constructUnhookedPlan(
 			resourceName = logicalName
 		}
 
-		resource, found := resources.Lookup(resourceName)
-		if !found {
-			return atc.Plan{}, ErrResourceNotFound
+		resource, err := factory.lookupResource(resources, resourceName)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		params, err := factory.variables.EvaluateParams(planConfig.Params)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		getParams, err := factory.variables.EvaluateParams(planConfig.GetParams)
+		if err != nil {
+			return atc.Plan{}, err
 		}
 
 		putPlan := atc.PutPlan{
@@ -169,7 +220,7 @@ func (factory *buildFactory) constructUnhookedPlan(
 			PipelineID:    factory.PipelineID,
 			Resource:      resourceName,
 			Source:        resource.Source,
-			Params:        planConfig.Params,
+			Params:        params,
 			Tags:          planConfig.Tags,
 			ResourceTypes: resourceTypes,
 		}
@@ -179,7 +230,7 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Name:          logicalName,
 			PipelineID:    factory.PipelineID,
 			Resource:      resourceName,
-			Params:        planConfig.GetParams,
+			Params:        getParams,
 			Tags:          planConfig.Tags,
 			Source:        resource.Source,
 			ResourceTypes: resourceTypes,
@@ -196,9 +247,14 @@ func (factory *buildFactory) constructUnhookedPlan(
 			resourceName = planConfig.Get
 		}
 
-		resource, found := resources.Lookup(resourceName)
-		if !found {
-			return atc.Plan{}, ErrResourceNotFound
+		resource, err := factory.lookupResource(resources, resourceName)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		params, err := factory.variables.EvaluateParams(planConfig.Params)
+		if err != nil {
+			return atc.Plan{}, err
 		}
 
 		name := planConfig.Get
@@ -216,22 +272,32 @@ func (factory *buildFactory) constructUnhookedPlan(
 			PipelineID:    factory.PipelineID,
 			Resource:      resourceName,
 			Source:        resource.Source,
-			Params:        planConfig.Params,
+			Params:        params,
 			Version:       atc.Version(version),
 			Tags:          planConfig.Tags,
 			ResourceTypes: resourceTypes,
 		})
 
 	case planConfig.Task != "":
+		params, err := factory.variables.EvaluateParams(planConfig.Params)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		taskConfig, err := factory.variables.EvaluateTaskConfig(planConfig.TaskConfig)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
 		plan = factory.planFactory.NewPlan(atc.TaskPlan{
 			Name:              planConfig.Task,
 			PipelineID:        factory.PipelineID,
 			Privileged:        planConfig.Privileged,
-			Config:            planConfig.TaskConfig,
+			Config:            taskConfig,
 			ConfigPath:        planConfig.TaskConfigPath,
 			Tags:              planConfig.Tags,
 			ResourceTypes:     resourceTypes,
-			Params:            planConfig.Params,
+			Params:            params,
 			InputMapping:      planConfig.InputMapping,
 			OutputMapping:     planConfig.OutputMapping,
 			ImageArtifactName: planConfig.ImageArtifactName,
@@ -269,6 +335,28 @@ func (factory *buildFactory) constructUnhookedPlan(
 		}
 
 		plan = factory.planFactory.NewPlan(aggregate)
+
+	case planConfig.InParallel != nil:
+		inParallel := atc.InParallelPlan{
+			Limit:    planConfig.InParallel.Limit,
+			FailFast: planConfig.InParallel.FailFast,
+		}
+
+		for _, planConfig := range planConfig.InParallel.Steps {
+			nextStep, err := factory.constructPlanFromConfig(
+				planConfig,
+				resources,
+				resourceTypes,
+				inputs,
+			)
+			if err != nil {
+				return atc.Plan{}, err
+			}
+
+			inParallel.Steps = append(inParallel.Steps, nextStep)
+		}
+
+		plan = factory.planFactory.NewPlan(inParallel)
 	}
 
 	if planConfig.Timeout != "" {
@@ -281,6 +369,22 @@ func (factory *buildFactory) constructUnhookedPlan(
 	return plan, nil
 }
 
+func (factory *buildFactory) lookupResource(resources atc.ResourceConfigs, resourceName string) (atc.ResourceConfig, error) {
+	resource, found := resources.Lookup(resourceName)
+	if !found {
+		return atc.ResourceConfig{}, ErrResourceNotFound
+	}
+
+	source, err := factory.variables.EvaluateSource(resource.Source)
+	if err != nil {
+		return atc.ResourceConfig{}, err
+	}
+
+	resource.Source = source
+
+	return resource, nil
+}
+
 type constructionParams struct {
 	plan          atc.Plan
 	planConfig    atc.PlanConfig
@@ -0,0 +1,102 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/scheduler/factory"
+	"github.com/concourse/atc/testhelpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Conditional Step", func() {
+	var (
+		buildFactory        factory.BuildFactory
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+
+		condition *atc.ConditionConfig
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(42, creds.Variables{}, actualPlanFactory)
+
+		condition = &atc.ConditionConfig{
+			Job: "some-job",
+			Version: &atc.VersionCondition{
+				Get:   "some-input",
+				Key:   "branch",
+				Value: "master",
+			},
+		}
+	})
+
+	Context("when there is a task with a condition", func() {
+		It("builds correctly", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:      "first task",
+						Condition: condition,
+					},
+					{
+						Task: "second task",
+					},
+				},
+			}, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.DoPlan{
+				expectedPlanFactory.NewPlan(atc.ConditionalPlan{
+					Condition: *condition,
+					Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:       "first task",
+						PipelineID: 42,
+					}),
+				}),
+				expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:       "second task",
+					PipelineID: 42,
+				}),
+			})
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+
+	Context("when the conditional step also has a hook", func() {
+		It("makes the hook conditional too", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:      "first task",
+						Condition: condition,
+						Success: &atc.PlanConfig{
+							Task: "second task",
+						},
+					},
+				},
+			}, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.ConditionalPlan{
+				Condition: *condition,
+				Step: expectedPlanFactory.NewPlan(atc.OnSuccessPlan{
+					Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:       "first task",
+						PipelineID: 42,
+					}),
+					Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:       "second task",
+						PipelineID: 42,
+					}),
+				}),
+			})
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+})
@@ -0,0 +1,199 @@
+package factory_test
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
+	"github.com/concourse/atc/scheduler/factory"
+	"github.com/concourse/atc/testhelpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Credentials", func() {
+	var (
+		fakeCredentialManager *credsfakes.FakeCredentialManager
+		buildFactory          factory.BuildFactory
+
+		resources           atc.ResourceConfigs
+		resourceTypes       atc.ResourceTypes
+		input               atc.JobConfig
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		fakeCredentialManager = new(credsfakes.FakeCredentialManager)
+		fakeCredentialManager.GetStub = func(teamName string, pipelineName string, varName string) (interface{}, bool, error) {
+			switch varName {
+			case "git-key":
+				return "super-secret-key", true, nil
+			case "registry-password":
+				return "super-secret-password", true, nil
+			case "api-token":
+				return "super-secret-token", true, nil
+			default:
+				return nil, false, nil
+			}
+		}
+
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(
+			42,
+			creds.Variables{
+				Manager:      fakeCredentialManager,
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+			},
+			actualPlanFactory,
+		)
+
+		resources = atc.ResourceConfigs{
+			{
+				Name:   "some-resource",
+				Type:   "git",
+				Source: atc.Source{"uri": "git://some-resource", "private_key": "((git-key))"},
+			},
+		}
+
+		resourceTypes = atc.ResourceTypes{
+			{
+				Name:   "some-custom-resource",
+				Type:   "docker-image",
+				Source: atc.Source{"password": "((registry-password))"},
+			},
+		}
+
+		input = atc.JobConfig{
+			Plan: atc.PlanSequence{
+				{
+					Get:    "some-resource",
+					Params: atc.Params{"token": "((api-token))"},
+				},
+				{
+					Task: "some-task",
+					TaskConfig: &atc.TaskConfig{
+						Params: map[string]string{"TOKEN": "((api-token))"},
+					},
+				},
+				{
+					Put:    "some-resource",
+					Params: atc.Params{"token": "((api-token))"},
+				},
+			},
+		}
+	})
+
+	It("resolves placeholders from the pipeline's scope", func() {
+		_, err := buildFactory.Create(input, resources, resourceTypes, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeCredentialManager.GetCallCount()).NotTo(BeZero())
+		for i := 0; i < fakeCredentialManager.GetCallCount(); i++ {
+			teamName, pipelineName, _ := fakeCredentialManager.GetArgsForCall(i)
+			Expect(teamName).To(Equal("some-team"))
+			Expect(pipelineName).To(Equal("some-pipeline"))
+		}
+	})
+
+	It("puts the resolved values in the plan", func() {
+		actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		resolvedSource := atc.Source{"uri": "git://some-resource", "private_key": "super-secret-key"}
+		resolvedTypes := atc.ResourceTypes{
+			{
+				Name:   "some-custom-resource",
+				Type:   "docker-image",
+				Source: atc.Source{"password": "super-secret-password"},
+			},
+		}
+		resolvedParams := atc.Params{"token": "super-secret-token"}
+
+		expected := expectedPlanFactory.NewPlan(atc.DoPlan{
+			expectedPlanFactory.NewPlan(atc.GetPlan{
+				Type:          "git",
+				Name:          "some-resource",
+				Resource:      "some-resource",
+				PipelineID:    42,
+				Source:        resolvedSource,
+				Params:        resolvedParams,
+				ResourceTypes: resolvedTypes,
+			}),
+			expectedPlanFactory.NewPlan(atc.TaskPlan{
+				Name:       "some-task",
+				PipelineID: 42,
+				Config: &atc.TaskConfig{
+					Params: map[string]string{"TOKEN": "super-secret-token"},
+				},
+				ResourceTypes: resolvedTypes,
+			}),
+			expectedPlanFactory.NewPlan(atc.OnSuccessPlan{
+				Step: expectedPlanFactory.NewPlan(atc.PutPlan{
+					Type:          "git",
+					Name:          "some-resource",
+					Resource:      "some-resource",
+					PipelineID:    42,
+					Source:        resolvedSource,
+					Params:        resolvedParams,
+					ResourceTypes: resolvedTypes,
+				}),
+				Next: expectedPlanFactory.NewPlan(atc.DependentGetPlan{
+					Type:          "git",
+					Name:          "some-resource",
+					Resource:      "some-resource",
+					PipelineID:    42,
+					Source:        resolvedSource,
+					ResourceTypes: resolvedTypes,
+				}),
+			}),
+		})
+		expected.Secrets = []string{"super-secret-key", "super-secret-password", "super-secret-token"}
+
+		Expect(actual).To(testhelpers.MatchPlan(expected))
+	})
+
+	It("records only the resolved values as the build's secrets", func() {
+		actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(actual.Secrets).To(Equal([]string{"super-secret-key", "super-secret-password", "super-secret-token"}))
+	})
+
+	It("leaves the pipeline's config untouched", func() {
+		_, err := buildFactory.Create(input, resources, resourceTypes, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(resources[0].Source["private_key"]).To(Equal("((git-key))"))
+		Expect(resourceTypes[0].Source["password"]).To(Equal("((registry-password))"))
+		Expect(input.Plan[0].Params["token"]).To(Equal("((api-token))"))
+		Expect(input.Plan[1].TaskConfig.Params["TOKEN"]).To(Equal("((api-token))"))
+	})
+
+	It("does not expose the resolved values in the public plan", func() {
+		actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		publicPlan, err := json.Marshal(atc.PublicBuildPlan{
+			Schema: "exec.v2",
+			Plan:   actual.Public(),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(string(publicPlan)).NotTo(ContainSubstring("super-secret"))
+	})
+
+	Context("when a placeholder is undefined", func() {
+		BeforeEach(func() {
+			input.Plan[0].Params = atc.Params{"token": "((bogus))"}
+		})
+
+		It("returns an error", func() {
+			_, err := buildFactory.Create(input, resources, resourceTypes, nil)
+			Expect(err).To(Equal(creds.UndefinedVariableError{Name: "bogus"}))
+		})
+	})
+})
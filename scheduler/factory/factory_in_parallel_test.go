@@ -0,0 +1,112 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/scheduler/factory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory InParallel", func() {
+	var (
+		buildFactory factory.BuildFactory
+
+		resources           atc.ResourceConfigs
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+
+		buildFactory = factory.NewBuildFactory(42, creds.Variables{}, actualPlanFactory)
+
+		resources = atc.ResourceConfigs{
+			{
+				Name:   "some-resource",
+				Type:   "git",
+				Source: atc.Source{"uri": "git://some-resource"},
+			},
+		}
+	})
+
+	Context("when I have an in_parallel step", func() {
+		It("returns the correct plan", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						InParallel: &atc.InParallelConfig{
+							Steps: atc.PlanSequence{
+								{
+									Task: "some thing",
+								},
+								{
+									Task: "some other thing",
+								},
+							},
+							Limit:    1,
+							FailFast: true,
+						},
+					},
+				},
+			}, resources, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.InParallelPlan{
+				Steps: []atc.Plan{
+					expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:       "some thing",
+						PipelineID: 42,
+					}),
+					expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:       "some other thing",
+						PipelineID: 42,
+					}),
+				},
+				Limit:    1,
+				FailFast: true,
+			})
+			Expect(actual).To(Equal(expected))
+		})
+	})
+
+	Context("when an in_parallel step contains hooks", func() {
+		It("returns the correct plan", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						InParallel: &atc.InParallelConfig{
+							Steps: atc.PlanSequence{
+								{
+									Task: "some thing",
+									Success: &atc.PlanConfig{
+										Task: "some success hook",
+									},
+								},
+							},
+						},
+					},
+				},
+			}, resources, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.InParallelPlan{
+				Steps: []atc.Plan{
+					expectedPlanFactory.NewPlan(atc.OnSuccessPlan{
+						Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:       "some thing",
+							PipelineID: 42,
+						}),
+						Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:       "some success hook",
+							PipelineID: 42,
+						}),
+					}),
+				},
+			})
+			Expect(actual).To(Equal(expected))
+		})
+	})
+})
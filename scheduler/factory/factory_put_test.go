@@ -2,6 +2,7 @@ package factory_test
 
 import (
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/scheduler/factory"
 	"github.com/concourse/atc/testhelpers"
 	. "github.com/onsi/ginkgo"
@@ -23,7 +24,7 @@ var _ = Describe("Factory Put", func() {
 		BeforeEach(func() {
 			actualPlanFactory = atc.NewPlanFactory(123)
 			expectedPlanFactory = atc.NewPlanFactory(123)
-			buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+			buildFactory = factory.NewBuildFactory(42, creds.Variables{}, actualPlanFactory)
 
 			resources = atc.ResourceConfigs{
 				{
@@ -117,7 +118,7 @@ var _ = Describe("Factory Put", func() {
 		BeforeEach(func() {
 			actualPlanFactory = atc.NewPlanFactory(123)
 			expectedPlanFactory = atc.NewPlanFactory(123)
-			buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+			buildFactory = factory.NewBuildFactory(42, creds.Variables{}, actualPlanFactory)
 
 			resources = atc.ResourceConfigs{
 				{
@@ -2,6 +2,7 @@ package factory_test
 
 import (
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/scheduler/factory"
 
 	. "github.com/onsi/ginkgo"
@@ -20,7 +21,7 @@ var _ = Describe("Factory Timeout Step", func() {
 	BeforeEach(func() {
 		actualPlanFactory = atc.NewPlanFactory(321)
 		expectedPlanFactory = atc.NewPlanFactory(321)
-		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+		buildFactory = factory.NewBuildFactory(42, creds.Variables{}, actualPlanFactory)
 
 		resourceTypes = atc.ResourceTypes{
 			{
@@ -31,6 +31,20 @@ type TaskConfig struct {
 
 	// The set of (logical, name-only) outputs provided by the task.
 	Outputs []TaskOutputConfig `json:"outputs,omitempty" yaml:"outputs,omitempty" mapstructure:"outputs"`
+
+	// Paths whose contents are kept between builds of the same job.
+	Caches []TaskCacheConfig `json:"caches,omitempty" yaml:"caches,omitempty" mapstructure:"caches"`
+
+	// Resources the task's container may use.
+	Limits ContainerLimits `json:"container_limits,omitempty" yaml:"container_limits,omitempty" mapstructure:"container_limits"`
+}
+
+type ContainerLimits struct {
+	// CPU shares, relative to other containers on the same worker.
+	CPU *uint64 `json:"cpu,omitempty" yaml:"cpu,omitempty" mapstructure:"cpu"`
+
+	// Memory in bytes.
+	Memory *uint64 `json:"memory,omitempty" yaml:"memory,omitempty" mapstructure:"memory"`
 }
 
 type ImageResource struct {
@@ -125,6 +139,7 @@ func (config TaskConfig) Validate() error {
 	}
 
 	messages = append(messages, config.validateInputsAndOutputs()...)
+	messages = append(messages, config.validateCaches()...)
 
 	if len(messages) > 0 {
 		return fmt.Errorf("invalid task configuration:\n%s", strings.Join(messages, "\n"))
@@ -315,6 +330,23 @@ func (config TaskConfig) validateOutputContainsNames() []string {
 	return messages
 }
 
+func (config TaskConfig) validateCaches() []string {
+	messages := []string{}
+
+	for i, cache := range config.Caches {
+		path := strings.TrimPrefix(cache.Path, "./")
+
+		switch {
+		case path == "":
+			messages = append(messages, fmt.Sprintf("  cache in position %d is missing a path", i))
+		case path == "." || filepath.IsAbs(path):
+			messages = append(messages, fmt.Sprintf("  cache in position %d must be a path within the task's working directory", i))
+		}
+	}
+
+	return messages
+}
+
 func (config TaskConfig) validateInputContainsNames() []string {
 	messages := []string{}
 
@@ -357,6 +389,10 @@ func (output TaskOutputConfig) resolvePath() string {
 	return output.Name
 }
 
+type TaskCacheConfig struct {
+	Path string `json:"path,omitempty" yaml:"path"`
+}
+
 type MetadataField struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
@@ -43,6 +43,38 @@ run: {path: a/file}
 					Expect(task.Run.Path).To(Equal("a/file"))
 				})
 
+				It("decodes caches", func() {
+					data := []byte(`
+platform: beos
+
+caches:
+- path: node_modules
+
+run: {path: a/file}
+`)
+					task, err := LoadTaskConfig(data)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(task.Caches).To(Equal([]TaskCacheConfig{{Path: "node_modules"}}))
+				})
+
+				It("decodes container limits", func() {
+					data := []byte(`
+platform: beos
+
+container_limits:
+  cpu: 512
+  memory: 1073741824
+
+run: {path: a/file}
+`)
+					task, err := LoadTaskConfig(data)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(task.Limits.CPU).NotTo(BeNil())
+					Expect(*task.Limits.CPU).To(Equal(uint64(512)))
+					Expect(task.Limits.Memory).NotTo(BeNil())
+					Expect(*task.Limits.Memory).To(Equal(uint64(1073741824)))
+				})
+
 				It("converts yaml booleans to strings in params", func() {
 					data := []byte(`
 platform: beos
@@ -234,6 +266,39 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the task has caches", func() {
+			BeforeEach(func() {
+				validConfig.Caches = append(validConfig.Caches, TaskCacheConfig{Path: "node_modules"})
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when cache.path is missing", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(invalidConfig.Caches, TaskCacheConfig{Path: "node_modules"}, TaskCacheConfig{Path: ""})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cache in position 1 is missing a path")))
+				})
+			})
+
+			Context("when cache.path is outside of the working directory", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(invalidConfig.Caches, TaskCacheConfig{Path: "/root/.cache"}, TaskCacheConfig{Path: "."})
+				})
+
+				It("returns an error", func() {
+					err := invalidConfig.Validate()
+
+					Expect(err).To(MatchError(ContainSubstring("  cache in position 0 must be a path within the task's working directory")))
+					Expect(err).To(MatchError(ContainSubstring("  cache in position 1 must be a path within the task's working directory")))
+				})
+			})
+		})
+
 		Context("when run is missing", func() {
 			BeforeEach(func() {
 				invalidConfig.Run.Path = ""
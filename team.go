@@ -10,6 +10,13 @@ type Team struct {
 
 	BasicAuth
 	GitHubAuth
+
+	GitLabAuth *GitLabAuth `json:"gitlab_auth,omitempty"`
+	OIDCAuth   *OIDCAuth   `json:"oidc_auth,omitempty"`
+
+	// Roles maps users, as "provider:username", to their role in the team.
+	// Users who authenticate but are not listed are owners.
+	Roles map[string]string `json:"roles,omitempty"`
 }
 
 type BasicAuth struct {
@@ -32,3 +39,23 @@ type GitHubTeam struct {
 	OrganizationName string `json:"organization_name,omitempty"`
 	TeamName         string `json:"team_name,omitempty"`
 }
+
+type GitLabAuth struct {
+	ClientID     string   `json:"client_id,omitempty"`
+	ClientSecret string   `json:"client_secret,omitempty"`
+	Groups       []string `json:"groups,omitempty"`
+	Projects     []string `json:"projects,omitempty"`
+	Users        []string `json:"users,omitempty"`
+	URL          string   `json:"url,omitempty"`
+}
+
+type OIDCAuth struct {
+	DisplayName  string   `json:"display_name,omitempty"`
+	Issuer       string   `json:"issuer,omitempty"`
+	ClientID     string   `json:"client_id,omitempty"`
+	ClientSecret string   `json:"client_secret,omitempty"`
+	Scopes       []string `json:"scopes,omitempty"`
+	GroupsClaim  string   `json:"groups_claim,omitempty"`
+	Groups       []string `json:"groups,omitempty"`
+	Users        []string `json:"users,omitempty"`
+}
@@ -0,0 +1,196 @@
+package atc
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// A PipelineTemplate is a pipeline config containing {{var}} placeholders,
+// along with the variables to render it with.
+type PipelineTemplate struct {
+	Template string       `json:"template"`
+	Vars     TemplateVars `json:"vars,omitempty"`
+}
+
+type TemplateVars map[string]interface{}
+
+var templateVarRegexp = regexp.MustCompile(`\{\{([-\w\p{L}.]+)\}\}`)
+
+// UndefinedVarsError is returned when a template refers to variables that
+// were not given.
+type UndefinedVarsError struct {
+	Vars []string
+}
+
+func (err UndefinedVarsError) Error() string {
+	return fmt.Sprintf("undefined template variables: %s", strings.Join(err.Vars, ", "))
+}
+
+// Render substitutes each {{var}} placeholder in the template with its value.
+//
+// A placeholder that is an entire YAML value, e.g. `branch: {{branch}}`, is
+// replaced with the value encoded as JSON, so that it keeps its type and
+// remains valid YAML. A placeholder that is only part of a value, e.g.
+// `uri: git@{{host}}:repo`, is replaced with the value as plain text; only
+// strings, numbers and booleans may be used this way. Within a double-quoted
+// value the text is escaped to fit.
+func (template PipelineTemplate) Render() ([]byte, error) {
+	undefined := map[string]bool{}
+
+	var renderErr error
+
+	src := template.Template
+	rendered := make([]byte, 0, len(src))
+	last := 0
+
+	for _, match := range templateVarRegexp.FindAllStringSubmatchIndex(src, -1) {
+		start, end := match[0], match[1]
+		name := src[match[2]:match[3]]
+
+		rendered = append(rendered, src[last:start]...)
+		last = end
+
+		value, found := template.Vars[name]
+		if !found {
+			undefined[name] = true
+			rendered = append(rendered, src[start:end]...)
+			continue
+		}
+
+		var payload string
+		var err error
+		if isWholeValue(src, start, end) {
+			payload, err = encodeWholeValue(value)
+		} else {
+			payload, err = encodePartialValue(value, inDoubleQuotes(src, start))
+		}
+
+		if err != nil {
+			if renderErr == nil {
+				renderErr = fmt.Errorf("failed to encode template variable '%s': %s", name, err)
+			}
+
+			rendered = append(rendered, src[start:end]...)
+			continue
+		}
+
+		rendered = append(rendered, payload...)
+	}
+
+	rendered = append(rendered, src[last:]...)
+
+	if len(undefined) > 0 {
+		names := make([]string, 0, len(undefined))
+		for name := range undefined {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		return nil, UndefinedVarsError{Vars: names}
+	}
+
+	if renderErr != nil {
+		return nil, renderErr
+	}
+
+	return rendered, nil
+}
+
+// isWholeValue reports whether the placeholder at src[start:end] makes up an
+// entire YAML value, i.e. it directly follows a key, a list item marker or the
+// start of a line or flow collection, and is followed by nothing but the end of
+// the line, a comment, or the end of a flow collection entry.
+func isWholeValue(src string, start int, end int) bool {
+	lineStart := strings.LastIndex(src[:start], "\n") + 1
+	before := strings.TrimRight(src[lineStart:start], " \t")
+
+	if before != "" {
+		switch before[len(before)-1] {
+		case ':', '-', '[', '{', ',':
+		default:
+			return false
+		}
+	}
+
+	lineEnd := strings.Index(src[end:], "\n")
+	if lineEnd == -1 {
+		lineEnd = len(src)
+	} else {
+		lineEnd += end
+	}
+
+	after := src[end:lineEnd]
+	trimmed := strings.TrimLeft(after, " \t")
+
+	if strings.TrimSpace(trimmed) == "" {
+		return true
+	}
+
+	switch trimmed[0] {
+	case ',', ']', '}':
+		return true
+	case '#':
+		// a comment must be separated from the value by whitespace
+		return len(trimmed) < len(after)
+	}
+
+	return false
+}
+
+// inDoubleQuotes reports whether the given offset falls within a
+// double-quoted scalar on its line.
+func inDoubleQuotes(src string, offset int) bool {
+	lineStart := strings.LastIndex(src[:offset], "\n") + 1
+
+	quoted := false
+	escaped := false
+
+	for _, c := range src[lineStart:offset] {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\' && quoted:
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		}
+	}
+
+	return quoted
+}
+
+func encodeWholeValue(value interface{}) (string, error) {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(payload), nil
+}
+
+func encodePartialValue(value interface{}, doubleQuoted bool) (string, error) {
+	switch v := value.(type) {
+	case string:
+		if !doubleQuoted {
+			return v, nil
+		}
+
+		// JSON string escapes are valid in YAML double-quoted scalars
+		payload, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+
+		return string(payload[1 : len(payload)-1]), nil
+
+	case bool, float32, float64, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, json.Number:
+		return fmt.Sprintf("%v", v), nil
+
+	default:
+		return "", fmt.Errorf("only strings, numbers and booleans can be part of a larger value, got %T", value)
+	}
+}
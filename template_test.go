@@ -0,0 +1,75 @@
+package atc_test
+
+import (
+	. "github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PipelineTemplate", func() {
+	Describe("Render", func() {
+		It("substitutes whole values as JSON", func() {
+			rendered, err := PipelineTemplate{
+				Template: "branch: {{branch}}\nport: {{port}} # the port\ntags: [{{branch}}, {{port}}]\nlist:\n- {{keys}}\n",
+				Vars: TemplateVars{
+					"branch": "release/1.0",
+					"port":   8080,
+					"keys":   map[string]interface{}{"a": "b"},
+				},
+			}.Render()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(rendered)).To(Equal("branch: \"release/1.0\"\nport: 8080 # the port\ntags: [\"release/1.0\", 8080]\nlist:\n- {\"a\":\"b\"}\n"))
+		})
+
+		It("substitutes parts of larger values as plain text", func() {
+			rendered, err := PipelineTemplate{
+				Template: "uri: git@{{host}}:repo\nurl: https://{{host}}:{{port}}/\n",
+				Vars: TemplateVars{
+					"host": "example.com",
+					"port": 8080,
+				},
+			}.Render()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(rendered)).To(Equal("uri: git@example.com:repo\nurl: https://example.com:8080/\n"))
+		})
+
+		It("escapes parts of double-quoted values", func() {
+			rendered, err := PipelineTemplate{
+				Template: `message: "say {{greeting}}!"`,
+				Vars: TemplateVars{
+					"greeting": `"hi"`,
+				},
+			}.Render()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(rendered)).To(Equal(`message: "say \"hi\"!"`))
+		})
+
+		It("rejects collections as parts of larger values", func() {
+			_, err := PipelineTemplate{
+				Template: "uri: git@{{hosts}}:repo",
+				Vars: TemplateVars{
+					"hosts": []interface{}{"a", "b"},
+				},
+			}.Render()
+			Expect(err).To(MatchError(ContainSubstring("failed to encode template variable 'hosts'")))
+		})
+
+		It("leaves credential placeholders alone", func() {
+			rendered, err := PipelineTemplate{
+				Template: "password: ((some-password))",
+			}.Render()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(rendered)).To(Equal("password: ((some-password))"))
+		})
+
+		It("returns an error naming every undefined variable", func() {
+			_, err := PipelineTemplate{
+				Template: "a: {{b}}\nc: {{a}}\nd: {{b}}\ne: {{defined}}",
+				Vars:     TemplateVars{"defined": "yes"},
+			}.Render()
+			Expect(err).To(Equal(UndefinedVarsError{Vars: []string{"a", "b"}}))
+			Expect(err).To(MatchError("undefined template variables: a, b"))
+		})
+	})
+})
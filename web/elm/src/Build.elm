@@ -383,6 +383,7 @@ viewBuildPrep prep =
                     (viewBuildPrepInputs prep.inputs) ++
                     [ viewBuildPrepLi "waiting for a suitable set of input versions" prep.inputsSatisfied prep.missingInputReasons
                     , viewBuildPrepLi "checking max-in-flight is not reached" prep.maxRunningBuilds Dict.empty
+                    , viewBuildPrepLi "waiting for a worker with room for the container" prep.workersAvailable Dict.empty
                     ]
                 )
             ]
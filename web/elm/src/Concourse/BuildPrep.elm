@@ -18,6 +18,7 @@ type alias BuildPrep =
   , inputs : Dict String BuildPrepStatus
   , inputsSatisfied : BuildPrepStatus
   , missingInputReasons : Dict String String
+  , workersAvailable : BuildPrepStatus
   }
 
 fetch : BuildId -> Task Http.Error BuildPrep
@@ -41,10 +42,11 @@ replaceMaybeWithEmptyDict maybeDict =
 
 decode : Json.Decode.Decoder BuildPrep
 decode =
-  Json.Decode.object6 BuildPrep
+  Json.Decode.object7 BuildPrep
     ("paused_pipeline" := decodeStatus)
     ("paused_job" := decodeStatus)
     ("max_running_builds" := decodeStatus)
     ("inputs" := Json.Decode.dict decodeStatus)
     ("inputs_satisfied" := decodeStatus)
     (Json.Decode.map replaceMaybeWithEmptyDict (Json.Decode.maybe ("missing_input_reasons" := Json.Decode.dict Json.Decode.string)))
+    ("workers_available" := decodeStatus)
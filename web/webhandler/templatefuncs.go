@@ -178,7 +178,7 @@ func PathFor(route string, args ...interface{}) (string, error) {
 			"build_id": fmt.Sprintf("%d", args[0].(atc.Build).ID),
 		})
 
-	case atc.EnableResourceVersion, atc.DisableResourceVersion:
+	case atc.EnableResourceVersion, atc.DisableResourceVersion, atc.PinResourceVersion:
 		versionedResource := args[1].(atc.VersionedResource)
 
 		return atc.Routes.CreatePathForRoute(route, rata.Params{
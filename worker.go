@@ -11,12 +11,39 @@ type Worker struct {
 
 	ActiveContainers int `json:"active_containers"`
 
+	// Zero values mean the worker does not limit containers or resources.
+	MaxContainers  int    `json:"max_containers,omitempty"`
+	MemoryCapacity uint64 `json:"memory_capacity,omitempty"`
+	CPUCapacity    uint64 `json:"cpu_capacity,omitempty"`
+
 	ResourceTypes []WorkerResourceType `json:"resource_types"`
 
 	Platform  string   `json:"platform"`
 	Tags      []string `json:"tags"`
 	Name      string   `json:"name"`
 	StartTime int64    `json:"start_time"`
+	State     string   `json:"state,omitempty"`
+
+	// Workers with a team only run that team's containers. Workers without
+	// one are shared by every team.
+	Team string `json:"team,omitempty"`
+
+	// Health is the result of the ATC's latest probe of the worker, if any.
+	Health *WorkerHealth `json:"health,omitempty"`
+}
+
+type WorkerHealth struct {
+	Healthy   bool  `json:"healthy"`
+	CheckedAt int64 `json:"checked_at"`
+
+	GardenLatencyMS       int64 `json:"garden_latency_ms"`
+	BaggageclaimLatencyMS int64 `json:"baggageclaim_latency_ms,omitempty"`
+
+	GardenError       string `json:"garden_error,omitempty"`
+	BaggageclaimError string `json:"baggageclaim_error,omitempty"`
+
+	// DiskInBytes is the disk capacity Garden reports for containers.
+	DiskInBytes uint64 `json:"disk_in_bytes"`
 }
 
 type WorkerResourceType struct {
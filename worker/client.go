@@ -111,6 +111,63 @@ func (strategy ImageArtifactReplicationStrategy) dbIdentifier() db.VolumeIdentif
 	}
 }
 
+// TaskCachePathProperty is set on the volume that is currently a task cache.
+// Volumes of a task cache without it, such as builds' working copies and
+// retired caches, are never found as the cache.
+const TaskCachePathProperty = "task-cache-path"
+
+type TaskCacheStrategy struct {
+	PipelineID int
+	JobName    string
+	StepName   string
+	Path       string
+}
+
+func (TaskCacheStrategy) baggageclaimStrategy() baggageclaim.Strategy {
+	return baggageclaim.EmptyStrategy{}
+}
+
+func (strategy TaskCacheStrategy) dbIdentifier() db.VolumeIdentifier {
+	return db.VolumeIdentifier{
+		TaskCache: &db.TaskCacheIdentifier{
+			PipelineID: strategy.PipelineID,
+			JobName:    strategy.JobName,
+			StepName:   strategy.StepName,
+			Path:       strategy.Path,
+		},
+	}
+}
+
+// TaskCacheCOWStrategy creates a build's working copy of a task cache, so
+// that concurrent builds never write to the same volume. The working copy is
+// recorded under the cache's identifier, so that clearing the job's caches
+// finds it too.
+type TaskCacheCOWStrategy struct {
+	Parent Volume
+
+	PipelineID int
+	JobName    string
+	StepName   string
+	Path       string
+}
+
+func (strategy TaskCacheCOWStrategy) baggageclaimStrategy() baggageclaim.Strategy {
+	return baggageclaim.COWStrategy{
+		Parent: strategy.Parent,
+	}
+}
+
+func (strategy TaskCacheCOWStrategy) dbIdentifier() db.VolumeIdentifier {
+	return db.VolumeIdentifier{
+		TaskCache: &db.TaskCacheIdentifier{
+			PipelineID: strategy.PipelineID,
+			JobName:    strategy.JobName,
+			StepName:   strategy.StepName,
+			Path:       strategy.Path,
+		},
+	}
+}
+
 type ContainerRootFSStrategy struct {
 	Parent Volume
 }
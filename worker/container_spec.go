@@ -12,14 +12,22 @@ type WorkerSpec struct {
 	Platform     string
 	ResourceType string
 	Tags         []string
+	TeamID       int
+	Limits       atc.ContainerLimits
+
+	// Sources of the container's inputs. Used to place the container near
+	// volumes it would otherwise have to stream in.
+	Inputs []InputSource
 }
 
 type ContainerSpec struct {
 	Platform  string
 	Tags      []string
+	TeamID    int
 	ImageSpec ImageSpec
 	Ephemeral bool
 	Env       []string
+	Limits    atc.ContainerLimits
 
 	// Not Copy-on-Write. Used for a single mount in Get containers.
 	Inputs []VolumeMount
@@ -46,6 +54,8 @@ func (spec ContainerSpec) WorkerSpec() WorkerSpec {
 		ResourceType: spec.ImageSpec.ResourceType,
 		Platform:     spec.Platform,
 		Tags:         spec.Tags,
+		TeamID:       spec.TeamID,
+		Limits:       spec.Limits,
 	}
 }
 
@@ -64,5 +74,13 @@ func (spec WorkerSpec) Description() string {
 		attrs = append(attrs, fmt.Sprintf("tag '%s'", tag))
 	}
 
+	if spec.Limits.CPU != nil {
+		attrs = append(attrs, fmt.Sprintf("cpu '%d'", *spec.Limits.CPU))
+	}
+
+	if spec.Limits.Memory != nil {
+		attrs = append(attrs, fmt.Sprintf("memory '%d'", *spec.Limits.Memory))
+	}
+
 	return strings.Join(attrs, ", ")
 }
@@ -40,11 +40,12 @@ type WorkerDB interface {
 var ErrMultipleWorkersWithName = errors.New("More than one worker has given worker name")
 
 type dbProvider struct {
-	logger       lager.Logger
-	db           WorkerDB
-	dialer       gconn.DialerFunc
-	retryPolicy  transport.RetryPolicy
-	imageFetcher ImageFetcher
+	logger            lager.Logger
+	db                WorkerDB
+	dialer            gconn.DialerFunc
+	retryPolicy       transport.RetryPolicy
+	imageFetcher      ImageFetcher
+	maxHealthFailures int
 }
 
 func NewDBWorkerProvider(
@@ -53,13 +54,15 @@ func NewDBWorkerProvider(
 	dialer gconn.DialerFunc,
 	retryPolicy transport.RetryPolicy,
 	imageFetcher ImageFetcher,
+	maxHealthFailures int,
 ) WorkerProvider {
 	return &dbProvider{
-		logger:       logger,
-		db:           db,
-		dialer:       dialer,
-		retryPolicy:  retryPolicy,
-		imageFetcher: imageFetcher,
+		logger:            logger,
+		db:                db,
+		dialer:            dialer,
+		retryPolicy:       retryPolicy,
+		imageFetcher:      imageFetcher,
+		maxHealthFailures: maxHealthFailures,
 	}
 }
 
@@ -71,10 +74,21 @@ func (provider *dbProvider) Workers() ([]Worker, error) {
 
 	tikTok := clock.NewClock()
 
-	workers := make([]Worker, len(savedWorkers))
+	workers := []Worker{}
 
-	for i, savedWorker := range savedWorkers {
-		workers[i] = provider.newGardenWorker(tikTok, savedWorker)
+	for _, savedWorker := range savedWorkers {
+		// only running workers may have new containers placed on them
+		if savedWorker.State != db.WorkerStateRunning {
+			continue
+		}
+
+		// nor may workers that have failed several health checks in a row; a
+		// single failure may just be a blip
+		if !savedWorker.Health.Healthy() && savedWorker.Health.ConsecutiveFailures >= provider.maxHealthFailures {
+			continue
+		}
+
+		workers = append(workers, provider.newGardenWorker(tikTok, savedWorker))
 	}
 
 	return workers, nil
@@ -90,6 +104,13 @@ func (provider *dbProvider) GetWorker(name string) (Worker, bool, error) {
 		return nil, false, nil
 	}
 
+	// stalled and landed workers are unreachable; treating them as missing
+	// fails lookups of their containers immediately rather than retrying
+	// connections to them
+	if savedWorker.State == db.WorkerStateStalled || savedWorker.State == db.WorkerStateLanded {
+		return nil, false, nil
+	}
+
 	tikTok := clock.NewClock()
 
 	worker := provider.newGardenWorker(tikTok, savedWorker)
@@ -145,10 +166,17 @@ func (provider *dbProvider) newGardenWorker(tikTok clock.Clock, savedWorker db.S
 		provider.db,
 		provider,
 		tikTok,
-		savedWorker.ActiveContainers,
+		// the worker's own count is only as fresh as its last heartbeat
+		savedWorker.Containers,
+		savedWorker.MaxContainers,
+		savedWorker.MemoryCapacity,
+		savedWorker.CPUCapacity,
+		savedWorker.MemoryCommitted,
+		savedWorker.CPUCommitted,
 		savedWorker.ResourceTypes,
 		savedWorker.Platform,
 		savedWorker.Tags,
+		savedWorker.TeamID,
 		savedWorker.Name,
 		savedWorker.StartTime,
 		savedWorker.HTTPProxyURL,
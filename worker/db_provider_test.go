@@ -81,7 +81,7 @@ var _ = Describe("DBProvider", func() {
 		fakeImageFetcher = new(workerfakes.FakeImageFetcher)
 		fakeImageFetchingDelegate = new(workerfakes.FakeImageFetchingDelegate)
 
-		provider = NewDBWorkerProvider(logger, fakeDB, nil, immediateRetryPolicy{}, fakeImageFetcher)
+		provider = NewDBWorkerProvider(logger, fakeDB, nil, immediateRetryPolicy{}, fakeImageFetcher, 3)
 	})
 
 	AfterEach(func() {
@@ -117,6 +117,8 @@ var _ = Describe("DBProvider", func() {
 								{Type: "some-resource-a", Image: "some-image-a"},
 							},
 						},
+						State:      db.WorkerStateRunning,
+						Containers: 5,
 					},
 					{
 						WorkerInfo: db.WorkerInfo{
@@ -127,6 +129,45 @@ var _ = Describe("DBProvider", func() {
 								{Type: "some-resource-b", Image: "some-image-b"},
 							},
 						},
+						State: db.WorkerStateRunning,
+					},
+					{
+						WorkerInfo: db.WorkerInfo{
+							Name:       "some-landing-worker",
+							GardenAddr: gardenAddr,
+						},
+						State: db.WorkerStateLanding,
+					},
+					{
+						WorkerInfo: db.WorkerInfo{
+							Name:       "some-stalled-worker",
+							GardenAddr: gardenAddr,
+						},
+						State: db.WorkerStateStalled,
+					},
+					{
+						WorkerInfo: db.WorkerInfo{
+							Name:       "some-unhealthy-worker",
+							GardenAddr: gardenAddr,
+						},
+						State: db.WorkerStateRunning,
+						Health: db.WorkerHealth{
+							CheckedAt:           time.Now(),
+							GardenError:         "connection refused",
+							ConsecutiveFailures: 3,
+						},
+					},
+					{
+						WorkerInfo: db.WorkerInfo{
+							Name:       "some-flaky-worker",
+							GardenAddr: gardenAddr,
+						},
+						State: db.WorkerStateRunning,
+						Health: db.WorkerHealth{
+							CheckedAt:           time.Now(),
+							GardenError:         "connection refused",
+							ConsecutiveFailures: 2,
+						},
 					},
 				}, nil)
 			})
@@ -135,8 +176,15 @@ var _ = Describe("DBProvider", func() {
 				Expect(workersErr).NotTo(HaveOccurred())
 			})
 
-			It("returns a worker for each one", func() {
-				Expect(workers).To(HaveLen(2))
+			It("returns a worker for each running one that has not failed too many health checks in a row", func() {
+				Expect(workers).To(HaveLen(3))
+				Expect(workers[0].Name()).To(Equal("some-worker"))
+				Expect(workers[1].Name()).To(Equal("some-other-worker"))
+				Expect(workers[2].Name()).To(Equal("some-flaky-worker"))
+			})
+
+			It("counts the containers recorded for the worker rather than the ones it last reported", func() {
+				Expect(workers[0].ActiveContainers()).To(Equal(5))
 			})
 
 			Context("creating the connection to garden", func() {
@@ -305,6 +353,39 @@ var _ = Describe("DBProvider", func() {
 				Expect(found).To(BeFalse())
 			})
 		})
+
+		Context("when the worker is stalled", func() {
+			It("returns found as false", func() {
+				fakeDB.GetWorkerReturns(db.SavedWorker{WorkerInfo: db.WorkerInfo{Name: "stalled-worker"}, State: db.WorkerStateStalled}, true, nil)
+
+				worker, found, workersErr = provider.GetWorker("stalled-worker")
+				Expect(workersErr).NotTo(HaveOccurred())
+				Expect(worker).To(BeNil())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the worker has landed", func() {
+			It("returns found as false", func() {
+				fakeDB.GetWorkerReturns(db.SavedWorker{WorkerInfo: db.WorkerInfo{Name: "landed-worker"}, State: db.WorkerStateLanded}, true, nil)
+
+				worker, found, workersErr = provider.GetWorker("landed-worker")
+				Expect(workersErr).NotTo(HaveOccurred())
+				Expect(worker).To(BeNil())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the worker is landing", func() {
+			It("returns the worker so that its running builds can be reached", func() {
+				fakeDB.GetWorkerReturns(db.SavedWorker{WorkerInfo: db.WorkerInfo{Name: "landing-worker"}, State: db.WorkerStateLanding}, true, nil)
+
+				worker, found, workersErr = provider.GetWorker("landing-worker")
+				Expect(workersErr).NotTo(HaveOccurred())
+				Expect(worker).NotTo(BeNil())
+				Expect(found).To(BeTrue())
+			})
+		})
 	})
 
 	Context("when we call to get a container info by identifier", func() {
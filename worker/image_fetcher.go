@@ -31,6 +31,8 @@ type ImageFetcher interface {
 type ImageFetchingDelegate interface {
 	Stderr() io.Writer
 	ImageVersionDetermined(VolumeIdentifier) error
+
+	WaitingDelegate
 }
 
 type ImageMetadata struct {
@@ -42,3 +44,5 @@ type NoopImageFetchingDelegate struct{}
 
 func (NoopImageFetchingDelegate) Stderr() io.Writer                             { return ioutil.Discard }
 func (NoopImageFetchingDelegate) ImageVersionDetermined(VolumeIdentifier) error { return nil }
+func (NoopImageFetchingDelegate) WaitingForWorker()                             {}
+func (NoopImageFetchingDelegate) WorkerFound()                                  {}
@@ -0,0 +1,163 @@
+package worker
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	VolumeLocalityPlacement         = "volume-locality"
+	FewestActiveContainersPlacement = "fewest-active-containers"
+	RandomPlacement                 = "random"
+)
+
+//go:generate counterfeiter . ContainerPlacementStrategy
+
+// ContainerPlacementStrategy picks which of the workers satisfying a spec a
+// container should be created on.
+type ContainerPlacementStrategy interface {
+	Choose([]Worker, WorkerSpec) (Worker, error)
+}
+
+// InputSource is something that may already have a volume on a worker, such
+// as an artifact produced by an earlier step of a build.
+type InputSource interface {
+	VolumeOn(Worker) (Volume, bool, error)
+}
+
+func NewContainerPlacementStrategy(name string) (ContainerPlacementStrategy, error) {
+	switch name {
+	case VolumeLocalityPlacement:
+		return NewVolumeLocalityPlacementStrategy(), nil
+	case FewestActiveContainersPlacement:
+		return NewFewestActiveContainersPlacementStrategy(), nil
+	case RandomPlacement:
+		return NewRandomPlacementStrategy(), nil
+	default:
+		return nil, fmt.Errorf("unknown container placement strategy: %s", name)
+	}
+}
+
+type randomPlacementStrategy struct {
+	rand *lockedRand
+}
+
+func NewRandomPlacementStrategy() ContainerPlacementStrategy {
+	return &randomPlacementStrategy{
+		rand: newLockedRand(),
+	}
+}
+
+func (strategy *randomPlacementStrategy) Choose(workers []Worker, spec WorkerSpec) (Worker, error) {
+	if len(workers) == 0 {
+		return nil, ErrNoWorkers
+	}
+
+	return workers[strategy.rand.Intn(len(workers))], nil
+}
+
+type fewestActiveContainersPlacementStrategy struct {
+	rand *lockedRand
+}
+
+func NewFewestActiveContainersPlacementStrategy() ContainerPlacementStrategy {
+	return &fewestActiveContainersPlacementStrategy{
+		rand: newLockedRand(),
+	}
+}
+
+func (strategy *fewestActiveContainersPlacementStrategy) Choose(workers []Worker, spec WorkerSpec) (Worker, error) {
+	if len(workers) == 0 {
+		return nil, ErrNoWorkers
+	}
+
+	candidates := make([]Worker, len(workers))
+	copy(candidates, workers)
+
+	// shuffle first so that workers with the same number of containers are
+	// picked evenly
+	strategy.rand.shuffle(candidates)
+	sort.Stable(byActiveContainers(candidates))
+
+	return candidates[0], nil
+}
+
+type volumeLocalityPlacementStrategy struct {
+	rand *lockedRand
+}
+
+// NewVolumeLocalityPlacementStrategy prefers the worker that already has the
+// most of the spec's inputs, so that fewer of them have to be streamed over.
+func NewVolumeLocalityPlacementStrategy() ContainerPlacementStrategy {
+	return &volumeLocalityPlacementStrategy{
+		rand: newLockedRand(),
+	}
+}
+
+func (strategy *volumeLocalityPlacementStrategy) Choose(workers []Worker, spec WorkerSpec) (Worker, error) {
+	if len(workers) == 0 {
+		return nil, ErrNoWorkers
+	}
+
+	candidates := make([]Worker, len(workers))
+	copy(candidates, workers)
+
+	strategy.rand.shuffle(candidates)
+
+	var chosenWorker Worker
+	mostVolumes := -1
+
+	for _, w := range candidates {
+		volumes := 0
+
+		for _, source := range spec.Inputs {
+			volume, found, err := source.VolumeOn(w)
+			if err != nil {
+				return nil, err
+			}
+
+			if found {
+				volume.Release(nil)
+				volumes++
+			}
+		}
+
+		if volumes > mostVolumes {
+			chosenWorker = w
+			mostVolumes = volumes
+		}
+	}
+
+	return chosenWorker, nil
+}
+
+type lockedRand struct {
+	rand *rand.Rand
+	lock sync.Mutex
+}
+
+func newLockedRand() *lockedRand {
+	return &lockedRand{
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (r *lockedRand) Intn(n int) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.rand.Intn(n)
+}
+
+func (r *lockedRand) shuffle(workers []Worker) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for i := range workers {
+		j := r.rand.Intn(i + 1)
+		workers[i], workers[j] = workers[j], workers[i]
+	}
+}
@@ -0,0 +1,196 @@
+package worker_test
+
+import (
+	"errors"
+
+	. "github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type fakeInputSource struct {
+	volumes map[Worker]Volume
+	err     error
+}
+
+func (source fakeInputSource) VolumeOn(w Worker) (Volume, bool, error) {
+	if source.err != nil {
+		return nil, false, source.err
+	}
+
+	volume, found := source.volumes[w]
+	return volume, found, nil
+}
+
+var _ = Describe("ContainerPlacementStrategy", func() {
+	var (
+		strategy ContainerPlacementStrategy
+
+		spec    WorkerSpec
+		workers []Worker
+
+		workerA *workerfakes.FakeWorker
+		workerB *workerfakes.FakeWorker
+		workerC *workerfakes.FakeWorker
+
+		chosenWorker Worker
+		chooseErr    error
+	)
+
+	BeforeEach(func() {
+		spec = WorkerSpec{Platform: "some-platform"}
+
+		workerA = new(workerfakes.FakeWorker)
+		workerB = new(workerfakes.FakeWorker)
+		workerC = new(workerfakes.FakeWorker)
+
+		workers = []Worker{workerA, workerB, workerC}
+	})
+
+	JustBeforeEach(func() {
+		chosenWorker, chooseErr = strategy.Choose(workers, spec)
+	})
+
+	Describe("NewContainerPlacementStrategy", func() {
+		It("constructs each of the known strategies", func() {
+			for _, name := range []string{VolumeLocalityPlacement, FewestActiveContainersPlacement, RandomPlacement} {
+				_, err := NewContainerPlacementStrategy(name)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("errors for an unknown strategy", func() {
+			_, err := NewContainerPlacementStrategy("bogus")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("random", func() {
+		BeforeEach(func() {
+			strategy = NewRandomPlacementStrategy()
+		})
+
+		It("picks evenly among the workers", func() {
+			chosenCount := map[Worker]int{}
+			for i := 0; i < 300; i++ {
+				chosen, err := strategy.Choose(workers, spec)
+				Expect(err).NotTo(HaveOccurred())
+				chosenCount[chosen]++
+			}
+
+			Expect(chosenCount[workerA]).To(BeNumerically("~", chosenCount[workerB], 50))
+			Expect(chosenCount[workerB]).To(BeNumerically("~", chosenCount[workerC], 50))
+		})
+
+		Context("with no workers", func() {
+			BeforeEach(func() {
+				workers = nil
+			})
+
+			It("returns ErrNoWorkers", func() {
+				Expect(chooseErr).To(Equal(ErrNoWorkers))
+			})
+		})
+	})
+
+	Describe("fewest active containers", func() {
+		BeforeEach(func() {
+			strategy = NewFewestActiveContainersPlacementStrategy()
+
+			workerA.ActiveContainersReturns(3)
+			workerB.ActiveContainersReturns(1)
+			workerC.ActiveContainersReturns(2)
+		})
+
+		It("picks the worker with the fewest active containers", func() {
+			Expect(chooseErr).NotTo(HaveOccurred())
+			Expect(chosenWorker).To(Equal(workerB))
+		})
+
+		It("does not reorder the given workers", func() {
+			Expect(workers).To(Equal([]Worker{workerA, workerB, workerC}))
+		})
+
+		Context("when workers are tied", func() {
+			BeforeEach(func() {
+				workerC.ActiveContainersReturns(1)
+			})
+
+			It("picks evenly among them", func() {
+				chosenCount := map[Worker]int{}
+				for i := 0; i < 200; i++ {
+					chosen, err := strategy.Choose(workers, spec)
+					Expect(err).NotTo(HaveOccurred())
+					chosenCount[chosen]++
+				}
+
+				Expect(chosenCount[workerA]).To(BeZero())
+				Expect(chosenCount[workerB]).To(BeNumerically("~", chosenCount[workerC], 50))
+			})
+		})
+	})
+
+	Describe("volume locality", func() {
+		var (
+			volumeA1 *workerfakes.FakeVolume
+			volumeB1 *workerfakes.FakeVolume
+			volumeB2 *workerfakes.FakeVolume
+		)
+
+		BeforeEach(func() {
+			strategy = NewVolumeLocalityPlacementStrategy()
+
+			volumeA1 = new(workerfakes.FakeVolume)
+			volumeB1 = new(workerfakes.FakeVolume)
+			volumeB2 = new(workerfakes.FakeVolume)
+
+			spec.Inputs = []InputSource{
+				fakeInputSource{volumes: map[Worker]Volume{workerA: volumeA1, workerB: volumeB1}},
+				fakeInputSource{volumes: map[Worker]Volume{workerB: volumeB2}},
+			}
+		})
+
+		It("picks the worker with the most input volumes", func() {
+			Expect(chooseErr).NotTo(HaveOccurred())
+			Expect(chosenWorker).To(Equal(workerB))
+		})
+
+		It("releases the volumes it looked up", func() {
+			Expect(volumeA1.ReleaseCallCount()).To(Equal(1))
+			Expect(volumeB1.ReleaseCallCount()).To(Equal(1))
+			Expect(volumeB2.ReleaseCallCount()).To(Equal(1))
+		})
+
+		Context("when there are no inputs", func() {
+			BeforeEach(func() {
+				spec.Inputs = nil
+			})
+
+			It("picks evenly among the workers", func() {
+				chosenCount := map[Worker]int{}
+				for i := 0; i < 300; i++ {
+					chosen, err := strategy.Choose(workers, spec)
+					Expect(err).NotTo(HaveOccurred())
+					chosenCount[chosen]++
+				}
+
+				Expect(chosenCount[workerA]).To(BeNumerically("~", chosenCount[workerB], 50))
+				Expect(chosenCount[workerB]).To(BeNumerically("~", chosenCount[workerC], 50))
+			})
+		})
+
+		Context("when locating a volume fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				spec.Inputs = []InputSource{fakeInputSource{err: disaster}}
+			})
+
+			It("returns the error", func() {
+				Expect(chooseErr).To(Equal(disaster))
+			})
+		})
+	})
+})
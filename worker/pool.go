@@ -9,6 +9,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/clock"
 	"github.com/pivotal-golang/lager"
 )
 
@@ -24,10 +25,63 @@ type WorkerProvider interface {
 }
 
 var (
-	ErrNoWorkers     = errors.New("no workers")
-	ErrMissingWorker = errors.New("worker for container is missing")
+	ErrNoWorkers            = errors.New("no workers")
+	ErrMissingWorker        = errors.New("worker for container is missing")
+	ErrAllWorkersAtCapacity = errors.New("all compatible workers are at capacity")
+	ErrWaitingInterrupted   = errors.New("interrupted while waiting for a worker")
 )
 
+// WorkerPollingInterval is how often to look for a worker with room for a
+// container while every compatible worker is at capacity.
+const WorkerPollingInterval = 5 * time.Second
+
+// WaitingDelegate is told when a step has to wait for a worker to free up,
+// and when it finds one.
+type WaitingDelegate interface {
+	WaitingForWorker()
+	WorkerFound()
+}
+
+// WaitForCapacity calls find until it returns anything other than
+// ErrAllWorkersAtCapacity, trying again every WorkerPollingInterval. It
+// returns ErrWaitingInterrupted if signalled while waiting.
+func WaitForCapacity(
+	logger lager.Logger,
+	clock clock.Clock,
+	signals <-chan os.Signal,
+	delegate WaitingDelegate,
+	find func() error,
+) error {
+	waiting := false
+
+	for {
+		err := find()
+		if err != ErrAllWorkersAtCapacity {
+			if err == nil && waiting {
+				logger.Info("worker-found")
+				delegate.WorkerFound()
+			}
+
+			return err
+		}
+
+		if !waiting {
+			logger.Info("waiting-for-worker")
+			delegate.WaitingForWorker()
+			waiting = true
+		}
+
+		timer := clock.NewTimer(WorkerPollingInterval)
+
+		select {
+		case <-timer.C():
+		case <-signals:
+			timer.Stop()
+			return ErrWaitingInterrupted
+		}
+	}
+}
+
 type NoCompatibleWorkersError struct {
 	Spec    WorkerSpec
 	Workers []Worker
@@ -48,14 +102,15 @@ func (err NoCompatibleWorkersError) Error() string {
 
 type pool struct {
 	provider WorkerProvider
-
-	rand *rand.Rand
+	strategy ContainerPlacementStrategy
+	clock    clock.Clock
 }
 
-func NewPool(provider WorkerProvider) Client {
+func NewPool(provider WorkerProvider, strategy ContainerPlacementStrategy, clock clock.Clock) Client {
 	return &pool{
 		provider: provider,
-		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		strategy: strategy,
+		clock:    clock,
 	}
 }
 
@@ -90,10 +145,17 @@ func (pool *pool) AllSatisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes
 	}
 
 	compatibleWorkers := []Worker{}
+	availableWorkers := []Worker{}
 	for _, worker := range workers {
 		satisfyingWorker, err := worker.Satisfying(spec, resourceTypes)
-		if err == nil {
-			compatibleWorkers = append(compatibleWorkers, satisfyingWorker)
+		if err != nil {
+			continue
+		}
+
+		compatibleWorkers = append(compatibleWorkers, satisfyingWorker)
+
+		if !atCapacity(satisfyingWorker, spec) {
+			availableWorkers = append(availableWorkers, satisfyingWorker)
 		}
 	}
 
@@ -104,9 +166,22 @@ func (pool *pool) AllSatisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes
 		}
 	}
 
-	shuffleWorkers(compatibleWorkers)
+	if len(availableWorkers) == 0 {
+		return nil, ErrAllWorkersAtCapacity
+	}
+
+	shuffleWorkers(availableWorkers)
+
+	return availableWorkers, nil
+}
+
+func atCapacity(worker Worker, spec WorkerSpec) bool {
+	maxContainers := worker.MaxContainers()
+	if maxContainers != 0 && worker.ActiveContainers() >= maxContainers {
+		return true
+	}
 
-	return compatibleWorkers, nil
+	return !worker.HasCapacityFor(spec.Limits)
 }
 
 func (pool *pool) Satisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes) (Worker, error) {
@@ -114,12 +189,17 @@ func (pool *pool) Satisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes) (
 	if err != nil {
 		return nil, err
 	}
-	randomWorker := compatibleWorkers[pool.rand.Intn(len(compatibleWorkers))]
-	return randomWorker, nil
+
+	return pool.strategy.Choose(compatibleWorkers, spec)
 }
 
 func (pool *pool) CreateContainer(logger lager.Logger, signals <-chan os.Signal, delegate ImageFetchingDelegate, id Identifier, metadata Metadata, spec ContainerSpec, resourceTypes atc.ResourceTypes) (Container, error) {
-	worker, err := pool.Satisfying(spec.WorkerSpec(), resourceTypes)
+	var worker Worker
+	err := WaitForCapacity(logger, pool.clock, signals, delegate, func() error {
+		var err error
+		worker, err = pool.Satisfying(spec.WorkerSpec(), resourceTypes)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -2,11 +2,15 @@ package worker_test
 
 import (
 	"errors"
+	"os"
+	"sync/atomic"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	. "github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/workerfakes"
+	"github.com/pivotal-golang/clock/fakeclock"
 	"github.com/pivotal-golang/lager/lagertest"
 
 	. "github.com/onsi/ginkgo"
@@ -17,6 +21,7 @@ var _ = Describe("Pool", func() {
 	var (
 		logger       *lagertest.TestLogger
 		fakeProvider *workerfakes.FakeWorkerProvider
+		fakeClock    *fakeclock.FakeClock
 
 		pool Client
 	)
@@ -24,8 +29,9 @@ var _ = Describe("Pool", func() {
 	BeforeEach(func() {
 		logger = lagertest.NewTestLogger("test")
 		fakeProvider = new(workerfakes.FakeWorkerProvider)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
 
-		pool = NewPool(fakeProvider)
+		pool = NewPool(fakeProvider, NewRandomPlacementStrategy(), fakeClock)
 	})
 
 	Describe("GetWorker", func() {
@@ -112,6 +118,10 @@ var _ = Describe("Pool", func() {
 				workerB = new(workerfakes.FakeWorker)
 				workerC = new(workerfakes.FakeWorker)
 
+				workerA.HasCapacityForReturns(true)
+				workerB.HasCapacityForReturns(true)
+				workerC.HasCapacityForReturns(true)
+
 				workerA.SatisfyingReturns(workerA, nil)
 				workerB.SatisfyingReturns(workerB, nil)
 				workerC.SatisfyingReturns(nil, errors.New("nope"))
@@ -151,6 +161,41 @@ var _ = Describe("Pool", func() {
 				Expect(chosenCount[workerC]).To(BeZero())
 			})
 
+			Context("with a container placement strategy", func() {
+				var fakeStrategy *workerfakes.FakeContainerPlacementStrategy
+
+				BeforeEach(func() {
+					fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
+					fakeStrategy.ChooseReturns(workerB, nil)
+
+					pool = NewPool(fakeProvider, fakeStrategy, fakeClock)
+				})
+
+				It("returns the worker chosen by the strategy", func() {
+					Expect(satisfyingErr).NotTo(HaveOccurred())
+					Expect(satisfyingWorker).To(Equal(workerB))
+				})
+
+				It("chooses among the workers satisfying the spec", func() {
+					Expect(fakeStrategy.ChooseCallCount()).To(Equal(1))
+					candidates, actualSpec := fakeStrategy.ChooseArgsForCall(0)
+					Expect(candidates).To(ConsistOf(workerA, workerB))
+					Expect(actualSpec).To(Equal(spec))
+				})
+
+				Context("when the strategy fails to choose", func() {
+					disaster := errors.New("nope")
+
+					BeforeEach(func() {
+						fakeStrategy.ChooseReturns(nil, disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(satisfyingErr).To(Equal(disaster))
+					})
+				})
+			})
+
 			Context("when no workers satisfy the spec", func() {
 				BeforeEach(func() {
 					workerA.SatisfyingReturns(nil, errors.New("nope"))
@@ -229,6 +274,10 @@ var _ = Describe("Pool", func() {
 				workerB = new(workerfakes.FakeWorker)
 				workerC = new(workerfakes.FakeWorker)
 
+				workerA.HasCapacityForReturns(true)
+				workerB.HasCapacityForReturns(true)
+				workerC.HasCapacityForReturns(true)
+
 				workerA.SatisfyingReturns(workerA, nil)
 				workerB.SatisfyingReturns(workerB, nil)
 				workerC.SatisfyingReturns(nil, errors.New("nope"))
@@ -268,6 +317,68 @@ var _ = Describe("Pool", func() {
 				Expect(firstCount[workerA]).To(BeNumerically("~", firstCount[workerB], 50))
 			})
 
+			Context("when a satisfying worker is at its container limit", func() {
+				BeforeEach(func() {
+					workerA.MaxContainersReturns(10)
+					workerA.ActiveContainersReturns(10)
+
+					workerB.MaxContainersReturns(10)
+					workerB.ActiveContainersReturns(9)
+				})
+
+				It("leaves it out", func() {
+					Expect(satisfyingErr).NotTo(HaveOccurred())
+					Expect(satisfyingWorkers).To(Equal([]Worker{workerB}))
+				})
+
+				Context("when every satisfying worker is at its container limit", func() {
+					BeforeEach(func() {
+						workerB.ActiveContainersReturns(10)
+					})
+
+					It("returns ErrAllWorkersAtCapacity", func() {
+						Expect(satisfyingErr).To(Equal(ErrAllWorkersAtCapacity))
+					})
+				})
+			})
+
+			Context("when a satisfying worker does not have the memory or CPU left for the spec", func() {
+				BeforeEach(func() {
+					workerA.HasCapacityForReturns(false)
+				})
+
+				It("leaves it out", func() {
+					Expect(satisfyingErr).NotTo(HaveOccurred())
+					Expect(satisfyingWorkers).To(Equal([]Worker{workerB}))
+				})
+
+				It("checks the spec's limits", func() {
+					Expect(workerA.HasCapacityForArgsForCall(0)).To(Equal(spec.Limits))
+				})
+
+				Context("when no satisfying worker has any left", func() {
+					BeforeEach(func() {
+						workerB.HasCapacityForReturns(false)
+					})
+
+					It("returns ErrAllWorkersAtCapacity rather than finding no compatible workers", func() {
+						Expect(satisfyingErr).To(Equal(ErrAllWorkersAtCapacity))
+					})
+				})
+			})
+
+			Context("when a satisfying worker has no container limit", func() {
+				BeforeEach(func() {
+					workerA.MaxContainersReturns(0)
+					workerA.ActiveContainersReturns(1000)
+				})
+
+				It("returns it", func() {
+					Expect(satisfyingErr).NotTo(HaveOccurred())
+					Expect(satisfyingWorkers).To(ConsistOf(workerA, workerB))
+				})
+			})
+
 			Context("when no workers satisfy the spec", func() {
 				BeforeEach(func() {
 					workerA.SatisfyingReturns(nil, errors.New("nope"))
@@ -307,6 +418,107 @@ var _ = Describe("Pool", func() {
 		})
 	})
 
+	Describe("WaitForCapacity", func() {
+		var (
+			fakeDelegate *workerfakes.FakeImageFetchingDelegate
+			signals      chan os.Signal
+			findResults  chan error
+			findCalls    int32
+			waitErr      chan error
+		)
+
+		BeforeEach(func() {
+			fakeDelegate = new(workerfakes.FakeImageFetchingDelegate)
+			signals = make(chan os.Signal, 1)
+			findResults = make(chan error, 10)
+			findCalls = 0
+		})
+
+		JustBeforeEach(func() {
+			waitErr = make(chan error, 1)
+
+			go func() {
+				waitErr <- WaitForCapacity(logger, fakeClock, signals, fakeDelegate, func() error {
+					atomic.AddInt32(&findCalls, 1)
+
+					select {
+					case err := <-findResults:
+						return err
+					default:
+						return ErrAllWorkersAtCapacity
+					}
+				})
+			}()
+		})
+
+		Context("when a worker is available", func() {
+			BeforeEach(func() {
+				findResults <- nil
+			})
+
+			It("returns without waiting", func() {
+				Eventually(waitErr).Should(Receive(BeNil()))
+				Expect(fakeDelegate.WaitingForWorkerCallCount()).To(BeZero())
+				Expect(fakeDelegate.WorkerFoundCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when finding a worker fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				findResults <- disaster
+			})
+
+			It("returns the error", func() {
+				Eventually(waitErr).Should(Receive(Equal(disaster)))
+			})
+		})
+
+		Context("when every compatible worker is at capacity", func() {
+			BeforeEach(func() {
+				findResults <- ErrAllWorkersAtCapacity
+			})
+
+			It("keeps checking for a worker, only telling the delegate once", func() {
+				Eventually(func() int32 {
+					fakeClock.Increment(WorkerPollingInterval)
+					return atomic.LoadInt32(&findCalls)
+				}).Should(BeNumerically(">=", 3))
+
+				Expect(fakeDelegate.WaitingForWorkerCallCount()).To(Equal(1))
+
+				signals <- os.Interrupt
+				Eventually(waitErr).Should(Receive(Equal(ErrWaitingInterrupted)))
+			})
+
+			Context("when a worker frees up", func() {
+				BeforeEach(func() {
+					findResults <- nil
+				})
+
+				It("tells the delegate and returns", func() {
+					Eventually(func() int {
+						fakeClock.Increment(WorkerPollingInterval)
+						return fakeDelegate.WorkerFoundCallCount()
+					}).Should(Equal(1))
+
+					Eventually(waitErr).Should(Receive(BeNil()))
+					Expect(fakeDelegate.WaitingForWorkerCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when interrupted while waiting", func() {
+				It("returns ErrWaitingInterrupted", func() {
+					Eventually(fakeDelegate.WaitingForWorkerCallCount).Should(Equal(1))
+
+					signals <- os.Interrupt
+					Eventually(waitErr).Should(Receive(Equal(ErrWaitingInterrupted)))
+				})
+			})
+		})
+	})
+
 	Describe("CreateContainer", func() {
 		var (
 			fakeImageFetchingDelegate *workerfakes.FakeImageFetchingDelegate
@@ -372,6 +584,10 @@ var _ = Describe("Pool", func() {
 				workerB = new(workerfakes.FakeWorker)
 				workerC = new(workerfakes.FakeWorker)
 
+				workerA.HasCapacityForReturns(true)
+				workerB.HasCapacityForReturns(true)
+				workerC.HasCapacityForReturns(true)
+
 				workerA.ActiveContainersReturns(3)
 				workerB.ActiveContainersReturns(2)
 
@@ -436,6 +652,37 @@ var _ = Describe("Pool", func() {
 				})
 			})
 
+			Context("when every compatible worker's memory is committed to running containers", func() {
+				BeforeEach(func() {
+					memory := uint64(1024)
+					spec.Limits = atc.ContainerLimits{Memory: &memory}
+
+					workerA.HasCapacityForReturns(false)
+					workerB.HasCapacityForStub = func(atc.ContainerLimits) bool {
+						// a container on it finishes once the pool is waiting
+						return fakeImageFetchingDelegate.WaitingForWorkerCallCount() > 0
+					}
+
+					go func() {
+						for fakeImageFetchingDelegate.WorkerFoundCallCount() == 0 {
+							fakeClock.Increment(WorkerPollingInterval)
+							time.Sleep(10 * time.Millisecond)
+						}
+					}()
+				})
+
+				It("waits for a worker to have capacity rather than failing", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(createdContainer).To(Equal(fakeContainer))
+
+					Expect(fakeImageFetchingDelegate.WaitingForWorkerCallCount()).To(Equal(1))
+					Expect(fakeImageFetchingDelegate.WorkerFoundCallCount()).To(Equal(1))
+
+					Expect(workerA.CreateContainerCallCount()).To(BeZero())
+					Expect(workerB.CreateContainerCallCount()).To(Equal(1))
+				})
+			})
+
 			Context("when no workers satisfy the spec", func() {
 				BeforeEach(func() {
 					workerA.SatisfyingReturns(nil, errors.New("nope"))
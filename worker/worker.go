@@ -22,6 +22,8 @@ import (
 var ErrUnsupportedResourceType = errors.New("unsupported resource type")
 var ErrIncompatiblePlatform = errors.New("incompatible platform")
 var ErrMismatchedTags = errors.New("mismatched tags")
+var ErrInsufficientCapacity = errors.New("insufficient capacity")
+var ErrTeamMismatch = errors.New("worker belongs to another team")
 var ErrNoVolumeManager = errors.New("worker does not support volume management")
 
 type MalformedMetadataError struct {
@@ -49,6 +51,12 @@ type Worker interface {
 	Client
 
 	ActiveContainers() int
+	MaxContainers() int
+
+	// HasCapacityFor reports whether the limits fit in what is left of the
+	// worker's memory and CPU once its running containers' limits are
+	// accounted for.
+	HasCapacityFor(atc.ContainerLimits) bool
 
 	Description() string
 	Name() string
@@ -84,9 +92,15 @@ type gardenWorker struct {
 	clock clock.Clock
 
 	activeContainers int
+	maxContainers    int
+	memoryCapacity   uint64
+	cpuCapacity      uint64
+	memoryCommitted  uint64
+	cpuCommitted     uint64
 	resourceTypes    []atc.WorkerResourceType
 	platform         string
 	tags             atc.Tags
+	teamID           int
 	name             string
 	startTime        int64
 	httpProxyURL     string
@@ -104,9 +118,15 @@ func NewGardenWorker(
 	provider WorkerProvider,
 	clock clock.Clock,
 	activeContainers int,
+	maxContainers int,
+	memoryCapacity uint64,
+	cpuCapacity uint64,
+	memoryCommitted uint64,
+	cpuCommitted uint64,
 	resourceTypes []atc.WorkerResourceType,
 	platform string,
 	tags atc.Tags,
+	teamID int,
 	name string,
 	startTime int64,
 	httpProxyURL string,
@@ -124,9 +144,15 @@ func NewGardenWorker(
 		clock:              clock,
 
 		activeContainers: activeContainers,
+		maxContainers:    maxContainers,
+		memoryCapacity:   memoryCapacity,
+		cpuCapacity:      cpuCapacity,
+		memoryCommitted:  memoryCommitted,
+		cpuCommitted:     cpuCommitted,
 		resourceTypes:    resourceTypes,
 		platform:         platform,
 		tags:             tags,
+		teamID:           teamID,
 		name:             name,
 		startTime:        startTime,
 		httpProxyURL:     httpProxyURL,
@@ -409,6 +435,7 @@ func (worker *gardenWorker) CreateContainer(
 		Properties: gardenProperties,
 		RootFSPath: imageURL,
 		Env:        env,
+		Limits:     gardenLimits(spec.Limits),
 	}
 
 	gardenContainer, err := worker.gardenClient.Create(gardenSpec)
@@ -420,6 +447,14 @@ func (worker *gardenWorker) CreateContainer(
 	metadata.Handle = gardenContainer.Handle()
 	metadata.User = gardenSpec.Properties["user"]
 
+	if spec.Limits.Memory != nil {
+		metadata.MemoryLimit = *spec.Limits.Memory
+	}
+
+	if spec.Limits.CPU != nil {
+		metadata.CPULimit = *spec.Limits.CPU
+	}
+
 	id.ResourceTypeVersion = resourceTypeVersion
 
 	_, err = worker.db.CreateContainer(
@@ -528,6 +563,10 @@ func (worker *gardenWorker) ActiveContainers() int {
 	return worker.activeContainers
 }
 
+func (worker *gardenWorker) MaxContainers() int {
+	return worker.maxContainers
+}
+
 func (worker *gardenWorker) Satisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes) (Worker, error) {
 	if spec.ResourceType != "" {
 		underlyingType := determineUnderlyingTypeName(spec.ResourceType, resourceTypes)
@@ -555,9 +594,58 @@ func (worker *gardenWorker) Satisfying(spec WorkerSpec, resourceTypes atc.Resour
 		return nil, ErrMismatchedTags
 	}
 
+	// a worker that is only full for now is still compatible; the pool
+	// waits for it to have capacity again
+	if !worker.couldFit(spec.Limits) {
+		return nil, ErrInsufficientCapacity
+	}
+
+	if worker.teamID != 0 && worker.teamID != spec.TeamID {
+		return nil, ErrTeamMismatch
+	}
+
 	return worker, nil
 }
 
+// couldFit reports whether the limits fit in the worker's capacity at all.
+func (worker *gardenWorker) couldFit(limits atc.ContainerLimits) bool {
+	if limits.Memory != nil && worker.memoryCapacity != 0 && *limits.Memory > worker.memoryCapacity {
+		return false
+	}
+
+	if limits.CPU != nil && worker.cpuCapacity != 0 && *limits.CPU > worker.cpuCapacity {
+		return false
+	}
+
+	return true
+}
+
+func (worker *gardenWorker) HasCapacityFor(limits atc.ContainerLimits) bool {
+	if limits.Memory != nil && worker.memoryCapacity != 0 && worker.memoryCommitted+*limits.Memory > worker.memoryCapacity {
+		return false
+	}
+
+	if limits.CPU != nil && worker.cpuCapacity != 0 && worker.cpuCommitted+*limits.CPU > worker.cpuCapacity {
+		return false
+	}
+
+	return true
+}
+
+func gardenLimits(limits atc.ContainerLimits) garden.Limits {
+	gardenLimits := garden.Limits{}
+
+	if limits.CPU != nil {
+		gardenLimits.CPU = garden.CPULimits{LimitInShares: *limits.CPU}
+	}
+
+	if limits.Memory != nil {
+		gardenLimits.Memory = garden.MemoryLimits{LimitInBytes: *limits.Memory}
+	}
+
+	return gardenLimits
+}
+
 func determineUnderlyingTypeName(typeName string, resourceTypes atc.ResourceTypes) string {
 	resourceTypesMap := make(map[string]atc.ResourceType)
 	for _, resourceType := range resourceTypes {
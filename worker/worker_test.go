@@ -36,9 +36,15 @@ var _ = Describe("Worker", func() {
 		fakeWorkerProvider     *wfakes.FakeWorkerProvider
 		fakeClock              *fakeclock.FakeClock
 		activeContainers       int
+		maxContainers          int
+		memoryCapacity         uint64
+		cpuCapacity            uint64
+		memoryCommitted        uint64
+		cpuCommitted           uint64
 		resourceTypes          []atc.WorkerResourceType
 		platform               string
 		tags                   atc.Tags
+		teamID                 int
 		workerName             string
 		workerStartTime        int64
 		httpProxyURL           string
@@ -59,6 +65,11 @@ var _ = Describe("Worker", func() {
 		fakeWorkerProvider = new(wfakes.FakeWorkerProvider)
 		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
 		activeContainers = 42
+		maxContainers = 0
+		memoryCapacity = 0
+		cpuCapacity = 0
+		memoryCommitted = 0
+		cpuCommitted = 0
 		resourceTypes = []atc.WorkerResourceType{
 			{
 				Type:    "some-resource",
@@ -68,6 +79,7 @@ var _ = Describe("Worker", func() {
 		}
 		platform = "some-platform"
 		tags = atc.Tags{"some", "tags"}
+		teamID = 0
 		workerName = "some-worker"
 		workerStartTime = fakeClock.Now().Unix()
 
@@ -81,9 +93,15 @@ var _ = Describe("Worker", func() {
 			fakeWorkerProvider,
 			fakeClock,
 			activeContainers,
+			maxContainers,
+			memoryCapacity,
+			cpuCapacity,
+			memoryCommitted,
+			cpuCommitted,
 			resourceTypes,
 			platform,
 			tags,
+			teamID,
 			workerName,
 			workerStartTime,
 			httpProxyURL,
@@ -1103,9 +1121,15 @@ var _ = Describe("Worker", func() {
 					fakeWorkerProvider,
 					fakeClock,
 					activeContainers,
+					maxContainers,
+					memoryCapacity,
+					cpuCapacity,
+					memoryCommitted,
+					cpuCommitted,
 					resourceTypes,
 					platform,
 					tags,
+					teamID,
 					workerName,
 					workerStartTime,
 					"http://example.com",
@@ -1121,6 +1145,34 @@ var _ = Describe("Worker", func() {
 			})
 		})
 
+		Context("when the spec has container limits", func() {
+			BeforeEach(func() {
+				cpu := uint64(512)
+				memory := uint64(1024)
+
+				containerSpec.Limits = atc.ContainerLimits{
+					CPU:    &cpu,
+					Memory: &memory,
+				}
+			})
+
+			It("passes the limits to garden", func() {
+				Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+				actualGardenSpec := fakeGardenClient.CreateArgsForCall(0)
+				Expect(actualGardenSpec.Limits).To(Equal(garden.Limits{
+					CPU:    garden.CPULimits{LimitInShares: 512},
+					Memory: garden.MemoryLimits{LimitInBytes: 1024},
+				}))
+			})
+
+			It("records the limits with the container", func() {
+				Expect(fakeGardenWorkerDB.CreateContainerCallCount()).To(Equal(1))
+				container, _, _, _ := fakeGardenWorkerDB.CreateContainerArgsForCall(0)
+				Expect(container.MemoryLimit).To(Equal(uint64(1024)))
+				Expect(container.CPULimit).To(Equal(uint64(512)))
+			})
+		})
+
 		Context("when the worker has NoProxy", func() {
 			BeforeEach(func() {
 				gardenWorker = NewGardenWorker(
@@ -1133,9 +1185,15 @@ var _ = Describe("Worker", func() {
 					fakeWorkerProvider,
 					fakeClock,
 					activeContainers,
+					maxContainers,
+					memoryCapacity,
+					cpuCapacity,
+					memoryCommitted,
+					cpuCommitted,
 					resourceTypes,
 					platform,
 					tags,
+					teamID,
 					workerName,
 					workerStartTime,
 					httpProxyURL,
@@ -1163,9 +1221,15 @@ var _ = Describe("Worker", func() {
 					fakeWorkerProvider,
 					fakeClock,
 					activeContainers,
+					maxContainers,
+					memoryCapacity,
+					cpuCapacity,
+					memoryCommitted,
+					cpuCommitted,
 					resourceTypes,
 					platform,
 					tags,
+					teamID,
 					workerName,
 					workerStartTime,
 					httpProxyURL,
@@ -1319,9 +1383,15 @@ var _ = Describe("Worker", func() {
 								fakeWorkerProvider,
 								fakeClock,
 								activeContainers,
+								maxContainers,
+								memoryCapacity,
+								cpuCapacity,
+								memoryCommitted,
+								cpuCommitted,
 								resourceTypes,
 								platform,
 								tags,
+								teamID,
 								workerName,
 								workerStartTime,
 								httpProxyURL,
@@ -1380,9 +1450,15 @@ var _ = Describe("Worker", func() {
 								fakeWorkerProvider,
 								fakeClock,
 								activeContainers,
+								maxContainers,
+								memoryCapacity,
+								cpuCapacity,
+								memoryCommitted,
+								cpuCommitted,
 								resourceTypes,
 								platform,
 								tags,
+								teamID,
 								workerName,
 								workerStartTime,
 								httpProxyURL,
@@ -1795,9 +1871,15 @@ var _ = Describe("Worker", func() {
 				fakeWorkerProvider,
 				fakeClock,
 				activeContainers,
+				maxContainers,
+				memoryCapacity,
+				cpuCapacity,
+				memoryCommitted,
+				cpuCommitted,
 				resourceTypes,
 				platform,
 				tags,
+				teamID,
 				workerName,
 				workerStartTime,
 				httpProxyURL,
@@ -1874,6 +1956,161 @@ var _ = Describe("Worker", func() {
 					Expect(satisfyingErr).To(Equal(ErrMismatchedTags))
 				})
 			})
+
+			Context("when the worker belongs to a team", func() {
+				BeforeEach(func() {
+					spec.Tags = []string{"some", "tags"}
+					teamID = 2
+				})
+
+				Context("when the spec is for the same team", func() {
+					BeforeEach(func() {
+						spec.TeamID = 2
+					})
+
+					It("returns the worker", func() {
+						Expect(satisfyingErr).NotTo(HaveOccurred())
+						Expect(satisfyingWorker).To(Equal(gardenWorker))
+					})
+				})
+
+				Context("when the spec is for another team", func() {
+					BeforeEach(func() {
+						spec.TeamID = 3
+					})
+
+					It("returns ErrTeamMismatch", func() {
+						Expect(satisfyingErr).To(Equal(ErrTeamMismatch))
+					})
+				})
+
+				Context("when the spec has no team", func() {
+					BeforeEach(func() {
+						spec.TeamID = 0
+					})
+
+					It("returns ErrTeamMismatch", func() {
+						Expect(satisfyingErr).To(Equal(ErrTeamMismatch))
+					})
+				})
+			})
+
+			Context("when the worker is shared", func() {
+				BeforeEach(func() {
+					spec.Tags = []string{"some", "tags"}
+					spec.TeamID = 2
+				})
+
+				It("returns the worker for any team", func() {
+					Expect(satisfyingErr).NotTo(HaveOccurred())
+					Expect(satisfyingWorker).To(Equal(gardenWorker))
+				})
+			})
+
+			Context("when the worker has limited capacity", func() {
+				var cpu, memory uint64
+
+				BeforeEach(func() {
+					spec.Tags = []string{"some", "tags"}
+
+					cpuCapacity = 1024
+					memoryCapacity = 4096
+
+					spec.Limits = atc.ContainerLimits{
+						CPU:    &cpu,
+						Memory: &memory,
+					}
+				})
+
+				Context("when the limits fit", func() {
+					BeforeEach(func() {
+						cpu = 1024
+						memory = 4096
+					})
+
+					It("returns the worker", func() {
+						Expect(satisfyingErr).NotTo(HaveOccurred())
+						Expect(satisfyingWorker).To(Equal(gardenWorker))
+					})
+				})
+
+				Context("when the memory limit is more than the worker has", func() {
+					BeforeEach(func() {
+						cpu = 1024
+						memory = 4097
+					})
+
+					It("returns ErrInsufficientCapacity", func() {
+						Expect(satisfyingErr).To(Equal(ErrInsufficientCapacity))
+					})
+				})
+
+				Context("when the cpu limit is more than the worker has", func() {
+					BeforeEach(func() {
+						cpu = 1025
+						memory = 4096
+					})
+
+					It("returns ErrInsufficientCapacity", func() {
+						Expect(satisfyingErr).To(Equal(ErrInsufficientCapacity))
+					})
+				})
+
+				Context("when running containers have committed some of the capacity", func() {
+					BeforeEach(func() {
+						memoryCommitted = 3072
+						cpuCommitted = 512
+					})
+
+					Context("when the limits fit in what is left", func() {
+						BeforeEach(func() {
+							cpu = 512
+							memory = 1024
+						})
+
+						It("returns the worker", func() {
+							Expect(satisfyingErr).NotTo(HaveOccurred())
+							Expect(satisfyingWorker).To(Equal(gardenWorker))
+						})
+
+						It("has capacity for them", func() {
+							Expect(gardenWorker.HasCapacityFor(spec.Limits)).To(BeTrue())
+						})
+					})
+
+					Context("when the memory limit is more than is left", func() {
+						BeforeEach(func() {
+							cpu = 512
+							memory = 1025
+						})
+
+						It("still returns the worker, as it is only full for now", func() {
+							Expect(satisfyingErr).NotTo(HaveOccurred())
+							Expect(satisfyingWorker).To(Equal(gardenWorker))
+						})
+
+						It("does not have capacity for them", func() {
+							Expect(gardenWorker.HasCapacityFor(spec.Limits)).To(BeFalse())
+						})
+					})
+
+					Context("when the cpu limit is more than is left", func() {
+						BeforeEach(func() {
+							cpu = 513
+							memory = 1024
+						})
+
+						It("still returns the worker, as it is only full for now", func() {
+							Expect(satisfyingErr).NotTo(HaveOccurred())
+							Expect(satisfyingWorker).To(Equal(gardenWorker))
+						})
+
+						It("does not have capacity for them", func() {
+							Expect(gardenWorker.HasCapacityFor(spec.Limits)).To(BeFalse())
+						})
+					})
+				})
+			})
 		})
 
 		Context("when the platform is incompatible", func() {
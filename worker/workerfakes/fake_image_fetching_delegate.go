@@ -23,8 +23,14 @@ type FakeImageFetchingDelegate struct {
 	imageVersionDeterminedReturns struct {
 		result1 error
 	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
+	WaitingForWorkerStub        func()
+	waitingForWorkerMutex       sync.RWMutex
+	waitingForWorkerArgsForCall []struct{}
+	WorkerFoundStub             func()
+	workerFoundMutex            sync.RWMutex
+	workerFoundArgsForCall      []struct{}
+	invocations                 map[string][][]interface{}
+	invocationsMutex            sync.RWMutex
 }
 
 func (fake *FakeImageFetchingDelegate) Stderr() io.Writer {
@@ -85,6 +91,38 @@ func (fake *FakeImageFetchingDelegate) ImageVersionDeterminedReturns(result1 err
 	}{result1}
 }
 
+func (fake *FakeImageFetchingDelegate) WaitingForWorker() {
+	fake.waitingForWorkerMutex.Lock()
+	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct{}{})
+	fake.recordInvocation("WaitingForWorker", []interface{}{})
+	fake.waitingForWorkerMutex.Unlock()
+	if fake.WaitingForWorkerStub != nil {
+		fake.WaitingForWorkerStub()
+	}
+}
+
+func (fake *FakeImageFetchingDelegate) WaitingForWorkerCallCount() int {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	return len(fake.waitingForWorkerArgsForCall)
+}
+
+func (fake *FakeImageFetchingDelegate) WorkerFound() {
+	fake.workerFoundMutex.Lock()
+	fake.workerFoundArgsForCall = append(fake.workerFoundArgsForCall, struct{}{})
+	fake.recordInvocation("WorkerFound", []interface{}{})
+	fake.workerFoundMutex.Unlock()
+	if fake.WorkerFoundStub != nil {
+		fake.WorkerFoundStub()
+	}
+}
+
+func (fake *FakeImageFetchingDelegate) WorkerFoundCallCount() int {
+	fake.workerFoundMutex.RLock()
+	defer fake.workerFoundMutex.RUnlock()
+	return len(fake.workerFoundArgsForCall)
+}
+
 func (fake *FakeImageFetchingDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -92,6 +130,10 @@ func (fake *FakeImageFetchingDelegate) Invocations() map[string][][]interface{}
 	defer fake.stderrMutex.RUnlock()
 	fake.imageVersionDeterminedMutex.RLock()
 	defer fake.imageVersionDeterminedMutex.RUnlock()
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	fake.workerFoundMutex.RLock()
+	defer fake.workerFoundMutex.RUnlock()
 	return fake.invocations
 }
 
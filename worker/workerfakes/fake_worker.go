@@ -135,6 +135,20 @@ type FakeWorker struct {
 	activeContainersReturns     struct {
 		result1 int
 	}
+	MaxContainersStub        func() int
+	maxContainersMutex       sync.RWMutex
+	maxContainersArgsForCall []struct{}
+	maxContainersReturns     struct {
+		result1 int
+	}
+	HasCapacityForStub        func(atc.ContainerLimits) bool
+	hasCapacityForMutex       sync.RWMutex
+	hasCapacityForArgsForCall []struct {
+		arg1 atc.ContainerLimits
+	}
+	hasCapacityForReturns struct {
+		result1 bool
+	}
 	DescriptionStub        func() string
 	descriptionMutex       sync.RWMutex
 	descriptionArgsForCall []struct{}
@@ -574,6 +588,64 @@ func (fake *FakeWorker) ActiveContainersReturns(result1 int) {
 	}{result1}
 }
 
+func (fake *FakeWorker) MaxContainers() int {
+	fake.maxContainersMutex.Lock()
+	fake.maxContainersArgsForCall = append(fake.maxContainersArgsForCall, struct{}{})
+	fake.recordInvocation("MaxContainers", []interface{}{})
+	fake.maxContainersMutex.Unlock()
+	if fake.MaxContainersStub != nil {
+		return fake.MaxContainersStub()
+	} else {
+		return fake.maxContainersReturns.result1
+	}
+}
+
+func (fake *FakeWorker) MaxContainersCallCount() int {
+	fake.maxContainersMutex.RLock()
+	defer fake.maxContainersMutex.RUnlock()
+	return len(fake.maxContainersArgsForCall)
+}
+
+func (fake *FakeWorker) MaxContainersReturns(result1 int) {
+	fake.MaxContainersStub = nil
+	fake.maxContainersReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) HasCapacityFor(arg1 atc.ContainerLimits) bool {
+	fake.hasCapacityForMutex.Lock()
+	fake.hasCapacityForArgsForCall = append(fake.hasCapacityForArgsForCall, struct {
+		arg1 atc.ContainerLimits
+	}{arg1})
+	fake.recordInvocation("HasCapacityFor", []interface{}{arg1})
+	fake.hasCapacityForMutex.Unlock()
+	if fake.HasCapacityForStub != nil {
+		return fake.HasCapacityForStub(arg1)
+	} else {
+		return fake.hasCapacityForReturns.result1
+	}
+}
+
+func (fake *FakeWorker) HasCapacityForCallCount() int {
+	fake.hasCapacityForMutex.RLock()
+	defer fake.hasCapacityForMutex.RUnlock()
+	return len(fake.hasCapacityForArgsForCall)
+}
+
+func (fake *FakeWorker) HasCapacityForArgsForCall(i int) atc.ContainerLimits {
+	fake.hasCapacityForMutex.RLock()
+	defer fake.hasCapacityForMutex.RUnlock()
+	return fake.hasCapacityForArgsForCall[i].arg1
+}
+
+func (fake *FakeWorker) HasCapacityForReturns(result1 bool) {
+	fake.HasCapacityForStub = nil
+	fake.hasCapacityForReturns = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeWorker) Description() string {
 	fake.descriptionMutex.Lock()
 	fake.descriptionArgsForCall = append(fake.descriptionArgsForCall, struct{}{})
@@ -676,6 +748,10 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.getWorkerMutex.RUnlock()
 	fake.activeContainersMutex.RLock()
 	defer fake.activeContainersMutex.RUnlock()
+	fake.maxContainersMutex.RLock()
+	defer fake.maxContainersMutex.RUnlock()
+	fake.hasCapacityForMutex.RLock()
+	defer fake.hasCapacityForMutex.RUnlock()
 	fake.descriptionMutex.RLock()
 	defer fake.descriptionMutex.RUnlock()
 	fake.nameMutex.RLock()
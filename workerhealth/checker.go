@@ -0,0 +1,82 @@
+package workerhealth
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+)
+
+type HealthChecker interface {
+	Run() error
+}
+
+//go:generate counterfeiter . HealthCheckerDB
+
+type HealthCheckerDB interface {
+	Workers() ([]db.SavedWorker, error)
+	SaveWorkerHealth(workerName string, health db.WorkerHealth) error
+}
+
+type healthChecker struct {
+	logger lager.Logger
+	db     HealthCheckerDB
+	prober Prober
+}
+
+func NewHealthChecker(
+	logger lager.Logger,
+	db HealthCheckerDB,
+	prober Prober,
+) HealthChecker {
+	return &healthChecker{
+		logger: logger,
+		db:     db,
+		prober: prober,
+	}
+}
+
+func (hc *healthChecker) Run() error {
+	savedWorkers, err := hc.db.Workers()
+	if err != nil {
+		hc.logger.Error("failed-to-get-workers", err)
+		return err
+	}
+
+	wg := new(sync.WaitGroup)
+
+	for _, savedWorker := range savedWorkers {
+		// workers that have stopped heartbeating can't be reached anyway
+		if savedWorker.State == db.WorkerStateStalled || savedWorker.State == db.WorkerStateLanded {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(savedWorker db.SavedWorker) {
+			defer wg.Done()
+			hc.check(savedWorker)
+		}(savedWorker)
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+func (hc *healthChecker) check(savedWorker db.SavedWorker) {
+	logger := hc.logger.Session("check", lager.Data{"worker": savedWorker.Name})
+
+	health := hc.prober.Probe(logger, savedWorker)
+	if !health.Healthy() {
+		logger.Info("unhealthy", lager.Data{
+			"garden-error":       health.GardenError,
+			"baggageclaim-error": health.BaggageclaimError,
+		})
+	}
+
+	err := hc.db.SaveWorkerHealth(savedWorker.Name, health)
+	if err != nil {
+		logger.Error("failed-to-save-health", err)
+	}
+}
@@ -0,0 +1,131 @@
+package workerhealth_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/concourse/atc/db"
+	. "github.com/concourse/atc/workerhealth"
+	"github.com/concourse/atc/workerhealth/workerhealthfakes"
+	"github.com/pivotal-golang/lager"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HealthChecker", func() {
+	var (
+		fakeDB     *workerhealthfakes.FakeHealthCheckerDB
+		fakeProber *workerhealthfakes.FakeProber
+
+		checker HealthChecker
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeDB = new(workerhealthfakes.FakeHealthCheckerDB)
+		fakeProber = new(workerhealthfakes.FakeProber)
+
+		checker = NewHealthChecker(lagertest.NewTestLogger("test"), fakeDB, fakeProber)
+	})
+
+	JustBeforeEach(func() {
+		runErr = checker.Run()
+	})
+
+	Context("when there are workers", func() {
+		BeforeEach(func() {
+			fakeDB.WorkersReturns([]db.SavedWorker{
+				{
+					WorkerInfo: db.WorkerInfo{Name: "some-worker"},
+					State:      db.WorkerStateRunning,
+				},
+				{
+					WorkerInfo: db.WorkerInfo{Name: "some-landing-worker"},
+					State:      db.WorkerStateLanding,
+				},
+				{
+					WorkerInfo: db.WorkerInfo{Name: "some-stalled-worker"},
+					State:      db.WorkerStateStalled,
+				},
+				{
+					WorkerInfo: db.WorkerInfo{Name: "some-landed-worker"},
+					State:      db.WorkerStateLanded,
+				},
+			}, nil)
+
+			fakeProber.ProbeStub = func(_ lager.Logger, savedWorker db.SavedWorker) db.WorkerHealth {
+				return db.WorkerHealth{
+					CheckedAt:   time.Unix(1461864115, 0),
+					GardenError: savedWorker.Name + "-error",
+				}
+			}
+		})
+
+		It("succeeds", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+		})
+
+		It("probes each worker that is still heartbeating", func() {
+			Expect(fakeProber.ProbeCallCount()).To(Equal(2))
+
+			probed := []string{}
+			for i := 0; i < fakeProber.ProbeCallCount(); i++ {
+				_, savedWorker := fakeProber.ProbeArgsForCall(i)
+				probed = append(probed, savedWorker.Name)
+			}
+
+			Expect(probed).To(ConsistOf("some-worker", "some-landing-worker"))
+		})
+
+		It("saves the health of each probed worker", func() {
+			Expect(fakeDB.SaveWorkerHealthCallCount()).To(Equal(2))
+
+			saved := map[string]db.WorkerHealth{}
+			for i := 0; i < fakeDB.SaveWorkerHealthCallCount(); i++ {
+				name, health := fakeDB.SaveWorkerHealthArgsForCall(i)
+				saved[name] = health
+			}
+
+			Expect(saved).To(Equal(map[string]db.WorkerHealth{
+				"some-worker": {
+					CheckedAt:   time.Unix(1461864115, 0),
+					GardenError: "some-worker-error",
+				},
+				"some-landing-worker": {
+					CheckedAt:   time.Unix(1461864115, 0),
+					GardenError: "some-landing-worker-error",
+				},
+			}))
+		})
+
+		Context("when saving a worker's health fails", func() {
+			BeforeEach(func() {
+				fakeDB.SaveWorkerHealthReturns(errors.New("disaster"))
+			})
+
+			It("still saves the others and succeeds", func() {
+				Expect(fakeDB.SaveWorkerHealthCallCount()).To(Equal(2))
+				Expect(runErr).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Context("when getting the workers fails", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			fakeDB.WorkersReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+
+		It("does not probe anything", func() {
+			Expect(fakeProber.ProbeCallCount()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,110 @@
+package workerhealth
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cloudfoundry-incubator/garden"
+	gclient "github.com/cloudfoundry-incubator/garden/client"
+	gconn "github.com/cloudfoundry-incubator/garden/client/connection"
+	"github.com/cloudfoundry-incubator/garden/routes"
+	"github.com/concourse/retryhttp"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/rata"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker/transport"
+)
+
+//go:generate counterfeiter . Prober
+
+type Prober interface {
+	Probe(lager.Logger, db.SavedWorker) db.WorkerHealth
+}
+
+type prober struct {
+	clock   clock.Clock
+	timeout time.Duration
+}
+
+func NewProber(clock clock.Clock, timeout time.Duration) Prober {
+	return &prober{
+		clock:   clock,
+		timeout: timeout,
+	}
+}
+
+func (p *prober) Probe(logger lager.Logger, savedWorker db.SavedWorker) db.WorkerHealth {
+	health := db.WorkerHealth{
+		CheckedAt: p.clock.Now(),
+	}
+
+	// each request gives up after the timeout, so that an unreachable worker
+	// is reported rather than waited out
+	httpClient := &http.Client{
+		Timeout:   p.timeout,
+		Transport: &http.Transport{DisableKeepAlives: true},
+	}
+
+	// dial the worker directly, without retrying
+	gardenClient := gclient.New(gconn.NewWithHijacker(&transport.WorkerHijackStreamer{
+		HttpClient:       httpClient,
+		HijackableClient: retryhttp.DefaultHijackableClient,
+		Req:              rata.NewRequestGenerator("http://"+savedWorker.GardenAddr, routes.Routes),
+	}, logger))
+
+	var capacity garden.Capacity
+	latency, err := p.timed(func() error {
+		var err error
+		capacity, err = gardenClient.Capacity()
+		return err
+	})
+	if err != nil {
+		logger.Error("failed-to-reach-garden", err)
+		health.GardenError = err.Error()
+	} else {
+		health.DiskInBytes = capacity.DiskInBytes
+	}
+
+	health.GardenLatency = latency
+
+	if savedWorker.BaggageclaimURL != "" {
+		latency, err := p.timed(func() error {
+			return pingBaggageclaim(httpClient, savedWorker.BaggageclaimURL)
+		})
+		if err != nil {
+			logger.Error("failed-to-reach-baggageclaim", err)
+			health.BaggageclaimError = err.Error()
+		}
+
+		health.BaggageclaimLatency = latency
+	}
+
+	return health
+}
+
+func (p *prober) timed(f func() error) (time.Duration, error) {
+	started := p.clock.Now()
+	err := f()
+	return p.clock.Since(started), err
+}
+
+// pingBaggageclaim requests baggageclaim's root, which it has no route for.
+// Any response short of a server error shows that it is up and serving
+// requests, without the cost of listing every volume.
+func pingBaggageclaim(httpClient *http.Client, baggageclaimURL string) error {
+	response, err := httpClient.Get(baggageclaimURL + "/")
+	if err != nil {
+		return err
+	}
+
+	response.Body.Close()
+
+	if response.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("bad response: %s", response.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package workerhealth_test
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/cloudfoundry-incubator/garden"
+	gfakes "github.com/cloudfoundry-incubator/garden/fakes"
+	"github.com/cloudfoundry-incubator/garden/server"
+	"github.com/concourse/atc/db"
+	. "github.com/concourse/atc/workerhealth"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Prober", func() {
+	var (
+		logger *lagertest.TestLogger
+
+		fakeGardenBackend  *gfakes.FakeBackend
+		gardenAddr         string
+		gardenServer       *server.GardenServer
+		baggageclaimServer *ghttp.Server
+
+		fakeClock *fakeclock.FakeClock
+
+		savedWorker db.SavedWorker
+		timeout     time.Duration
+
+		unblock chan struct{}
+
+		health db.WorkerHealth
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		gardenAddr = fmt.Sprintf("127.0.0.1:%d", 7888+GinkgoParallelNode())
+		fakeGardenBackend = new(gfakes.FakeBackend)
+		gardenServer = server.New("tcp", gardenAddr, 0, fakeGardenBackend, logger)
+		err := gardenServer.Start()
+		Expect(err).NotTo(HaveOccurred())
+
+		baggageclaimServer = ghttp.NewServer()
+		baggageclaimServer.RouteToHandler("GET", "/", ghttp.RespondWith(http.StatusNotFound, nil))
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(1461864115, 0))
+
+		savedWorker = db.SavedWorker{
+			WorkerInfo: db.WorkerInfo{
+				Name:            "some-worker",
+				GardenAddr:      gardenAddr,
+				BaggageclaimURL: baggageclaimServer.URL(),
+			},
+		}
+
+		timeout = time.Minute
+
+		unblock = make(chan struct{})
+	})
+
+	AfterEach(func() {
+		close(unblock)
+
+		gardenServer.Stop()
+
+		Eventually(func() error {
+			conn, err := net.Dial("tcp", gardenAddr)
+			if err == nil {
+				conn.Close()
+			}
+
+			return err
+		}).Should(HaveOccurred())
+
+		baggageclaimServer.Close()
+	})
+
+	JustBeforeEach(func() {
+		health = NewProber(fakeClock, timeout).Probe(logger, savedWorker)
+	})
+
+	Context("when garden and baggageclaim respond", func() {
+		BeforeEach(func() {
+			fakeGardenBackend.CapacityReturns(garden.Capacity{DiskInBytes: 1073741824}, nil)
+		})
+
+		It("reports the worker as healthy", func() {
+			Expect(health.Healthy()).To(BeTrue())
+			Expect(health.CheckedAt).To(Equal(time.Unix(1461864115, 0)))
+		})
+
+		It("records the disk garden reports", func() {
+			Expect(health.DiskInBytes).To(Equal(uint64(1073741824)))
+		})
+
+		It("pings baggageclaim without listing its volumes", func() {
+			Expect(baggageclaimServer.ReceivedRequests()).To(HaveLen(1))
+			Expect(baggageclaimServer.ReceivedRequests()[0].URL.Path).To(Equal("/"))
+		})
+	})
+
+	Context("when garden fails", func() {
+		BeforeEach(func() {
+			fakeGardenBackend.CapacityReturns(garden.Capacity{}, fmt.Errorf("disaster"))
+		})
+
+		It("records the error", func() {
+			Expect(health.Healthy()).To(BeFalse())
+			Expect(health.GardenError).To(ContainSubstring("disaster"))
+			Expect(health.BaggageclaimError).To(BeEmpty())
+		})
+	})
+
+	Context("when garden does not respond in time", func() {
+		BeforeEach(func() {
+			timeout = 100 * time.Millisecond
+
+			fakeGardenBackend.CapacityStub = func() (garden.Capacity, error) {
+				<-unblock
+				return garden.Capacity{}, nil
+			}
+		})
+
+		It("gives up on the request and records the error", func() {
+			Expect(health.Healthy()).To(BeFalse())
+			Expect(health.GardenError).NotTo(BeEmpty())
+			Expect(health.BaggageclaimError).To(BeEmpty())
+		})
+	})
+
+	Context("when baggageclaim fails", func() {
+		BeforeEach(func() {
+			baggageclaimServer.RouteToHandler("GET", "/", ghttp.RespondWith(http.StatusInternalServerError, nil))
+		})
+
+		It("records the error", func() {
+			Expect(health.Healthy()).To(BeFalse())
+			Expect(health.GardenError).To(BeEmpty())
+			Expect(health.BaggageclaimError).NotTo(BeEmpty())
+		})
+	})
+
+	Context("when baggageclaim does not respond in time", func() {
+		BeforeEach(func() {
+			timeout = 100 * time.Millisecond
+
+			baggageclaimServer.RouteToHandler("GET", "/", func(w http.ResponseWriter, r *http.Request) {
+				<-unblock
+			})
+		})
+
+		It("gives up on the request and records the error", func() {
+			Expect(health.Healthy()).To(BeFalse())
+			Expect(health.GardenError).To(BeEmpty())
+			Expect(health.BaggageclaimError).NotTo(BeEmpty())
+		})
+	})
+
+	Context("when the worker has no baggageclaim", func() {
+		BeforeEach(func() {
+			savedWorker.BaggageclaimURL = ""
+		})
+
+		It("only probes garden", func() {
+			Expect(health.Healthy()).To(BeTrue())
+			Expect(baggageclaimServer.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package workerhealth_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestWorkerhealth(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Workerhealth Suite")
+}
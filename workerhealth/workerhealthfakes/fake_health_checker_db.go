@@ -0,0 +1,114 @@
+// This file was generated by counterfeiter
+package workerhealthfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/workerhealth"
+)
+
+type FakeHealthCheckerDB struct {
+	WorkersStub        func() ([]db.SavedWorker, error)
+	workersMutex       sync.RWMutex
+	workersArgsForCall []struct{}
+	workersReturns     struct {
+		result1 []db.SavedWorker
+		result2 error
+	}
+	SaveWorkerHealthStub        func(workerName string, health db.WorkerHealth) error
+	saveWorkerHealthMutex       sync.RWMutex
+	saveWorkerHealthArgsForCall []struct {
+		workerName string
+		health     db.WorkerHealth
+	}
+	saveWorkerHealthReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeHealthCheckerDB) Workers() ([]db.SavedWorker, error) {
+	fake.workersMutex.Lock()
+	fake.workersArgsForCall = append(fake.workersArgsForCall, struct{}{})
+	fake.recordInvocation("Workers", []interface{}{})
+	fake.workersMutex.Unlock()
+	if fake.WorkersStub != nil {
+		return fake.WorkersStub()
+	} else {
+		return fake.workersReturns.result1, fake.workersReturns.result2
+	}
+}
+
+func (fake *FakeHealthCheckerDB) WorkersCallCount() int {
+	fake.workersMutex.RLock()
+	defer fake.workersMutex.RUnlock()
+	return len(fake.workersArgsForCall)
+}
+
+func (fake *FakeHealthCheckerDB) WorkersReturns(result1 []db.SavedWorker, result2 error) {
+	fake.WorkersStub = nil
+	fake.workersReturns = struct {
+		result1 []db.SavedWorker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeHealthCheckerDB) SaveWorkerHealth(workerName string, health db.WorkerHealth) error {
+	fake.saveWorkerHealthMutex.Lock()
+	fake.saveWorkerHealthArgsForCall = append(fake.saveWorkerHealthArgsForCall, struct {
+		workerName string
+		health     db.WorkerHealth
+	}{workerName, health})
+	fake.recordInvocation("SaveWorkerHealth", []interface{}{workerName, health})
+	fake.saveWorkerHealthMutex.Unlock()
+	if fake.SaveWorkerHealthStub != nil {
+		return fake.SaveWorkerHealthStub(workerName, health)
+	} else {
+		return fake.saveWorkerHealthReturns.result1
+	}
+}
+
+func (fake *FakeHealthCheckerDB) SaveWorkerHealthCallCount() int {
+	fake.saveWorkerHealthMutex.RLock()
+	defer fake.saveWorkerHealthMutex.RUnlock()
+	return len(fake.saveWorkerHealthArgsForCall)
+}
+
+func (fake *FakeHealthCheckerDB) SaveWorkerHealthArgsForCall(i int) (string, db.WorkerHealth) {
+	fake.saveWorkerHealthMutex.RLock()
+	defer fake.saveWorkerHealthMutex.RUnlock()
+	return fake.saveWorkerHealthArgsForCall[i].workerName, fake.saveWorkerHealthArgsForCall[i].health
+}
+
+func (fake *FakeHealthCheckerDB) SaveWorkerHealthReturns(result1 error) {
+	fake.SaveWorkerHealthStub = nil
+	fake.saveWorkerHealthReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHealthCheckerDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.workersMutex.RLock()
+	defer fake.workersMutex.RUnlock()
+	fake.saveWorkerHealthMutex.RLock()
+	defer fake.saveWorkerHealthMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeHealthCheckerDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ workerhealth.HealthCheckerDB = new(FakeHealthCheckerDB)
@@ -0,0 +1,80 @@
+// This file was generated by counterfeiter
+package workerhealthfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/workerhealth"
+	"github.com/pivotal-golang/lager"
+)
+
+type FakeProber struct {
+	ProbeStub        func(lager.Logger, db.SavedWorker) db.WorkerHealth
+	probeMutex       sync.RWMutex
+	probeArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 db.SavedWorker
+	}
+	probeReturns struct {
+		result1 db.WorkerHealth
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeProber) Probe(arg1 lager.Logger, arg2 db.SavedWorker) db.WorkerHealth {
+	fake.probeMutex.Lock()
+	fake.probeArgsForCall = append(fake.probeArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 db.SavedWorker
+	}{arg1, arg2})
+	fake.recordInvocation("Probe", []interface{}{arg1, arg2})
+	fake.probeMutex.Unlock()
+	if fake.ProbeStub != nil {
+		return fake.ProbeStub(arg1, arg2)
+	} else {
+		return fake.probeReturns.result1
+	}
+}
+
+func (fake *FakeProber) ProbeCallCount() int {
+	fake.probeMutex.RLock()
+	defer fake.probeMutex.RUnlock()
+	return len(fake.probeArgsForCall)
+}
+
+func (fake *FakeProber) ProbeArgsForCall(i int) (lager.Logger, db.SavedWorker) {
+	fake.probeMutex.RLock()
+	defer fake.probeMutex.RUnlock()
+	return fake.probeArgsForCall[i].arg1, fake.probeArgsForCall[i].arg2
+}
+
+func (fake *FakeProber) ProbeReturns(result1 db.WorkerHealth) {
+	fake.ProbeStub = nil
+	fake.probeReturns = struct {
+		result1 db.WorkerHealth
+	}{result1}
+}
+
+func (fake *FakeProber) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.probeMutex.RLock()
+	defer fake.probeMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeProber) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ workerhealth.Prober = new(FakeProber)
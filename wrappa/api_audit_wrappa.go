@@ -0,0 +1,125 @@
+package wrappa
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/rata"
+)
+
+//go:generate counterfeiter . AuditDB
+
+type AuditDB interface {
+	SaveAuditEvent(event db.AuditEvent) error
+}
+
+type APIAuditWrappa struct {
+	logger lager.Logger
+	db     AuditDB
+}
+
+func NewAPIAuditWrappa(logger lager.Logger, db AuditDB) Wrappa {
+	return APIAuditWrappa{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (wrappa APIAuditWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
+	wrapped := rata.Handlers{}
+
+	for name, handler := range handlers {
+		switch name {
+		// mutating routes
+		case atc.SaveConfig,
+			atc.DeletePipeline,
+			atc.OrderPipelines,
+			atc.PausePipeline,
+			atc.UnpausePipeline,
+			atc.PausePipelineInstances,
+			atc.UnpausePipelineInstances,
+			atc.ArchivePipeline,
+			atc.ArchivePipelineInstances,
+			atc.RenamePipeline,
+			atc.CreateJobBuild,
+			atc.PauseJob,
+			atc.UnpauseJob,
+			atc.ClearJobCaches,
+			atc.PauseResource,
+			atc.UnpauseResource,
+			atc.UnpinResource,
+			atc.CheckResource,
+			atc.CheckResourceWebhook,
+			atc.EnableResourceVersion,
+			atc.DisableResourceVersion,
+			atc.PinResourceVersion,
+			atc.CreateBuild,
+			atc.AbortBuild,
+			atc.HijackContainer,
+			atc.LandWorker,
+			atc.RetireWorker,
+			atc.PruneWorker,
+			atc.SetLogLevel,
+			atc.SetTeam,
+			atc.DeleteTeam,
+			atc.RenameTeam,
+			atc.CreateAPIToken,
+			atc.RevokeAPIToken:
+			wrapped[name] = AuditedHandler{
+				Logger:  wrappa.logger,
+				DB:      wrappa.db,
+				Route:   name,
+				Handler: handler,
+			}
+
+		// read-only routes, and the worker and pipe plumbing used by
+		// workers and the fly CLI
+		case atc.GetConfig,
+			atc.GetBuild,
+			atc.GetBuildPlan,
+			atc.ListBuilds,
+			atc.BuildEvents,
+			atc.BuildResources,
+			atc.GetBuildPreparation,
+			atc.GetJob,
+			atc.ListJobs,
+			atc.ListJobBuilds,
+			atc.ListJobInputs,
+			atc.GetJobBuild,
+			atc.GetVersionsDB,
+			atc.JobBadge,
+			atc.ListResources,
+			atc.GetResource,
+			atc.ListResourceVersions,
+			atc.ListBuildsWithVersionAsInput,
+			atc.ListBuildsWithVersionAsOutput,
+			atc.ListPipelines,
+			atc.GetPipeline,
+			atc.ListPipelineInstances,
+			atc.CreatePipe,
+			atc.WritePipe,
+			atc.ReadPipe,
+			atc.RegisterWorker,
+			atc.ListWorkers,
+			atc.GetLogLevel,
+			atc.DownloadCLI,
+			atc.GetInfo,
+			atc.ListContainers,
+			atc.GetContainer,
+			atc.ListVolumes,
+			atc.ListAuthMethods,
+			atc.GetAuthToken,
+			atc.ListTeams,
+			atc.GetTeam,
+			atc.ListAPITokens,
+			atc.ListAuditEvents:
+			wrapped[name] = handler
+
+		// think about it!
+		default:
+			panic("you missed a spot")
+		}
+	}
+
+	return wrapped
+}
@@ -0,0 +1,113 @@
+package wrappa_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/wrappa"
+	"github.com/concourse/atc/wrappa/wrappafakes"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("APIAuditWrappa", func() {
+	var (
+		logger      *lagertest.TestLogger
+		fakeAuditDB *wrappafakes.FakeAuditDB
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeAuditDB = new(wrappafakes.FakeAuditDB)
+	})
+
+	Describe("Wrap", func() {
+		var (
+			inputHandlers rata.Handlers
+
+			wrappedHandlers rata.Handlers
+		)
+
+		BeforeEach(func() {
+			inputHandlers = rata.Handlers{}
+
+			for _, route := range atc.Routes {
+				inputHandlers[route.Name] = &stupidHandler{}
+			}
+		})
+
+		JustBeforeEach(func() {
+			wrappedHandlers = wrappa.NewAPIAuditWrappa(logger, fakeAuditDB).Wrap(inputHandlers)
+		})
+
+		audited := []string{
+			atc.SaveConfig,
+			atc.DeletePipeline,
+			atc.OrderPipelines,
+			atc.PausePipeline,
+			atc.UnpausePipeline,
+			atc.PausePipelineInstances,
+			atc.UnpausePipelineInstances,
+			atc.ArchivePipeline,
+			atc.ArchivePipelineInstances,
+			atc.RenamePipeline,
+			atc.CreateJobBuild,
+			atc.PauseJob,
+			atc.UnpauseJob,
+			atc.ClearJobCaches,
+			atc.PauseResource,
+			atc.UnpauseResource,
+			atc.UnpinResource,
+			atc.CheckResource,
+			atc.CheckResourceWebhook,
+			atc.EnableResourceVersion,
+			atc.DisableResourceVersion,
+			atc.PinResourceVersion,
+			atc.CreateBuild,
+			atc.AbortBuild,
+			atc.HijackContainer,
+			atc.LandWorker,
+			atc.RetireWorker,
+			atc.PruneWorker,
+			atc.SetLogLevel,
+			atc.SetTeam,
+			atc.DeleteTeam,
+			atc.RenameTeam,
+			atc.CreateAPIToken,
+			atc.RevokeAPIToken,
+		}
+
+		It("audits mutating routes and leaves the rest alone", func() {
+			for name := range inputHandlers {
+				expectedHandler := inputHandlers[name]
+				for _, auditedName := range audited {
+					if name == auditedName {
+						expectedHandler = wrappa.AuditedHandler{
+							Logger:  logger,
+							DB:      fakeAuditDB,
+							Route:   name,
+							Handler: inputHandlers[name],
+						}
+					}
+				}
+
+				Expect(descriptiveRoute{
+					route:   name,
+					handler: wrappedHandlers[name],
+				}).To(Equal(descriptiveRoute{
+					route:   name,
+					handler: expectedHandler,
+				}))
+			}
+		})
+
+		It("panics on routes it does not know about", func() {
+			Expect(func() {
+				wrappa.NewAPIAuditWrappa(logger, fakeAuditDB).Wrap(rata.Handlers{
+					"SomeNewRoute": &stupidHandler{},
+				})
+			}).To(Panic())
+		})
+	})
+})
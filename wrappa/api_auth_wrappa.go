@@ -35,64 +35,107 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		switch name {
 		// authenticated
 		case atc.GetAuthToken,
-			atc.AbortBuild,
-			atc.CreateBuild,
-			atc.CreatePipe,
-			atc.DeletePipeline,
-			atc.DisableResourceVersion,
-			atc.EnableResourceVersion,
-			atc.GetConfig,
 			atc.GetContainer,
-			atc.HijackContainer,
 			atc.ListContainers,
-			atc.ListJobInputs,
 			atc.ListWorkers,
+			atc.RegisterWorker,
+			atc.LandWorker,
+			atc.RetireWorker,
+			atc.PruneWorker,
+			atc.SetLogLevel,
+			atc.ListTeams,
+			atc.ListVolumes:
+			newHandler = auth.CheckAuthHandler(handler, rejector)
+
+		// authenticated as at least a member of their team
+		case atc.AbortBuild,
+			atc.CreateBuild,
+			atc.CreatePipe,
+			atc.HijackContainer,
+			atc.ReadPipe,
+			atc.WritePipe:
+			newHandler = auth.CheckRoleHandler(handler, rejector, atc.RoleMember)
+
+		// authenticated as an owner of their team
+		case atc.SetTeam,
+			atc.DeleteTeam,
+			atc.RenameTeam,
+			atc.ListAuditEvents:
+			newHandler = auth.CheckRoleHandler(handler, rejector, atc.RoleOwner)
+
+		// authorized for the requested team as an owner
+		case atc.DeletePipeline,
+			atc.ArchivePipeline,
+			atc.ArchivePipelineInstances,
 			atc.OrderPipelines,
+			atc.RenamePipeline,
+			atc.SaveConfig,
+			atc.ListAPITokens,
+			atc.CreateAPIToken,
+			atc.RevokeAPIToken:
+			newHandler = auth.CheckAuthorizationHandler(handler, rejector, atc.RoleOwner)
+
+		// authorized for the requested team as at least a member
+		case atc.DisableResourceVersion,
+			atc.EnableResourceVersion,
+			atc.PinResourceVersion,
 			atc.PauseJob,
 			atc.PausePipeline,
+			atc.PausePipelineInstances,
 			atc.PauseResource,
-			atc.ReadPipe,
-			atc.RegisterWorker,
-			atc.SaveConfig,
-			atc.SetLogLevel,
-			atc.SetTeam,
 			atc.UnpauseJob,
+			atc.ClearJobCaches,
 			atc.UnpausePipeline,
+			atc.UnpausePipelineInstances,
 			atc.UnpauseResource,
+			atc.UnpinResource,
 			atc.CheckResource,
-			atc.WritePipe,
-			atc.ListVolumes,
+			atc.CreateJobBuild:
+			newHandler = auth.CheckAuthorizationHandler(handler, rejector, atc.RoleMember)
+
+		// authorized for the requested team
+		case atc.GetConfig,
+			atc.ListJobInputs,
 			atc.GetVersionsDB,
-			atc.CreateJobBuild,
-			atc.RenamePipeline:
-			newHandler = auth.CheckAuthHandler(handler, rejector)
+			atc.GetTeam:
+			newHandler = auth.CheckAuthorizationHandler(handler, rejector, atc.RoleViewer)
 
 		// unauthenticated
 		case atc.ListAuthMethods, atc.GetInfo:
 
+		// authorized by the resource's webhook token
+		case atc.CheckResourceWebhook:
+
 		// unauthenticated if publicly viewable
 		case atc.BuildEvents,
 			atc.DownloadCLI,
 			atc.GetBuild,
-			atc.GetJobBuild,
 			atc.BuildResources,
+			atc.GetLogLevel,
+			atc.ListBuilds,
+			atc.GetBuildPlan,
+			atc.GetBuildPreparation:
+			if !wrappa.PubliclyViewable {
+				newHandler = auth.CheckAuthHandler(handler, rejector)
+			}
+
+		// unauthenticated if publicly viewable, otherwise authorized for the
+		// requested team
+		case atc.GetJobBuild,
 			atc.GetJob,
 			atc.JobBadge,
-			atc.GetLogLevel,
 			atc.GetResource,
 			atc.ListResourceVersions,
-			atc.ListBuilds,
 			atc.ListBuildsWithVersionAsInput,
 			atc.ListBuildsWithVersionAsOutput,
 			atc.ListJobBuilds,
 			atc.ListJobs,
 			atc.ListPipelines,
+			atc.ListPipelineInstances,
 			atc.GetPipeline,
-			atc.ListResources,
-			atc.GetBuildPlan,
-			atc.GetBuildPreparation:
+			atc.ListResources:
 			if !wrappa.PubliclyViewable {
-				newHandler = auth.CheckAuthHandler(handler, rejector)
+				newHandler = auth.CheckAuthorizationHandler(handler, rejector, atc.RoleViewer)
 			}
 
 		// think about it!
@@ -45,6 +45,30 @@ var _ = Describe("APIAuthWrappa", func() {
 		)
 	}
 
+	roled := func(handler http.Handler, role string) http.Handler {
+		return auth.WrapHandler(
+			auth.CheckRoleHandler(
+				handler,
+				auth.UnauthorizedRejector{},
+				role,
+			),
+			fakeValidator,
+			fakeUserContextReader,
+		)
+	}
+
+	authorized := func(handler http.Handler, role string) http.Handler {
+		return auth.WrapHandler(
+			auth.CheckAuthorizationHandler(
+				handler,
+				auth.UnauthorizedRejector{},
+				role,
+			),
+			fakeValidator,
+			fakeUserContextReader,
+		)
+	}
+
 	Describe("Wrap", func() {
 		var (
 			inputHandlers rata.Handlers
@@ -75,37 +99,55 @@ var _ = Describe("APIAuthWrappa", func() {
 				publiclyViewable = true
 
 				expectedHandlers = rata.Handlers{
-					atc.AbortBuild:             authed(inputHandlers[atc.AbortBuild]),
-					atc.CreateBuild:            authed(inputHandlers[atc.CreateBuild]),
-					atc.CreateJobBuild:         authed(inputHandlers[atc.CreateJobBuild]),
-					atc.CreatePipe:             authed(inputHandlers[atc.CreatePipe]),
-					atc.DeletePipeline:         authed(inputHandlers[atc.DeletePipeline]),
-					atc.DisableResourceVersion: authed(inputHandlers[atc.DisableResourceVersion]),
-					atc.EnableResourceVersion:  authed(inputHandlers[atc.EnableResourceVersion]),
-					atc.GetAuthToken:           authed(inputHandlers[atc.GetAuthToken]),
-					atc.GetConfig:              authed(inputHandlers[atc.GetConfig]),
-					atc.GetContainer:           authed(inputHandlers[atc.GetContainer]),
-					atc.GetVersionsDB:          authed(inputHandlers[atc.GetVersionsDB]),
-					atc.HijackContainer:        authed(inputHandlers[atc.HijackContainer]),
-					atc.ListContainers:         authed(inputHandlers[atc.ListContainers]),
-					atc.ListJobInputs:          authed(inputHandlers[atc.ListJobInputs]),
-					atc.ListVolumes:            authed(inputHandlers[atc.ListVolumes]),
-					atc.ListWorkers:            authed(inputHandlers[atc.ListWorkers]),
-					atc.OrderPipelines:         authed(inputHandlers[atc.OrderPipelines]),
-					atc.PauseJob:               authed(inputHandlers[atc.PauseJob]),
-					atc.PausePipeline:          authed(inputHandlers[atc.PausePipeline]),
-					atc.PauseResource:          authed(inputHandlers[atc.PauseResource]),
-					atc.CheckResource:          authed(inputHandlers[atc.CheckResource]),
-					atc.ReadPipe:               authed(inputHandlers[atc.ReadPipe]),
-					atc.RegisterWorker:         authed(inputHandlers[atc.RegisterWorker]),
-					atc.SaveConfig:             authed(inputHandlers[atc.SaveConfig]),
-					atc.SetLogLevel:            authed(inputHandlers[atc.SetLogLevel]),
-					atc.SetTeam:                authed(inputHandlers[atc.SetTeam]),
-					atc.UnpauseJob:             authed(inputHandlers[atc.UnpauseJob]),
-					atc.UnpausePipeline:        authed(inputHandlers[atc.UnpausePipeline]),
-					atc.UnpauseResource:        authed(inputHandlers[atc.UnpauseResource]),
-					atc.WritePipe:              authed(inputHandlers[atc.WritePipe]),
-					atc.RenamePipeline:         authed(inputHandlers[atc.RenamePipeline]),
+					atc.AbortBuild:               roled(inputHandlers[atc.AbortBuild], atc.RoleMember),
+					atc.CreateBuild:              roled(inputHandlers[atc.CreateBuild], atc.RoleMember),
+					atc.CreateJobBuild:           authorized(inputHandlers[atc.CreateJobBuild], atc.RoleMember),
+					atc.CreatePipe:               roled(inputHandlers[atc.CreatePipe], atc.RoleMember),
+					atc.DeletePipeline:           authorized(inputHandlers[atc.DeletePipeline], atc.RoleOwner),
+					atc.ArchivePipeline:          authorized(inputHandlers[atc.ArchivePipeline], atc.RoleOwner),
+					atc.ArchivePipelineInstances: authorized(inputHandlers[atc.ArchivePipelineInstances], atc.RoleOwner),
+					atc.DisableResourceVersion:   authorized(inputHandlers[atc.DisableResourceVersion], atc.RoleMember),
+					atc.PinResourceVersion:       authorized(inputHandlers[atc.PinResourceVersion], atc.RoleMember),
+					atc.EnableResourceVersion:    authorized(inputHandlers[atc.EnableResourceVersion], atc.RoleMember),
+					atc.GetAuthToken:             authed(inputHandlers[atc.GetAuthToken]),
+					atc.GetConfig:                authorized(inputHandlers[atc.GetConfig], atc.RoleViewer),
+					atc.GetContainer:             authed(inputHandlers[atc.GetContainer]),
+					atc.GetVersionsDB:            authorized(inputHandlers[atc.GetVersionsDB], atc.RoleViewer),
+					atc.HijackContainer:          roled(inputHandlers[atc.HijackContainer], atc.RoleMember),
+					atc.ListContainers:           authed(inputHandlers[atc.ListContainers]),
+					atc.ListJobInputs:            authorized(inputHandlers[atc.ListJobInputs], atc.RoleViewer),
+					atc.ListVolumes:              authed(inputHandlers[atc.ListVolumes]),
+					atc.ListWorkers:              authed(inputHandlers[atc.ListWorkers]),
+					atc.OrderPipelines:           authorized(inputHandlers[atc.OrderPipelines], atc.RoleOwner),
+					atc.PauseJob:                 authorized(inputHandlers[atc.PauseJob], atc.RoleMember),
+					atc.PausePipeline:            authorized(inputHandlers[atc.PausePipeline], atc.RoleMember),
+					atc.PausePipelineInstances:   authorized(inputHandlers[atc.PausePipelineInstances], atc.RoleMember),
+					atc.PauseResource:            authorized(inputHandlers[atc.PauseResource], atc.RoleMember),
+					atc.CheckResource:            authorized(inputHandlers[atc.CheckResource], atc.RoleMember),
+					atc.ReadPipe:                 roled(inputHandlers[atc.ReadPipe], atc.RoleMember),
+					atc.RegisterWorker:           authed(inputHandlers[atc.RegisterWorker]),
+					atc.LandWorker:               authed(inputHandlers[atc.LandWorker]),
+					atc.RetireWorker:             authed(inputHandlers[atc.RetireWorker]),
+					atc.PruneWorker:              authed(inputHandlers[atc.PruneWorker]),
+					atc.SaveConfig:               authorized(inputHandlers[atc.SaveConfig], atc.RoleOwner),
+					atc.SetLogLevel:              authed(inputHandlers[atc.SetLogLevel]),
+					atc.ListTeams:                authed(inputHandlers[atc.ListTeams]),
+					atc.GetTeam:                  authorized(inputHandlers[atc.GetTeam], atc.RoleViewer),
+					atc.SetTeam:                  roled(inputHandlers[atc.SetTeam], atc.RoleOwner),
+					atc.DeleteTeam:               roled(inputHandlers[atc.DeleteTeam], atc.RoleOwner),
+					atc.RenameTeam:               roled(inputHandlers[atc.RenameTeam], atc.RoleOwner),
+					atc.UnpauseJob:               authorized(inputHandlers[atc.UnpauseJob], atc.RoleMember),
+					atc.ClearJobCaches:           authorized(inputHandlers[atc.ClearJobCaches], atc.RoleMember),
+					atc.UnpausePipeline:          authorized(inputHandlers[atc.UnpausePipeline], atc.RoleMember),
+					atc.UnpausePipelineInstances: authorized(inputHandlers[atc.UnpausePipelineInstances], atc.RoleMember),
+					atc.UnpauseResource:          authorized(inputHandlers[atc.UnpauseResource], atc.RoleMember),
+					atc.UnpinResource:            authorized(inputHandlers[atc.UnpinResource], atc.RoleMember),
+					atc.WritePipe:                roled(inputHandlers[atc.WritePipe], atc.RoleMember),
+					atc.RenamePipeline:           authorized(inputHandlers[atc.RenamePipeline], atc.RoleOwner),
+					atc.ListAPITokens:            authorized(inputHandlers[atc.ListAPITokens], atc.RoleOwner),
+					atc.CreateAPIToken:           authorized(inputHandlers[atc.CreateAPIToken], atc.RoleOwner),
+					atc.RevokeAPIToken:           authorized(inputHandlers[atc.RevokeAPIToken], atc.RoleOwner),
+					atc.ListAuditEvents:          roled(inputHandlers[atc.ListAuditEvents], atc.RoleOwner),
 
 					atc.BuildEvents:                   unauthed(inputHandlers[atc.BuildEvents]),
 					atc.BuildResources:                unauthed(inputHandlers[atc.BuildResources]),
@@ -114,6 +156,7 @@ var _ = Describe("APIAuthWrappa", func() {
 					atc.GetBuildPlan:                  unauthed(inputHandlers[atc.GetBuildPlan]),
 					atc.GetBuildPreparation:           unauthed(inputHandlers[atc.GetBuildPreparation]),
 					atc.GetInfo:                       unauthed(inputHandlers[atc.GetInfo]),
+					atc.CheckResourceWebhook:          unauthed(inputHandlers[atc.CheckResourceWebhook]),
 					atc.GetJob:                        unauthed(inputHandlers[atc.GetJob]),
 					atc.GetJobBuild:                   unauthed(inputHandlers[atc.GetJobBuild]),
 					atc.GetLogLevel:                   unauthed(inputHandlers[atc.GetLogLevel]),
@@ -127,6 +170,7 @@ var _ = Describe("APIAuthWrappa", func() {
 					atc.ListJobBuilds:                 unauthed(inputHandlers[atc.ListJobBuilds]),
 					atc.ListJobs:                      unauthed(inputHandlers[atc.ListJobs]),
 					atc.ListPipelines:                 unauthed(inputHandlers[atc.ListPipelines]),
+					atc.ListPipelineInstances:         unauthed(inputHandlers[atc.ListPipelineInstances]),
 					atc.ListResourceVersions:          unauthed(inputHandlers[atc.ListResourceVersions]),
 					atc.ListResources:                 unauthed(inputHandlers[atc.ListResources]),
 				}
@@ -152,60 +196,80 @@ var _ = Describe("APIAuthWrappa", func() {
 				publiclyViewable = false
 
 				expectedHandlers = rata.Handlers{
-					atc.AbortBuild:             authed(inputHandlers[atc.AbortBuild]),
-					atc.CreateBuild:            authed(inputHandlers[atc.CreateBuild]),
-					atc.CreateJobBuild:         authed(inputHandlers[atc.CreateJobBuild]),
-					atc.CreatePipe:             authed(inputHandlers[atc.CreatePipe]),
-					atc.DeletePipeline:         authed(inputHandlers[atc.DeletePipeline]),
-					atc.DisableResourceVersion: authed(inputHandlers[atc.DisableResourceVersion]),
-					atc.EnableResourceVersion:  authed(inputHandlers[atc.EnableResourceVersion]),
-					atc.GetAuthToken:           authed(inputHandlers[atc.GetAuthToken]),
-					atc.GetConfig:              authed(inputHandlers[atc.GetConfig]),
-					atc.GetContainer:           authed(inputHandlers[atc.GetContainer]),
-					atc.GetVersionsDB:          authed(inputHandlers[atc.GetVersionsDB]),
-					atc.HijackContainer:        authed(inputHandlers[atc.HijackContainer]),
-					atc.ListContainers:         authed(inputHandlers[atc.ListContainers]),
-					atc.ListJobInputs:          authed(inputHandlers[atc.ListJobInputs]),
-					atc.ListVolumes:            authed(inputHandlers[atc.ListVolumes]),
-					atc.ListWorkers:            authed(inputHandlers[atc.ListWorkers]),
-					atc.OrderPipelines:         authed(inputHandlers[atc.OrderPipelines]),
-					atc.PauseJob:               authed(inputHandlers[atc.PauseJob]),
-					atc.PausePipeline:          authed(inputHandlers[atc.PausePipeline]),
-					atc.PauseResource:          authed(inputHandlers[atc.PauseResource]),
-					atc.CheckResource:          authed(inputHandlers[atc.CheckResource]),
-					atc.ReadPipe:               authed(inputHandlers[atc.ReadPipe]),
-					atc.RegisterWorker:         authed(inputHandlers[atc.RegisterWorker]),
-					atc.SaveConfig:             authed(inputHandlers[atc.SaveConfig]),
-					atc.SetLogLevel:            authed(inputHandlers[atc.SetLogLevel]),
-					atc.SetTeam:                authed(inputHandlers[atc.SetTeam]),
-					atc.UnpauseJob:             authed(inputHandlers[atc.UnpauseJob]),
-					atc.UnpausePipeline:        authed(inputHandlers[atc.UnpausePipeline]),
-					atc.UnpauseResource:        authed(inputHandlers[atc.UnpauseResource]),
-					atc.WritePipe:              authed(inputHandlers[atc.WritePipe]),
-					atc.RenamePipeline:         authed(inputHandlers[atc.RenamePipeline]),
-
-					atc.ListAuthMethods: unauthed(inputHandlers[atc.ListAuthMethods]),
-					atc.GetInfo:         unauthed(inputHandlers[atc.GetInfo]),
+					atc.AbortBuild:               roled(inputHandlers[atc.AbortBuild], atc.RoleMember),
+					atc.CreateBuild:              roled(inputHandlers[atc.CreateBuild], atc.RoleMember),
+					atc.CreateJobBuild:           authorized(inputHandlers[atc.CreateJobBuild], atc.RoleMember),
+					atc.CreatePipe:               roled(inputHandlers[atc.CreatePipe], atc.RoleMember),
+					atc.DeletePipeline:           authorized(inputHandlers[atc.DeletePipeline], atc.RoleOwner),
+					atc.ArchivePipeline:          authorized(inputHandlers[atc.ArchivePipeline], atc.RoleOwner),
+					atc.ArchivePipelineInstances: authorized(inputHandlers[atc.ArchivePipelineInstances], atc.RoleOwner),
+					atc.DisableResourceVersion:   authorized(inputHandlers[atc.DisableResourceVersion], atc.RoleMember),
+					atc.PinResourceVersion:       authorized(inputHandlers[atc.PinResourceVersion], atc.RoleMember),
+					atc.EnableResourceVersion:    authorized(inputHandlers[atc.EnableResourceVersion], atc.RoleMember),
+					atc.GetAuthToken:             authed(inputHandlers[atc.GetAuthToken]),
+					atc.GetConfig:                authorized(inputHandlers[atc.GetConfig], atc.RoleViewer),
+					atc.GetContainer:             authed(inputHandlers[atc.GetContainer]),
+					atc.GetVersionsDB:            authorized(inputHandlers[atc.GetVersionsDB], atc.RoleViewer),
+					atc.HijackContainer:          roled(inputHandlers[atc.HijackContainer], atc.RoleMember),
+					atc.ListContainers:           authed(inputHandlers[atc.ListContainers]),
+					atc.ListJobInputs:            authorized(inputHandlers[atc.ListJobInputs], atc.RoleViewer),
+					atc.ListVolumes:              authed(inputHandlers[atc.ListVolumes]),
+					atc.ListWorkers:              authed(inputHandlers[atc.ListWorkers]),
+					atc.OrderPipelines:           authorized(inputHandlers[atc.OrderPipelines], atc.RoleOwner),
+					atc.PauseJob:                 authorized(inputHandlers[atc.PauseJob], atc.RoleMember),
+					atc.PausePipeline:            authorized(inputHandlers[atc.PausePipeline], atc.RoleMember),
+					atc.PausePipelineInstances:   authorized(inputHandlers[atc.PausePipelineInstances], atc.RoleMember),
+					atc.PauseResource:            authorized(inputHandlers[atc.PauseResource], atc.RoleMember),
+					atc.CheckResource:            authorized(inputHandlers[atc.CheckResource], atc.RoleMember),
+					atc.ReadPipe:                 roled(inputHandlers[atc.ReadPipe], atc.RoleMember),
+					atc.RegisterWorker:           authed(inputHandlers[atc.RegisterWorker]),
+					atc.LandWorker:               authed(inputHandlers[atc.LandWorker]),
+					atc.RetireWorker:             authed(inputHandlers[atc.RetireWorker]),
+					atc.PruneWorker:              authed(inputHandlers[atc.PruneWorker]),
+					atc.SaveConfig:               authorized(inputHandlers[atc.SaveConfig], atc.RoleOwner),
+					atc.SetLogLevel:              authed(inputHandlers[atc.SetLogLevel]),
+					atc.ListTeams:                authed(inputHandlers[atc.ListTeams]),
+					atc.GetTeam:                  authorized(inputHandlers[atc.GetTeam], atc.RoleViewer),
+					atc.SetTeam:                  roled(inputHandlers[atc.SetTeam], atc.RoleOwner),
+					atc.DeleteTeam:               roled(inputHandlers[atc.DeleteTeam], atc.RoleOwner),
+					atc.RenameTeam:               roled(inputHandlers[atc.RenameTeam], atc.RoleOwner),
+					atc.UnpauseJob:               authorized(inputHandlers[atc.UnpauseJob], atc.RoleMember),
+					atc.ClearJobCaches:           authorized(inputHandlers[atc.ClearJobCaches], atc.RoleMember),
+					atc.UnpausePipeline:          authorized(inputHandlers[atc.UnpausePipeline], atc.RoleMember),
+					atc.UnpausePipelineInstances: authorized(inputHandlers[atc.UnpausePipelineInstances], atc.RoleMember),
+					atc.UnpauseResource:          authorized(inputHandlers[atc.UnpauseResource], atc.RoleMember),
+					atc.UnpinResource:            authorized(inputHandlers[atc.UnpinResource], atc.RoleMember),
+					atc.WritePipe:                roled(inputHandlers[atc.WritePipe], atc.RoleMember),
+					atc.RenamePipeline:           authorized(inputHandlers[atc.RenamePipeline], atc.RoleOwner),
+					atc.ListAPITokens:            authorized(inputHandlers[atc.ListAPITokens], atc.RoleOwner),
+					atc.CreateAPIToken:           authorized(inputHandlers[atc.CreateAPIToken], atc.RoleOwner),
+					atc.RevokeAPIToken:           authorized(inputHandlers[atc.RevokeAPIToken], atc.RoleOwner),
+					atc.ListAuditEvents:          roled(inputHandlers[atc.ListAuditEvents], atc.RoleOwner),
+
+					atc.ListAuthMethods:      unauthed(inputHandlers[atc.ListAuthMethods]),
+					atc.GetInfo:              unauthed(inputHandlers[atc.GetInfo]),
+					atc.CheckResourceWebhook: unauthed(inputHandlers[atc.CheckResourceWebhook]),
 
 					atc.BuildEvents:                   authed(inputHandlers[atc.BuildEvents]),
 					atc.BuildResources:                authed(inputHandlers[atc.BuildResources]),
 					atc.DownloadCLI:                   authed(inputHandlers[atc.DownloadCLI]),
 					atc.GetBuild:                      authed(inputHandlers[atc.GetBuild]),
 					atc.GetBuildPreparation:           authed(inputHandlers[atc.GetBuildPreparation]),
-					atc.GetJob:                        authed(inputHandlers[atc.GetJob]),
-					atc.GetJobBuild:                   authed(inputHandlers[atc.GetJobBuild]),
+					atc.GetJob:                        authorized(inputHandlers[atc.GetJob], atc.RoleViewer),
+					atc.GetJobBuild:                   authorized(inputHandlers[atc.GetJobBuild], atc.RoleViewer),
 					atc.GetLogLevel:                   authed(inputHandlers[atc.GetLogLevel]),
-					atc.GetPipeline:                   authed(inputHandlers[atc.GetPipeline]),
-					atc.GetResource:                   authed(inputHandlers[atc.GetResource]),
-					atc.JobBadge:                      authed(inputHandlers[atc.JobBadge]),
+					atc.GetPipeline:                   authorized(inputHandlers[atc.GetPipeline], atc.RoleViewer),
+					atc.GetResource:                   authorized(inputHandlers[atc.GetResource], atc.RoleViewer),
+					atc.JobBadge:                      authorized(inputHandlers[atc.JobBadge], atc.RoleViewer),
 					atc.ListBuilds:                    authed(inputHandlers[atc.ListBuilds]),
-					atc.ListBuildsWithVersionAsInput:  authed(inputHandlers[atc.ListBuildsWithVersionAsInput]),
-					atc.ListBuildsWithVersionAsOutput: authed(inputHandlers[atc.ListBuildsWithVersionAsOutput]),
-					atc.ListJobBuilds:                 authed(inputHandlers[atc.ListJobBuilds]),
-					atc.ListJobs:                      authed(inputHandlers[atc.ListJobs]),
-					atc.ListPipelines:                 authed(inputHandlers[atc.ListPipelines]),
-					atc.ListResourceVersions:          authed(inputHandlers[atc.ListResourceVersions]),
-					atc.ListResources:                 authed(inputHandlers[atc.ListResources]),
+					atc.ListBuildsWithVersionAsInput:  authorized(inputHandlers[atc.ListBuildsWithVersionAsInput], atc.RoleViewer),
+					atc.ListBuildsWithVersionAsOutput: authorized(inputHandlers[atc.ListBuildsWithVersionAsOutput], atc.RoleViewer),
+					atc.ListJobBuilds:                 authorized(inputHandlers[atc.ListJobBuilds], atc.RoleViewer),
+					atc.ListJobs:                      authorized(inputHandlers[atc.ListJobs], atc.RoleViewer),
+					atc.ListPipelines:                 authorized(inputHandlers[atc.ListPipelines], atc.RoleViewer),
+					atc.ListPipelineInstances:         authorized(inputHandlers[atc.ListPipelineInstances], atc.RoleViewer),
+					atc.ListResourceVersions:          authorized(inputHandlers[atc.ListResourceVersions], atc.RoleViewer),
+					atc.ListResources:                 authorized(inputHandlers[atc.ListResources], atc.RoleViewer),
 					atc.GetBuildPlan:                  authed(inputHandlers[atc.GetBuildPlan]),
 				}
 			})
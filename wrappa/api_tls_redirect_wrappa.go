@@ -39,6 +39,7 @@ func (wrappa *APITLSRedirectWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetJobBuild,
 			atc.JobBadge,
 			atc.ListPipelines,
+			atc.ListPipelineInstances,
 			atc.GetPipeline,
 			atc.GetVersionsDB,
 			atc.ListResources,
@@ -55,7 +56,11 @@ func (wrappa *APITLSRedirectWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.HijackContainer,
 			atc.ListVolumes,
 			atc.ListAuthMethods,
-			atc.GetAuthToken:
+			atc.GetAuthToken,
+			atc.ListTeams,
+			atc.GetTeam,
+			atc.ListAPITokens,
+			atc.ListAuditEvents:
 			newHandler = RedirectingAPIHandler(wrappa.externalHost)
 
 			//except ReadPipe
@@ -64,23 +69,38 @@ func (wrappa *APITLSRedirectWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.AbortBuild,
 			atc.CreateJobBuild,
 			atc.CheckResource,
+			atc.CheckResourceWebhook,
 			atc.CreatePipe,
 			atc.RegisterWorker,
+			atc.LandWorker,
+			atc.RetireWorker,
+			atc.PruneWorker,
 			atc.DeletePipeline,
 			atc.SaveConfig,
 			atc.PauseJob,
 			atc.UnpauseJob,
+			atc.ClearJobCaches,
 			atc.OrderPipelines,
 			atc.PausePipeline,
 			atc.UnpausePipeline,
+			atc.PausePipelineInstances,
+			atc.UnpausePipelineInstances,
+			atc.ArchivePipeline,
+			atc.ArchivePipelineInstances,
 			atc.RenamePipeline,
 			atc.PauseResource,
 			atc.UnpauseResource,
+			atc.UnpinResource,
 			atc.EnableResourceVersion,
 			atc.DisableResourceVersion,
+			atc.PinResourceVersion,
 			atc.WritePipe,
 			atc.SetLogLevel,
-			atc.SetTeam:
+			atc.SetTeam,
+			atc.DeleteTeam,
+			atc.RenameTeam,
+			atc.CreateAPIToken,
+			atc.RevokeAPIToken:
 
 		default:
 			panic("you missed a spot")
@@ -0,0 +1,130 @@
+package wrappa
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+)
+
+// AuditedHandler records an audit event, including the status the request
+// was answered with, as soon as the wrapped handler starts responding. That
+// way requests that are hijacked, e.g. to stream into a container, are
+// recorded when they start rather than when they end. It must wrap the auth
+// handlers so that requests they reject are recorded as well; by the time a
+// response starts, they have read the auth context from the request.
+type AuditedHandler struct {
+	Logger  lager.Logger
+	DB      AuditDB
+	Route   string
+	Handler http.Handler
+}
+
+func (handler AuditedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	recorder := &statusRecorder{
+		ResponseWriter: w,
+		responding: func(status int) {
+			handler.saveEvent(r, status)
+		},
+	}
+
+	handler.Handler.ServeHTTP(recorder, r)
+
+	// handlers that write nothing respond with 200 OK
+	recorder.respond(http.StatusOK)
+}
+
+func (handler AuditedHandler) saveEvent(r *http.Request, status int) {
+	event := db.AuditEvent{
+		TeamName:     r.FormValue(":team_name"),
+		UserName:     requestUser(r),
+		Route:        handler.Route,
+		PipelineName: r.FormValue(":pipeline_name"),
+		JobName:      r.FormValue(":job_name"),
+		ResourceName: r.FormValue(":resource_name"),
+		Status:       status,
+	}
+
+	authTeamName, authTeamID, _, found := auth.GetTeam(r)
+
+	if event.TeamName == "" {
+		// routes that are not scoped to a team act on the caller's team
+		if found {
+			event.TeamName = authTeamName
+			event.TeamID = authTeamID
+		} else {
+			event.TeamName = auth.GetRequestedTeamName(r)
+		}
+	} else if found && strings.EqualFold(event.TeamName, authTeamName) {
+		event.TeamID = authTeamID
+	}
+
+	err := handler.DB.SaveAuditEvent(event)
+	if err != nil {
+		handler.Logger.Error("failed-to-save-audit-event", err, lager.Data{
+			"route": handler.Route,
+		})
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+
+	responding func(status int)
+	responded  bool
+}
+
+// respond reports the status the request is answered with, the first time it
+// is called.
+func (recorder *statusRecorder) respond(status int) {
+	if recorder.responded {
+		return
+	}
+
+	recorder.responded = true
+	recorder.responding(status)
+}
+
+func (recorder *statusRecorder) WriteHeader(code int) {
+	recorder.respond(code)
+	recorder.ResponseWriter.WriteHeader(code)
+}
+
+func (recorder *statusRecorder) Write(body []byte) (int, error) {
+	recorder.respond(http.StatusOK)
+	return recorder.ResponseWriter.Write(body)
+}
+
+// Hijack is passed through so that audited websocket routes, e.g. hijacking
+// a container, keep working. The request is recorded as switching protocols
+// before the connection is handed over, as the handler may hold on to it
+// indefinitely.
+func (recorder *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := recorder.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+
+	recorder.respond(http.StatusSwitchingProtocols)
+
+	return hijacker.Hijack()
+}
+
+func requestUser(r *http.Request) string {
+	user, found := auth.GetUser(r)
+	if found {
+		return user
+	}
+
+	username, _, ok := r.BasicAuth()
+	if ok {
+		return "basic:" + username
+	}
+
+	return ""
+}
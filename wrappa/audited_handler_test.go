@@ -0,0 +1,239 @@
+package wrappa_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/wrappa"
+	"github.com/concourse/atc/wrappa/wrappafakes"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditedHandler", func() {
+	var (
+		fakeAuditDB           *wrappafakes.FakeAuditDB
+		fakeValidator         *authfakes.FakeValidator
+		fakeUserContextReader *authfakes.FakeUserContextReader
+
+		handled           bool
+		savedBeforeHandle int
+		savedAfterRespond int
+		handlerStatus     int
+		hijack            bool
+
+		server *httptest.Server
+		client *http.Client
+
+		route   string
+		params  rata.Params
+		request *http.Request
+	)
+
+	BeforeEach(func() {
+		fakeAuditDB = new(wrappafakes.FakeAuditDB)
+		fakeValidator = new(authfakes.FakeValidator)
+		fakeUserContextReader = new(authfakes.FakeUserContextReader)
+
+		fakeValidator.IsAuthenticatedReturns(true)
+
+		handled = false
+		savedBeforeHandle = 0
+		savedAfterRespond = 0
+		handlerStatus = http.StatusOK
+		hijack = false
+
+		route = atc.PausePipeline
+		params = rata.Params{"team_name": "some-team", "pipeline_name": "some-pipeline"}
+
+		client = &http.Client{
+			Transport: &http.Transport{},
+		}
+	})
+
+	JustBeforeEach(func() {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handled = true
+			savedBeforeHandle = fakeAuditDB.SaveAuditEventCallCount()
+
+			if hijack {
+				conn, _, err := w.(http.Hijacker).Hijack()
+				Expect(err).NotTo(HaveOccurred())
+
+				savedAfterRespond = fakeAuditDB.SaveAuditEventCallCount()
+
+				_, err = conn.Write([]byte("HTTP/1.1 101 Switching Protocols\r\nUpgrade: test\r\nConnection: Upgrade\r\n\r\n"))
+				Expect(err).NotTo(HaveOccurred())
+
+				conn.Close()
+				return
+			}
+
+			w.WriteHeader(handlerStatus)
+			savedAfterRespond = fakeAuditDB.SaveAuditEventCallCount()
+		})
+
+		handlers := rata.Handlers{
+			route: wrappa.AuditedHandler{
+				Logger: lagertest.NewTestLogger("test"),
+				DB:     fakeAuditDB,
+				Route:  route,
+				Handler: auth.WrapHandler(
+					auth.CheckAuthHandler(handler, auth.UnauthorizedRejector{}),
+					fakeValidator,
+					fakeUserContextReader,
+				),
+			},
+		}
+
+		router, err := rata.NewRouter(atc.Routes, handlers)
+		Expect(err).NotTo(HaveOccurred())
+
+		server = httptest.NewServer(router)
+
+		request, err = rata.NewRequestGenerator(server.URL, atc.Routes).CreateRequest(route, params, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		response, err := client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(handlerStatus))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when the request carries a user", func() {
+		BeforeEach(func() {
+			fakeUserContextReader.GetTeamReturns("some-team", 1, false, true)
+			fakeUserContextReader.GetUserReturns("github:some-user", true)
+		})
+
+		It("records who did what", func() {
+			Expect(fakeAuditDB.SaveAuditEventCallCount()).To(Equal(1))
+			Expect(fakeAuditDB.SaveAuditEventArgsForCall(0)).To(Equal(db.AuditEvent{
+				TeamName:     "some-team",
+				TeamID:       1,
+				UserName:     "github:some-user",
+				Route:        atc.PausePipeline,
+				PipelineName: "some-pipeline",
+				Status:       http.StatusOK,
+			}))
+		})
+
+		It("calls the wrapped handler", func() {
+			Expect(handled).To(BeTrue())
+		})
+
+		It("records the event once the wrapped handler responds", func() {
+			Expect(savedBeforeHandle).To(BeZero())
+			Expect(savedAfterRespond).To(Equal(1))
+		})
+	})
+
+	Context("when the request is for another team", func() {
+		BeforeEach(func() {
+			fakeUserContextReader.GetTeamReturns(atc.DefaultTeamName, 1, true, true)
+		})
+
+		It("leaves the team to be looked up by name", func() {
+			Expect(fakeAuditDB.SaveAuditEventCallCount()).To(Equal(1))
+
+			event := fakeAuditDB.SaveAuditEventArgsForCall(0)
+			Expect(event.TeamName).To(Equal("some-team"))
+			Expect(event.TeamID).To(BeZero())
+		})
+	})
+
+	Context("when the wrapped handler hijacks the request", func() {
+		BeforeEach(func() {
+			route = atc.HijackContainer
+			params = rata.Params{"id": "some-handle"}
+
+			hijack = true
+			handlerStatus = http.StatusSwitchingProtocols
+
+			fakeUserContextReader.GetTeamReturns("some-team", 1, false, true)
+		})
+
+		It("records the event before handing over the connection", func() {
+			Expect(savedAfterRespond).To(Equal(1))
+			Expect(fakeAuditDB.SaveAuditEventCallCount()).To(Equal(1))
+			Expect(fakeAuditDB.SaveAuditEventArgsForCall(0).Status).To(Equal(http.StatusSwitchingProtocols))
+		})
+	})
+
+	Context("when the request is rejected as unauthenticated", func() {
+		BeforeEach(func() {
+			fakeValidator.IsAuthenticatedReturns(false)
+			handlerStatus = http.StatusUnauthorized
+		})
+
+		It("does not call the wrapped handler", func() {
+			Expect(handled).To(BeFalse())
+		})
+
+		It("records the rejected request", func() {
+			Expect(fakeAuditDB.SaveAuditEventCallCount()).To(Equal(1))
+			Expect(fakeAuditDB.SaveAuditEventArgsForCall(0)).To(Equal(db.AuditEvent{
+				TeamName:     "some-team",
+				Route:        atc.PausePipeline,
+				PipelineName: "some-pipeline",
+				Status:       http.StatusUnauthorized,
+			}))
+		})
+	})
+
+	Context("when the wrapped handler fails the request", func() {
+		BeforeEach(func() {
+			handlerStatus = http.StatusForbidden
+
+			fakeUserContextReader.GetTeamReturns("some-team", 1, false, true)
+			fakeUserContextReader.GetUserReturns("github:some-user", true)
+		})
+
+		It("records the status it responded with", func() {
+			Expect(fakeAuditDB.SaveAuditEventCallCount()).To(Equal(1))
+			Expect(fakeAuditDB.SaveAuditEventArgsForCall(0).Status).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Context("when the route is not scoped to a team", func() {
+		BeforeEach(func() {
+			route = atc.AbortBuild
+			params = rata.Params{"build_id": "42"}
+
+			fakeUserContextReader.GetTeamReturns("some-team", 1, false, true)
+			fakeUserContextReader.GetUserReturns("token:ci-bot", true)
+		})
+
+		It("attributes the event to the caller's team", func() {
+			Expect(fakeAuditDB.SaveAuditEventCallCount()).To(Equal(1))
+			Expect(fakeAuditDB.SaveAuditEventArgsForCall(0)).To(Equal(db.AuditEvent{
+				TeamName: "some-team",
+				TeamID:   1,
+				UserName: "token:ci-bot",
+				Route:    atc.AbortBuild,
+				Status:   http.StatusOK,
+			}))
+		})
+	})
+
+	Context("when saving the event fails", func() {
+		BeforeEach(func() {
+			fakeAuditDB.SaveAuditEventReturns(errors.New("nope"))
+		})
+
+		It("still calls the wrapped handler", func() {
+			Expect(handled).To(BeTrue())
+		})
+	})
+})